@@ -0,0 +1,166 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/BevisDev/godev/database"
+	"github.com/jmoiron/sqlx"
+)
+
+// Entry is a row of the audit table.
+type Entry struct {
+	ID        int64     `db:"id" json:"id"`
+	TableName string    `db:"table_name" json:"tableName"`
+	EntityID  string    `db:"entity_id" json:"entityId"`
+	Action    Action    `db:"action" json:"action"`
+	Actor     string    `db:"actor" json:"actor"`
+	RID       string    `db:"rid" json:"rid"`
+	Changes   string    `db:"changes" json:"changes"`
+	OldData   *string   `db:"old_data" json:"oldData,omitempty"`
+	NewData   *string   `db:"new_data" json:"newData,omitempty"`
+	CreatedAt time.Time `db:"created_at" json:"createdAt"`
+}
+
+// Recorder writes ChangeSets to the audit table, stamped with the actor and RID of the context.
+type Recorder struct {
+	*options
+	db *database.DB
+}
+
+// New creates a Recorder writing to db.
+func New(db *database.DB, opts ...Option) *Recorder {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &Recorder{options: o, db: db}
+}
+
+// Schema returns the CREATE TABLE statement of the audit table for the database type.
+func (r *Recorder) Schema() string {
+	return Schema(r.db.Type(), r.table)
+}
+
+// CreateTable creates the audit table; it fails if the table already exists.
+func (r *Recorder) CreateTable(ctx context.Context) error {
+	return r.db.Execute(ctx, r.Schema(), nil)
+}
+
+// Enable records every change made through Chain.Update and Chain.Delete of the database
+// (limited by WithTables). Each of those writes then selects the matched rows first.
+func (r *Recorder) Enable() {
+	r.db.OnChange(r.onChange)
+}
+
+// Record writes the change sets in one transaction. Updates that changed nothing are skipped.
+func (r *Recorder) Record(ctx context.Context, changes ...*ChangeSet) error {
+	entries := make([]Entry, 0, len(changes))
+	for _, cs := range changes {
+		if cs == nil || cs.Empty() {
+			continue
+		}
+		e, err := r.entry(ctx, cs)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, e)
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+
+	query := r.db.GetDB().Rebind(fmt.Sprintf(
+		"INSERT INTO %s (table_name, entity_id, action, actor, rid, changes, old_data, new_data, created_at) "+
+			"VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)", r.table))
+
+	return r.db.RunTx(ctx, sql.LevelDefault, func(ctx context.Context, tx *sqlx.Tx) error {
+		for _, e := range entries {
+			if err := r.db.Execute(ctx, query, tx,
+				e.TableName, e.EntityID, string(e.Action), e.Actor, e.RID,
+				e.Changes, e.OldData, e.NewData, e.CreatedAt,
+			); err != nil {
+				return fmt.Errorf("[audit] write %s/%s: %w", e.TableName, e.EntityID, err)
+			}
+		}
+		return nil
+	})
+}
+
+// RecordChange builds a ChangeSet from old and new and records it.
+func (r *Recorder) RecordChange(ctx context.Context, table, entityID string, action Action, old, new any) error {
+	cs, err := NewChangeSet(table, entityID, action, old, new)
+	if err != nil {
+		return err
+	}
+	return r.Record(ctx, cs)
+}
+
+// History returns the entries of an entity, oldest first.
+func (r *Recorder) History(ctx context.Context, table, entityID string) ([]Entry, error) {
+	var out []Entry
+	query := fmt.Sprintf("SELECT * FROM %s WHERE table_name = ? AND entity_id = ? ORDER BY id", r.table)
+	if err := r.db.GetList(ctx, &out, query, table, entityID); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *Recorder) entry(ctx context.Context, cs *ChangeSet) (Entry, error) {
+	patch, err := json.Marshal(cs.Patch)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	e := Entry{
+		TableName: cs.Table,
+		EntityID:  cs.EntityID,
+		Action:    cs.Action,
+		Actor:     r.actor(ctx),
+		RID:       ridFromContext(ctx),
+		Changes:   string(patch),
+		CreatedAt: time.Now().UTC(),
+	}
+	if r.snapshots {
+		e.OldData = rawString(cs.Old)
+		e.NewData = rawString(cs.New)
+	}
+	return e, nil
+}
+
+// onChange turns a database change event into change sets.
+func (r *Recorder) onChange(ctx context.Context, ev database.ChangeEvent) error {
+	if ev.Table == r.table {
+		return nil
+	}
+	if len(r.tables) > 0 {
+		if _, ok := r.tables[ev.Table]; !ok {
+			return nil
+		}
+	}
+
+	changes := make([]*ChangeSet, 0, len(ev.Before))
+	for i, before := range ev.Before {
+		var after any
+		if row := ev.After(i); row != nil {
+			after = row
+		}
+		cs, err := NewChangeSet(ev.Table, fmt.Sprint(before[r.keyColumn]), Action(ev.Action), before, after)
+		if err != nil {
+			return err
+		}
+		changes = append(changes, cs)
+	}
+	return r.Record(ctx, changes...)
+}
+
+func rawString(raw []byte) *string {
+	if raw == nil {
+		return nil
+	}
+	s := string(raw)
+	return &s
+}
@@ -0,0 +1,123 @@
+package audit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/BevisDev/godev/consts"
+	"github.com/BevisDev/godev/database"
+	"github.com/BevisDev/godev/utils"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTestDB(t *testing.T) (*database.DB, sqlmock.Sqlmock) {
+	t.Helper()
+
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	db, err := database.FromSqlx(sqlx.NewDb(sqlDB, "sqlmock"), &database.Config{
+		DBType:  database.MySQL,
+		Timeout: 5 * time.Second,
+	})
+	require.NoError(t, err)
+	t.Cleanup(db.Close)
+	return db, mock
+}
+
+type user struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+func TestNewChangeSet(t *testing.T) {
+	cs, err := NewChangeSet("users", "1", ActionUpdate,
+		user{ID: 1, Name: "Alice", Email: "a@example.com"},
+		user{ID: 1, Name: "Alice", Email: "b@example.com"},
+	)
+	require.NoError(t, err)
+	require.Len(t, cs.Patch, 1)
+	assert.Equal(t, "replace", cs.Patch[0].Op)
+	assert.Equal(t, "/email", cs.Patch[0].Path)
+	assert.False(t, cs.Empty())
+
+	cs, err = NewChangeSet("users", "1", ActionUpdate, user{ID: 1}, user{ID: 1})
+	require.NoError(t, err)
+	assert.True(t, cs.Empty())
+
+	cs, err = NewChangeSet("users", "1", ActionDelete, user{ID: 1, Name: "Alice"}, nil)
+	require.NoError(t, err)
+	assert.Nil(t, cs.New)
+	assert.Len(t, cs.Patch, 3, "every field removed")
+}
+
+func TestContext_Actor(t *testing.T) {
+	assert.Equal(t, "", ActorFromContext(context.Background()))
+	assert.Equal(t, "bob", ActorFromContext(WithActor(context.Background(), "bob")))
+}
+
+func TestRecorder_Record(t *testing.T) {
+	db, mock := setupTestDB(t)
+	r := New(db, WithSnapshots())
+
+	ctx := utils.SetValueCtx(WithActor(context.Background(), "bob"), consts.RID, "rid-1")
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO audit_log").
+		WithArgs("users", "1", "update", "bob", "rid-1",
+			`[{"op":"replace","path":"/name","value":"B"}]`,
+			sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err := r.RecordChange(ctx, "users", "1", ActionUpdate, user{ID: 1, Name: "A"}, user{ID: 1, Name: "B"})
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRecorder_SkipsEmptyUpdate(t *testing.T) {
+	db, mock := setupTestDB(t)
+	r := New(db)
+
+	err := r.RecordChange(context.Background(), "users", "1", ActionUpdate, user{ID: 1}, user{ID: 1})
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRecorder_Enable_ChainUpdate(t *testing.T) {
+	db, mock := setupTestDB(t)
+	New(db, WithTables("users")).Enable()
+
+	ctx := WithActor(context.Background(), "bob")
+
+	mock.ExpectQuery("SELECT \\* FROM users WHERE id = \\?").
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(7, []byte("Alice")))
+	mock.ExpectExec("UPDATE users SET name = \\? WHERE id = \\?").
+		WithArgs("Bob", 7).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO audit_log").
+		WithArgs("users", "7", "update", "bob", "",
+			`[{"op":"replace","path":"/name","value":"Bob"}]`,
+			nil, nil, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	n, err := database.Builder[user](db).From("users").Select("name").Where("id = ?", 7).
+		Update(ctx, map[string]interface{}{"name": "Bob"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSchema(t *testing.T) {
+	assert.Contains(t, Schema(database.Postgres, "audit_log"), "BIGSERIAL")
+	assert.Contains(t, Schema(database.SqlServer, "audit_log"), "IDENTITY(1,1)")
+	assert.Contains(t, Schema(database.MySQL, "audit"), "CREATE TABLE audit (")
+}
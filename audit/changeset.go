@@ -0,0 +1,73 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/BevisDev/godev/utils/jsonx"
+)
+
+// Action is the kind of change recorded.
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+// ChangeSet is a change of one entity: its old and new JSON and the RFC 6902 patch between them.
+type ChangeSet struct {
+	Table    string
+	EntityID string
+	Action   Action
+	Old      json.RawMessage // nil for a create
+	New      json.RawMessage // nil for a delete
+	Patch    jsonx.Patch
+}
+
+// NewChangeSet marshals old and new (either may be nil) and diffs them.
+//
+// Example:
+//
+//	cs, err := audit.NewChangeSet("users", "42", audit.ActionUpdate, oldUser, newUser)
+//	// cs.Patch: [{"op":"replace","path":"/email","value":"new@example.com"}]
+func NewChangeSet(table, entityID string, action Action, old, new any) (*ChangeSet, error) {
+	cs := &ChangeSet{Table: table, EntityID: entityID, Action: action}
+
+	var err error
+	if cs.Old, err = marshal(old); err != nil {
+		return nil, fmt.Errorf("[audit] marshal old %s/%s: %w", table, entityID, err)
+	}
+	if cs.New, err = marshal(new); err != nil {
+		return nil, fmt.Errorf("[audit] marshal new %s/%s: %w", table, entityID, err)
+	}
+
+	// a missing side diffs as an empty object so every field shows up as added/removed
+	left, right := cs.Old, cs.New
+	if left == nil {
+		left = json.RawMessage("{}")
+	}
+	if right == nil {
+		right = json.RawMessage("{}")
+	}
+	if cs.Patch, err = jsonx.Diff(left, right); err != nil {
+		return nil, fmt.Errorf("[audit] diff %s/%s: %w", table, entityID, err)
+	}
+	return cs, nil
+}
+
+// Empty reports whether an update changed nothing.
+func (c *ChangeSet) Empty() bool {
+	return c.Action == ActionUpdate && len(c.Patch) == 0
+}
+
+func marshal(v any) (json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+	if raw, ok := v.(json.RawMessage); ok {
+		return raw, nil
+	}
+	return json.Marshal(v)
+}
@@ -0,0 +1,22 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/BevisDev/godev/utils/ctxmeta"
+)
+
+// WithActor stores the user performing the request, e.g. from an auth middleware.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return ctxmeta.WithUserID(ctx, actor)
+}
+
+// ActorFromContext returns the actor stored by WithActor, or "".
+func ActorFromContext(ctx context.Context) string {
+	return ctxmeta.UserID(ctx)
+}
+
+// ridFromContext returns the request ID without generating one when missing.
+func ridFromContext(ctx context.Context) string {
+	return ctxmeta.RID(ctx)
+}
@@ -0,0 +1,80 @@
+package audit
+
+import "context"
+
+const (
+	defaultTable     = "audit_log"
+	defaultKeyColumn = "id"
+)
+
+type Option func(*options)
+
+type options struct {
+	// table is the audit table written by the Recorder.
+	table string
+
+	// keyColumn identifies the entity in rows reported by Chain.Update/Delete.
+	keyColumn string
+
+	// tables limits automatic recording to these tables; empty records every table.
+	tables map[string]struct{}
+
+	// snapshots stores old_data/new_data besides the patch.
+	snapshots bool
+
+	// actor resolves the actor of a change.
+	actor func(ctx context.Context) string
+}
+
+func defaultOptions() *options {
+	return &options{
+		table:     defaultTable,
+		keyColumn: defaultKeyColumn,
+		tables:    make(map[string]struct{}),
+		actor:     ActorFromContext,
+	}
+}
+
+// WithTable sets the audit table (default "audit_log").
+func WithTable(table string) Option {
+	return func(o *options) {
+		if table != "" {
+			o.table = table
+		}
+	}
+}
+
+// WithKeyColumn sets the column holding the entity ID of audited rows (default "id").
+func WithKeyColumn(column string) Option {
+	return func(o *options) {
+		if column != "" {
+			o.keyColumn = column
+		}
+	}
+}
+
+// WithTables limits automatic recording to the given tables.
+func WithTables(tables ...string) Option {
+	return func(o *options) {
+		for _, t := range tables {
+			o.tables[t] = struct{}{}
+		}
+	}
+}
+
+// WithSnapshots also stores the full old and new JSON of each entity.
+func WithSnapshots() Option {
+	return func(o *options) {
+		o.snapshots = true
+	}
+}
+
+// WithActorFunc resolves the actor from the context, e.g. from JWT claims.
+// By default the value stored by WithActor is used.
+func WithActorFunc(fn func(ctx context.Context) string) Option {
+	return func(o *options) {
+		if fn != nil {
+			o.actor = fn
+		}
+	}
+}
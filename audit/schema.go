@@ -0,0 +1,38 @@
+package audit
+
+import (
+	"fmt"
+
+	"github.com/BevisDev/godev/database"
+)
+
+// Schema returns the CREATE TABLE statement of the audit table for dbType.
+//
+// Columns: id, table_name, entity_id, action, actor, rid, changes (JSON patch),
+// old_data, new_data (JSON snapshots, only with WithSnapshots) and created_at.
+func Schema(dbType database.DBType, table string) string {
+	var id, text, ts string
+	switch dbType {
+	case database.SqlServer:
+		id, text, ts = "BIGINT IDENTITY(1,1) PRIMARY KEY", "NVARCHAR(MAX)", "DATETIME2"
+	case database.Postgres:
+		id, text, ts = "BIGSERIAL PRIMARY KEY", "TEXT", "TIMESTAMP"
+	case database.Oracle:
+		id, text, ts = "NUMBER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY", "CLOB", "TIMESTAMP"
+	default: // mysql
+		id, text, ts = "BIGINT AUTO_INCREMENT PRIMARY KEY", "LONGTEXT", "DATETIME(6)"
+	}
+
+	return fmt.Sprintf(`CREATE TABLE %s (
+    id %s,
+    table_name VARCHAR(128) NOT NULL,
+    entity_id VARCHAR(128) NOT NULL,
+    action VARCHAR(16) NOT NULL,
+    actor VARCHAR(128),
+    rid VARCHAR(64),
+    changes %s,
+    old_data %s,
+    new_data %s,
+    created_at %s NOT NULL
+)`, table, id, text, text, text, ts)
+}
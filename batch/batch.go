@@ -0,0 +1,277 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/BevisDev/godev/utils/async"
+	"github.com/BevisDev/godev/utils/console"
+)
+
+var (
+	// ErrFilter is returned by a Processor to drop an item without error.
+	ErrFilter = errors.New("[batch] item filtered")
+
+	// ErrSkipLimit is returned by Run when more items failed than WithSkip allows.
+	ErrSkipLimit = errors.New("[batch] skip limit exceeded")
+)
+
+// Reader yields the input items of a Job. Read returns io.EOF when there are no more items.
+// A Reader implementing io.Closer is closed when Run returns.
+type Reader[T any] interface {
+	Read(ctx context.Context) (T, error)
+}
+
+// Processor transforms an input item. It returns ErrFilter to drop the item.
+type Processor[I, O any] interface {
+	Process(ctx context.Context, item I) (O, error)
+}
+
+// Writer writes the processed items of a chunk. Writes are retried as a
+// whole, so a Writer should be transactional or idempotent.
+type Writer[T any] interface {
+	Write(ctx context.Context, items []T) error
+}
+
+// Committer is implemented by readers that acknowledge their input
+// (e.g. Kafka offsets) once a chunk was written.
+type Committer interface {
+	Commit(ctx context.Context) error
+}
+
+type ReaderFunc[T any] func(ctx context.Context) (T, error)
+
+func (f ReaderFunc[T]) Read(ctx context.Context) (T, error) {
+	return f(ctx)
+}
+
+type ProcessorFunc[I, O any] func(ctx context.Context, item I) (O, error)
+
+func (f ProcessorFunc[I, O]) Process(ctx context.Context, item I) (O, error) {
+	return f(ctx, item)
+}
+
+type WriterFunc[T any] func(ctx context.Context, items []T) error
+
+func (f WriterFunc[T]) Write(ctx context.Context, items []T) error {
+	return f(ctx, items)
+}
+
+// Identity returns a Processor passing items through unchanged.
+func Identity[T any]() Processor[T, T] {
+	return ProcessorFunc[T, T](func(_ context.Context, item T) (T, error) {
+		return item, nil
+	})
+}
+
+// Job runs chunk-oriented processing: items are read and processed one by
+// one, and every chunk of processed items is written at once.
+type Job[I, O any] struct {
+	*options
+	reader    Reader[I]
+	processor Processor[I, O]
+	writer    Writer[O]
+	log       *console.Logger
+}
+
+// New returns a Job reading from r, transforming with p and writing to w.
+//
+// Example:
+//
+//	job := batch.New[Txn, Settlement](
+//		batch.NewDBReader[Txn](db, "SELECT * FROM txns WHERE settled_at IS NULL"),
+//		batch.ProcessorFunc[Txn, Settlement](settle),
+//		batch.NewDBWriter[Settlement](db, "settlements"),
+//		batch.WithChunkSize(500),
+//		batch.WithSkip(10, nil),
+//	)
+//	m, err := job.Run(ctx)
+func New[I, O any](r Reader[I], p Processor[I, O], w Writer[O], opts ...Option) *Job[I, O] {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return &Job[I, O]{
+		options:   o,
+		reader:    r,
+		processor: p,
+		writer:    w,
+		log:       console.New("batch"),
+	}
+}
+
+// chunk holds the processed items of a chunk with their read positions.
+type chunk[T any] struct {
+	items []T
+	index []int
+}
+
+// Run processes every item of the reader, chunk by chunk, until io.EOF,
+// an error that cannot be skipped, or ctx is done. The returned metrics
+// are filled in either case; chunks written before an error stay written.
+func (j *Job[I, O]) Run(ctx context.Context) (*Metrics, error) {
+	m := &Metrics{Name: j.name, StartedAt: time.Now()}
+	defer func() { m.Duration = time.Since(m.StartedAt) }()
+
+	if c, ok := j.reader.(io.Closer); ok {
+		defer c.Close()
+	}
+
+	var (
+		out = chunk[O]{items: make([]O, 0, j.chunkSize), index: make([]int, 0, j.chunkSize)}
+		pos int
+	)
+	for eof := false; !eof; {
+		out.items, out.index = out.items[:0], out.index[:0]
+		start := pos
+
+		for n := 0; n < j.chunkSize; n++ {
+			if err := ctx.Err(); err != nil {
+				return m, err
+			}
+
+			item, err := j.reader.Read(ctx)
+			if errors.Is(err, io.EOF) {
+				eof = true
+				break
+			}
+			pos++
+			if err != nil {
+				if err := j.skip(m, StageRead, pos, err); err != nil {
+					return m, err
+				}
+				continue
+			}
+			m.Read++
+
+			v, err := j.process(ctx, m, item)
+			if errors.Is(err, ErrFilter) {
+				m.Processed++
+				m.Filtered++
+				continue
+			}
+			if err != nil {
+				if err := j.skip(m, StageProcess, pos, err); err != nil {
+					return m, err
+				}
+				continue
+			}
+			m.Processed++
+			out.items = append(out.items, v)
+			out.index = append(out.index, pos)
+		}
+
+		if pos == start {
+			break // nothing read since the last chunk
+		}
+		if err := j.write(ctx, m, out); err != nil {
+			return m, err
+		}
+		if c, ok := j.reader.(Committer); ok {
+			if err := c.Commit(ctx); err != nil {
+				return m, fmt.Errorf("[batch] commit chunk %d: %w", m.Chunks+1, err)
+			}
+		}
+		m.Chunks++
+
+		if j.onChunk != nil {
+			j.onChunk(ctx, *m)
+		}
+	}
+
+	j.log.Info("%s done: read=%d written=%d filtered=%d skipped=%d chunks=%d in %s",
+		j.name, m.Read, m.Written, m.Filtered, m.Skipped(), m.Chunks, time.Since(m.StartedAt),
+	)
+	return m, nil
+}
+
+func (j *Job[I, O]) process(ctx context.Context, m *Metrics, item I) (O, error) {
+	policy := j.retry
+	retryIf := policy.RetryIf
+	policy.RetryIf = func(err error) bool {
+		return !errors.Is(err, ErrFilter) && (retryIf == nil || retryIf(err))
+	}
+
+	attempts := 0
+	v, err := async.RetryValue(ctx, policy, func(ctx context.Context) (O, error) {
+		attempts++
+		return j.processor.Process(ctx, item)
+	})
+	m.Retries += attempts - 1
+	return v, err
+}
+
+// write writes the chunk. When it keeps failing with a skippable error, the
+// items are written one by one to skip only the failing ones.
+func (j *Job[I, O]) write(ctx context.Context, m *Metrics, out chunk[O]) error {
+	if len(out.items) == 0 {
+		return nil
+	}
+
+	err := j.writeItems(ctx, m, out.items)
+	if err == nil {
+		m.Written += len(out.items)
+		return nil
+	}
+	if !j.skippable(err) || len(out.items) == 1 {
+		if len(out.items) == 1 {
+			return j.skip(m, StageWrite, out.index[0], err)
+		}
+		return fmt.Errorf("[batch] write chunk %d: %w", m.Chunks+1, err)
+	}
+
+	for i, item := range out.items {
+		if err := j.writeItems(ctx, m, []O{item}); err != nil {
+			if err := j.skip(m, StageWrite, out.index[i], err); err != nil {
+				return err
+			}
+			continue
+		}
+		m.Written++
+	}
+	return nil
+}
+
+func (j *Job[I, O]) writeItems(ctx context.Context, m *Metrics, items []O) error {
+	attempts := 0
+	err := async.Retry(ctx, j.retry, func(ctx context.Context) error {
+		attempts++
+		return j.writer.Write(ctx, items)
+	})
+	m.Retries += attempts - 1
+	return err
+}
+
+func (j *Job[I, O]) skippable(err error) bool {
+	if j.skipLimit <= 0 || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	return j.skipIf == nil || j.skipIf(err)
+}
+
+// skip records the failed item, or returns the error that stops the job.
+func (j *Job[I, O]) skip(m *Metrics, stage Stage, index int, err error) error {
+	itemErr := ItemError{Stage: stage, Index: index, Err: err}
+	if !j.skippable(err) {
+		return itemErr
+	}
+	if m.Skipped() >= j.skipLimit {
+		return fmt.Errorf("%w: %w", ErrSkipLimit, itemErr)
+	}
+
+	switch stage {
+	case StageRead:
+		m.ReadSkips++
+	case StageProcess:
+		m.ProcessSkips++
+	case StageWrite:
+		m.WriteSkips++
+	}
+	m.Errors = append(m.Errors, itemErr)
+	j.log.Warn("%s skip %v", j.name, itemErr)
+	return nil
+}
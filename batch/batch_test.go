@@ -0,0 +1,220 @@
+package batch
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BevisDev/godev/utils/async"
+	"github.com/BevisDev/godev/utils/csvx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memWriter[T any] struct {
+	chunks [][]T
+	fail   func(items []T) error
+}
+
+func (w *memWriter[T]) Write(_ context.Context, items []T) error {
+	if w.fail != nil {
+		if err := w.fail(items); err != nil {
+			return err
+		}
+	}
+	w.chunks = append(w.chunks, append([]T(nil), items...))
+	return nil
+}
+
+func ints(n int) []int {
+	items := make([]int, n)
+	for i := range items {
+		items[i] = i + 1
+	}
+	return items
+}
+
+var double = ProcessorFunc[int, int](func(_ context.Context, n int) (int, error) {
+	return n * 2, nil
+})
+
+func TestRun_Chunks(t *testing.T) {
+	w := &memWriter[int]{}
+	var seen []int
+
+	m, err := New[int, int](NewSliceReader(ints(5)), double, w,
+		WithChunkSize(2),
+		WithOnChunk(func(_ context.Context, m Metrics) { seen = append(seen, m.Written) }),
+	).Run(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, [][]int{{2, 4}, {6, 8}, {10}}, w.chunks)
+	assert.Equal(t, []int{2, 4, 5}, seen)
+	assert.Equal(t, 5, m.Read)
+	assert.Equal(t, 5, m.Written)
+	assert.Equal(t, 3, m.Chunks)
+	assert.Equal(t, "batch", m.Name)
+}
+
+func TestRun_Empty(t *testing.T) {
+	w := &memWriter[int]{}
+	m, err := New[int, int](NewSliceReader[int](nil), double, w).Run(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, w.chunks)
+	assert.Equal(t, 0, m.Chunks)
+}
+
+func TestRun_FilterAndSkip(t *testing.T) {
+	p := ProcessorFunc[int, string](func(_ context.Context, n int) (string, error) {
+		switch {
+		case n%2 == 0:
+			return "", ErrFilter
+		case n == 3:
+			return "", errors.New("bad item")
+		}
+		return strconv.Itoa(n), nil
+	})
+	w := &memWriter[string]{}
+
+	m, err := New[int, string](NewSliceReader(ints(6)), p, w, WithSkip(1, nil)).Run(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, [][]string{{"1", "5"}}, w.chunks)
+	assert.Equal(t, 3, m.Filtered)
+	assert.Equal(t, 1, m.ProcessSkips)
+	require.Len(t, m.Errors, 1)
+	assert.Equal(t, StageProcess, m.Errors[0].Stage)
+	assert.Equal(t, 3, m.Errors[0].Index)
+}
+
+func TestRun_SkipLimit(t *testing.T) {
+	fail := errors.New("bad item")
+	p := ProcessorFunc[int, int](func(_ context.Context, n int) (int, error) {
+		if n > 2 {
+			return 0, fail
+		}
+		return n, nil
+	})
+
+	m, err := New[int, int](NewSliceReader(ints(5)), p, &memWriter[int]{}, WithSkip(2, nil)).Run(context.Background())
+	assert.ErrorIs(t, err, ErrSkipLimit)
+	assert.ErrorIs(t, err, fail)
+	assert.Equal(t, 2, m.Skipped())
+
+	_, err = New[int, int](NewSliceReader(ints(5)), p, &memWriter[int]{}).Run(context.Background())
+	var itemErr ItemError
+	require.ErrorAs(t, err, &itemErr)
+	assert.Equal(t, 3, itemErr.Index)
+	assert.NotErrorIs(t, err, ErrSkipLimit)
+}
+
+func TestRun_Retry(t *testing.T) {
+	calls := 0
+	p := ProcessorFunc[int, int](func(_ context.Context, n int) (int, error) {
+		calls++
+		if calls == 1 {
+			return 0, errors.New("transient")
+		}
+		return n, nil
+	})
+
+	writes := 0
+	w := &memWriter[int]{fail: func([]int) error {
+		writes++
+		if writes == 1 {
+			return errors.New("deadlock")
+		}
+		return nil
+	}}
+
+	m, err := New[int, int](NewSliceReader(ints(2)), p, w,
+		WithRetry(async.RetryPolicy{MaxAttempts: 2, InitialDelay: time.Millisecond}),
+	).Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, [][]int{{1, 2}}, w.chunks)
+	assert.Equal(t, 2, m.Retries)
+}
+
+func TestRun_WriteScan(t *testing.T) {
+	w := &memWriter[int]{fail: func(items []int) error {
+		for _, n := range items {
+			if n == 4 {
+				return errors.New("constraint violation")
+			}
+		}
+		return nil
+	}}
+
+	m, err := New[int, int](NewSliceReader(ints(3)), double, w,
+		WithChunkSize(3), WithSkip(1, nil),
+	).Run(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, [][]int{{2}, {6}}, w.chunks)
+	assert.Equal(t, 2, m.Written)
+	assert.Equal(t, 1, m.WriteSkips)
+	assert.Equal(t, 2, m.Errors[0].Index)
+}
+
+func TestRun_WriteFailure(t *testing.T) {
+	w := &memWriter[int]{fail: func([]int) error { return errors.New("db down") }}
+
+	m, err := New[int, int](NewSliceReader(ints(3)), double, w,
+		WithSkip(5, func(err error) bool { return false }),
+	).Run(context.Background())
+	assert.ErrorContains(t, err, "db down")
+	assert.Equal(t, 0, m.Written)
+	assert.Equal(t, 0, m.Chunks)
+}
+
+type commitReader struct {
+	*SliceReader[int]
+	commits int
+}
+
+func (r *commitReader) Commit(context.Context) error {
+	r.commits++
+	return nil
+}
+
+func TestRun_Commit(t *testing.T) {
+	r := &commitReader{SliceReader: NewSliceReader(ints(3))}
+
+	_, err := New[int, int](r, Identity[int](), &memWriter[int]{}, WithChunkSize(2)).Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, r.commits)
+}
+
+func TestRun_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := New[int, int](NewSliceReader(ints(3)), double, &memWriter[int]{}).Run(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+type csvRow struct {
+	Name string `csv:"name"`
+	Age  int    `csv:"age"`
+}
+
+func TestCSVReaderWriter(t *testing.T) {
+	r, err := NewCSVReader[csvRow](strings.NewReader("name,age\nAlice,30\nBob,abc\nCarol,40\n"))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	w, err := NewCSVWriter[csvRow](&buf)
+	require.NoError(t, err)
+
+	m, err := New[csvRow, csvRow](r, Identity[csvRow](), w, WithSkip(1, nil)).Run(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, "name,age\nAlice,30\nCarol,40\n", buf.String())
+	assert.Equal(t, 1, m.ReadSkips)
+	var pe *csvx.ParseError
+	assert.ErrorAs(t, m.Errors[0], &pe)
+}
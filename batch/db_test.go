@@ -0,0 +1,55 @@
+package batch
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/BevisDev/godev/database/dbtest"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type txn struct {
+	ID     int   `db:"id"`
+	Amount int64 `db:"amount"`
+}
+
+type settlement struct {
+	TxnID int   `db:"txn_id"`
+	Fee   int64 `db:"fee"`
+}
+
+func TestDBReaderWriter(t *testing.T) {
+	db := dbtest.NewMock(t)
+
+	db.Mock.ExpectQuery(regexp.QuoteMeta("SELECT id, amount FROM txns WHERE status = ?")).
+		WithArgs("new").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "amount"}).
+			AddRow(1, 1000).AddRow(2, 2000).AddRow(3, 3000))
+	db.Mock.ExpectBegin()
+	db.Mock.ExpectExec("INSERT INTO settlements").
+		WithArgs(1, 10, 2, 20).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	db.Mock.ExpectCommit()
+	db.Mock.ExpectBegin()
+	db.Mock.ExpectExec("INSERT INTO settlements").
+		WithArgs(3, 30).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	db.Mock.ExpectCommit()
+
+	settle := ProcessorFunc[txn, settlement](func(_ context.Context, t txn) (settlement, error) {
+		return settlement{TxnID: t.ID, Fee: t.Amount / 100}, nil
+	})
+
+	m, err := New[txn, settlement](
+		NewDBReader[txn](db.DB, "SELECT id, amount FROM txns WHERE status = ?", "new"),
+		settle,
+		NewDBWriter[settlement](db.DB, "settlements"),
+		WithChunkSize(2),
+	).Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, m.Written)
+	assert.Equal(t, 2, m.Chunks)
+}
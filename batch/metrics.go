@@ -0,0 +1,54 @@
+package batch
+
+import (
+	"fmt"
+	"time"
+)
+
+// Stage is the step of the chunk an item failed in.
+type Stage string
+
+const (
+	StageRead    Stage = "read"
+	StageProcess Stage = "process"
+	StageWrite   Stage = "write"
+)
+
+// ItemError is an item skipped by the skip policy.
+type ItemError struct {
+	Stage Stage
+	Index int // 1-based position of the item in the read order
+	Err   error
+}
+
+func (e ItemError) Error() string {
+	return fmt.Sprintf("%s item %d: %v", e.Stage, e.Index, e.Err)
+}
+
+func (e ItemError) Unwrap() error {
+	return e.Err
+}
+
+// Metrics reports the progress and outcome of a Run.
+type Metrics struct {
+	Name      string
+	Read      int // items read successfully
+	Processed int // items processed successfully, including filtered ones
+	Filtered  int // items dropped by the processor with ErrFilter
+	Written   int // items written
+	Chunks    int // committed chunks
+	Retries   int // retried process calls and chunk writes
+
+	ReadSkips    int
+	ProcessSkips int
+	WriteSkips   int
+	Errors       []ItemError // skipped items
+
+	StartedAt time.Time
+	Duration  time.Duration
+}
+
+// Skipped returns the number of skipped items over all stages.
+func (m Metrics) Skipped() int {
+	return m.ReadSkips + m.ProcessSkips + m.WriteSkips
+}
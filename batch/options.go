@@ -0,0 +1,71 @@
+package batch
+
+import (
+	"context"
+
+	"github.com/BevisDev/godev/utils/async"
+)
+
+type Option func(*options)
+
+type options struct {
+	name      string
+	chunkSize int
+	retry     async.RetryPolicy
+	skipLimit int
+	skipIf    func(error) bool
+	onChunk   func(ctx context.Context, m Metrics)
+}
+
+func defaultOptions() *options {
+	return &options{
+		name:      "batch",
+		chunkSize: 100,
+		retry:     async.RetryPolicy{MaxAttempts: 1},
+	}
+}
+
+// WithName sets the job name used in logs and Metrics.
+func WithName(name string) Option {
+	return func(o *options) {
+		if name != "" {
+			o.name = name
+		}
+	}
+}
+
+// WithChunkSize sets how many items are read and processed before
+// they are written together (default 100).
+func WithChunkSize(n int) Option {
+	return func(o *options) {
+		if n > 0 {
+			o.chunkSize = n
+		}
+	}
+}
+
+// WithRetry retries failing Process calls and chunk writes with policy.
+// By default nothing is retried.
+func WithRetry(policy async.RetryPolicy) Option {
+	return func(o *options) {
+		o.retry = policy
+	}
+}
+
+// WithSkip skips up to limit items whose read, process or write fails
+// (after retries) with an error matched by skipIf, nil matching every error.
+// The job fails with ErrSkipLimit once the limit is exceeded. By default
+// nothing is skipped.
+func WithSkip(limit int, skipIf func(error) bool) Option {
+	return func(o *options) {
+		o.skipLimit = limit
+		o.skipIf = skipIf
+	}
+}
+
+// WithOnChunk calls fn with the running metrics after each committed chunk.
+func WithOnChunk(fn func(ctx context.Context, m Metrics)) Option {
+	return func(o *options) {
+		o.onChunk = fn
+	}
+}
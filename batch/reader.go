@@ -0,0 +1,180 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"time"
+
+	"github.com/BevisDev/godev/database"
+	"github.com/BevisDev/godev/kafkax"
+	"github.com/BevisDev/godev/utils/csvx"
+	"github.com/BevisDev/godev/utils/jsonx"
+	"github.com/jmoiron/sqlx"
+)
+
+// SliceReader reads the items of a slice.
+type SliceReader[T any] struct {
+	items []T
+	pos   int
+}
+
+func NewSliceReader[T any](items []T) *SliceReader[T] {
+	return &SliceReader[T]{items: items}
+}
+
+func (r *SliceReader[T]) Read(context.Context) (T, error) {
+	var zero T
+	if r.pos >= len(r.items) {
+		return zero, io.EOF
+	}
+	r.pos++
+	return r.items[r.pos-1], nil
+}
+
+// DBReader streams the rows of a query through a database cursor, so large
+// result sets are not loaded in memory. The query is run on the first Read
+// and its rows are scanned into T by `db` tag (or as a single column for scalar T).
+type DBReader[T any] struct {
+	db    *database.DB
+	query string
+	args  []interface{}
+	rows  *sqlx.Rows
+	done  bool
+}
+
+// NewDBReader returns a reader for query with "?" placeholders.
+func NewDBReader[T any](db *database.DB, query string, args ...interface{}) *DBReader[T] {
+	return &DBReader[T]{db: db, query: query, args: args}
+}
+
+func (r *DBReader[T]) Read(ctx context.Context) (T, error) {
+	var v T
+	if r.done {
+		return v, io.EOF
+	}
+
+	if r.rows == nil {
+		rows, err := r.db.GetDB().QueryxContext(ctx, r.db.GetDB().Rebind(r.query), r.args...)
+		if err != nil {
+			r.done = true
+			return v, err
+		}
+		r.rows = rows
+	}
+
+	if !r.rows.Next() {
+		err := r.rows.Err()
+		r.Close()
+		if err != nil {
+			return v, err
+		}
+		return v, io.EOF
+	}
+
+	if reflect.TypeFor[T]().Kind() == reflect.Struct {
+		return v, r.rows.StructScan(&v)
+	}
+	return v, r.rows.Scan(&v)
+}
+
+// Close closes the cursor; Run calls it when done.
+func (r *DBReader[T]) Close() error {
+	r.done = true
+	if r.rows == nil {
+		return nil
+	}
+	return r.rows.Close()
+}
+
+// CSVReader reads CSV records decoded into T with csvx. Records that cannot
+// be decoded are returned as *csvx.ParseError and can be skipped with WithSkip.
+type CSVReader[T any] struct {
+	r *csvx.Reader[T]
+}
+
+func NewCSVReader[T any](r io.Reader, opts ...csvx.Option) (*CSVReader[T], error) {
+	cr, err := csvx.NewReader[T](r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &CSVReader[T]{r: cr}, nil
+}
+
+func (r *CSVReader[T]) Read(context.Context) (T, error) {
+	return r.r.Read()
+}
+
+// Line returns the line number of the last record read.
+func (r *CSVReader[T]) Line() int {
+	return r.r.Line()
+}
+
+// KafkaReader reads messages of a consumer decoded into T. As a topic has no end,
+// the reader ends (io.EOF) once no message arrives for idle. Offsets are
+// committed after each written chunk, including those of skipped messages.
+type KafkaReader[T any] struct {
+	consumer *kafkax.Consumer
+	idle     time.Duration
+	decode   func(msg *kafkax.ConsumedMessage) (T, error)
+
+	// last uncommitted message per topic partition
+	pending map[kafkaPartition]*kafkax.ConsumedMessage
+}
+
+type kafkaPartition struct {
+	topic     string
+	partition int
+}
+
+// NewKafkaReader returns a reader decoding message values as JSON, unless
+// decode is set. idle defaults to 10s.
+func NewKafkaReader[T any](c *kafkax.Consumer, idle time.Duration,
+	decode func(msg *kafkax.ConsumedMessage) (T, error),
+) *KafkaReader[T] {
+	if idle <= 0 {
+		idle = 10 * time.Second
+	}
+	if decode == nil {
+		decode = func(msg *kafkax.ConsumedMessage) (T, error) {
+			return jsonx.FromJSONBytes[T](msg.Value)
+		}
+	}
+
+	return &KafkaReader[T]{
+		consumer: c,
+		idle:     idle,
+		decode:   decode,
+		pending:  make(map[kafkaPartition]*kafkax.ConsumedMessage),
+	}
+}
+
+func (r *KafkaReader[T]) Read(ctx context.Context) (T, error) {
+	var zero T
+
+	readCtx, cancel := context.WithTimeout(ctx, r.idle)
+	defer cancel()
+
+	msg, err := r.consumer.ReadMessage(readCtx)
+	if err != nil {
+		if ctx.Err() == nil && errors.Is(err, context.DeadlineExceeded) {
+			return zero, io.EOF
+		}
+		return zero, err
+	}
+
+	r.pending[kafkaPartition{msg.Topic, msg.Partition}] = msg
+	return r.decode(msg)
+}
+
+// Commit commits the offsets of the messages read so far.
+func (r *KafkaReader[T]) Commit(ctx context.Context) error {
+	for k, msg := range r.pending {
+		if err := r.consumer.CommitMessage(ctx, msg); err != nil {
+			return err
+		}
+		delete(r.pending, k)
+	}
+	return nil
+}
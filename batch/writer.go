@@ -0,0 +1,74 @@
+package batch
+
+import (
+	"context"
+	"io"
+
+	"github.com/BevisDev/godev/database"
+	"github.com/BevisDev/godev/kafkax"
+	"github.com/BevisDev/godev/utils/csvx"
+	"github.com/BevisDev/godev/utils/jsonx"
+)
+
+// DBWriter bulk-inserts each chunk into a table in one transaction
+// (see database.InsertRows).
+type DBWriter[T any] struct {
+	db    *database.DB
+	table string
+	cols  []string
+}
+
+// NewDBWriter returns a writer inserting into table the columns of T's `db`
+// tags, restricted to cols when given (e.g. to leave out generated IDs).
+func NewDBWriter[T any](db *database.DB, table string, cols ...string) *DBWriter[T] {
+	return &DBWriter[T]{db: db, table: table, cols: cols}
+}
+
+func (w *DBWriter[T]) Write(ctx context.Context, items []T) error {
+	return database.InsertRows(ctx, w.db, w.table, items, w.cols...)
+}
+
+// CSVWriter appends each chunk to a CSV output with csvx and flushes it.
+type CSVWriter[T any] struct {
+	w *csvx.Writer[T]
+}
+
+func NewCSVWriter[T any](w io.Writer, opts ...csvx.Option) (*CSVWriter[T], error) {
+	cw, err := csvx.NewWriter[T](w, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &CSVWriter[T]{w: cw}, nil
+}
+
+func (w *CSVWriter[T]) Write(_ context.Context, items []T) error {
+	return w.w.WriteAll(items)
+}
+
+// KafkaWriter sends each chunk as a batch of JSON messages to a topic.
+type KafkaWriter[T any] struct {
+	producer *kafkax.Producer
+	topic    string
+	key      func(item T) string
+}
+
+// NewKafkaWriter returns a writer sending to topic, keyed by key when set.
+func NewKafkaWriter[T any](p *kafkax.Producer, topic string, key func(item T) string) *KafkaWriter[T] {
+	return &KafkaWriter[T]{producer: p, topic: topic, key: key}
+}
+
+func (w *KafkaWriter[T]) Write(ctx context.Context, items []T) error {
+	msgs := make([]*kafkax.Message, len(items))
+	for i, item := range items {
+		value, err := jsonx.ToJSONBytes(item)
+		if err != nil {
+			return err
+		}
+
+		msgs[i] = &kafkax.Message{Topic: w.topic, Value: value}
+		if w.key != nil {
+			msgs[i].Key = []byte(w.key(item))
+		}
+	}
+	return w.producer.SendBatch(ctx, msgs)
+}
@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/BevisDev/godev/framework"
+)
+
+// RunFunc runs a command with the remaining arguments.
+type RunFunc func(ctx context.Context, b *framework.Bootstrap, args []string) error
+
+// Command is a CLI subcommand.
+type Command struct {
+	Name  string
+	Usage string // one-line description shown by help
+
+	// Init runs Bootstrap.Init before Run and Bootstrap.Close after it.
+	Init bool
+	Run  RunFunc
+}
+
+// App is an operational CLI for a service built on framework.Bootstrap.
+// Built-in commands: serve (default), migrate, seed, health, routes and config-dump.
+type App struct {
+	*options
+	name      string
+	bootstrap *framework.Bootstrap
+	commands  map[string]*Command
+}
+
+// New creates a CLI named name (shown in help) for b.
+func New(name string, b *framework.Bootstrap, opts ...Option) *App {
+	o := withDefaults()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	a := &App{
+		options:   o,
+		name:      name,
+		bootstrap: b,
+		commands:  make(map[string]*Command),
+	}
+	for _, cmd := range a.builtins() {
+		a.commands[cmd.Name] = cmd
+	}
+	for _, cmd := range o.commands {
+		a.commands[cmd.Name] = cmd
+	}
+	return a
+}
+
+// Execute runs the command from os.Args and exits with status 1 on error.
+func (a *App) Execute(ctx context.Context) {
+	if err := a.Run(ctx, os.Args[1:]); err != nil {
+		fmt.Fprintf(a.errOut, "%s: %v\n", a.name, err)
+		os.Exit(1)
+	}
+}
+
+// Run runs the command named by args[0]; no arguments runs serve.
+func (a *App) Run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		args = []string{"serve"}
+	}
+
+	name := args[0]
+	if name == "help" || name == "-h" || name == "--help" {
+		a.usage()
+		return nil
+	}
+
+	cmd, ok := a.commands[name]
+	if !ok {
+		a.usage()
+		return fmt.Errorf("%w: %s", ErrUnknownCommand, name)
+	}
+
+	if !cmd.Init {
+		return cmd.Run(ctx, a.bootstrap, args[1:])
+	}
+
+	if err := a.bootstrap.Init(ctx); err != nil {
+		return err
+	}
+	defer a.bootstrap.Close()
+	return cmd.Run(ctx, a.bootstrap, args[1:])
+}
+
+func (a *App) usage() {
+	fmt.Fprintf(a.out, "Usage: %s <command> [args]\n\nCommands:\n", a.name)
+
+	names := make([]string, 0, len(a.commands))
+	for name := range a.commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	w := tabwriter.NewWriter(a.out, 0, 0, 2, ' ', 0)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %s\t%s\n", name, a.commands[name].Usage)
+	}
+	_ = w.Flush()
+}
+
+// newFlagSet returns a flag set writing errors to the CLI output.
+func (a *App) newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(a.name+" "+name, flag.ContinueOnError)
+	fs.SetOutput(a.errOut)
+	return fs
+}
+
+// printf writes to the CLI output.
+func (a *App) printf(format string, args ...any) {
+	fmt.Fprintf(a.out, format, args...)
+}
@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/BevisDev/godev/framework"
+	"github.com/BevisDev/godev/ginfw/server"
+	"github.com/BevisDev/godev/healthcheck"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestApp(t *testing.T, opts ...Option) (*App, *bytes.Buffer) {
+	t.Helper()
+	return newTestAppWith(t, framework.New(context.Background()), opts...)
+}
+
+func newTestAppWith(t *testing.T, b *framework.Bootstrap, opts ...Option) (*App, *bytes.Buffer) {
+	t.Helper()
+	var out bytes.Buffer
+	opts = append([]Option{WithOutput(&out, &out)}, opts...)
+	return New("svc", b, opts...), &out
+}
+
+func TestRun_HelpAndUnknown(t *testing.T) {
+	app, out := newTestApp(t)
+
+	require.NoError(t, app.Run(context.Background(), []string{"help"}))
+	for _, name := range []string{"serve", "migrate", "seed", "health", "routes", "config-dump"} {
+		assert.Contains(t, out.String(), name)
+	}
+
+	err := app.Run(context.Background(), []string{"nope"})
+	assert.ErrorIs(t, err, ErrUnknownCommand)
+}
+
+func TestRun_CustomCommand(t *testing.T) {
+	var got []string
+	app, _ := newTestApp(t, WithCommand(&Command{
+		Name:  "reindex",
+		Usage: "rebuild the search index",
+		Run: func(ctx context.Context, b *framework.Bootstrap, args []string) error {
+			got = args
+			return nil
+		},
+	}))
+
+	require.NoError(t, app.Run(context.Background(), []string{"reindex", "users"}))
+	assert.Equal(t, []string{"users"}, got)
+}
+
+func TestSeed(t *testing.T) {
+	app, _ := newTestApp(t)
+	assert.ErrorIs(t, app.Run(context.Background(), []string{"seed"}), ErrNoSeed)
+
+	seeded := false
+	app, _ = newTestApp(t, WithSeed(func(ctx context.Context, b *framework.Bootstrap) error {
+		seeded = b.Logger() != nil
+		return nil
+	}))
+	require.NoError(t, app.Run(context.Background(), []string{"seed"}))
+	assert.True(t, seeded, "seed runs after Init")
+}
+
+func TestMigrate_NotConfigured(t *testing.T) {
+	app, _ := newTestApp(t)
+	assert.ErrorIs(t, app.Run(context.Background(), []string{"migrate", "up"}), ErrNoMigration)
+}
+
+func TestHealth(t *testing.T) {
+	b := framework.New(context.Background(),
+		framework.WithHealthChecker("ok-svc", func(ctx context.Context) error { return nil }),
+		framework.WithHealthChecker("bad-svc", func(ctx context.Context) error { return errors.New("down") }),
+	)
+	app, out := newTestAppWith(t, b)
+
+	err := app.Run(context.Background(), []string{"health"})
+	assert.ErrorIs(t, err, ErrUnhealthy)
+	assert.Contains(t, err.Error(), "bad-svc")
+	assert.Regexp(t, `bad-svc\s+DOWN\s+down`, out.String())
+	assert.Regexp(t, `ok-svc\s+UP`, out.String())
+}
+
+func TestHealth_SoftFailure(t *testing.T) {
+	b := framework.New(context.Background(),
+		framework.WithHealthChecker("ok-svc", func(ctx context.Context) error { return nil }),
+		framework.WithHealthChecker("cache", func(ctx context.Context) error { return errors.New("down") }, healthcheck.Soft()),
+	)
+	app, out := newTestAppWith(t, b)
+
+	require.NoError(t, app.Run(context.Background(), []string{"health"}))
+	assert.Regexp(t, `cache\s+DOWN\s+soft\s+down`, out.String())
+	assert.Contains(t, out.String(), "DEGRADED")
+}
+
+func TestRoutes(t *testing.T) {
+	b := framework.New(context.Background(), framework.WithServer(&server.Config{
+		Port: 8080,
+		Setup: func(r *gin.Engine) {
+			r.GET("/users", func(c *gin.Context) {})
+			r.POST("/users", func(c *gin.Context) {})
+		},
+	}))
+	app, out := newTestAppWith(t, b)
+
+	require.NoError(t, app.Run(context.Background(), []string{"routes"}))
+	assert.Regexp(t, `GET\s+/users`, out.String())
+	assert.Regexp(t, `POST\s+/users`, out.String())
+}
+
+func TestConfigDump(t *testing.T) {
+	settings := map[string]any{
+		"app": map[string]any{"name": "svc"},
+		"database": map[string]any{
+			"host":     "db",
+			"password": "s3cret",
+		},
+		"api_token": "abc",
+	}
+
+	app, out := newTestApp(t, WithSettings(settings))
+	require.NoError(t, app.Run(context.Background(), []string{"config-dump"}))
+	assert.Contains(t, out.String(), "host: db")
+	assert.Contains(t, out.String(), "password: '******'")
+	assert.NotContains(t, out.String(), "s3cret")
+	assert.NotContains(t, out.String(), "abc")
+	assert.Equal(t, "s3cret", settings["database"].(map[string]any)["password"], "settings are not modified")
+
+	out.Reset()
+	require.NoError(t, app.Run(context.Background(), []string{"config-dump", "-format", "json", "-unmask"}))
+	assert.Contains(t, out.String(), `"password": "s3cret"`)
+
+	app, _ = newTestApp(t)
+	assert.ErrorIs(t, app.Run(context.Background(), []string{"config-dump"}), ErrNoSettings)
+}
@@ -0,0 +1,224 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/BevisDev/godev/framework"
+	"github.com/BevisDev/godev/utils/jsonx"
+	"github.com/gin-gonic/gin"
+	"go.yaml.in/yaml/v3"
+)
+
+// masked replaces sensitive values in config-dump.
+const masked = "******"
+
+// sensitiveKeys are key fragments whose values config-dump masks.
+var sensitiveKeys = []string{"password", "secret", "token", "apikey", "api_key", "privatekey", "private_key", "dsn"}
+
+func (a *App) builtins() []*Command {
+	return []*Command{
+		{
+			Name:  "serve",
+			Usage: "start the service (default)",
+			Run: func(ctx context.Context, b *framework.Bootstrap, args []string) error {
+				return b.Run(ctx)
+			},
+		},
+		{
+			Name:  "migrate",
+			Usage: "run database migrations: up [version] | down [version] | status | verify",
+			Init:  true,
+			Run:   a.migrate,
+		},
+		{
+			Name:  "seed",
+			Usage: "run the seed function",
+			Init:  true,
+			Run: func(ctx context.Context, b *framework.Bootstrap, args []string) error {
+				if a.seed == nil {
+					return ErrNoSeed
+				}
+				return a.seed(ctx, b)
+			},
+		},
+		{
+			Name:  "health",
+			Usage: "check configured services and exit non-zero when a critical one fails",
+			Init:  true,
+			Run:   a.health,
+		},
+		{
+			Name:  "routes",
+			Usage: "list registered HTTP routes",
+			Init:  true,
+			Run:   a.routes,
+		},
+		{
+			Name:  "config-dump",
+			Usage: "print the loaded configuration with secrets masked (-format yaml|json, -unmask)",
+			Run:   a.configDump,
+		},
+	}
+}
+
+func (a *App) migrate(ctx context.Context, b *framework.Bootstrap, args []string) error {
+	m := b.Migration()
+	if m == nil {
+		return ErrNoMigration
+	}
+
+	if len(args) == 0 {
+		return fmt.Errorf("[cli] migrate: missing action (up, down, status, verify)")
+	}
+
+	var version int64
+	if len(args) > 1 {
+		v, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("[cli] migrate: invalid version %q", args[1])
+		}
+		version = v
+	}
+
+	switch args[0] {
+	case "up":
+		return m.Up(ctx, version)
+	case "down":
+		return m.Down(ctx, version)
+	case "status":
+		return m.Status()
+	case "verify":
+		report, err := m.Verify(ctx)
+		if err != nil {
+			return err
+		}
+		for _, d := range report.Drifts {
+			fmt.Fprintln(a.out, d.String())
+		}
+		if report.OK() {
+			fmt.Fprintln(a.out, "schema OK")
+		}
+		return report.Err()
+	default:
+		return fmt.Errorf("[cli] migrate: unknown action %q (up, down, status, verify)", args[0])
+	}
+}
+
+func (a *App) health(ctx context.Context, b *framework.Bootstrap, args []string) error {
+	report := b.Health(ctx)
+
+	w := tabwriter.NewWriter(a.out, 0, 0, 2, ' ', 0)
+	for _, name := range report.Names() {
+		res := report.Checks[name]
+		level := ""
+		if !res.Critical {
+			level = "soft"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", name, res.Status, level, res.Error)
+	}
+	_ = w.Flush()
+	fmt.Fprintln(a.out, report.Status)
+
+	if !report.Healthy() {
+		return fmt.Errorf("%w: %s", ErrUnhealthy, strings.Join(report.Failed(), ", "))
+	}
+	return nil
+}
+
+func (a *App) routes(ctx context.Context, b *framework.Bootstrap, args []string) error {
+	// silence gin's debug route printing while building the engine
+	prev := gin.DefaultWriter
+	gin.DefaultWriter = io.Discard
+	routes := b.Routes()
+	gin.DefaultWriter = prev
+
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+		return routes[i].Method < routes[j].Method
+	})
+
+	w := tabwriter.NewWriter(a.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "METHOD\tPATH\tHANDLER")
+	for _, r := range routes {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", r.Method, r.Path, r.Handler)
+	}
+	return w.Flush()
+}
+
+func (a *App) configDump(ctx context.Context, b *framework.Bootstrap, args []string) error {
+	fs := a.newFlagSet("config-dump")
+	format := fs.String("format", "yaml", "output format: yaml or json")
+	unmask := fs.Bool("unmask", false, "print sensitive values")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if a.settings == nil {
+		return ErrNoSettings
+	}
+
+	settings := a.settings
+	if !*unmask {
+		settings = maskSettings(settings)
+	}
+
+	switch *format {
+	case "yaml":
+		out, err := yaml.Marshal(settings)
+		if err != nil {
+			return err
+		}
+		_, err = a.out.Write(out)
+		return err
+	case "json":
+		a.printf("%s\n", jsonx.Pretty(settings))
+		return nil
+	default:
+		return fmt.Errorf("[cli] config-dump: unknown format %q (yaml, json)", *format)
+	}
+}
+
+// maskSettings returns a copy of settings with sensitive values masked.
+func maskSettings(settings map[string]any) map[string]any {
+	out := make(map[string]any, len(settings))
+	for k, v := range settings {
+		out[k] = maskValue(k, v)
+	}
+	return out
+}
+
+func maskValue(key string, v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		return maskSettings(val)
+	case []any:
+		items := make([]any, len(val))
+		for i, item := range val {
+			items[i] = maskValue(key, item)
+		}
+		return items
+	default:
+		if isSensitive(key) && v != nil && v != "" {
+			return masked
+		}
+		return v
+	}
+}
+
+func isSensitive(key string) bool {
+	k := strings.ToLower(key)
+	for _, s := range sensitiveKeys {
+		if strings.Contains(k, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,11 @@
+package cli
+
+import "errors"
+
+var (
+	ErrUnknownCommand = errors.New("[cli] unknown command")
+	ErrNoMigration    = errors.New("[cli] migration is not configured")
+	ErrNoSeed         = errors.New("[cli] no seed function, use WithSeed")
+	ErrNoSettings     = errors.New("[cli] no settings, use WithSettings")
+	ErrUnhealthy      = errors.New("[cli] unhealthy services")
+)
@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/BevisDev/godev/framework"
+)
+
+type Option func(*options)
+
+type options struct {
+	out      io.Writer
+	errOut   io.Writer
+	seed     func(ctx context.Context, b *framework.Bootstrap) error
+	settings map[string]any
+	commands []*Command
+}
+
+func withDefaults() *options {
+	return &options{
+		out:    os.Stdout,
+		errOut: os.Stderr,
+	}
+}
+
+// WithOutput sets where commands print (default os.Stdout / os.Stderr).
+func WithOutput(out, errOut io.Writer) Option {
+	return func(o *options) {
+		if out != nil {
+			o.out = out
+		}
+		if errOut != nil {
+			o.errOut = errOut
+		}
+	}
+}
+
+// WithSeed sets the function run by the seed command after Init.
+func WithSeed(fn func(ctx context.Context, b *framework.Bootstrap) error) Option {
+	return func(o *options) {
+		o.seed = fn
+	}
+}
+
+// WithSettings sets the settings printed by config-dump, e.g. config.Response.Settings.
+func WithSettings(settings map[string]any) Option {
+	return func(o *options) {
+		o.settings = settings
+	}
+}
+
+// WithCommand adds a custom command or replaces a built-in one with the same name.
+func WithCommand(cmd *Command) Option {
+	return func(o *options) {
+		if cmd != nil && cmd.Name != "" && cmd.Run != nil {
+			o.commands = append(o.commands, cmd)
+		}
+	}
+}
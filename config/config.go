@@ -1,14 +1,17 @@
 package config
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/BevisDev/godev/utils/str"
 	"github.com/BevisDev/godev/utils/validate"
+	"github.com/go-viper/mapstructure/v2"
 	"github.com/spf13/viper"
 )
 
@@ -21,6 +24,9 @@ type Config struct {
 	AutoEnv    bool   // AutoEnv is used for env overrides (APP_PORT overrides app.port)
 	ReplaceEnv bool   // ReplaceEnv is used for replacing placeholders like "$DB_DSN"
 	Profile    string // Profile is config file name (without extension), e.g., "dev", "prod".
+
+	// Secrets resolves values like "vault:secret/data/db#password" at load time (optional).
+	Secrets *Secrets
 }
 
 type Response[T any] struct {
@@ -28,9 +34,18 @@ type Response[T any] struct {
 	Data     T
 }
 
-// Load loads configuration and panics on failure.
-// It reads the config file, applies env overrides, expands $VARS,
-// and unmarshal the result into the target struct.
+// MustLoad is like Load but panics on failure.
+func MustLoad[T any](cf *Config) Response[T] {
+	out, err := Load[T](cf)
+	if err != nil {
+		panic(err)
+	}
+	return out
+}
+
+// Load loads configuration.
+// It reads the config file, applies env overrides, expands $VARS, resolves secrets,
+// unmarshal the result into the target struct and checks its `validate` tags (see Validate).
 func Load[T any](cf *Config) (Response[T], error) {
 	if cf == nil {
 		return Response[T]{}, fmt.Errorf("config is nil")
@@ -64,6 +79,18 @@ func Load[T any](cf *Config) (Response[T], error) {
 		}
 	}
 
+	// RESOLVE SECRETS
+	if cf.Secrets != nil {
+		resolved, err := cf.Secrets.resolveSettings(context.Background(), "", settings)
+		if err != nil {
+			return Response[T]{}, err
+		}
+		// Set overrides env and file values
+		for k, val := range resolved {
+			v.Set(k, val)
+		}
+	}
+
 	// RETURN
 	var out Response[T]
 	if validate.IsNilOrEmpty(settings) {
@@ -71,16 +98,31 @@ func Load[T any](cf *Config) (Response[T], error) {
 	}
 
 	var t T
-	err := v.Unmarshal(&t)
+	err := v.Unmarshal(&t, viper.DecodeHook(decodeHook()))
 	if err != nil {
 		return Response[T]{}, fmt.Errorf("[config] failed to unmarshal: %v", err)
 	}
 
+	// VALIDATE
+	if err := Validate(t); err != nil {
+		return Response[T]{}, err
+	}
+
 	out.Data = t
 	out.Settings = v.AllSettings()
 	return out, nil
 }
 
+// decodeHook extends viper's default hooks ("30s" -> time.Duration, "a,b" -> slice)
+// with encoding.TextUnmarshaler, used by ByteSize ("100MB").
+func decodeHook() mapstructure.DecodeHookFunc {
+	return mapstructure.ComposeDecodeHookFunc(
+		mapstructure.StringToTimeDurationHookFunc(),
+		mapstructure.StringToSliceHookFunc(","),
+		mapstructure.TextUnmarshallerHookFunc(),
+	)
+}
+
 func replaceSettings(data map[string]interface{}) {
 	for k, v := range data {
 		data[k] = replace(v)
@@ -109,6 +151,13 @@ func replace(value interface{}) interface{} {
 	}
 }
 
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	byteSizeType = reflect.TypeOf(ByteSize(0))
+)
+
+// MapStruct maps cfMap into a new T using `config` struct tags.
+// time.Duration fields accept "30s" and ByteSize fields accept "100MB".
 func MapStruct[T any](m map[string]string) (*T, error) {
 	var dest T
 	err := mapStruct(&dest, m)
@@ -164,6 +213,24 @@ func mapStruct(target interface{}, cfMap map[string]string) error {
 		}
 
 		if val, ok := cfMap[key]; ok {
+			// typed values before their int64 kind
+			switch field.Type() {
+			case durationType:
+				d, err := time.ParseDuration(strings.TrimSpace(val))
+				if err != nil {
+					return fmt.Errorf("[config] %s: invalid duration %q", key, val)
+				}
+				field.SetInt(int64(d))
+				continue
+			case byteSizeType:
+				b, err := ParseByteSize(val)
+				if err != nil {
+					return fmt.Errorf("[config] %s: %w", key, err)
+				}
+				field.SetInt(int64(b))
+				continue
+			}
+
 			switch field.Kind() {
 			case reflect.String:
 				field.SetString(val)
@@ -0,0 +1,272 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultSecretTimeout = 10 * time.Second
+
+var (
+	ErrSecretNotFound    = errors.New("[config] secret not found")
+	ErrSecretKeyNotFound = errors.New("[config] secret key not found")
+)
+
+// SecretProvider fetches the secret stored at path.
+// Secrets holding several values (Vault KV, JSON in AWS Secrets Manager)
+// are returned as a JSON object; a "#key" suffix in the reference selects one value.
+type SecretProvider interface {
+	GetSecret(ctx context.Context, path string) (string, error)
+}
+
+// SecretProviderFunc adapts a function to SecretProvider,
+// e.g. to plug in the AWS SDK for "aws-sm:" references.
+type SecretProviderFunc func(ctx context.Context, path string) (string, error)
+
+func (f SecretProviderFunc) GetSecret(ctx context.Context, path string) (string, error) {
+	return f(ctx, path)
+}
+
+// SecretOption configures Secrets.
+type SecretOption func(*Secrets)
+
+// WithSecretProvider registers p for config values starting with "<scheme>:".
+func WithSecretProvider(scheme string, p SecretProvider) SecretOption {
+	return func(s *Secrets) {
+		if scheme != "" && p != nil {
+			s.providers[scheme] = p
+		}
+	}
+}
+
+// WithSecretTimeout sets the timeout of a single fetch (default 10s).
+func WithSecretTimeout(d time.Duration) SecretOption {
+	return func(s *Secrets) {
+		if d > 0 {
+			s.timeout = d
+		}
+	}
+}
+
+// Secrets resolves config values like "vault:secret/data/db#password" or
+// "aws-sm:myapp/db" through registered providers. Fetched secrets are cached
+// by path, so several keys of one secret cost one fetch.
+type Secrets struct {
+	providers map[string]SecretProvider
+	timeout   time.Duration
+
+	mu    sync.RWMutex
+	cache map[string]string // "<scheme>:<path>" -> raw secret
+}
+
+// NewSecrets creates a secrets resolver with the given providers.
+func NewSecrets(opts ...SecretOption) *Secrets {
+	s := &Secrets{
+		providers: make(map[string]SecretProvider),
+		timeout:   defaultSecretTimeout,
+		cache:     make(map[string]string),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// IsSecret reports whether value references a registered provider.
+func (s *Secrets) IsSecret(value string) bool {
+	_, _, _, ok := s.parse(value)
+	return ok
+}
+
+// Resolve returns the secret referenced by value, or value unchanged when it
+// does not start with a registered scheme.
+func (s *Secrets) Resolve(ctx context.Context, value string) (string, error) {
+	scheme, path, key, ok := s.parse(value)
+	if !ok {
+		return value, nil
+	}
+
+	cacheKey := scheme + ":" + path
+	s.mu.RLock()
+	raw, cached := s.cache[cacheKey]
+	s.mu.RUnlock()
+
+	if !cached {
+		var err error
+		raw, err = s.fetch(ctx, scheme, path)
+		if err != nil {
+			return "", err
+		}
+		s.mu.Lock()
+		s.cache[cacheKey] = raw
+		s.mu.Unlock()
+	}
+
+	return selectKey(raw, key, value)
+}
+
+// Refresh fetches every cached secret again and calls onChange for each one
+// whose value changed. Failed fetches are logged and keep the cached value.
+func (s *Secrets) Refresh(ctx context.Context, onChange func(ref string)) {
+	s.mu.RLock()
+	refs := make(map[string]string, len(s.cache))
+	for k, v := range s.cache {
+		refs[k] = v
+	}
+	s.mu.RUnlock()
+
+	for ref, old := range refs {
+		scheme, path, _ := strings.Cut(ref, ":")
+		raw, err := s.fetch(ctx, scheme, path)
+		if err != nil {
+			log.Printf("[config] refresh secret %s: %v", ref, err)
+			continue
+		}
+		if raw == old {
+			continue
+		}
+
+		s.mu.Lock()
+		s.cache[ref] = raw
+		s.mu.Unlock()
+		if onChange != nil {
+			onChange(ref)
+		}
+	}
+}
+
+// StartRefresh calls Refresh every interval until ctx is done.
+// Use onChange to reload the configuration (Load) after a rotation.
+func (s *Secrets) StartRefresh(ctx context.Context, interval time.Duration, onChange func(ref string)) {
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.Refresh(ctx, onChange)
+			}
+		}
+	}()
+}
+
+func (s *Secrets) fetch(ctx context.Context, scheme, path string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	raw, err := s.providers[scheme].GetSecret(ctx, path)
+	if err != nil {
+		return "", fmt.Errorf("[config] secret %s:%s: %w", scheme, path, err)
+	}
+	return raw, nil
+}
+
+// parse splits "<scheme>:<path>#<key>" for a registered scheme.
+func (s *Secrets) parse(value string) (scheme, path, key string, ok bool) {
+	if s == nil {
+		return "", "", "", false
+	}
+
+	scheme, rest, found := strings.Cut(value, ":")
+	if !found || rest == "" {
+		return "", "", "", false
+	}
+	if _, registered := s.providers[scheme]; !registered {
+		return "", "", "", false
+	}
+
+	path, key, _ = strings.Cut(rest, "#")
+	return scheme, path, key, path != ""
+}
+
+// selectKey returns raw, or the value of key when raw is a JSON object.
+func selectKey(raw, key, ref string) (string, error) {
+	if key == "" {
+		return raw, nil
+	}
+
+	var m map[string]any
+	if err := json.Unmarshal([]byte(raw), &m); err != nil {
+		return "", fmt.Errorf("%w: %s (secret is not a JSON object)", ErrSecretKeyNotFound, ref)
+	}
+
+	v, ok := m[key]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrSecretKeyNotFound, ref)
+	}
+	if str, ok := v.(string); ok {
+		return str, nil
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// resolveSettings replaces secret references in settings and returns the
+// dotted keys of replaced values.
+func (s *Secrets) resolveSettings(ctx context.Context, prefix string, data map[string]any) (map[string]any, error) {
+	resolved := make(map[string]any)
+	for k, v := range data {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+
+		switch val := v.(type) {
+		case string:
+			if !s.IsSecret(val) {
+				continue
+			}
+			secret, err := s.Resolve(ctx, val)
+			if err != nil {
+				return nil, err
+			}
+			data[k] = secret
+			resolved[key] = secret
+
+		case map[string]any:
+			sub, err := s.resolveSettings(ctx, key, val)
+			if err != nil {
+				return nil, err
+			}
+			for sk, sv := range sub {
+				resolved[sk] = sv
+			}
+
+		case []any:
+			changed := false
+			for i, item := range val {
+				str, ok := item.(string)
+				if !ok || !s.IsSecret(str) {
+					continue
+				}
+				secret, err := s.Resolve(ctx, str)
+				if err != nil {
+					return nil, err
+				}
+				val[i] = secret
+				changed = true
+			}
+			if changed {
+				resolved[key] = val
+			}
+		}
+	}
+	return resolved, nil
+}
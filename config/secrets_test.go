@@ -0,0 +1,129 @@
+package config
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecrets_Resolve(t *testing.T) {
+	var calls int32
+	s := NewSecrets(WithSecretProvider("aws-sm", SecretProviderFunc(
+		func(ctx context.Context, path string) (string, error) {
+			atomic.AddInt32(&calls, 1)
+			switch path {
+			case "myapp/db":
+				return `{"user":"app","password":"s3cret","port":5432}`, nil
+			case "myapp/token":
+				return "plain-token", nil
+			}
+			return "", ErrSecretNotFound
+		},
+	)))
+
+	ctx := context.Background()
+
+	v, err := s.Resolve(ctx, "aws-sm:myapp/db#password")
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret", v)
+
+	v, err = s.Resolve(ctx, "aws-sm:myapp/db#port")
+	require.NoError(t, err)
+	assert.Equal(t, "5432", v)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls), "secret is cached by path")
+
+	v, err = s.Resolve(ctx, "aws-sm:myapp/token")
+	require.NoError(t, err)
+	assert.Equal(t, "plain-token", v)
+
+	_, err = s.Resolve(ctx, "aws-sm:myapp/db#missing")
+	assert.ErrorIs(t, err, ErrSecretKeyNotFound)
+
+	_, err = s.Resolve(ctx, "aws-sm:myapp/none")
+	assert.ErrorIs(t, err, ErrSecretNotFound)
+
+	// unregistered schemes are plain values
+	v, err = s.Resolve(ctx, "http://localhost:8080")
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost:8080", v)
+}
+
+func TestSecrets_Refresh(t *testing.T) {
+	var value atomic.Value
+	value.Store("v1")
+	s := NewSecrets(WithSecretProvider("mem", SecretProviderFunc(
+		func(ctx context.Context, path string) (string, error) {
+			return value.Load().(string), nil
+		},
+	)))
+
+	v, err := s.Resolve(context.Background(), "mem:key")
+	require.NoError(t, err)
+	assert.Equal(t, "v1", v)
+
+	var changed []string
+	s.Refresh(context.Background(), func(ref string) { changed = append(changed, ref) })
+	assert.Empty(t, changed)
+
+	value.Store("v2")
+	s.Refresh(context.Background(), func(ref string) { changed = append(changed, ref) })
+	assert.Equal(t, []string{"mem:key"}, changed)
+
+	v, _ = s.Resolve(context.Background(), "mem:key")
+	assert.Equal(t, "v2", v)
+}
+
+func TestVaultProvider(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "root" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/db":
+			_, _ = w.Write([]byte(`{"data":{"data":{"host":"db.internal","password":"pw"},"metadata":{"version":3}}}`))
+		case "/v1/kv/app":
+			_, _ = w.Write([]byte(`{"data":{"api_key":"k1"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	s := NewSecrets(WithSecretProvider("vault", NewVaultProvider(srv.URL, "root")))
+	ctx := context.Background()
+
+	v, err := s.Resolve(ctx, "vault:secret/data/db#password")
+	require.NoError(t, err)
+	assert.Equal(t, "pw", v)
+
+	v, err = s.Resolve(ctx, "vault:kv/app#api_key")
+	require.NoError(t, err)
+	assert.Equal(t, "k1", v)
+
+	_, err = s.Resolve(ctx, "vault:secret/data/none#x")
+	assert.ErrorIs(t, err, ErrSecretNotFound)
+}
+
+func TestLoad_Secrets(t *testing.T) {
+	secrets := NewSecrets(WithSecretProvider("vault", SecretProviderFunc(
+		func(ctx context.Context, path string) (string, error) {
+			return `{"host":"db.internal"}`, nil
+		},
+	)))
+
+	resp, err := Load[TestConfigStruct](&Config{
+		Path:    "./testdata",
+		Ext:     "yaml",
+		Profile: "test_secrets",
+		Secrets: secrets,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "db.internal", resp.Data.DatabaseAPP.Host)
+	assert.Equal(t, "static-host", resp.Data.RedisAPP.Host)
+}
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ByteSize is a size in bytes that can be written in config as "512", "100KB",
+// "1.5GB" or "64MiB". Units are 1024-based like viper's GetSizeInBytes:
+// KB and KiB both mean 1024 bytes.
+type ByteSize int64
+
+const (
+	Byte ByteSize = 1
+	KB            = 1024 * Byte
+	MB            = 1024 * KB
+	GB            = 1024 * MB
+	TB            = 1024 * GB
+)
+
+var byteUnits = map[string]ByteSize{
+	"":    Byte,
+	"b":   Byte,
+	"k":   KB,
+	"kb":  KB,
+	"kib": KB,
+	"m":   MB,
+	"mb":  MB,
+	"mib": MB,
+	"g":   GB,
+	"gb":  GB,
+	"gib": GB,
+	"t":   TB,
+	"tb":  TB,
+	"tib": TB,
+}
+
+// ParseByteSize parses sizes like "100MB" or "1.5 GiB" (case-insensitive).
+func ParseByteSize(s string) (ByteSize, error) {
+	in := strings.ToLower(strings.TrimSpace(s))
+	i := strings.IndexFunc(in, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if i < 0 {
+		i = len(in)
+	}
+
+	num, unit := in[:i], strings.TrimSpace(in[i:])
+	mul, ok := byteUnits[unit]
+	if num == "" || !ok {
+		return 0, fmt.Errorf("[config] invalid byte size %q", s)
+	}
+
+	f, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0, fmt.Errorf("[config] invalid byte size %q", s)
+	}
+	return ByteSize(f * float64(mul)), nil
+}
+
+// UnmarshalText lets Load and MapStruct decode strings like "100MB".
+func (b *ByteSize) UnmarshalText(text []byte) error {
+	v, err := ParseByteSize(string(text))
+	if err != nil {
+		return err
+	}
+	*b = v
+	return nil
+}
+
+// Bytes returns the size as int64.
+func (b ByteSize) Bytes() int64 {
+	return int64(b)
+}
+
+// String formats the size with the largest exact unit, e.g. "100MB".
+func (b ByteSize) String() string {
+	for _, u := range []struct {
+		size ByteSize
+		name string
+	}{{TB, "TB"}, {GB, "GB"}, {MB, "MB"}, {KB, "KB"}} {
+		if b != 0 && b%u.size == 0 {
+			return strconv.FormatInt(int64(b/u.size), 10) + u.name
+		}
+	}
+	return strconv.FormatInt(int64(b), 10) + "B"
+}
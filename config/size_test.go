@@ -0,0 +1,71 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseByteSize(t *testing.T) {
+	cases := map[string]ByteSize{
+		"512":     512,
+		"100B":    100,
+		"1KB":     KB,
+		"64 MiB":  64 * MB,
+		"1.5gb":   GB + GB/2,
+		"2T":      2 * TB,
+		" 10mb ":  10 * MB,
+		"0":       0,
+		"1048576": MB,
+	}
+	for in, want := range cases {
+		got, err := ParseByteSize(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+
+	for _, in := range []string{"", "MB", "10XB", "1.2.3KB"} {
+		_, err := ParseByteSize(in)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestByteSize_String(t *testing.T) {
+	assert.Equal(t, "100MB", (100 * MB).String())
+	assert.Equal(t, "1536KB", (MB + MB/2).String())
+	assert.Equal(t, "10B", ByteSize(10).String())
+	assert.Equal(t, "0B", ByteSize(0).String())
+}
+
+func TestLoad_DurationAndSize(t *testing.T) {
+	type units struct {
+		Timeout  time.Duration `mapstructure:"timeout"`
+		MaxBody  ByteSize      `mapstructure:"max_body"`
+		PartSize ByteSize      `mapstructure:"part_size"`
+	}
+
+	resp, err := Load[units](&Config{Path: "./testdata", Ext: "yaml", Profile: "test_units"})
+	require.NoError(t, err)
+	assert.Equal(t, 90*time.Second, resp.Data.Timeout)
+	assert.Equal(t, 10*MB, resp.Data.MaxBody)
+	assert.Equal(t, MB, resp.Data.PartSize)
+}
+
+func TestMapStruct_DurationAndSize(t *testing.T) {
+	type units struct {
+		Timeout time.Duration `config:"timeout"`
+		MaxBody ByteSize      `config:"max_body"`
+	}
+
+	out, err := MapStruct[units](map[string]string{"timeout": "5m", "max_body": "2KB"})
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Minute, out.Timeout)
+	assert.Equal(t, 2*KB, out.MaxBody)
+
+	_, err = MapStruct[units](map[string]string{"timeout": "5 minutes"})
+	assert.Error(t, err)
+	_, err = MapStruct[units](map[string]string{"max_body": "big"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,161 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// ValidationError lists every missing or invalid key found by Validate.
+type ValidationError struct {
+	Problems []string // e.g. "database.host: is required"
+}
+
+func (e *ValidationError) Error() string {
+	return "[config] invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+// Validate checks the `validate` struct tags of v (a struct or pointer to struct)
+// and returns a *ValidationError listing all problems. Keys are reported by their
+// mapstructure names, e.g. "database.host".
+//
+// Supported rules, separated by commas:
+//   - required: string not blank, pointer/slice/map not nil or empty
+//   - nonzero:  value is not the zero value (numbers, durations, ...)
+//   - oneof=a b c: value is one of the listed values
+//   - url:      absolute URL with scheme and host
+//   - duration: string accepted by time.ParseDuration
+//
+// Except for required and nonzero, rules are skipped for empty values.
+// Unknown rules are ignored so tags can be shared with other validators.
+func Validate(v any) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return &ValidationError{Problems: []string{"config is nil"}}
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var problems []string
+	validateStruct(rv, "", &problems)
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+func validateStruct(v reflect.Value, prefix string, problems *[]string) {
+	t := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		field := v.Field(i)
+		key := keyName(sf, prefix)
+		if tag := sf.Tag.Get("validate"); tag != "" && tag != "-" {
+			validateField(field, key, tag, problems)
+		}
+
+		// nested structs, except types like time.Time
+		switch {
+		case field.Kind() == reflect.Struct && field.NumField() > 0 && sf.Type != reflect.TypeOf(time.Time{}):
+			validateStruct(field, key, problems)
+		case field.Kind() == reflect.Ptr && !field.IsNil() && field.Elem().Kind() == reflect.Struct:
+			validateStruct(field.Elem(), key, problems)
+		}
+	}
+}
+
+func validateField(field reflect.Value, key, tag string, problems *[]string) {
+	empty := isEmptyValue(field)
+	for _, rule := range strings.Split(tag, ",") {
+		name, arg, _ := strings.Cut(strings.TrimSpace(rule), "=")
+
+		var problem string
+		switch name {
+		case "required":
+			if empty {
+				problem = "is required"
+			}
+		case "nonzero":
+			if field.IsZero() {
+				problem = "must not be zero"
+			}
+		case "oneof":
+			if !empty && !oneOf(valueString(field), strings.Fields(arg)) {
+				problem = fmt.Sprintf("must be one of [%s], got %q", strings.Join(strings.Fields(arg), " "), valueString(field))
+			}
+		case "url":
+			if !empty && !isURL(valueString(field)) {
+				problem = fmt.Sprintf("must be an absolute URL, got %q", valueString(field))
+			}
+		case "duration":
+			if !empty && field.Kind() == reflect.String {
+				if _, err := time.ParseDuration(field.String()); err != nil {
+					problem = fmt.Sprintf("must be a duration like 5s or 1m30s, got %q", field.String())
+				}
+			}
+		}
+
+		if problem != "" {
+			*problems = append(*problems, key+": "+problem)
+		}
+	}
+}
+
+// keyName returns the dotted config key of a field.
+func keyName(sf reflect.StructField, prefix string) string {
+	name := strings.Split(sf.Tag.Get("mapstructure"), ",")[0]
+	if name == "" || name == "-" {
+		name = strings.ToLower(sf.Name)
+	}
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.String:
+		return strings.TrimSpace(v.String()) == ""
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return v.Len() == 0
+	default:
+		return v.IsZero()
+	}
+}
+
+func valueString(v reflect.Value) string {
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.String {
+		return v.String()
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+func oneOf(s string, values []string) bool {
+	for _, v := range values {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+func isURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && u.Scheme != "" && u.Host != ""
+}
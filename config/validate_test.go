@@ -0,0 +1,77 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type validatedConfig struct {
+	Env      string `mapstructure:"env" validate:"required,oneof=dev staging prod"`
+	Port     int    `mapstructure:"port" validate:"nonzero"`
+	Database struct {
+		Host    string `mapstructure:"host" validate:"required"`
+		Timeout string `mapstructure:"timeout" validate:"duration"`
+	} `mapstructure:"database"`
+	Callback string        `mapstructure:"callback_url" validate:"url"`
+	Retry    time.Duration `validate:"nonzero"`
+	Redis    *struct {
+		Addr string `mapstructure:"addr" validate:"required"`
+	} `mapstructure:"redis"`
+	Tags []string `mapstructure:"tags" validate:"required,min=1"`
+}
+
+func TestValidate_Aggregated(t *testing.T) {
+	cfg := validatedConfig{Env: "qa", Callback: "not a url"}
+	cfg.Database.Timeout = "5 seconds"
+	cfg.Redis = &struct {
+		Addr string `mapstructure:"addr" validate:"required"`
+	}{}
+
+	err := Validate(&cfg)
+	require.Error(t, err)
+
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.ElementsMatch(t, []string{
+		`env: must be one of [dev staging prod], got "qa"`,
+		"port: must not be zero",
+		"database.host: is required",
+		`database.timeout: must be a duration like 5s or 1m30s, got "5 seconds"`,
+		`callback_url: must be an absolute URL, got "not a url"`,
+		"retry: must not be zero",
+		"redis.addr: is required",
+		"tags: is required",
+	}, verr.Problems)
+	assert.Contains(t, err.Error(), "database.host: is required")
+}
+
+func TestValidate_OK(t *testing.T) {
+	cfg := validatedConfig{
+		Env:      "prod",
+		Port:     8080,
+		Callback: "https://example.com/hook",
+		Retry:    time.Second,
+		Tags:     []string{"a"},
+	}
+	cfg.Database.Host = "db"
+	cfg.Database.Timeout = "5s"
+
+	assert.NoError(t, Validate(cfg))
+	assert.NoError(t, Validate("not a struct"))
+	assert.Error(t, Validate((*validatedConfig)(nil)))
+}
+
+func TestMustLoad_Panics(t *testing.T) {
+	assert.Panics(t, func() { MustLoad[TestConfigStruct](nil) })
+
+	type required struct {
+		Missing string `mapstructure:"missing" validate:"required"`
+	}
+	_, err := Load[required](&Config{Path: "./testdata", Ext: "yaml", Profile: "test"})
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.Equal(t, []string{"missing: is required"}, verr.Problems)
+}
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// VaultProvider reads secrets from the HashiCorp Vault HTTP API.
+// Paths are API paths without "/v1", e.g. "secret/data/db" for KV v2;
+// the returned value is the JSON object of the secret data (KV v1 and v2).
+type VaultProvider struct {
+	Addr      string       // Vault address, default $VAULT_ADDR
+	Token     string       // Vault token, default $VAULT_TOKEN
+	Namespace string       // Vault Enterprise namespace, default $VAULT_NAMESPACE
+	Client    *http.Client // default http.DefaultClient
+}
+
+// NewVaultProvider creates a provider; empty addr and token are read from
+// VAULT_ADDR and VAULT_TOKEN.
+func NewVaultProvider(addr, token string) *VaultProvider {
+	if addr == "" {
+		addr = os.Getenv("VAULT_ADDR")
+	}
+	if token == "" {
+		token = os.Getenv("VAULT_TOKEN")
+	}
+	return &VaultProvider{
+		Addr:      addr,
+		Token:     token,
+		Namespace: os.Getenv("VAULT_NAMESPACE"),
+	}
+}
+
+func (p *VaultProvider) GetSecret(ctx context.Context, path string) (string, error) {
+	url := strings.TrimRight(p.Addr, "/") + "/v1/" + strings.TrimLeft(path, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", p.Token)
+	if p.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.Namespace)
+	}
+
+	client := p.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return "", ErrSecretNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("vault status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var out struct {
+		Data map[string]json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(body, &out); err != nil {
+		return "", fmt.Errorf("decode vault response: %w", err)
+	}
+
+	// KV v2 nests the secret in data.data next to data.metadata
+	if inner, ok := out.Data["data"]; ok {
+		if _, hasMeta := out.Data["metadata"]; hasMeta {
+			if string(inner) == "null" {
+				return "", ErrSecretNotFound
+			}
+			return string(inner), nil
+		}
+	}
+
+	data, err := json.Marshal(out.Data)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
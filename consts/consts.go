@@ -35,10 +35,14 @@ const (
 
 const (
 	RID         = "rid"
+	Lang        = "lang"
+	Actor       = "actor"
+	TenantID    = "tenant_id"
 	Status      = "status"
 	Header      = "header"
 	Body        = "body"
 	Duration    = "duration"
+	Timing      = "timing"
 	RequestTime = "request_time"
 	Method      = "method"
 	Url         = "url"
@@ -11,6 +11,10 @@ const (
 	UTF8                    = "UTF-8"
 	ContentTransferEncoding = "Content-Transfer-Encoding"
 
+	AcceptLanguage  = "Accept-Language"
+	ContentLanguage = "Content-Language"
+	SOAPAction      = "SOAPAction"
+
 	Authorization          = "Authorization"
 	ApplicationJSON        = "application/json"
 	ApplicationFormData    = "application/x-www-form-urlencoded"
@@ -35,8 +39,9 @@ const (
 	ApplicationXZip = "application/x-zip-compressed"
 
 	// ApplicationXML for using xml
-	ApplicationXML = "application/xml"
-	TextXML        = "text/xml"
+	ApplicationXML     = "application/xml"
+	TextXML            = "text/xml"
+	ApplicationSOAPXML = "application/soap+xml"
 
 	// TextCSV ( commons text)
 	TextCSV           = "text/csv"
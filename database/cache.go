@@ -0,0 +1,299 @@
+package database
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/BevisDev/godev/redis"
+)
+
+// defaultCachePrefix is prepended to every query cache key: <prefix><table>:<key>.
+const defaultCachePrefix = "db:"
+
+// QueryCache stores serialized query results for Chain.Cache.
+type QueryCache interface {
+	// Get returns the cached value; ok is false on a miss.
+	Get(ctx context.Context, key string) (value []byte, ok bool, err error)
+
+	// Set stores value for ttl.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+
+	// Delete removes the given keys.
+	Delete(ctx context.Context, keys ...string) error
+
+	// DeletePrefix removes every key starting with prefix.
+	DeletePrefix(ctx context.Context, prefix string) error
+}
+
+// UseCache enables result caching for chains that call Cache.
+// Keys are namespaced as "db:<table>:..." so a table can be invalidated at once.
+func (d *DB) UseCache(cache QueryCache) {
+	d.cache = cache
+}
+
+// QueryCache returns the cache set by UseCache, or nil.
+func (d *DB) QueryCache() QueryCache {
+	return d.cache
+}
+
+// CacheKey returns the cache key for table and keyParts, as used by Chain.Cache.
+func (d *DB) CacheKey(table string, keyParts ...string) string {
+	return defaultCachePrefix + table + ":" + strings.Join(keyParts, ":")
+}
+
+// InvalidateCache removes the cached result stored under table and keyParts
+// (for the tenant of ctx when the table is scoped, see ScopeTenant).
+func (d *DB) InvalidateCache(ctx context.Context, table string, keyParts ...string) error {
+	if d.cache == nil {
+		return nil
+	}
+	keyParts, err := d.tenantKeyParts(ctx, table, keyParts)
+	if err != nil {
+		return err
+	}
+	return d.cache.Delete(ctx, d.CacheKey(table, keyParts...))
+}
+
+// InvalidateTable removes every cached result of table.
+func (d *DB) InvalidateTable(ctx context.Context, table string) error {
+	if d.cache == nil {
+		return nil
+	}
+	return d.cache.DeletePrefix(ctx, defaultCachePrefix+table+":")
+}
+
+// InvalidatePrefix removes every cached result whose key starts with CacheKey(table, prefixParts...)
+// (for the tenant of ctx when the table is scoped, see ScopeTenant).
+func (d *DB) InvalidatePrefix(ctx context.Context, table string, prefixParts ...string) error {
+	if d.cache == nil {
+		return nil
+	}
+	prefixParts, err := d.tenantKeyParts(ctx, table, prefixParts)
+	if err != nil {
+		return err
+	}
+	return d.cache.DeletePrefix(ctx, d.CacheKey(table, prefixParts...))
+}
+
+// tenantKeyParts prefixes explicit key parts with the tenant of ctx for scoped tables.
+func (d *DB) tenantKeyParts(ctx context.Context, table string, parts []string) ([]string, error) {
+	_, id, ok, err := d.tenantScope(ctx, table)
+	if err != nil || !ok {
+		return parts, err
+	}
+	return tenantParts(id, parts), nil
+}
+
+func tenantParts(tenantID string, parts []string) []string {
+	if tenantID == "" {
+		return parts
+	}
+	return append([]string{"tenant", tenantID}, parts...)
+}
+
+// cacheKey returns the key of the chain: the explicit key parts, or a hash of the query and args.
+func (d *Chain[T]) cacheKey(query string, args []interface{}) string {
+	if len(d.cacheParts) > 0 {
+		return d.CacheKey(d.table, tenantParts(d.tenantID, d.cacheParts)...)
+	}
+	h := sha1.New()
+	h.Write([]byte(query))
+	for _, a := range args {
+		_, _ = fmt.Fprintf(h, "|%T:%v", a, a)
+	}
+	return d.CacheKey(d.table, hex.EncodeToString(h.Sum(nil)))
+}
+
+// cached returns the result stored under key, or runs load and stores its result for ttl.
+// Cache failures are logged and never fail the query. Queries in a transaction bypass
+// the cache, which holds committed data only.
+func cached[R any](ctx context.Context, d *DB, key string, ttl time.Duration, load func() (R, error)) (R, error) {
+	if d.TxFrom(ctx) != nil {
+		return load()
+	}
+	if b, ok, err := d.cache.Get(ctx, key); err != nil {
+		log.Printf("[database] cache get %s: %v", key, err)
+	} else if ok {
+		var out R
+		if err := json.Unmarshal(b, &out); err == nil {
+			return out, nil
+		}
+		log.Printf("[database] cache decode %s: %v", key, err)
+	}
+
+	out, err := load()
+	if err != nil {
+		return out, err
+	}
+
+	b, err := json.Marshal(out)
+	if err != nil {
+		log.Printf("[database] cache encode %s: %v", key, err)
+		return out, nil
+	}
+	if err := d.cache.Set(ctx, key, b, ttl); err != nil {
+		log.Printf("[database] cache set %s: %v", key, err)
+	}
+	return out, nil
+}
+
+// invalidateAfterWrite drops the cached results of table after a successful write.
+// In a RunTx transaction it waits for the commit, so readers cannot cache the
+// data of before the commit again in between.
+func (d *DB) invalidateAfterWrite(ctx context.Context, table string) {
+	if d.cache == nil || table == "" {
+		return
+	}
+	invalidate := func(ctx context.Context) {
+		if err := d.InvalidateTable(ctx, table); err != nil {
+			log.Printf("[database] cache invalidate %s: %v", table, err)
+		}
+	}
+	if d.afterCommit(ctx, func() { invalidate(context.WithoutCancel(ctx)) }) {
+		return
+	}
+	invalidate(ctx)
+}
+
+// ============================================================
+// =============== MEMORY / REDIS ===================
+// ============================================================
+
+// MemoryCache is an in-process QueryCache, suitable for a single instance and tests.
+type MemoryCache struct {
+	mu    sync.Mutex
+	items map[string]memoryItem
+}
+
+type memoryItem struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// NewMemoryCache creates an empty MemoryCache.
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{items: make(map[string]memoryItem)}
+}
+
+func (m *MemoryCache) Get(_ context.Context, key string) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	it, ok := m.items[key]
+	if !ok {
+		return nil, false, nil
+	}
+	if !it.expiresAt.IsZero() && time.Now().After(it.expiresAt) {
+		delete(m.items, key)
+		return nil, false, nil
+	}
+	return it.value, true, nil
+}
+
+func (m *MemoryCache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	// drop expired entries so the map does not grow with stale keys
+	for k, it := range m.items {
+		if !it.expiresAt.IsZero() && now.After(it.expiresAt) {
+			delete(m.items, k)
+		}
+	}
+
+	it := memoryItem{value: value}
+	if ttl > 0 {
+		it.expiresAt = now.Add(ttl)
+	}
+	m.items[key] = it
+	return nil
+}
+
+func (m *MemoryCache) Delete(_ context.Context, keys ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, k := range keys {
+		delete(m.items, k)
+	}
+	return nil
+}
+
+func (m *MemoryCache) DeletePrefix(_ context.Context, prefix string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for k := range m.items {
+		if strings.HasPrefix(k, prefix) {
+			delete(m.items, k)
+		}
+	}
+	return nil
+}
+
+// RedisCache is a QueryCache backed by redis, shared by every instance.
+type RedisCache struct {
+	cache *redis.Cache
+}
+
+// NewRedisCache creates a QueryCache on top of cache.
+func NewRedisCache(cache *redis.Cache) *RedisCache {
+	return &RedisCache{cache: cache}
+}
+
+func (r *RedisCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	b, err := r.cache.GetClient().Get(ctx, r.cache.Key(key)).Bytes()
+	if err != nil {
+		if r.cache.IsNil(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	return b, true, nil
+}
+
+func (r *RedisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return r.cache.GetClient().Set(ctx, r.cache.Key(key), value, ttl).Err()
+}
+
+func (r *RedisCache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	full := make([]string, len(keys))
+	for i, k := range keys {
+		full[i] = r.cache.Key(k)
+	}
+	return r.cache.GetClient().Del(ctx, full...).Err()
+}
+
+// DeletePrefix scans for matching keys, so it should not be called on hot paths of large keyspaces.
+func (r *RedisCache) DeletePrefix(ctx context.Context, prefix string) error {
+	rdb := r.cache.GetClient()
+	pattern := redis.EscapePattern(r.cache.Key(prefix)) + "*"
+
+	var cursor uint64
+	for {
+		keys, next, err := rdb.Scan(ctx, cursor, pattern, 500).Result()
+		if err != nil {
+			return err
+		}
+		if len(keys) > 0 {
+			if err := rdb.Del(ctx, keys...).Err(); err != nil {
+				return err
+			}
+		}
+		if next == 0 {
+			return nil
+		}
+		cursor = next
+	}
+}
@@ -0,0 +1,202 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChain_Cache_FindAll(t *testing.T) {
+	db, mock := setupTestDB(t)
+	defer db.Close()
+	db.UseCache(NewMemoryCache())
+	ctx := context.Background()
+
+	// only one query reaches the database
+	mock.ExpectQuery("SELECT \\* FROM users").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "email"}).
+			AddRow("Alice", "alice@example.com").
+			AddRow("Bob", "bob@example.com"))
+
+	q := Builder[User](db).From("users").Where("active = ?", 1).Cache(time.Minute)
+	for i := 0; i < 2; i++ {
+		users, err := q.FindAll(ctx)
+		require.NoError(t, err)
+		require.Len(t, users, 2)
+		assert.Equal(t, "Bob", users[1].Name)
+	}
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestChain_Cache_KeyParts(t *testing.T) {
+	db, mock := setupTestDB(t)
+	defer db.Close()
+	db.UseCache(NewMemoryCache())
+	ctx := context.Background()
+
+	mock.ExpectQuery("SELECT \\* FROM users").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "email"}).AddRow("Alice", "alice@example.com"))
+
+	q := Builder[User](db).From("users").Where("id = ?", 1).Cache(time.Minute, "id", "1")
+	user, err := q.First(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "Alice", user.Name)
+
+	b, ok, err := db.QueryCache().Get(ctx, "db:users:id:1")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.JSONEq(t, `{"Name":"Alice","Email":"alice@example.com"}`, string(b))
+
+	// explicit invalidation forces the next read to hit the database
+	require.NoError(t, db.InvalidateCache(ctx, "users", "id", "1"))
+	mock.ExpectQuery("SELECT \\* FROM users").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "email"}).AddRow("Alice2", "alice@example.com"))
+
+	user, err = q.First(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "Alice2", user.Name)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestChain_Cache_InvalidatedByWrite(t *testing.T) {
+	db, mock := setupTestDB(t)
+	defer db.Close()
+	db.UseCache(NewMemoryCache())
+	ctx := context.Background()
+
+	q := Builder[User](db).From("users").Cache(time.Minute)
+	mock.ExpectQuery("SELECT \\* FROM users").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "email"}).AddRow("Alice", "a@example.com"))
+	_, err := q.FindAll(ctx)
+	require.NoError(t, err)
+
+	mock.ExpectExec("UPDATE users SET").WillReturnResult(sqlmock.NewResult(0, 1))
+	_, err = Builder[User](db).From("users").Select("name").Where("id = ?", 1).
+		Update(ctx, map[string]interface{}{"name": "Bob"})
+	require.NoError(t, err)
+
+	mock.ExpectQuery("SELECT \\* FROM users").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "email"}).AddRow("Bob", "a@example.com"))
+	users, err := q.FindAll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "Bob", users[0].Name)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestChain_Cache_InvalidatedAfterCommit(t *testing.T) {
+	db, mock := setupTestDB(t)
+	defer db.Close()
+	db.UseCache(NewMemoryCache())
+	ctx := context.Background()
+
+	q := Builder[User](db).From("users").Cache(time.Minute, "all")
+	mock.ExpectQuery("SELECT \\* FROM users").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "email"}).AddRow("Alice", "a@example.com"))
+	_, err := q.FindAll(ctx)
+	require.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE users SET").WillReturnResult(sqlmock.NewResult(0, 1))
+	// reads in the transaction bypass the cache
+	mock.ExpectQuery("SELECT \\* FROM users").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "email"}).AddRow("Bob", "a@example.com"))
+	mock.ExpectCommit()
+	err = db.RunTx(ctx, sql.LevelDefault, func(ctx context.Context, _ *sqlx.Tx) error {
+		_, err := Builder[User](db).From("users").Select("name").Where("id = ?", 1).
+			Update(ctx, map[string]interface{}{"name": "Bob"})
+		require.NoError(t, err)
+
+		// not invalidated before commit
+		users, err := q.FindAll(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "Bob", users[0].Name)
+		_, ok, _ := db.QueryCache().Get(ctx, "db:users:all")
+		assert.True(t, ok)
+		return nil
+	})
+	require.NoError(t, err)
+
+	_, ok, err := db.QueryCache().Get(ctx, "db:users:all")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestChain_Cache_KeptOnRollback(t *testing.T) {
+	db, mock := setupTestDB(t)
+	defer db.Close()
+	db.UseCache(NewMemoryCache())
+	ctx := context.Background()
+
+	q := Builder[User](db).From("users").Cache(time.Minute, "all")
+	mock.ExpectQuery("SELECT \\* FROM users").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "email"}).AddRow("Alice", "a@example.com"))
+	_, err := q.FindAll(ctx)
+	require.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE users SET").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectRollback()
+	err = db.RunTx(ctx, sql.LevelDefault, func(ctx context.Context, _ *sqlx.Tx) error {
+		_, err := Builder[User](db).From("users").Select("name").Where("id = ?", 1).
+			Update(ctx, map[string]interface{}{"name": "Bob"})
+		require.NoError(t, err)
+		return errors.New("abort")
+	})
+	require.Error(t, err)
+
+	users, err := q.FindAll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", users[0].Name)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestChain_Cache_NoCacheConfigured(t *testing.T) {
+	db, mock := setupTestDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		mock.ExpectQuery("SELECT \\* FROM users").
+			WillReturnRows(sqlmock.NewRows([]string{"name", "email"}).AddRow("Alice", "a@example.com"))
+	}
+
+	q := Builder[User](db).From("users").Cache(time.Minute)
+	for i := 0; i < 2; i++ {
+		_, err := q.FindAll(ctx)
+		require.NoError(t, err)
+	}
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMemoryCache(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemoryCache()
+
+	require.NoError(t, c.Set(ctx, "db:users:1", []byte("a"), time.Minute))
+	require.NoError(t, c.Set(ctx, "db:users:2", []byte("b"), time.Minute))
+	require.NoError(t, c.Set(ctx, "db:orders:1", []byte("c"), time.Minute))
+	require.NoError(t, c.Set(ctx, "db:tmp:1", []byte("d"), time.Millisecond))
+
+	v, ok, err := c.Get(ctx, "db:users:1")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "a", string(v))
+
+	time.Sleep(5 * time.Millisecond)
+	_, ok, _ = c.Get(ctx, "db:tmp:1")
+	assert.False(t, ok, "expired")
+
+	require.NoError(t, c.DeletePrefix(ctx, "db:users:"))
+	_, ok, _ = c.Get(ctx, "db:users:2")
+	assert.False(t, ok)
+	_, ok, _ = c.Get(ctx, "db:orders:1")
+	assert.True(t, ok)
+}
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/BevisDev/godev/utils"
+	"github.com/jmoiron/sqlx"
 )
 
 type Chain[T any] struct {
@@ -26,6 +28,16 @@ type Chain[T any] struct {
 	updates map[string]interface{}
 	inserts map[string]interface{}
 	values  []interface{}
+
+	cacheTTL   time.Duration // > 0 when Cache was called
+	cacheParts []string
+
+	tenantID  string // set by scoped, part of explicit cache keys
+	tenantCol string // set by scoped, never updated
+
+	explain bool // log the plan of First and FindAll, see Explain
+
+	search *searchSpec // full-text condition, see Search
 }
 
 // Builder creates a new query builder chain for type T.
@@ -62,6 +74,9 @@ func (d *Chain[T]) clone() *Chain[T] {
 	if len(d.values) > 0 {
 		c.values = append([]interface{}{}, d.values...)
 	}
+	if len(d.cacheParts) > 0 {
+		c.cacheParts = append([]string{}, d.cacheParts...)
+	}
 
 	if c.updates != nil {
 		c.updates = make(map[string]interface{}, len(d.updates))
@@ -117,6 +132,28 @@ func (d *Chain[T]) OrderBy(order string) ChainExec[T] {
 	return c
 }
 
+// Cache serves First and FindAll from the cache set by DB.UseCache for ttl.
+// The key is "db:<table>:<keyParts...>", or a hash of the query and args when
+// keyParts is empty. Without a cache on the DB the query always hits the database.
+func (d *Chain[T]) Cache(ttl time.Duration, keyParts ...string) ChainExec[T] {
+	c := d.clone()
+	c.cacheTTL = ttl
+	c.cacheParts = keyParts
+	return c
+}
+
+// Explain logs the plan of the query of First and FindAll (see DB.Explain)
+// before running it. Meant for debugging a slow query; remove it afterwards.
+func (d *Chain[T]) Explain() ChainExec[T] {
+	c := d.clone()
+	c.explain = true
+	return c
+}
+
+func (d *Chain[T]) useCache() bool {
+	return d.cache != nil && d.cacheTTL > 0
+}
+
 // ToSql builds and returns the SQL query string and arguments from the chain.
 func (d *Chain[T]) ToSql() (string, []interface{}) {
 	var sb strings.Builder
@@ -132,6 +169,19 @@ func (d *Chain[T]) ToSql() (string, []interface{}) {
 	if len(d.columns) > 0 {
 		cols = strings.Join(d.columns, ", ")
 	}
+
+	// full-text search: the rank args precede the where args
+	where, orders, args := d.where, d.orders, d.args
+	if d.search != nil {
+		cond, condArgs, rank, rankArgs := d.searchSQL(d.search)
+		where = append(where[:len(where):len(where)], cond)
+		args = append(args[:len(args):len(args)], condArgs...)
+		if d.search.rank != "" {
+			cols += ", " + rank + " AS " + d.search.rank
+			args = append(rankArgs, args...)
+			orders = append([]string{d.search.rank + " DESC"}, orders...)
+		}
+	}
 	sb.WriteString(cols)
 
 	// build table
@@ -139,26 +189,38 @@ func (d *Chain[T]) ToSql() (string, []interface{}) {
 	sb.WriteString(d.table)
 
 	// build where
-	if len(d.where) > 0 {
+	if len(where) > 0 {
 		sb.WriteString(" WHERE ")
-		sb.WriteString(strings.Join(d.where, " AND "))
+		sb.WriteString(strings.Join(where, " AND "))
 	}
 
 	// build order
-	if len(d.orders) > 0 {
+	if len(orders) > 0 {
 		sb.WriteString(" ORDER BY ")
-		sb.WriteString(strings.Join(d.orders, ", "))
+		sb.WriteString(strings.Join(orders, ", "))
 	}
 
 	// LIMIT/OFFSET
-	if d.cfg.DBType != SqlServer && d.limit > 0 {
-		sb.WriteString(fmt.Sprintf(" LIMIT %d", d.limit))
-	}
-	if d.cfg.DBType != SqlServer && d.offset > 0 {
-		sb.WriteString(fmt.Sprintf(" OFFSET %d", d.offset))
+	switch d.cfg.DBType {
+	case SqlServer:
+	case Oracle:
+		// 12c row limiting clause
+		if d.offset > 0 {
+			sb.WriteString(fmt.Sprintf(" OFFSET %d ROWS", d.offset))
+		}
+		if d.limit > 0 {
+			sb.WriteString(fmt.Sprintf(" FETCH NEXT %d ROWS ONLY", d.limit))
+		}
+	default:
+		if d.limit > 0 {
+			sb.WriteString(fmt.Sprintf(" LIMIT %d", d.limit))
+		}
+		if d.offset > 0 {
+			sb.WriteString(fmt.Sprintf(" OFFSET %d", d.offset))
+		}
 	}
 
-	return sb.String(), d.args
+	return expandSubqueries(sb.String(), args)
 }
 
 // ============================================================
@@ -168,6 +230,9 @@ func (d *Chain[T]) ToSql() (string, []interface{}) {
 func (d *Chain[T]) getAny(c context.Context) (*T, error) {
 	var obj T
 	query, args := d.ToSql()
+	if d.explain {
+		d.logPlan(c, query, args)
+	}
 
 	query, newArgs, err := d.rebind(query, args...)
 	if err != nil {
@@ -177,7 +242,7 @@ func (d *Chain[T]) getAny(c context.Context) (*T, error) {
 	ctx, cancel := utils.NewCtxTimeout(c, d.cfg.Timeout)
 	defer cancel()
 
-	db := d.GetDB()
+	db := d.conn(ctx)
 	if err := db.GetContext(ctx, &obj, query, newArgs...); err != nil {
 		return nil, err
 	}
@@ -185,6 +250,27 @@ func (d *Chain[T]) getAny(c context.Context) (*T, error) {
 }
 
 func (d *Chain[T]) First(c context.Context) (*T, error) {
+	q, err := d.scoped(c)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *T
+	if q.useCache() {
+		query, args := q.ToSql()
+		result, err = cached(c, q.DB, q.cacheKey(query, args), q.cacheTTL, func() (*T, error) {
+			return q.first(c)
+		})
+	} else {
+		result, err = q.first(c)
+	}
+	if err != nil || result == nil {
+		return result, err
+	}
+	return result, q.decrypt(result)
+}
+
+func (d *Chain[T]) first(c context.Context) (*T, error) {
 	result, err := d.getAny(c)
 	if err != nil {
 		if d.IsNoResult(err) {
@@ -197,8 +283,32 @@ func (d *Chain[T]) First(c context.Context) (*T, error) {
 }
 
 func (d *Chain[T]) FindAll(c context.Context) ([]*T, error) {
+	q, err := d.scoped(c)
+	if err != nil {
+		return nil, err
+	}
+
+	var list []*T
+	if q.useCache() {
+		query, args := q.ToSql()
+		list, err = cached(c, q.DB, q.cacheKey(query, args), q.cacheTTL, func() ([]*T, error) {
+			return q.findAll(c)
+		})
+	} else {
+		list, err = q.findAll(c)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return list, q.decrypt(&list)
+}
+
+func (d *Chain[T]) findAll(c context.Context) ([]*T, error) {
 	var list []*T
 	query, args := d.ToSql()
+	if d.explain {
+		d.logPlan(c, query, args)
+	}
 
 	query, newArgs, err := d.rebind(query, args...)
 	if err != nil {
@@ -208,18 +318,65 @@ func (d *Chain[T]) FindAll(c context.Context) ([]*T, error) {
 	ctx, cancel := utils.NewCtxTimeout(c, d.cfg.Timeout)
 	defer cancel()
 
-	db := d.GetDB()
+	db := d.conn(ctx)
 	if err = db.SelectContext(ctx, &list, query, newArgs...); err != nil {
 		return nil, err
 	}
 	return list, nil
 }
 
+func (d *Chain[T]) FindAllMaps(c context.Context) ([]map[string]any, error) {
+	q, err := d.scoped(c)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args := q.ToSql()
+	if q.explain {
+		q.logPlan(c, query, args)
+	}
+	return q.GetRows(c, query, args...)
+}
+
 // ============================================================
 // =============== INSERT / UPDATE / DELETE ===================
 // ============================================================
 
+// Insert runs the INSERT and, on success, invalidates the cached results of the table.
 func (d *Chain[T]) Insert(ctx context.Context, data any, outputs ...string) (*T, error) {
+	q := d
+	col, id, ok, err := d.tenantScope(ctx, d.table)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		q = d.clone()
+		if !containsFold(q.columns, col) {
+			q.columns = append(q.columns, col)
+		}
+		data = withTenantData(data, col, id)
+	}
+
+	data, err = q.encryptData(typeOf[T](), data)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := q.insert(ctx, data, outputs...)
+	if err != nil {
+		return nil, err
+	}
+	d.invalidateAfterWrite(ctx, d.table)
+	if out == nil {
+		return nil, nil
+	}
+	return out, d.decrypt(out)
+}
+
+func (d *Chain[T]) insert(c context.Context, data any, outputs ...string) (*T, error) {
+	ctx, cancel := utils.NewCtxTimeout(c, d.cfg.Timeout)
+	defer cancel()
+
 	if len(d.columns) == 0 {
 		return nil, ErrMissingSelect
 	}
@@ -263,14 +420,14 @@ func (d *Chain[T]) Insert(ctx context.Context, data any, outputs ...string) (*T,
 		d.ViewQuery(query)
 
 		if !hasOutput {
-			_, err := d.db.NamedExecContext(ctx, query, data)
+			_, err := d.conn(ctx).NamedExecContext(ctx, query, data)
 			if err != nil {
 				return nil, err
 			}
 			return nil, nil
 		}
 
-		res, err := d.db.NamedExecContext(ctx, query, data)
+		res, err := d.conn(ctx).NamedExecContext(ctx, query, data)
 		if err != nil {
 			return nil, err
 		}
@@ -282,7 +439,7 @@ func (d *Chain[T]) Insert(ctx context.Context, data any, outputs ...string) (*T,
 
 		if id > 0 {
 			q := fmt.Sprintf("SELECT * FROM %s WHERE id = ?", d.table)
-			if err := d.db.GetContext(ctx, &dest, q, id); err != nil {
+			if err := d.conn(ctx).GetContext(ctx, &dest, q, id); err != nil {
 				return nil, err
 			}
 		}
@@ -293,11 +450,11 @@ func (d *Chain[T]) Insert(ctx context.Context, data any, outputs ...string) (*T,
 
 	// handle dont have outputs
 	if !hasOutput {
-		_, err := d.db.NamedExecContext(ctx, query, data)
+		_, err := d.conn(ctx).NamedExecContext(ctx, query, data)
 		return nil, err
 	}
 
-	rows, err := d.db.NamedQueryContext(ctx, query, data)
+	rows, err := sqlx.NamedQueryContext(ctx, d.conn(ctx), query, data)
 	if err != nil {
 		return nil, err
 	}
@@ -310,7 +467,7 @@ func (d *Chain[T]) Insert(ctx context.Context, data any, outputs ...string) (*T,
 		return nil, nil
 	}
 
-	rt := reflect.TypeOf((*T)(nil)).Elem()
+	rt := typeOf[T]()
 	switch rt.Kind() {
 	case reflect.Struct:
 		if err := rows.StructScan(&dest); err != nil {
@@ -329,7 +486,90 @@ func (d *Chain[T]) Insert(ctx context.Context, data any, outputs ...string) (*T,
 	return &dest, nil
 }
 
+// Update runs the UPDATE and, on success, invalidates the cached results of the table
+// and notifies the hooks registered with DB.OnChange.
 func (d *Chain[T]) Update(ctx context.Context, fields map[string]interface{}) (int64, error) {
+	q, err := d.scopedWrite(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	fields, err = q.encryptMap(typeOf[T](), withoutColumn(fields, q.tenantCol))
+	if err != nil {
+		return 0, err
+	}
+
+	before, err := q.beforeWrite(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := q.update(ctx, fields)
+	if err == nil {
+		q.invalidateAfterWrite(ctx, q.table)
+		q.notifyChange(ctx, ChangeEvent{Table: q.table, Action: ActionUpdate, Before: before, Fields: fields})
+	}
+	return n, err
+}
+
+// UpdateWithVersion runs the UPDATE with optimistic locking: fields[versionCol] holds the
+// version the caller read, the row is only updated while versionCol still has that value,
+// and versionCol is incremented:
+//
+//	UPDATE t SET name = ?, version = version + 1 WHERE id = ? AND version = ?
+//
+// It returns ErrStaleObject when no row was updated, i.e. the row was changed or deleted
+// since it was read.
+func (d *Chain[T]) UpdateWithVersion(ctx context.Context, fields map[string]interface{}, versionCol string) (int64, error) {
+	version, ok := fields[versionCol]
+	if !ok || version == nil {
+		return 0, ErrMissingVersion
+	}
+	if len(d.where) == 0 {
+		return 0, ErrMissingWhere
+	}
+
+	set := make(map[string]interface{}, len(fields))
+	for k, v := range fields {
+		if k != versionCol {
+			set[k] = v
+		}
+	}
+
+	q, err := d.Where(versionCol+" = ?", version).(*Chain[T]).scopedWrite(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	set, err = q.encryptMap(typeOf[T](), withoutColumn(set, q.tenantCol))
+	if err != nil {
+		return 0, err
+	}
+
+	before, err := q.beforeWrite(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := q.update(ctx, set, fmt.Sprintf("%s = %s + 1", versionCol, versionCol))
+	if err != nil {
+		return 0, err
+	}
+	if n == 0 {
+		return 0, ErrStaleObject
+	}
+
+	q.invalidateAfterWrite(ctx, q.table)
+	q.notifyChange(ctx, ChangeEvent{Table: q.table, Action: ActionUpdate, Before: before, Fields: set})
+	return n, nil
+}
+
+// update builds "UPDATE ... SET <fields>, <exprs> WHERE ..."; exprs are raw assignments
+// such as "version = version + 1".
+func (d *Chain[T]) update(c context.Context, fields map[string]interface{}, exprs ...string) (int64, error) {
+	ctx, cancel := utils.NewCtxTimeout(c, d.cfg.Timeout)
+	defer cancel()
+
 	if len(d.columns) == 0 {
 		return 0, ErrMissingSelect
 	}
@@ -346,6 +586,10 @@ func (d *Chain[T]) Update(ctx context.Context, fields map[string]interface{}) (i
 		setParts = append(setParts, fmt.Sprintf("%s = ?", k))
 		args = append(args, v)
 	}
+	setParts = append(setParts, exprs...)
+	if len(setParts) == 0 {
+		return 0, ErrMissingData
+	}
 
 	query := fmt.Sprintf("UPDATE %s SET %s WHERE %s",
 		d.table,
@@ -361,20 +605,90 @@ func (d *Chain[T]) Update(ctx context.Context, fields map[string]interface{}) (i
 		return 0, err
 	}
 
-	res, err := d.db.ExecContext(ctx, query, args...)
+	res, err := d.conn(ctx).ExecContext(ctx, query, args...)
 	if err != nil {
 		return 0, err
 	}
 	return res.RowsAffected()
 }
 
+// Delete runs the DELETE and, on success, invalidates the cached results of the table
+// and notifies the hooks registered with DB.OnChange.
 func (d *Chain[T]) Delete(ctx context.Context) (int64, error) {
+	q, err := d.scopedWrite(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	before, err := q.beforeWrite(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := q.delete(ctx)
+	if err == nil {
+		q.invalidateAfterWrite(ctx, q.table)
+		q.notifyChange(ctx, ChangeEvent{Table: q.table, Action: ActionDelete, Before: before})
+	}
+	return n, err
+}
+
+// scoped returns the chain with the tenant condition of ctx added (see DB.ScopeTenant).
+func (d *Chain[T]) scoped(ctx context.Context) (*Chain[T], error) {
+	d, err := d.scopedArgs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	col, id, ok, err := d.tenantScope(ctx, d.table)
+	if err != nil || !ok {
+		return d, err
+	}
+	c := d.clone()
+	c.where = scopeWhere(c.where, c.table, col)
+	c.args = append(c.args, id)
+	c.tenantID = id
+	c.tenantCol = col
+	return c, nil
+}
+
+// scopedWrite is scoped for Update and Delete: without a Where of the caller the chain is
+// returned as is, so the write still fails with ErrMissingWhere instead of hitting every
+// row of the tenant. A Search condition is refused rather than dropped.
+func (d *Chain[T]) scopedWrite(ctx context.Context) (*Chain[T], error) {
+	if d.search != nil {
+		return nil, ErrSearchWrite
+	}
+	if len(d.where) == 0 {
+		return d, nil
+	}
+	return d.scoped(ctx)
+}
+
+// beforeWrite loads the rows about to change for the change hooks.
+func (d *Chain[T]) beforeWrite(ctx context.Context) ([]map[string]interface{}, error) {
+	if len(d.where) == 0 {
+		// the write itself reports ErrMissingWhere
+		return nil, nil
+	}
+	return d.loadBefore(ctx, d.table, d.where, d.args)
+}
+
+func (d *Chain[T]) delete(c context.Context) (int64, error) {
+	ctx, cancel := utils.NewCtxTimeout(c, d.cfg.Timeout)
+	defer cancel()
+
 	if len(d.where) == 0 {
 		return 0, ErrMissingWhere
 	}
 
 	query := fmt.Sprintf("DELETE FROM %s WHERE %s", d.table, strings.Join(d.where, " AND "))
-	res, err := d.db.ExecContext(ctx, query, d.args...)
+	query, args, err := d.rebind(query, d.args...)
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := d.conn(ctx).ExecContext(ctx, query, args...)
 	if err != nil {
 		return 0, err
 	}
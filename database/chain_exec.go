@@ -1,6 +1,9 @@
 package database
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type ChainExec[T any] interface {
 	// Select specifies the columns to retrieve.
@@ -10,6 +13,7 @@ type ChainExec[T any] interface {
 	From(table string) ChainExec[T]
 
 	// Where adds a WHERE condition with optional args.
+	// A Subquery arg is rendered in place of its placeholder, e.g. Where("id IN ?", sub).
 	Where(cond string, args ...interface{}) ChainExec[T]
 
 	Top(n int) ChainExec[T]
@@ -18,9 +22,29 @@ type ChainExec[T any] interface {
 
 	Offset(n int) ChainExec[T]
 
+	// WhereExists adds an EXISTS (subquery) condition, e.g. a correlated Builder chain.
+	WhereExists(sub Subquery) ChainExec[T]
+
+	// WhereNotExists adds a NOT EXISTS (subquery) condition.
+	WhereNotExists(sub Subquery) ChainExec[T]
+
+	// Search adds a full-text condition for term on cols, in the syntax of the database
+	// (LIKE where full-text search is not available); see WithSearchRank for relevance.
+	Search(cols []string, term string, opts ...SearchOption) ChainExec[T]
+
 	// OrderBy sets the ORDER BY clause.
 	OrderBy(order string) ChainExec[T]
 
+	// Cache serves First and FindAll from the DB query cache for ttl (see DB.UseCache).
+	// Writes through the chain invalidate the cached results of the table.
+	Cache(ttl time.Duration, keyParts ...string) ChainExec[T]
+
+	// Explain logs the plan of the query of First and FindAll before running it (see DB.Explain).
+	Explain() ChainExec[T]
+
+	// ToSql builds the SELECT query and its args; a chain is also a Subquery for Where.
+	ToSql() (string, []interface{})
+
 	// First executes a query and scans a single result into dest.
 	// Returns nil if no record is found.
 	First(ctx context.Context) (*T, error)
@@ -28,10 +52,18 @@ type ChainExec[T any] interface {
 	// FindAll executes the query and returns all results as a slice.
 	FindAll(ctx context.Context) ([]*T, error)
 
+	// FindAllMaps executes the query and returns the rows as column-value maps (see DB.GetRows),
+	// for dynamic column sets. Results are not cached and encrypted fields are not decrypted.
+	FindAllMaps(ctx context.Context) ([]map[string]any, error)
+
 	// Insert builds an INSERT statement with given columns and values.
 	Insert(ctx context.Context, data any, outputs ...string) (*T, error)
 
 	// Update builds and executes an UPDATE statement with given column-value pairs.
 	// Requires a WHERE clause to target rows, otherwise all rows will be updated.
 	Update(ctx context.Context, fields map[string]interface{}) (int64, error)
+
+	// UpdateWithVersion updates like Update, only if versionCol still holds fields[versionCol],
+	// and increments versionCol. Returns ErrStaleObject when no row matched.
+	UpdateWithVersion(ctx context.Context, fields map[string]interface{}, versionCol string) (int64, error)
 }
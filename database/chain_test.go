@@ -0,0 +1,48 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChain_UpdateWithVersion(t *testing.T) {
+	db, mock := setupTestDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	chain := Builder[User](db).From("users").Select("name").Where("id = ?", 1)
+
+	t.Run("updates and increments the version", func(t *testing.T) {
+		mock.ExpectExec("UPDATE users SET name = \\?, version = version \\+ 1 WHERE id = \\? AND version = \\?").
+			WithArgs("Bob", 1, 3).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		n, err := chain.UpdateWithVersion(ctx, map[string]interface{}{"name": "Bob", "version": 3}, "version")
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), n)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("stale version", func(t *testing.T) {
+		mock.ExpectExec("UPDATE users SET name = \\?, version = version \\+ 1 WHERE id = \\? AND version = \\?").
+			WithArgs("Bob", 1, 2).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		_, err := chain.UpdateWithVersion(ctx, map[string]interface{}{"name": "Bob", "version": 2}, "version")
+		assert.ErrorIs(t, err, ErrStaleObject)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("missing version or where", func(t *testing.T) {
+		_, err := chain.UpdateWithVersion(ctx, map[string]interface{}{"name": "Bob"}, "version")
+		assert.ErrorIs(t, err, ErrMissingVersion)
+
+		_, err = Builder[User](db).From("users").Select("name").
+			UpdateWithVersion(ctx, map[string]interface{}{"name": "Bob", "version": 1}, "version")
+		assert.ErrorIs(t, err, ErrMissingWhere)
+	})
+}
@@ -1,10 +1,6 @@
 package database
 
-import (
-	"fmt"
-	"net/url"
-	"time"
-)
+import "time"
 
 // Config defines the configuration for connecting to a SQL database.
 //
@@ -48,7 +44,49 @@ type Config struct {
 	// ShowQuery enables SQL query logging when set to true.
 	ShowQuery bool
 
-	// Params is an optional map of additional connection string parameters.
+	// KillOnCancel sends a KILL/cancel request for the running query to the server when
+	// its context is cancelled or times out, so it does not keep running after the client
+	// gave up (e.g. MySQL, whose driver only drops the connection).
+	// Applies to Exec, Get and Select outside transactions; supported for SqlServer,
+	// Postgres and MySQL. Each query then first reads the session ID of its connection.
+	KillOnCancel bool
+
+	// LazyConnect skips the ping of New: the connection is verified on first use,
+	// with ConnectRetries and ConnectBackoff, so a briefly unreachable database
+	// does not fail the boot.
+	LazyConnect bool
+
+	// ConnectRetries is the number of extra pings when the database is unreachable,
+	// at boot or on first use with LazyConnect. Default 0 (a single ping).
+	ConnectRetries int
+
+	// ConnectBackoff is the wait before the first retry, doubled after each one
+	// up to 30 seconds. Default 1 second.
+	ConnectBackoff time.Duration
+
+	// TxRetries is the number of times RunTx runs a transaction again after a
+	// deadlock or serialization failure. Default 0 (no retry).
+	TxRetries int
+
+	// DeadlockDiagnostics adds the lock information of the database (blocked
+	// sessions, or the latest InnoDB deadlock on MySQL) to the log entry of a
+	// deadlock or serialization failure in RunTx. It costs one query per conflict.
+	DeadlockDiagnostics bool
+
+	// TLS encrypts the connection: TLSDisable, TLSRequire or TLSVerify.
+	// Empty keeps the default of the driver (disabled for Postgres).
+	TLS TLSMode
+
+	// AppName identifies the application to the server (application_name,
+	// app name or program_name), shown in session and lock views.
+	AppName string
+
+	// ConnectTimeout bounds dialing and login of a new connection.
+	// Default 0 (the default of the driver).
+	ConnectTimeout time.Duration
+
+	// Params is an optional map of additional connection string parameters;
+	// they override the parameters built from the fields above.
 	Params map[string]string
 }
 
@@ -70,52 +108,14 @@ func (c *Config) clone() *Config {
 	if cc.MaxLifeTime <= 0 {
 		cc.MaxLifeTime = 3600 * time.Second
 	}
-	return &cc
-}
-
-func (c *Config) getDSN() string {
-	var template = c.DBType.ConnectionString()
-	if template == "" {
-		return ""
+	if cc.ConnectRetries < 0 {
+		cc.ConnectRetries = 0
 	}
-
-	var connStr string
-	switch c.DBType {
-	case SqlServer:
-		connStr = fmt.Sprintf(template,
-			c.Username, c.Password, c.Host, c.Port, c.DBName)
-		if len(c.Params) > 0 {
-			params := url.Values{}
-			for k, v := range c.Params {
-				params.Add(k, v)
-			}
-			connStr += "&" + params.Encode()
-		}
-	case Postgres:
-		connStr = fmt.Sprintf(template,
-			c.Username, c.Password, c.Host, c.Port, c.DBName)
-		if len(c.Params) > 0 {
-			params := url.Values{}
-			for k, v := range c.Params {
-				params.Add(k, v)
-			}
-			connStr += "&" + params.Encode()
-		}
-	case Oracle:
-		connStr = fmt.Sprintf(template,
-			c.Username, c.Password, c.Host, c.Port, c.DBName)
-	case MySQL:
-		connStr = fmt.Sprintf(template,
-			c.Username, c.Password, c.Host, c.Port, c.DBName)
-		if len(c.Params) > 0 {
-			params := url.Values{}
-			for k, v := range c.Params {
-				params.Add(k, v)
-			}
-			connStr += "?" + params.Encode()
-		}
-	default:
-		return connStr
+	if cc.TxRetries < 0 {
+		cc.TxRetries = 0
+	}
+	if cc.ConnectBackoff <= 0 {
+		cc.ConnectBackoff = time.Second
 	}
-	return connStr
+	return &cc
 }
@@ -8,9 +8,13 @@ import (
 	"log"
 	"runtime/debug"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/BevisDev/godev/errreport"
 	"github.com/BevisDev/godev/utils"
+	"github.com/BevisDev/godev/utils/crypto"
 	"github.com/BevisDev/godev/utils/validate"
 	"github.com/jmoiron/sqlx"
 )
@@ -20,6 +24,9 @@ const (
 	// To avoid hitting this hard limit, it's recommended to stay under 2000.
 	// This value is used to determine safe batch sizes for bulk operations
 	maxParams = 2000
+
+	// maxConnectBackoff caps the wait between connection retries.
+	maxConnectBackoff = 30 * time.Second
 )
 
 // DB represents a database connection along with configuration
@@ -28,15 +35,26 @@ const (
 // It embeds *Config to provide access to database configuration,
 // and maintains an internal sqlx.DB connection for executing queries.
 type DB struct {
-	cfg *Config
-	db  *sqlx.DB // db is the initialized sqlx.DB connection.
+	cfg   *Config
+	db    *sqlx.DB   // db is the initialized sqlx.DB connection.
+	cache QueryCache // cache stores Chain results, see UseCache.
+	hooks []ChangeHook
+
+	metrics  *dbMetrics          // query metrics, see UseMetrics.
+	reporter *errreport.Reporter // reports RunTx panics, see UseErrorReporter.
+
+	keyring *crypto.Keyring   // keyring seals encrypted fields, see UseEncryption.
+	tenants map[string]string // tenant column by scoped table, see ScopeTenant.
+
+	connected atomic.Bool // connection verified, see Connect.
+	connectMu sync.Mutex
 }
 
 // New creates a new DB instance from the given Config.
 //
 // It applies default values, initializes connection settings (pool, timeout),
 // connects to the appropriate database based on DBType (e.g., SQL Server, Postgres),
-// and performs a ping to verify connectivity.
+// and performs a ping to verify connectivity, unless LazyConnect is set.
 func New(cfg *Config) (*DB, error) {
 	if cfg == nil {
 		return nil, errors.New("[database] config is nil")
@@ -56,20 +74,32 @@ func New(cfg *Config) (*DB, error) {
 	return db, nil
 }
 
+// FromSqlx wraps an existing sqlx connection, e.g. one shared with other code or a sqlmock in tests.
+// Connection pool settings of cfg are not applied; the connection is assumed verified
+// unless LazyConnect is set.
+func FromSqlx(dbx *sqlx.DB, cfg *Config) (*DB, error) {
+	if cfg == nil {
+		return nil, errors.New("[database] config is nil")
+	}
+	db := &DB{cfg: cfg.clone(), db: dbx}
+	db.connected.Store(!db.cfg.LazyConnect)
+	return db, nil
+}
+
 // connect establishes a database connection using the configured settings.
 func (d *DB) connect() (*sqlx.DB, error) {
 	cfg := d.cfg
 
 	// Get connection string
-	connStr := cfg.getDSN()
-	if connStr == "" {
-		return nil, fmt.Errorf("[database] unsupported database type: %s", cfg.DBType.String())
+	connStr, err := BuildDSN(cfg)
+	if err != nil {
+		return nil, err
 	}
 
-	// Connect to database
-	db, err := sqlx.Connect(cfg.DBType.GetDriver(), connStr)
+	// Open the pool; connections are dialed on use
+	db, err := sqlx.Open(cfg.DBType.GetDriver(), connStr)
 	if err != nil {
-		return nil, fmt.Errorf("[database] failed to connect: %w", err)
+		return nil, fmt.Errorf("[database] failed to connect: %w", redactErr(cfg, err))
 	}
 
 	// Configure connection pool
@@ -77,17 +107,97 @@ func (d *DB) connect() (*sqlx.DB, error) {
 	db.SetMaxIdleConns(cfg.MaxIdleConns)
 	db.SetConnMaxIdleTime(cfg.MaxIdleTime)
 	db.SetConnMaxLifetime(cfg.MaxLifeTime)
+	d.db = db
+
+	if cfg.LazyConnect {
+		log.Printf("[database] %s will connect on first use", cfg.DBName)
+		return db, nil
+	}
 
 	// Verify connection
-	if err := db.Ping(); err != nil {
+	if err := d.Connect(context.Background()); err != nil {
 		_ = db.Close()
-		return nil, fmt.Errorf("[database] ping failed: %w", err)
+		d.db = nil
+		return nil, err
 	}
-
-	log.Printf("[database] connected to %s successfully", cfg.DBName)
 	return db, nil
 }
 
+// Connect pings the database until it answers, retrying ConnectRetries times with
+// exponential backoff from ConnectBackoff. It returns at once when the connection was
+// already verified. With LazyConnect it runs on first use.
+func (d *DB) Connect(ctx context.Context) error {
+	if d.connected.Load() {
+		return nil
+	}
+
+	d.connectMu.Lock()
+	defer d.connectMu.Unlock()
+	if d.connected.Load() {
+		return nil
+	}
+	if d.db == nil {
+		return errors.New("[database] connection is closed")
+	}
+
+	backoff := d.cfg.ConnectBackoff
+	for attempt := 0; ; attempt++ {
+		pingCtx, cancel := utils.NewCtxTimeout(ctx, d.cfg.Timeout)
+		err := d.db.PingContext(pingCtx)
+		cancel()
+		if err == nil {
+			break
+		}
+		err = redactErr(d.cfg, err)
+		if attempt >= d.cfg.ConnectRetries || ctx.Err() != nil {
+			return fmt.Errorf("[database] ping failed: %w", err)
+		}
+
+		log.Printf("[database] ping failed (attempt %d/%d), retrying in %s: %v",
+			attempt+1, d.cfg.ConnectRetries+1, backoff, err)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return fmt.Errorf("[database] ping failed: %w", ctx.Err())
+		}
+		backoff = min(backoff*2, maxConnectBackoff)
+	}
+
+	d.connected.Store(true)
+	log.Printf("[database] connected to %s successfully", d.cfg.DBName)
+	return nil
+}
+
+// WarmUp opens up to n pooled connections ahead of traffic (capped at MaxOpenConns and
+// MaxIdleConns), connecting first with LazyConnect. Idle connections are still closed
+// after MaxIdleTime.
+func (d *DB) WarmUp(ctx context.Context, n int) error {
+	if err := d.Connect(ctx); err != nil {
+		return err
+	}
+	n = min(n, d.cfg.MaxOpenConns, d.cfg.MaxIdleConns)
+
+	conns := make([]*sqlx.Conn, 0, n)
+	defer func() {
+		// back to the idle pool
+		for _, c := range conns {
+			_ = c.Close()
+		}
+	}()
+
+	for i := 0; i < n; i++ {
+		c, err := d.db.Connx(ctx)
+		if err != nil {
+			return fmt.Errorf("[database] warm up: %w", err)
+		}
+		conns = append(conns, c)
+		if err := c.PingContext(ctx); err != nil {
+			return fmt.Errorf("[database] warm up: %w", err)
+		}
+	}
+	return nil
+}
+
 // Ping verifies the database connection is still alive.
 func (d *DB) Ping() error {
 	if d.db == nil {
@@ -109,6 +219,11 @@ func (d *DB) GetDB() *sqlx.DB {
 	return d.db
 }
 
+// Type returns the configured database type.
+func (d *DB) Type() DBType {
+	return d.cfg.DBType
+}
+
 // SetTimeout sets the query timeout for database operations.
 func (d *DB) SetTimeout(t time.Duration) {
 	if t > 0 {
@@ -147,7 +262,7 @@ func (d *DB) GetTemplate(template TemplateJSON) string {
 }
 
 // FormatRow formats a parameter placeholder for the current database type.
-// For MySQL, returns "?"; for others, returns formatted placeholder with index (e.g., "$1", "@p1").
+// For MySQL, returns "?"; for others, returns formatted placeholder with index (e.g., "$1", "@p1", ":1").
 func (d *DB) FormatRow(idx int) string {
 	placeholder := d.cfg.DBType.GetPlaceHolder()
 	if d.cfg.DBType == MySQL {
@@ -159,6 +274,9 @@ func (d *DB) FormatRow(idx int) string {
 // rebind processes the query string and arguments for database-specific placeholder binding.
 // It handles IN clauses and rebinds placeholders according to the database type.
 func (d *DB) rebind(query string, args ...interface{}) (string, []interface{}, error) {
+	// Render subqueries (see Subquery) with their args
+	query, args = expandSubqueries(query, args)
+
 	// Handle IN clauses (expand slice arguments)
 	if strings.Contains(strings.ToUpper(query), "IN") {
 		var err error
@@ -176,13 +294,56 @@ func (d *DB) rebind(query string, args ...interface{}) (string, []interface{}, e
 	return query, args, nil
 }
 
+// UseErrorReporter reports panics recovered by RunTx to r, tagged with the
+// database name. The panic is still returned as an error.
+func (d *DB) UseErrorReporter(r *errreport.Reporter) {
+	d.reporter = r
+}
+
 // RunTx runs a function within a database transaction with the specified isolation level.
 //
 // It handles transaction lifecycle (begin, commit, rollback) and recovers from panics.
 // If the function returns an error or panics, the transaction is rolled back.
+//
+// The context passed to fn carries the transaction (see WithTx). When ctx
+// already carries one of d, fn joins it and level is ignored; a transaction of
+// another DB is not joined.
+//
+// A deadlock or serialization failure is logged with the failing statement (see
+// Config.DeadlockDiagnostics) and, with Config.TxRetries, the whole transaction
+// is run again; fn must then have no side effects outside the transaction.
 func (d *DB) RunTx(ctx context.Context, level sql.IsolationLevel,
 	fn func(ctx context.Context, tx *sqlx.Tx) error,
-) (err error) {
+) error {
+	if tx := d.TxFrom(ctx); tx != nil {
+		// join the transaction of the caller, which commits or rolls it back
+		return fn(ctx, tx)
+	}
+
+	for attempt := 0; ; attempt++ {
+		kind, err := d.runTx(ctx, level, fn, attempt)
+		if kind == "" {
+			return err
+		}
+		d.metrics.txConflict(d.cfg.DBName, kind)
+		if attempt >= d.cfg.TxRetries || ctx.Err() != nil {
+			return err
+		}
+
+		d.metrics.txRetry(d.cfg.DBName, kind)
+		select {
+		case <-time.After(txRetryDelay(attempt)):
+		case <-ctx.Done():
+			return err
+		}
+	}
+}
+
+// runTx runs one attempt of RunTx and returns the conflict (deadlock or
+// serialization) it failed with, or "".
+func (d *DB) runTx(ctx context.Context, level sql.IsolationLevel,
+	fn func(ctx context.Context, tx *sqlx.Tx) error, attempt int,
+) (kind string, err error) {
 	txCtx, cancel := utils.NewCtxTimeout(ctx, d.cfg.Timeout)
 	defer cancel()
 
@@ -191,26 +352,46 @@ func (d *DB) RunTx(ctx context.Context, level sql.IsolationLevel,
 		Isolation: level,
 	})
 	if beginErr != nil {
-		return fmt.Errorf("[database] failed to begin transaction: %w", beginErr)
+		return "", fmt.Errorf("[database] failed to begin transaction: %w", beginErr)
 	}
 
+	start := time.Now()
+	trace := &txTrace{}
+	state := &txState{tx: tx, managed: true}
 	defer func() {
 		if p := recover(); p != nil {
 			_ = tx.Rollback()
+			d.metrics.tx(d.cfg.DBName, time.Since(start), false)
+			d.reporter.CapturePanic(ctx, p, errreport.Tag("db", d.cfg.DBName))
 			err = fmt.Errorf("[database] panic recovered in transaction: %v\n%s", p, debug.Stack())
 			return
 		}
-		if err != nil {
+		if err == nil {
+			if commitErr := tx.Commit(); commitErr != nil {
+				err = fmt.Errorf("[database] failed to commit transaction: %w", commitErr)
+			} else {
+				state.committed()
+			}
+		} else {
 			_ = tx.Rollback()
-			return
 		}
-		if commitErr := tx.Commit(); commitErr != nil {
-			err = fmt.Errorf("[database] failed to commit transaction: %w", commitErr)
+		d.metrics.tx(d.cfg.DBName, time.Since(start), err == nil)
+
+		if kind = conflictKind(err); kind != "" {
+			d.logConflict(ctx, &txConflict{
+				DB:        d.cfg.DBName,
+				Kind:      kind,
+				Attempt:   attempt + 1,
+				Isolation: level.String(),
+				Duration:  time.Since(start).String(),
+				Statement: trace.statement(),
+				Error:     err.Error(),
+			})
 		}
 	}()
 
-	err = fn(txCtx, tx)
-	return err
+	err = fn(withTxTrace(context.WithValue(txCtx, txKey{db: d}, state), trace), tx)
+	return "", err
 }
 
 // GetList executes a query and scans all resulting rows into dest.
@@ -230,7 +411,7 @@ func (d *DB) GetList(c context.Context, dest interface{}, query string, args ...
 	ctx, cancel := utils.NewCtxTimeout(c, d.cfg.Timeout)
 	defer cancel()
 
-	db := d.GetDB()
+	db := d.conn(ctx)
 	if validate.IsNilOrEmpty(newArgs) {
 		return db.SelectContext(ctx, dest, query)
 	}
@@ -255,7 +436,7 @@ func (d *DB) GetAny(c context.Context, dest interface{}, query string, args ...i
 	ctx, cancel := utils.NewCtxTimeout(c, d.cfg.Timeout)
 	defer cancel()
 
-	db := d.GetDB()
+	db := d.conn(ctx)
 	if validate.IsNilOrEmpty(newArgs) {
 		return db.GetContext(ctx, dest, query)
 	}
@@ -264,18 +445,26 @@ func (d *DB) GetAny(c context.Context, dest interface{}, query string, args ...i
 
 // Execute runs the given SQL query with optional arguments.
 // If a transaction is provided, the query runs within it.
-// Otherwise, it executes directly on the database connection.
+// Otherwise, it runs in the transaction of ctx (WithTx) or on the database connection.
 func (d *DB) Execute(ctx context.Context, query string, tx *sqlx.Tx, args ...interface{}) error {
+	_, err := d.ExecuteResult(ctx, query, tx, args...)
+	return err
+}
+
+// ExecuteResult is like Execute but returns the sql.Result, to read
+// RowsAffected or LastInsertId.
+func (d *DB) ExecuteResult(c context.Context, query string, tx *sqlx.Tx, args ...interface{}) (sql.Result, error) {
 	d.ViewQuery(query)
 
+	ctx, cancel := utils.NewCtxTimeout(c, d.cfg.Timeout)
+	defer cancel()
+
 	if tx != nil {
-		_, err := tx.ExecContext(ctx, query, args...)
-		return err
+		return d.txConn(tx).ExecContext(ctx, query, args...)
 	}
 
-	db := d.GetDB()
-	_, err := db.ExecContext(ctx, query, args...)
-	return err
+	db := d.conn(ctx)
+	return db.ExecContext(ctx, query, args...)
 }
 
 // ExecuteTx runs the query in a new transaction with default isolation level.
@@ -297,10 +486,13 @@ func (d *DB) ExecuteSafe(ctx context.Context, query string, args ...interface{})
 // ExecReturningId executes a query that returns a single auto-generated ID.
 //
 // Returns the generated ID and any error encountered.
-func (d *DB) ExecReturningId(ctx context.Context, query string, args ...interface{}) (int, error) {
+func (d *DB) ExecReturningId(c context.Context, query string, args ...interface{}) (int, error) {
 	d.ViewQuery(query)
 
-	db := d.GetDB()
+	ctx, cancel := utils.NewCtxTimeout(c, d.cfg.Timeout)
+	defer cancel()
+
+	db := d.conn(ctx)
 	var id int
 	err := db.QueryRowxContext(ctx, query, args...).Scan(&id)
 	if err != nil {
@@ -311,7 +503,7 @@ func (d *DB) ExecReturningId(ctx context.Context, query string, args ...interfac
 
 // Prepare creates a prepared statement for later execution.
 func (d *DB) Prepare(ctx context.Context, query string) (*sqlx.Stmt, error) {
-	db := d.GetDB()
+	db := d.conn(ctx)
 	stmt, err := db.PreparexContext(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("[database] failed to prepare statement: %w", err)
@@ -322,20 +514,28 @@ func (d *DB) Prepare(ctx context.Context, query string) (*sqlx.Stmt, error) {
 // Save executes a query with named parameters.
 //
 // The query should use named placeholders (e.g., :name).
-// If tx is nil, the query is executed using the default connection;
-// otherwise, it is executed within the provided transaction.
+// If tx is nil, the query is executed in the transaction of ctx (WithTx)
+// or on the default connection; otherwise, it is executed within the provided transaction.
 //
 // Returns any error encountered during execution.
 func (d *DB) Save(ctx context.Context, tx *sqlx.Tx, query string, args interface{}) (err error) {
+	_, err = d.SaveResult(ctx, tx, query, args)
+	return
+}
+
+// SaveResult is like Save but returns the sql.Result, to read
+// RowsAffected or LastInsertId.
+func (d *DB) SaveResult(c context.Context, tx *sqlx.Tx, query string, args interface{}) (sql.Result, error) {
 	d.ViewQuery(query)
 
+	ctx, cancel := utils.NewCtxTimeout(c, d.cfg.Timeout)
+	defer cancel()
+
 	if tx == nil {
-		db := d.GetDB()
-		_, err = db.NamedExecContext(ctx, query, args)
-	} else {
-		_, err = tx.NamedExecContext(ctx, query, args)
+		db := d.conn(ctx)
+		return db.NamedExecContext(ctx, query, args)
 	}
-	return
+	return d.txConn(tx).NamedExecContext(ctx, query, args)
 }
 
 // InsertOrUpdate executes an SQL statement to insert a new record or update an existing one.
@@ -424,7 +624,7 @@ func (d *DB) InsertReturning(c context.Context, query string, dest interface{},
 	ctx, cancel := utils.NewCtxTimeout(c, d.cfg.Timeout)
 	defer cancel()
 
-	db := d.GetDB()
+	db := d.conn(ctx)
 	row := db.QueryRowxContext(ctx, query, args...)
 
 	switch dest.(type) {
@@ -439,6 +639,8 @@ func (d *DB) InsertReturning(c context.Context, query string, dest interface{},
 //
 // It builds placeholders dynamically and executes the insert in a single query.
 // Automatically batches large inserts to avoid parameter limits.
+// On Oracle, which has no multi-row VALUES, the rows are sent with array binds
+// (one column slice per placeholder), see insertArrayBind.
 //
 // Params:
 //   - table:     Table name.
@@ -467,6 +669,9 @@ func (d *DB) InsertBulk(ctx context.Context,
 	}
 
 	return d.RunTx(ctx, sql.LevelDefault, func(ctx context.Context, tx *sqlx.Tx) error {
+		if d.cfg.DBType == Oracle {
+			return d.insertArrayBind(ctx, tx, table, colNames, row, args)
+		}
 		if len(args) > maxParams {
 			batchRow := maxParams / col
 			for start := 0; start < row; start += batchRow {
@@ -547,7 +752,7 @@ func (d *DB) InsertMany(ctx context.Context, query string, entities []interface{
 
 			batch := entities[i:end]
 			for _, e := range batch {
-				_, err := tx.NamedExecContext(ctx, query, e)
+				_, err := d.txConn(tx).NamedExecContext(ctx, query, e)
 				if err != nil {
 					return err
 				}
@@ -560,40 +765,54 @@ func (d *DB) InsertMany(ctx context.Context, query string, entities []interface{
 // Delete runs a delete query within a transaction using default isolation level.
 //
 // The query should use named parameters matching the fields in args.
-func (d *DB) Delete(ctx context.Context, query string, args interface{}) (err error) {
-	return d.RunTx(ctx, sql.LevelDefault, func(ctx context.Context, tx *sqlx.Tx) error {
-		return d.Save(ctx, tx, query, args)
+// Returns the number of deleted rows.
+func (d *DB) Delete(ctx context.Context, query string, args interface{}) (rows int64, err error) {
+	err = d.RunTx(ctx, sql.LevelDefault, func(ctx context.Context, tx *sqlx.Tx) error {
+		res, err := d.SaveResult(ctx, tx, query, args)
+		if err != nil {
+			return err
+		}
+		rows, err = res.RowsAffected()
+		return err
 	})
+	return
 }
 
 // UpdateMany executes the same update query for multiple entities,
 // each with its own named parameters, inside a single transaction.
 //
-// Uses default isolation level.
-func (d *DB) UpdateMany(ctx context.Context, query string, entities []interface{}) (err error) {
-	return d.RunTx(ctx, sql.LevelDefault, func(ctx context.Context, tx *sqlx.Tx) error {
-		d.ViewQuery(query)
-		for _, e := range entities {
-			_, err = tx.NamedExecContext(ctx, query, e)
-			if err != nil {
-				return err
-			}
-		}
-		return nil
-	})
+// Uses default isolation level. Returns the total number of affected rows,
+// so callers can detect no-op updates.
+func (d *DB) UpdateMany(ctx context.Context, query string, entities []interface{}) (int64, error) {
+	return d.updateMany(ctx, sql.LevelDefault, query, entities)
 }
 
 // UpdateManySafe is like UpdateMany but runs with serializable isolation level,
 // ensuring maximum safety in concurrent environments.
-func (d *DB) UpdateManySafe(ctx context.Context, query string, entities []interface{}) (err error) {
-	return d.RunTx(ctx, sql.LevelSerializable, func(ctx context.Context, tx *sqlx.Tx) error {
+func (d *DB) UpdateManySafe(ctx context.Context, query string, entities []interface{}) (int64, error) {
+	return d.updateMany(ctx, sql.LevelSerializable, query, entities)
+}
+
+func (d *DB) updateMany(ctx context.Context, level sql.IsolationLevel,
+	query string, entities []interface{},
+) (rows int64, err error) {
+	err = d.RunTx(ctx, level, func(ctx context.Context, tx *sqlx.Tx) error {
 		d.ViewQuery(query)
 		for _, e := range entities {
-			_, err = tx.NamedExecContext(ctx, query, e)
+			res, err := d.txConn(tx).NamedExecContext(ctx, query, e)
 			if err != nil {
 				return err
 			}
+			n, err := res.RowsAffected()
+			if err != nil {
+				return err
+			}
+			rows += n
 		}
 		return nil
 	})
+	if err != nil {
+		return 0, err
+	}
+	return rows, nil
 }
@@ -74,6 +74,83 @@ func TestDatabase_Ping(t *testing.T) {
 	})
 }
 
+func newLazyTestDB(t *testing.T, retries int) (*DB, sqlmock.Sqlmock) {
+	t.Helper()
+
+	sqlDB, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	db, err := FromSqlx(sqlx.NewDb(sqlDB, "sqlmock"), &Config{
+		DBType:         SqlServer,
+		Timeout:        time.Second,
+		LazyConnect:    true,
+		ConnectRetries: retries,
+		ConnectBackoff: time.Millisecond,
+	})
+	require.NoError(t, err)
+	return db, mock
+}
+
+func TestDatabase_LazyConnect_RetriesOnFirstUse(t *testing.T) {
+	db, mock := newLazyTestDB(t, 2)
+
+	mock.ExpectPing().WillReturnError(errors.New("dial tcp: i/o timeout"))
+	mock.ExpectPing().WillReturnError(errors.New("dial tcp: i/o timeout"))
+	mock.ExpectPing()
+	mock.ExpectExec("UPDATE users").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE users").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	ctx := context.Background()
+	require.NoError(t, db.Execute(ctx, "UPDATE users SET name = 'a'", nil))
+	// verified once: no more pings
+	require.NoError(t, db.Execute(ctx, "UPDATE users SET name = 'b'", nil))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatabase_Connect_GivesUp(t *testing.T) {
+	db, mock := newLazyTestDB(t, 1)
+
+	mock.ExpectPing().WillReturnError(errors.New("connection refused"))
+	mock.ExpectPing().WillReturnError(errors.New("connection refused"))
+
+	err := db.Connect(context.Background())
+	assert.ErrorContains(t, err, "connection refused")
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// retried on next use
+	mock.ExpectPing()
+	require.NoError(t, db.Connect(context.Background()))
+	require.NoError(t, db.Connect(context.Background()))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatabase_Connect_ContextCancelled(t *testing.T) {
+	db, mock := newLazyTestDB(t, 10)
+	db.cfg.ConnectBackoff = time.Hour
+
+	mock.ExpectPing().WillReturnError(errors.New("connection refused"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, db.Connect(ctx), context.DeadlineExceeded)
+}
+
+func TestDatabase_WarmUp(t *testing.T) {
+	db, mock := newLazyTestDB(t, 0)
+	db.cfg.MaxOpenConns, db.cfg.MaxIdleConns = 10, 3
+	db.GetDB().SetMaxIdleConns(3) // FromSqlx does not apply the pool settings
+
+	mock.ExpectPing()
+	for i := 0; i < 3; i++ {
+		mock.ExpectPing()
+	}
+
+	require.NoError(t, db.WarmUp(context.Background(), 5))
+	assert.Equal(t, 3, db.GetDB().Stats().Idle)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestDatabase_Close(t *testing.T) {
 	db, _ := setupTestDB(t)
 
@@ -177,6 +254,7 @@ func TestDatabase_FormatRow(t *testing.T) {
 		{"Postgres", Postgres, 10, "$10"},
 		{"MySQL", MySQL, 1, "?"},
 		{"MySQL", MySQL, 5, "?"},
+		{"Oracle", Oracle, 3, ":3"},
 	}
 
 	for _, tt := range tests {
@@ -204,10 +282,14 @@ func TestDatabase_GetTemplate(t *testing.T) {
 		assert.Contains(t, db.GetTemplate(TemplateJSONArray), "JSON_ARRAYAGG")
 		assert.Contains(t, db.GetTemplate(TemplateJSONObject), "JSON_OBJECT")
 	})
-	t.Run("Oracle_unknown", func(t *testing.T) {
+	t.Run("Oracle", func(t *testing.T) {
 		db := &DB{cfg: &Config{DBType: Oracle}}
+		assert.Contains(t, db.GetTemplate(TemplateJSONArray), "JSON_ARRAYAGG")
+		assert.Contains(t, db.GetTemplate(TemplateJSONObject), "JSON_OBJECT(t.*")
+	})
+	t.Run("unknown", func(t *testing.T) {
+		db := &DB{cfg: &Config{}}
 		assert.Empty(t, db.GetTemplate(TemplateJSONArray))
-		assert.Empty(t, db.GetTemplate(TemplateJSONObject))
 	})
 }
 
@@ -771,8 +853,9 @@ func TestDatabase_UpdateMany(t *testing.T) {
 		WillReturnResult(sqlmock.NewResult(0, 1))
 	mock.ExpectCommit()
 
-	err := db.UpdateMany(ctx, query, entities)
+	rows, err := db.UpdateMany(ctx, query, entities)
 	assert.NoError(t, err)
+	assert.Equal(t, int64(2), rows)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -792,8 +875,9 @@ func TestDatabase_UpdateManySafe(t *testing.T) {
 		WillReturnResult(sqlmock.NewResult(0, 1))
 	mock.ExpectCommit()
 
-	err := db.UpdateManySafe(ctx, query, entities)
+	rows, err := db.UpdateManySafe(ctx, query, entities)
 	assert.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -806,17 +890,61 @@ func TestDatabase_UpdateMany_Empty(t *testing.T) {
 
 	mock.ExpectBegin()
 	mock.ExpectCommit()
-	err := db.UpdateMany(ctx, query, nil)
+	rows, err := db.UpdateMany(ctx, query, nil)
 	assert.NoError(t, err)
+	assert.Zero(t, rows)
 	assert.NoError(t, mock.ExpectationsWereMet())
 
 	mock.ExpectBegin()
 	mock.ExpectCommit()
-	err = db.UpdateMany(ctx, query, []interface{}{})
+	_, err = db.UpdateMany(ctx, query, []interface{}{})
 	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestDatabase_DeleteRows(t *testing.T) {
+	db, mock := setupTestDB(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM users WHERE status = ?")).
+		WithArgs("inactive").
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+
+	rows, err := db.Delete(context.Background(), "DELETE FROM users WHERE status = :status",
+		map[string]interface{}{"status": "inactive"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), rows)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatabase_ExecuteResult(t *testing.T) {
+	db, mock := setupTestDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE users SET name = ? WHERE id = ?")).
+		WithArgs("Bob", 1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	res, err := db.ExecuteResult(ctx, "UPDATE users SET name = ? WHERE id = ?", nil, "Bob", 1)
+	require.NoError(t, err)
+	n, _ := res.RowsAffected()
+	assert.Zero(t, n)
+
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO users (name) VALUES (?)")).
+		WithArgs("Alice").
+		WillReturnResult(sqlmock.NewResult(42, 1))
+
+	res, err = db.SaveResult(ctx, nil, "INSERT INTO users (name) VALUES (:name)",
+		map[string]interface{}{"name": "Alice"})
+	require.NoError(t, err)
+	id, _ := res.LastInsertId()
+	assert.Equal(t, int64(42), id)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestDatabase_Prepare(t *testing.T) {
 	db, mock := setupTestDB(t)
 	defer db.Close()
@@ -1,5 +1,7 @@
 package database
 
+import "fmt"
+
 type DBType int
 
 // type db
@@ -25,6 +27,10 @@ func (d DBType) String() string {
 	}
 }
 
+// ConnectionString returns the printf template of the connection string.
+//
+// Deprecated: use BuildDSN, which escapes credentials and applies TLS,
+// AppName, ConnectTimeout and Params.
 func (d DBType) ConnectionString() string {
 	switch d {
 	case SqlServer:
@@ -71,7 +77,66 @@ func (d DBType) GetPlaceHolder() string {
 		return "@p"
 	case Postgres:
 		return "$"
+	case Oracle:
+		return ":"
 	default: // mysql
 		return "?"
 	}
 }
+
+// SessionIDQuery returns the query reading the server session ID of the connection,
+// or "" when the database has no supported way to cancel a running query.
+func (d DBType) SessionIDQuery() string {
+	switch d {
+	case SqlServer:
+		return "SELECT @@SPID"
+	case Postgres:
+		return "SELECT pg_backend_pid()"
+	case MySQL:
+		return "SELECT CONNECTION_ID()"
+	default:
+		return ""
+	}
+}
+
+// KillQuery returns the statement cancelling the query running in session id.
+// SQL Server has no per-query cancel, so its session is killed.
+func (d DBType) KillQuery(id int64) string {
+	switch d {
+	case SqlServer:
+		return fmt.Sprintf("KILL %d", id)
+	case Postgres:
+		return fmt.Sprintf("SELECT pg_cancel_backend(%d)", id)
+	case MySQL:
+		return fmt.Sprintf("KILL QUERY %d", id)
+	default:
+		return ""
+	}
+}
+
+// LockInfoQuery returns the query reading the lock information logged with
+// Config.DeadlockDiagnostics: blocked sessions and their blockers, or the
+// InnoDB status on MySQL.
+func (d DBType) LockInfoQuery() string {
+	switch d {
+	case SqlServer:
+		return `SELECT r.session_id, r.blocking_session_id, r.wait_type, r.wait_resource, r.wait_time,
+	SUBSTRING(t.text, 1, 500) AS statement
+FROM sys.dm_exec_requests r
+CROSS APPLY sys.dm_exec_sql_text(r.sql_handle) t
+WHERE r.blocking_session_id <> 0`
+	case Postgres:
+		return `SELECT a.pid, pg_blocking_pids(a.pid)::text AS blocked_by, a.wait_event_type, a.wait_event,
+	a.state, now() - a.xact_start AS xact_age, LEFT(a.query, 500) AS statement
+FROM pg_stat_activity a
+WHERE cardinality(pg_blocking_pids(a.pid)) > 0`
+	case Oracle:
+		return `SELECT s.sid, s.blocking_session, s.event, s.seconds_in_wait, s.sql_id
+FROM v$session s
+WHERE s.blocking_session IS NOT NULL`
+	case MySQL:
+		return "SHOW ENGINE INNODB STATUS"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,192 @@
+// Package dbtest is a database harness for tests: a sqlmock or real database per test,
+// transactions rolled back after the test, throwaway schemas and YAML/JSON fixtures.
+//
+//	func TestOrders(t *testing.T) {
+//		db := dbtest.NewTestDatabase(t, dbtest.RequireReal())
+//		ctx := db.Tx(context.Background()) // rolled back when the test ends
+//		db.LoadFixtures(ctx, "testdata/orders.yaml")
+//		...
+//	}
+package dbtest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/BevisDev/godev/database"
+	"github.com/BevisDev/godev/utils/random"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+)
+
+const mockTimeout = 5 * time.Second
+
+// TestDB is a database for one test, closed when the test ends.
+type TestDB struct {
+	*database.DB
+
+	// Mock is set when the database is a sqlmock; its expectations are checked on cleanup.
+	Mock sqlmock.Sqlmock
+
+	t testing.TB
+}
+
+// NewTestDatabase returns a real database when one is configured (WithConfig or the
+// DBTEST_* variables), otherwise a sqlmock database (see RequireReal to skip instead).
+func NewTestDatabase(t testing.TB, opts ...Option) *TestDB {
+	t.Helper()
+
+	o := withDefaults()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	cfg := o.config
+	if cfg == nil {
+		var err error
+		if cfg, err = configFromEnv(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if cfg == nil {
+		if o.real {
+			t.Skipf("dbtest: no database configured, set %s", EnvType)
+		}
+		return newMock(t, o)
+	}
+
+	if o.isolated {
+		cfg = isolate(t, cfg)
+	}
+
+	db, err := database.New(cfg)
+	if err != nil {
+		t.Fatalf("dbtest: connect: %v", err)
+	}
+	t.Cleanup(db.Close)
+
+	return &TestDB{DB: db, t: t}
+}
+
+// NewMock returns a sqlmock database. Queries keep "?" placeholders, whatever the dialect.
+func NewMock(t testing.TB, opts ...Option) *TestDB {
+	t.Helper()
+
+	o := withDefaults()
+	for _, opt := range opts {
+		opt(o)
+	}
+	return newMock(t, o)
+}
+
+func newMock(t testing.TB, o *options) *TestDB {
+	t.Helper()
+
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("dbtest: sqlmock: %v", err)
+	}
+
+	db, err := database.FromSqlx(sqlx.NewDb(sqlDB, "sqlmock"), &database.Config{
+		DBType:  o.dbType,
+		Timeout: mockTimeout,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("dbtest: %v", err)
+		}
+		db.Close()
+	})
+
+	return &TestDB{DB: db, Mock: mock, t: t}
+}
+
+// IsMock reports whether the database is a sqlmock.
+func (d *TestDB) IsMock() bool {
+	return d.Mock != nil
+}
+
+// Tx begins a transaction rolled back when the test ends and returns ctx carrying it,
+// so Builder, Model, Repository and Execute calls with that context never persist.
+// With sqlmock, expect it with Mock.ExpectBegin and Mock.ExpectRollback.
+func (d *TestDB) Tx(ctx context.Context) context.Context {
+	d.t.Helper()
+
+	tx, err := d.GetDB().BeginTxx(ctx, nil)
+	if err != nil {
+		d.t.Fatalf("dbtest: begin: %v", err)
+	}
+	d.t.Cleanup(func() { _ = tx.Rollback() })
+
+	return d.WithTx(ctx, tx)
+}
+
+// LoadFixtures loads fixture files into the database, failing the test on error.
+// With sqlmock the tables are inserted in file order, since foreign keys cannot be read.
+func (d *TestDB) LoadFixtures(ctx context.Context, paths ...string) {
+	d.t.Helper()
+
+	fixtures, err := readFixtures(paths...)
+	if err == nil {
+		if !d.IsMock() {
+			err = orderByForeignKeys(ctx, d.DB, fixtures)
+		}
+		if err == nil {
+			err = insertFixtures(ctx, d.DB, fixtures)
+		}
+	}
+	if err != nil {
+		d.t.Fatalf("dbtest: fixtures: %v", err)
+	}
+}
+
+// isolate creates a throwaway schema (Postgres) or database (MySQL), dropped on cleanup,
+// and returns cfg connecting to it.
+func isolate(t testing.TB, cfg *database.Config) *database.Config {
+	t.Helper()
+
+	admin, err := database.New(cfg)
+	if err != nil {
+		t.Fatalf("dbtest: connect: %v", err)
+	}
+
+	name := "test_" + random.NewLowerString(10)
+	isolated := *cfg
+
+	var create, drop string
+	switch cfg.DBType {
+	case database.Postgres:
+		create, drop = "CREATE SCHEMA "+name, "DROP SCHEMA "+name+" CASCADE"
+		isolated.Params = map[string]string{"search_path": name}
+		for k, v := range cfg.Params {
+			if k != "search_path" {
+				isolated.Params[k] = v
+			}
+		}
+	case database.MySQL:
+		create, drop = "CREATE DATABASE "+name, "DROP DATABASE "+name
+		isolated.DBName = name
+	default:
+		admin.Close()
+		t.Fatal(ErrIsolationType)
+	}
+
+	ctx := context.Background()
+	if err := admin.Execute(ctx, create, nil); err != nil {
+		admin.Close()
+		t.Fatalf("dbtest: %s: %v", create, err)
+	}
+	t.Cleanup(func() {
+		if err := admin.Execute(ctx, drop, nil); err != nil {
+			t.Errorf("dbtest: %s: %v", drop, err)
+		}
+		admin.Close()
+	})
+
+	return &isolated
+}
@@ -0,0 +1,51 @@
+package dbtest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/BevisDev/godev/database"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTestDatabase_FallsBackToMock(t *testing.T) {
+	t.Setenv(EnvType, "")
+
+	db := NewTestDatabase(t, WithDBType(database.MySQL))
+	assert.True(t, db.IsMock())
+	assert.NotNil(t, db.GetDB())
+}
+
+func TestNewTestDatabase_UnknownType(t *testing.T) {
+	t.Setenv(EnvType, "sqlite")
+
+	_, err := configFromEnv()
+	assert.ErrorIs(t, err, ErrUnknownDBType)
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv(EnvType, "Postgres")
+	t.Setenv(EnvHost, "localhost")
+	t.Setenv(EnvPort, "5432")
+	t.Setenv(EnvName, "app")
+
+	cfg, err := configFromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, database.Postgres, cfg.DBType)
+	assert.Equal(t, "localhost", cfg.Host)
+	assert.Equal(t, 5432, cfg.Port)
+	assert.Equal(t, "app", cfg.DBName)
+}
+
+func TestTestDB_Tx(t *testing.T) {
+	db := NewMock(t)
+	db.Mock.ExpectBegin()
+	db.Mock.ExpectExec("DELETE FROM users").WillReturnResult(sqlmock.NewResult(0, 1))
+	db.Mock.ExpectRollback()
+
+	// the rollback runs on cleanup, before the expectations are checked
+	ctx := db.Tx(context.Background())
+	require.NoError(t, db.Execute(ctx, "DELETE FROM users", nil))
+}
@@ -0,0 +1,10 @@
+package dbtest
+
+import "errors"
+
+var (
+	ErrUnknownDBType  = errors.New("[dbtest] unknown DBTEST_TYPE, use postgres, mysql, sqlserver or oracle")
+	ErrIsolationType  = errors.New("[dbtest] isolated schema is only supported for Postgres and MySQL")
+	ErrFixtureFormat  = errors.New("[dbtest] fixture must map table names to lists of rows")
+	ErrFixtureFKCycle = errors.New("[dbtest] foreign keys between fixture tables form a cycle")
+)
@@ -0,0 +1,186 @@
+package dbtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/BevisDev/godev/database"
+	"go.yaml.in/yaml/v3"
+)
+
+// fixtureTable holds the rows of one table; tables keep the order of the files.
+type fixtureTable struct {
+	name string
+	rows []map[string]any
+}
+
+// LoadFixtures inserts the rows of fixture files (YAML or JSON) into db, parents before
+// children according to the foreign keys of the database, otherwise in file order:
+//
+//	users:
+//	  - id: 1
+//	    name: Alice
+//	orders:
+//	  - id: 10
+//	    user_id: 1
+//
+// Rows are inserted with ctx, so they join a transaction of TestDB.Tx.
+func LoadFixtures(ctx context.Context, db *database.DB, paths ...string) error {
+	fixtures, err := readFixtures(paths...)
+	if err != nil {
+		return err
+	}
+	if err := orderByForeignKeys(ctx, db, fixtures); err != nil {
+		return err
+	}
+	return insertFixtures(ctx, db, fixtures)
+}
+
+// readFixtures parses the files; rows of a table found in several files are appended.
+func readFixtures(paths ...string) ([]*fixtureTable, error) {
+	var (
+		tables []*fixtureTable
+		byName = make(map[string]*fixtureTable)
+	)
+
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		// JSON is valid YAML; a node keeps the order of the tables
+		var doc yaml.Node
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if len(doc.Content) == 0 {
+			continue
+		}
+		root := doc.Content[0]
+		if root.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("%s: %w", path, ErrFixtureFormat)
+		}
+
+		for i := 0; i+1 < len(root.Content); i += 2 {
+			name := root.Content[i].Value
+
+			var rows []map[string]any
+			if err := root.Content[i+1].Decode(&rows); err != nil {
+				return nil, fmt.Errorf("%s: table %s: %w", path, name, ErrFixtureFormat)
+			}
+
+			t, ok := byName[strings.ToLower(name)]
+			if !ok {
+				t = &fixtureTable{name: name}
+				byName[strings.ToLower(name)] = t
+				tables = append(tables, t)
+			}
+			t.rows = append(t.rows, rows...)
+		}
+	}
+	return tables, nil
+}
+
+// orderByForeignKeys sorts tables so referenced tables come first, keeping the file
+// order otherwise. Databases without foreign key introspection keep the file order.
+func orderByForeignKeys(ctx context.Context, db *database.DB, tables []*fixtureTable) error {
+	fks, err := db.ForeignKeys(ctx)
+	if errors.Is(err, database.ErrUnsupportedDBType) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	sorted, err := sortTables(tables, fks)
+	if err != nil {
+		return err
+	}
+	copy(tables, sorted)
+	return nil
+}
+
+// sortTables is a stable topological sort of tables by foreign key.
+func sortTables(tables []*fixtureTable, fks []database.ForeignKey) ([]*fixtureTable, error) {
+	index := make(map[string]int, len(tables))
+	for i, t := range tables {
+		index[strings.ToLower(t.name)] = i
+	}
+
+	// deps[i] are the fixture tables that table i references
+	deps := make([]map[int]struct{}, len(tables))
+	for _, fk := range fks {
+		from, okFrom := index[strings.ToLower(fk.Table)]
+		to, okTo := index[strings.ToLower(fk.RefTable)]
+		if !okFrom || !okTo || from == to {
+			continue
+		}
+		if deps[from] == nil {
+			deps[from] = make(map[int]struct{})
+		}
+		deps[from][to] = struct{}{}
+	}
+
+	var (
+		sorted = make([]*fixtureTable, 0, len(tables))
+		done   = make([]bool, len(tables))
+	)
+	for len(sorted) < len(tables) {
+		var ready []int
+		for i := range tables {
+			if done[i] {
+				continue
+			}
+			blocked := false
+			for d := range deps[i] {
+				if !done[d] {
+					blocked = true
+					break
+				}
+			}
+			if !blocked {
+				ready = append(ready, i)
+			}
+		}
+		if len(ready) == 0 {
+			return nil, ErrFixtureFKCycle
+		}
+		sort.Ints(ready)
+		for _, i := range ready {
+			done[i] = true
+			sorted = append(sorted, tables[i])
+		}
+	}
+	return sorted, nil
+}
+
+func insertFixtures(ctx context.Context, db *database.DB, tables []*fixtureTable) error {
+	for _, t := range tables {
+		for _, row := range t.rows {
+			cols := make([]string, 0, len(row))
+			for col := range row {
+				cols = append(cols, col)
+			}
+			sort.Strings(cols)
+
+			args := make([]interface{}, len(cols))
+			marks := make([]string, len(cols))
+			for i, col := range cols {
+				args[i] = row[col]
+				marks[i] = "?"
+			}
+
+			query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
+				t.name, strings.Join(cols, ", "), strings.Join(marks, ", "))
+			if err := db.Execute(ctx, db.GetDB().Rebind(query), nil, args...); err != nil {
+				return fmt.Errorf("insert into %s: %w", t.name, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package dbtest
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/BevisDev/godev/database"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func tableNames(tables []*fixtureTable) []string {
+	names := make([]string, len(tables))
+	for i, t := range tables {
+		names[i] = t.name
+	}
+	return names
+}
+
+func TestReadFixtures(t *testing.T) {
+	tables, err := readFixtures("testdata/users.yaml", "testdata/more.json")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"orders", "users"}, tableNames(tables))
+	require.Len(t, tables[1].rows, 2)
+	assert.Equal(t, "Bob", tables[1].rows[1]["name"])
+}
+
+func TestReadFixtures_InvalidFormat(t *testing.T) {
+	path := t.TempDir() + "/bad.yaml"
+	require.NoError(t, writeFile(path, "- 1\n- 2\n"))
+
+	_, err := readFixtures(path)
+	assert.ErrorIs(t, err, ErrFixtureFormat)
+}
+
+func TestSortTables(t *testing.T) {
+	tables := []*fixtureTable{{name: "order_items"}, {name: "orders"}, {name: "users"}, {name: "tags"}}
+	fks := []database.ForeignKey{
+		{Name: "fk_items_order", Table: "order_items", RefTable: "orders"},
+		{Name: "fk_orders_user", Table: "ORDERS", RefTable: "users"},
+		{Name: "fk_users_parent", Table: "users", RefTable: "users"},
+		{Name: "fk_other", Table: "audit", RefTable: "users"},
+	}
+
+	sorted, err := sortTables(tables, fks)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"users", "tags", "orders", "order_items"}, tableNames(sorted))
+}
+
+func TestSortTables_Cycle(t *testing.T) {
+	tables := []*fixtureTable{{name: "a"}, {name: "b"}}
+	fks := []database.ForeignKey{
+		{Table: "a", RefTable: "b"},
+		{Table: "b", RefTable: "a"},
+	}
+
+	_, err := sortTables(tables, fks)
+	assert.ErrorIs(t, err, ErrFixtureFKCycle)
+}
+
+func TestTestDB_LoadFixtures(t *testing.T) {
+	db := NewMock(t)
+	db.Mock.ExpectExec(`INSERT INTO orders \(amount, id, user_id\) VALUES \(\?, \?, \?\)`).
+		WithArgs(99.5, 10, 1).
+		WillReturnResult(sqlmock.NewResult(10, 1))
+	db.Mock.ExpectExec(`INSERT INTO users \(id, name\) VALUES \(\?, \?\)`).
+		WithArgs(1, "Alice").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	db.LoadFixtures(context.Background(), "testdata/users.yaml")
+}
+
+func TestLoadFixtures_OrdersByForeignKeys(t *testing.T) {
+	db := NewMock(t)
+	db.Mock.ExpectQuery(`FROM pg_constraint`).
+		WillReturnRows(sqlmock.NewRows([]string{"conname", "relname", "relname"}).
+			AddRow("fk_orders_user", "orders", "users"))
+	db.Mock.ExpectExec(`INSERT INTO users`).WillReturnResult(sqlmock.NewResult(1, 1))
+	db.Mock.ExpectExec(`INSERT INTO orders`).WillReturnResult(sqlmock.NewResult(10, 1))
+
+	require.NoError(t, LoadFixtures(context.Background(), db.DB, "testdata/users.yaml"))
+}
+
+func writeFile(path, content string) error {
+	return os.WriteFile(path, []byte(content), 0o644)
+}
@@ -0,0 +1,92 @@
+package dbtest
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/BevisDev/godev/database"
+)
+
+// Environment variables read by NewTestDatabase to connect to a real database,
+// e.g. a container started by CI or docker compose.
+const (
+	EnvType     = "DBTEST_TYPE" // postgres, mysql, sqlserver or oracle
+	EnvHost     = "DBTEST_HOST"
+	EnvPort     = "DBTEST_PORT"
+	EnvUser     = "DBTEST_USER"
+	EnvPassword = "DBTEST_PASSWORD"
+	EnvName     = "DBTEST_NAME"
+)
+
+// Option configures NewTestDatabase.
+type Option func(*options)
+
+type options struct {
+	config   *database.Config
+	dbType   database.DBType
+	real     bool
+	isolated bool
+}
+
+func withDefaults() *options {
+	return &options{dbType: database.Postgres}
+}
+
+// WithConfig connects to the database of cfg instead of reading the DBTEST_* variables.
+func WithConfig(cfg *database.Config) Option {
+	return func(o *options) {
+		o.config = cfg
+	}
+}
+
+// WithDBType sets the dialect of the sqlmock database (default Postgres).
+func WithDBType(t database.DBType) Option {
+	return func(o *options) {
+		o.dbType = t
+	}
+}
+
+// RequireReal skips the test when no real database is configured, instead of using sqlmock.
+func RequireReal() Option {
+	return func(o *options) {
+		o.real = true
+	}
+}
+
+// WithIsolatedSchema runs the test in a throwaway schema (Postgres) or database (MySQL)
+// created for it and dropped on cleanup. Ignored with sqlmock.
+func WithIsolatedSchema() Option {
+	return func(o *options) {
+		o.isolated = true
+	}
+}
+
+var dbTypes = map[string]database.DBType{
+	"postgres":  database.Postgres,
+	"mysql":     database.MySQL,
+	"sqlserver": database.SqlServer,
+	"oracle":    database.Oracle,
+}
+
+// configFromEnv returns the database of the DBTEST_* variables, or nil when DBTEST_TYPE is unset.
+func configFromEnv() (*database.Config, error) {
+	name := strings.ToLower(strings.TrimSpace(os.Getenv(EnvType)))
+	if name == "" {
+		return nil, nil
+	}
+	dbType, ok := dbTypes[name]
+	if !ok {
+		return nil, ErrUnknownDBType
+	}
+
+	port, _ := strconv.Atoi(os.Getenv(EnvPort))
+	return &database.Config{
+		DBType:   dbType,
+		Host:     os.Getenv(EnvHost),
+		Port:     port,
+		Username: os.Getenv(EnvUser),
+		Password: os.Getenv(EnvPassword),
+		DBName:   os.Getenv(EnvName),
+	}, nil
+}
@@ -0,0 +1,278 @@
+package database
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/BevisDev/godev/utils/crypto"
+)
+
+// tagEncrypt marks a string (or *string) field stored encrypted: `db:"email" encrypt:"true"`.
+const tagEncrypt = "encrypt"
+
+// encField is a struct field tagged with encrypt:"true".
+type encField struct {
+	index  int
+	column string
+	ptr    bool
+}
+
+// encFieldsCache caches the encrypted fields by struct type.
+var encFieldsCache sync.Map
+
+// UseEncryption enables transparent encryption of fields tagged `encrypt:"true"`.
+//
+// Chain.Insert/Update and Model.Create/Updates encrypt those columns with the primary key
+// of kr (AES-GCM, column name as authenticated data); Chain.First/FindAll and Model.First/Find
+// decrypt them with the key referenced by each value, so old rows stay readable after a key
+// rotation. Results are cached (see Cache) in their encrypted form.
+func (d *DB) UseEncryption(kr *crypto.Keyring) {
+	d.keyring = kr
+}
+
+// typeOf returns the reflect type of T.
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// encryptedFields returns the tagged fields of struct type t.
+func encryptedFields(t reflect.Type) []encField {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	if v, ok := encFieldsCache.Load(t); ok {
+		return v.([]encField)
+	}
+
+	var fields []encField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Tag.Get(tagEncrypt) != "true" || !f.IsExported() {
+			continue
+		}
+		ptr := f.Type.Kind() == reflect.Ptr
+		if (ptr && f.Type.Elem().Kind() != reflect.String) || (!ptr && f.Type.Kind() != reflect.String) {
+			continue
+		}
+		col := strings.Split(f.Tag.Get("db"), ",")[0]
+		if col == "-" {
+			continue
+		}
+		if col == "" {
+			col = strings.ToLower(f.Name)
+		}
+		fields = append(fields, encField{index: i, column: col, ptr: ptr})
+	}
+	encFieldsCache.Store(t, fields)
+	return fields
+}
+
+// encryptedColumns returns the set of encrypted column names of struct type t, lower-cased.
+func encryptedColumns(t reflect.Type) map[string]struct{} {
+	fields := encryptedFields(t)
+	if len(fields) == 0 {
+		return nil
+	}
+	cols := make(map[string]struct{}, len(fields))
+	for _, f := range fields {
+		cols[strings.ToLower(f.column)] = struct{}{}
+	}
+	return cols
+}
+
+// EncryptFields encrypts in place the tagged fields of the struct v points to.
+// Empty strings and nil pointers are left as they are.
+func EncryptFields(kr *crypto.Keyring, v any) error {
+	return transformFields(v, func(col, s string) (string, error) {
+		return kr.Encrypt([]byte(s), columnAAD(col))
+	})
+}
+
+// DecryptFields decrypts in place the tagged fields of v: a pointer to a struct,
+// or to a slice of structs or struct pointers.
+func DecryptFields(kr *crypto.Keyring, v any) error {
+	return transformFields(v, func(col, s string) (string, error) {
+		plain, err := kr.Decrypt(s, columnAAD(col))
+		if err != nil {
+			return "", err
+		}
+		return string(plain), nil
+	})
+}
+
+// RotateFields re-encrypts in place, with the primary key of kr, the tagged fields of v
+// sealed with an older key. It reports whether any field changed, i.e. the row must be saved.
+func RotateFields(kr *crypto.Keyring, v any) (bool, error) {
+	changed := false
+	err := transformFields(v, func(col, s string) (string, error) {
+		if !kr.NeedsRotation(s) {
+			return s, nil
+		}
+		changed = true
+		return kr.Rotate(s, columnAAD(col))
+	})
+	return changed, err
+}
+
+func transformFields(v any, fn func(col, s string) (string, error)) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("[database] %T must be a non-nil pointer", v)
+	}
+	rv = rv.Elem()
+
+	if rv.Kind() == reflect.Slice {
+		for i := 0; i < rv.Len(); i++ {
+			el := rv.Index(i)
+			if el.Kind() == reflect.Ptr {
+				if el.IsNil() {
+					continue
+				}
+				el = el.Elem()
+			}
+			if err := transformStruct(el, fn); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return transformStruct(rv, fn)
+}
+
+func transformStruct(rv reflect.Value, fn func(col, s string) (string, error)) error {
+	for _, f := range encryptedFields(rv.Type()) {
+		fv := rv.Field(f.index)
+		if f.ptr {
+			if fv.IsNil() {
+				continue
+			}
+			fv = fv.Elem()
+		}
+		if fv.String() == "" {
+			continue
+		}
+		out, err := fn(f.column, fv.String())
+		if err != nil {
+			return fmt.Errorf("[database] encrypted column %s: %w", f.column, err)
+		}
+		if f.ptr {
+			// do not write through a pointer shared with the caller's copy
+			rv.Field(f.index).Set(reflect.ValueOf(&out))
+		} else {
+			fv.SetString(out)
+		}
+	}
+	return nil
+}
+
+// encryptData returns data with its encrypted columns sealed, leaving data untouched.
+// Structs use their own tags; maps use the tags of model type t.
+func (d *DB) encryptData(t reflect.Type, data any) (any, error) {
+	if d.keyring == nil || data == nil {
+		return data, nil
+	}
+
+	if m, ok := data.(map[string]interface{}); ok {
+		return d.encryptMap(t, m)
+	}
+
+	rv := reflect.ValueOf(data)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return data, nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct || len(encryptedFields(rv.Type())) == 0 {
+		return data, nil
+	}
+
+	cp := reflect.New(rv.Type())
+	cp.Elem().Set(rv)
+	if err := EncryptFields(d.keyring, cp.Interface()); err != nil {
+		return nil, err
+	}
+	return cp.Interface(), nil
+}
+
+// encryptMap returns a copy of fields with the encrypted columns of model type t sealed.
+func (d *DB) encryptMap(t reflect.Type, fields map[string]interface{}) (map[string]interface{}, error) {
+	cols := encryptedColumns(t)
+	if d.keyring == nil || len(cols) == 0 {
+		return fields, nil
+	}
+
+	out := make(map[string]interface{}, len(fields))
+	for k, v := range fields {
+		out[k] = v
+		if _, ok := cols[strings.ToLower(k)]; !ok {
+			continue
+		}
+		s, err := d.encryptValue(k, v)
+		if err != nil {
+			return nil, err
+		}
+		out[k] = s
+	}
+	return out, nil
+}
+
+// encryptValues seals in place the values of encrypted columns of model type t.
+func (d *DB) encryptValues(t reflect.Type, cols []string, vals []interface{}) error {
+	enc := encryptedColumns(t)
+	if d.keyring == nil || len(enc) == 0 {
+		return nil
+	}
+	for i, c := range cols {
+		if _, ok := enc[strings.ToLower(c)]; !ok {
+			continue
+		}
+		s, err := d.encryptValue(c, vals[i])
+		if err != nil {
+			return err
+		}
+		vals[i] = s
+	}
+	return nil
+}
+
+func (d *DB) encryptValue(col string, v interface{}) (interface{}, error) {
+	var s string
+	switch x := v.(type) {
+	case string:
+		s = x
+	case *string:
+		if x == nil {
+			return v, nil
+		}
+		s = *x
+	default:
+		return nil, fmt.Errorf("[database] encrypted column %s must be a string, got %T", col, v)
+	}
+	if s == "" {
+		return s, nil
+	}
+	out, err := d.keyring.Encrypt([]byte(s), columnAAD(col))
+	if err != nil {
+		return nil, fmt.Errorf("[database] encrypted column %s: %w", col, err)
+	}
+	return out, nil
+}
+
+// columnAAD binds a ciphertext to its column, so values cannot be swapped between columns.
+func columnAAD(col string) []byte {
+	return []byte(strings.ToLower(col))
+}
+
+// decrypt opens the encrypted fields of dest (pointer to a struct or a slice of them).
+func (d *DB) decrypt(dest any) error {
+	if d.keyring == nil {
+		return nil
+	}
+	return DecryptFields(d.keyring, dest)
+}
@@ -0,0 +1,129 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"testing"
+
+	"github.com/BevisDev/godev/utils/crypto"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Customer struct {
+	ID    int     `db:"id"`
+	Name  string  `db:"name"`
+	Email string  `db:"email" encrypt:"true"`
+	Phone *string `db:"phone" encrypt:"true"`
+}
+
+func newTestKeyring(t *testing.T) *crypto.Keyring {
+	t.Helper()
+	kr, err := crypto.NewKeyring("k1", []byte("0123456789abcdef0123456789abcdef"))
+	require.NoError(t, err)
+	return kr
+}
+
+// envelopeArg matches an encrypted value.
+type envelopeArg struct{}
+
+func (envelopeArg) Match(v driver.Value) bool {
+	s, ok := v.(string)
+	return ok && strings.HasPrefix(s, "v1:k1:")
+}
+
+func TestEncryptFields_RoundTrip(t *testing.T) {
+	kr := newTestKeyring(t)
+	phone := "0901234567"
+	c := Customer{ID: 1, Name: "Alice", Email: "a@example.com", Phone: &phone}
+
+	require.NoError(t, EncryptFields(kr, &c))
+	assert.Equal(t, "Alice", c.Name)
+	assert.True(t, strings.HasPrefix(c.Email, "v1:k1:"))
+	assert.True(t, strings.HasPrefix(*c.Phone, "v1:k1:"))
+	assert.Equal(t, "0901234567", phone, "caller's pointer is not modified")
+
+	list := []*Customer{&c}
+	require.NoError(t, DecryptFields(kr, &list))
+	assert.Equal(t, "a@example.com", c.Email)
+	assert.Equal(t, "0901234567", *c.Phone)
+}
+
+func TestEncryptFields_ColumnBound(t *testing.T) {
+	kr := newTestKeyring(t)
+	c := Customer{Email: "a@example.com"}
+	require.NoError(t, EncryptFields(kr, &c))
+
+	// a ciphertext moved to another column does not decrypt
+	swapped := c.Email
+	c.Phone = &swapped
+	c.Email = ""
+	assert.Error(t, DecryptFields(kr, &c))
+}
+
+func TestRotateFields(t *testing.T) {
+	kr := newTestKeyring(t)
+	c := Customer{Email: "a@example.com"}
+	require.NoError(t, EncryptFields(kr, &c))
+
+	require.NoError(t, kr.AddKey("k2", []byte("abcdef0123456789abcdef0123456789")))
+	require.NoError(t, kr.SetPrimary("k2"))
+
+	changed, err := RotateFields(kr, &c)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.True(t, strings.HasPrefix(c.Email, "v1:k2:"))
+
+	changed, err = RotateFields(kr, &c)
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	require.NoError(t, DecryptFields(kr, &c))
+	assert.Equal(t, "a@example.com", c.Email)
+}
+
+func TestChain_Encryption(t *testing.T) {
+	db, mock := setupTestDB(t)
+	defer db.Close()
+	kr := newTestKeyring(t)
+	db.UseEncryption(kr)
+	ctx := context.Background()
+
+	t.Run("update encrypts tagged columns", func(t *testing.T) {
+		mock.ExpectExec("UPDATE customers SET email = \\?").
+			WithArgs(envelopeArg{}, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		_, err := Builder[Customer](db).From("customers").Select("email").Where("id = ?", 1).
+			Update(ctx, map[string]interface{}{"email": "b@example.com"})
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("read decrypts tagged columns", func(t *testing.T) {
+		stored := Customer{Email: "a@example.com"}
+		require.NoError(t, EncryptFields(kr, &stored))
+
+		mock.ExpectQuery("SELECT \\* FROM customers").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "phone"}).
+				AddRow(1, "Alice", stored.Email, nil))
+
+		list, err := Builder[Customer](db).From("customers").FindAll(ctx)
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		assert.Equal(t, "a@example.com", list[0].Email)
+		assert.Nil(t, list[0].Phone)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("plaintext value fails", func(t *testing.T) {
+		mock.ExpectQuery("SELECT \\* FROM customers").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "phone"}).
+				AddRow(1, "Alice", "plain@example.com", nil))
+
+		_, err := Builder[Customer](db).From("customers").First(ctx)
+		assert.ErrorIs(t, err, crypto.ErrInvalidEnvelope)
+	})
+}
@@ -3,9 +3,19 @@ package database
 import "errors"
 
 var (
-	ErrMissingFrom   = errors.New("use From() before")
-	ErrMissingSelect = errors.New("use Select() before")
-	ErrMissingWhere  = errors.New("use Where() before")
-	ErrMissingTable  = errors.New("missing TableName() for model")
-	ErrMissingData   = errors.New("missing model data")
+	ErrMissingFrom    = errors.New("use From() before")
+	ErrMissingSelect  = errors.New("use Select() before")
+	ErrMissingWhere   = errors.New("use Where() before")
+	ErrMissingTable   = errors.New("missing TableName() for model")
+	ErrMissingData    = errors.New("missing model data")
+	ErrMissingTenant  = errors.New("missing tenant in context for a tenant-scoped table")
+	ErrMissingVersion = errors.New("missing current version in fields")
+	ErrStaleObject    = errors.New("stale object: row was modified or deleted by another transaction")
+
+	ErrMissingPrimaryKey = errors.New("missing primary key: tag a field with db:\"<col>,pk\" or map an id column")
+	ErrUnknownColumn     = errors.New("unknown column")
+	ErrInvalidOrderBy    = errors.New("invalid order by")
+	ErrSearchWrite       = errors.New("use Where() instead of Search() to update or delete")
+
+	ErrUnsupportedDBType = errors.New("unsupported database type")
 )
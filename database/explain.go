@@ -0,0 +1,177 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/BevisDev/godev/utils"
+)
+
+// PlanFormat is the format of a Plan.
+type PlanFormat string
+
+const (
+	// PlanJSON is an indented JSON plan (Postgres, MySQL).
+	PlanJSON PlanFormat = "json"
+
+	// PlanText is a plan of text lines (SQL Server, Oracle).
+	PlanText PlanFormat = "text"
+)
+
+// Plan is the execution plan of a query, as estimated by the database.
+type Plan struct {
+	// Query is the explained query, with the placeholders of the database.
+	Query string
+
+	// Format is PlanJSON or PlanText.
+	Format PlanFormat
+
+	// Plan is the plan: indented JSON, or text lines without trailing spaces.
+	Plan string
+}
+
+func (p *Plan) String() string {
+	return p.Plan
+}
+
+// Explain returns the estimated execution plan of query; the query is not run.
+// Placeholders and IN clauses are handled like GetList.
+//
+//	Postgres:   EXPLAIN (FORMAT JSON)
+//	MySQL:      EXPLAIN FORMAT=JSON
+//	SQL Server: SET SHOWPLAN_TEXT ON
+//	Oracle:     EXPLAIN PLAN FOR + DBMS_XPLAN.DISPLAY
+func (d *DB) Explain(c context.Context, query string, args ...interface{}) (*Plan, error) {
+	query, newArgs, err := d.rebind(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := utils.NewCtxTimeout(c, d.cfg.Timeout)
+	defer cancel()
+
+	var (
+		format = PlanText
+		plan   string
+	)
+	switch d.cfg.DBType {
+	case Postgres:
+		format = PlanJSON
+		err = d.conn(ctx).GetContext(ctx, &plan, "EXPLAIN (FORMAT JSON) "+query, newArgs...)
+	case MySQL:
+		format = PlanJSON
+		err = d.conn(ctx).GetContext(ctx, &plan, "EXPLAIN FORMAT=JSON "+query, newArgs...)
+	case SqlServer:
+		plan, err = d.showPlan(ctx, query, newArgs)
+	case Oracle:
+		plan, err = d.explainPlan(ctx, query, newArgs)
+	default:
+		return nil, fmt.Errorf("[database] explain is not supported for %s", d.cfg.DBType)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("[database] failed to explain query: %w", err)
+	}
+
+	if format == PlanJSON {
+		plan, err = indentJSON(plan)
+		if err != nil {
+			return nil, fmt.Errorf("[database] failed to read plan: %w", err)
+		}
+	}
+	return &Plan{Query: query, Format: format, Plan: plan}, nil
+}
+
+// showPlan reads the text plan of query on SQL Server. SHOWPLAN applies to the
+// session, so it runs on a dedicated connection.
+func (d *DB) showPlan(ctx context.Context, query string, args []interface{}) (string, error) {
+	c, err := d.db.Connx(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer c.Close()
+
+	if _, err := c.ExecContext(ctx, "SET SHOWPLAN_TEXT ON"); err != nil {
+		return "", err
+	}
+	defer func() {
+		if _, err := c.ExecContext(context.Background(), "SET SHOWPLAN_TEXT OFF"); err != nil {
+			// never return a connection that only explains to the pool
+			_ = c.Raw(func(interface{}) error { return driver.ErrBadConn })
+		}
+	}()
+
+	rows, err := c.QueryContext(ctx, query, args...)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	// one result set with the statement, one with its plan, per statement
+	var lines []string
+	for {
+		for rows.Next() {
+			var line string
+			if err := rows.Scan(&line); err != nil {
+				return "", err
+			}
+			lines = append(lines, line)
+		}
+		if !rows.NextResultSet() {
+			break
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+	return trimLines(lines), nil
+}
+
+// explainPlan reads the text plan of query on Oracle. The plan is written to
+// the PLAN_TABLE of the session, so it runs on a dedicated connection.
+func (d *DB) explainPlan(ctx context.Context, query string, args []interface{}) (string, error) {
+	c, err := d.db.Connx(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer c.Close()
+
+	if _, err := c.ExecContext(ctx, "EXPLAIN PLAN FOR "+query, args...); err != nil {
+		return "", err
+	}
+	var lines []string
+	if err := c.SelectContext(ctx, &lines, "SELECT plan_table_output FROM TABLE(DBMS_XPLAN.DISPLAY())"); err != nil {
+		return "", err
+	}
+	return trimLines(lines), nil
+}
+
+// logPlan logs the plan of query for Chain.Explain; failures are logged too.
+func (d *DB) logPlan(ctx context.Context, query string, args []interface{}) {
+	plan, err := d.Explain(ctx, query, args...)
+	if err != nil {
+		log.Printf("[database] plan of %s: %v", query, err)
+		return
+	}
+	log.Printf("[database] plan of %s:\n%s", plan.Query, plan)
+}
+
+func indentJSON(s string) (string, error) {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, []byte(s), "", "  "); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// trimLines joins lines without their trailing spaces and the trailing empty lines.
+func trimLines(lines []string) string {
+	for i, l := range lines {
+		lines[i] = strings.TrimRight(l, " \t\r")
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
@@ -0,0 +1,66 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplain_Postgres(t *testing.T) {
+	db, mock := setupTestDB(t)
+	db.cfg.DBType = Postgres
+
+	mock.ExpectQuery(`EXPLAIN \(FORMAT JSON\) SELECT \* FROM users WHERE id IN \(\?, \?\)`).
+		WithArgs(1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"QUERY PLAN"}).
+			AddRow(`[{"Plan":{"Node Type":"Index Scan","Total Cost":8.3}}]`))
+
+	plan, err := db.Explain(context.Background(), "SELECT * FROM users WHERE id IN (?)", []int{1, 2})
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+	assert.Equal(t, PlanJSON, plan.Format)
+	assert.Equal(t, "SELECT * FROM users WHERE id IN (?, ?)", plan.Query)
+	assert.Equal(t, "[\n  {\n    \"Plan\": {\n      \"Node Type\": \"Index Scan\",\n      \"Total Cost\": 8.3\n    }\n  }\n]", plan.String())
+}
+
+func TestExplain_SqlServer(t *testing.T) {
+	db, mock := setupTestDB(t)
+
+	mock.ExpectExec("SET SHOWPLAN_TEXT ON").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT \* FROM users WHERE id = \?`).
+		WithArgs(7).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"StmtText"}).AddRow("SELECT * FROM users WHERE id = @p1"),
+			sqlmock.NewRows([]string{"StmtText"}).
+				AddRow("  |--Clustered Index Seek(OBJECT:([users].[PK_users]))   "),
+		)
+	mock.ExpectExec("SET SHOWPLAN_TEXT OFF").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	plan, err := db.Explain(context.Background(), "SELECT * FROM users WHERE id = ?", 7)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+	assert.Equal(t, PlanText, plan.Format)
+	assert.Equal(t, "SELECT * FROM users WHERE id = @p1\n  |--Clustered Index Seek(OBJECT:([users].[PK_users]))", plan.Plan)
+}
+
+func TestChain_Explain(t *testing.T) {
+	db, mock := setupTestDB(t)
+	db.cfg.DBType = MySQL
+	logs := captureLog(t)
+
+	mock.ExpectQuery(`EXPLAIN FORMAT=JSON SELECT name FROM users WHERE id = \?`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"EXPLAIN"}).AddRow(`{"query_block":{"select_id":1}}`))
+	mock.ExpectQuery(`SELECT name FROM users WHERE id = \?`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("An"))
+
+	user, err := Builder[User](db).From("users").Select("name").Where("id = ?", 1).Explain().First(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "An", user.Name)
+	require.NoError(t, mock.ExpectationsWereMet())
+	assert.Contains(t, logs.String(), "plan of SELECT name FROM users WHERE id = ?:\n{\n  \"query_block\": {")
+}
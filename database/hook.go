@@ -0,0 +1,102 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/BevisDev/godev/utils"
+)
+
+// Change actions reported to a ChangeHook.
+const (
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+// ChangeEvent describes rows changed through Chain.Update or Chain.Delete.
+type ChangeEvent struct {
+	Table  string
+	Action string
+
+	// Before holds the matched rows as read right before the write, keyed by column.
+	Before []map[string]interface{}
+
+	// Fields holds the new column values of an update.
+	Fields map[string]interface{}
+}
+
+// After returns the row i as it is after the write: Before merged with Fields, or nil for a delete.
+func (e ChangeEvent) After(i int) map[string]interface{} {
+	if e.Action == ActionDelete {
+		return nil
+	}
+	row := make(map[string]interface{}, len(e.Before[i])+len(e.Fields))
+	for k, v := range e.Before[i] {
+		row[k] = v
+	}
+	for k, v := range e.Fields {
+		row[k] = v
+	}
+	return row
+}
+
+// ChangeHook is called after a successful Chain.Update or Chain.Delete.
+// Errors are logged and do not fail the write, which is already applied.
+type ChangeHook func(ctx context.Context, ev ChangeEvent) error
+
+// OnChange registers hook for writes made through Chain.Update and Chain.Delete.
+// While a hook is registered, each of these writes first selects the matched rows.
+func (d *DB) OnChange(hook ChangeHook) {
+	d.hooks = append(d.hooks, hook)
+}
+
+// loadBefore selects the rows of table matched by where, when hooks are registered.
+func (d *DB) loadBefore(c context.Context, table string, where []string, args []interface{}) ([]map[string]interface{}, error) {
+	if len(d.hooks) == 0 {
+		return nil, nil
+	}
+
+	query := fmt.Sprintf("SELECT * FROM %s WHERE %s", table, strings.Join(where, " AND "))
+	query, args, err := d.rebind(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := utils.NewCtxTimeout(c, d.cfg.Timeout)
+	defer cancel()
+
+	rows, err := d.conn(ctx).QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []map[string]interface{}
+	for rows.Next() {
+		row := make(map[string]interface{})
+		if err := rows.MapScan(row); err != nil {
+			return nil, err
+		}
+		for k, v := range row {
+			// drivers return text columns as []byte
+			if b, ok := v.([]byte); ok {
+				row[k] = string(b)
+			}
+		}
+		out = append(out, row)
+	}
+	return out, rows.Err()
+}
+
+func (d *DB) notifyChange(ctx context.Context, ev ChangeEvent) {
+	if len(ev.Before) == 0 {
+		return
+	}
+	for _, hook := range d.hooks {
+		if err := hook(ctx, ev); err != nil {
+			log.Printf("[database] change hook %s %s: %v", ev.Action, ev.Table, err)
+		}
+	}
+}
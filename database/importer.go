@@ -0,0 +1,281 @@
+package database
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/BevisDev/godev/utils/csvx"
+)
+
+const defaultImportBatchSize = 500
+
+// ErrTooManyRowErrors is returned by Import when the number of invalid rows exceeds WithMaxRowErrors.
+var ErrTooManyRowErrors = errors.New("[database] import aborted: too many invalid rows")
+
+// RowSource yields decoded rows for Import. *csvx.Reader[T] satisfies it, both for
+// CSV files (csvx.NewReader) and Excel sheets (csvx.NewRecordReader with excel.RowIterator).
+type RowSource[T any] interface {
+	// Read returns the next row, or io.EOF at the end.
+	Read() (T, error)
+
+	// Line returns the line (or sheet row) number of the last row read.
+	Line() int
+}
+
+// RowValidator is implemented by row types that validate themselves.
+type RowValidator interface {
+	Validate() error
+}
+
+// RowError is a row that was skipped during Import.
+type RowError struct {
+	Line int
+	Err  error
+}
+
+func (e RowError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e RowError) Unwrap() error {
+	return e.Err
+}
+
+// ImportSummary reports the outcome of Import.
+type ImportSummary struct {
+	Total    int
+	Inserted int
+	Skipped  int
+	Errors   []RowError
+	Duration time.Duration
+}
+
+type ImportOption[T any] func(*importer[T])
+
+type importer[T any] struct {
+	db        *DB
+	table     string
+	columns   []string
+	batchSize int
+	maxErrors int
+	validate  func(T) error
+}
+
+// WithImportTable sets the target table. By default the table comes from T's TableName().
+func WithImportTable[T any](table string) ImportOption[T] {
+	return func(i *importer[T]) { i.table = table }
+}
+
+// WithImportColumns restricts the inserted columns (by `db` tag), e.g. to leave
+// out auto-generated IDs. By default every exported field is inserted.
+func WithImportColumns[T any](cols ...string) ImportOption[T] {
+	return func(i *importer[T]) { i.columns = cols }
+}
+
+// WithImportBatchSize sets how many valid rows are inserted per transaction (default 500).
+func WithImportBatchSize[T any](n int) ImportOption[T] {
+	return func(i *importer[T]) {
+		if n > 0 {
+			i.batchSize = n
+		}
+	}
+}
+
+// WithMaxRowErrors aborts the import with ErrTooManyRowErrors once more than n rows are invalid.
+// Zero (the default) never aborts.
+func WithMaxRowErrors[T any](n int) ImportOption[T] {
+	return func(i *importer[T]) { i.maxErrors = n }
+}
+
+// WithRowValidator adds a validation step run for every row, after RowValidator.Validate
+// when T implements it. Return errors.Join(...) to report several problems at once.
+func WithRowValidator[T any](fn func(T) error) ImportOption[T] {
+	return func(i *importer[T]) { i.validate = fn }
+}
+
+// Import streams rows from src, validates each one and bulk-inserts the valid
+// rows in batches, each batch in its own transaction (see InsertBulk).
+//
+// Rows that cannot be decoded (csvx.ParseError, csv.ParseError) or fail validation are
+// skipped and collected in the summary with their line numbers. If a batch insert
+// fails, Import stops and returns the summary so far together with the error;
+// previously inserted batches stay committed.
+//
+// Example:
+//
+//	type Customer struct {
+//		Name  string `db:"name"  csv:"Họ tên"`
+//		Email string `db:"email" csv:"Email"`
+//	}
+//
+//	func (c Customer) Validate() error {
+//		if !validate.IsEmail(c.Email) {
+//			return errors.New("email is invalid")
+//		}
+//		return nil
+//	}
+//
+//	src, err := csvx.NewReader[Customer](file)
+//	sum, err := database.Import(ctx, db, src,
+//		database.WithImportTable[Customer]("customers"),
+//		database.WithImportBatchSize[Customer](1000),
+//	)
+//	// sum.Inserted, sum.Skipped, sum.Errors[i].Line
+func Import[T any](ctx context.Context, db *DB, src RowSource[T], opts ...ImportOption[T]) (*ImportSummary, error) {
+	imp := &importer[T]{db: db, batchSize: defaultImportBatchSize}
+	for _, opt := range opts {
+		opt(imp)
+	}
+	if imp.table == "" {
+		table, err := tableNameFor[T]()
+		if err != nil {
+			return nil, err
+		}
+		imp.table = table
+	}
+	return imp.run(ctx, src)
+}
+
+// InsertRows bulk-inserts rows into table in a single transaction (see InsertBulk).
+// Columns come from the `db` tags of T, restricted to cols when given.
+func InsertRows[T any](ctx context.Context, db *DB, table string, rows []T, cols ...string) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	imp := &importer[T]{db: db, table: table, columns: cols}
+
+	var (
+		names []string
+		args  = make([]interface{}, 0, len(rows))
+	)
+	for _, row := range rows {
+		c, vals, err := imp.values(row)
+		if err != nil {
+			return err
+		}
+		if names == nil {
+			names = c
+		}
+		args = append(args, vals...)
+	}
+	return db.InsertBulk(ctx, table, len(rows), names, args...)
+}
+
+func (imp *importer[T]) run(ctx context.Context, src RowSource[T]) (*ImportSummary, error) {
+	start := time.Now()
+	sum := &ImportSummary{}
+	defer func() { sum.Duration = time.Since(start) }()
+
+	var (
+		cols  []string
+		args  []interface{}
+		rows  int
+		first int
+	)
+
+	flush := func() error {
+		if rows == 0 {
+			return nil
+		}
+		if err := imp.db.InsertBulk(ctx, imp.table, rows, cols, args...); err != nil {
+			return fmt.Errorf("[database] import rows from line %d to %d: %w", first, src.Line(), err)
+		}
+		sum.Inserted += rows
+		args, rows = args[:0], 0
+		return nil
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return sum, err
+		}
+
+		row, err := src.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil && !isRowError(err) {
+			return sum, err
+		}
+		sum.Total++
+
+		if err == nil {
+			err = imp.check(row)
+		}
+		if err != nil {
+			sum.Skipped++
+			sum.Errors = append(sum.Errors, RowError{Line: src.Line(), Err: err})
+			if imp.maxErrors > 0 && len(sum.Errors) > imp.maxErrors {
+				return sum, ErrTooManyRowErrors
+			}
+			continue
+		}
+
+		c, vals, err := imp.values(row)
+		if err != nil {
+			return sum, err
+		}
+		if cols == nil {
+			cols = c
+		}
+		if rows == 0 {
+			first = src.Line()
+		}
+		args = append(args, vals...)
+		rows++
+
+		if rows >= imp.batchSize {
+			if err := flush(); err != nil {
+				return sum, err
+			}
+		}
+	}
+
+	return sum, flush()
+}
+
+func (imp *importer[T]) check(row T) error {
+	if v, ok := any(row).(RowValidator); ok {
+		if err := v.Validate(); err != nil {
+			return err
+		}
+	} else if v, ok := any(&row).(RowValidator); ok {
+		if err := v.Validate(); err != nil {
+			return err
+		}
+	}
+	if imp.validate != nil {
+		return imp.validate(row)
+	}
+	return nil
+}
+
+func (imp *importer[T]) values(row T) ([]string, []interface{}, error) {
+	cols, vals, err := extractColumnsAndValues(row)
+	if err != nil || len(imp.columns) == 0 {
+		return cols, vals, err
+	}
+
+	picked := make([]interface{}, 0, len(imp.columns))
+	for _, c := range imp.columns {
+		idx := slices.Index(cols, c)
+		if idx < 0 {
+			return nil, nil, fmt.Errorf("[database] import column %q not found", c)
+		}
+		picked = append(picked, vals[idx])
+	}
+	return imp.columns, picked, nil
+}
+
+// isRowError reports decode errors that only affect the current row.
+func isRowError(err error) bool {
+	var pe *csvx.ParseError
+	var ce *csv.ParseError
+	return errors.As(err, &pe) || errors.As(err, &ce)
+}
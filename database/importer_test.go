@@ -0,0 +1,174 @@
+package database
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/BevisDev/godev/utils/csvx"
+	"github.com/BevisDev/godev/utils/validate"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type importCustomer struct {
+	ID    int    `db:"id" csv:"-"`
+	Name  string `db:"name" csv:"name"`
+	Email string `db:"email" csv:"email"`
+	Age   int    `db:"age" csv:"age"`
+}
+
+func (importCustomer) TableName() string { return "customers" }
+
+func (c importCustomer) Validate() error {
+	if !validate.IsEmail(c.Email) {
+		return errors.New("email is invalid")
+	}
+	return nil
+}
+
+func newCustomerSource(t *testing.T, data string) *csvx.Reader[importCustomer] {
+	t.Helper()
+	src, err := csvx.NewReader[importCustomer](strings.NewReader(data))
+	require.NoError(t, err)
+	return src
+}
+
+func TestImport_BatchesAndRowErrors(t *testing.T) {
+	db, mock := setupTestDB(t)
+	defer db.Close()
+
+	src := newCustomerSource(t, "name,email,age\n"+
+		"Alice,alice@example.com,30\n"+
+		"Bob,not-an-email,20\n"+
+		"Carol,carol@example.com,abc\n"+
+		"Dan,dan@example.com,40\n"+
+		"Eve,eve@example.com,17\n")
+
+	cols := []string{"name", "email", "age"}
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(buildExpectedInsertQuery(db, "customers", cols, 2))).
+		WithArgs("Alice", "alice@example.com", 30, "Dan", "dan@example.com", 40).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(buildExpectedInsertQuery(db, "customers", cols, 1))).
+		WithArgs("Eve", "eve@example.com", 17).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	sum, err := Import(context.Background(), db, src,
+		WithImportColumns[importCustomer](cols...),
+		WithImportBatchSize[importCustomer](2),
+	)
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	assert.Equal(t, 5, sum.Total)
+	assert.Equal(t, 3, sum.Inserted)
+	assert.Equal(t, 2, sum.Skipped)
+	require.Len(t, sum.Errors, 2)
+	assert.Equal(t, 3, sum.Errors[0].Line)
+	assert.EqualError(t, sum.Errors[0], "line 3: email is invalid")
+	assert.Equal(t, 4, sum.Errors[1].Line)
+	var pe *csvx.ParseError
+	assert.ErrorAs(t, sum.Errors[1], &pe)
+}
+
+func TestImport_MalformedRowLine(t *testing.T) {
+	db, mock := setupTestDB(t)
+	defer db.Close()
+
+	src := newCustomerSource(t, "name,email,age\n"+
+		"Alice,alice@example.com,30\n"+
+		"Bob,\"bob\"@example.com,20\n"+
+		"Dan,dan@example.com,40\n")
+
+	cols := []string{"name", "email", "age"}
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(buildExpectedInsertQuery(db, "customers", cols, 2))).
+		WithArgs("Alice", "alice@example.com", 30, "Dan", "dan@example.com", 40).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	sum, err := Import(context.Background(), db, src, WithImportColumns[importCustomer](cols...))
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	require.Len(t, sum.Errors, 1)
+	assert.Equal(t, 3, sum.Errors[0].Line)
+	var ce *csv.ParseError
+	assert.ErrorAs(t, sum.Errors[0], &ce)
+}
+
+func TestImport_CustomValidatorAndMaxErrors(t *testing.T) {
+	db, mock := setupTestDB(t)
+	defer db.Close()
+
+	src := newCustomerSource(t, "name,email,age\n"+
+		"A,a@example.com,10\n"+
+		"B,b@example.com,11\n")
+
+	sum, err := Import(context.Background(), db, src,
+		WithImportTable[importCustomer]("staging_customers"),
+		WithRowValidator(func(c importCustomer) error {
+			if c.Age < 18 {
+				return errors.New("age must be at least 18")
+			}
+			return nil
+		}),
+		WithMaxRowErrors[importCustomer](1),
+	)
+	assert.ErrorIs(t, err, ErrTooManyRowErrors)
+	assert.Equal(t, 2, sum.Skipped)
+	assert.Equal(t, 0, sum.Inserted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestImport_InsertFailure(t *testing.T) {
+	db, mock := setupTestDB(t)
+	defer db.Close()
+
+	src := newCustomerSource(t, "name,email,age\nA,a@example.com,20\n")
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO customers").WillReturnError(errors.New("duplicate key"))
+	mock.ExpectRollback()
+
+	sum, err := Import(context.Background(), db, src)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "duplicate key")
+	assert.Contains(t, err.Error(), "line 2")
+	assert.Equal(t, 0, sum.Inserted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	_, err = Import(context.Background(), db, newCustomerSource(t, ""), WithImportColumns[importCustomer]("missing"))
+	assert.NoError(t, err, "empty input inserts nothing")
+}
+
+func TestInsertRows(t *testing.T) {
+	db, mock := setupTestDB(t)
+	defer db.Close()
+
+	cols := []string{"name", "email"}
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(buildExpectedInsertQuery(db, "customers", cols, 2))).
+		WithArgs("A", "a@example.com", "B", "b@example.com").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	rows := []importCustomer{
+		{Name: "A", Email: "a@example.com"},
+		{Name: "B", Email: "b@example.com"},
+	}
+	require.NoError(t, InsertRows(context.Background(), db, "customers", rows, cols...))
+	require.NoError(t, InsertRows[importCustomer](context.Background(), db, "customers", nil))
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	err := InsertRows(context.Background(), db, "customers", rows, "missing")
+	assert.ErrorContains(t, err, `column "missing" not found`)
+}
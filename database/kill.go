@@ -0,0 +1,97 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// killTimeout bounds the KILL/cancel request sent by Config.KillOnCancel.
+const killTimeout = 5 * time.Second
+
+// killableConn runs Exec, Get and Select on a dedicated connection whose query
+// is killed on the server when the context is done (Config.KillOnCancel).
+// Other methods use the pool as usual.
+type killableConn struct {
+	*sqlx.DB
+	d *DB
+}
+
+func (k killableConn) ExecContext(ctx context.Context, query string, args ...interface{}) (res sql.Result, err error) {
+	err = k.d.killable(ctx, func(c *sqlx.Conn) error {
+		res, err = c.ExecContext(ctx, query, args...)
+		return err
+	})
+	return
+}
+
+func (k killableConn) NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error) {
+	q, args, err := k.BindNamed(query, arg)
+	if err != nil {
+		return nil, err
+	}
+	return k.ExecContext(ctx, q, args...)
+}
+
+func (k killableConn) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return k.d.killable(ctx, func(c *sqlx.Conn) error {
+		return c.GetContext(ctx, dest, query, args...)
+	})
+}
+
+func (k killableConn) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return k.d.killable(ctx, func(c *sqlx.Conn) error {
+		return c.SelectContext(ctx, dest, query, args...)
+	})
+}
+
+// killable runs fn on a dedicated connection and, when ctx is done before fn
+// returns, asks the server to cancel the query of that connection.
+func (d *DB) killable(ctx context.Context, fn func(c *sqlx.Conn) error) error {
+	c, err := d.db.Connx(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	var sid int64
+	if err := c.QueryRowxContext(ctx, d.cfg.DBType.SessionIDQuery()).Scan(&sid); err != nil {
+		return fmt.Errorf("[database] failed to read session id: %w", err)
+	}
+
+	done := make(chan struct{})
+	killed := make(chan bool, 1)
+	go func() {
+		select {
+		case <-ctx.Done():
+			d.kill(sid)
+			killed <- true
+		case <-done:
+			killed <- false
+		}
+	}()
+
+	err = fn(c)
+	close(done)
+	if <-killed {
+		// the session may be gone (SQL Server): keep the connection out of the pool
+		_ = c.Raw(func(interface{}) error { return driver.ErrBadConn })
+	}
+	return err
+}
+
+func (d *DB) kill(sid int64) {
+	ctx, cancel := context.WithTimeout(context.Background(), killTimeout)
+	defer cancel()
+
+	query := d.cfg.DBType.KillQuery(sid)
+	d.ViewQuery(query)
+	if _, err := d.db.ExecContext(ctx, query); err != nil {
+		log.Printf("[database] failed to cancel query of session %d: %v", sid, err)
+	}
+}
@@ -0,0 +1,59 @@
+package database
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDBType_KillQuery(t *testing.T) {
+	assert.Equal(t, "KILL QUERY 7", MySQL.KillQuery(7))
+	assert.Equal(t, "SELECT pg_cancel_backend(7)", Postgres.KillQuery(7))
+	assert.Equal(t, "KILL 7", SqlServer.KillQuery(7))
+	assert.Empty(t, Oracle.KillQuery(7))
+	assert.Empty(t, Oracle.SessionIDQuery())
+}
+
+func TestKillOnCancel(t *testing.T) {
+	db, mock := setupTestDB(t)
+	defer db.Close()
+	db.cfg.DBType = MySQL
+	db.cfg.KillOnCancel = true
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT CONNECTION_ID()")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(42))
+	mock.ExpectExec("SELECT SLEEP").
+		WillDelayFor(200 * time.Millisecond).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("KILL QUERY 42")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := db.Execute(ctx, "SELECT SLEEP(10)", nil)
+	require.Error(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestKillOnCancel_Completed(t *testing.T) {
+	db, mock := setupTestDB(t)
+	defer db.Close()
+	db.cfg.DBType = Postgres
+	db.cfg.KillOnCancel = true
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT pg_backend_pid()")).
+		WillReturnRows(sqlmock.NewRows([]string{"pid"}).AddRow(9))
+	mock.ExpectQuery("SELECT name FROM users").
+		WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("Alice"))
+
+	var names []string
+	require.NoError(t, db.GetList(context.Background(), &names, "SELECT name FROM users"))
+	assert.Equal(t, []string{"Alice"}, names)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,174 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/BevisDev/godev/metrics"
+	"github.com/jmoiron/sqlx"
+)
+
+// dbMetrics are the metrics recorded by UseMetrics.
+type dbMetrics struct {
+	queries   metrics.Timer
+	txs       metrics.Timer
+	txResults metrics.Counter
+	conflicts metrics.Counter
+	retries   metrics.Counter
+}
+
+// UseMetrics records the duration of every query run through the DB on p, as
+// db_query_duration_seconds labeled by database name, statement (select,
+// insert, update, delete, other) and status (ok, error).
+//
+// Transactions of RunTx are recorded as db_tx_duration_seconds and
+// db_tx_total by database and result (commit, rollback), and their deadlocks
+// and serialization failures as db_tx_conflicts_total and db_tx_retries_total
+// by database and kind (deadlock, serialization).
+//
+// Call it before the DB is used; several DBs may share the same provider.
+func (d *DB) UseMetrics(p metrics.Provider) {
+	d.metrics = &dbMetrics{
+		queries: p.Timer(metrics.Opts{
+			Name:   "db_query_duration_seconds",
+			Help:   "Duration of database queries.",
+			Labels: []string{"db", "statement", "status"},
+		}),
+		txs: p.Timer(metrics.Opts{
+			Name:   "db_tx_duration_seconds",
+			Help:   "Duration of database transactions.",
+			Labels: []string{"db", "result"},
+		}),
+		txResults: p.Counter(metrics.Opts{
+			Name:   "db_tx_total",
+			Help:   "Database transactions by result.",
+			Labels: []string{"db", "result"},
+		}),
+		conflicts: p.Counter(metrics.Opts{
+			Name:   "db_tx_conflicts_total",
+			Help:   "Transactions failed with a deadlock or serialization failure.",
+			Labels: []string{"db", "kind"},
+		}),
+		retries: p.Counter(metrics.Opts{
+			Name:   "db_tx_retries_total",
+			Help:   "Transactions run again after a deadlock or serialization failure.",
+			Labels: []string{"db", "kind"},
+		}),
+	}
+}
+
+// tx records a transaction that committed or rolled back.
+func (m *dbMetrics) tx(db string, d time.Duration, committed bool) {
+	if m == nil {
+		return
+	}
+	result := "rollback"
+	if committed {
+		result = "commit"
+	}
+	m.txs.Record(d, db, result)
+	m.txResults.Inc(db, result)
+}
+
+func (m *dbMetrics) txConflict(db, kind string) {
+	if m != nil {
+		m.conflicts.Inc(db, kind)
+	}
+}
+
+func (m *dbMetrics) txRetry(db, kind string) {
+	if m != nil {
+		m.retries.Inc(db, kind)
+	}
+}
+
+// observe wraps c for the dialect (see oracleConn) and to record query
+// metrics, when UseMetrics was called.
+func (d *DB) observe(c conn) conn {
+	if d.cfg.DBType == Oracle {
+		c = oracleConn{conn: c}
+	}
+	if d.metrics == nil {
+		return c
+	}
+	return meteredConn{conn: c, d: d}
+}
+
+// meteredConn records the duration of the queries run on conn.
+type meteredConn struct {
+	conn
+	d *DB
+}
+
+func (m meteredConn) record(start time.Time, query string, err error) {
+	if err == sql.ErrNoRows {
+		err = nil
+	}
+	m.d.metrics.queries.Record(time.Since(start), m.d.cfg.DBName, statement(query), metrics.Status(err))
+}
+
+func (m meteredConn) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	start := time.Now()
+	res, err := m.conn.ExecContext(ctx, query, args...)
+	m.record(start, query, err)
+	return res, err
+}
+
+func (m meteredConn) NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error) {
+	start := time.Now()
+	res, err := m.conn.NamedExecContext(ctx, query, arg)
+	m.record(start, query, err)
+	return res, err
+}
+
+func (m meteredConn) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	start := time.Now()
+	rows, err := m.conn.QueryContext(ctx, query, args...)
+	m.record(start, query, err)
+	return rows, err
+}
+
+func (m meteredConn) QueryxContext(ctx context.Context, query string, args ...interface{}) (*sqlx.Rows, error) {
+	start := time.Now()
+	rows, err := m.conn.QueryxContext(ctx, query, args...)
+	m.record(start, query, err)
+	return rows, err
+}
+
+func (m meteredConn) QueryRowxContext(ctx context.Context, query string, args ...interface{}) *sqlx.Row {
+	start := time.Now()
+	row := m.conn.QueryRowxContext(ctx, query, args...)
+	m.record(start, query, row.Err())
+	return row
+}
+
+func (m meteredConn) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	start := time.Now()
+	err := m.conn.GetContext(ctx, dest, query, args...)
+	m.record(start, query, err)
+	return err
+}
+
+func (m meteredConn) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	start := time.Now()
+	err := m.conn.SelectContext(ctx, dest, query, args...)
+	m.record(start, query, err)
+	return err
+}
+
+// statement returns the lower-cased leading keyword of query when it is a
+// common statement, "other" otherwise, keeping the label cardinality low.
+func statement(query string) string {
+	q := strings.TrimLeft(query, " \t\r\n(")
+	end := strings.IndexAny(q, " \t\r\n(")
+	if end < 0 {
+		end = len(q)
+	}
+	switch kw := strings.ToLower(q[:end]); kw {
+	case "select", "insert", "update", "delete", "merge", "with", "call", "exec":
+		return kw
+	}
+	return "other"
+}
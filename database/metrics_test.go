@@ -0,0 +1,51 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/BevisDev/godev/metrics/prometheus"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUseMetrics(t *testing.T) {
+	db, mock := setupTestDB(t)
+	db.cfg.DBName = "shop"
+	reg := prometheus.New()
+	db.UseMetrics(reg)
+
+	mock.ExpectQuery("SELECT name").WillReturnRows(sqlmock.NewRows([]string{"name", "email"}).AddRow("a", "a@x.vn"))
+	mock.ExpectExec("UPDATE users").WillReturnError(errors.New("deadlock"))
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO users").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	var users []User
+	require.NoError(t, db.GetList(context.Background(), &users, "SELECT name, email FROM users"))
+	assert.Error(t, db.Execute(context.Background(), "UPDATE users SET name = 'b'", nil))
+	require.NoError(t, db.RunTx(context.Background(), sql.LevelDefault, func(ctx context.Context, tx *sqlx.Tx) error {
+		return db.Execute(ctx, "INSERT INTO users (name) VALUES ('c')", tx)
+	}))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	var out strings.Builder
+	_, err := reg.WriteTo(&out)
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), `db_query_duration_seconds_count{db="shop",statement="select",status="ok"} 1`)
+	assert.Contains(t, out.String(), `db_query_duration_seconds_count{db="shop",statement="update",status="error"} 1`)
+	assert.Contains(t, out.String(), `db_query_duration_seconds_count{db="shop",statement="insert",status="ok"} 1`)
+}
+
+func TestStatement(t *testing.T) {
+	assert.Equal(t, "select", statement("\n  SELECT * FROM t"))
+	assert.Equal(t, "with", statement("WITH x AS (SELECT 1) SELECT * FROM x"))
+	assert.Equal(t, "select", statement("(select 1)"))
+	assert.Equal(t, "other", statement("TRUNCATE TABLE t"))
+	assert.Equal(t, "other", statement(""))
+}
@@ -21,6 +21,8 @@ type modelChain[T any] struct {
 	tableErr error
 	where    []string
 	args     []interface{}
+
+	tenantCol string // set by scoped, never updated
 }
 
 // Model creates a new model chain based on TableName() from type T.
@@ -44,6 +46,28 @@ func (m *modelChain[T]) clone() *modelChain[T] {
 	return &c
 }
 
+// scoped returns the chain with the tenant condition of ctx added (see DB.ScopeTenant).
+func (m *modelChain[T]) scoped(ctx context.Context) (*modelChain[T], error) {
+	col, id, ok, err := m.tenantScope(ctx, m.table)
+	if err != nil || !ok {
+		return m, err
+	}
+	c := m.clone()
+	c.where = scopeWhere(c.where, c.table, col)
+	c.args = append(c.args, id)
+	c.tenantCol = col
+	return c, nil
+}
+
+// scopedWrite is scoped for Updates and Delete, leaving chains without Where untouched
+// so they still fail with ErrMissingWhere.
+func (m *modelChain[T]) scopedWrite(ctx context.Context) (*modelChain[T], error) {
+	if len(m.where) == 0 {
+		return m, nil
+	}
+	return m.scoped(ctx)
+}
+
 func (m *modelChain[T]) ensureTable() error {
 	if m.tableErr != nil {
 		return m.tableErr
@@ -62,6 +86,14 @@ func (m *modelChain[T]) Where(cond string, args ...interface{}) ModelExec[T] {
 }
 
 func (m *modelChain[T]) First(ctx context.Context) (*T, error) {
+	q, err := m.scoped(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return q.first(ctx)
+}
+
+func (m *modelChain[T]) first(ctx context.Context) (*T, error) {
 	if err := m.ensureTable(); err != nil {
 		return nil, err
 	}
@@ -76,16 +108,24 @@ func (m *modelChain[T]) First(ctx context.Context) (*T, error) {
 	cctx, cancel := utils.NewCtxTimeout(ctx, m.cfg.Timeout)
 	defer cancel()
 
-	if err := m.db.GetContext(cctx, &obj, query, args...); err != nil {
+	if err := m.conn(cctx).GetContext(cctx, &obj, query, args...); err != nil {
 		if m.IsNoResult(err) {
 			return nil, nil
 		}
 		return nil, err
 	}
-	return &obj, nil
+	return &obj, m.decrypt(&obj)
 }
 
 func (m *modelChain[T]) Find(ctx context.Context) ([]*T, error) {
+	q, err := m.scoped(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return q.find(ctx)
+}
+
+func (m *modelChain[T]) find(ctx context.Context) ([]*T, error) {
 	if err := m.ensureTable(); err != nil {
 		return nil, err
 	}
@@ -100,13 +140,26 @@ func (m *modelChain[T]) Find(ctx context.Context) ([]*T, error) {
 	cctx, cancel := utils.NewCtxTimeout(ctx, m.cfg.Timeout)
 	defer cancel()
 
-	if err := m.db.SelectContext(cctx, &list, query, args...); err != nil {
+	if err := m.conn(cctx).SelectContext(cctx, &list, query, args...); err != nil {
 		return nil, err
 	}
-	return list, nil
+	return list, m.decrypt(&list)
 }
 
+// Create inserts data and, on success, invalidates the cached results of the table.
 func (m *modelChain[T]) Create(ctx context.Context, data any) (*T, error) {
+	out, err := m.create(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	m.invalidateAfterWrite(ctx, m.table)
+	if out == nil {
+		return nil, nil
+	}
+	return out, m.decrypt(out)
+}
+
+func (m *modelChain[T]) create(ctx context.Context, data any) (*T, error) {
 	if err := m.ensureTable(); err != nil {
 		return nil, err
 	}
@@ -114,6 +167,14 @@ func (m *modelChain[T]) Create(ctx context.Context, data any) (*T, error) {
 	if err != nil {
 		return nil, err
 	}
+	if col, id, ok, err := m.tenantScope(ctx, m.table); err != nil {
+		return nil, err
+	} else if ok {
+		cols, vals = withTenantValues(cols, vals, col, id)
+	}
+	if err := m.encryptValues(typeOf[T](), cols, vals); err != nil {
+		return nil, err
+	}
 
 	placeholders := make([]string, len(cols))
 	for i := range placeholders {
@@ -151,7 +212,7 @@ func (m *modelChain[T]) Create(ctx context.Context, data any) (*T, error) {
 	// If the DB supports RETURNING/OUTPUT, fetch the inserted row.
 	if m.cfg.DBType == Postgres || m.cfg.DBType == SqlServer {
 		var dest T
-		row := m.db.QueryRowxContext(cctx, query, vals...)
+		row := m.conn(cctx).QueryRowxContext(cctx, query, vals...)
 		if err := row.StructScan(&dest); err != nil {
 			return nil, err
 		}
@@ -159,7 +220,7 @@ func (m *modelChain[T]) Create(ctx context.Context, data any) (*T, error) {
 	}
 
 	// Default: execute and return nil (or fetch by id when available).
-	res, err := m.db.ExecContext(cctx, query, vals...)
+	res, err := m.conn(cctx).ExecContext(cctx, query, vals...)
 	if err != nil {
 		return nil, err
 	}
@@ -176,13 +237,21 @@ func (m *modelChain[T]) Create(ctx context.Context, data any) (*T, error) {
 	if err != nil {
 		return nil, err
 	}
-	if err := m.db.GetContext(cctx, &dest, q, args...); err != nil {
+	if err := m.conn(cctx).GetContext(cctx, &dest, q, args...); err != nil {
 		return nil, err
 	}
 	return &dest, nil
 }
 
 func (m *modelChain[T]) Updates(ctx context.Context, data any) (int64, error) {
+	q, err := m.scopedWrite(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return q.updates(ctx, data)
+}
+
+func (m *modelChain[T]) updates(ctx context.Context, data any) (int64, error) {
 	if err := m.ensureTable(); err != nil {
 		return 0, err
 	}
@@ -193,6 +262,13 @@ func (m *modelChain[T]) Updates(ctx context.Context, data any) (int64, error) {
 	if err != nil {
 		return 0, err
 	}
+	cols, vals = m.withoutTenant(cols, vals)
+	if len(cols) == 0 {
+		return 0, ErrMissingData
+	}
+	if err := m.encryptValues(typeOf[T](), cols, vals); err != nil {
+		return 0, err
+	}
 
 	setParts := make([]string, len(cols))
 	for i, col := range cols {
@@ -215,14 +291,23 @@ func (m *modelChain[T]) Updates(ctx context.Context, data any) (int64, error) {
 	cctx, cancel := utils.NewCtxTimeout(ctx, m.cfg.Timeout)
 	defer cancel()
 
-	res, err := m.db.ExecContext(cctx, query, vals...)
+	res, err := m.conn(cctx).ExecContext(cctx, query, vals...)
 	if err != nil {
 		return 0, err
 	}
+	m.invalidateAfterWrite(ctx, m.table)
 	return res.RowsAffected()
 }
 
 func (m *modelChain[T]) Delete(ctx context.Context) (int64, error) {
+	q, err := m.scopedWrite(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return q.delete(ctx)
+}
+
+func (m *modelChain[T]) delete(ctx context.Context) (int64, error) {
 	if err := m.ensureTable(); err != nil {
 		return 0, err
 	}
@@ -244,14 +329,23 @@ func (m *modelChain[T]) Delete(ctx context.Context) (int64, error) {
 	cctx, cancel := utils.NewCtxTimeout(ctx, m.cfg.Timeout)
 	defer cancel()
 
-	res, err := m.db.ExecContext(cctx, query, args...)
+	res, err := m.conn(cctx).ExecContext(cctx, query, args...)
 	if err != nil {
 		return 0, err
 	}
+	m.invalidateAfterWrite(ctx, m.table)
 	return res.RowsAffected()
 }
 
 func (m *modelChain[T]) Count(ctx context.Context) (int64, error) {
+	q, err := m.scoped(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return q.count(ctx)
+}
+
+func (m *modelChain[T]) count(ctx context.Context) (int64, error) {
 	if err := m.ensureTable(); err != nil {
 		return 0, err
 	}
@@ -270,7 +364,7 @@ func (m *modelChain[T]) Count(ctx context.Context) (int64, error) {
 	defer cancel()
 
 	var count int64
-	if err := m.db.GetContext(cctx, &count, query, args...); err != nil {
+	if err := m.conn(cctx).GetContext(cctx, &count, query, args...); err != nil {
 		return 0, err
 	}
 	return count, nil
@@ -290,12 +384,50 @@ func (m *modelChain[T]) buildSelect(limit int) (string, []interface{}) {
 		sb.WriteString(strings.Join(m.where, " AND "))
 	}
 
-	if m.cfg.DBType != SqlServer && limit > 0 {
+	if m.cfg.DBType == Oracle && limit > 0 {
+		sb.WriteString(fmt.Sprintf(" FETCH FIRST %d ROWS ONLY", limit))
+	} else if m.cfg.DBType != SqlServer && limit > 0 {
 		sb.WriteString(fmt.Sprintf(" LIMIT %d", limit))
 	}
 	return sb.String(), m.args
 }
 
+// page selects limit rows from offset ordered by order (required by SQL Server).
+func (m *modelChain[T]) page(ctx context.Context, order string, limit, offset int) ([]*T, error) {
+	if err := m.ensureTable(); err != nil {
+		return nil, err
+	}
+
+	var sb strings.Builder
+	sb.WriteString("SELECT * FROM ")
+	sb.WriteString(m.table)
+	if len(m.where) > 0 {
+		sb.WriteString(" WHERE ")
+		sb.WriteString(strings.Join(m.where, " AND "))
+	}
+	sb.WriteString(" ORDER BY ")
+	sb.WriteString(order)
+	if m.cfg.DBType == SqlServer || m.cfg.DBType == Oracle {
+		sb.WriteString(fmt.Sprintf(" OFFSET %d ROWS FETCH NEXT %d ROWS ONLY", offset, limit))
+	} else {
+		sb.WriteString(fmt.Sprintf(" LIMIT %d OFFSET %d", limit, offset))
+	}
+
+	query, args, err := m.rebind(sb.String(), m.args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var list []*T
+	cctx, cancel := utils.NewCtxTimeout(ctx, m.cfg.Timeout)
+	defer cancel()
+
+	if err := m.conn(cctx).SelectContext(cctx, &list, query, args...); err != nil {
+		return nil, err
+	}
+	return list, m.decrypt(&list)
+}
+
 func tableNameFor[T any]() (string, error) {
 	var zero T
 	candidates := []any{zero}
@@ -384,3 +516,19 @@ func extractColumnsAndValues(data any) ([]string, []interface{}, error) {
 		return nil, nil, fmt.Errorf("[database] unsupported data type: %s", v.Kind())
 	}
 }
+
+// withoutTenant drops the tenant column from an update, so rows cannot move to another tenant.
+func (m *modelChain[T]) withoutTenant(cols []string, vals []interface{}) ([]string, []interface{}) {
+	if m.tenantCol == "" {
+		return cols, vals
+	}
+	outCols := cols[:0:0]
+	outVals := vals[:0:0]
+	for i, col := range cols {
+		if !strings.EqualFold(col, m.tenantCol) {
+			outCols = append(outCols, col)
+			outVals = append(outVals, vals[i])
+		}
+	}
+	return outCols, outVals
+}
@@ -0,0 +1,112 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// oracleArrayBatch caps the rows sent in one array-bind execution.
+const oracleArrayBatch = 10000
+
+// oracleBindName matches the bind names of a named query rebound by sqlx.
+var oracleBindName = regexp.MustCompile(`:([A-Za-z_][A-Za-z0-9_$#]*)`)
+
+// oracleConn binds the named queries of Oracle by name: sqlx binds them by
+// position, which fails in PL/SQL blocks repeating a name.
+type oracleConn struct {
+	conn
+}
+
+func (c oracleConn) NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error) {
+	q, args, err := c.BindNamed(query, arg)
+	if err != nil {
+		return nil, err
+	}
+	return c.ExecContext(ctx, q, namedArgs(q, args)...)
+}
+
+// namedArgs returns args as sql.Named values, one per distinct bind name of
+// query. It keeps args positional when the names do not match them, e.g. a
+// literal containing a colon.
+func namedArgs(query string, args []interface{}) []interface{} {
+	names := oracleBindName.FindAllStringSubmatch(query, -1)
+	if len(names) != len(args) {
+		return args
+	}
+
+	seen := make(map[string]bool, len(names))
+	out := make([]interface{}, 0, len(args))
+	for i, m := range names {
+		if seen[m[1]] {
+			continue
+		}
+		seen[m[1]] = true
+		out = append(out, sql.Named(m[1], args[i]))
+	}
+	return out
+}
+
+// insertArrayBind inserts row rows with one statement executed with array
+// binds: each placeholder gets the slice of its column, in batches of
+// oracleArrayBatch rows.
+func (d *DB) insertArrayBind(ctx context.Context, tx *sqlx.Tx,
+	table string, colNames []string, row int, args []interface{},
+) error {
+	col := len(colNames)
+	placeholders := make([]string, col)
+	for j := range placeholders {
+		placeholders[j] = d.FormatRow(j + 1)
+	}
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
+		table, strings.Join(colNames, ", "), strings.Join(placeholders, ", "))
+	d.ViewQuery(query)
+
+	for start := 0; start < row; start += oracleArrayBatch {
+		end := min(start+oracleArrayBatch, row)
+		batch := columnSlices(args[start*col:end*col], col, end-start)
+		if _, err := d.txConn(tx).ExecContext(ctx, query, batch...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// columnSlices transposes row-major args into one slice per column. A column
+// whose values share one type gets a typed slice ([]string, []int64, ...);
+// columns with NULLs or mixed types get []interface{}.
+func columnSlices(args []interface{}, col, row int) []interface{} {
+	out := make([]interface{}, col)
+	for j := 0; j < col; j++ {
+		var typ reflect.Type
+		for i := 0; i < row; i++ {
+			v := args[i*col+j]
+			if v == nil || (typ != nil && reflect.TypeOf(v) != typ) {
+				typ = nil
+				break
+			}
+			typ = reflect.TypeOf(v)
+		}
+
+		if typ == nil {
+			vals := make([]interface{}, row)
+			for i := range vals {
+				vals[i] = args[i*col+j]
+			}
+			out[j] = vals
+			continue
+		}
+
+		vals := reflect.MakeSlice(reflect.SliceOf(typ), row, row)
+		for i := 0; i < row; i++ {
+			vals.Index(i).Set(reflect.ValueOf(args[i*col+j]))
+		}
+		out[j] = vals.Interface()
+	}
+	return out
+}
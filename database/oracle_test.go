@@ -0,0 +1,80 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// arrayConverter lets slices through, as godror does for array binds.
+type arrayConverter struct{}
+
+func (arrayConverter) ConvertValue(v interface{}) (driver.Value, error) {
+	return v, nil
+}
+
+func setupOracleDB(t *testing.T) (*DB, sqlmock.Sqlmock) {
+	t.Helper()
+	mockDB, mock, err := sqlmock.New(sqlmock.ValueConverterOption(arrayConverter{}))
+	require.NoError(t, err)
+	t.Cleanup(func() { mockDB.Close() })
+
+	db, err := FromSqlx(sqlx.NewDb(mockDB, "godror"), &Config{DBType: Oracle, Timeout: 5 * time.Second})
+	require.NoError(t, err)
+	return db, mock
+}
+
+func TestOracle_InsertBulkArrayBind(t *testing.T) {
+	db, mock := setupOracleDB(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO users \(name, age, note\) VALUES \(:1, :2, :3\)`).
+		WithArgs([]string{"An", "Binh"}, []int{30, 41}, []interface{}{"vip", nil}).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	err := db.InsertBulk(context.Background(), "users", 2, []string{"name", "age", "note"},
+		"An", 30, "vip",
+		"Binh", 41, nil,
+	)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOracle_NamedBinds(t *testing.T) {
+	db, mock := setupOracleDB(t)
+
+	mock.ExpectExec(`BEGIN UPDATE users SET name = :name WHERE id = :id; log_change\(:id\); END;`).
+		WithArgs(sql.Named("name", "An"), sql.Named("id", 7)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := db.Save(context.Background(), nil,
+		"BEGIN UPDATE users SET name = :name WHERE id = :id; log_change(:id); END;",
+		map[string]interface{}{"name": "An", "id": 7})
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOracle_LimitOffset(t *testing.T) {
+	db, _ := setupOracleDB(t)
+
+	query, _ := Builder[User](db).From("users").OrderBy("id").Offset(20).Limit(10).ToSql()
+	assert.Equal(t, "SELECT * FROM users ORDER BY id OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY", query)
+}
+
+func TestNamedArgs(t *testing.T) {
+	assert.Equal(t,
+		[]interface{}{sql.Named("a", 1), sql.Named("b", 2)},
+		namedArgs("SELECT :a, :b, :a FROM dual", []interface{}{1, 2, 1}))
+	assert.Equal(t,
+		[]interface{}{1},
+		namedArgs("SELECT TO_CHAR(d, 'HH24:MI') FROM t WHERE id = :id", []interface{}{1}),
+		"names not matching the args stay positional")
+}
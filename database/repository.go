@@ -0,0 +1,345 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// defaultPageSize is the page size List uses when Pagination.Size is not set.
+const defaultPageSize = 20
+
+// Repository provides CRUD for T on a table, derived from the `db` struct tags of T.
+//
+// The primary key is the field tagged `db:"<col>,pk"`, or the `id` column otherwise.
+// Queries go through Model, so tenant scoping, encrypted fields and cache
+// invalidation apply as for Model and Builder.
+type Repository[T any] struct {
+	db     *DB
+	table  string
+	fields []repoField
+	pk     *repoField
+	err    error
+}
+
+type repoField struct {
+	col   string
+	index []int
+	pk    bool
+}
+
+// Pagination selects a page for List. Page starts at 1; Size defaults to 20.
+// OrderBy is a comma separated list of "<column> [ASC|DESC]", defaulting to the primary key.
+type Pagination struct {
+	Page    int
+	Size    int
+	OrderBy string
+}
+
+// Page is a page of results returned by List.
+type Page[T any] struct {
+	Items []*T
+	Total int64
+	Page  int
+	Size  int
+}
+
+// NewRepository creates a repository of T on table.
+// Mapping errors (e.g. no primary key) are returned by the first call.
+func NewRepository[T any](db *DB, table string) *Repository[T] {
+	r := &Repository[T]{db: db, table: table}
+	if strings.TrimSpace(table) == "" {
+		r.err = ErrMissingTable
+		return r
+	}
+
+	t := typeOf[T]()
+	if t.Kind() != reflect.Struct {
+		r.err = fmt.Errorf("[database] repository type must be a struct, got %s", t.Kind())
+		return r
+	}
+
+	r.fields = repoFields(t, nil)
+	for i := range r.fields {
+		if r.fields[i].pk {
+			r.pk = &r.fields[i]
+			break
+		}
+	}
+	if r.pk == nil {
+		for i := range r.fields {
+			if strings.EqualFold(r.fields[i].col, "id") {
+				r.fields[i].pk = true
+				r.pk = &r.fields[i]
+				break
+			}
+		}
+	}
+	if r.pk == nil {
+		r.err = ErrMissingPrimaryKey
+	}
+	return r
+}
+
+// repoFields returns the mapped columns of t, flattening embedded structs like sqlx.
+func repoFields(t reflect.Type, index []int) []repoField {
+	var fields []repoField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := strings.TrimSpace(f.Tag.Get("db"))
+		if tag == "-" || (f.PkgPath != "" && !f.Anonymous) {
+			continue
+		}
+
+		idx := append(append([]int{}, index...), i)
+		if f.Anonymous && tag == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				fields = append(fields, repoFields(ft, idx)...)
+				continue
+			}
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		fields = append(fields, repoField{
+			col:   name,
+			index: idx,
+			pk:    hasTagOption(opts, "pk"),
+		})
+	}
+	return fields
+}
+
+func hasTagOption(opts, opt string) bool {
+	for _, o := range strings.Split(opts, ",") {
+		if strings.TrimSpace(o) == opt {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Repository[T]) model() *modelChain[T] {
+	return &modelChain[T]{DB: r.db, table: r.table}
+}
+
+func (r *Repository[T]) hasColumn(col string) bool {
+	for _, f := range r.fields {
+		if f.col == col {
+			return true
+		}
+	}
+	return false
+}
+
+// where adds "<col> = ?" (or IS NULL) for each condition, in column order.
+func (r *Repository[T]) where(conds map[string]any) (*modelChain[T], error) {
+	cols := make([]string, 0, len(conds))
+	for col := range conds {
+		if !r.hasColumn(col) {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownColumn, col)
+		}
+		cols = append(cols, col)
+	}
+	sort.Strings(cols)
+
+	m := r.model()
+	for _, col := range cols {
+		if v := conds[col]; v == nil {
+			m.where = append(m.where, col+" IS NULL")
+		} else {
+			m.where = append(m.where, col+" = ?")
+			m.args = append(m.args, v)
+		}
+	}
+	return m, nil
+}
+
+// values returns the columns of entity, without the primary key when skipPK is set
+// and without the tenant column, which is managed by ScopeTenant.
+func (r *Repository[T]) values(entity *T, skipPK bool) map[string]any {
+	rv := reflect.ValueOf(entity).Elem()
+	tenantCol := r.db.tenantColumn(r.table)
+
+	data := make(map[string]any, len(r.fields))
+	for _, f := range r.fields {
+		if (skipPK && f.pk) || (tenantCol != "" && f.col == tenantCol) {
+			continue
+		}
+		v, ok := fieldByIndex(rv, f.index)
+		if !ok {
+			continue
+		}
+		data[f.col] = v.Interface()
+	}
+	return data
+}
+
+func (r *Repository[T]) id(entity *T) reflect.Value {
+	v, _ := fieldByIndex(reflect.ValueOf(entity).Elem(), r.pk.index)
+	return v
+}
+
+// fieldByIndex is reflect.Value.FieldByIndex without panicking on nil embedded pointers.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// FindByID returns the row with the given primary key, or nil if none found.
+func (r *Repository[T]) FindByID(ctx context.Context, id any) (*T, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	return r.model().Where(r.pk.col+" = ?", id).First(ctx)
+}
+
+// FindBy returns the rows matching every column = value of conds (nil matches NULL).
+func (r *Repository[T]) FindBy(ctx context.Context, conds map[string]any) ([]*T, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	m, err := r.where(conds)
+	if err != nil {
+		return nil, err
+	}
+	return m.Find(ctx)
+}
+
+// Exists reports whether a row with the given primary key exists.
+func (r *Repository[T]) Exists(ctx context.Context, id any) (bool, error) {
+	if r.err != nil {
+		return false, r.err
+	}
+	n, err := r.model().Where(r.pk.col+" = ?", id).Count(ctx)
+	return n > 0, err
+}
+
+// Create inserts entity. A zero primary key is left to the database (auto increment).
+// Returns the inserted row when supported by the database, entity otherwise.
+func (r *Repository[T]) Create(ctx context.Context, entity *T) (*T, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	if entity == nil {
+		return nil, ErrMissingData
+	}
+
+	out, err := r.model().Create(ctx, r.values(entity, r.id(entity).IsZero()))
+	if err != nil {
+		return nil, err
+	}
+	if out == nil {
+		return entity, nil
+	}
+	return out, nil
+}
+
+// Update writes every column of entity to the row with its primary key.
+func (r *Repository[T]) Update(ctx context.Context, entity *T) (int64, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	if entity == nil {
+		return 0, ErrMissingData
+	}
+
+	id := r.id(entity)
+	if id.IsZero() {
+		return 0, ErrMissingPrimaryKey
+	}
+	return r.model().
+		Where(r.pk.col+" = ?", id.Interface()).
+		Updates(ctx, r.values(entity, true))
+}
+
+// Delete deletes the row with the given primary key.
+func (r *Repository[T]) Delete(ctx context.Context, id any) (int64, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	return r.model().Where(r.pk.col+" = ?", id).Delete(ctx)
+}
+
+// List returns a page of rows with the total number of rows.
+func (r *Repository[T]) List(ctx context.Context, p Pagination) (*Page[T], error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	if p.Page < 1 {
+		p.Page = 1
+	}
+	if p.Size <= 0 {
+		p.Size = defaultPageSize
+	}
+
+	order, err := r.orderBy(p.OrderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := r.model().scoped(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := m.count(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := m.page(ctx, order, p.Size, (p.Page-1)*p.Size)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Page[T]{
+		Items: items,
+		Total: total,
+		Page:  p.Page,
+		Size:  p.Size,
+	}, nil
+}
+
+// orderBy validates the columns of order so it can be written into the query.
+func (r *Repository[T]) orderBy(order string) (string, error) {
+	if strings.TrimSpace(order) == "" {
+		return r.pk.col, nil
+	}
+
+	parts := strings.Split(order, ",")
+	for i, part := range parts {
+		fields := strings.Fields(part)
+		if len(fields) == 0 || len(fields) > 2 || !r.hasColumn(fields[0]) {
+			return "", fmt.Errorf("%w: %q", ErrInvalidOrderBy, strings.TrimSpace(part))
+		}
+		if len(fields) == 2 {
+			dir := strings.ToUpper(fields[1])
+			if dir != "ASC" && dir != "DESC" {
+				return "", fmt.Errorf("%w: %q", ErrInvalidOrderBy, strings.TrimSpace(part))
+			}
+			fields[1] = dir
+		}
+		parts[i] = strings.Join(fields, " ")
+	}
+	return strings.Join(parts, ", "), nil
+}
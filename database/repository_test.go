@@ -0,0 +1,180 @@
+package database
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type RepoBase struct {
+	CreatedBy string `db:"created_by"`
+}
+
+type RepoUser struct {
+	UserID int    `db:"user_id,pk"`
+	Name   string `db:"name"`
+	Email  string `db:"email"`
+	RepoBase
+	Ignored string `db:"-"`
+}
+
+type RepoNoPK struct {
+	Name string `db:"name"`
+}
+
+func TestRepository_Mapping(t *testing.T) {
+	r := NewRepository[RepoUser](&DB{cfg: &Config{}}, "users")
+	require.NoError(t, r.err)
+	assert.Equal(t, "user_id", r.pk.col)
+
+	cols := make([]string, 0, len(r.fields))
+	for _, f := range r.fields {
+		cols = append(cols, f.col)
+	}
+	assert.Equal(t, []string{"user_id", "name", "email", "created_by"}, cols)
+
+	type WithID struct {
+		ID   int64  `db:"id"`
+		Name string `db:"name"`
+	}
+	assert.Equal(t, "id", NewRepository[WithID](&DB{cfg: &Config{}}, "t").pk.col)
+
+	_, err := NewRepository[RepoNoPK](&DB{cfg: &Config{}}, "t").FindByID(context.Background(), 1)
+	assert.ErrorIs(t, err, ErrMissingPrimaryKey)
+
+	_, err = NewRepository[RepoUser](&DB{cfg: &Config{}}, "").FindByID(context.Background(), 1)
+	assert.ErrorIs(t, err, ErrMissingTable)
+}
+
+func TestRepository_FindByID(t *testing.T) {
+	db, mock := setupTestDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT TOP 1 * FROM users WHERE user_id = ?")).
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "name", "email", "created_by"}).
+			AddRow(7, "Alice", "alice@example.com", "admin"))
+
+	user, err := NewRepository[RepoUser](db, "users").FindByID(context.Background(), 7)
+	require.NoError(t, err)
+	require.NotNil(t, user)
+	assert.Equal(t, "Alice", user.Name)
+	assert.Equal(t, "admin", user.CreatedBy)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_FindBy(t *testing.T) {
+	db, mock := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewRepository[RepoUser](db, "users")
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM users WHERE email IS NULL AND name = ?")).
+		WithArgs("Bob").
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "name"}).AddRow(1, "Bob"))
+
+	users, err := repo.FindBy(context.Background(), map[string]any{"name": "Bob", "email": nil})
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	_, err = repo.FindBy(context.Background(), map[string]any{"name; DROP TABLE users": 1})
+	assert.ErrorIs(t, err, ErrUnknownColumn)
+}
+
+func TestRepository_Exists(t *testing.T) {
+	db, mock := setupTestDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(1) FROM users WHERE user_id = ?")).
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	ok, err := NewRepository[RepoUser](db, "users").Exists(context.Background(), 7)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_Create(t *testing.T) {
+	db, mock := setupTestDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(
+		"INSERT INTO users (created_by, email, name) OUTPUT INSERTED.* VALUES (?, ?, ?)")).
+		WithArgs("admin", "alice@example.com", "Alice").
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "name", "email", "created_by"}).
+			AddRow(10, "Alice", "alice@example.com", "admin"))
+
+	user, err := NewRepository[RepoUser](db, "users").Create(context.Background(), &RepoUser{
+		Name:     "Alice",
+		Email:    "alice@example.com",
+		RepoBase: RepoBase{CreatedBy: "admin"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 10, user.UserID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_Update(t *testing.T) {
+	db, mock := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewRepository[RepoUser](db, "users")
+
+	mock.ExpectExec(regexp.QuoteMeta(
+		"UPDATE users SET created_by = ?, email = ?, name = ? WHERE user_id = ?")).
+		WithArgs("admin", "bob@example.com", "Bob", 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	n, err := repo.Update(context.Background(), &RepoUser{
+		UserID: 3, Name: "Bob", Email: "bob@example.com", RepoBase: RepoBase{CreatedBy: "admin"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	_, err = repo.Update(context.Background(), &RepoUser{Name: "no id"})
+	assert.ErrorIs(t, err, ErrMissingPrimaryKey)
+}
+
+func TestRepository_Delete(t *testing.T) {
+	db, mock := setupTestDB(t)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM users WHERE user_id = ?")).
+		WithArgs(3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	n, err := NewRepository[RepoUser](db, "users").Delete(context.Background(), 3)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_List(t *testing.T) {
+	db, mock := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewRepository[RepoUser](db, "users")
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(1) FROM users")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery(regexp.QuoteMeta(
+		"SELECT * FROM users ORDER BY name DESC, user_id OFFSET 2 ROWS FETCH NEXT 2 ROWS ONLY")).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "name"}).AddRow(1, "Alice"))
+
+	page, err := repo.List(context.Background(), Pagination{Page: 2, Size: 2, OrderBy: "name desc, user_id"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), page.Total)
+	assert.Equal(t, 2, page.Page)
+	assert.Len(t, page.Items, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	_, err = repo.List(context.Background(), Pagination{OrderBy: "name; DROP TABLE users"})
+	assert.ErrorIs(t, err, ErrInvalidOrderBy)
+}
@@ -0,0 +1,201 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BevisDev/godev/utils"
+)
+
+// GetRows executes a query and returns its rows as maps of column name to
+// value, for reports and admin endpoints whose columns are not known ahead.
+// Placeholders and IN clauses are handled like GetList.
+//
+// Values are normalized the same way on every database:
+//
+//	integer, float columns        int64, float64
+//	DECIMAL, NUMERIC, NUMBER      json.Number (exact, rendered as a JSON number)
+//	DATE, DATETIME, TIMESTAMP     time.Time
+//	JSON, JSONB                   json.RawMessage
+//	UNIQUEIDENTIFIER, UUID        string
+//	binary columns                []byte
+//	other text                    string
+//	NULL                          nil
+//
+// A query returning no rows returns an empty slice.
+func (d *DB) GetRows(c context.Context, query string, args ...interface{}) ([]map[string]any, error) {
+	query, newArgs, err := d.rebind(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := utils.NewCtxTimeout(c, d.cfg.Timeout)
+	defer cancel()
+
+	rows, err := d.conn(ctx).QueryxContext(ctx, query, newArgs...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanMaps(rows.Rows, d.cfg.DBType)
+}
+
+// scanMaps reads all rows into maps, normalizing each value by its column type.
+func scanMaps(rows *sql.Rows, dbType DBType) ([]map[string]any, error) {
+	cols, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]map[string]any, 0)
+	values := make([]any, len(cols))
+	ptrs := make([]any, len(cols))
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, err
+		}
+		m := make(map[string]any, len(cols))
+		for i, col := range cols {
+			v, err := normalizeValue(dbType, col.DatabaseTypeName(), values[i])
+			if err != nil {
+				return nil, fmt.Errorf("[database] failed to read column %s: %w", col.Name(), err)
+			}
+			m[col.Name()] = v
+		}
+		list = append(list, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// normalizeValue converts v, scanned from a column of type typeName, to the
+// Go type documented by GetRows. Drivers differ mostly in what they return as
+// []byte: MySQL returns every column as text without parseTime, Postgres and
+// SQL Server return decimals as text, SQL Server returns GUIDs as bytes.
+func normalizeValue(dbType DBType, typeName string, v any) (any, error) {
+	if v == nil {
+		return nil, nil
+	}
+	typeName = strings.ToUpper(typeName)
+
+	switch x := v.(type) {
+	case time.Time:
+		return x, nil
+	case []byte:
+		switch {
+		case typeName == "UNIQUEIDENTIFIER" && len(x) == 16:
+			return mssqlGUID(x), nil
+		case isBinaryType(typeName):
+			return append([]byte(nil), x...), nil
+		}
+		return normalizeText(dbType, typeName, string(x))
+	}
+
+	// driver-specific text types, e.g. godror.Number
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.String {
+		return normalizeText(dbType, typeName, rv.String())
+	}
+	return v, nil
+}
+
+// normalizeText converts the text form s of a column of type typeName.
+func normalizeText(dbType DBType, typeName, s string) (any, error) {
+	switch {
+	case isIntegerType(typeName):
+		return strconv.ParseInt(s, 10, 64)
+	case isFloatType(typeName):
+		return strconv.ParseFloat(s, 64)
+	case isDecimalType(typeName):
+		return json.Number(s), nil
+	case typeName == "JSON" || typeName == "JSONB":
+		if json.Valid([]byte(s)) {
+			return json.RawMessage(s), nil
+		}
+	case dbType == MySQL && isTimeType(typeName):
+		// DATETIME, TIMESTAMP and DATE as text, without parseTime=true
+		if t, err := parseMySQLTime(s); err == nil {
+			return t, nil
+		}
+	}
+	return s, nil
+}
+
+func isIntegerType(typeName string) bool {
+	switch typeName {
+	case "INT", "INTEGER", "TINYINT", "SMALLINT", "MEDIUMINT", "BIGINT",
+		"INT2", "INT4", "INT8", "UNSIGNED INT", "UNSIGNED TINYINT", "UNSIGNED SMALLINT",
+		"UNSIGNED MEDIUMINT", "UNSIGNED BIGINT", "YEAR":
+		return true
+	}
+	return false
+}
+
+func isFloatType(typeName string) bool {
+	switch typeName {
+	case "FLOAT", "DOUBLE", "REAL", "FLOAT4", "FLOAT8", "BINARY_FLOAT", "BINARY_DOUBLE":
+		return true
+	}
+	return false
+}
+
+func isDecimalType(typeName string) bool {
+	switch typeName {
+	case "DECIMAL", "NUMERIC", "NUMBER", "MONEY", "SMALLMONEY":
+		return true
+	}
+	return false
+}
+
+func isTimeType(typeName string) bool {
+	switch typeName {
+	case "DATETIME", "TIMESTAMP", "DATE":
+		return true
+	}
+	return false
+}
+
+func isBinaryType(typeName string) bool {
+	switch typeName {
+	case "BINARY", "VARBINARY", "IMAGE", "BYTEA", "BLOB", "TINYBLOB", "MEDIUMBLOB", "LONGBLOB", "RAW", "LONG RAW", "BIT":
+		return true
+	}
+	return false
+}
+
+// mysqlTimeLayouts are the text forms of MySQL DATETIME/TIMESTAMP (with up to
+// 6 fractional digits) and DATE values.
+var mysqlTimeLayouts = []string{"2006-01-02 15:04:05.999999", "2006-01-02"}
+
+// parseMySQLTime parses a MySQL time value in UTC, the loc default of the driver.
+func parseMySQLTime(s string) (time.Time, error) {
+	var err error
+	for _, layout := range mysqlTimeLayouts {
+		var t time.Time
+		if t, err = time.ParseInLocation(layout, s, time.UTC); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+// mssqlGUID formats the bytes of a SQL Server UNIQUEIDENTIFIER, whose first
+// three groups are little-endian.
+func mssqlGUID(b []byte) string {
+	return fmt.Sprintf("%X-%X-%X-%X-%X",
+		[]byte{b[3], b[2], b[1], b[0]},
+		[]byte{b[5], b[4]},
+		[]byte{b[7], b[6]},
+		b[8:10], b[10:])
+}
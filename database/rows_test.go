@@ -0,0 +1,105 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRows_MySQL(t *testing.T) {
+	db, mock := setupTestDB(t)
+	db.cfg.DBType = MySQL
+
+	// text protocol without parseTime: every value is []byte
+	rows := mock.NewRowsWithColumnDefinition(
+		sqlmock.NewColumn("id").OfType("BIGINT", []byte{}),
+		sqlmock.NewColumn("total").OfType("DECIMAL", []byte{}),
+		sqlmock.NewColumn("ratio").OfType("DOUBLE", []byte{}),
+		sqlmock.NewColumn("created_at").OfType("DATETIME", []byte{}),
+		sqlmock.NewColumn("meta").OfType("JSON", []byte{}),
+		sqlmock.NewColumn("name").OfType("VARCHAR", []byte{}),
+		sqlmock.NewColumn("note").OfType("VARCHAR", nil),
+	).AddRow([]byte("7"), []byte("12.50"), []byte("0.25"), []byte("2026-10-15 10:00:00.5"),
+		[]byte(`{"vip":true}`), []byte("An"), nil)
+	mock.ExpectQuery(`SELECT \* FROM orders WHERE id IN \(\?, \?\)`).WithArgs(7, 8).WillReturnRows(rows)
+
+	got, err := db.GetRows(context.Background(), "SELECT * FROM orders WHERE id IN (?)", []int{7, 8})
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+	assert.Equal(t, []map[string]any{{
+		"id":         int64(7),
+		"total":      json.Number("12.50"),
+		"ratio":      0.25,
+		"created_at": time.Date(2026, 10, 15, 10, 0, 0, 500_000_000, time.UTC),
+		"meta":       json.RawMessage(`{"vip":true}`),
+		"name":       "An",
+		"note":       nil,
+	}}, got)
+
+	out, err := json.Marshal(got)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"id":7,"total":12.50,"ratio":0.25,"created_at":"2026-10-15T10:00:00.5Z","meta":{"vip":true},"name":"An","note":null}]`, string(out))
+}
+
+func TestGetRows_SqlServer(t *testing.T) {
+	db, mock := setupTestDB(t)
+
+	rows := mock.NewRowsWithColumnDefinition(
+		sqlmock.NewColumn("id").OfType("UNIQUEIDENTIFIER", []byte{}),
+		sqlmock.NewColumn("amount").OfType("MONEY", []byte{}),
+		sqlmock.NewColumn("photo").OfType("VARBINARY", []byte{}),
+		sqlmock.NewColumn("count").OfType("INT", int64(0)),
+	).AddRow(
+		[]byte{0x67, 0x45, 0x23, 0x01, 0xAB, 0x89, 0xEF, 0xCD, 0x01, 0x23, 0x45, 0x67, 0x89, 0xAB, 0xCD, 0xEF},
+		[]byte("9.99"), []byte{0xFF, 0xD8}, int64(3))
+	mock.ExpectQuery(`SELECT id, amount, photo, count FROM items`).WillReturnRows(rows)
+
+	got, err := db.GetRows(context.Background(), "SELECT id, amount, photo, count FROM items")
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]any{{
+		"id":     "01234567-89AB-CDEF-0123-456789ABCDEF",
+		"amount": json.Number("9.99"),
+		"photo":  []byte{0xFF, 0xD8},
+		"count":  int64(3),
+	}}, got)
+}
+
+func TestGetRows_Empty(t *testing.T) {
+	db, mock := setupTestDB(t)
+	mock.ExpectQuery(`SELECT id FROM users`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	got, err := db.GetRows(context.Background(), "SELECT id FROM users")
+	require.NoError(t, err)
+	assert.NotNil(t, got)
+	assert.Empty(t, got)
+}
+
+func TestChain_FindAllMaps(t *testing.T) {
+	db, mock := setupTestDB(t)
+	db.cfg.DBType = Postgres
+
+	rows := mock.NewRowsWithColumnDefinition(
+		sqlmock.NewColumn("region").OfType("TEXT", ""),
+		sqlmock.NewColumn("revenue").OfType("NUMERIC", []byte{}),
+	).AddRow("north", []byte("1200.00")).AddRow("south", []byte("980.50"))
+	mock.ExpectQuery(`SELECT region, SUM\(total\) AS revenue FROM orders WHERE year = \? GROUP BY region LIMIT 10`).
+		WithArgs(2026).
+		WillReturnRows(rows)
+
+	got, err := Builder[User](db).From("orders").
+		Select("region", "SUM(total) AS revenue").
+		Where("year = ? GROUP BY region", 2026).
+		Limit(10).
+		FindAllMaps(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+	assert.Equal(t, []map[string]any{
+		{"region": "north", "revenue": json.Number("1200.00")},
+		{"region": "south", "revenue": json.Number("980.50")},
+	}, got)
+}
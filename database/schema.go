@@ -0,0 +1,243 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/BevisDev/godev/utils"
+)
+
+// Column describes a table column, normalized across databases.
+type Column struct {
+	Name     string
+	Type     string // lower-case data type, e.g. "varchar", "integer"
+	Nullable bool
+	Default  *string // nil when the column has no default
+	Position int     // 1-based ordinal position
+}
+
+// Index describes a table index, normalized across databases.
+type Index struct {
+	Name    string
+	Columns []string // in key order
+	Unique  bool
+	Primary bool
+}
+
+// ForeignKey is a reference from Table to RefTable.
+type ForeignKey struct {
+	Name     string
+	Table    string
+	RefTable string
+}
+
+// schemaQueries holds the introspection queries of a database type.
+// Columns and Indexes take the table name as their only argument.
+type schemaQueries struct {
+	tables      string
+	columns     string
+	indexes     string
+	foreignKeys string
+}
+
+var schemaQueriesByType = map[DBType]schemaQueries{
+	Postgres: {
+		tables: `SELECT table_name FROM information_schema.tables
+			WHERE table_schema = current_schema() AND table_type = 'BASE TABLE' ORDER BY table_name`,
+		columns: `SELECT column_name, data_type, is_nullable, column_default, ordinal_position
+			FROM information_schema.columns
+			WHERE table_schema = current_schema() AND table_name = ? ORDER BY ordinal_position`,
+		indexes: `SELECT i.relname, a.attname, ix.indisunique, ix.indisprimary
+			FROM pg_index ix
+			JOIN pg_class t ON t.oid = ix.indrelid
+			JOIN pg_class i ON i.oid = ix.indexrelid
+			JOIN pg_namespace n ON n.oid = t.relnamespace
+			JOIN LATERAL unnest(ix.indkey) WITH ORDINALITY AS k(attnum, ord) ON true
+			JOIN pg_attribute a ON a.attrelid = t.oid AND a.attnum = k.attnum
+			WHERE n.nspname = current_schema() AND t.relname = ? ORDER BY i.relname, k.ord`,
+		foreignKeys: `SELECT c.conname, t.relname, r.relname
+			FROM pg_constraint c
+			JOIN pg_class t ON t.oid = c.conrelid
+			JOIN pg_class r ON r.oid = c.confrelid
+			JOIN pg_namespace n ON n.oid = t.relnamespace
+			WHERE c.contype = 'f' AND n.nspname = current_schema() ORDER BY c.conname`,
+	},
+	MySQL: {
+		tables: `SELECT table_name FROM information_schema.tables
+			WHERE table_schema = DATABASE() AND table_type = 'BASE TABLE' ORDER BY table_name`,
+		columns: `SELECT column_name, data_type, is_nullable, column_default, ordinal_position
+			FROM information_schema.columns
+			WHERE table_schema = DATABASE() AND table_name = ? ORDER BY ordinal_position`,
+		indexes: `SELECT index_name, column_name, non_unique = 0, index_name = 'PRIMARY'
+			FROM information_schema.statistics
+			WHERE table_schema = DATABASE() AND table_name = ? ORDER BY index_name, seq_in_index`,
+		foreignKeys: `SELECT constraint_name, table_name, referenced_table_name
+			FROM information_schema.referential_constraints
+			WHERE constraint_schema = DATABASE() ORDER BY constraint_name`,
+	},
+	SqlServer: {
+		tables: `SELECT TABLE_NAME FROM INFORMATION_SCHEMA.TABLES
+			WHERE TABLE_SCHEMA = SCHEMA_NAME() AND TABLE_TYPE = 'BASE TABLE' ORDER BY TABLE_NAME`,
+		columns: `SELECT COLUMN_NAME, DATA_TYPE, IS_NULLABLE, COLUMN_DEFAULT, ORDINAL_POSITION
+			FROM INFORMATION_SCHEMA.COLUMNS
+			WHERE TABLE_SCHEMA = SCHEMA_NAME() AND TABLE_NAME = ? ORDER BY ORDINAL_POSITION`,
+		indexes: `SELECT i.name, c.name, i.is_unique, i.is_primary_key
+			FROM sys.indexes i
+			JOIN sys.index_columns ic ON ic.object_id = i.object_id AND ic.index_id = i.index_id
+			JOIN sys.columns c ON c.object_id = ic.object_id AND c.column_id = ic.column_id
+			WHERE i.object_id = OBJECT_ID(?) AND i.name IS NOT NULL AND ic.is_included_column = 0
+			ORDER BY i.name, ic.key_ordinal`,
+		foreignKeys: `SELECT name, OBJECT_NAME(parent_object_id), OBJECT_NAME(referenced_object_id)
+			FROM sys.foreign_keys WHERE schema_id = SCHEMA_ID() ORDER BY name`,
+	},
+	Oracle: {
+		tables: `SELECT table_name FROM user_tables ORDER BY table_name`,
+		columns: `SELECT column_name, data_type, nullable, data_default, column_id
+			FROM user_tab_columns WHERE table_name = UPPER(?) ORDER BY column_id`,
+		indexes: `SELECT i.index_name, c.column_name,
+				CASE WHEN i.uniqueness = 'UNIQUE' THEN 1 ELSE 0 END,
+				CASE WHEN k.constraint_type = 'P' THEN 1 ELSE 0 END
+			FROM user_indexes i
+			JOIN user_ind_columns c ON c.index_name = i.index_name
+			LEFT JOIN user_constraints k ON k.index_name = i.index_name AND k.constraint_type = 'P'
+			WHERE i.table_name = UPPER(?) ORDER BY i.index_name, c.column_position`,
+		foreignKeys: `SELECT a.constraint_name, a.table_name, r.table_name
+			FROM user_constraints a
+			JOIN user_constraints r ON r.constraint_name = a.r_constraint_name
+			WHERE a.constraint_type = 'R' ORDER BY a.constraint_name`,
+	},
+}
+
+func (d *DB) schemaQueries() (schemaQueries, error) {
+	q, ok := schemaQueriesByType[d.cfg.DBType]
+	if !ok {
+		return schemaQueries{}, ErrUnsupportedDBType
+	}
+	return q, nil
+}
+
+// queryRows runs query and calls scan for each row.
+func (d *DB) queryRows(c context.Context, query string, args []interface{}, scan func(*sql.Rows) error) error {
+	query, args, err := d.rebind(query, args...)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := utils.NewCtxTimeout(c, d.cfg.Timeout)
+	defer cancel()
+
+	rows, err := d.conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// Tables returns the base tables (not views) of the current schema, sorted by name.
+func (d *DB) Tables(ctx context.Context) ([]string, error) {
+	q, err := d.schemaQueries()
+	if err != nil {
+		return nil, err
+	}
+
+	var tables []string
+	err = d.queryRows(ctx, q.tables, nil, func(rows *sql.Rows) error {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return err
+		}
+		tables = append(tables, name)
+		return nil
+	})
+	return tables, err
+}
+
+// Columns returns the columns of table in ordinal order; empty when the table does not exist.
+func (d *DB) Columns(ctx context.Context, table string) ([]Column, error) {
+	q, err := d.schemaQueries()
+	if err != nil {
+		return nil, err
+	}
+
+	var cols []Column
+	err = d.queryRows(ctx, q.columns, []interface{}{table}, func(rows *sql.Rows) error {
+		var (
+			col      Column
+			nullable string
+			def      sql.NullString
+		)
+		if err := rows.Scan(&col.Name, &col.Type, &nullable, &def, &col.Position); err != nil {
+			return err
+		}
+		col.Type = strings.ToLower(col.Type)
+		// information_schema uses YES/NO, Oracle Y/N
+		col.Nullable = strings.HasPrefix(strings.ToUpper(nullable), "Y")
+		if def.Valid {
+			v := strings.TrimSpace(def.String)
+			col.Default = &v
+		}
+		cols = append(cols, col)
+		return nil
+	})
+	return cols, err
+}
+
+// Indexes returns the indexes of table sorted by name, with their columns in key order.
+func (d *DB) Indexes(ctx context.Context, table string) ([]Index, error) {
+	q, err := d.schemaQueries()
+	if err != nil {
+		return nil, err
+	}
+
+	var indexes []Index
+	err = d.queryRows(ctx, q.indexes, []interface{}{table}, func(rows *sql.Rows) error {
+		var (
+			name, col       string
+			unique, primary bool
+		)
+		if err := rows.Scan(&name, &col, &unique, &primary); err != nil {
+			return err
+		}
+
+		// rows are ordered by index, so a new name starts a new index
+		if n := len(indexes); n > 0 && indexes[n-1].Name == name {
+			indexes[n-1].Columns = append(indexes[n-1].Columns, col)
+			return nil
+		}
+		indexes = append(indexes, Index{
+			Name:    name,
+			Columns: []string{col},
+			Unique:  unique || primary,
+			Primary: primary,
+		})
+		return nil
+	})
+	return indexes, err
+}
+
+// ForeignKeys returns the foreign keys between the tables of the current schema, sorted by name.
+func (d *DB) ForeignKeys(ctx context.Context) ([]ForeignKey, error) {
+	q, err := d.schemaQueries()
+	if err != nil {
+		return nil, err
+	}
+
+	var fks []ForeignKey
+	err = d.queryRows(ctx, q.foreignKeys, nil, func(rows *sql.Rows) error {
+		var fk ForeignKey
+		if err := rows.Scan(&fk.Name, &fk.Table, &fk.RefTable); err != nil {
+			return err
+		}
+		fks = append(fks, fk)
+		return nil
+	})
+	return fks, err
+}
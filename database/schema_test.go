@@ -0,0 +1,85 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchema_Tables(t *testing.T) {
+	db, mock := setupTestDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery("FROM INFORMATION_SCHEMA.TABLES").
+		WillReturnRows(sqlmock.NewRows([]string{"TABLE_NAME"}).AddRow("orders").AddRow("users"))
+
+	tables, err := db.Tables(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"orders", "users"}, tables)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSchema_Columns(t *testing.T) {
+	db, mock := setupTestDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery("FROM INFORMATION_SCHEMA.COLUMNS").
+		WithArgs("users").
+		WillReturnRows(sqlmock.NewRows([]string{"COLUMN_NAME", "DATA_TYPE", "IS_NULLABLE", "COLUMN_DEFAULT", "ORDINAL_POSITION"}).
+			AddRow("id", "INT", "NO", nil, 1).
+			AddRow("status", "NVARCHAR", "YES", " ('new') ", 2))
+
+	cols, err := db.Columns(context.Background(), "users")
+	require.NoError(t, err)
+	require.Len(t, cols, 2)
+
+	assert.Equal(t, Column{Name: "id", Type: "int", Nullable: false, Position: 1}, cols[0])
+	assert.Equal(t, "nvarchar", cols[1].Type)
+	assert.True(t, cols[1].Nullable)
+	require.NotNil(t, cols[1].Default)
+	assert.Equal(t, "('new')", *cols[1].Default)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSchema_Indexes(t *testing.T) {
+	db, mock := setupTestDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery("FROM sys.indexes").
+		WithArgs("users").
+		WillReturnRows(sqlmock.NewRows([]string{"index", "column", "is_unique", "is_primary_key"}).
+			AddRow("ix_users_name_email", "name", false, false).
+			AddRow("ix_users_name_email", "email", false, false).
+			AddRow("pk_users", "id", false, true))
+
+	indexes, err := db.Indexes(context.Background(), "users")
+	require.NoError(t, err)
+	assert.Equal(t, []Index{
+		{Name: "ix_users_name_email", Columns: []string{"name", "email"}},
+		{Name: "pk_users", Columns: []string{"id"}, Unique: true, Primary: true},
+	}, indexes)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSchema_ForeignKeys(t *testing.T) {
+	db, mock := setupTestDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery("FROM sys.foreign_keys").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "table", "ref_table"}).
+			AddRow("fk_orders_users", "orders", "users"))
+
+	fks, err := db.ForeignKeys(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []ForeignKey{{Name: "fk_orders_users", Table: "orders", RefTable: "users"}}, fks)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSchema_UnsupportedDBType(t *testing.T) {
+	db := &DB{cfg: &Config{}}
+	_, err := db.Tables(context.Background())
+	assert.ErrorIs(t, err, ErrUnsupportedDBType)
+}
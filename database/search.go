@@ -0,0 +1,159 @@
+package database
+
+import (
+	"strings"
+)
+
+// SearchOption configures Chain.Search.
+type SearchOption func(*searchSpec)
+
+// searchSpec is the full-text condition of a chain, rendered by ToSql.
+type searchSpec struct {
+	cols     []string
+	term     string
+	rank     string // alias of the rank column, "" for none
+	like     bool
+	language string // Postgres text search configuration
+	key      string // SQL Server key column for CONTAINSTABLE
+}
+
+// WithSearchRank selects the relevance of each row as column alias and orders
+// the results by it, best first. T (or FindAllMaps) needs a field for alias.
+func WithSearchRank(alias string) SearchOption {
+	return func(s *searchSpec) {
+		s.rank = alias
+	}
+}
+
+// WithSearchLike matches with LIKE instead of full-text search, e.g. for
+// tables without a full-text index.
+func WithSearchLike() SearchOption {
+	return func(s *searchSpec) {
+		s.like = true
+	}
+}
+
+// WithSearchLanguage sets the Postgres text search configuration, e.g.
+// "english". Defaults to "simple".
+func WithSearchLanguage(config string) SearchOption {
+	return func(s *searchSpec) {
+		s.language = config
+	}
+}
+
+// WithSearchKey sets the unique key column that SQL Server ranking joins
+// CONTAINSTABLE on. Defaults to "id".
+func WithSearchKey(col string) SearchOption {
+	return func(s *searchSpec) {
+		s.key = col
+	}
+}
+
+// Search adds a full-text condition matching term in any of cols:
+//
+//	Postgres:   to_tsvector(...) @@ plainto_tsquery(...)
+//	MySQL:      MATCH (cols) AGAINST (term IN NATURAL LANGUAGE MODE)
+//	SQL Server: CONTAINS((cols), '"word" AND ...')
+//
+// Other databases, or WithSearchLike, match every word of term with a
+// case-insensitive LIKE in any of cols. A blank term adds no condition.
+func (d *Chain[T]) Search(cols []string, term string, opts ...SearchOption) ChainExec[T] {
+	c := d.clone()
+	if len(cols) == 0 || strings.TrimSpace(term) == "" {
+		c.search = nil
+		return c
+	}
+
+	s := &searchSpec{
+		cols:     cols,
+		term:     strings.TrimSpace(term),
+		language: "simple",
+		key:      "id",
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	c.search = s
+	return c
+}
+
+// searchSQL returns the condition of s and, with a rank alias, the rank
+// expression, with their args.
+func (d *Chain[T]) searchSQL(s *searchSpec) (cond string, condArgs []interface{}, rank string, rankArgs []interface{}) {
+	cols := strings.Join(s.cols, ", ")
+	dbType := d.cfg.DBType
+	if s.like {
+		dbType = 0
+	}
+
+	switch dbType {
+	case Postgres:
+		lang := "'" + strings.ReplaceAll(s.language, "'", "''") + "'"
+		doc := "to_tsvector(" + lang + ", concat_ws(' ', " + cols + "))"
+		query := "plainto_tsquery(" + lang + ", ?)"
+		return doc + " @@ " + query, []interface{}{s.term},
+			"ts_rank(" + doc + ", " + query + ")", []interface{}{s.term}
+
+	case MySQL:
+		match := "MATCH (" + cols + ") AGAINST (? IN NATURAL LANGUAGE MODE)"
+		return match, []interface{}{s.term}, match, []interface{}{s.term}
+
+	case SqlServer:
+		term := containsTerm(s.term)
+		return "CONTAINS((" + cols + "), ?)", []interface{}{term},
+			d.containsRank(s), []interface{}{term}
+
+	default:
+		return likeSearch(s)
+	}
+}
+
+// containsRank reads the rank of the row from CONTAINSTABLE by its key.
+func (d *Chain[T]) containsRank(s *searchSpec) string {
+	fields := strings.Fields(d.table)
+	table, ref := fields[0], fields[len(fields)-1]
+
+	// CONTAINSTABLE takes unqualified column names
+	cols := make([]string, len(s.cols))
+	for i, col := range s.cols {
+		cols[i] = col[strings.LastIndex(col, ".")+1:]
+	}
+	return "(SELECT ft.[RANK] FROM CONTAINSTABLE(" + table + ", (" + strings.Join(cols, ", ") + "), ?) AS ft" +
+		" WHERE ft.[KEY] = " + ref + "." + s.key + ")"
+}
+
+// containsTerm quotes each word of term for CONTAINS, so user input cannot
+// break the search condition syntax.
+func containsTerm(term string) string {
+	words := strings.Fields(term)
+	for i, w := range words {
+		words[i] = `"` + strings.ReplaceAll(w, `"`, `""`) + `"`
+	}
+	return strings.Join(words, " AND ")
+}
+
+// likeSearch matches every word of s.term in any of s.cols; the rank is the
+// number of matching word and column pairs.
+func likeSearch(s *searchSpec) (cond string, condArgs []interface{}, rank string, rankArgs []interface{}) {
+	var conds, ranks []string
+	for _, w := range strings.Fields(s.term) {
+		pattern := "%" + escapeLike(strings.ToLower(w)) + "%"
+
+		ors := make([]string, len(s.cols))
+		for i, col := range s.cols {
+			match := "LOWER(" + col + ") LIKE ? ESCAPE '!'"
+			ors[i] = match
+			condArgs = append(condArgs, pattern)
+			ranks = append(ranks, "CASE WHEN "+match+" THEN 1 ELSE 0 END")
+			rankArgs = append(rankArgs, pattern)
+		}
+		conds = append(conds, "("+strings.Join(ors, " OR ")+")")
+	}
+	return strings.Join(conds, " AND "), condArgs, "(" + strings.Join(ranks, " + ") + ")", rankArgs
+}
+
+// escapeLike escapes the LIKE wildcards of s with '!', an escape character
+// every database accepts in ESCAPE.
+func escapeLike(s string) string {
+	return strings.NewReplacer("!", "!!", "%", "!%", "_", "!_", "[", "![").Replace(s)
+}
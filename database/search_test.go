@@ -0,0 +1,133 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChain_Search(t *testing.T) {
+	cols := []string{"title", "body"}
+
+	for _, tt := range []struct {
+		name   string
+		dbType DBType
+		opts   []SearchOption
+		query  string
+		args   []interface{}
+	}{
+		{
+			name:   "Postgres",
+			dbType: Postgres,
+			opts:   []SearchOption{WithSearchLanguage("english")},
+			query: "SELECT * FROM posts WHERE status = ? AND " +
+				"to_tsvector('english', concat_ws(' ', title, body)) @@ plainto_tsquery('english', ?)",
+			args: []interface{}{"published", "go generics"},
+		},
+		{
+			name:   "MySQL",
+			dbType: MySQL,
+			query:  "SELECT * FROM posts WHERE status = ? AND MATCH (title, body) AGAINST (? IN NATURAL LANGUAGE MODE)",
+			args:   []interface{}{"published", "go generics"},
+		},
+		{
+			name:   "SqlServer",
+			dbType: SqlServer,
+			query:  "SELECT * FROM posts WHERE status = ? AND CONTAINS((title, body), ?)",
+			args:   []interface{}{"published", `"go" AND "generics"`},
+		},
+		{
+			name:   "Oracle falls back to LIKE",
+			dbType: Oracle,
+			query: "SELECT * FROM posts WHERE status = ? AND " +
+				"(LOWER(title) LIKE ? ESCAPE '!' OR LOWER(body) LIKE ? ESCAPE '!') AND " +
+				"(LOWER(title) LIKE ? ESCAPE '!' OR LOWER(body) LIKE ? ESCAPE '!')",
+			args: []interface{}{"published", "%go%", "%go%", "%generics%", "%generics%"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &DB{cfg: &Config{DBType: tt.dbType}}
+			query, args := Builder[User](db).From("posts").
+				Where("status = ?", "published").
+				Search(cols, "  go generics ", tt.opts...).
+				ToSql()
+			assert.Equal(t, tt.query, query)
+			assert.Equal(t, tt.args, args)
+		})
+	}
+}
+
+func TestChain_SearchRank(t *testing.T) {
+	t.Run("Postgres", func(t *testing.T) {
+		db := &DB{cfg: &Config{DBType: Postgres}}
+		query, args := Builder[User](db).From("posts").Select("id", "title").
+			Where("status = ?", "published").
+			Search([]string{"title"}, "go", WithSearchRank("score")).
+			OrderBy("id").
+			ToSql()
+		assert.Equal(t, "SELECT id, title, ts_rank(to_tsvector('simple', concat_ws(' ', title)), plainto_tsquery('simple', ?)) AS score"+
+			" FROM posts WHERE status = ? AND to_tsvector('simple', concat_ws(' ', title)) @@ plainto_tsquery('simple', ?)"+
+			" ORDER BY score DESC, id", query)
+		assert.Equal(t, []interface{}{"go", "published", "go"}, args)
+	})
+
+	t.Run("SqlServer", func(t *testing.T) {
+		db := &DB{cfg: &Config{DBType: SqlServer}}
+		query, args := Builder[User](db).From("dbo.posts p").Select("p.id").
+			Search([]string{"p.title"}, "go", WithSearchRank("score"), WithSearchKey("post_id")).
+			ToSql()
+		assert.Equal(t, "SELECT p.id, (SELECT ft.[RANK] FROM CONTAINSTABLE(dbo.posts, (title), ?) AS ft WHERE ft.[KEY] = p.post_id) AS score"+
+			" FROM dbo.posts p WHERE CONTAINS((p.title), ?) ORDER BY score DESC", query)
+		assert.Equal(t, []interface{}{`"go"`, `"go"`}, args)
+	})
+
+	t.Run("LIKE", func(t *testing.T) {
+		db := &DB{cfg: &Config{DBType: MySQL}}
+		query, args := Builder[User](db).From("posts").Select("id").
+			Search([]string{"title", "body"}, "100%", WithSearchLike(), WithSearchRank("score")).
+			ToSql()
+		assert.Equal(t, "SELECT id, (CASE WHEN LOWER(title) LIKE ? ESCAPE '!' THEN 1 ELSE 0 END + CASE WHEN LOWER(body) LIKE ? ESCAPE '!' THEN 1 ELSE 0 END) AS score"+
+			" FROM posts WHERE (LOWER(title) LIKE ? ESCAPE '!' OR LOWER(body) LIKE ? ESCAPE '!') ORDER BY score DESC", query)
+		assert.Equal(t, []interface{}{"%100!%%", "%100!%%", "%100!%%", "%100!%%"}, args)
+	})
+}
+
+func TestChain_SearchBlank(t *testing.T) {
+	db := &DB{cfg: &Config{DBType: Postgres}}
+	query, args := Builder[User](db).From("posts").Search([]string{"title"}, "   ").ToSql()
+	assert.Equal(t, "SELECT * FROM posts", query)
+	assert.Empty(t, args)
+}
+
+func TestChain_SearchFindAllMaps(t *testing.T) {
+	db, mock := setupTestDB(t)
+	db.cfg.DBType = MySQL
+
+	mock.ExpectQuery(`SELECT id, title, MATCH \(title\) AGAINST \(\? IN NATURAL LANGUAGE MODE\) AS score FROM posts `+
+		`WHERE MATCH \(title\) AGAINST \(\? IN NATURAL LANGUAGE MODE\) ORDER BY score DESC LIMIT 10`).
+		WithArgs("golang", "golang").
+		WillReturnRows(mock.NewRowsWithColumnDefinition(
+			sqlmock.NewColumn("id").OfType("BIGINT", []byte{}),
+			sqlmock.NewColumn("title").OfType("VARCHAR", []byte{}),
+			sqlmock.NewColumn("score").OfType("DOUBLE", []byte{}),
+		).AddRow([]byte("7"), []byte("Golang tips"), []byte("0.9")))
+
+	rows, err := Builder[User](db).From("posts").Select("id", "title").
+		Search([]string{"title"}, "golang", WithSearchRank("score")).
+		Limit(10).
+		FindAllMaps(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+	assert.Equal(t, []map[string]any{{"id": int64(7), "title": "Golang tips", "score": 0.9}}, rows)
+}
+
+func TestChain_SearchRefusesWrites(t *testing.T) {
+	db, _ := setupTestDB(t)
+	q := Builder[User](db).From("posts").Select("title").Where("id = ?", 1).Search([]string{"title"}, "go")
+
+	_, err := q.Update(context.Background(), map[string]interface{}{"title": "Go"})
+	assert.ErrorIs(t, err, ErrSearchWrite)
+}
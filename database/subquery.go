@@ -0,0 +1,96 @@
+package database
+
+import (
+	"context"
+	"strings"
+)
+
+// Subquery is a query usable as a Where argument: it is rendered as "(<query>)" in
+// place of its placeholder and its args are merged in order. Builder chains implement it.
+//
+//	orders := database.Builder[Order](db).From("orders").Select("user_id").Where("total > ?", 100)
+//	database.Builder[User](db).From("users").Where("id IN ?", orders).FindAll(ctx)
+type Subquery interface {
+	ToSql() (string, []interface{})
+}
+
+// subqueryScoper is implemented by subqueries that apply the tenant scope of ctx.
+type subqueryScoper interface {
+	scopeSubquery(ctx context.Context) (Subquery, error)
+}
+
+func (d *Chain[T]) scopeSubquery(ctx context.Context) (Subquery, error) {
+	return d.scoped(ctx)
+}
+
+// WhereExists adds "EXISTS (<sub>)"; sub may reference the outer table (correlated).
+func (d *Chain[T]) WhereExists(sub Subquery) ChainExec[T] {
+	return d.Where("EXISTS ?", sub)
+}
+
+// WhereNotExists adds "NOT EXISTS (<sub>)".
+func (d *Chain[T]) WhereNotExists(sub Subquery) ChainExec[T] {
+	return d.Where("NOT EXISTS ?", sub)
+}
+
+// scopedArgs applies the tenant scope of ctx to the subqueries in the args.
+func (d *Chain[T]) scopedArgs(ctx context.Context) (*Chain[T], error) {
+	c := d
+	for i, arg := range d.args {
+		s, ok := arg.(subqueryScoper)
+		if !ok {
+			continue
+		}
+		sub, err := s.scopeSubquery(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if c == d {
+			c = d.clone()
+		}
+		c.args[i] = sub
+	}
+	return c, nil
+}
+
+// expandSubqueries renders the Subquery args of query in place of their "?"
+// placeholder and merges their args, before the query is rebound for the dialect.
+func expandSubqueries(query string, args []interface{}) (string, []interface{}) {
+	has := false
+	for _, arg := range args {
+		if _, ok := arg.(Subquery); ok {
+			has = true
+			break
+		}
+	}
+	if !has {
+		return query, args
+	}
+
+	var (
+		sb  strings.Builder
+		out = make([]interface{}, 0, len(args))
+		i   int
+	)
+	for _, ch := range query {
+		if ch != '?' || i >= len(args) {
+			sb.WriteRune(ch)
+			continue
+		}
+
+		if sub, ok := args[i].(Subquery); ok {
+			subQuery, subArgs := sub.ToSql()
+			subQuery, subArgs = expandSubqueries(subQuery, subArgs)
+			sb.WriteString("(")
+			sb.WriteString(subQuery)
+			sb.WriteString(")")
+			out = append(out, subArgs...)
+		} else {
+			sb.WriteRune(ch)
+			out = append(out, args[i])
+		}
+		i++
+	}
+	out = append(out, args[i:]...)
+	return sb.String(), out
+}
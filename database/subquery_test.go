@@ -0,0 +1,107 @@
+package database
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/BevisDev/godev/tenant"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChain_WhereSubquery(t *testing.T) {
+	db, mock := setupTestDB(t)
+	defer db.Close()
+
+	orders := Builder[Order](db).From("orders").Select("user_id").Where("code = ?", "A1")
+	chain := Builder[User](db).From("users").
+		Where("age > ?", 18).
+		Where("id IN ?", orders).
+		Where("name <> ?", "root")
+
+	query, args := chain.ToSql()
+	assert.Equal(t, "SELECT * FROM users WHERE age > ? AND id IN (SELECT user_id FROM orders WHERE code = ?) AND name <> ?", query)
+	assert.Equal(t, []interface{}{18, "A1", "root"}, args)
+
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(18, "A1", "root").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "email"}).AddRow("Alice", "a@example.com"))
+
+	users, err := chain.FindAll(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, users, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestChain_WhereExists(t *testing.T) {
+	db, _ := setupTestDB(t)
+	defer db.Close()
+
+	sub := Builder[Order](db).From("orders o").Select("1").Where("o.user_id = u.id").Where("o.code = ?", "A1")
+
+	query, args := Builder[User](db).From("users u").WhereExists(sub).Where("u.name = ?", "Bob").ToSql()
+	assert.Equal(t, "SELECT * FROM users u WHERE EXISTS (SELECT 1 FROM orders o WHERE o.user_id = u.id AND o.code = ?) AND u.name = ?", query)
+	assert.Equal(t, []interface{}{"A1", "Bob"}, args)
+
+	query, _ = Builder[User](db).From("users u").WhereNotExists(sub).ToSql()
+	assert.Equal(t, "SELECT * FROM users u WHERE NOT EXISTS (SELECT 1 FROM orders o WHERE o.user_id = u.id AND o.code = ?)", query)
+}
+
+func TestChain_NestedSubquery(t *testing.T) {
+	db, _ := setupTestDB(t)
+	defer db.Close()
+
+	codes := Builder[Order](db).From("codes").Select("code").Where("active = ?", true)
+	orders := Builder[Order](db).From("orders").Select("user_id").Where("code IN ?", codes)
+
+	query, args := Builder[User](db).From("users").Where("id IN ?", orders).ToSql()
+	assert.Equal(t, "SELECT * FROM users WHERE id IN (SELECT user_id FROM orders WHERE code IN (SELECT code FROM codes WHERE active = ?))", query)
+	assert.Equal(t, []interface{}{true}, args)
+}
+
+func TestChain_SubqueryUpdateAndDelete(t *testing.T) {
+	db, mock := setupTestDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	orders := Builder[Order](db).From("orders").Select("user_id").Where("code = ?", "A1")
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE users SET name = ? WHERE id IN (SELECT user_id FROM orders WHERE code = ?)")).
+		WithArgs("Bob", "A1").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	n, err := Builder[User](db).From("users").Select("name").Where("id IN ?", orders).
+		Update(ctx, map[string]interface{}{"name": "Bob"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM users WHERE id IN (SELECT user_id FROM orders WHERE code = ?)")).
+		WithArgs("A1").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	n, err = Builder[User](db).From("users").Where("id IN ?", orders).(*Chain[User]).Delete(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestChain_SubqueryTenantScope(t *testing.T) {
+	db, mock := setupTestDB(t)
+	defer db.Close()
+	db.ScopeTenant("orders")
+
+	ctx := tenant.WithTenant(context.Background(), "acme")
+	orders := Builder[Order](db).From("orders").Select("user_id").Where("code = ?", "A1")
+
+	mock.ExpectQuery(regexp.QuoteMeta(
+		"SELECT * FROM users WHERE id IN (SELECT user_id FROM orders WHERE (code = ?) AND tenant_id = ?)")).
+		WithArgs("A1", "acme").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "email"}))
+
+	_, err := Builder[User](db).From("users").Where("id IN ?", orders).FindAll(ctx)
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	_, err = Builder[User](db).From("users").Where("id IN ?", orders).FindAll(context.Background())
+	assert.ErrorIs(t, err, ErrMissingTenant)
+}
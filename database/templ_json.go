@@ -21,6 +21,10 @@ var TemplateDBMap = map[DBType]map[TemplateJSON]string{
 		TemplateJSONArray:  MySQLJSONArrayTemplate,
 		TemplateJSONObject: MySQLJSONObjectTemplate,
 	},
+	Oracle: {
+		TemplateJSONArray:  OracleJSONArrayTemplate,
+		TemplateJSONObject: OracleJSONObjectTemplate,
+	},
 }
 
 // MSSQL templates
@@ -85,3 +89,25 @@ const (
 	%s
 	`
 )
+
+// Oracle templates (19c+, JSON_OBJECT with a wildcard) wrap the query like Postgres.
+// OracleJSONArrayTemplate returns a JSON array as a CLOB, '[]' when there are no rows.
+// OracleJSONObjectTemplate returns a single JSON object as a CLOB.
+const (
+	OracleJSONArrayTemplate = `
+	SELECT COALESCE(
+		JSON_ARRAYAGG(JSON_OBJECT(t.* RETURNING CLOB) RETURNING CLOB),
+		TO_CLOB('[]')
+	) AS data
+	FROM (
+		%s
+	) t
+	`
+
+	OracleJSONObjectTemplate = `
+	SELECT JSON_OBJECT(t.* RETURNING CLOB) AS data
+	FROM (
+		%s
+	) t
+	`
+)
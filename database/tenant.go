@@ -0,0 +1,185 @@
+package database
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	"github.com/BevisDev/godev/tenant"
+)
+
+// DefaultTenantColumn is the tenant column used when ScopeTenant is called without one.
+const DefaultTenantColumn = "tenant_id"
+
+// ScopeTenant makes every Builder and Model query on table filter by the tenant of the
+// context (tenant.FromContext), using column (default "tenant_id"):
+//
+//   - First, FindAll, Count, Update and Delete add "AND <column> = <tenant>"
+//   - Insert and Create set the column to the tenant
+//
+// Queries on a scoped table without a tenant in the context fail with ErrMissingTenant,
+// unless the context is marked with tenant.Bypass. Raw SQL (GetList, Execute, ...) is not scoped.
+//
+// The table of From is matched by its name, so From("orders o") and From("orders AS o")
+// are scoped too and the condition uses the alias. Update and Updates never change the
+// tenant column of a scoped table.
+func (d *DB) ScopeTenant(table string, column ...string) {
+	col := DefaultTenantColumn
+	if len(column) > 0 && column[0] != "" {
+		col = column[0]
+	}
+	if d.tenants == nil {
+		d.tenants = make(map[string]string)
+	}
+	d.tenants[strings.ToLower(table)] = col
+}
+
+// tenantColumn returns the tenant column of the table of from ("orders", "orders o",
+// "orders AS o", "dbo.orders"), or "" when it is not scoped.
+func (d *DB) tenantColumn(from string) string {
+	name, _ := splitTable(from)
+	if col, ok := d.tenants[name]; ok {
+		return col
+	}
+	// schema-qualified name of a table scoped without schema
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		return d.tenants[name[i+1:]]
+	}
+	return ""
+}
+
+// splitTable returns the lower-case table name of from and its alias, if any.
+func splitTable(from string) (name, alias string) {
+	fields := strings.Fields(from)
+	if len(fields) == 0 {
+		return "", ""
+	}
+	name = strings.ToLower(fields[0])
+	switch {
+	case len(fields) > 2 && strings.EqualFold(fields[1], "AS"):
+		alias = fields[2]
+	case len(fields) > 1 && !isJoinKeyword(fields[1]):
+		alias = fields[1]
+	}
+	return name, alias
+}
+
+func isJoinKeyword(s string) bool {
+	switch strings.ToUpper(s) {
+	case "JOIN", "INNER", "LEFT", "RIGHT", "FULL", "CROSS", "OUTER", "NATURAL", "WHERE", "WITH":
+		return true
+	}
+	return false
+}
+
+// tenantCond returns the tenant condition on the table of from, qualified by its alias.
+func tenantCond(from, col string) string {
+	if _, alias := splitTable(from); alias != "" {
+		col = alias + "." + col
+	}
+	return col + " = ?"
+}
+
+// scopeWhere returns where AND-ed with the tenant condition; the conditions of the caller
+// are grouped so an OR among them cannot escape the tenant.
+func scopeWhere(where []string, from, col string) []string {
+	if len(where) == 0 {
+		return []string{tenantCond(from, col)}
+	}
+	return []string{"(" + strings.Join(where, " AND ") + ")", tenantCond(from, col)}
+}
+
+// tenantScope returns the tenant column and ID to apply on table; ok is false when
+// the table is not scoped or the context bypasses tenancy.
+func (d *DB) tenantScope(ctx context.Context, table string) (col, id string, ok bool, err error) {
+	col = d.tenantColumn(table)
+	if col == "" || tenant.IsBypassed(ctx) {
+		return "", "", false, nil
+	}
+	id = tenant.FromContext(ctx)
+	if id == "" {
+		return "", "", false, ErrMissingTenant
+	}
+	return col, id, true, nil
+}
+
+// withTenantData returns data with the tenant column set to id, overriding any other
+// tenant so rows cannot be written for another tenant. Structs and maps are copied
+// so the caller's value is not modified.
+func withTenantData(data any, col, id string) any {
+	if m, ok := data.(map[string]interface{}); ok {
+		out := make(map[string]interface{}, len(m)+1)
+		for k, v := range m {
+			out[k] = v
+		}
+		out[col] = id
+		return out
+	}
+
+	rv := reflect.ValueOf(data)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return data
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return data
+	}
+
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() || strings.Split(f.Tag.Get("db"), ",")[0] != col {
+			continue
+		}
+
+		cp := reflect.New(t).Elem()
+		cp.Set(rv)
+		fv := cp.Field(i)
+		switch {
+		case fv.Kind() == reflect.String:
+			fv.SetString(id)
+		case fv.Kind() == reflect.Ptr && fv.Type().Elem().Kind() == reflect.String:
+			fv.Set(reflect.ValueOf(&id))
+		default:
+			return data
+		}
+		return cp.Addr().Interface()
+	}
+	return data
+}
+
+// withTenantValues sets the tenant column in cols/vals, appending it when missing.
+func withTenantValues(cols []string, vals []interface{}, col, id string) ([]string, []interface{}) {
+	for i, c := range cols {
+		if strings.EqualFold(c, col) {
+			vals[i] = id
+			return cols, vals
+		}
+	}
+	return append(cols, col), append(vals, id)
+}
+
+// withoutColumn returns fields without col, so an update cannot move rows to another tenant.
+func withoutColumn(fields map[string]interface{}, col string) map[string]interface{} {
+	if col == "" {
+		return fields
+	}
+	out := make(map[string]interface{}, len(fields))
+	for k, v := range fields {
+		if !strings.EqualFold(k, col) {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,193 @@
+package database
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/BevisDev/godev/tenant"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Order struct {
+	ID       int    `db:"id"`
+	TenantID string `db:"tenant_id"`
+	Code     string `db:"code"`
+}
+
+func (Order) TableName() string { return "orders" }
+
+func TestTenant_ChainRead(t *testing.T) {
+	db, mock := setupTestDB(t)
+	defer db.Close()
+	db.ScopeTenant("orders")
+
+	ctx := tenant.WithTenant(context.Background(), "acme")
+
+	mock.ExpectQuery("SELECT \\* FROM orders WHERE \\(code = \\?\\) AND tenant_id = \\?").
+		WithArgs("A1", "acme").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "tenant_id", "code"}).AddRow(1, "acme", "A1"))
+
+	list, err := Builder[Order](db).From("orders").Where("code = ?", "A1").FindAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTenant_OrCondition(t *testing.T) {
+	db, mock := setupTestDB(t)
+	defer db.Close()
+	db.ScopeTenant("orders")
+	ctx := tenant.WithTenant(context.Background(), "acme")
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM orders WHERE (status = ? OR public = ? AND code <> ?) AND tenant_id = ?")).
+		WithArgs("open", true, "X", "acme").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "tenant_id", "code"}))
+
+	_, err := Builder[Order](db).From("orders").Where("status = ? OR public = ?", "open", true).
+		Where("code <> ?", "X").FindAll(ctx)
+	require.NoError(t, err)
+
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM orders WHERE (status = ? OR public = ?) AND tenant_id = ?")).
+		WithArgs("closed", true, "acme").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	_, err = Model[Order](db).Where("status = ? OR public = ?", "closed", true).Delete(ctx)
+	require.NoError(t, err)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(1) FROM orders WHERE (status = ? OR public = ?) AND tenant_id = ?")).
+		WithArgs("open", true, "acme").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	_, err = Model[Order](db).Where("status = ? OR public = ?", "open", true).Count(ctx)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTenant_AliasedTable(t *testing.T) {
+	db, mock := setupTestDB(t)
+	defer db.Close()
+	db.ScopeTenant("orders")
+	ctx := tenant.WithTenant(context.Background(), "acme")
+
+	for _, from := range []string{"orders o", "orders AS o", "ORDERS o"} {
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM "+from+" WHERE (o.code = ?) AND o.tenant_id = ?")).
+			WithArgs("A1", "acme").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "tenant_id", "code"}))
+
+		_, err := Builder[Order](db).From(from).Where("o.code = ?", "A1").FindAll(ctx)
+		require.NoError(t, err, from)
+
+		_, err = Builder[Order](db).From(from).FindAll(context.Background())
+		assert.ErrorIs(t, err, ErrMissingTenant, from)
+	}
+
+	_, err := Builder[Order](db).From("dbo.orders").FindAll(context.Background())
+	assert.ErrorIs(t, err, ErrMissingTenant, "schema-qualified name")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTenant_MissingAndBypass(t *testing.T) {
+	db, mock := setupTestDB(t)
+	defer db.Close()
+	db.ScopeTenant("orders")
+
+	_, err := Builder[Order](db).From("orders").FindAll(context.Background())
+	assert.ErrorIs(t, err, ErrMissingTenant)
+
+	// unscoped tables are not affected
+	mock.ExpectQuery("SELECT \\* FROM users$").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "email"}))
+	_, err = Builder[User](db).From("users").FindAll(context.Background())
+	require.NoError(t, err)
+
+	mock.ExpectQuery("SELECT \\* FROM orders$").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "tenant_id", "code"}))
+	_, err = Builder[Order](db).From("orders").FindAll(tenant.Bypass(context.Background()))
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTenant_ChainWrite(t *testing.T) {
+	db, mock := setupTestDB(t)
+	defer db.Close()
+	db.ScopeTenant("orders")
+	ctx := tenant.WithTenant(context.Background(), "acme")
+
+	t.Run("update is scoped", func(t *testing.T) {
+		mock.ExpectExec("UPDATE orders SET code = \\? WHERE \\(id = \\?\\) AND tenant_id = \\?").
+			WithArgs("B2", 1, "acme").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		_, err := Builder[Order](db).From("orders").Select("code").Where("id = ?", 1).
+			Update(ctx, map[string]interface{}{"code": "B2"})
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("update never changes the tenant", func(t *testing.T) {
+		mock.ExpectExec("UPDATE orders SET code = \\? WHERE \\(id = \\?\\) AND tenant_id = \\?").
+			WithArgs("B2", 1, "acme").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		_, err := Builder[Order](db).From("orders").Select("code", "tenant_id").Where("id = ?", 1).
+			Update(ctx, map[string]interface{}{"code": "B2", "TENANT_ID": "other"})
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+
+		_, err = Builder[Order](db).From("orders").Select("tenant_id").Where("id = ?", 1).
+			Update(ctx, map[string]interface{}{"tenant_id": "other"})
+		assert.ErrorIs(t, err, ErrMissingData)
+	})
+
+	t.Run("update without where still fails", func(t *testing.T) {
+		_, err := Builder[Order](db).From("orders").Select("code").
+			Update(ctx, map[string]interface{}{"code": "B2"})
+		assert.ErrorIs(t, err, ErrMissingWhere)
+	})
+
+	t.Run("insert sets the tenant", func(t *testing.T) {
+		mock.ExpectExec("INSERT INTO orders \\(code, tenant_id\\)").
+			WithArgs("C3", "acme").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		in := Order{Code: "C3", TenantID: "other"}
+		_, err := Builder[Order](db).From("orders").Select("code").Insert(ctx, in)
+		require.NoError(t, err)
+		assert.Equal(t, "other", in.TenantID, "caller's value is not modified")
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestTenant_Model(t *testing.T) {
+	db, mock := setupTestDB(t)
+	defer db.Close()
+	db.ScopeTenant("orders")
+	ctx := tenant.WithTenant(context.Background(), "acme")
+
+	mock.ExpectQuery("SELECT COUNT\\(1\\) FROM orders WHERE tenant_id = \\?").
+		WithArgs("acme").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	n, err := Model[Order](db).Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+
+	mock.ExpectQuery("INSERT INTO orders \\(code, id, tenant_id\\) OUTPUT INSERTED.\\*").
+		WithArgs("D4", 0, "acme").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "tenant_id", "code"}).AddRow(9, "acme", "D4"))
+
+	created, err := Model[Order](db).Create(ctx, map[string]interface{}{"id": 0, "code": "D4"})
+	require.NoError(t, err)
+	assert.Equal(t, "acme", created.TenantID)
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE orders SET code = ? WHERE (id = ?) AND tenant_id = ?")).
+		WithArgs("D5", 9, "acme").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	_, err = Model[Order](db).Where("id = ?", 9).Updates(ctx, map[string]interface{}{"code": "D5", "tenant_id": "other"})
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
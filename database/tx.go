@@ -0,0 +1,105 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// txKey keys the transaction of a DB in a context, so a context can carry the
+// transactions of several databases without one joining another's.
+type txKey struct {
+	db *DB
+}
+
+// txState is the transaction of a context. afterCommit holds the functions to
+// run once RunTx commits; it is only used for transactions RunTx manages.
+type txState struct {
+	tx      *sqlx.Tx
+	managed bool
+
+	mu          sync.Mutex
+	afterCommit []func()
+}
+
+// conn is implemented by both *sqlx.DB and *sqlx.Tx.
+type conn interface {
+	sqlx.ExtContext
+	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error)
+	PreparexContext(ctx context.Context, query string) (*sqlx.Stmt, error)
+}
+
+// WithTx returns a copy of ctx carrying tx, a transaction begun on d. Queries of d
+// run with that context (GetList, GetAny, Execute, Save, Builder, Model, ...) use tx
+// instead of the pool; queries of other databases ignore it.
+//
+// RunTx does this for its callback, so repository calls made inside it join the transaction:
+//
+//	err := db.RunTx(ctx, sql.LevelDefault, func(ctx context.Context, _ *sqlx.Tx) error {
+//		if err := orders.Create(ctx, o); err != nil { // uses the transaction
+//			return err
+//		}
+//		return stock.Reserve(ctx, o.Items) // same transaction
+//	})
+func (d *DB) WithTx(ctx context.Context, tx *sqlx.Tx) context.Context {
+	return context.WithValue(ctx, txKey{db: d}, &txState{tx: tx})
+}
+
+// TxFrom returns the transaction of d stored by WithTx, or nil.
+func (d *DB) TxFrom(ctx context.Context) *sqlx.Tx {
+	if s := d.txState(ctx); s != nil {
+		return s.tx
+	}
+	return nil
+}
+
+func (d *DB) txState(ctx context.Context) *txState {
+	if ctx == nil {
+		return nil
+	}
+	s, _ := ctx.Value(txKey{db: d}).(*txState)
+	return s
+}
+
+// afterCommit defers fn until the RunTx transaction of ctx commits; it is dropped
+// on rollback. It reports false when ctx has no such transaction.
+func (d *DB) afterCommit(ctx context.Context, fn func()) bool {
+	s := d.txState(ctx)
+	if s == nil || !s.managed {
+		return false
+	}
+	s.mu.Lock()
+	s.afterCommit = append(s.afterCommit, fn)
+	s.mu.Unlock()
+	return true
+}
+
+// committed runs the functions deferred with afterCommit.
+func (s *txState) committed() {
+	s.mu.Lock()
+	fns := s.afterCommit
+	s.afterCommit = nil
+	s.mu.Unlock()
+	for _, fn := range fns {
+		fn()
+	}
+}
+
+// conn returns the transaction of d in ctx, or the connection pool.
+func (d *DB) conn(ctx context.Context) conn {
+	if tx := d.TxFrom(ctx); tx != nil {
+		return d.txConn(tx)
+	}
+	if !d.connected.Load() {
+		// LazyConnect: on failure the query reports its own connection error
+		_ = d.Connect(ctx)
+	}
+	if d.cfg.KillOnCancel && d.cfg.DBType.SessionIDQuery() != "" {
+		return d.observe(killableConn{DB: d.db, d: d})
+	}
+	return d.observe(d.db)
+}
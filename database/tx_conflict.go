@@ -0,0 +1,255 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"math/rand/v2"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/BevisDev/godev/utils/ctxmeta"
+	"github.com/jmoiron/sqlx"
+)
+
+// Conflict kinds of a failed transaction.
+const (
+	conflictDeadlock      = "deadlock"
+	conflictSerialization = "serialization"
+)
+
+const (
+	// txRetryBackoff is the base wait before a transaction is run again.
+	txRetryBackoff = 20 * time.Millisecond
+
+	// diagnoseTimeout bounds the lock query of Config.DeadlockDiagnostics.
+	diagnoseTimeout = 5 * time.Second
+
+	// maxLoggedStatement caps the statement written to the conflict log.
+	maxLoggedStatement = 4096
+)
+
+// IsDeadlock reports whether err is a deadlock reported by the database:
+// Postgres 40P01, MySQL 1213, SQL Server 1205 or Oracle ORA-00060.
+func IsDeadlock(err error) bool {
+	return conflictKind(err) == conflictDeadlock
+}
+
+// IsSerializationFailure reports whether err is a serialization failure of a
+// transaction: Postgres 40001, SQL Server snapshot update conflict (3960) or
+// Oracle ORA-08177. Such transactions succeed when run again.
+func IsSerializationFailure(err error) bool {
+	return conflictKind(err) == conflictSerialization
+}
+
+// conflictKind classifies err as a deadlock, a serialization failure or "".
+// It reads the SQLSTATE or error number exposed by the drivers and falls back
+// to the message, so no driver package is imported.
+func conflictKind(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var state interface{ SQLState() string }
+	if errors.As(err, &state) {
+		switch state.SQLState() {
+		case "40P01":
+			return conflictDeadlock
+		case "40001":
+			return conflictSerialization
+		}
+	}
+	var number interface{ SQLErrorNumber() int32 }
+	if errors.As(err, &number) {
+		switch number.SQLErrorNumber() {
+		case 1205:
+			return conflictDeadlock
+		case 3960:
+			return conflictSerialization
+		}
+	}
+
+	msg := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(msg, "deadlock"), strings.Contains(msg, "ora-00060"):
+		return conflictDeadlock
+	case strings.Contains(msg, "serialize access"),
+		strings.Contains(msg, "ora-08177"),
+		strings.Contains(msg, "aborted due to update conflict"):
+		return conflictSerialization
+	}
+	return ""
+}
+
+// txRetryDelay returns the wait before running a transaction again after
+// attempt, growing linearly with jitter so the conflicting transactions do
+// not collide again.
+func txRetryDelay(attempt int) time.Duration {
+	return time.Duration(attempt+1)*txRetryBackoff + rand.N(txRetryBackoff)
+}
+
+// txConflict is the log entry of a deadlock or serialization failure.
+type txConflict struct {
+	DB        string `json:"db"`
+	Kind      string `json:"kind"`
+	Attempt   int    `json:"attempt"`
+	Isolation string `json:"isolation"`
+	Duration  string `json:"duration"`
+	RID       string `json:"rid,omitempty"`
+	Statement string `json:"statement,omitempty"`
+	Error     string `json:"error"`
+	Locks     any    `json:"locks,omitempty"`
+}
+
+// logConflict writes c as one JSON log line, with the lock information of the
+// database when Config.DeadlockDiagnostics is set.
+func (d *DB) logConflict(ctx context.Context, c *txConflict) {
+	c.RID = ctxmeta.RID(ctx)
+	if d.cfg.DeadlockDiagnostics {
+		locks, err := d.lockInfo()
+		if err != nil {
+			locks = "failed to read lock information: " + err.Error()
+		}
+		c.Locks = locks
+	}
+
+	b, err := json.Marshal(c)
+	if err != nil {
+		log.Printf("[database] transaction %s on %s: %s", c.Kind, c.DB, c.Error)
+		return
+	}
+	log.Printf("[database] transaction conflict: %s", b)
+}
+
+// lockInfo runs the lock query of the database on the pool. MySQL returns
+// the latest deadlock section of the InnoDB status, other databases rows.
+func (d *DB) lockInfo() (any, error) {
+	query := d.cfg.DBType.LockInfoQuery()
+	if query == "" {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), diagnoseTimeout)
+	defer cancel()
+
+	rows, err := d.db.QueryxContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []map[string]any
+	for rows.Next() {
+		row := make(map[string]any)
+		if err := rows.MapScan(row); err != nil {
+			return nil, err
+		}
+		for k, v := range row {
+			if b, ok := v.([]byte); ok {
+				row[k] = string(b)
+			}
+		}
+		out = append(out, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if d.cfg.DBType == MySQL && len(out) == 1 {
+		if status, ok := out[0]["Status"].(string); ok {
+			return latestDeadlock(status), nil
+		}
+	}
+	return out, nil
+}
+
+// latestDeadlock extracts the LATEST DETECTED DEADLOCK section of the output
+// of SHOW ENGINE INNODB STATUS.
+func latestDeadlock(status string) string {
+	_, section, ok := strings.Cut(status, "LATEST DETECTED DEADLOCK")
+	if !ok {
+		return ""
+	}
+	section, _, _ = strings.Cut(section, "\nTRANSACTIONS\n")
+	return strings.Trim(section, "-\n")
+}
+
+type txTraceKey struct{}
+
+// txTrace records the last statement run in a transaction of RunTx.
+type txTrace struct {
+	mu   sync.Mutex
+	last string
+}
+
+func withTxTrace(ctx context.Context, t *txTrace) context.Context {
+	return context.WithValue(ctx, txTraceKey{}, t)
+}
+
+// recordStatement stores query in the txTrace of ctx, if any.
+func recordStatement(ctx context.Context, query string) {
+	if t, ok := ctx.Value(txTraceKey{}).(*txTrace); ok {
+		t.mu.Lock()
+		t.last = query
+		t.mu.Unlock()
+	}
+}
+
+func (t *txTrace) statement() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.last) > maxLoggedStatement {
+		return t.last[:maxLoggedStatement] + "..."
+	}
+	return t.last
+}
+
+// txConn wraps tx to record the statements run with a context of RunTx, so a
+// conflict log shows the failing one. Statements run on the *sqlx.Tx passed
+// to the callback directly are not recorded.
+func (d *DB) txConn(tx *sqlx.Tx) conn {
+	return d.observe(tracedConn{conn: tx})
+}
+
+// tracedConn records each statement in the txTrace of its context.
+type tracedConn struct {
+	conn
+}
+
+func (c tracedConn) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	recordStatement(ctx, query)
+	return c.conn.ExecContext(ctx, query, args...)
+}
+
+func (c tracedConn) NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error) {
+	recordStatement(ctx, query)
+	return c.conn.NamedExecContext(ctx, query, arg)
+}
+
+func (c tracedConn) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	recordStatement(ctx, query)
+	return c.conn.QueryContext(ctx, query, args...)
+}
+
+func (c tracedConn) QueryxContext(ctx context.Context, query string, args ...interface{}) (*sqlx.Rows, error) {
+	recordStatement(ctx, query)
+	return c.conn.QueryxContext(ctx, query, args...)
+}
+
+func (c tracedConn) QueryRowxContext(ctx context.Context, query string, args ...interface{}) *sqlx.Row {
+	recordStatement(ctx, query)
+	return c.conn.QueryRowxContext(ctx, query, args...)
+}
+
+func (c tracedConn) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	recordStatement(ctx, query)
+	return c.conn.GetContext(ctx, dest, query, args...)
+}
+
+func (c tracedConn) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	recordStatement(ctx, query)
+	return c.conn.SelectContext(ctx, dest, query, args...)
+}
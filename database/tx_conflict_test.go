@@ -0,0 +1,150 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/BevisDev/godev/metrics/prometheus"
+	"github.com/BevisDev/godev/utils/ctxmeta"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type pgError struct{ code string }
+
+func (e *pgError) Error() string    { return "pg error " + e.code }
+func (e *pgError) SQLState() string { return e.code }
+
+type mssqlError struct{ number int32 }
+
+func (e mssqlError) Error() string         { return fmt.Sprintf("mssql error %d", e.number) }
+func (e mssqlError) SQLErrorNumber() int32 { return e.number }
+
+func TestConflictKind(t *testing.T) {
+	for _, tt := range []struct {
+		err  error
+		kind string
+	}{
+		{nil, ""},
+		{errors.New("duplicate key"), ""},
+		{&pgError{"40P01"}, conflictDeadlock},
+		{fmt.Errorf("update stock: %w", &pgError{"40001"}), conflictSerialization},
+		{&pgError{"23505"}, ""},
+		{mssqlError{1205}, conflictDeadlock},
+		{mssqlError{3960}, conflictSerialization},
+		{errors.New("Error 1213 (40001): Deadlock found when trying to get lock; try restarting transaction"), conflictDeadlock},
+		{errors.New("pq: could not serialize access due to concurrent update"), conflictSerialization},
+		{errors.New("ORA-00060: deadlock detected while waiting for resource"), conflictDeadlock},
+		{errors.New("ORA-08177: can't serialize access for this transaction"), conflictSerialization},
+	} {
+		assert.Equal(t, tt.kind, conflictKind(tt.err), "%v", tt.err)
+	}
+	assert.True(t, IsDeadlock(mssqlError{1205}))
+	assert.True(t, IsSerializationFailure(&pgError{"40001"}))
+}
+
+func captureLog(t *testing.T) *bytes.Buffer {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+	return &buf
+}
+
+func TestRunTx_RetriesConflict(t *testing.T) {
+	db, mock := setupTestDB(t)
+	db.cfg.DBName = "shop"
+	db.cfg.TxRetries = 2
+	reg := prometheus.New()
+	db.UseMetrics(reg)
+	logs := captureLog(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE stock").WillReturnError(&pgError{"40P01"})
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE stock").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	attempts := 0
+	ctx := ctxmeta.WithRID(context.Background(), "rid-1")
+	err := db.RunTx(ctx, sql.LevelReadCommitted, func(ctx context.Context, _ *sqlx.Tx) error {
+		attempts++
+		return db.Execute(ctx, "UPDATE stock SET qty = qty - 1 WHERE id = 7", nil)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	line := strings.TrimSpace(logs.String())
+	_, entry, ok := strings.Cut(line, "transaction conflict: ")
+	require.True(t, ok, line)
+	var got txConflict
+	require.NoError(t, json.Unmarshal([]byte(entry), &got))
+	assert.Equal(t, "shop", got.DB)
+	assert.Equal(t, conflictDeadlock, got.Kind)
+	assert.Equal(t, 1, got.Attempt)
+	assert.Equal(t, "Read Committed", got.Isolation)
+	assert.Equal(t, "rid-1", got.RID)
+	assert.Equal(t, "UPDATE stock SET qty = qty - 1 WHERE id = 7", got.Statement)
+
+	var out strings.Builder
+	_, err = reg.WriteTo(&out)
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), `db_tx_total{db="shop",result="rollback"} 1`)
+	assert.Contains(t, out.String(), `db_tx_total{db="shop",result="commit"} 1`)
+	assert.Contains(t, out.String(), `db_tx_conflicts_total{db="shop",kind="deadlock"} 1`)
+	assert.Contains(t, out.String(), `db_tx_retries_total{db="shop",kind="deadlock"} 1`)
+	assert.Contains(t, out.String(), `db_tx_duration_seconds_count{db="shop",result="commit"} 1`)
+}
+
+func TestRunTx_ConflictWithoutRetry(t *testing.T) {
+	db, mock := setupTestDB(t)
+	db.cfg.DBType = MySQL
+	db.cfg.DeadlockDiagnostics = true
+	logs := captureLog(t)
+
+	mock.ExpectBegin()
+	mock.ExpectCommit().WillReturnError(errors.New("Error 1213 (40001): Deadlock found when trying to get lock"))
+	mock.ExpectQuery("SHOW ENGINE INNODB STATUS").WillReturnRows(
+		sqlmock.NewRows([]string{"Type", "Name", "Status"}).AddRow("InnoDB", "", innodbStatus))
+
+	err := db.RunTx(context.Background(), sql.LevelDefault, func(ctx context.Context, _ *sqlx.Tx) error {
+		return nil
+	})
+	require.Error(t, err)
+	assert.True(t, IsDeadlock(err))
+	require.NoError(t, mock.ExpectationsWereMet())
+	assert.Contains(t, logs.String(), `"locks":"2026-10-15 10:00:00 0x7f\n*** (1) TRANSACTION:`)
+}
+
+const innodbStatus = `=====================================
+2026-10-15 10:00:01 INNODB MONITOR OUTPUT
+=====================================
+------------------------
+LATEST DETECTED DEADLOCK
+------------------------
+2026-10-15 10:00:00 0x7f
+*** (1) TRANSACTION:
+UPDATE stock SET qty = qty - 1 WHERE id = 7
+*** WE ROLL BACK TRANSACTION (1)
+------------
+TRANSACTIONS
+------------
+Trx id counter 1
+`
+
+func TestLatestDeadlock(t *testing.T) {
+	assert.Equal(t, "2026-10-15 10:00:00 0x7f\n*** (1) TRANSACTION:\nUPDATE stock SET qty = qty - 1 WHERE id = 7\n*** WE ROLL BACK TRANSACTION (1)",
+		latestDeadlock(innodbStatus))
+	assert.Empty(t, latestDeadlock("no deadlock"))
+}
@@ -0,0 +1,141 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/BevisDev/godev/errreport"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxFrom(t *testing.T) {
+	db := &DB{}
+	assert.Nil(t, db.TxFrom(context.Background()))
+
+	tx := &sqlx.Tx{}
+	ctx := db.WithTx(context.Background(), tx)
+	assert.Same(t, tx, db.TxFrom(ctx))
+	assert.Nil(t, (&DB{}).TxFrom(ctx), "transaction of another DB")
+}
+
+func TestRunTx_OtherDBDoesNotJoin(t *testing.T) {
+	dbA, mockA := setupTestDB(t)
+	defer dbA.Close()
+	dbB, mockB := setupTestDB(t)
+	defer dbB.Close()
+
+	mockA.ExpectBegin()
+	mockA.ExpectExec("UPDATE orders").WillReturnResult(sqlmock.NewResult(0, 1))
+	mockA.ExpectCommit()
+
+	mockB.ExpectExec("UPDATE audit").WillReturnResult(sqlmock.NewResult(0, 1))
+	mockB.ExpectBegin()
+	mockB.ExpectExec("UPDATE stock").WillReturnResult(sqlmock.NewResult(0, 1))
+	mockB.ExpectCommit()
+
+	err := dbA.RunTx(context.Background(), sql.LevelDefault, func(ctx context.Context, txA *sqlx.Tx) error {
+		if err := dbA.Execute(ctx, "UPDATE orders SET status = 1", nil); err != nil {
+			return err
+		}
+		// B runs on its own pool, not on A's transaction
+		if err := dbB.Execute(ctx, "UPDATE audit SET n = 1", nil); err != nil {
+			return err
+		}
+		// and RunTx on B begins and commits its own transaction
+		return dbB.RunTx(ctx, sql.LevelDefault, func(ctx context.Context, txB *sqlx.Tx) error {
+			assert.NotSame(t, txA, txB)
+			assert.Same(t, txA, dbA.TxFrom(ctx))
+			return dbB.Execute(ctx, "UPDATE stock SET n = 1", nil)
+		})
+	})
+	require.NoError(t, err)
+	require.NoError(t, mockA.ExpectationsWereMet())
+	require.NoError(t, mockB.ExpectationsWereMet())
+}
+
+func TestRunTx_PropagatesThroughContext(t *testing.T) {
+	db, mock := setupTestDB(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE users SET name = \\? WHERE id = \\?").
+		WithArgs("Bob", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT name FROM users").
+		WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("Bob"))
+	mock.ExpectCommit()
+
+	err := db.RunTx(context.Background(), sql.LevelDefault, func(ctx context.Context, tx *sqlx.Tx) error {
+		assert.Same(t, tx, db.TxFrom(ctx))
+
+		_, err := Builder[User](db).From("users").Select("name").Where("id = ?", 1).
+			Update(ctx, map[string]interface{}{"name": "Bob"})
+		if err != nil {
+			return err
+		}
+
+		// a nested RunTx joins the outer transaction instead of beginning a new one
+		return db.RunTx(ctx, sql.LevelSerializable, func(ctx context.Context, inner *sqlx.Tx) error {
+			assert.Same(t, tx, inner)
+			var name string
+			return db.GetAny(ctx, &name, "SELECT name FROM users")
+		})
+	})
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRunTx_NestedErrorRollsBack(t *testing.T) {
+	db, mock := setupTestDB(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	boom := errors.New("boom")
+	err := db.RunTx(context.Background(), sql.LevelDefault, func(ctx context.Context, _ *sqlx.Tx) error {
+		return db.RunTx(ctx, sql.LevelDefault, func(ctx context.Context, _ *sqlx.Tx) error {
+			return boom
+		})
+	})
+	assert.ErrorIs(t, err, boom)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+type eventRecorder struct {
+	events []*errreport.Event
+}
+
+func (r *eventRecorder) Send(_ context.Context, ev *errreport.Event) error {
+	r.events = append(r.events, ev)
+	return nil
+}
+
+func TestRunTx_ReportsPanic(t *testing.T) {
+	db, mock := setupTestDB(t)
+	defer db.Close()
+
+	rec := &eventRecorder{}
+	reporter, err := errreport.New(rec)
+	require.NoError(t, err)
+	db.UseErrorReporter(reporter)
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	err = db.RunTx(context.Background(), sql.LevelDefault, func(ctx context.Context, tx *sqlx.Tx) error {
+		panic("lost update")
+	})
+	require.Error(t, err)
+	require.NoError(t, reporter.Close(context.Background()))
+
+	require.Len(t, rec.events, 1)
+	assert.Equal(t, "lost update", rec.events[0].Message)
+	assert.Equal(t, errreport.LevelFatal, rec.events[0].Level)
+	assert.Contains(t, rec.events[0].Tags, "db")
+}
@@ -0,0 +1,12 @@
+package errreport
+
+import "errors"
+
+// Errors
+var (
+	ErrProviderNil = errors.New("[errreport] provider is nil")
+	ErrConfigNil   = errors.New("[errreport] config is nil")
+	ErrInvalidDSN  = errors.New("[errreport] invalid DSN")
+	ErrQueueFull   = errors.New("[errreport] queue is full, event dropped")
+	ErrClosed      = errors.New("[errreport] reporter is closed")
+)
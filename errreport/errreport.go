@@ -0,0 +1,345 @@
+// Package errreport captures errors and panics with their stack, request
+// and context tags, and delivers them asynchronously to a Provider such as
+// Sentry (or a Sentry-compatible server like GlitchTip).
+package errreport
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"maps"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/BevisDev/godev/utils/ctxmeta"
+	"github.com/BevisDev/godev/utils/mask"
+)
+
+// Level is the severity of an event.
+type Level string
+
+const (
+	LevelFatal   Level = "fatal"
+	LevelError   Level = "error"
+	LevelWarning Level = "warning"
+	LevelInfo    Level = "info"
+)
+
+// Tags set from the context of a capture.
+const (
+	TagRID     = "rid"
+	TagTenant  = "tenant"
+	TagTraceID = "trace_id"
+)
+
+// Event is a captured error, panic or message.
+type Event struct {
+	ID    string
+	Time  time.Time
+	Level Level
+
+	// Message is the error text, the panic value or the captured message.
+	Message string
+
+	// Type is the Go type of the error, "panic" for panics, empty for messages.
+	Type string
+
+	// Stack is where the error was captured or the panic happened, oldest call first.
+	Stack []Frame
+
+	Tags  map[string]string
+	Extra map[string]any
+
+	// UserID is the user of the context (ctxmeta.UserID).
+	UserID string
+
+	// Request is the HTTP request being served, set with WithRequest.
+	Request *Request
+
+	Release     string
+	Environment string
+	ServerName  string
+}
+
+// Request describes the HTTP request of an event.
+type Request struct {
+	Method  string
+	URL     string
+	Query   string
+	Headers map[string]string
+}
+
+// Provider delivers events, e.g. to Sentry.
+type Provider interface {
+	Send(ctx context.Context, ev *Event) error
+}
+
+// CaptureOption adds information to a single event.
+type CaptureOption func(ev *Event)
+
+// Tag sets a tag of the event.
+func Tag(k, v string) CaptureOption {
+	return func(ev *Event) {
+		ev.Tags[k] = v
+	}
+}
+
+// Extra sets additional data of the event.
+func Extra(k string, v any) CaptureOption {
+	return func(ev *Event) {
+		if ev.Extra == nil {
+			ev.Extra = make(map[string]any)
+		}
+		ev.Extra[k] = v
+	}
+}
+
+// WithLevel overrides the level of the event.
+func WithLevel(l Level) CaptureOption {
+	return func(ev *Event) {
+		ev.Level = l
+	}
+}
+
+// sensitiveHeaders are masked in the request of an event.
+var sensitiveHeaders = map[string]struct{}{
+	"Authorization":       {},
+	"Proxy-Authorization": {},
+	"Cookie":              {},
+	"Set-Cookie":          {},
+	"X-Api-Key":           {},
+}
+
+// WithRequest attaches req to the event; credentials headers are masked.
+func WithRequest(req *http.Request) CaptureOption {
+	return func(ev *Event) {
+		if req == nil {
+			return
+		}
+		r := &Request{
+			Method:  req.Method,
+			URL:     requestURL(req),
+			Query:   req.URL.RawQuery,
+			Headers: make(map[string]string, len(req.Header)),
+		}
+		for k, v := range req.Header {
+			value := strings.Join(v, ",")
+			if _, ok := sensitiveHeaders[k]; ok {
+				value = mask.Secret(value)
+			}
+			r.Headers[k] = value
+		}
+		ev.Request = r
+	}
+}
+
+func requestURL(req *http.Request) string {
+	scheme := "http"
+	if req.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + req.Host + req.URL.Path
+}
+
+// Reporter captures events and sends them to a Provider in the background.
+// A nil *Reporter is valid and captures nothing.
+type Reporter struct {
+	*options
+	provider Provider
+
+	queue   chan *Event
+	pending sync.WaitGroup
+	mu      sync.RWMutex
+	closed  bool
+	done    chan struct{}
+}
+
+// New creates a Reporter sending to provider and starts its sender.
+// Call Close on shutdown to deliver the queued events.
+//
+// Example:
+//
+//	sentry, err := errreport.NewSentry(&errreport.SentryConfig{DSN: cfg.SentryDSN}, nil)
+//	reporter, err := errreport.New(sentry, errreport.WithEnvironment("production"))
+//	defer reporter.Close(context.Background())
+func New(provider Provider, opts ...Option) (*Reporter, error) {
+	if provider == nil {
+		return nil, ErrProviderNil
+	}
+
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	r := &Reporter{
+		options:  o,
+		provider: provider,
+		queue:    make(chan *Event, o.queueSize),
+		done:     make(chan struct{}),
+	}
+	go r.loop()
+	return r, nil
+}
+
+// CaptureError reports err with the stack of the caller and returns the
+// event ID, or "" when err is nil or the event was dropped.
+func (r *Reporter) CaptureError(ctx context.Context, err error, opts ...CaptureOption) string {
+	if r == nil || err == nil {
+		return ""
+	}
+	ev := r.newEvent(ctx, LevelError, err.Error(), fmt.Sprintf("%T", err), 1)
+	return r.capture(ev, opts)
+}
+
+// CapturePanic reports a recovered panic value with the stack of the panic.
+// Call it from the deferred function that recovered:
+//
+//	defer func() {
+//		if p := recover(); p != nil {
+//			reporter.CapturePanic(ctx, p)
+//		}
+//	}()
+func (r *Reporter) CapturePanic(ctx context.Context, recovered any, opts ...CaptureOption) string {
+	if r == nil || recovered == nil {
+		return ""
+	}
+	ev := r.newEvent(ctx, LevelFatal, fmt.Sprint(recovered), "panic", 1)
+	return r.capture(ev, opts)
+}
+
+// CaptureMessage reports a message at level.
+func (r *Reporter) CaptureMessage(ctx context.Context, level Level, msg string, opts ...CaptureOption) string {
+	if r == nil {
+		return ""
+	}
+	ev := r.newEvent(ctx, level, msg, "", 1)
+	return r.capture(ev, opts)
+}
+
+// Flush waits until the queued events are sent or ctx is done.
+func (r *Reporter) Flush(ctx context.Context) error {
+	if r == nil {
+		return nil
+	}
+	flushed := make(chan struct{})
+	go func() {
+		r.pending.Wait()
+		close(flushed)
+	}()
+	select {
+	case <-flushed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close stops capturing, then waits until the queued events are sent or ctx is done.
+func (r *Reporter) Close(ctx context.Context) error {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return nil
+	}
+	r.closed = true
+	close(r.queue)
+	r.mu.Unlock()
+
+	select {
+	case <-r.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// newEvent builds an event with the stack of the caller skip frames above
+// the caller of newEvent, and the tags of ctx.
+func (r *Reporter) newEvent(ctx context.Context, level Level, msg, typ string, skip int) *Event {
+	ev := &Event{
+		ID:          newEventID(),
+		Time:        time.Now().UTC(),
+		Level:       level,
+		Message:     msg,
+		Type:        typ,
+		Stack:       captureStack(skip + 1),
+		Tags:        make(map[string]string, len(r.tags)+3),
+		Release:     r.release,
+		Environment: r.environment,
+		ServerName:  r.serverName,
+	}
+	maps.Copy(ev.Tags, r.tags)
+
+	if ctx != nil {
+		ev.UserID = ctxmeta.UserID(ctx)
+		for k, v := range map[string]string{
+			TagRID:     ctxmeta.RID(ctx),
+			TagTenant:  ctxmeta.Tenant(ctx),
+			TagTraceID: ctxmeta.TraceID(ctx),
+		} {
+			if v != "" {
+				ev.Tags[k] = v
+			}
+		}
+	}
+	return ev
+}
+
+func (r *Reporter) capture(ev *Event, opts []CaptureOption) string {
+	for _, opt := range opts {
+		opt(ev)
+	}
+	if r.beforeSend != nil {
+		if ev = r.beforeSend(ev); ev == nil {
+			return ""
+		}
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.closed {
+		return ""
+	}
+
+	r.pending.Add(1)
+	select {
+	case r.queue <- ev:
+		return ev.ID
+	default:
+		r.pending.Done()
+		log.Printf("%v: %s", ErrQueueFull, ev.Message)
+		return ""
+	}
+}
+
+func (r *Reporter) loop() {
+	defer close(r.done)
+	for ev := range r.queue {
+		r.send(ev)
+	}
+}
+
+func (r *Reporter) send(ev *Event) {
+	defer r.pending.Done()
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.sendTimeout)
+	defer cancel()
+	if err := r.provider.Send(ctx, ev); err != nil {
+		log.Printf("[errreport] failed to send event %s: %v", ev.ID, err)
+	}
+}
+
+// newEventID returns 32 random hex characters.
+func newEventID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,157 @@
+package errreport
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/BevisDev/godev/utils/ctxmeta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recorder struct {
+	mu     sync.Mutex
+	events []*Event
+	delay  time.Duration
+}
+
+func (r *recorder) Send(_ context.Context, ev *Event) error {
+	time.Sleep(r.delay)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, ev)
+	return nil
+}
+
+func (r *recorder) all() []*Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*Event(nil), r.events...)
+}
+
+func functions(ev *Event) []string {
+	out := make([]string, len(ev.Stack))
+	for i, f := range ev.Stack {
+		out[i] = f.Function
+	}
+	return out
+}
+
+func TestReporter_CaptureError(t *testing.T) {
+	rec := &recorder{}
+	r, err := New(rec, WithRelease("v1.2.3"), WithEnvironment("test"), WithTags(map[string]string{"service": "orders"}))
+	require.NoError(t, err)
+
+	ctx := ctxmeta.WithTenant(ctxmeta.WithUserID(ctxmeta.WithRID(context.Background(), "rid-1"), "u-7"), "acme")
+	req := httptest.NewRequest("POST", "http://api.local/orders?x=1", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	req.Header.Set("Accept", "application/json")
+
+	id := r.CaptureError(ctx, errors.New("stock not found"), Tag("order", "42"), Extra("items", 3), WithRequest(req))
+	assert.Len(t, id, 32)
+	assert.Empty(t, r.CaptureError(ctx, nil))
+	require.NoError(t, r.Flush(context.Background()))
+
+	events := rec.all()
+	require.Len(t, events, 1)
+	ev := events[0]
+	assert.Equal(t, id, ev.ID)
+	assert.Equal(t, LevelError, ev.Level)
+	assert.Equal(t, "stock not found", ev.Message)
+	assert.Equal(t, "*errors.errorString", ev.Type)
+	assert.Equal(t, map[string]string{"service": "orders", "order": "42", TagRID: "rid-1", TagTenant: "acme"}, ev.Tags)
+	assert.Equal(t, map[string]any{"items": 3}, ev.Extra)
+	assert.Equal(t, "u-7", ev.UserID)
+	assert.Equal(t, "v1.2.3", ev.Release)
+	assert.Equal(t, "test", ev.Environment)
+	assert.Equal(t, &Request{
+		Method:  "POST",
+		URL:     "http://api.local/orders",
+		Query:   "x=1",
+		Headers: map[string]string{"Authorization": "******", "Accept": "application/json"},
+	}, ev.Request)
+	innermost := ev.Stack[len(ev.Stack)-1]
+	assert.Equal(t, "TestReporter_CaptureError", innermost.Function, "the stack starts at the caller")
+	assert.Equal(t, selfModule, innermost.Module)
+	assert.False(t, innermost.InApp)
+}
+
+func TestReporter_CapturePanic(t *testing.T) {
+	rec := &recorder{}
+	r, _ := New(rec)
+
+	func() {
+		defer func() {
+			if p := recover(); p != nil {
+				r.CapturePanic(context.Background(), p)
+			}
+		}()
+		explode()
+	}()
+	require.NoError(t, r.Close(context.Background()))
+
+	ev := rec.all()[0]
+	assert.Equal(t, LevelFatal, ev.Level)
+	assert.Equal(t, "panic", ev.Type)
+	assert.Equal(t, "boom", ev.Message)
+	assert.Contains(t, functions(ev), "explode", "the stack includes the panicking function")
+}
+
+func explode() {
+	panic("boom")
+}
+
+func TestReporter_BeforeSendAndClose(t *testing.T) {
+	rec := &recorder{}
+	r, _ := New(rec, WithBeforeSend(func(ev *Event) *Event {
+		if ev.Level == LevelInfo {
+			return nil
+		}
+		ev.Tags["scrubbed"] = "yes"
+		return ev
+	}))
+
+	assert.Empty(t, r.CaptureMessage(context.Background(), LevelInfo, "dropped"))
+	assert.NotEmpty(t, r.CaptureMessage(context.Background(), LevelWarning, "kept"))
+	require.NoError(t, r.Close(context.Background()))
+	assert.Empty(t, r.CaptureMessage(context.Background(), LevelWarning, "after close"))
+
+	events := rec.all()
+	require.Len(t, events, 1)
+	assert.Equal(t, "kept", events[0].Message)
+	assert.Equal(t, "yes", events[0].Tags["scrubbed"])
+
+	var nilReporter *Reporter
+	assert.Empty(t, nilReporter.CaptureError(context.Background(), errors.New("x")))
+	assert.NoError(t, nilReporter.Close(context.Background()))
+
+	_, err := New(nil)
+	assert.ErrorIs(t, err, ErrProviderNil)
+}
+
+func TestReporter_QueueFull(t *testing.T) {
+	rec := &recorder{delay: 50 * time.Millisecond}
+	r, _ := New(rec, WithQueueSize(1))
+
+	var sent int
+	for range 5 {
+		if r.CaptureMessage(context.Background(), LevelError, "burst") != "" {
+			sent++
+		}
+	}
+	require.NoError(t, r.Close(context.Background()))
+	assert.Less(t, sent, 5)
+	assert.Len(t, rec.all(), sent)
+}
+
+func TestInApp(t *testing.T) {
+	assert.True(t, inApp("github.com/acme/orders/service"))
+	assert.True(t, inApp("main"))
+	assert.False(t, inApp("runtime"))
+	assert.False(t, inApp("net/http"))
+	assert.False(t, inApp(selfModule))
+}
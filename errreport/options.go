@@ -0,0 +1,88 @@
+package errreport
+
+import (
+	"os"
+	"time"
+)
+
+const (
+	defaultQueueSize   = 100
+	defaultSendTimeout = 5 * time.Second
+)
+
+type Option func(*options)
+
+type options struct {
+	release     string
+	environment string
+	serverName  string
+	tags        map[string]string
+	beforeSend  func(ev *Event) *Event
+	queueSize   int
+	sendTimeout time.Duration
+}
+
+func defaultOptions() *options {
+	host, _ := os.Hostname()
+	return &options{
+		release:     BuildRelease(),
+		serverName:  host,
+		queueSize:   defaultQueueSize,
+		sendTimeout: defaultSendTimeout,
+	}
+}
+
+// WithRelease sets the release of the events (default BuildRelease()).
+func WithRelease(release string) Option {
+	return func(o *options) {
+		o.release = release
+	}
+}
+
+// WithEnvironment sets the environment of the events, e.g. "production".
+func WithEnvironment(env string) Option {
+	return func(o *options) {
+		o.environment = env
+	}
+}
+
+// WithServerName sets the server name of the events (default the hostname).
+func WithServerName(name string) Option {
+	return func(o *options) {
+		o.serverName = name
+	}
+}
+
+// WithTags adds tags to every event, e.g. {"service": "orders"}.
+func WithTags(tags map[string]string) Option {
+	return func(o *options) {
+		o.tags = tags
+	}
+}
+
+// WithBeforeSend is called with every event before it is queued; it may
+// modify the event, or return nil to drop it.
+func WithBeforeSend(fn func(ev *Event) *Event) Option {
+	return func(o *options) {
+		o.beforeSend = fn
+	}
+}
+
+// WithQueueSize sets how many events may wait to be sent (default 100);
+// events captured while the queue is full are dropped.
+func WithQueueSize(n int) Option {
+	return func(o *options) {
+		if n > 0 {
+			o.queueSize = n
+		}
+	}
+}
+
+// WithSendTimeout bounds the delivery of one event (default 5s).
+func WithSendTimeout(d time.Duration) Option {
+	return func(o *options) {
+		if d > 0 {
+			o.sendTimeout = d
+		}
+	}
+}
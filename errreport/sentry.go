@@ -0,0 +1,180 @@
+package errreport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/BevisDev/godev/rest"
+)
+
+const (
+	sentryClient       = "godev-errreport/1.0"
+	sentryEnvelopeType = "application/x-sentry-envelope"
+)
+
+// SentryConfig configures the Sentry provider.
+type SentryConfig struct {
+	// DSN is the client key URL of the project, e.g.
+	// https://<public_key>@o123.ingest.sentry.io/<project_id>.
+	// Self-hosted Sentry and GlitchTip DSNs work the same way.
+	DSN string
+}
+
+// Sentry sends events to the envelope endpoint of Sentry.
+type Sentry struct {
+	client   *rest.Client
+	endpoint string
+	auth     string
+	dsn      string
+}
+
+// NewSentry creates a Sentry provider. A nil client uses a rest client with a 10s timeout.
+func NewSentry(cfg *SentryConfig, client *rest.Client) (*Sentry, error) {
+	if cfg == nil {
+		return nil, ErrConfigNil
+	}
+	endpoint, key, err := parseDSN(cfg.DSN)
+	if err != nil {
+		return nil, err
+	}
+	if client == nil {
+		client = rest.New(rest.WithTimeout(10 * time.Second))
+	}
+	return &Sentry{
+		client:   client,
+		endpoint: endpoint,
+		auth:     fmt.Sprintf("Sentry sentry_version=7, sentry_client=%s, sentry_key=%s", sentryClient, key),
+		dsn:      cfg.DSN,
+	}, nil
+}
+
+// parseDSN returns the envelope endpoint and the public key of dsn.
+func parseDSN(dsn string) (string, string, error) {
+	u, err := url.Parse(dsn)
+	if err != nil || u.User == nil || u.User.Username() == "" || u.Host == "" {
+		return "", "", ErrInvalidDSN
+	}
+	path := strings.TrimRight(u.Path, "/")
+	i := strings.LastIndex(path, "/")
+	project := path[i+1:]
+	if project == "" {
+		return "", "", ErrInvalidDSN
+	}
+	return fmt.Sprintf("%s://%s%s/api/%s/envelope/", u.Scheme, u.Host, path[:i], project), u.User.Username(), nil
+}
+
+// Send delivers ev as an envelope holding one event.
+func (s *Sentry) Send(ctx context.Context, ev *Event) error {
+	body, err := s.envelope(ev)
+	if err != nil {
+		return err
+	}
+
+	_, err = rest.NewRequest[map[string]any](s.client).
+		URL(s.endpoint).
+		Headers(map[string]string{
+			"Content-Type":  sentryEnvelopeType,
+			"X-Sentry-Auth": s.auth,
+		}).
+		Body(body).
+		POST(ctx)
+	return err
+}
+
+// envelope renders the envelope header, the item header and the event, one JSON document per line.
+func (s *Sentry) envelope(ev *Event) ([]byte, error) {
+	payload, err := json.Marshal(sentryEvent(ev))
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	header, _ := json.Marshal(map[string]string{
+		"event_id": ev.ID,
+		"sent_at":  time.Now().UTC().Format(time.RFC3339Nano),
+		"dsn":      s.dsn,
+	})
+	buf.Write(header)
+	buf.WriteByte('\n')
+	item, _ := json.Marshal(map[string]any{"type": "event", "length": len(payload)})
+	buf.Write(item)
+	buf.WriteByte('\n')
+	buf.Write(payload)
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+// sentryEvent converts ev to the event payload of Sentry.
+func sentryEvent(ev *Event) map[string]any {
+	out := map[string]any{
+		"event_id":  ev.ID,
+		"timestamp": ev.Time.Format(time.RFC3339Nano),
+		"platform":  "go",
+		"level":     string(ev.Level),
+		"tags":      ev.Tags,
+	}
+	for k, v := range map[string]string{
+		"release":     ev.Release,
+		"environment": ev.Environment,
+		"server_name": ev.ServerName,
+	} {
+		if v != "" {
+			out[k] = v
+		}
+	}
+	if len(ev.Extra) > 0 {
+		out["extra"] = ev.Extra
+	}
+	if ev.UserID != "" {
+		out["user"] = map[string]string{"id": ev.UserID}
+	}
+	if ev.Request != nil {
+		out["request"] = map[string]any{
+			"method":       ev.Request.Method,
+			"url":          ev.Request.URL,
+			"query_string": ev.Request.Query,
+			"headers":      ev.Request.Headers,
+		}
+	}
+
+	frames := make([]map[string]any, len(ev.Stack))
+	for i, f := range ev.Stack {
+		frames[i] = map[string]any{
+			"function": f.Function,
+			"module":   f.Module,
+			"abs_path": f.File,
+			"filename": f.File,
+			"lineno":   f.Line,
+			"in_app":   f.InApp,
+		}
+	}
+
+	if ev.Type == "" {
+		out["message"] = map[string]string{"formatted": ev.Message}
+		if len(frames) > 0 {
+			out["threads"] = map[string]any{"values": []any{map[string]any{
+				"current":    true,
+				"stacktrace": map[string]any{"frames": frames},
+			}}}
+		}
+		return out
+	}
+
+	exception := map[string]any{
+		"type":  ev.Type,
+		"value": ev.Message,
+	}
+	if len(frames) > 0 {
+		exception["stacktrace"] = map[string]any{"frames": frames}
+	}
+	if ev.Type == "panic" {
+		exception["mechanism"] = map[string]any{"type": "panic", "handled": false}
+	}
+	out["exception"] = map[string]any{"values": []any{exception}}
+	return out
+}
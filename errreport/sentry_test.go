@@ -0,0 +1,81 @@
+package errreport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDSN(t *testing.T) {
+	endpoint, key, err := parseDSN("https://abc123@o1.ingest.sentry.io/4505")
+	require.NoError(t, err)
+	assert.Equal(t, "https://o1.ingest.sentry.io/api/4505/envelope/", endpoint)
+	assert.Equal(t, "abc123", key)
+
+	endpoint, _, err = parseDSN("http://key@glitchtip.local:8000/sentry/7/")
+	require.NoError(t, err)
+	assert.Equal(t, "http://glitchtip.local:8000/sentry/api/7/envelope/", endpoint)
+
+	for _, dsn := range []string{"", "https://o1.ingest.sentry.io/1", "https://key@host/"} {
+		_, _, err = parseDSN(dsn)
+		assert.ErrorIs(t, err, ErrInvalidDSN, dsn)
+	}
+}
+
+func TestSentry_Send(t *testing.T) {
+	var (
+		auth, contentType string
+		lines             [][]byte
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/42/envelope/", r.URL.Path)
+		auth, contentType = r.Header.Get("X-Sentry-Auth"), r.Header.Get("Content-Type")
+		body, _ := io.ReadAll(r.Body)
+		lines = bytes.Split(bytes.TrimSpace(body), []byte("\n"))
+		_, _ = w.Write([]byte(`{"id":"x"}`))
+	}))
+	defer srv.Close()
+
+	sentry, err := NewSentry(&SentryConfig{DSN: strings.Replace(srv.URL, "http://", "http://pubkey@", 1) + "/42"}, nil)
+	require.NoError(t, err)
+	r, _ := New(sentry, WithRelease("abc123def456"))
+
+	id := r.CaptureError(context.Background(), errors.New("payment failed"), Tag("provider", "vnpay"))
+	require.NoError(t, r.Close(context.Background()))
+
+	assert.Equal(t, "Sentry sentry_version=7, sentry_client=godev-errreport/1.0, sentry_key=pubkey", auth)
+	assert.Equal(t, sentryEnvelopeType, contentType)
+	require.Len(t, lines, 3)
+
+	var header, item, event map[string]any
+	require.NoError(t, json.Unmarshal(lines[0], &header))
+	require.NoError(t, json.Unmarshal(lines[1], &item))
+	require.NoError(t, json.Unmarshal(lines[2], &event))
+	assert.Equal(t, id, header["event_id"])
+	assert.Equal(t, "event", item["type"])
+	assert.Equal(t, float64(len(lines[2])), item["length"])
+
+	assert.Equal(t, id, event["event_id"])
+	assert.Equal(t, "error", event["level"])
+	assert.Equal(t, "abc123def456", event["release"])
+	assert.Equal(t, map[string]any{"provider": "vnpay"}, event["tags"])
+	exception := event["exception"].(map[string]any)["values"].([]any)[0].(map[string]any)
+	assert.Equal(t, "payment failed", exception["value"])
+	assert.NotEmpty(t, exception["stacktrace"].(map[string]any)["frames"])
+}
+
+func TestNewSentry_Errors(t *testing.T) {
+	_, err := NewSentry(nil, nil)
+	assert.ErrorIs(t, err, ErrConfigNil)
+	_, err = NewSentry(&SentryConfig{DSN: "not a dsn"}, nil)
+	assert.ErrorIs(t, err, ErrInvalidDSN)
+}
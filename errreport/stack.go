@@ -0,0 +1,99 @@
+package errreport
+
+import (
+	"runtime"
+	"runtime/debug"
+	"strings"
+)
+
+// Frame is a stack frame of an event.
+type Frame struct {
+	Function string
+	Module   string
+	File     string
+	Line     int
+
+	// InApp is false for frames of the standard library and of this package.
+	InApp bool
+}
+
+// captureStack returns the stack of the caller, oldest call first, skipping
+// skip frames above the caller of captureStack.
+func captureStack(skip int) []Frame {
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(skip+2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var out []Frame
+	for {
+		f, more := frames.Next()
+		module, function := splitFunction(f.Function)
+		out = append(out, Frame{
+			Function: function,
+			Module:   module,
+			File:     f.File,
+			Line:     f.Line,
+			InApp:    inApp(module),
+		})
+		if !more {
+			break
+		}
+	}
+
+	// oldest call first, like the stack traces of Sentry
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return out
+}
+
+// splitFunction splits "github.com/a/b.(*T).Method" into the package path
+// "github.com/a/b" and the function "(*T).Method".
+func splitFunction(name string) (string, string) {
+	slash := strings.LastIndex(name, "/")
+	dot := strings.Index(name[slash+1:], ".")
+	if dot < 0 {
+		return "", name
+	}
+	return name[:slash+1+dot], name[slash+1+dot+1:]
+}
+
+// selfModule is the package path of errreport, whose frames are not in-app.
+const selfModule = "github.com/BevisDev/godev/errreport"
+
+// inApp reports whether module is application code: not the standard
+// library (no dot in the first path element) and not errreport itself.
+func inApp(module string) bool {
+	first, _, _ := strings.Cut(module, "/")
+	return module == "main" || (strings.Contains(first, ".") && module != selfModule)
+}
+
+// BuildRelease returns the release of the running binary from its build
+// information: the module version when built from a tagged module, or the
+// VCS revision (suffixed with "-dirty" for modified trees), or "".
+func BuildRelease() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	if v := info.Main.Version; v != "" && v != "(devel)" {
+		return info.Main.Path + "@" + v
+	}
+
+	var revision, modified string
+	for _, s := range info.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			revision = s.Value
+		case "vcs.modified":
+			modified = s.Value
+		}
+	}
+	if len(revision) > 12 {
+		revision = revision[:12]
+	}
+	if revision != "" && modified == "true" {
+		revision += "-dirty"
+	}
+	return revision
+}
@@ -9,15 +9,17 @@ import (
 	"os/signal"
 	"sync"
 	"syscall"
-	"time"
 
+	"github.com/BevisDev/godev/healthcheck"
 	"github.com/BevisDev/godev/kafkax"
 	"github.com/BevisDev/godev/mailer"
 	"github.com/BevisDev/godev/utils"
 	"github.com/BevisDev/godev/utils/console"
+	"github.com/BevisDev/godev/utils/reconnect"
 
 	"github.com/BevisDev/godev/database"
 	"github.com/BevisDev/godev/ginfw/server"
+	"github.com/BevisDev/godev/ginfw/ws"
 	"github.com/BevisDev/godev/keycloak"
 	"github.com/BevisDev/godev/logger"
 	"github.com/BevisDev/godev/migration"
@@ -25,6 +27,7 @@ import (
 	"github.com/BevisDev/godev/redis"
 	"github.com/BevisDev/godev/rest"
 	"github.com/BevisDev/godev/scheduler"
+	"github.com/BevisDev/godev/storage"
 	"github.com/BevisDev/godev/tgbot"
 	"github.com/gin-gonic/gin"
 	"golang.org/x/sync/errgroup"
@@ -41,6 +44,7 @@ type Bootstrap struct {
 	migration  *migration.Migration
 	redisCache *redis.Cache
 	mailer     *mailer.Mailer
+	storage    *storage.S3
 	rabbitmq   *rabbitmq.MQ
 	keycloak   *keycloak.KC
 	kafka      *kafkax.Kafka
@@ -50,6 +54,18 @@ type Bootstrap struct {
 
 	// server
 	httpApp *server.HTTPApp
+	wsHub   *ws.Hub
+
+	// managed message consumers
+	consumers consumers
+
+	// health checks of services and WithHealthChecker
+	health        *healthcheck.Registry
+	serviceChecks []string // names registered by registerHealthChecks
+
+	// connection states of Redis and RabbitMQ, see DependencyStates
+	depStates   map[string]reconnect.State
+	depStatesMu sync.RWMutex
 
 	// Lifecycle hooks
 	beforeInit  []func(ctx context.Context) error
@@ -81,6 +97,7 @@ func New(c context.Context, opts ...Option) *Bootstrap {
 	for _, opt := range opts {
 		opt(b.options)
 	}
+	b.initHealth()
 
 	return b
 }
@@ -190,6 +207,8 @@ func (b *Bootstrap) Init(ctx context.Context) error {
 		return err
 	}
 
+	b.registerHealthChecks()
+
 	// Consume after init hooks (services are now available, can set Setup/Shutdown here)
 	for _, fn := range b.afterInit {
 		if err := fn(ctx); err != nil {
@@ -270,7 +289,7 @@ func (b *Bootstrap) runServices(ctx context.Context) error {
 	// Redis
 	if b.redisConf != nil && b.redisCache == nil {
 		g.Go(func() error {
-			cache, err := redis.New(b.redisConf)
+			cache, err := redis.New(b.withRedisStates(b.redisConf))
 			if err != nil {
 				return fmt.Errorf("[redis] %w", err)
 			}
@@ -284,7 +303,9 @@ func (b *Bootstrap) runServices(ctx context.Context) error {
 	// MQ
 	if b.rabbitConf != nil && b.rabbitmq == nil {
 		g.Go(func() error {
-			mq, err := rabbitmq.New(ctx, b.rabbitConf, b.rabbitOpt...)
+			opts := append([]rabbitmq.Option{}, b.rabbitOpt...)
+			opts = append(opts, rabbitmq.WithStateListener(b.onDependencyState))
+			mq, err := rabbitmq.New(ctx, b.rabbitConf, opts...)
 			if err != nil {
 				return fmt.Errorf("[rabbitmq] %w", err)
 			}
@@ -309,6 +330,20 @@ func (b *Bootstrap) runServices(ctx context.Context) error {
 		})
 	}
 
+	// Object storage
+	if b.storageConf != nil && b.storage == nil {
+		g.Go(func() error {
+			s, err := storage.NewS3(gCtx, b.storageConf)
+			if err != nil {
+				return err
+			}
+			initMu.Lock()
+			b.storage = s
+			initMu.Unlock()
+			return nil
+		})
+	}
+
 	// Keycloak
 	if b.keycloakConf != nil && b.keycloak == nil {
 		g.Go(func() error {
@@ -375,6 +410,18 @@ func (b *Bootstrap) runServices(ctx context.Context) error {
 		return err
 	}
 
+	// WebSocket hub: after Redis so the bridge can use it
+	if b.wsOn && b.wsHub == nil {
+		opts := b.wsOpts
+		if b.wsRedisBridge {
+			if b.redisCache == nil {
+				return errors.New("[bootstrap] websocket redis bridge requires WithRedis")
+			}
+			opts = append(opts, ws.WithBroker(ws.NewRedisBroker(b.redisCache)))
+		}
+		b.wsHub = ws.New(opts...)
+	}
+
 	return nil
 }
 
@@ -406,28 +453,43 @@ func (b *Bootstrap) Start(ctx context.Context) error {
 	}
 
 	if b.rabbitmq != nil && b.rabbitmq.Consumer() != nil {
-		go b.rabbitmq.Consumer().Start(ctx)
+		cm := b.rabbitmq.Consumer()
+		b.AddConsumer("rabbitmq", ConsumerFunc(func(ctx context.Context) error {
+			cm.Start(ctx)
+			return nil
+		}))
 	}
 
 	// Start Kafka consumer if configured (handler registered and consumer initialized)
 	if b.kafka != nil && b.kafka.HasConsumer() && b.kafkaConsumerHandler != nil {
 		handler := b.kafkaConsumerHandler
+		k := b.kafka
 		if b.kafkaConsumerRetry.enabled {
 			maxRetries := b.kafkaConsumerRetry.maxRetries
 			retryDelay := b.kafkaConsumerRetry.retryDelay
-			go func() {
-				_ = b.kafka.ConsumeWithRetry(ctx, handler, maxRetries, retryDelay)
-			}()
+			b.AddConsumer("kafka", ConsumerFunc(func(ctx context.Context) error {
+				return k.ConsumeWithRetry(ctx, handler, maxRetries, retryDelay)
+			}))
 		} else {
-			go func() {
-				_ = b.kafka.Consume(ctx, handler)
-			}()
+			b.AddConsumer("kafka", ConsumerFunc(func(ctx context.Context) error {
+				return k.Consume(ctx, handler)
+			}))
+		}
+	}
+
+	b.startConsumers(ctx)
+
+	if b.wsHub != nil {
+		if err := b.wsHub.Start(b.ctx); err != nil {
+			return fmt.Errorf("[bootstrap] failed to start websocket hub: %w", err)
 		}
-		b.log.Info("Kafka consumer started")
 	}
 
 	// Start HTTP server if configured
 	if b.serverConf != nil {
+		if b.serverConf.ReadinessPath != "" && b.serverConf.Readiness == nil {
+			b.serverConf.Readiness = b.HealthHandler()
+		}
 		b.httpApp = server.New(b.serverConf)
 		if err := b.httpApp.Start(); err != nil {
 			return fmt.Errorf("[bootstrap] failed to start HTTP server: %w", err)
@@ -474,6 +536,12 @@ func (b *Bootstrap) Stop(ctx context.Context) error {
 	}
 	b.mu.Unlock()
 
+	// Fail the readiness probe at once; the server keeps serving until its drain
+	// window has passed, while consumers and goroutines stop below
+	if b.httpApp != nil {
+		b.httpApp.Drain()
+	}
+
 	// Cancel bootstrap context so Kafka consumer and other goroutines using b.ctx exit
 	b.cancel()
 
@@ -486,7 +554,20 @@ func (b *Bootstrap) Stop(ctx context.Context) error {
 		}
 	}
 
-	// Stop HTTP server if configured
+	// Drain consumers while their connections and the database are still open
+	b.stopConsumers(ctx)
+
+	// Wait for goroutines started with framework.Go
+	b.stopGoroutines(ctx)
+
+	// Close websocket clients before the server stops
+	if b.wsHub != nil {
+		if err := b.wsHub.Shutdown(ctx); err != nil {
+			b.log.Info("websocket hub shutdown error: %v", err)
+		}
+	}
+
+	// Stop HTTP server if configured, after the rest of its drain window
 	if b.httpApp != nil {
 		if err := b.httpApp.Stop(ctx); err != nil {
 			b.log.Info("HTTP server stop error: %v", err)
@@ -528,55 +609,23 @@ func (b *Bootstrap) Run(ctx context.Context) error {
 	return b.Stop(ctx)
 }
 
-// Health checks the health of all configured services plus any custom health checkers
-// registered via WithHealthChecker.
-func (b *Bootstrap) Health(ctx context.Context) map[string]interface{} {
-	health := make(map[string]interface{})
-
-	if b.database != nil {
-		if err := b.database.Ping(); err != nil {
-			health["database"] = err
-		} else {
-			health["database"] = "OK"
-		}
-	}
-
-	if b.redisCache != nil {
-		ctxTimeout, cancel := context.WithTimeout(ctx, 5*time.Second)
-		defer cancel()
-		if err := b.redisCache.Ping(ctxTimeout); err != nil {
-			health["redis"] = err
-		} else {
-			health["redis"] = "OK"
-		}
-	}
-
-	if b.rabbitmq != nil {
-		conn, err := b.rabbitmq.GetConnection()
-		if err != nil || conn == nil || conn.IsClosed() {
-			health["rabbitmq"] = fmt.Errorf("connection not available")
-		} else {
-			health["rabbitmq"] = "OK"
-		}
-	}
-
-	if b.kafka != nil {
-		if b.kafka.IsClosed() {
-			health["kafka"] = fmt.Errorf("client closed")
-		} else {
-			health["kafka"] = "OK"
-		}
+// Routes builds the HTTP engine with the server Setup, without listening,
+// and returns its routes. It returns nil when no server is configured.
+func (b *Bootstrap) Routes() gin.RoutesInfo {
+	if b.serverConf == nil {
+		return nil
 	}
+	return server.New(b.serverConf).Routes()
+}
 
-	for _, entry := range b.healthCheckers {
-		if err := entry.fn(ctx); err != nil {
-			health[entry.name] = err
-		} else {
-			health[entry.name] = "OK"
-		}
-	}
+// Close releases services created by Init without starting them,
+// e.g. after a one-off command such as a migration.
+func (b *Bootstrap) Close() {
+	b.closeServices()
 
-	return health
+	b.mu.Lock()
+	b.initialized = false
+	b.mu.Unlock()
 }
 
 // Context returns the bootstrap context.
@@ -590,6 +639,8 @@ func (b *Bootstrap) Shutdown() {
 }
 
 func (b *Bootstrap) closeServices() {
+	b.unregisterHealthChecks()
+
 	if b.restClient != nil {
 		if hc := b.restClient.GetClient(); hc != nil {
 			if tr, ok := hc.Transport.(*http.Transport); ok {
@@ -602,6 +653,9 @@ func (b *Bootstrap) closeServices() {
 	if b.mailer != nil {
 		b.mailer = nil
 	}
+	if b.storage != nil {
+		b.storage = nil
+	}
 	if b.tgBot != nil {
 		b.tgBot = nil
 	}
@@ -706,6 +760,14 @@ func (b *Bootstrap) Mailer() *mailer.Mailer {
 	return b.mailer
 }
 
+func (b *Bootstrap) Storage() *storage.S3 {
+	return b.storage
+}
+
+func (b *Bootstrap) WebSocket() *ws.Hub {
+	return b.wsHub
+}
+
 func (b *Bootstrap) TgBot() *tgbot.TgBot {
 	return b.tgBot
 }
@@ -0,0 +1,129 @@
+package framework
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultDrainTimeout = 30 * time.Second
+
+// Consumer is a long-running message consumer managed by Bootstrap.
+// Run consumes until ctx is cancelled and returns once in-flight messages are done.
+// A Consumer may also implement interface{ Stop(ctx context.Context) error },
+// called during shutdown after ctx is cancelled.
+type Consumer interface {
+	Run(ctx context.Context) error
+}
+
+// ConsumerFunc adapts a function to Consumer.
+type ConsumerFunc func(ctx context.Context) error
+
+func (f ConsumerFunc) Run(ctx context.Context) error {
+	return f(ctx)
+}
+
+type managedConsumer struct {
+	name     string
+	consumer Consumer
+	done     chan struct{}
+}
+
+// consumers runs managed consumers with their own context, so Stop can
+// cancel and drain them before services are closed.
+type consumers struct {
+	mu      sync.Mutex
+	list    []*managedConsumer
+	ctx     context.Context
+	cancel  context.CancelFunc
+	started bool
+}
+
+// AddConsumer registers a consumer started by Start and drained by Stop
+// before the database, Redis and messaging connections are closed.
+// Consumers added after Start (e.g. in an AfterStart hook) start immediately.
+func (b *Bootstrap) AddConsumer(name string, c Consumer) {
+	if c == nil {
+		return
+	}
+
+	mc := &managedConsumer{name: name, consumer: c, done: make(chan struct{})}
+
+	b.consumers.mu.Lock()
+	defer b.consumers.mu.Unlock()
+	b.consumers.list = append(b.consumers.list, mc)
+	if b.consumers.started {
+		b.runConsumer(b.consumers.ctx, mc)
+	}
+}
+
+// startConsumers starts the registered consumers with a context derived from ctx.
+func (b *Bootstrap) startConsumers(ctx context.Context) {
+	b.consumers.mu.Lock()
+	defer b.consumers.mu.Unlock()
+
+	b.consumers.ctx, b.consumers.cancel = context.WithCancel(ctx)
+	b.consumers.started = true
+	for _, mc := range b.consumers.list {
+		b.runConsumer(b.consumers.ctx, mc)
+	}
+}
+
+func (b *Bootstrap) runConsumer(ctx context.Context, mc *managedConsumer) {
+	b.log.Info("consumer %s started", mc.name)
+	Go(ctx, "consumer "+mc.name, func(ctx context.Context) error {
+		defer close(mc.done)
+		if err := mc.consumer.Run(ctx); err != nil && ctx.Err() == nil {
+			b.log.Error("consumer %s stopped: %v", mc.name, err)
+		}
+		return nil
+	})
+}
+
+// stopConsumers cancels the consumers and waits for them to drain,
+// at most the drain timeout (WithDrainTimeout) or until ctx is done.
+func (b *Bootstrap) stopConsumers(ctx context.Context) {
+	b.consumers.mu.Lock()
+	if !b.consumers.started {
+		b.consumers.mu.Unlock()
+		return
+	}
+	b.consumers.started = false
+	b.consumers.cancel()
+	list := append([]*managedConsumer(nil), b.consumers.list...)
+	b.consumers.mu.Unlock()
+
+	timeout := b.drainTimeout
+	if timeout <= 0 {
+		timeout = defaultDrainTimeout
+	}
+	drainCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	b.log.Info("draining %d consumer(s)...", len(list))
+	for _, mc := range list {
+		if s, ok := mc.consumer.(interface {
+			Stop(ctx context.Context) error
+		}); ok {
+			if err := s.Stop(drainCtx); err != nil {
+				b.log.Error("consumer %s stop error: %v", mc.name, err)
+			}
+		}
+	}
+
+	var pending []string
+	for _, mc := range list {
+		select {
+		case <-mc.done:
+		case <-drainCtx.Done():
+			pending = append(pending, mc.name)
+		}
+	}
+
+	if len(pending) > 0 {
+		b.log.Warn("drain deadline exceeded, consumer(s) still running: %s", strings.Join(pending, ", "))
+		return
+	}
+	b.log.Info("all consumers drained")
+}
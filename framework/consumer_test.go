@@ -0,0 +1,102 @@
+package framework
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBootstrap_ConsumersDrainBeforeClose(t *testing.T) {
+	var mu sync.Mutex
+	var events []string
+	record := func(e string) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, e)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	b := New(ctx)
+	require.NoError(t, b.Init(ctx))
+
+	running := make(chan struct{})
+	b.AddConsumer("orders", ConsumerFunc(func(ctx context.Context) error {
+		close(running)
+		<-ctx.Done()
+		time.Sleep(100 * time.Millisecond) // finish the in-flight message
+		record("orders drained")
+		return nil
+	}))
+	b.AfterStop(func(context.Context) error {
+		record("services closed")
+		return nil
+	})
+
+	started := make(chan error, 1)
+	go func() { started <- b.Start(ctx) }()
+	<-running
+
+	cancel()
+	require.NoError(t, <-started)
+	require.NoError(t, b.Stop(context.Background()))
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"orders drained", "services closed"}, events)
+}
+
+func TestBootstrap_DrainTimeout(t *testing.T) {
+	b := New(context.Background(), WithDrainTimeout(100*time.Millisecond))
+
+	release := make(chan struct{})
+	defer close(release)
+	b.AddConsumer("stuck", ConsumerFunc(func(context.Context) error {
+		<-release // ignores cancellation
+		return nil
+	}))
+	b.startConsumers(context.Background())
+
+	start := time.Now()
+	b.stopConsumers(context.Background())
+	elapsed := time.Since(start)
+	assert.GreaterOrEqual(t, elapsed, 100*time.Millisecond)
+	assert.Less(t, elapsed, time.Second, "Stop does not wait past the drain timeout")
+}
+
+func TestBootstrap_AddConsumerAfterStart(t *testing.T) {
+	b := New(context.Background())
+	b.startConsumers(context.Background())
+
+	running := make(chan struct{})
+	stopped := make(chan struct{})
+	b.AddConsumer("late", ConsumerFunc(func(ctx context.Context) error {
+		close(running)
+		<-ctx.Done()
+		close(stopped)
+		return nil
+	}))
+
+	select {
+	case <-running:
+	case <-time.After(time.Second):
+		t.Fatal("consumer added after start did not run")
+	}
+
+	b.stopConsumers(context.Background())
+	select {
+	case <-stopped:
+	default:
+		t.Fatal("consumer added after start was not drained")
+	}
+
+	// after stop, consumers are only registered
+	b.AddConsumer("after-stop", ConsumerFunc(func(context.Context) error {
+		t.Error("consumer added after stop must not run")
+		return nil
+	}))
+	time.Sleep(50 * time.Millisecond)
+}
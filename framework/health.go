@@ -0,0 +1,170 @@
+package framework
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/BevisDev/godev/healthcheck"
+	"github.com/BevisDev/godev/redis"
+	"github.com/BevisDev/godev/utils/reconnect"
+)
+
+// initHealth creates the health registry with the checkers of WithHealthChecker.
+func (b *Bootstrap) initHealth() {
+	b.health = healthcheck.New(b.healthOpts...)
+	for _, c := range b.healthCheckers {
+		b.health.Register(c.name, healthcheck.CheckFunc(c.fn), c.opts...)
+	}
+}
+
+// registerHealthChecks registers a critical check for each initialized service.
+func (b *Bootstrap) registerHealthChecks() {
+	if db := b.database; db != nil {
+		b.registerServiceCheck("database", func(ctx context.Context) error {
+			sqlDB := db.GetDB()
+			if sqlDB == nil {
+				return errors.New("connection closed")
+			}
+			return sqlDB.PingContext(ctx)
+		})
+	}
+
+	if cache := b.redisCache; cache != nil {
+		b.registerServiceCheck("redis", func(ctx context.Context) error {
+			if err := cache.Ping(ctx); err != nil {
+				return b.dependencyErr("redis", err)
+			}
+			return nil
+		})
+	}
+
+	if mq := b.rabbitmq; mq != nil {
+		b.registerServiceCheck("rabbitmq", func(ctx context.Context) error {
+			if err := mq.Health(); err != nil {
+				return err
+			}
+			return mq.Topology()
+		})
+	}
+
+	if kafka := b.kafka; kafka != nil {
+		b.registerServiceCheck("kafka", func(ctx context.Context) error {
+			if kafka.IsClosed() {
+				return errors.New("client closed")
+			}
+			return nil
+		})
+	}
+
+	if s3 := b.storage; s3 != nil {
+		b.registerServiceCheck("storage", s3.Ping)
+	}
+}
+
+// registerServiceCheck registers the check of a service, remembered for unregisterHealthChecks.
+// A checker of the same name passed to WithHealthChecker takes precedence.
+func (b *Bootstrap) registerServiceCheck(name string, fn healthcheck.CheckFunc) {
+	for _, c := range b.healthCheckers {
+		if c.name == name {
+			return
+		}
+	}
+	b.health.Register(name, fn)
+	b.serviceChecks = append(b.serviceChecks, name)
+}
+
+// unregisterHealthChecks removes the checks of services being closed; checks
+// registered by the application are kept.
+func (b *Bootstrap) unregisterHealthChecks() {
+	for _, name := range b.serviceChecks {
+		b.health.Unregister(name)
+	}
+	b.serviceChecks = nil
+}
+
+// Health checks the health of all initialized services plus any custom health checkers
+// registered via WithHealthChecker. The report is DOWN when a critical check fails and
+// DEGRADED when only soft checks fail.
+func (b *Bootstrap) Health(ctx context.Context) *healthcheck.Report {
+	return b.health.Check(ctx)
+}
+
+// HealthChecks returns the health registry, to register checks after New.
+func (b *Bootstrap) HealthChecks() *healthcheck.Registry {
+	return b.health
+}
+
+// HealthHandler serves the health report as JSON with status 503 when it is down, e.g.:
+//
+//	r.GET("/readyz", gin.WrapH(b.HealthHandler()))
+//
+// It also answers server.Config.ReadinessPath when no Readiness handler is set.
+func (b *Bootstrap) HealthHandler() http.Handler {
+	return b.health.Handler()
+}
+
+// withRedisStates returns a copy of cfg whose reconnect policy also reports to Bootstrap.
+func (b *Bootstrap) withRedisStates(cfg *redis.Config) *redis.Config {
+	cc := *cfg
+
+	var policy reconnect.Policy
+	if cfg.Reconnect != nil {
+		policy = *cfg.Reconnect
+	}
+	userOnState := policy.OnStateChange
+	policy.OnStateChange = func(e reconnect.Event) {
+		b.onDependencyState(e)
+		if userOnState != nil {
+			userOnState(e)
+		}
+	}
+	cc.Reconnect = &policy
+	return &cc
+}
+
+// onDependencyState records and logs a connection state change of Redis or RabbitMQ.
+func (b *Bootstrap) onDependencyState(e reconnect.Event) {
+	b.depStatesMu.Lock()
+	if b.depStates == nil {
+		b.depStates = make(map[string]reconnect.State)
+	}
+	prev := b.depStates[e.Name]
+	b.depStates[e.Name] = e.State
+	b.depStatesMu.Unlock()
+
+	switch {
+	case e.State == reconnect.StateConnected && prev != "" && prev != reconnect.StateConnected:
+		b.log.Info("%s reconnected after %d attempt(s)", e.Name, e.Attempt)
+	case e.State == reconnect.StateFailed:
+		b.log.Error("%s: reconnect gave up after %d attempt(s): %v", e.Name, e.Attempt, e.Err)
+	case e.State == reconnect.StateDisconnected:
+		b.log.Warn("%s disconnected: %v", e.Name, e.Err)
+	}
+}
+
+// DependencyStates returns the connection states of Redis and RabbitMQ, e.g.
+// {"redis": "connected", "rabbitmq": "reconnecting"}.
+func (b *Bootstrap) DependencyStates() map[string]reconnect.State {
+	b.depStatesMu.RLock()
+	defer b.depStatesMu.RUnlock()
+
+	states := make(map[string]reconnect.State, len(b.depStates))
+	for name, state := range b.depStates {
+		states[name] = state
+	}
+	return states
+}
+
+// dependencyErr prefixes err with the connection state of name when it is not connected.
+func (b *Bootstrap) dependencyErr(name string, err error) error {
+	b.depStatesMu.RLock()
+	state := b.depStates[name]
+	b.depStatesMu.RUnlock()
+
+	if state == "" || state == reconnect.StateConnected {
+		return err
+	}
+	return fmt.Errorf("%s is %s: %w", name, state, err)
+}
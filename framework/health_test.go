@@ -0,0 +1,146 @@
+package framework
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/BevisDev/godev/database"
+	"github.com/BevisDev/godev/ginfw/server"
+	"github.com/BevisDev/godev/healthcheck"
+	"github.com/BevisDev/godev/redis"
+	"github.com/BevisDev/godev/utils/reconnect"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBootstrap_Health(t *testing.T) {
+	calls := 0
+	b := New(context.Background(),
+		WithHealth(healthcheck.WithCacheTTL(time.Hour)),
+		WithHealthChecker("api", func(ctx context.Context) error {
+			calls++
+			return nil
+		}),
+		WithHealthChecker("search", func(ctx context.Context) error { return errors.New("timeout") }, healthcheck.Soft()),
+	)
+
+	report := b.Health(context.Background())
+	assert.Equal(t, healthcheck.StatusDegraded, report.Status)
+	assert.Equal(t, []string{"api", "search"}, report.Names())
+
+	b.Health(context.Background())
+	assert.Equal(t, 1, calls, "cached result is reused")
+
+	b.HealthChecks().Register("queue", func(ctx context.Context) error { return errors.New("down") })
+	rec := httptest.NewRecorder()
+	b.HealthHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"status":"DOWN"`)
+}
+
+func TestBootstrap_DatabaseHealthUsesContext(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	require.NoError(t, err)
+	defer sqlDB.Close()
+	mock.ExpectPing().WillDelayFor(time.Second)
+
+	b := New(context.Background())
+	b.database, err = database.FromSqlx(sqlx.NewDb(sqlDB, "sqlmock"), &database.Config{DBType: database.Postgres})
+	require.NoError(t, err)
+	b.registerHealthChecks()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	report := b.Health(ctx)
+	assert.Less(t, time.Since(start), 500*time.Millisecond, "ping is cancelled with ctx")
+	assert.Equal(t, healthcheck.StatusDown, report.Status)
+}
+
+func TestBootstrap_UnregisterKeepsUserChecks(t *testing.T) {
+	b := New(context.Background(),
+		WithHealthChecker("redis", func(ctx context.Context) error { return nil }),
+	)
+	b.HealthChecks().Register("queue", func(ctx context.Context) error { return nil })
+
+	b.registerServiceCheck("redis", func(ctx context.Context) error { return errors.New("service check") })
+	b.registerServiceCheck("storage", func(ctx context.Context) error { return nil })
+	assert.Equal(t, []string{"queue", "redis", "storage"}, b.Health(context.Background()).Names())
+
+	b.unregisterHealthChecks()
+	report := b.Health(context.Background())
+	assert.Equal(t, []string{"queue", "redis"}, report.Names())
+	assert.Equal(t, healthcheck.StatusUp, report.Status, "the user's redis check is kept")
+}
+
+func TestBootstrap_DependencyStates(t *testing.T) {
+	var userEvents []reconnect.State
+	b := New(context.Background())
+
+	cfg := b.withRedisStates(&redis.Config{Reconnect: &reconnect.Policy{
+		OnStateChange: func(e reconnect.Event) { userEvents = append(userEvents, e.State) },
+	}})
+	cfg.Reconnect.Notify("redis", reconnect.StateConnected, 0, nil)
+	cfg.Reconnect.Notify("redis", reconnect.StateDisconnected, 0, errors.New("EOF"))
+	b.onDependencyState(reconnect.Event{Name: "rabbitmq", State: reconnect.StateConnected})
+
+	assert.Equal(t, map[string]reconnect.State{
+		"redis":    reconnect.StateDisconnected,
+		"rabbitmq": reconnect.StateConnected,
+	}, b.DependencyStates())
+	assert.Equal(t, []reconnect.State{reconnect.StateConnected, reconnect.StateDisconnected}, userEvents)
+
+	pingErr := errors.New("dial tcp: connection refused")
+	err := b.dependencyErr("redis", pingErr)
+	assert.ErrorIs(t, err, pingErr)
+	assert.Equal(t, "redis is disconnected: dial tcp: connection refused", err.Error())
+	assert.Same(t, pingErr, b.dependencyErr("rabbitmq", pingErr))
+}
+
+func TestBootstrap_Stop_DrainsReadiness(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := l.Addr().(*net.TCPAddr).Port
+	require.NoError(t, l.Close())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	b := New(ctx, WithServer(&server.Config{
+		Port:          port,
+		ReadinessPath: "/readyz",
+		DrainTimeout:  300 * time.Millisecond,
+	}))
+	require.NoError(t, b.Init(ctx))
+
+	started := make(chan error, 1)
+	go func() { started <- b.Start(ctx) }()
+
+	url := fmt.Sprintf("http://127.0.0.1:%d/readyz", port)
+	readyz := func() int {
+		resp, err := http.Get(url)
+		if err != nil {
+			return 0
+		}
+		_ = resp.Body.Close()
+		return resp.StatusCode
+	}
+	require.Eventually(t, func() bool { return readyz() == http.StatusOK }, 2*time.Second, 10*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-started)
+
+	stopped := make(chan error, 1)
+	go func() { stopped <- b.Stop(context.Background()) }()
+
+	// readiness fails while the server still accepts requests during the drain window
+	require.Eventually(t, func() bool { return readyz() == http.StatusServiceUnavailable }, time.Second, 10*time.Millisecond)
+	require.NoError(t, <-stopped)
+	assert.Equal(t, 0, readyz())
+}
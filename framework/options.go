@@ -6,6 +6,8 @@ import (
 
 	"github.com/BevisDev/godev/database"
 	"github.com/BevisDev/godev/ginfw/server"
+	"github.com/BevisDev/godev/ginfw/ws"
+	"github.com/BevisDev/godev/healthcheck"
 	"github.com/BevisDev/godev/kafkax"
 	"github.com/BevisDev/godev/keycloak"
 	"github.com/BevisDev/godev/logger"
@@ -15,6 +17,7 @@ import (
 	"github.com/BevisDev/godev/redis"
 	"github.com/BevisDev/godev/rest"
 	"github.com/BevisDev/godev/scheduler"
+	"github.com/BevisDev/godev/storage"
 	"github.com/BevisDev/godev/tgbot"
 )
 
@@ -27,6 +30,7 @@ type HealthCheckFunc func(ctx context.Context) error
 type healthChecker struct {
 	name string
 	fn   HealthCheckFunc
+	opts []healthcheck.CheckOption
 }
 
 type options struct {
@@ -60,13 +64,24 @@ type options struct {
 
 	mailerConf *mailer.Config
 
+	storageConf *storage.Config
+
 	schedulerOn  bool
 	schedulerOpt []scheduler.Option
 
 	serverConf *server.Config
 
+	// websocket
+	wsOn          bool
+	wsOpts        []ws.Option
+	wsRedisBridge bool
+
 	// custom health checkers (e.g. from other projects)
 	healthCheckers []healthChecker
+	healthOpts     []healthcheck.Option
+
+	// drainTimeout bounds how long Stop waits for consumers
+	drainTimeout time.Duration
 }
 
 // WithLogger configures the logger.
@@ -112,6 +127,13 @@ func WithMailer(cfg *mailer.Config) Option {
 	}
 }
 
+// WithStorage configures the S3-compatible object storage.
+func WithStorage(cfg *storage.Config) Option {
+	return func(o *options) {
+		o.storageConf = cfg
+	}
+}
+
 // WithTgBot configures the Telegram bot client to be initialized by Bootstrap.
 func WithTgBot(cfg *tgbot.Config, opts ...tgbot.Option) Option {
 	return func(o *options) {
@@ -150,6 +172,24 @@ func WithServer(cfg *server.Config) Option {
 	}
 }
 
+// WithWebSocket creates a websocket hub, started with the server and shut down
+// gracefully on Stop. Mount it with b.WebSocket().Handler().
+func WithWebSocket(opts ...ws.Option) Option {
+	return func(o *options) {
+		o.wsOn = true
+		o.wsOpts = append(o.wsOpts, opts...)
+	}
+}
+
+// WithWebSocketRedisBridge relays hub broadcasts between instances through the
+// configured Redis (see WithRedis), so every instance reaches its own clients.
+func WithWebSocketRedisBridge() Option {
+	return func(o *options) {
+		o.wsOn = true
+		o.wsRedisBridge = true
+	}
+}
+
 // WithKafka configures the Kafka connection.
 func WithKafka(cfg *kafkax.Config) Option {
 	return func(o *options) {
@@ -178,10 +218,29 @@ func WithKafkaConsumerRetry(handler kafkax.Handler, maxRetries int, retryDelay t
 
 // WithHealthChecker registers a custom health checker. Name is used as the key in Health() result.
 // Use this to plug in health checks from other projects (e.g. external APIs, custom services).
-func WithHealthChecker(name string, fn HealthCheckFunc) Option {
+// Checks are critical unless healthcheck.Soft() is given; see healthcheck.CheckOption.
+func WithHealthChecker(name string, fn HealthCheckFunc, opts ...healthcheck.CheckOption) Option {
 	return func(o *options) {
 		if name != "" && fn != nil {
-			o.healthCheckers = append(o.healthCheckers, healthChecker{name: name, fn: fn})
+			o.healthCheckers = append(o.healthCheckers, healthChecker{name: name, fn: fn, opts: opts})
+		}
+	}
+}
+
+// WithHealth configures the health registry, e.g. healthcheck.WithCacheTTL so probes
+// do not ping every service each time, or healthcheck.WithTimeout.
+func WithHealth(opts ...healthcheck.Option) Option {
+	return func(o *options) {
+		o.healthOpts = append(o.healthOpts, opts...)
+	}
+}
+
+// WithDrainTimeout sets how long Stop waits for managed consumers (AddConsumer,
+// Kafka and RabbitMQ consumers) to finish in-flight messages. Default 30s.
+func WithDrainTimeout(d time.Duration) Option {
+	return func(o *options) {
+		if d > 0 {
+			o.drainTimeout = d
 		}
 	}
 }
@@ -0,0 +1,204 @@
+package framework
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/BevisDev/godev/utils/console"
+)
+
+const (
+	defaultRestartBackoff    = time.Second
+	defaultMaxRestartBackoff = 30 * time.Second
+)
+
+// GoOption configures a goroutine started by Go.
+type GoOption func(*goOptions)
+
+type goOptions struct {
+	restart     bool
+	backoff     time.Duration
+	maxBackoff  time.Duration
+	maxRestarts int
+}
+
+// WithRestart restarts the goroutine when it panics or returns an error,
+// waiting backoff before the first restart and doubling up to maxBackoff.
+// Zero values use 1s and 30s.
+func WithRestart(backoff, maxBackoff time.Duration) GoOption {
+	return func(o *goOptions) {
+		o.restart = true
+		if backoff > 0 {
+			o.backoff = backoff
+		}
+		if maxBackoff > 0 {
+			o.maxBackoff = maxBackoff
+		}
+	}
+}
+
+// WithMaxRestarts limits the number of restarts (0 = unlimited). Implies WithRestart.
+func WithMaxRestarts(n int) GoOption {
+	return func(o *goOptions) {
+		o.restart = true
+		if n >= 0 {
+			o.maxRestarts = n
+		}
+	}
+}
+
+// Supervisor runs background goroutines with panic recovery, optional
+// auto-restart and tracked shutdown.
+type Supervisor struct {
+	log *console.Logger
+
+	mu      sync.Mutex
+	wg      sync.WaitGroup
+	ctx     context.Context
+	cancel  context.CancelFunc
+	running map[string]int
+}
+
+// NewSupervisor creates an empty Supervisor.
+func NewSupervisor() *Supervisor {
+	s := &Supervisor{
+		log:     console.New("supervisor"),
+		running: make(map[string]int),
+	}
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	return s
+}
+
+var defaultSupervisor = NewSupervisor()
+
+// Go runs fn in a goroutine supervised by the default Supervisor,
+// which Bootstrap.Stop stops and waits for. See Supervisor.Go.
+func Go(ctx context.Context, name string, fn func(ctx context.Context) error, opts ...GoOption) {
+	defaultSupervisor.Go(ctx, name, fn, opts...)
+}
+
+// Go runs fn in a goroutine. Panics are recovered and logged with the stack;
+// with WithRestart, fn is restarted after a panic or error until ctx is
+// cancelled or the Supervisor is stopped. fn's ctx is cancelled by either.
+func (s *Supervisor) Go(ctx context.Context, name string, fn func(ctx context.Context) error, opts ...GoOption) {
+	if fn == nil {
+		return
+	}
+
+	o := &goOptions{
+		backoff:    defaultRestartBackoff,
+		maxBackoff: defaultMaxRestartBackoff,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	s.mu.Lock()
+	runCtx, cancel := context.WithCancel(ctx)
+	stop := context.AfterFunc(s.ctx, cancel)
+	s.running[name]++
+	s.wg.Add(1)
+	s.mu.Unlock()
+
+	go func() {
+		defer func() {
+			stop()
+			cancel()
+			s.mu.Lock()
+			if s.running[name]--; s.running[name] <= 0 {
+				delete(s.running, name)
+			}
+			s.mu.Unlock()
+			s.wg.Done()
+		}()
+
+		backoff := o.backoff
+		for restarts := 0; ; restarts++ {
+			err := s.run(runCtx, name, fn)
+			if runCtx.Err() != nil {
+				return
+			}
+			if err == nil || !o.restart {
+				if err != nil {
+					s.log.Error("goroutine %s exited: %v", name, err)
+				}
+				return
+			}
+			if o.maxRestarts > 0 && restarts >= o.maxRestarts {
+				s.log.Error("goroutine %s exited after %d restarts: %v", name, restarts, err)
+				return
+			}
+
+			s.log.Warn("goroutine %s failed: %v, restarting in %s", name, err, backoff)
+			select {
+			case <-runCtx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			backoff = min(backoff*2, o.maxBackoff)
+		}
+	}()
+}
+
+// run calls fn, turning a panic into an error.
+func (s *Supervisor) run(ctx context.Context, name string, fn func(ctx context.Context) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.log.Error("[RECOVER] goroutine %s: %v \npanic: %s", name, r, debug.Stack())
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return fn(ctx)
+}
+
+// Running returns the names of goroutines still running, sorted.
+func (s *Supervisor) Running() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	names := make([]string, 0, len(s.running))
+	for name := range s.running {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Stop cancels every supervised goroutine and waits for them until ctx is done.
+// The Supervisor can be reused afterwards.
+func (s *Supervisor) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	s.cancel()
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("[supervisor] goroutines still running %v: %w", s.Running(), ctx.Err())
+	}
+}
+
+// stopGoroutines stops the default Supervisor, waiting at most the drain timeout.
+func (b *Bootstrap) stopGoroutines(ctx context.Context) {
+	timeout := b.drainTimeout
+	if timeout <= 0 {
+		timeout = defaultDrainTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if err := defaultSupervisor.Stop(ctx); err != nil {
+		b.log.Error("%v", err)
+	}
+}
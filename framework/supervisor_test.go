@@ -0,0 +1,86 @@
+package framework
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSupervisor_RecoversPanic(t *testing.T) {
+	s := NewSupervisor()
+	done := make(chan struct{})
+
+	s.Go(context.Background(), "panic", func(ctx context.Context) error {
+		defer close(done)
+		panic("boom")
+	})
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("goroutine did not run")
+	}
+	require.NoError(t, s.Stop(context.Background()))
+	assert.Empty(t, s.Running())
+}
+
+func TestSupervisor_RestartWithBackoff(t *testing.T) {
+	s := NewSupervisor()
+	var calls atomic.Int32
+
+	s.Go(context.Background(), "flaky", func(ctx context.Context) error {
+		if calls.Add(1) == 1 {
+			panic("first run")
+		}
+		return errors.New("fail")
+	}, WithRestart(time.Millisecond, 2*time.Millisecond), WithMaxRestarts(3))
+
+	assert.Eventually(t, func() bool { return len(s.Running()) == 0 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, int32(4), calls.Load())
+}
+
+func TestSupervisor_StopCancelsAndWaits(t *testing.T) {
+	s := NewSupervisor()
+	var stopped atomic.Bool
+
+	s.Go(context.Background(), "loop", func(ctx context.Context) error {
+		<-ctx.Done()
+		stopped.Store(true)
+		return nil
+	}, WithRestart(0, 0))
+
+	assert.Equal(t, []string{"loop"}, s.Running())
+	require.NoError(t, s.Stop(context.Background()))
+	assert.True(t, stopped.Load())
+
+	// reusable after Stop
+	ran := make(chan struct{})
+	s.Go(context.Background(), "again", func(ctx context.Context) error {
+		close(ran)
+		return nil
+	})
+	<-ran
+	require.NoError(t, s.Stop(context.Background()))
+}
+
+func TestSupervisor_StopDeadline(t *testing.T) {
+	s := NewSupervisor()
+	release := make(chan struct{})
+	defer close(release)
+
+	s.Go(context.Background(), "stuck", func(ctx context.Context) error {
+		<-release
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := s.Stop(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), "stuck")
+}
@@ -0,0 +1,140 @@
+package apikey
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/BevisDev/godev/ginfw/response"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/time/rate"
+)
+
+// ContextKey is the gin.Context key of the authenticated *Key.
+const ContextKey = "api_key"
+
+var (
+	// ErrMissingKey is passed to the reject handler when the request has no API key.
+	ErrMissingKey = errors.New("[apikey] missing api key")
+
+	// ErrInvalidKey is passed to the reject handler for unknown and disabled keys.
+	ErrInvalidKey = errors.New("[apikey] invalid api key")
+
+	// ErrExpiredKey is passed to the reject handler for expired keys.
+	ErrExpiredKey = errors.New("[apikey] api key expired")
+
+	// ErrInsufficientScope is passed to the reject handler by Require.
+	ErrInsufficientScope = errors.New("[apikey] insufficient scope")
+
+	// ErrRateLimited is passed to the reject handler when the tier of the key is exceeded.
+	ErrRateLimited = errors.New("[apikey] rate limit exceeded")
+)
+
+// APIKey authenticates requests by API key against a Store, applies the rate
+// limit tier of the key and stores the key in the request context.
+type APIKey struct {
+	*options
+	store    Store
+	limiters sync.Map // key ID -> *rate.Limiter
+}
+
+func New(store Store, opts ...Option) *APIKey {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &APIKey{options: o, store: store}
+}
+
+func (a *APIKey) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		raw := c.GetHeader(a.header)
+		if raw == "" && a.queryParam != "" {
+			raw = c.Query(a.queryParam)
+		}
+		if raw == "" {
+			if a.optional {
+				c.Next()
+				return
+			}
+			a.reject(c, ErrMissingKey)
+			return
+		}
+
+		key, err := a.store.Lookup(c.Request.Context(), raw)
+		switch {
+		case err != nil:
+			a.reject(c, err)
+			return
+		case key == nil || key.Disabled:
+			a.reject(c, ErrInvalidKey)
+			return
+		case key.Expired(time.Now()):
+			a.reject(c, ErrExpiredKey)
+			return
+		}
+
+		if l := a.limiter(key); l != nil && !l.Allow() {
+			a.reject(c, ErrRateLimited)
+			return
+		}
+
+		c.Request = c.Request.WithContext(WithKey(c.Request.Context(), key))
+		c.Set(ContextKey, key)
+		c.Next()
+	}
+}
+
+// Require rejects requests whose key lacks any of scopes with 403.
+// Use it after Handler, e.g. on a route group.
+func (a *APIKey) Require(scopes ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := FromContext(c.Request.Context())
+		if key == nil {
+			a.reject(c, ErrMissingKey)
+			return
+		}
+		if !key.HasScopes(scopes...) {
+			a.reject(c, ErrInsufficientScope)
+			return
+		}
+		c.Next()
+	}
+}
+
+// limiter returns the limiter of the key's tier, or nil when unlimited.
+func (a *APIKey) limiter(key *Key) *rate.Limiter {
+	name := key.Tier
+	if name == "" {
+		name = a.defaultTier
+	}
+	tier, ok := a.tiers[name]
+	if !ok {
+		return nil
+	}
+
+	id := name + ":" + key.ID
+	if l, ok := a.limiters.Load(id); ok {
+		return l.(*rate.Limiter)
+	}
+	l, _ := a.limiters.LoadOrStore(id, rate.NewLimiter(rate.Limit(tier.RPS), max(tier.Burst, 1)))
+	return l.(*rate.Limiter)
+}
+
+func (a *APIKey) reject(c *gin.Context, err error) {
+	c.Abort()
+	if a.onReject != nil {
+		a.onReject(c, err)
+		return
+	}
+	switch {
+	case errors.Is(err, ErrMissingKey), errors.Is(err, ErrInvalidKey), errors.Is(err, ErrExpiredKey):
+		response.Unauthorized(c, "", "")
+	case errors.Is(err, ErrInsufficientScope):
+		response.Forbidden(c, "", "")
+	case errors.Is(err, ErrRateLimited):
+		response.TooManyRequests(c, "", "")
+	default:
+		response.ServiceUnavailable(c, "", "")
+	}
+}
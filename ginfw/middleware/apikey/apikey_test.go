@@ -0,0 +1,109 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/BevisDev/godev/utils/ctxmeta"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+var expired = time.Now().Add(-time.Hour)
+
+var store = StaticStore{
+	"k-admin":    {ID: "1", Scopes: []string{ScopeAll}},
+	"k-reader":   {ID: "2", Scopes: []string{"orders:read"}, Tier: "free"},
+	"k-disabled": {ID: "3", Disabled: true},
+	"k-expired":  {ID: "4", ExpiresAt: &expired},
+}
+
+func router(mw *APIKey) *gin.Engine {
+	gin.SetMode(gin.ReleaseMode)
+
+	r := gin.New()
+	api := r.Group("/api", mw.Handler())
+	api.GET("/orders", mw.Require("orders:read"), func(c *gin.Context) {
+		ctx := c.Request.Context()
+		c.String(http.StatusOK, FromContext(ctx).ID+"|"+ctxmeta.Value(ctx, MetaKeyID))
+	})
+	api.POST("/orders", mw.Require("orders:write"), func(c *gin.Context) {
+		c.Status(http.StatusCreated)
+	})
+	return r
+}
+
+func do(r *gin.Engine, method, key string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/api/orders", nil)
+	if key != "" {
+		req.Header.Set(DefaultHeader, key)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestAPIKey_Authenticate(t *testing.T) {
+	r := router(New(store))
+
+	w := do(r, "GET", "k-reader")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "2|2", w.Body.String())
+
+	assert.Equal(t, http.StatusUnauthorized, do(r, "GET", "").Code)
+	assert.Equal(t, http.StatusUnauthorized, do(r, "GET", "k-unknown").Code)
+	assert.Equal(t, http.StatusUnauthorized, do(r, "GET", "k-disabled").Code)
+	assert.Equal(t, http.StatusUnauthorized, do(r, "GET", "k-expired").Code)
+}
+
+func TestAPIKey_Scopes(t *testing.T) {
+	r := router(New(store))
+
+	assert.Equal(t, http.StatusForbidden, do(r, "POST", "k-reader").Code)
+	assert.Equal(t, http.StatusCreated, do(r, "POST", "k-admin").Code)
+}
+
+func TestAPIKey_Tiers(t *testing.T) {
+	r := router(New(store,
+		WithTier("free", Tier{RPS: 0.001, Burst: 2}),
+		WithTier("default", Tier{RPS: 0.001, Burst: 1}),
+		WithDefaultTier("default"),
+	))
+
+	assert.Equal(t, http.StatusOK, do(r, "GET", "k-reader").Code)
+	assert.Equal(t, http.StatusOK, do(r, "GET", "k-reader").Code)
+	assert.Equal(t, http.StatusTooManyRequests, do(r, "GET", "k-reader").Code)
+
+	assert.Equal(t, http.StatusOK, do(r, "GET", "k-admin").Code)
+	assert.Equal(t, http.StatusTooManyRequests, do(r, "GET", "k-admin").Code)
+}
+
+func TestAPIKey_QueryParamAndOptional(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+
+	var got *Key
+	r := gin.New()
+	r.GET("/", New(store, WithQueryParam("api_key"), WithOptional()).Handler(), func(c *gin.Context) {
+		got = FromContext(c.Request.Context())
+	})
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/?api_key=k-admin", nil))
+	assert.Equal(t, "1", got.ID)
+
+	got = nil
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Nil(t, got)
+}
+
+func TestAPIKey_StoreError(t *testing.T) {
+	failing := StoreFunc(func(ctx context.Context, key string) (*Key, error) {
+		return nil, errors.New("db down")
+	})
+	assert.Equal(t, http.StatusServiceUnavailable, do(router(New(failing)), "GET", "k").Code)
+}
@@ -0,0 +1,69 @@
+package apikey
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"time"
+
+	"github.com/BevisDev/godev/utils/ctxmeta"
+)
+
+// MetaKeyID is the ctxmeta key of the API key ID, so it appears in ctxmeta.Metadata.
+const MetaKeyID = "api_key_id"
+
+// ScopeAll grants every scope.
+const ScopeAll = "*"
+
+// Key is the identity behind an API key.
+type Key struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name,omitempty"`
+	Scopes    []string   `json:"scopes,omitempty"`
+	Tier      string     `json:"tier,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Disabled  bool       `json:"disabled,omitempty"`
+}
+
+// HasScopes reports whether the key has every scope (or ScopeAll).
+func (k *Key) HasScopes(scopes ...string) bool {
+	if slices.Contains(k.Scopes, ScopeAll) {
+		return true
+	}
+	for _, s := range scopes {
+		if !slices.Contains(k.Scopes, s) {
+			return false
+		}
+	}
+	return true
+}
+
+// Expired reports whether the key expired at now.
+func (k *Key) Expired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
+
+// Hash returns the hex SHA-256 of a raw API key, as stored by DBStore.
+// Only hashes are persisted, so a leaked table does not leak usable keys.
+func Hash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+type ctxKey struct{}
+
+// WithKey stores the authenticated key in ctx, with its ID as ctxmeta value MetaKeyID.
+func WithKey(ctx context.Context, k *Key) context.Context {
+	ctx = ctxmeta.WithValue(ctx, MetaKeyID, k.ID)
+	return context.WithValue(ctx, ctxKey{}, k)
+}
+
+// FromContext returns the authenticated key, or nil.
+func FromContext(ctx context.Context) *Key {
+	if ctx == nil {
+		return nil
+	}
+	k, _ := ctx.Value(ctxKey{}).(*Key)
+	return k
+}
@@ -0,0 +1,79 @@
+package apikey
+
+import "github.com/gin-gonic/gin"
+
+// DefaultHeader is the header carrying the API key.
+const DefaultHeader = "X-API-Key"
+
+// Tier is a rate limit applied per key.
+type Tier struct {
+	// RPS is the sustained number of requests per second.
+	RPS float64
+
+	// Burst is the number of requests allowed at once (default 1).
+	Burst int
+}
+
+type Option func(*options)
+
+type options struct {
+	header      string
+	queryParam  string
+	optional    bool
+	tiers       map[string]Tier
+	defaultTier string
+	onReject    func(c *gin.Context, err error)
+}
+
+func defaultOptions() *options {
+	return &options{
+		header: DefaultHeader,
+		tiers:  make(map[string]Tier),
+	}
+}
+
+// WithHeader sets the header carrying the key (default "X-API-Key").
+func WithHeader(name string) Option {
+	return func(o *options) {
+		if name != "" {
+			o.header = name
+		}
+	}
+}
+
+// WithQueryParam also accepts the key in a query parameter, e.g. "api_key".
+// Keys in URLs end up in access logs; prefer the header.
+func WithQueryParam(name string) Option {
+	return func(o *options) {
+		o.queryParam = name
+	}
+}
+
+// WithOptional lets requests without a key through unauthenticated;
+// invalid keys are still rejected.
+func WithOptional() Option {
+	return func(o *options) {
+		o.optional = true
+	}
+}
+
+// WithTier defines a rate limit tier referenced by Key.Tier.
+func WithTier(name string, tier Tier) Option {
+	return func(o *options) {
+		o.tiers[name] = tier
+	}
+}
+
+// WithDefaultTier applies a tier to keys without one (default unlimited).
+func WithDefaultTier(name string) Option {
+	return func(o *options) {
+		o.defaultTier = name
+	}
+}
+
+// WithOnReject overrides the response of rejected requests.
+func WithOnReject(fn func(c *gin.Context, err error)) Option {
+	return func(o *options) {
+		o.onReject = fn
+	}
+}
@@ -0,0 +1,159 @@
+package apikey
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/BevisDev/godev/database"
+	"github.com/BevisDev/godev/redis"
+)
+
+// Store looks up the key behind a raw API key.
+// It returns nil without error when the key is unknown.
+type Store interface {
+	Lookup(ctx context.Context, key string) (*Key, error)
+}
+
+// StoreFunc adapts a function to Store.
+type StoreFunc func(ctx context.Context, key string) (*Key, error)
+
+func (f StoreFunc) Lookup(ctx context.Context, key string) (*Key, error) {
+	return f(ctx, key)
+}
+
+// StaticStore holds keys from configuration, by raw API key.
+type StaticStore map[string]Key
+
+func (s StaticStore) Lookup(_ context.Context, key string) (*Key, error) {
+	k, ok := s[key]
+	if !ok {
+		return nil, nil
+	}
+	return &k, nil
+}
+
+// DBStore looks up keys by hash (see Hash) in a database table created with Schema.
+type DBStore struct {
+	db    *database.DB
+	query string
+}
+
+// NewDBStore returns a store reading table (default "api_keys").
+func NewDBStore(db *database.DB, table string) *DBStore {
+	if table == "" {
+		table = "api_keys"
+	}
+	return &DBStore{
+		db:    db,
+		query: fmt.Sprintf("SELECT id, name, scopes, tier, expires_at, disabled FROM %s WHERE key_hash = ?", table),
+	}
+}
+
+type keyRow struct {
+	ID        string         `db:"id"`
+	Name      sql.NullString `db:"name"`
+	Scopes    sql.NullString `db:"scopes"`
+	Tier      sql.NullString `db:"tier"`
+	ExpiresAt sql.NullTime   `db:"expires_at"`
+	Disabled  bool           `db:"disabled"`
+}
+
+func (s *DBStore) Lookup(ctx context.Context, key string) (*Key, error) {
+	var row keyRow
+	if err := s.db.GetAny(ctx, &row, s.query, Hash(key)); err != nil {
+		if s.db.IsNoResult(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	k := &Key{
+		ID:       row.ID,
+		Name:     row.Name.String,
+		Scopes:   strings.FieldsFunc(row.Scopes.String, func(r rune) bool { return r == ',' || r == ' ' }),
+		Tier:     row.Tier.String,
+		Disabled: row.Disabled,
+	}
+	if row.ExpiresAt.Valid {
+		t := row.ExpiresAt.Time
+		k.ExpiresAt = &t
+	}
+	return k, nil
+}
+
+// Schema returns the CREATE TABLE statement of the key table for dbType.
+//
+// Columns: id, key_hash (Hash of the raw key), name, scopes (comma separated),
+// tier, expires_at, disabled and created_at.
+func Schema(dbType database.DBType, table string) string {
+	var text, ts, boolean string
+	switch dbType {
+	case database.SqlServer:
+		text, ts, boolean = "NVARCHAR(1024)", "DATETIME2", "BIT NOT NULL DEFAULT 0"
+	case database.Postgres:
+		text, ts, boolean = "VARCHAR(1024)", "TIMESTAMP", "BOOLEAN NOT NULL DEFAULT FALSE"
+	case database.Oracle:
+		text, ts, boolean = "VARCHAR2(1024)", "TIMESTAMP", "NUMBER(1) DEFAULT 0 NOT NULL"
+	default: // mysql
+		text, ts, boolean = "VARCHAR(1024)", "DATETIME(6)", "BOOLEAN NOT NULL DEFAULT FALSE"
+	}
+
+	return fmt.Sprintf(`CREATE TABLE %s (
+    id VARCHAR(64) PRIMARY KEY,
+    key_hash CHAR(64) NOT NULL UNIQUE,
+    name VARCHAR(128),
+    scopes %s,
+    tier VARCHAR(64),
+    expires_at %s,
+    disabled %s,
+    created_at %s NOT NULL
+)`, table, text, ts, boolean, ts)
+}
+
+// CachedStore caches the lookups of another store in Redis, including unknown keys,
+// so a database store is not queried on every request.
+type CachedStore struct {
+	next  Store
+	cache *redis.Cache
+	ttl   time.Duration
+}
+
+// NewCachedStore caches the lookups of next for ttl (default 5m).
+// Revoked keys stay valid until their entry expires; see Invalidate.
+func NewCachedStore(next Store, cache *redis.Cache, ttl time.Duration) *CachedStore {
+	if ttl <= 0 {
+		ttl = 5 * time.Minute
+	}
+	return &CachedStore{next: next, cache: cache, ttl: ttl}
+}
+
+// cachedKey distinguishes a cached unknown key from a cache miss.
+type cachedKey struct {
+	Key *Key `json:"key"`
+}
+
+func (s *CachedStore) Lookup(ctx context.Context, key string) (*Key, error) {
+	ck := s.cacheKey(key)
+	if hit, err := redis.With[*cachedKey](s.cache).Key(ck).Get(ctx); err == nil && hit != nil {
+		return hit.Key, nil
+	}
+
+	k, err := s.next.Lookup(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	_ = redis.With[*cachedKey](s.cache).Key(ck).Value(&cachedKey{Key: k}).Expire(s.ttl).Set(ctx)
+	return k, nil
+}
+
+// Invalidate removes the cached lookup of a raw API key, e.g. after revoking it.
+func (s *CachedStore) Invalidate(ctx context.Context, key string) error {
+	return redis.With[*cachedKey](s.cache).Key(s.cacheKey(key)).Delete(ctx)
+}
+
+func (s *CachedStore) cacheKey(key string) string {
+	return "apikey:" + Hash(key)
+}
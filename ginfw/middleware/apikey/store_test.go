@@ -0,0 +1,79 @@
+package apikey
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/BevisDev/godev/database"
+	"github.com/BevisDev/godev/database/dbtest"
+	"github.com/BevisDev/godev/redis/redistest"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDBStore(t *testing.T) {
+	db := dbtest.NewMock(t)
+	expires := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	query := "SELECT id, name, scopes, tier, expires_at, disabled FROM api_keys WHERE key_hash = ?"
+	db.Mock.ExpectQuery(query).
+		WithArgs(Hash("k-1")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "scopes", "tier", "expires_at", "disabled"}).
+			AddRow("1", "billing", "orders:read, orders:write", "pro", expires, false))
+	db.Mock.ExpectQuery(query).
+		WithArgs(Hash("k-unknown")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	s := NewDBStore(db.DB, "")
+	k, err := s.Lookup(context.Background(), "k-1")
+	require.NoError(t, err)
+	assert.Equal(t, &Key{
+		ID:        "1",
+		Name:      "billing",
+		Scopes:    []string{"orders:read", "orders:write"},
+		Tier:      "pro",
+		ExpiresAt: &expires,
+	}, k)
+
+	k, err = s.Lookup(context.Background(), "k-unknown")
+	require.NoError(t, err)
+	assert.Nil(t, k)
+}
+
+func TestCachedStore(t *testing.T) {
+	cache := redistest.New(t)
+
+	calls := 0
+	next := StoreFunc(func(ctx context.Context, key string) (*Key, error) {
+		calls++
+		if key == "k-1" {
+			return &Key{ID: "1"}, nil
+		}
+		return nil, nil
+	})
+	s := NewCachedStore(next, cache.Cache, time.Minute)
+	ctx := context.Background()
+
+	for range 2 {
+		k, err := s.Lookup(ctx, "k-1")
+		require.NoError(t, err)
+		assert.Equal(t, "1", k.ID)
+
+		k, err = s.Lookup(ctx, "k-unknown")
+		require.NoError(t, err)
+		assert.Nil(t, k)
+	}
+	assert.Equal(t, 2, calls, "hits and unknown keys are cached")
+	cache.AssertTTL("apikey:"+Hash("k-1"), time.Minute)
+
+	require.NoError(t, s.Invalidate(ctx, "k-1"))
+	_, _ = s.Lookup(ctx, "k-1")
+	assert.Equal(t, 3, calls)
+}
+
+func TestSchema(t *testing.T) {
+	assert.Contains(t, Schema(database.Postgres, "api_keys"), "key_hash CHAR(64) NOT NULL UNIQUE")
+	assert.Contains(t, Schema(database.SqlServer, "api_keys"), "disabled BIT")
+}
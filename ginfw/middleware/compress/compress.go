@@ -0,0 +1,224 @@
+package compress
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/BevisDev/godev/consts"
+	"github.com/andybalholm/brotli"
+	"github.com/gin-gonic/gin"
+)
+
+// encoder is a pooled gzip or brotli writer.
+type encoder interface {
+	io.WriteCloser
+	Reset(w io.Writer)
+	Flush() error
+}
+
+// Compress compresses responses with brotli or gzip, as negotiated with Accept-Encoding.
+// Responses below the minimum size or of other content types are sent as-is.
+type Compress struct {
+	*options
+	pools map[string]*sync.Pool
+}
+
+func New(opts ...Option) *Compress {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return &Compress{
+		options: o,
+		pools: map[string]*sync.Pool{
+			Gzip: {New: func() any {
+				w, _ := gzip.NewWriterLevel(io.Discard, o.gzipLevel)
+				return w
+			}},
+			Brotli: {New: func() any {
+				return brotli.NewWriterLevel(io.Discard, o.brotliLevel)
+			}},
+		},
+	}
+}
+
+func (m *Compress) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Vary", "Accept-Encoding")
+
+		encoding := m.negotiate(c.GetHeader("Accept-Encoding"))
+		if encoding == "" || c.Request.Method == http.MethodHead {
+			c.Next()
+			return
+		}
+		if _, ok := m.excluded[c.FullPath()]; ok {
+			c.Next()
+			return
+		}
+		if _, ok := m.excluded[c.Request.URL.Path]; ok {
+			c.Next()
+			return
+		}
+
+		w := &compressWriter{ResponseWriter: c.Writer, m: m, encoding: encoding}
+		c.Writer = w
+		defer func() {
+			w.close()
+			c.Writer = w.ResponseWriter
+		}()
+
+		c.Next()
+	}
+}
+
+// negotiate picks the supported encoding with the highest q-value of the
+// Accept-Encoding header; ties go to the configured preference.
+func (m *Compress) negotiate(accept string) string {
+	if accept == "" {
+		return ""
+	}
+
+	weights := make(map[string]float64)
+	for _, part := range strings.Split(accept, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				q = f
+			}
+		}
+		weights[strings.ToLower(strings.TrimSpace(name))] = q
+	}
+
+	best, bestQ := "", 0.0
+	for _, enc := range m.encodings {
+		if _, ok := m.pools[enc]; !ok {
+			continue
+		}
+		q, ok := weights[enc]
+		if !ok {
+			q = weights["*"]
+		}
+		if q > bestQ {
+			best, bestQ = enc, q
+		}
+	}
+	return best
+}
+
+// compressible reports whether responses of contentType are compressed.
+func (m *Compress) compressible(contentType string) bool {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+	if mediaType == "" {
+		return false
+	}
+	for _, t := range m.contentTypes {
+		if mediaType == t || strings.HasSuffix(t, "/") && strings.HasPrefix(mediaType, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// compressWriter buffers the response until the minimum size is reached and
+// then either compresses it or passes it through.
+type compressWriter struct {
+	gin.ResponseWriter
+	m        *Compress
+	encoding string
+
+	buf     bytes.Buffer
+	enc     encoder
+	decided bool
+}
+
+func (w *compressWriter) Write(b []byte) (int, error) {
+	if w.decided {
+		if w.enc != nil {
+			return w.enc.Write(b)
+		}
+		return w.ResponseWriter.Write(b)
+	}
+
+	w.buf.Write(b)
+	if w.buf.Len() >= w.m.minSize {
+		if err := w.decide(true); err != nil {
+			return 0, err
+		}
+	}
+	return len(b), nil
+}
+
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush sends the buffered response; a stream started before the minimum
+// size is reached is not compressed.
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		_ = w.decide(false)
+	}
+	if w.enc != nil {
+		_ = w.enc.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// decide starts compressing when large is set and the response is eligible,
+// and writes out the buffered bytes.
+func (w *compressWriter) decide(large bool) error {
+	w.decided = true
+
+	if large && w.eligible() {
+		h := w.Header()
+		h.Set("Content-Encoding", w.encoding)
+		h.Del("Content-Length")
+
+		w.enc = w.m.pools[w.encoding].Get().(encoder)
+		w.enc.Reset(w.ResponseWriter)
+		if w.buf.Len() > 0 {
+			_, err := w.enc.Write(w.buf.Bytes())
+			return err
+		}
+		return nil
+	}
+
+	if w.buf.Len() > 0 {
+		_, err := w.ResponseWriter.Write(w.buf.Bytes())
+		return err
+	}
+	return nil
+}
+
+func (w *compressWriter) eligible() bool {
+	switch w.Status() {
+	case http.StatusNoContent, http.StatusNotModified, http.StatusPartialContent:
+		return false
+	}
+	h := w.Header()
+	if h.Get("Content-Encoding") != "" {
+		return false
+	}
+	return w.m.compressible(h.Get(consts.ContentType))
+}
+
+// close writes out a response below the minimum size or finishes the compressed stream.
+func (w *compressWriter) close() {
+	if !w.decided {
+		_ = w.decide(false)
+	}
+	if w.enc != nil {
+		_ = w.enc.Close()
+		w.enc.Reset(io.Discard)
+		w.m.pools[w.encoding].Put(w.enc)
+		w.enc = nil
+	}
+}
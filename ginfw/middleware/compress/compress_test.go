@@ -0,0 +1,100 @@
+package compress
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var large = strings.Repeat(`{"id":1,"name":"item"},`, 100)
+
+func serve(mw *Compress, accept, path string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.ReleaseMode)
+
+	r := gin.New()
+	r.Use(mw.Handler())
+	r.GET("/large", func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/json; charset=utf-8", []byte(large))
+	})
+	r.GET("/small", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"id": 1})
+	})
+	r.GET("/image", func(c *gin.Context) {
+		c.Data(http.StatusOK, "image/png", []byte(large))
+	})
+
+	req := httptest.NewRequest("GET", path, nil)
+	if accept != "" {
+		req.Header.Set("Accept-Encoding", accept)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestCompress_Gzip(t *testing.T) {
+	w := serve(New(), "gzip, deflate", "/large")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+	assert.Less(t, w.Body.Len(), len(large))
+
+	zr, err := gzip.NewReader(w.Body)
+	require.NoError(t, err)
+	raw, err := io.ReadAll(zr)
+	require.NoError(t, err)
+	assert.Equal(t, large, string(raw))
+}
+
+func TestCompress_Brotli(t *testing.T) {
+	w := serve(New(), "gzip, br", "/large")
+	assert.Equal(t, "br", w.Header().Get("Content-Encoding"))
+
+	raw, err := io.ReadAll(brotli.NewReader(w.Body))
+	require.NoError(t, err)
+	assert.Equal(t, large, string(raw))
+}
+
+func TestCompress_PassThrough(t *testing.T) {
+	tests := []struct {
+		name   string
+		mw     *Compress
+		accept string
+		path   string
+	}{
+		{"no accept-encoding", New(), "", "/large"},
+		{"below min size", New(), "gzip", "/small"},
+		{"content type", New(), "gzip", "/image"},
+		{"excluded path", New(WithExcludedPaths("/large")), "gzip", "/large"},
+		{"unsupported encoding", New(WithEncodings(Gzip)), "br", "/large"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(tt.mw, tt.accept, tt.path)
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Empty(t, w.Header().Get("Content-Encoding"))
+			assert.NotEmpty(t, w.Body.String())
+		})
+	}
+
+	w := serve(New(WithMinSize(0)), "gzip", "/small")
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+}
+
+func TestNegotiate(t *testing.T) {
+	m := New()
+	assert.Equal(t, "br", m.negotiate("gzip, br"))
+	assert.Equal(t, "gzip", m.negotiate("gzip;q=1.0, br;q=0.5"))
+	assert.Equal(t, "gzip", m.negotiate("br;q=0, gzip"))
+	assert.Equal(t, "br", m.negotiate("*"))
+	assert.Equal(t, "", m.negotiate("identity"))
+	assert.Equal(t, "", m.negotiate(""))
+}
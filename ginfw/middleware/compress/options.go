@@ -0,0 +1,101 @@
+package compress
+
+import (
+	"compress/gzip"
+)
+
+// Content encodings.
+const (
+	Gzip   = "gzip"
+	Brotli = "br"
+)
+
+const (
+	defaultMinSize     = 1024
+	defaultBrotliLevel = 4
+)
+
+// DefaultContentTypes are compressed when no content types are configured.
+// Entries ending in "/" match every subtype.
+var DefaultContentTypes = []string{
+	"application/json",
+	"application/problem+json",
+	"application/xml",
+	"application/javascript",
+	"image/svg+xml",
+	"text/",
+}
+
+type Option func(*options)
+
+type options struct {
+	encodings    []string
+	minSize      int
+	contentTypes []string
+	excluded     map[string]struct{}
+	gzipLevel    int
+	brotliLevel  int
+}
+
+func defaultOptions() *options {
+	return &options{
+		encodings:    []string{Brotli, Gzip},
+		minSize:      defaultMinSize,
+		contentTypes: DefaultContentTypes,
+		excluded:     make(map[string]struct{}),
+		gzipLevel:    gzip.DefaultCompression,
+		brotliLevel:  defaultBrotliLevel,
+	}
+}
+
+// WithEncodings sets the supported encodings in order of preference (default "br", "gzip").
+func WithEncodings(encodings ...string) Option {
+	return func(o *options) {
+		if len(encodings) > 0 {
+			o.encodings = encodings
+		}
+	}
+}
+
+// WithMinSize compresses only responses of at least n bytes (default 1024).
+func WithMinSize(n int) Option {
+	return func(o *options) {
+		if n >= 0 {
+			o.minSize = n
+		}
+	}
+}
+
+// WithContentTypes replaces the compressed content types (default DefaultContentTypes).
+func WithContentTypes(types ...string) Option {
+	return func(o *options) {
+		o.contentTypes = types
+	}
+}
+
+// WithExcludedPaths never compresses responses of these paths (e.g. "/metrics").
+func WithExcludedPaths(paths ...string) Option {
+	return func(o *options) {
+		for _, p := range paths {
+			o.excluded[p] = struct{}{}
+		}
+	}
+}
+
+// WithGzipLevel sets the gzip level (gzip.BestSpeed to gzip.BestCompression).
+func WithGzipLevel(level int) Option {
+	return func(o *options) {
+		if level >= gzip.HuffmanOnly && level <= gzip.BestCompression {
+			o.gzipLevel = level
+		}
+	}
+}
+
+// WithBrotliLevel sets the brotli level, 0 to 11 (default 4).
+func WithBrotliLevel(level int) Option {
+	return func(o *options) {
+		if level >= 0 && level <= 11 {
+			o.brotliLevel = level
+		}
+	}
+}
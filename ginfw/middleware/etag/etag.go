@@ -0,0 +1,112 @@
+package etag
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/BevisDev/godev/ginfw/response"
+	"github.com/gin-gonic/gin"
+)
+
+// ETag buffers successful GET and HEAD responses, sets an ETag computed from
+// the body (unless the handler set one) and answers a matching If-None-Match
+// with response.NotModified instead of the body.
+type ETag struct {
+	*options
+}
+
+func New(opts ...Option) *ETag {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &ETag{options: o}
+}
+
+func (e *ETag) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		method := c.Request.Method
+		if method != http.MethodGet && method != http.MethodHead {
+			c.Next()
+			return
+		}
+
+		w := &etagWriter{ResponseWriter: c.Writer, maxSize: e.maxSize}
+		c.Writer = w
+		c.Next()
+		c.Writer = w.ResponseWriter
+
+		if w.streaming {
+			return
+		}
+		if w.Status() != http.StatusOK || w.buf.Len() == 0 {
+			w.flush()
+			return
+		}
+
+		tag := w.Header().Get("ETag")
+		if tag == "" {
+			tag = e.compute(w.buf.Bytes())
+		}
+		if response.CheckETag(c, tag) {
+			return
+		}
+		w.flush()
+	}
+}
+
+// compute returns the quoted ETag of body.
+func (e *ETag) compute(body []byte) string {
+	sum := sha256.Sum256(body)
+	tag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	if e.strong {
+		return tag
+	}
+	return "W/" + tag
+}
+
+// etagWriter buffers the body until maxSize is exceeded, then streams it.
+type etagWriter struct {
+	gin.ResponseWriter
+	maxSize   int
+	buf       bytes.Buffer
+	streaming bool
+}
+
+func (w *etagWriter) Write(b []byte) (int, error) {
+	if w.streaming {
+		return w.ResponseWriter.Write(b)
+	}
+	w.buf.Write(b)
+	if w.buf.Len() > w.maxSize {
+		w.streaming = true
+		if err := w.flush(); err != nil {
+			return 0, err
+		}
+	}
+	return len(b), nil
+}
+
+func (w *etagWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush gives up on the ETag: the response is streamed from here on.
+func (w *etagWriter) Flush() {
+	if !w.streaming {
+		w.streaming = true
+		_ = w.flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+func (w *etagWriter) flush() error {
+	if w.buf.Len() == 0 {
+		return nil
+	}
+	_, err := w.ResponseWriter.Write(w.buf.Bytes())
+	w.buf.Reset()
+	return err
+}
@@ -0,0 +1,116 @@
+package etag
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/BevisDev/godev/ginfw/middleware/compress"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var body = strings.Repeat(`{"id":1,"name":"item"},`, 100)
+
+func router(handlers ...gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.ReleaseMode)
+
+	r := gin.New()
+	r.Use(handlers...)
+	r.GET("/items", func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/json", []byte(body))
+	})
+	r.GET("/versioned", func(c *gin.Context) {
+		c.Header("ETag", `"v7"`)
+		c.Data(http.StatusOK, "application/json", []byte(body))
+	})
+	r.GET("/missing", func(c *gin.Context) {
+		c.String(http.StatusNotFound, "not found")
+	})
+	r.POST("/items", func(c *gin.Context) {
+		c.String(http.StatusCreated, "created")
+	})
+	return r
+}
+
+func do(r *gin.Engine, method, path, ifNoneMatch string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	if ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", ifNoneMatch)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestETag_NotModified(t *testing.T) {
+	r := router(New().Handler())
+
+	w := do(r, "GET", "/items", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, body, w.Body.String())
+
+	tag := w.Header().Get("ETag")
+	require.True(t, strings.HasPrefix(tag, `W/"`))
+
+	w = do(r, "GET", "/items", tag)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+
+	w = do(r, "GET", "/items", `W/"other"`)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestETag_HandlerETag(t *testing.T) {
+	r := router(New().Handler())
+
+	w := do(r, "GET", "/versioned", "")
+	assert.Equal(t, `"v7"`, w.Header().Get("ETag"))
+
+	w = do(r, "GET", "/versioned", `"v7"`)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+}
+
+func TestETag_Skipped(t *testing.T) {
+	r := router(New().Handler())
+
+	w := do(r, "GET", "/missing", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Empty(t, w.Header().Get("ETag"))
+	assert.Equal(t, "not found", w.Body.String())
+
+	w = do(r, "POST", "/items", "*")
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Empty(t, w.Header().Get("ETag"))
+
+	w = do(router(New(WithMaxSize(10)).Handler()), "GET", "/items", "*")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("ETag"))
+	assert.Equal(t, body, w.Body.String())
+}
+
+func TestETag_Strong(t *testing.T) {
+	w := do(router(New(WithStrong()).Handler()), "GET", "/items", "")
+	assert.True(t, strings.HasPrefix(w.Header().Get("ETag"), `"`))
+}
+
+func TestETag_WithCompress(t *testing.T) {
+	r := router(compress.New().Handler(), New().Handler())
+
+	req := httptest.NewRequest("GET", "/items", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+
+	req = httptest.NewRequest("GET", "/items", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	req.Header.Set("If-None-Match", w.Header().Get("ETag"))
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+	assert.Empty(t, w.Body.String())
+}
@@ -0,0 +1,34 @@
+package etag
+
+type Option func(*options)
+
+type options struct {
+	strong  bool
+	maxSize int
+}
+
+const defaultMaxSize = 4 << 20
+
+func defaultOptions() *options {
+	return &options{
+		maxSize: defaultMaxSize,
+	}
+}
+
+// WithStrong generates strong ETags ("x") instead of weak ones (W/"x").
+// Only use it when no middleware changes the body afterwards, e.g. compression.
+func WithStrong() Option {
+	return func(o *options) {
+		o.strong = true
+	}
+}
+
+// WithMaxSize skips ETags for responses larger than n bytes (default 4 MiB),
+// which are then streamed instead of buffered.
+func WithMaxSize(n int) Option {
+	return func(o *options) {
+		if n > 0 {
+			o.maxSize = n
+		}
+	}
+}
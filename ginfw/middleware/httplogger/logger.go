@@ -12,6 +12,7 @@ import (
 
 	"github.com/BevisDev/godev/consts"
 	"github.com/BevisDev/godev/utils"
+	"github.com/BevisDev/godev/utils/ctxmeta"
 	"github.com/BevisDev/godev/utils/datetime"
 	"github.com/BevisDev/godev/utils/random"
 	"github.com/gin-gonic/gin"
@@ -53,7 +54,7 @@ func (h *HTTPLogger) Handler() gin.HandlerFunc {
 		}
 
 		// Attach RID to context.Context
-		ctx := utils.SetValueCtx(c.Request.Context(), consts.RID, rid)
+		ctx := ctxmeta.WithRID(c.Request.Context(), rid)
 		c.Request = c.Request.WithContext(ctx)
 
 		// Read and log request
@@ -0,0 +1,242 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/BevisDev/godev/ginfw/response"
+	"github.com/BevisDev/godev/redis"
+	"github.com/BevisDev/godev/utils/console"
+	"github.com/gin-gonic/gin"
+)
+
+// ReplayedHeader is set to "true" on replayed responses.
+const ReplayedHeader = "Idempotent-Replayed"
+
+var (
+	// ErrMissingKey is passed to the reject handler when the request has no idempotency key.
+	ErrMissingKey = errors.New("[idempotency] missing idempotency key")
+
+	// ErrInProgress is passed to the reject handler while a request with the same key is processed.
+	ErrInProgress = errors.New("[idempotency] request with the same key is in progress")
+
+	// ErrKeyMismatch is passed to the reject handler when a key is reused with a different body.
+	ErrKeyMismatch = errors.New("[idempotency] key reused with a different request")
+)
+
+const (
+	stateProcessing = "processing"
+	stateCompleted  = "completed"
+)
+
+// record is stored in Redis under the key: a lock while processing, the response once completed.
+type record struct {
+	State       string      `json:"state"`
+	Fingerprint string      `json:"fingerprint"`
+	Status      int         `json:"status,omitempty"`
+	Header      http.Header `json:"header,omitempty"`
+	Body        []byte      `json:"body,omitempty"`
+}
+
+// Idempotency enforces an idempotency key on requests: the first response for a
+// key is stored in Redis and replayed for retries, and concurrent requests with
+// the same key are rejected with 409 while the first is processed.
+type Idempotency struct {
+	*options
+	cache *redis.Cache
+	log   *console.Logger
+}
+
+func New(cache *redis.Cache, opts ...Option) *Idempotency {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &Idempotency{
+		options: o,
+		cache:   cache,
+		log:     console.New("idempotency"),
+	}
+}
+
+func (i *Idempotency) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := i.methods[c.Request.Method]; !ok {
+			c.Next()
+			return
+		}
+
+		key := c.GetHeader(i.header)
+		if key == "" {
+			if i.optional {
+				c.Next()
+				return
+			}
+			i.reject(c, ErrMissingKey)
+			return
+		}
+
+		fingerprint, err := i.fingerprint(c)
+		if err != nil {
+			i.reject(c, err)
+			return
+		}
+
+		ctx := c.Request.Context()
+		rkey := i.key(c, key)
+		locked, err := redis.With[*record](i.cache).
+			Key(rkey).
+			Value(&record{State: stateProcessing, Fingerprint: fingerprint}).
+			Expire(i.lockTTL).
+			SetIfNotExists(ctx)
+		if err != nil {
+			i.reject(c, err)
+			return
+		}
+		if !locked {
+			i.replay(c, rkey, fingerprint)
+			return
+		}
+
+		i.process(c, rkey, fingerprint)
+	}
+}
+
+// process runs the handler and stores its response; the lock is released
+// without storing on 5xx and panics, so the request can be retried.
+func (i *Idempotency) process(c *gin.Context, rkey, fingerprint string) {
+	ctx := context.WithoutCancel(c.Request.Context())
+	stored := false
+	defer func() {
+		if !stored {
+			if err := redis.With[*record](i.cache).Key(rkey).Delete(ctx); err != nil {
+				i.log.Error("failed to release key %s: %v", rkey, err)
+			}
+		}
+	}()
+
+	w := &captureWriter{ResponseWriter: c.Writer}
+	c.Writer = w
+	c.Next()
+	c.Writer = w.ResponseWriter
+
+	status := w.Status()
+	if status >= http.StatusInternalServerError {
+		return
+	}
+
+	header := w.Header().Clone()
+	header.Del("Content-Length")
+	err := redis.With[*record](i.cache).
+		Key(rkey).
+		Value(&record{
+			State:       stateCompleted,
+			Fingerprint: fingerprint,
+			Status:      status,
+			Header:      header,
+			Body:        w.body.Bytes(),
+		}).
+		Expire(i.ttl).
+		Set(ctx)
+	if err != nil {
+		i.log.Error("failed to store response of key %s: %v", rkey, err)
+		return
+	}
+	stored = true
+}
+
+// replay writes the stored response of rkey, or rejects the request while it is processed.
+func (i *Idempotency) replay(c *gin.Context, rkey, fingerprint string) {
+	rec, err := redis.With[*record](i.cache).Key(rkey).Get(c.Request.Context())
+	if err != nil {
+		i.reject(c, err)
+		return
+	}
+	// a missing record expired between the lock attempt and now: ask for a retry
+	if rec == nil || rec.State != stateCompleted {
+		i.reject(c, ErrInProgress)
+		return
+	}
+	if rec.Fingerprint != fingerprint {
+		i.reject(c, ErrKeyMismatch)
+		return
+	}
+
+	h := c.Writer.Header()
+	for k, v := range rec.Header {
+		h[k] = v
+	}
+	h.Set(ReplayedHeader, "true")
+	c.Status(rec.Status)
+	_, _ = c.Writer.Write(rec.Body)
+	c.Abort()
+}
+
+// key returns the Redis key of an idempotency key, bound to the scope and route.
+func (i *Idempotency) key(c *gin.Context, key string) string {
+	route := c.FullPath()
+	if route == "" {
+		route = c.Request.URL.Path
+	}
+
+	k := i.prefix
+	if i.scope != nil {
+		k += i.scope(c) + ":"
+	}
+	return k + c.Request.Method + ":" + route + ":" + key
+}
+
+// fingerprint hashes the request body, which is restored for the handler.
+func (i *Idempotency) fingerprint(c *gin.Context) (string, error) {
+	h := sha256.New()
+	h.Write([]byte(c.Request.URL.RawQuery))
+
+	if c.Request.Body != nil {
+		raw, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			return "", err
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(raw))
+		h.Write(raw)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func (i *Idempotency) reject(c *gin.Context, err error) {
+	c.Abort()
+	if i.onReject != nil {
+		i.onReject(c, err)
+		return
+	}
+	switch {
+	case errors.Is(err, ErrMissingKey):
+		response.BadRequest(c, "", "missing "+i.header+" header")
+	case errors.Is(err, ErrKeyMismatch):
+		response.BadRequest(c, "", "idempotency key reused with a different request")
+	case errors.Is(err, ErrInProgress):
+		response.Conflict(c, "", "request with the same idempotency key is in progress")
+	default:
+		response.ServiceUnavailable(c, "", "")
+	}
+}
+
+// captureWriter copies the response body while writing it.
+type captureWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *captureWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *captureWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
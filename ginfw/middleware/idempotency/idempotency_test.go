@@ -0,0 +1,168 @@
+package idempotency
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/BevisDev/godev/redis/redistest"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+const key = "idempotency:POST:/payments:k-1"
+
+func router(mw *Idempotency, calls *atomic.Int32, handler gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.ReleaseMode)
+
+	r := gin.New()
+	r.POST("/payments", mw.Handler(), func(c *gin.Context) {
+		calls.Add(1)
+		handler(c)
+	})
+	r.GET("/payments", mw.Handler(), func(c *gin.Context) {
+		calls.Add(1)
+		c.Status(http.StatusOK)
+	})
+	return r
+}
+
+func do(r *gin.Engine, method, idemKey, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/payments", strings.NewReader(body))
+	if idemKey != "" {
+		req.Header.Set(DefaultHeader, idemKey)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestIdempotency_Replay(t *testing.T) {
+	cache := redistest.New(t)
+	var calls atomic.Int32
+	r := router(New(cache.Cache, WithTTL(time.Hour)), &calls, func(c *gin.Context) {
+		c.Header("Location", "/payments/1")
+		c.JSON(http.StatusCreated, gin.H{"id": 1})
+	})
+
+	first := do(r, "POST", "k-1", `{"amount":10}`)
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.Empty(t, first.Header().Get(ReplayedHeader))
+	cache.AssertExists(key)
+	cache.AssertTTL(key, time.Hour)
+
+	retry := do(r, "POST", "k-1", `{"amount":10}`)
+	assert.Equal(t, http.StatusCreated, retry.Code)
+	assert.Equal(t, "true", retry.Header().Get(ReplayedHeader))
+	assert.Equal(t, "/payments/1", retry.Header().Get("Location"))
+	assert.JSONEq(t, first.Body.String(), retry.Body.String())
+	assert.Equal(t, int32(1), calls.Load())
+
+	mismatch := do(r, "POST", "k-1", `{"amount":20}`)
+	assert.Equal(t, http.StatusBadRequest, mismatch.Code)
+
+	other := do(r, "POST", "k-2", `{"amount":10}`)
+	assert.Equal(t, http.StatusCreated, other.Code)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestIdempotency_InProgress(t *testing.T) {
+	cache := redistest.New(t)
+	var calls atomic.Int32
+
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	r := router(New(cache.Cache), &calls, func(c *gin.Context) {
+		close(entered)
+		<-release
+		c.Status(http.StatusCreated)
+	})
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() { done <- do(r, "POST", "k-1", "") }()
+	<-entered
+
+	assert.Equal(t, http.StatusConflict, do(r, "POST", "k-1", "").Code)
+
+	close(release)
+	assert.Equal(t, http.StatusCreated, (<-done).Code)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestIdempotency_ServerErrorReleasesKey(t *testing.T) {
+	cache := redistest.New(t)
+	var calls atomic.Int32
+	r := router(New(cache.Cache), &calls, func(c *gin.Context) {
+		if calls.Load() == 1 {
+			c.Status(http.StatusBadGateway)
+			return
+		}
+		c.Status(http.StatusCreated)
+	})
+
+	assert.Equal(t, http.StatusBadGateway, do(r, "POST", "k-1", "").Code)
+	cache.AssertMissing(key)
+
+	assert.Equal(t, http.StatusCreated, do(r, "POST", "k-1", "").Code)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestIdempotency_PanicReleasesKey(t *testing.T) {
+	cache := redistest.New(t)
+	var calls atomic.Int32
+
+	gin.SetMode(gin.ReleaseMode)
+	r := gin.New()
+	r.Use(gin.CustomRecovery(func(c *gin.Context, err any) {
+		c.AbortWithStatus(http.StatusInternalServerError)
+	}))
+	r.POST("/payments", New(cache.Cache).Handler(), func(c *gin.Context) {
+		calls.Add(1)
+		panic("boom")
+	})
+
+	assert.Equal(t, http.StatusInternalServerError, do(r, "POST", "k-1", "").Code)
+	cache.AssertMissing(key)
+}
+
+func TestIdempotency_MissingKey(t *testing.T) {
+	cache := redistest.New(t)
+	var calls atomic.Int32
+	created := func(c *gin.Context) { c.Status(http.StatusCreated) }
+
+	assert.Equal(t, http.StatusBadRequest, do(router(New(cache.Cache), &calls, created), "POST", "", "").Code)
+	assert.Equal(t, int32(0), calls.Load())
+
+	assert.Equal(t, http.StatusCreated, do(router(New(cache.Cache, WithOptional()), &calls, created), "POST", "", "").Code)
+
+	// other methods are not enforced
+	assert.Equal(t, http.StatusOK, do(router(New(cache.Cache), &calls, created), "GET", "", "").Code)
+}
+
+func TestIdempotency_Scope(t *testing.T) {
+	cache := redistest.New(t)
+	var calls atomic.Int32
+	mw := New(cache.Cache, WithScope(func(c *gin.Context) string { return c.GetHeader("X-User") }))
+	r := router(mw, &calls, func(c *gin.Context) { c.Status(http.StatusCreated) })
+
+	req := httptest.NewRequest("POST", "/payments", nil)
+	req.Header.Set(DefaultHeader, "k-1")
+	req.Header.Set("X-User", "u-1")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	cache.AssertExists("idempotency:u-1:POST:/payments:k-1")
+}
+
+func TestIdempotency_RedisDown(t *testing.T) {
+	cache := redistest.New(t)
+	cache.Server.Close()
+
+	var calls atomic.Int32
+	r := router(New(cache.Cache), &calls, func(c *gin.Context) { c.Status(http.StatusCreated) })
+
+	assert.Equal(t, http.StatusServiceUnavailable, do(r, "POST", "k-1", "").Code)
+	assert.Equal(t, int32(0), calls.Load())
+}
@@ -0,0 +1,104 @@
+package idempotency
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultHeader is the request header carrying the idempotency key.
+const DefaultHeader = "Idempotency-Key"
+
+type Option func(*options)
+
+type options struct {
+	header   string
+	ttl      time.Duration
+	lockTTL  time.Duration
+	prefix   string
+	methods  map[string]struct{}
+	optional bool
+	scope    func(c *gin.Context) string
+	onReject func(c *gin.Context, err error)
+}
+
+func defaultOptions() *options {
+	return &options{
+		header:  DefaultHeader,
+		ttl:     24 * time.Hour,
+		lockTTL: 30 * time.Second,
+		prefix:  "idempotency:",
+		methods: map[string]struct{}{http.MethodPost: {}},
+	}
+}
+
+// WithHeader sets the header carrying the key (default "Idempotency-Key").
+func WithHeader(name string) Option {
+	return func(o *options) {
+		if name != "" {
+			o.header = name
+		}
+	}
+}
+
+// WithTTL sets how long a completed response is replayed (default 24h).
+func WithTTL(d time.Duration) Option {
+	return func(o *options) {
+		if d > 0 {
+			o.ttl = d
+		}
+	}
+}
+
+// WithLockTTL sets how long a key stays locked while its request is processed (default 30s).
+// It bounds the lock when the instance dies mid-request; keep it above the request timeout.
+func WithLockTTL(d time.Duration) Option {
+	return func(o *options) {
+		if d > 0 {
+			o.lockTTL = d
+		}
+	}
+}
+
+// WithPrefix sets the Redis key prefix (default "idempotency:").
+func WithPrefix(prefix string) Option {
+	return func(o *options) {
+		o.prefix = prefix
+	}
+}
+
+// WithMethods sets the enforced methods (default POST); other methods pass through.
+func WithMethods(methods ...string) Option {
+	return func(o *options) {
+		if len(methods) == 0 {
+			return
+		}
+		o.methods = make(map[string]struct{}, len(methods))
+		for _, m := range methods {
+			o.methods[m] = struct{}{}
+		}
+	}
+}
+
+// WithOptional lets requests without a key through instead of rejecting them with 400.
+func WithOptional() Option {
+	return func(o *options) {
+		o.optional = true
+	}
+}
+
+// WithScope namespaces keys, e.g. by the authenticated user, so clients cannot
+// replay each other's responses.
+func WithScope(fn func(c *gin.Context) string) Option {
+	return func(o *options) {
+		o.scope = fn
+	}
+}
+
+// WithOnReject overrides the response of rejected requests.
+func WithOnReject(fn func(c *gin.Context, err error)) Option {
+	return func(o *options) {
+		o.onReject = fn
+	}
+}
@@ -0,0 +1,48 @@
+package locale
+
+import (
+	"github.com/BevisDev/godev/consts"
+	"github.com/BevisDev/godev/i18n"
+	"github.com/gin-gonic/gin"
+)
+
+// Locale resolves the request language and stores it in the request context
+// so i18n.LangFromContext and the response package can use it.
+type Locale struct {
+	*options
+	bundle *i18n.Bundle
+}
+
+func New(bundle *i18n.Bundle, opts ...Option) *Locale {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return &Locale{
+		options: o,
+		bundle:  bundle,
+	}
+}
+
+func (l *Locale) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		lang := l.resolve(c)
+
+		c.Request = c.Request.WithContext(i18n.WithLang(c.Request.Context(), lang))
+		c.Set(consts.Lang, lang)
+		if l.setHeader {
+			c.Header(consts.ContentLanguage, lang)
+		}
+		c.Next()
+	}
+}
+
+func (l *Locale) resolve(c *gin.Context) string {
+	if l.queryParam != "" {
+		if q := c.Query(l.queryParam); q != "" {
+			return l.bundle.Match(q)
+		}
+	}
+	return l.bundle.Match(c.GetHeader(consts.AcceptLanguage))
+}
@@ -0,0 +1,28 @@
+package locale
+
+type Option func(*options)
+
+type options struct {
+	queryParam string
+	setHeader  bool
+}
+
+// WithQueryParam lets clients override Accept-Language with a query parameter (e.g. ?lang=vi).
+func WithQueryParam(name string) Option {
+	return func(o *options) {
+		o.queryParam = name
+	}
+}
+
+// WithContentLanguage controls whether the Content-Language response header is set (default: true).
+func WithContentLanguage(enabled bool) Option {
+	return func(o *options) {
+		o.setHeader = enabled
+	}
+}
+
+func defaultOptions() *options {
+	return &options{
+		setHeader: true,
+	}
+}
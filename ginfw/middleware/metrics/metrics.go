@@ -0,0 +1,68 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/BevisDev/godev/metrics"
+	"github.com/gin-gonic/gin"
+)
+
+// unmatchedRoute is the route label of requests matching no route,
+// so that scanned URLs do not create a series each.
+const unmatchedRoute = "unmatched"
+
+// Metrics records the duration of every request on a metrics.Provider,
+// as http_server_request_duration_seconds labeled by method, route
+// pattern and status code.
+type Metrics struct {
+	*options
+	requests metrics.Timer
+	inFlight metrics.Gauge
+}
+
+func New(p metrics.Provider, opts ...Option) *Metrics {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return &Metrics{
+		options: o,
+		requests: p.Timer(metrics.Opts{
+			Name:   "http_server_request_duration_seconds",
+			Help:   "Duration of HTTP requests.",
+			Labels: []string{"method", "route", "status"},
+		}),
+		inFlight: p.Gauge(metrics.Opts{
+			Name: "http_server_requests_in_flight",
+			Help: "HTTP requests being served.",
+		}),
+	}
+}
+
+func (m *Metrics) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.FullPath()
+		if _, ok := m.excluded[route]; ok {
+			c.Next()
+			return
+		}
+		if _, ok := m.excluded[c.Request.URL.Path]; ok {
+			c.Next()
+			return
+		}
+		if route == "" {
+			route = unmatchedRoute
+		}
+
+		start := time.Now()
+		m.inFlight.Add(1)
+		defer func() {
+			m.inFlight.Add(-1)
+			m.requests.Record(time.Since(start), c.Request.Method, route, strconv.Itoa(c.Writer.Status()))
+		}()
+
+		c.Next()
+	}
+}
@@ -0,0 +1,35 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/BevisDev/godev/metrics/prometheus"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	reg := prometheus.New()
+
+	r := gin.New()
+	r.Use(New(reg, WithExcludedPaths("/metrics")).Handler())
+	r.GET("/orders/:id", func(c *gin.Context) { c.Status(http.StatusOK) })
+	r.GET("/metrics", gin.WrapH(reg.Handler()))
+
+	for _, path := range []string{"/orders/1", "/orders/2", "/missing", "/metrics"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	var out strings.Builder
+	_, err := reg.WriteTo(&out)
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), `http_server_request_duration_seconds_count{method="GET",route="/orders/:id",status="200"} 2`)
+	assert.Contains(t, out.String(), `http_server_request_duration_seconds_count{method="GET",route="unmatched",status="404"} 1`)
+	assert.NotContains(t, out.String(), `route="/metrics"`)
+	assert.Contains(t, out.String(), "http_server_requests_in_flight 0")
+}
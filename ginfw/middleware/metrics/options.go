@@ -0,0 +1,22 @@
+package metrics
+
+type Option func(*options)
+
+type options struct {
+	excluded map[string]struct{}
+}
+
+func defaultOptions() *options {
+	return &options{
+		excluded: make(map[string]struct{}),
+	}
+}
+
+// WithExcludedPaths skips these paths (route pattern or request path), e.g. "/metrics" and "/healthz".
+func WithExcludedPaths(paths ...string) Option {
+	return func(o *options) {
+		for _, p := range paths {
+			o.excluded[p] = struct{}{}
+		}
+	}
+}
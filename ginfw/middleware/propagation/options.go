@@ -0,0 +1,39 @@
+package propagation
+
+type Option func(*options)
+
+type options struct {
+	headers  []string
+	generate bool
+	echo     bool
+}
+
+func defaultOptions() *options {
+	return &options{
+		generate: true,
+		echo:     true,
+	}
+}
+
+// WithHeaders propagates custom correlation headers (e.g. "X-Correlation-ID")
+// in addition to X-Request-ID, traceparent and tracestate.
+func WithHeaders(names ...string) Option {
+	return func(o *options) {
+		o.headers = append(o.headers, names...)
+	}
+}
+
+// WithoutGenerate leaves the request ID empty when the X-Request-ID header is missing
+// instead of generating one.
+func WithoutGenerate() Option {
+	return func(o *options) {
+		o.generate = false
+	}
+}
+
+// WithoutEcho does not return the request ID in the X-Request-ID response header.
+func WithoutEcho() Option {
+	return func(o *options) {
+		o.echo = false
+	}
+}
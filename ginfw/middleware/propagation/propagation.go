@@ -0,0 +1,63 @@
+package propagation
+
+import (
+	"net/http"
+
+	"github.com/BevisDev/godev/consts"
+	"github.com/BevisDev/godev/utils/ctxmeta"
+	"github.com/BevisDev/godev/utils/propagation"
+	"github.com/BevisDev/godev/utils/random"
+	"github.com/gin-gonic/gin"
+)
+
+// Propagation captures the request ID, trace context and correlation headers
+// of inbound requests into the request context, from where the rest client
+// forwards them on outgoing calls.
+type Propagation struct {
+	*options
+}
+
+func New(opts ...Option) *Propagation {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &Propagation{options: o}
+}
+
+func (p *Propagation) Handler() gin.HandlerFunc {
+	names := append(append([]string(nil), propagation.DefaultHeaders...), p.headers...)
+
+	return func(c *gin.Context) {
+		h := make(http.Header, len(names))
+		for _, name := range names {
+			if v := c.GetHeader(name); v != "" {
+				h.Set(name, v)
+			}
+		}
+
+		rid := h.Get(consts.XRequestID)
+		if rid == "" {
+			rid = ctxmeta.RID(c.Request.Context())
+		}
+		if rid == "" && p.generate {
+			rid = random.NewUUID()
+		}
+
+		ctx := c.Request.Context()
+		if rid != "" {
+			h.Set(consts.XRequestID, rid)
+			ctx = ctxmeta.WithRID(ctx, rid)
+			c.Set(consts.RID, rid)
+			if p.echo {
+				c.Header(consts.XRequestID, rid)
+			}
+		}
+		if traceID := propagation.TraceID(h.Get(propagation.TraceParent)); traceID != "" {
+			ctx = ctxmeta.WithTraceID(ctx, traceID)
+		}
+
+		c.Request = c.Request.WithContext(propagation.WithHeaders(ctx, h))
+		c.Next()
+	}
+}
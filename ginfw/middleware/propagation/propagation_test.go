@@ -0,0 +1,73 @@
+package propagation
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/BevisDev/godev/utils/ctxmeta"
+	"github.com/BevisDev/godev/utils/propagation"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func serve(mw *Propagation, req *http.Request) (*httptest.ResponseRecorder, context.Context) {
+	gin.SetMode(gin.ReleaseMode)
+
+	var ctx context.Context
+	r := gin.New()
+	r.Use(mw.Handler())
+	r.GET("/", func(c *gin.Context) {
+		ctx = c.Request.Context()
+		c.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w, ctx
+}
+
+func TestPropagation_CapturesHeaders(t *testing.T) {
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("X-Request-ID", "rid-1")
+	req.Header.Set("traceparent", traceparent)
+	req.Header.Set("X-Correlation-ID", "c-1")
+	req.Header.Set("X-Other", "o-1")
+
+	w, ctx := serve(New(WithHeaders("X-Correlation-ID")), req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "rid-1", w.Header().Get("X-Request-ID"))
+
+	assert.Equal(t, "rid-1", ctxmeta.RID(ctx))
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", ctxmeta.TraceID(ctx))
+
+	h := propagation.Headers(ctx)
+	assert.Equal(t, "rid-1", h.Get("X-Request-ID"))
+	assert.Equal(t, traceparent, h.Get("traceparent"))
+	assert.Equal(t, "c-1", h.Get("X-Correlation-ID"))
+	assert.Empty(t, h.Get("X-Other"))
+}
+
+func TestPropagation_GeneratesRID(t *testing.T) {
+	w, ctx := serve(New(), httptest.NewRequest("GET", "/", nil))
+
+	rid := ctxmeta.RID(ctx)
+	assert.NotEmpty(t, rid)
+	assert.Equal(t, rid, w.Header().Get("X-Request-ID"))
+	assert.Equal(t, rid, propagation.Headers(ctx).Get("X-Request-ID"))
+	assert.Empty(t, ctxmeta.TraceID(ctx))
+}
+
+func TestPropagation_WithoutGenerateAndEcho(t *testing.T) {
+	w, ctx := serve(New(WithoutGenerate(), WithoutEcho()), httptest.NewRequest("GET", "/", nil))
+	assert.Empty(t, ctxmeta.RID(ctx))
+	assert.Empty(t, w.Header().Get("X-Request-ID"))
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("X-Request-ID", "rid-1")
+	w, _ = serve(New(WithoutEcho()), req)
+	assert.Empty(t, w.Header().Get("X-Request-ID"))
+}
@@ -0,0 +1,88 @@
+package sanitize
+
+import "github.com/gin-gonic/gin"
+
+const (
+	defaultMaxDepth = 32
+	defaultMaxKeys  = 1000
+)
+
+type Option func(*options)
+
+type options struct {
+	trim         bool
+	stripControl bool
+	escapeHTML   bool
+	maxDepth     int
+	maxKeys      int
+	skipFields   map[string]struct{}
+	onReject     func(c *gin.Context, err error)
+}
+
+func defaultOptions() *options {
+	return &options{
+		trim:         true,
+		stripControl: true,
+		maxDepth:     defaultMaxDepth,
+		maxKeys:      defaultMaxKeys,
+		skipFields:   make(map[string]struct{}),
+	}
+}
+
+// WithoutTrim keeps leading and trailing whitespace of strings.
+func WithoutTrim() Option {
+	return func(o *options) {
+		o.trim = false
+	}
+}
+
+// WithoutStripControl keeps control characters; by default all but tab, newline
+// and carriage return are removed.
+func WithoutStripControl() Option {
+	return func(o *options) {
+		o.stripControl = false
+	}
+}
+
+// WithEscapeHTML escapes <, >, &, ' and " in strings.
+// Use it for values rendered as HTML without escaping further down the line.
+func WithEscapeHTML() Option {
+	return func(o *options) {
+		o.escapeHTML = true
+	}
+}
+
+// WithMaxDepth rejects JSON bodies nested deeper than n objects/arrays (default 32).
+func WithMaxDepth(n int) Option {
+	return func(o *options) {
+		if n > 0 {
+			o.maxDepth = n
+		}
+	}
+}
+
+// WithMaxKeys rejects JSON bodies with more than n object keys in total (default 1000).
+func WithMaxKeys(n int) Option {
+	return func(o *options) {
+		if n > 0 {
+			o.maxKeys = n
+		}
+	}
+}
+
+// WithSkipFields leaves the values of these JSON keys, query and form fields
+// untouched, e.g. "password".
+func WithSkipFields(fields ...string) Option {
+	return func(o *options) {
+		for _, f := range fields {
+			o.skipFields[f] = struct{}{}
+		}
+	}
+}
+
+// WithOnReject overrides the response of rejected requests.
+func WithOnReject(fn func(c *gin.Context, err error)) Option {
+	return func(o *options) {
+		o.onReject = fn
+	}
+}
@@ -0,0 +1,215 @@
+package sanitize
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"html"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/BevisDev/godev/consts"
+	"github.com/BevisDev/godev/ginfw/response"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	// ErrTooDeep is passed to the reject handler when a JSON body exceeds the max depth.
+	ErrTooDeep = errors.New("[sanitize] json body nested too deep")
+
+	// ErrTooManyKeys is passed to the reject handler when a JSON body exceeds the max keys.
+	ErrTooManyKeys = errors.New("[sanitize] json body has too many keys")
+)
+
+// Sanitize cleans path params, query params and JSON or form bodies before
+// handlers bind them: strings are trimmed, control characters stripped and,
+// optionally, HTML escaped. Oversized JSON structures are rejected with 400.
+type Sanitize struct {
+	*options
+}
+
+func New(opts ...Option) *Sanitize {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &Sanitize{options: o}
+}
+
+func (s *Sanitize) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for i := range c.Params {
+			c.Params[i].Value = s.String(c.Params[i].Value)
+		}
+
+		if c.Request.URL.RawQuery != "" {
+			c.Request.URL.RawQuery = s.values(c.Request.URL.Query()).Encode()
+		}
+
+		if err := s.body(c); err != nil {
+			s.reject(c, err)
+			return
+		}
+		c.Next()
+	}
+}
+
+// String returns v trimmed, without control characters and HTML escaped, as configured.
+func (s *Sanitize) String(v string) string {
+	if s.stripControl {
+		v = strings.Map(func(r rune) rune {
+			if unicode.IsControl(r) && r != '\n' && r != '\r' && r != '\t' {
+				return -1
+			}
+			return r
+		}, v)
+	}
+	if s.trim {
+		v = strings.TrimSpace(v)
+	}
+	if s.escapeHTML {
+		v = html.EscapeString(v)
+	}
+	return v
+}
+
+func (s *Sanitize) values(vals url.Values) url.Values {
+	for k, vs := range vals {
+		if _, skip := s.skipFields[k]; skip {
+			continue
+		}
+		for i := range vs {
+			vs[i] = s.String(vs[i])
+		}
+	}
+	return vals
+}
+
+// body rewrites JSON and urlencoded form bodies; other bodies pass through.
+// Malformed bodies are left for binding to report.
+func (s *Sanitize) body(c *gin.Context) error {
+	if c.Request.Body == nil || c.Request.Body == http.NoBody {
+		return nil
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(c.GetHeader(consts.ContentType))
+	isJSON := mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+	isForm := mediaType == "application/x-www-form-urlencoded"
+	if !isJSON && !isForm {
+		return nil
+	}
+
+	raw, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return err
+	}
+
+	out := raw
+	if isJSON {
+		out, err = s.json(raw)
+	} else {
+		out, err = s.form(raw)
+	}
+	if err != nil {
+		return err
+	}
+
+	c.Request.Body = io.NopCloser(bytes.NewReader(out))
+	c.Request.ContentLength = int64(len(out))
+	c.Request.Header.Set("Content-Length", strconv.Itoa(len(out)))
+	return nil
+}
+
+func (s *Sanitize) form(raw []byte) ([]byte, error) {
+	vals, err := url.ParseQuery(string(raw))
+	if err != nil {
+		return raw, nil
+	}
+	return []byte(s.values(vals).Encode()), nil
+}
+
+func (s *Sanitize) json(raw []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return raw, nil
+	}
+
+	keys := 0
+	v, err := s.walk(v, 0, &keys)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+func (s *Sanitize) walk(v any, depth int, keys *int) (any, error) {
+	switch t := v.(type) {
+	case string:
+		return s.String(t), nil
+	case []any:
+		if depth++; depth > s.maxDepth {
+			return nil, ErrTooDeep
+		}
+		for i := range t {
+			item, err := s.walk(t[i], depth, keys)
+			if err != nil {
+				return nil, err
+			}
+			t[i] = item
+		}
+		return t, nil
+	case map[string]any:
+		if depth++; depth > s.maxDepth {
+			return nil, ErrTooDeep
+		}
+		if *keys += len(t); *keys > s.maxKeys {
+			return nil, ErrTooManyKeys
+		}
+		for k, item := range t {
+			if _, skip := s.skipFields[k]; skip {
+				if _, ok := item.(string); ok {
+					continue
+				}
+			}
+			item, err := s.walk(item, depth, keys)
+			if err != nil {
+				return nil, err
+			}
+			t[k] = item
+		}
+		return t, nil
+	default:
+		return v, nil
+	}
+}
+
+func (s *Sanitize) reject(c *gin.Context, err error) {
+	c.Abort()
+	if s.onReject != nil {
+		s.onReject(c, err)
+		return
+	}
+	switch {
+	case errors.Is(err, ErrTooDeep):
+		response.BadRequest(c, "", "request body is nested too deep")
+	case errors.Is(err, ErrTooManyKeys):
+		response.BadRequest(c, "", "request body has too many keys")
+	default:
+		response.BadRequest(c, "", "")
+	}
+}
@@ -0,0 +1,118 @@
+package sanitize
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type order struct {
+	Name     string   `json:"name" form:"name"`
+	Note     string   `json:"note" form:"note"`
+	Password string   `json:"password" form:"password"`
+	Tags     []string `json:"tags" form:"tags"`
+	Amount   float64  `json:"amount"`
+}
+
+func serve(mw *Sanitize, req *http.Request) (*httptest.ResponseRecorder, order, string) {
+	gin.SetMode(gin.ReleaseMode)
+
+	var got order
+	var q, id string
+	r := gin.New()
+	api := r.Group("/api", mw.Handler())
+	api.POST("/orders/:id", func(c *gin.Context) {
+		id = c.Param("id")
+		q = c.Query("q")
+		_ = c.ShouldBind(&got)
+		c.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w, got, id + "|" + q
+}
+
+func jsonRequest(body string) *http.Request {
+	req := httptest.NewRequest("POST", "/api/orders/%20o-1%20?q=%20hi%07", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
+
+func TestSanitize_JSON(t *testing.T) {
+	body := `{"name":"  Alice\u0000 ","note":"line1\nline2 ","password":" p@ss ","tags":[" a ","b\u0007"],"amount":10.5}`
+
+	w, got, params := serve(New(WithSkipFields("password")), jsonRequest(body))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "Alice", got.Name)
+	assert.Equal(t, "line1\nline2", got.Note)
+	assert.Equal(t, " p@ss ", got.Password)
+	assert.Equal(t, []string{"a", "b"}, got.Tags)
+	assert.Equal(t, 10.5, got.Amount)
+	assert.Equal(t, "o-1|hi", params)
+}
+
+func TestSanitize_EscapeHTML(t *testing.T) {
+	_, got, _ := serve(New(WithEscapeHTML()), jsonRequest(`{"name":"<b>Bob</b> & co"}`))
+	assert.Equal(t, "&lt;b&gt;Bob&lt;/b&gt; &amp; co", got.Name)
+}
+
+func TestSanitize_Form(t *testing.T) {
+	req := httptest.NewRequest("POST", "/api/orders/1", strings.NewReader("name=+Carol+&tags=+x+&tags=y"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	w, got, _ := serve(New(), req)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "Carol", got.Name)
+	assert.Equal(t, []string{"x", "y"}, got.Tags)
+}
+
+func TestSanitize_Limits(t *testing.T) {
+	deep := strings.Repeat(`{"a":`, 5) + `1` + strings.Repeat(`}`, 5)
+	w, _, _ := serve(New(WithMaxDepth(4)), jsonRequest(deep))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "nested too deep")
+
+	w, _, _ = serve(New(WithMaxDepth(5)), jsonRequest(deep))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w, _, _ = serve(New(WithMaxKeys(2)), jsonRequest(`{"a":1,"b":{"c":2}}`))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "too many keys")
+
+	// skipped fields are still counted
+	w, _, _ = serve(New(WithMaxDepth(1), WithSkipFields("a")), jsonRequest(`{"a":{"b":1}}`))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestSanitize_PassThrough(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+
+	var body string
+	r := gin.New()
+	r.POST("/", New().Handler(), func(c *gin.Context) {
+		raw, _ := io.ReadAll(c.Request.Body)
+		body = string(raw)
+	})
+
+	for _, tc := range []struct{ contentType, body string }{
+		{"application/json", `{"name": " broken`},
+		{"text/plain", "  raw  "},
+	} {
+		req := httptest.NewRequest("POST", "/", strings.NewReader(tc.body))
+		req.Header.Set("Content-Type", tc.contentType)
+		r.ServeHTTP(httptest.NewRecorder(), req)
+		assert.Equal(t, tc.body, body)
+	}
+}
+
+func TestSanitize_String(t *testing.T) {
+	s := New(WithoutTrim(), WithoutStripControl())
+	assert.Equal(t, " a\x00 ", s.String(" a\x00 "))
+}
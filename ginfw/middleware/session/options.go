@@ -0,0 +1,86 @@
+package session
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultCookie is the name of the session cookie.
+const DefaultCookie = "sid"
+
+type Option func(*options)
+
+type options struct {
+	cookie   string
+	path     string
+	domain   string
+	insecure bool
+	sameSite http.SameSite
+	optional bool
+	onReject func(c *gin.Context, err error)
+}
+
+func defaultOptions() *options {
+	return &options{
+		cookie:   DefaultCookie,
+		path:     "/",
+		sameSite: http.SameSiteLaxMode,
+	}
+}
+
+// WithCookie sets the name of the session cookie (default "sid").
+func WithCookie(name string) Option {
+	return func(o *options) {
+		if name != "" {
+			o.cookie = name
+		}
+	}
+}
+
+// WithPath sets the path of the cookie (default "/").
+func WithPath(path string) Option {
+	return func(o *options) {
+		if path != "" {
+			o.path = path
+		}
+	}
+}
+
+// WithDomain sets the domain of the cookie, e.g. ".example.com" to share it
+// with subdomains (default the request host).
+func WithDomain(domain string) Option {
+	return func(o *options) {
+		o.domain = domain
+	}
+}
+
+// WithInsecure drops the Secure flag of the cookie, for local development over HTTP.
+func WithInsecure() Option {
+	return func(o *options) {
+		o.insecure = true
+	}
+}
+
+// WithSameSite sets the SameSite attribute of the cookie (default Lax).
+func WithSameSite(mode http.SameSite) Option {
+	return func(o *options) {
+		o.sameSite = mode
+	}
+}
+
+// WithOptional lets requests without a valid session through instead of
+// rejecting them with 401, e.g. on the login page.
+func WithOptional() Option {
+	return func(o *options) {
+		o.optional = true
+	}
+}
+
+// WithOnReject overrides the response of rejected requests, e.g. to redirect
+// to the login page.
+func WithOnReject(fn func(c *gin.Context, err error)) Option {
+	return func(o *options) {
+		o.onReject = fn
+	}
+}
@@ -0,0 +1,157 @@
+package session
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/BevisDev/godev/consts"
+	"github.com/BevisDev/godev/ginfw/response"
+	"github.com/BevisDev/godev/session"
+	"github.com/BevisDev/godev/utils/console"
+	"github.com/gin-gonic/gin"
+)
+
+// ErrMissingSession is passed to the reject handler when the request has no valid session.
+var ErrMissingSession = errors.New("[session] missing session")
+
+// Session loads the session of the request from a cookie, stores it in the
+// request context for session.FromContext, and saves its data after the
+// handler when it changed.
+type Session[T any] struct {
+	*options
+	manager *session.Manager[T]
+	log     *console.Logger
+}
+
+func New[T any](manager *session.Manager[T], opts ...Option) *Session[T] {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &Session[T]{
+		options: o,
+		manager: manager,
+		log:     console.New("session"),
+	}
+}
+
+func (s *Session[T]) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		var sess *session.Session[T]
+		if id, err := c.Cookie(s.cookie); err == nil && id != "" {
+			sess, err = s.manager.Get(ctx, id)
+			switch {
+			case errors.Is(err, session.ErrNotFound):
+				s.clearCookie(c)
+			case err != nil:
+				s.reject(c, err)
+				return
+			}
+		}
+
+		if sess == nil {
+			if s.optional {
+				c.Next()
+				return
+			}
+			s.reject(c, ErrMissingSession)
+			return
+		}
+
+		before, _ := json.Marshal(sess.Data)
+		s.bind(c, sess)
+		c.Next()
+
+		// Login and Logout replace the session of the request
+		cur := session.FromContext[T](c.Request.Context())
+		if cur != sess {
+			return
+		}
+		after, err := json.Marshal(sess.Data)
+		if err != nil || bytes.Equal(before, after) {
+			return
+		}
+		// ErrNotFound: the session ended during the request, e.g. by Logout
+		err = s.manager.Save(context.WithoutCancel(ctx), sess)
+		if err != nil && !errors.Is(err, session.ErrNotFound) {
+			s.log.Error("failed to save session of user %s: %v", sess.UserID, err)
+		}
+	}
+}
+
+// Login starts a session of userID, ending the session of the request so
+// its ID cannot be fixed by an attacker, and sets the cookie.
+func (s *Session[T]) Login(c *gin.Context, userID string, data T) (*session.Session[T], error) {
+	ctx := c.Request.Context()
+	if old := session.FromContext[T](ctx); old != nil {
+		if err := s.manager.Destroy(ctx, old); err != nil {
+			return nil, err
+		}
+	}
+
+	sess, err := s.manager.Create(ctx, userID, data)
+	if err != nil {
+		return nil, err
+	}
+	s.setCookie(c, sess.ID)
+	s.bind(c, sess)
+	return sess, nil
+}
+
+// Logout ends the session of the request and clears the cookie.
+func (s *Session[T]) Logout(c *gin.Context) error {
+	s.clearCookie(c)
+	sess := session.FromContext[T](c.Request.Context())
+	if sess == nil {
+		return nil
+	}
+	return s.manager.Destroy(c.Request.Context(), sess)
+}
+
+// bind stores sess in the request context and the gin context.
+func (s *Session[T]) bind(c *gin.Context, sess *session.Session[T]) {
+	c.Request = c.Request.WithContext(session.WithSession(c.Request.Context(), sess))
+	c.Set(consts.Actor, sess.UserID)
+}
+
+func (s *Session[T]) setCookie(c *gin.Context, id string) {
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     s.cookie,
+		Value:    id,
+		Path:     s.path,
+		Domain:   s.domain,
+		Secure:   !s.insecure,
+		HttpOnly: true,
+		SameSite: s.sameSite,
+	})
+}
+
+func (s *Session[T]) clearCookie(c *gin.Context) {
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     s.cookie,
+		Path:     s.path,
+		Domain:   s.domain,
+		MaxAge:   -1,
+		Secure:   !s.insecure,
+		HttpOnly: true,
+		SameSite: s.sameSite,
+	})
+}
+
+func (s *Session[T]) reject(c *gin.Context, err error) {
+	c.Abort()
+	if s.onReject != nil {
+		s.onReject(c, err)
+		return
+	}
+	if errors.Is(err, ErrMissingSession) {
+		response.Unauthorized(c, "", "")
+		return
+	}
+	response.ServiceUnavailable(c, "", "")
+}
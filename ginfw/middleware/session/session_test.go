@@ -0,0 +1,134 @@
+package session
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/BevisDev/godev/redis/redistest"
+	"github.com/BevisDev/godev/session"
+	"github.com/BevisDev/godev/utils/ctxmeta"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type cart struct {
+	Items int `json:"items"`
+}
+
+func setup(t *testing.T) (*gin.Engine, *session.Manager[cart]) {
+	gin.SetMode(gin.ReleaseMode)
+	c := redistest.New(t)
+	m, err := session.New[cart](c.Cache)
+	require.NoError(t, err)
+
+	mw := New(m)
+	public := New(m, WithOptional())
+
+	r := gin.New()
+	r.POST("/login", public.Handler(), func(c *gin.Context) {
+		if _, err := mw.Login(c, "u1", cart{}); err != nil {
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	})
+	r.POST("/logout", mw.Handler(), func(c *gin.Context) {
+		_ = mw.Logout(c)
+		c.Status(http.StatusNoContent)
+	})
+	r.POST("/cart", mw.Handler(), func(c *gin.Context) {
+		s := session.FromContext[cart](c.Request.Context())
+		s.Data.Items++
+		c.JSON(http.StatusOK, gin.H{"items": s.Data.Items, "user": ctxmeta.UserID(c.Request.Context())})
+	})
+	return r, m
+}
+
+func do(r *gin.Engine, path string, cookie *http.Cookie) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, nil)
+	if cookie != nil {
+		req.AddCookie(cookie)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func cookieOf(t *testing.T, w *httptest.ResponseRecorder) *http.Cookie {
+	for _, c := range w.Result().Cookies() {
+		if c.Name == DefaultCookie {
+			return c
+		}
+	}
+	t.Fatal("no session cookie")
+	return nil
+}
+
+func TestSession_Flow(t *testing.T) {
+	r, m := setup(t)
+
+	assert.Equal(t, http.StatusUnauthorized, do(r, "/cart", nil).Code)
+
+	w := do(r, "/login", nil)
+	require.Equal(t, http.StatusNoContent, w.Code)
+	sid := cookieOf(t, w)
+	assert.True(t, sid.HttpOnly)
+	assert.True(t, sid.Secure)
+	assert.Equal(t, http.SameSiteLaxMode, sid.SameSite)
+
+	w = do(r, "/cart", sid)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"items":1,"user":"u1"}`, w.Body.String())
+	w = do(r, "/cart", sid)
+	assert.JSONEq(t, `{"items":2,"user":"u1"}`, w.Body.String(), "changed data is saved")
+
+	// a new login replaces the session of the request
+	w = do(r, "/login", sid)
+	next := cookieOf(t, w)
+	assert.NotEqual(t, sid.Value, next.Value)
+	assert.Equal(t, http.StatusUnauthorized, do(r, "/cart", sid).Code)
+
+	w = do(r, "/logout", next)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, -1, cookieOf(t, w).MaxAge)
+	assert.Equal(t, http.StatusUnauthorized, do(r, "/cart", next).Code)
+
+	list, err := m.List(t.Context(), "u1")
+	require.NoError(t, err)
+	assert.Empty(t, list)
+}
+
+func TestSession_ForcedInvalidation(t *testing.T) {
+	r, m := setup(t)
+	sid := cookieOf(t, do(r, "/login", nil))
+
+	_, err := m.DestroyUser(t.Context(), "u1")
+	require.NoError(t, err)
+
+	w := do(r, "/cart", sid)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, -1, cookieOf(t, w).MaxAge, "the stale cookie is cleared")
+}
+
+func TestSession_OnReject(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+	m, err := session.New[cart](redistest.New(t).Cache)
+	require.NoError(t, err)
+
+	mw := New(m, WithOnReject(func(c *gin.Context, err error) {
+		assert.ErrorIs(t, err, ErrMissingSession)
+		c.Redirect(http.StatusFound, "/login")
+	}), WithCookie("admin_sid"), WithInsecure())
+
+	r := gin.New()
+	r.GET("/admin", mw.Handler(), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+	req.AddCookie(&http.Cookie{Name: "admin_sid", Value: "unknown"})
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Equal(t, "/login", w.Header().Get("Location"))
+}
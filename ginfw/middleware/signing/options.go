@@ -0,0 +1,36 @@
+package signing
+
+import "github.com/gin-gonic/gin"
+
+// DefaultMaxBody is the largest body read to verify its hash.
+const DefaultMaxBody = 10 << 20
+
+type Option func(*options)
+
+type options struct {
+	maxBody  int64
+	onReject func(c *gin.Context, err error)
+}
+
+func defaultOptions() *options {
+	return &options{
+		maxBody: DefaultMaxBody,
+	}
+}
+
+// WithMaxBody sets the largest accepted body in bytes (default 10 MiB);
+// larger requests are rejected with 400.
+func WithMaxBody(n int64) Option {
+	return func(o *options) {
+		if n > 0 {
+			o.maxBody = n
+		}
+	}
+}
+
+// WithOnReject overrides the response of rejected requests.
+func WithOnReject(fn func(c *gin.Context, err error)) Option {
+	return func(o *options) {
+		o.onReject = fn
+	}
+}
@@ -0,0 +1,87 @@
+package signing
+
+import (
+	"bytes"
+	"errors"
+	"io"
+
+	"github.com/BevisDev/godev/ginfw/response"
+	"github.com/BevisDev/godev/signing"
+	"github.com/BevisDev/godev/utils/ctxmeta"
+	"github.com/gin-gonic/gin"
+)
+
+// ContextKey is the gin.Context key of the key ID of a verified request,
+// also stored as ctxmeta value MetaKeyID.
+const ContextKey = "signing_key_id"
+
+// MetaKeyID is the ctxmeta key of the key ID of a verified request.
+const MetaKeyID = "signing_key_id"
+
+// ErrBodyTooLarge is passed to the reject handler when the body exceeds the limit.
+var ErrBodyTooLarge = errors.New("[signing] request body too large")
+
+// Signing verifies the signature of inbound requests signed by signing.Signer,
+// e.g. webhooks and calls from partners.
+type Signing struct {
+	*options
+	verifier *signing.Verifier
+}
+
+func New(verifier *signing.Verifier, opts ...Option) *Signing {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &Signing{options: o, verifier: verifier}
+}
+
+func (s *Signing) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var body []byte
+		if c.Request.Body != nil {
+			raw, err := io.ReadAll(io.LimitReader(c.Request.Body, s.maxBody+1))
+			if err != nil {
+				s.reject(c, err)
+				return
+			}
+			if int64(len(raw)) > s.maxBody {
+				s.reject(c, ErrBodyTooLarge)
+				return
+			}
+			c.Request.Body = io.NopCloser(bytes.NewReader(raw))
+			body = raw
+		}
+
+		keyID, err := s.verifier.Verify(c.Request, body)
+		if err != nil {
+			s.reject(c, err)
+			return
+		}
+
+		c.Request = c.Request.WithContext(ctxmeta.WithValue(c.Request.Context(), MetaKeyID, keyID))
+		c.Set(ContextKey, keyID)
+		c.Next()
+	}
+}
+
+func (s *Signing) reject(c *gin.Context, err error) {
+	c.Abort()
+	if s.onReject != nil {
+		s.onReject(c, err)
+		return
+	}
+	switch {
+	case errors.Is(err, ErrBodyTooLarge), errors.Is(err, signing.ErrMalformed):
+		response.BadRequest(c, "", err.Error())
+	case errors.Is(err, signing.ErrMissingSignature),
+		errors.Is(err, signing.ErrUnknownKey),
+		errors.Is(err, signing.ErrExpired),
+		errors.Is(err, signing.ErrBodyMismatch),
+		errors.Is(err, signing.ErrInvalidSignature),
+		errors.Is(err, signing.ErrUnsignedHeader):
+		response.Unauthorized(c, "", "")
+	default:
+		response.ServiceUnavailable(c, "", "")
+	}
+}
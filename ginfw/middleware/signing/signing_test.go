@@ -0,0 +1,87 @@
+package signing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/BevisDev/godev/rest"
+	"github.com/BevisDev/godev/signing"
+	"github.com/BevisDev/godev/utils/ctxmeta"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type payment struct {
+	Amount int `json:"amount"`
+}
+
+func server(t *testing.T, opts ...Option) *httptest.Server {
+	gin.SetMode(gin.ReleaseMode)
+	verifier, err := signing.NewVerifier(signing.StaticKeys(map[string]any{"partner-1": []byte("secret")}),
+		signing.WithSignedHeaders("host"))
+	require.NoError(t, err)
+
+	r := gin.New()
+	r.POST("/payments", New(verifier, opts...).Handler(), func(c *gin.Context) {
+		var p payment
+		if err := c.ShouldBindJSON(&p); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"amount": p.Amount,
+			"key":    c.GetString(ContextKey),
+			"meta":   ctxmeta.Value(c.Request.Context(), MetaKeyID),
+		})
+	})
+
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestSigning_RestClient(t *testing.T) {
+	srv := server(t)
+
+	signer, err := signing.NewHMACSigner("partner-1", []byte("secret"), signing.WithSignedHeaders("host", "content-type"))
+	require.NoError(t, err)
+	client := rest.New(rest.WithSigner(signer))
+
+	resp, err := rest.NewRequest[map[string]any](client).
+		URL(srv.URL + "/payments?ref=42").
+		Body(payment{Amount: 1000}).
+		POST(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"amount": float64(1000), "key": "partner-1", "meta": "partner-1"}, resp.Data)
+
+	wrong, _ := signing.NewHMACSigner("partner-1", []byte("other"), signing.WithSignedHeaders("host"))
+	_, err = rest.NewRequest[map[string]any](rest.New(rest.WithSigner(wrong))).
+		URL(srv.URL + "/payments").
+		Body(payment{Amount: 1000}).
+		POST(t.Context())
+	httpErr, ok := rest.AsHTTPError(err)
+	require.True(t, ok, err)
+	assert.Equal(t, http.StatusUnauthorized, httpErr.Status)
+}
+
+func TestSigning_Reject(t *testing.T) {
+	var got error
+	srv := server(t, WithMaxBody(16), WithOnReject(func(c *gin.Context, err error) {
+		got = err
+		c.Status(http.StatusForbidden)
+	}))
+
+	resp, err := http.Post(srv.URL+"/payments", "application/json", strings.NewReader(`{"amount":1}`))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	assert.ErrorIs(t, got, signing.ErrMissingSignature)
+
+	resp, err = http.Post(srv.URL+"/payments", "application/json", strings.NewReader(`{"amount":1000000000}`))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.ErrorIs(t, got, ErrBodyTooLarge)
+}
@@ -0,0 +1,76 @@
+package tenant
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultHeader is the header read when no other source is configured.
+const DefaultHeader = "X-Tenant-ID"
+
+type Option func(*options)
+
+type options struct {
+	header     string
+	headerSet  bool
+	baseDomain string
+	resolver   func(c *gin.Context) string
+	optional   bool
+	validate   func(ctx context.Context, id string) error
+	onReject   func(c *gin.Context, err error)
+}
+
+func defaultOptions() *options {
+	return &options{}
+}
+
+// WithHeader sets the header carrying the tenant ID; "" disables it. Without WithHeader,
+// DefaultHeader is read only when neither WithSubdomain nor WithResolver is set.
+//
+// Any client can send the header, so a tenant taken from it must be checked against the
+// authenticated user, e.g. with WithValidator or a resolver reading the token instead.
+func WithHeader(name string) Option {
+	return func(o *options) {
+		o.header = name
+		o.headerSet = true
+	}
+}
+
+// WithSubdomain resolves the tenant from the subdomain of baseDomain,
+// e.g. "acme" for "acme.example.com" with baseDomain "example.com".
+// It is tried before the header.
+func WithSubdomain(baseDomain string) Option {
+	return func(o *options) {
+		o.baseDomain = baseDomain
+	}
+}
+
+// WithResolver resolves the tenant with fn (e.g. from a JWT claim); it is tried first.
+func WithResolver(fn func(c *gin.Context) string) Option {
+	return func(o *options) {
+		o.resolver = fn
+	}
+}
+
+// WithOptional lets requests without a tenant through instead of rejecting them with 400.
+func WithOptional() Option {
+	return func(o *options) {
+		o.optional = true
+	}
+}
+
+// WithValidator checks the resolved tenant, e.g. that it exists and is active.
+// A non-nil error rejects the request with 403.
+func WithValidator(fn func(ctx context.Context, id string) error) Option {
+	return func(o *options) {
+		o.validate = fn
+	}
+}
+
+// WithOnReject overrides the response of rejected requests.
+func WithOnReject(fn func(c *gin.Context, err error)) Option {
+	return func(o *options) {
+		o.onReject = fn
+	}
+}
@@ -0,0 +1,109 @@
+package tenant
+
+import (
+	"errors"
+	"net"
+	"strings"
+
+	"github.com/BevisDev/godev/consts"
+	"github.com/BevisDev/godev/ginfw/response"
+	"github.com/BevisDev/godev/tenant"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	// ErrMissingTenant is passed to the reject handler when no tenant could be resolved.
+	ErrMissingTenant = errors.New("[tenant] missing tenant")
+)
+
+// Tenant resolves the tenant of a request (resolver, subdomain, then header)
+// and stores it in the request context for tenant.FromContext and database scoping.
+type Tenant struct {
+	*options
+}
+
+func New(opts ...Option) *Tenant {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	if !o.headerSet && o.resolver == nil && o.baseDomain == "" {
+		o.header = DefaultHeader
+	}
+	return &Tenant{options: o}
+}
+
+func (t *Tenant) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := t.resolve(c)
+		if id == "" {
+			if t.optional {
+				c.Next()
+				return
+			}
+			t.reject(c, ErrMissingTenant)
+			return
+		}
+
+		if t.validate != nil {
+			if err := t.validate(c.Request.Context(), id); err != nil {
+				t.reject(c, err)
+				return
+			}
+		}
+
+		c.Request = c.Request.WithContext(tenant.WithTenant(c.Request.Context(), id))
+		c.Set(consts.TenantID, id)
+		c.Next()
+	}
+}
+
+func (t *Tenant) resolve(c *gin.Context) string {
+	if t.resolver != nil {
+		if id := t.resolver(c); id != "" {
+			return id
+		}
+	}
+	if t.baseDomain != "" {
+		if id := subdomain(c.Request.Host, t.baseDomain); id != "" {
+			return id
+		}
+	}
+	if t.header != "" {
+		return strings.TrimSpace(c.GetHeader(t.header))
+	}
+	return ""
+}
+
+// subdomain returns the first label of host below baseDomain, or "".
+func subdomain(host, baseDomain string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.ToLower(host)
+	suffix := "." + strings.ToLower(strings.TrimPrefix(baseDomain, "."))
+	if !strings.HasSuffix(host, suffix) {
+		return ""
+	}
+	sub := strings.TrimSuffix(host, suffix)
+	if i := strings.LastIndexByte(sub, '.'); i >= 0 {
+		sub = sub[i+1:]
+	}
+	if sub == "www" {
+		return ""
+	}
+	return sub
+}
+
+func (t *Tenant) reject(c *gin.Context, err error) {
+	c.Abort()
+	if t.onReject != nil {
+		t.onReject(c, err)
+		return
+	}
+	if errors.Is(err, ErrMissingTenant) {
+		response.BadRequest(c, "", "missing tenant")
+		return
+	}
+	response.Forbidden(c, "", "")
+}
@@ -0,0 +1,92 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/BevisDev/godev/tenant"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func serve(mw *Tenant, req *http.Request) (*httptest.ResponseRecorder, string) {
+	gin.SetMode(gin.ReleaseMode)
+
+	var got string
+	r := gin.New()
+	r.Use(mw.Handler())
+	r.GET("/", func(c *gin.Context) {
+		got = tenant.FromContext(c.Request.Context())
+		c.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w, got
+}
+
+func TestTenant_Header(t *testing.T) {
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set(DefaultHeader, "acme")
+
+	w, got := serve(New(), req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "acme", got)
+}
+
+func TestTenant_Subdomain(t *testing.T) {
+	req := httptest.NewRequest("GET", "http://acme.example.com:8080/", nil)
+
+	w, got := serve(New(WithSubdomain("example.com")), req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "acme", got)
+
+	assert.Equal(t, "", subdomain("www.example.com", "example.com"))
+	assert.Equal(t, "", subdomain("example.com", "example.com"))
+	assert.Equal(t, "acme", subdomain("api.acme.example.com", "example.com"))
+}
+
+func TestTenant_HeaderDoesNotOverride(t *testing.T) {
+	req := httptest.NewRequest("GET", "http://acme.example.com/", nil)
+	req.Header.Set(DefaultHeader, "evil")
+
+	_, got := serve(New(WithSubdomain("example.com")), req)
+	assert.Equal(t, "acme", got, "header is off with a subdomain")
+
+	_, got = serve(New(WithResolver(func(*gin.Context) string { return "acme" })), req)
+	assert.Equal(t, "acme", got, "header is off with a resolver")
+
+	_, got = serve(New(WithSubdomain("example.com"), WithHeader(DefaultHeader)), req)
+	assert.Equal(t, "acme", got, "subdomain is tried before an opted-in header")
+
+	req = httptest.NewRequest("GET", "http://example.com/", nil)
+	req.Header.Set(DefaultHeader, "beta")
+	_, got = serve(New(WithSubdomain("example.com"), WithHeader(DefaultHeader)), req)
+	assert.Equal(t, "beta", got, "opted-in header as fallback")
+}
+
+func TestTenant_Missing(t *testing.T) {
+	w, _ := serve(New(), httptest.NewRequest("GET", "/", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w, got := serve(New(WithOptional()), httptest.NewRequest("GET", "/", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "", got)
+}
+
+func TestTenant_Validator(t *testing.T) {
+	mw := New(WithValidator(func(ctx context.Context, id string) error {
+		if id != "acme" {
+			return errors.New("unknown tenant")
+		}
+		return nil
+	}))
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set(DefaultHeader, "evil")
+	w, _ := serve(mw, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
@@ -0,0 +1,94 @@
+package request
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/BevisDev/godev/types"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// QueryEnum returns the query parameter key as a value of enum, or def when
+// it is missing or empty. A value outside the set returns types.ErrBadRequest
+// listing the allowed values, ready for response.Fail.
+//
+//	status, err := request.QueryEnum(c, "status", Statuses, StatusPending)
+//	if err != nil {
+//		response.Fail(c, err)
+//		return
+//	}
+func QueryEnum[E ~string](c *gin.Context, key string, enum *types.Enum[E], def E) (E, error) {
+	s := c.Query(key)
+	if s == "" {
+		return def, nil
+	}
+	v, err := enum.Parse(s)
+	if err != nil {
+		return "", badEnum(key, enum, err)
+	}
+	return v, nil
+}
+
+// QueryEnums returns the values of a repeated or comma-separated query
+// parameter, e.g. ?status=new&status=paid or ?status=new,paid, in order and
+// without duplicates. It returns nil when the parameter is missing.
+func QueryEnums[E ~string](c *gin.Context, key string, enum *types.Enum[E]) ([]E, error) {
+	var out []E
+	seen := make(map[E]struct{})
+	for _, param := range c.QueryArray(key) {
+		for _, s := range strings.Split(param, ",") {
+			s = strings.TrimSpace(s)
+			if s == "" {
+				continue
+			}
+			v, err := enum.Parse(s)
+			if err != nil {
+				return nil, badEnum(key, enum, err)
+			}
+			if _, ok := seen[v]; !ok {
+				seen[v] = struct{}{}
+				out = append(out, v)
+			}
+		}
+	}
+	return out, nil
+}
+
+func badEnum[E ~string](key string, enum *types.Enum[E], err error) error {
+	return types.ErrBadRequest.
+		WithMessage("%s must be one of [%s]", key, strings.Join(enum.Strings(), ", ")).
+		Wrap(err)
+}
+
+// RegisterEnumValidation adds the "enum" tag to the validator of gin binding:
+// a field of a type registered with types.RegisterEnum must hold one of its
+// values. Empty values pass, so combine with "required" when needed; use
+// "dive,enum" for slices.
+//
+//	type ListOrdersReq struct {
+//		Status Status `form:"status" binding:"omitempty,enum"`
+//	}
+//
+// Call it once at startup, before binding requests.
+func RegisterEnumValidation() error {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return errors.New("[request] gin binding does not use go-playground/validator")
+	}
+	return v.RegisterValidation("enum", validateEnum)
+}
+
+func validateEnum(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	if field.Kind() != reflect.String {
+		return false
+	}
+	if field.String() == "" {
+		return true
+	}
+	valid, registered := types.IsEnumValue(field.Interface())
+	return valid && registered
+}
@@ -0,0 +1,76 @@
+package request
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/BevisDev/godev/types"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type status string
+
+const (
+	statusNew  status = "new"
+	statusPaid status = "paid"
+)
+
+var statuses = types.RegisterEnum(statusNew, statusPaid)
+
+func newContext(target string) *gin.Context {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, target, nil)
+	return c
+}
+
+func TestQueryEnum(t *testing.T) {
+	v, err := QueryEnum(newContext("/orders?status=paid"), "status", statuses, statusNew)
+	require.NoError(t, err)
+	assert.Equal(t, statusPaid, v)
+
+	v, err = QueryEnum(newContext("/orders"), "status", statuses, statusNew)
+	require.NoError(t, err)
+	assert.Equal(t, statusNew, v)
+
+	_, err = QueryEnum(newContext("/orders?status=lost"), "status", statuses, statusNew)
+	appErr, ok := types.AsAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusBadRequest, appErr.Status)
+	assert.Equal(t, "status must be one of [new, paid]", appErr.Message)
+	assert.ErrorIs(t, err, types.ErrInvalidEnum)
+}
+
+func TestQueryEnums(t *testing.T) {
+	v, err := QueryEnums(newContext("/orders?status=paid,new&status=paid"), "status", statuses)
+	require.NoError(t, err)
+	assert.Equal(t, []status{statusPaid, statusNew}, v)
+
+	v, err = QueryEnums(newContext("/orders"), "status", statuses)
+	require.NoError(t, err)
+	assert.Nil(t, v)
+
+	_, err = QueryEnums(newContext("/orders?status=new,lost"), "status", statuses)
+	assert.ErrorIs(t, err, types.ErrBadRequest)
+}
+
+func TestRegisterEnumValidation(t *testing.T) {
+	require.NoError(t, RegisterEnumValidation())
+
+	type listReq struct {
+		Status status   `form:"status" binding:"omitempty,enum"`
+		Tags   []status `form:"tag" binding:"dive,enum"`
+	}
+
+	var req listReq
+	require.NoError(t, newContext("/orders?status=paid&tag=new").ShouldBindQuery(&req))
+	assert.Equal(t, statusPaid, req.Status)
+
+	req = listReq{}
+	require.NoError(t, newContext("/orders").ShouldBindQuery(&req))
+
+	assert.ErrorContains(t, newContext("/orders?status=lost").ShouldBindQuery(&listReq{}), "'enum' tag")
+	assert.ErrorContains(t, newContext("/orders?tag=new&tag=lost").ShouldBindQuery(&listReq{}), "'enum' tag")
+}
@@ -0,0 +1,100 @@
+package response
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/BevisDev/godev/types"
+	"github.com/gin-gonic/gin"
+)
+
+// HandlerFunc is a gin handler that returns an error instead of writing the failure itself.
+type HandlerFunc func(c *gin.Context) error
+
+// NewError builds the failure response for err and returns it with its HTTP status.
+//
+// A *types.AppError anywhere in err's chain supplies the status, code and message;
+// the message is translated through its MessageKey when an i18n bundle is installed.
+// context.DeadlineExceeded maps to 504; any other error maps to 500 with a generic
+// message so internal details are never leaked to clients.
+func NewError(ctx context.Context, err error) (int, *Response) {
+	appErr, ok := types.AsAppError(err)
+	if !ok {
+		if errors.Is(err, context.DeadlineExceeded) {
+			appErr = types.ErrGatewayTimeout
+		} else {
+			appErr = types.ErrInternal
+		}
+	}
+
+	message := appErr.Message
+	if msg, ok := translate(ctx, appErr.MessageKey, appErr.Params); ok {
+		message = msg
+	}
+	code, message := GetCode(appErr.Code, message, "500")
+	return appErr.Status, NewFailure(ctx, code, message)
+}
+
+// Fail writes the failure response for err (see NewError) and aborts the chain.
+//
+// Example:
+//
+//	order, err := svc.GetOrder(ctx, id)
+//	if err != nil {
+//		response.Fail(c, err) // ErrOrderNotFound -> 404 {"code":"ORDER_404",...}
+//		return
+//	}
+func Fail(c *gin.Context, err error) {
+	status, res := NewError(c.Request.Context(), err)
+	c.AbortWithStatusJSON(status, res)
+}
+
+// Handle adapts a HandlerFunc to gin: a returned error is recorded on the context
+// (so logging middleware can see it) and written with Fail.
+//
+// Example:
+//
+//	r.GET("/orders/:id", response.Handle(func(c *gin.Context) error {
+//		order, err := svc.GetOrder(c.Request.Context(), c.Param("id"))
+//		if err != nil {
+//			return err
+//		}
+//		response.Success(c, order)
+//		return nil
+//	}))
+func Handle(fn HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := fn(c); err != nil {
+			_ = c.Error(err)
+			if !c.Writer.Written() {
+				Fail(c, err)
+			}
+		}
+	}
+}
+
+// ErrorHandler is a middleware that writes the last error added with c.Error
+// when the handler chain did not write a response.
+func ErrorHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+		status, res := NewError(c.Request.Context(), c.Errors.Last().Err)
+		c.JSON(status, res)
+	}
+}
+
+// StatusOf returns the HTTP status err maps to (see NewError).
+func StatusOf(err error) int {
+	if appErr, ok := types.AsAppError(err); ok {
+		return appErr.Status
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return http.StatusGatewayTimeout
+	}
+	return http.StatusInternalServerError
+}
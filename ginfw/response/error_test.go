@@ -0,0 +1,96 @@
+package response
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/BevisDev/godev/types"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errOrderNotFound = types.RegisterError("ORDER_404", http.StatusNotFound, "order.not_found", "Order not found")
+
+func serve(t *testing.T, r *gin.Engine, path string) (int, Response) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+
+	var res Response
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	return w.Code, res
+}
+
+func TestHandle_MapsErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/app", Handle(func(c *gin.Context) error {
+		return fmt.Errorf("load: %w", errOrderNotFound.Wrap(errors.New("sql: no rows")))
+	}))
+	r.GET("/plain", Handle(func(c *gin.Context) error {
+		return errors.New("connection refused to 10.0.0.1")
+	}))
+	r.GET("/timeout", Handle(func(c *gin.Context) error {
+		return context.DeadlineExceeded
+	}))
+	r.GET("/ok", Handle(func(c *gin.Context) error {
+		Success(c, "done")
+		return nil
+	}))
+
+	status, res := serve(t, r, "/app")
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.False(t, res.Success)
+	assert.Equal(t, "ORDER_404", res.Error.Code)
+	assert.Equal(t, "Order not found", res.Error.Message)
+
+	status, res = serve(t, r, "/plain")
+	assert.Equal(t, http.StatusInternalServerError, status)
+	assert.Equal(t, "500", res.Error.Code)
+	assert.Equal(t, "Internal Server Error", res.Error.Message, "internal details must not leak")
+
+	status, _ = serve(t, r, "/timeout")
+	assert.Equal(t, http.StatusGatewayTimeout, status)
+
+	status, res = serve(t, r, "/ok")
+	assert.Equal(t, http.StatusOK, status)
+	assert.True(t, res.Success)
+}
+
+func TestErrorHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(ErrorHandler())
+	r.GET("/conflict", func(c *gin.Context) {
+		_ = c.Error(types.ErrConflict.WithMessage("order %s already paid", "A1"))
+	})
+
+	status, res := serve(t, r, "/conflict")
+	assert.Equal(t, http.StatusConflict, status)
+	assert.Equal(t, "409", res.Error.Code)
+	assert.Equal(t, "order A1 already paid", res.Error.Message)
+}
+
+func TestAppError(t *testing.T) {
+	wrapped := fmt.Errorf("svc: %w", errOrderNotFound.Wrap(errors.New("cause")))
+	assert.ErrorIs(t, wrapped, errOrderNotFound)
+	assert.NotErrorIs(t, wrapped, types.ErrNotFound)
+	assert.Equal(t, http.StatusNotFound, StatusOf(wrapped))
+	assert.Equal(t, "ORDER_404: Order not found: cause", errors.Unwrap(wrapped).Error())
+	assert.Nil(t, errOrderNotFound.Unwrap(), "definition must not be mutated")
+
+	e, ok := types.LookupError("ORDER_404")
+	require.True(t, ok)
+	assert.Equal(t, "order.not_found", e.MessageKey)
+	assert.Panics(t, func() { types.RegisterError("ORDER_404", 404, "", "") })
+
+	code, msg := GetCode("ORDER_404", "", "400")
+	assert.Equal(t, "ORDER_404", code)
+	assert.Equal(t, "Order not found", msg)
+}
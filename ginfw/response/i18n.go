@@ -0,0 +1,43 @@
+package response
+
+import (
+	"context"
+
+	"github.com/BevisDev/godev/i18n"
+	"github.com/BevisDev/godev/types"
+)
+
+// translate looks key up in the default i18n bundle using the language stored in ctx.
+func translate(ctx context.Context, key string, params map[string]any) (string, bool) {
+	b := i18n.Default()
+	if b == nil || key == "" {
+		return "", false
+	}
+	return b.Lookup(i18n.LangFromContext(ctx), key, params)
+}
+
+// localize translates the default message of code when an i18n bundle is installed.
+// The message key is the registered AppError's MessageKey, or the code itself.
+// A custom message (different from the code's default) is returned unchanged.
+func localize(ctx context.Context, code, message string) string {
+	if i18n.Default() == nil {
+		return message
+	}
+
+	key, def := code, Code[code]
+	if e, ok := types.LookupError(code); ok {
+		if e.MessageKey != "" {
+			key = e.MessageKey
+		}
+		if def == "" {
+			def = e.Message
+		}
+	}
+	if message != "" && message != def {
+		return message
+	}
+	if msg, ok := translate(ctx, key, nil); ok {
+		return msg
+	}
+	return message
+}
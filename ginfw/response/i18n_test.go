@@ -0,0 +1,102 @@
+package response
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/BevisDev/godev/ginfw/middleware/locale"
+	"github.com/BevisDev/godev/i18n"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type createUserReq struct {
+	Email string `json:"email" binding:"required,email"`
+	Name  string `json:"name" binding:"required,min=3"`
+}
+
+func setupLocalized(t *testing.T) *gin.Engine {
+	t.Helper()
+	b := i18n.New("en")
+	b.AddMessages("vi", map[string]string{
+		"order.not_found":     "Không tìm thấy đơn hàng",
+		"error.not_found":     "Không tìm thấy",
+		"validation.required": "{field} là bắt buộc",
+		"field.Name":          "Tên",
+	})
+	i18n.SetDefault(b)
+	t.Cleanup(func() { i18n.SetDefault(nil) })
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(locale.New(b).Handler())
+	r.GET("/order", Handle(func(c *gin.Context) error {
+		return errOrderNotFound
+	}))
+	r.GET("/notfound", func(c *gin.Context) {
+		NotFound(c, "", "")
+	})
+	r.GET("/custom", func(c *gin.Context) {
+		NotFound(c, "", "user 42 missing")
+	})
+	r.POST("/users", func(c *gin.Context) {
+		var req createUserReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			ValidationFailed(c, err)
+			return
+		}
+		Success(c, req)
+	})
+	return r
+}
+
+func doLocalized(t *testing.T, r *gin.Engine, method, path, body, lang string) (int, Response) {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Accept-Language", lang)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var res Response
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	return w.Code, res
+}
+
+func TestLocalize_Failures(t *testing.T) {
+	r := setupLocalized(t)
+
+	status, res := doLocalized(t, r, http.MethodGet, "/order", "", "vi-VN,vi;q=0.9")
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.Equal(t, "Không tìm thấy đơn hàng", res.Error.Message)
+
+	_, res = doLocalized(t, r, http.MethodGet, "/order", "", "en")
+	assert.Equal(t, "Order not found", res.Error.Message, "missing translation keeps the default")
+
+	_, res = doLocalized(t, r, http.MethodGet, "/notfound", "", "vi")
+	assert.Equal(t, "Không tìm thấy", res.Error.Message)
+
+	_, res = doLocalized(t, r, http.MethodGet, "/custom", "", "vi")
+	assert.Equal(t, "user 42 missing", res.Error.Message, "custom messages are not translated")
+}
+
+func TestValidationFailed(t *testing.T) {
+	r := setupLocalized(t)
+
+	status, res := doLocalized(t, r, http.MethodPost, "/users", `{"email":"bad"}`, "vi")
+	assert.Equal(t, http.StatusBadRequest, status)
+	require.Len(t, res.Errors, 2)
+	assert.Equal(t, Error{Code: "email", Field: "Email", Message: "Email must be a valid email address"}, res.Errors[0])
+	assert.Equal(t, Error{Code: "required", Field: "Name", Message: "Tên là bắt buộc"}, res.Errors[1])
+
+	_, res = doLocalized(t, r, http.MethodPost, "/users", `{"email":"a@b.co","name":"ab"}`, "en")
+	require.Len(t, res.Errors, 1)
+	assert.Equal(t, "Name must be at least 3", res.Errors[0].Message)
+
+	status, res = doLocalized(t, r, http.MethodPost, "/users", `{bad json`, "en")
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "400", res.Error.Code)
+}
@@ -3,8 +3,10 @@ package response
 import (
 	"context"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/BevisDev/godev/types"
 	"github.com/BevisDev/godev/utils"
 	"github.com/BevisDev/godev/utils/datetime"
 	"github.com/gin-gonic/gin"
@@ -37,6 +39,7 @@ type Response struct {
 // Error represents an error in the API response.
 type Error struct {
 	Code    string `json:"code,omitempty"`
+	Field   string `json:"field,omitempty"`
 	Message string `json:"message,omitempty"`
 }
 
@@ -51,7 +54,10 @@ func NewSuccess(ctx context.Context, data any) *Response {
 }
 
 // NewFailure creates a failure response with error code and message.
+// When an i18n bundle is installed (i18n.SetDefault) and message is empty or the
+// code's default, the message is translated into the language stored in ctx.
 func NewFailure(ctx context.Context, code, message string) *Response {
+	message = localize(ctx, code, message)
 	return &Response{
 		RID:        utils.GetRID(ctx),
 		Success:    false,
@@ -101,6 +107,8 @@ func responseAt() string {
 	return datetime.ToString(time.Now(), datetime.DateTimeLayout)
 }
 
+// GetCode fills in defaultCode when code is empty, and the default message for the code
+// when message is empty: first from Code, then from the types error registry.
 func GetCode(code string, message string, defaultCode string) (string, string) {
 	if code == "" {
 		code = defaultCode
@@ -108,6 +116,11 @@ func GetCode(code string, message string, defaultCode string) (string, string) {
 	if message == "" {
 		message = Code[code]
 	}
+	if message == "" {
+		if e, ok := types.LookupError(code); ok {
+			message = e.Message
+		}
+	}
 	return code, message
 }
 
@@ -135,6 +148,36 @@ func NotModified(c *gin.Context) {
 	c.JSON(http.StatusNotModified, res)
 }
 
+// CheckETag sets the ETag response header and sends NotModified when the
+// If-None-Match header of the request matches it.
+// It returns true when the response was sent and the handler should return.
+func CheckETag(c *gin.Context, etag string) bool {
+	c.Header("ETag", etag)
+	if !MatchETag(c.GetHeader("If-None-Match"), etag) {
+		return false
+	}
+	NotModified(c)
+	return true
+}
+
+// MatchETag reports whether an If-None-Match header value matches etag.
+// The comparison is weak, so W/"x" matches "x".
+func MatchETag(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" || etag == "" {
+		return false
+	}
+	if strings.TrimSpace(ifNoneMatch) == "*" {
+		return true
+	}
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, tag := range strings.Split(ifNoneMatch, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(tag), "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
 // BadRequest sends a 400 Bad Request response with error code and message.
 func BadRequest(c *gin.Context, code, message string) {
 	code, message = GetCode(code, message, "400")
@@ -0,0 +1,42 @@
+package response
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchETag(t *testing.T) {
+	assert.True(t, MatchETag(`"v1"`, `"v1"`))
+	assert.True(t, MatchETag(`W/"v1"`, `"v1"`))
+	assert.True(t, MatchETag(`"v0", W/"v1"`, `W/"v1"`))
+	assert.True(t, MatchETag(`*`, `"v1"`))
+	assert.False(t, MatchETag(`"v0"`, `"v1"`))
+	assert.False(t, MatchETag("", `"v1"`))
+}
+
+func TestCheckETag(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+	r := gin.New()
+	r.GET("/", func(c *gin.Context) {
+		if CheckETag(c, `"v1"`) {
+			return
+		}
+		Success(c, "data")
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"v1"`, w.Header().Get("ETag"))
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("If-None-Match", `"v1"`)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+}
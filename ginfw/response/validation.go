@@ -0,0 +1,84 @@
+package response
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/BevisDev/godev/i18n"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// ValidationMessages are the English fallbacks for validator tags, used when the
+// i18n bundle has no "validation.<tag>" message. {field} and {param} are replaced.
+var ValidationMessages = map[string]string{
+	"required": "{field} is required",
+	"email":    "{field} must be a valid email address",
+	"url":      "{field} must be a valid URL",
+	"uuid":     "{field} must be a valid UUID",
+	"numeric":  "{field} must be numeric",
+	"len":      "{field} must have length {param}",
+	"min":      "{field} must be at least {param}",
+	"max":      "{field} must be at most {param}",
+	"gt":       "{field} must be greater than {param}",
+	"gte":      "{field} must be greater than or equal to {param}",
+	"lt":       "{field} must be less than {param}",
+	"lte":      "{field} must be less than or equal to {param}",
+	"oneof":    "{field} must be one of [{param}]",
+	"enum":     "{field} must be one of the allowed values",
+	"default":  "{field} is invalid",
+}
+
+// NewValidationErrors maps validator errors (as returned by c.ShouldBind*) to response errors.
+// Code is the failed tag; the message comes from the i18n bundle key "validation.<tag>",
+// then ValidationMessages. Field labels can be translated with "field.<name>" keys.
+// It returns nil when err is not a validator.ValidationErrors.
+func NewValidationErrors(ctx context.Context, err error) []Error {
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return nil
+	}
+
+	out := make([]Error, 0, len(verrs))
+	for _, fe := range verrs {
+		field := fe.Field()
+		label := field
+		if msg, ok := translate(ctx, "field."+field, nil); ok {
+			label = msg
+		}
+		params := map[string]any{"field": label, "param": fe.Param()}
+
+		msg, ok := translate(ctx, "validation."+fe.Tag(), params)
+		if !ok {
+			tmpl, found := ValidationMessages[fe.Tag()]
+			if !found {
+				tmpl = ValidationMessages["default"]
+			}
+			msg = i18n.Format(tmpl, params)
+		}
+		out = append(out, Error{Code: fe.Tag(), Field: field, Message: msg})
+	}
+	return out
+}
+
+// ValidationFailed sends a 400 Bad Request for a binding error.
+// Validation errors are listed per field; other errors (e.g. malformed JSON)
+// produce the generic bad request failure.
+//
+// Example:
+//
+//	var req CreateUserReq
+//	if err := c.ShouldBindJSON(&req); err != nil {
+//		response.ValidationFailed(c, err)
+//		return
+//	}
+func ValidationFailed(c *gin.Context, err error) {
+	ctx := c.Request.Context()
+	if errs := NewValidationErrors(ctx, err); len(errs) > 0 {
+		c.AbortWithStatusJSON(http.StatusBadRequest, NewFailures(ctx, errs...))
+		return
+	}
+	code, message := GetCode("", "", "400")
+	c.AbortWithStatusJSON(http.StatusBadRequest, NewFailure(ctx, code, message))
+}
@@ -2,8 +2,10 @@ package server
 
 import (
 	"context"
+	"net/http"
 	"time"
 
+	"github.com/BevisDev/godev/errreport"
 	"github.com/gin-gonic/gin"
 )
 
@@ -75,6 +77,31 @@ type Config struct {
 
 	// Recovery is an optional custom panic recovery middleware.
 	Recovery func(c *gin.Context, err any)
+
+	// ErrorReporter captures handler panics with their stack and request
+	// before Recovery (or the default recovery) answers the request.
+	ErrorReporter *errreport.Reporter
+
+	// DrainTimeout is how long the server keeps serving after Stop (or a shutdown
+	// signal) marks it as draining, before it closes the listeners. It gives load
+	// balancers time to notice the failing readiness probe. Zero stops at once.
+	DrainTimeout time.Duration
+
+	// ReadinessPath registers a readiness endpoint (e.g. "/readyz") that returns
+	// 503 as soon as the server is draining, and Readiness otherwise.
+	ReadinessPath string
+
+	// Readiness answers ReadinessPath while the server is not draining.
+	// Defaults to 200 {"status":"UP"}.
+	Readiness http.Handler
+
+	// Static serves embedded assets (e.g. a dashboard build) for GET and HEAD
+	// requests no route matched, optionally with SPA fallback to index.html.
+	Static []Static
+
+	// DebugEndpoints exposes pprof, expvar, GC, build and runtime information
+	// when Enabled, on a separate port or under a protected path.
+	DebugEndpoints DebugEndpoints
 }
 
 func (c *Config) clone() *Config {
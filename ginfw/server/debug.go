@@ -0,0 +1,230 @@
+package server
+
+import (
+	"expvar"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultDebugPath = "/debug"
+	defaultDebugHost = "127.0.0.1"
+)
+
+// startedAt is reported as the uptime of the process by the runtime endpoint.
+var startedAt = time.Now()
+
+// DebugEndpoints exposes pprof, expvar and runtime information for
+// production debugging. It is off unless Enabled is set, so it can be
+// toggled from the configuration without a redeploy of code.
+//
+// With Port set, the endpoints are served on a separate listener (bound to
+// 127.0.0.1 by default); otherwise they are mounted under Path on the main
+// server and require Auth or Username/Password.
+//
+//	DebugEndpoints: server.DebugEndpoints{Enabled: cfg.Debug.Enabled, Port: 6060}
+type DebugEndpoints struct {
+	// Enabled turns the endpoints on.
+	Enabled bool
+
+	// Port serves the endpoints on their own listener. Zero mounts them on the main server.
+	Port int
+
+	// Host is the interface the separate listener binds to (default "127.0.0.1").
+	Host string
+
+	// Path is the route prefix of the endpoints (default "/debug").
+	Path string
+
+	// Username and Password protect the endpoints with HTTP basic auth.
+	Username string
+	Password string
+
+	// Auth protects the endpoints, e.g. an admin JWT check. It takes
+	// precedence over Username/Password.
+	Auth gin.HandlerFunc
+}
+
+func (d DebugEndpoints) withDefaults() DebugEndpoints {
+	if d.Path == "" {
+		d.Path = defaultDebugPath
+	}
+	d.Path = "/" + strings.Trim(d.Path, "/")
+	if d.Host == "" {
+		d.Host = defaultDebugHost
+	}
+	return d
+}
+
+// guard returns the auth middleware of the endpoints, nil when none is configured.
+func (d DebugEndpoints) guard() gin.HandlerFunc {
+	if d.Auth != nil {
+		return d.Auth
+	}
+	if d.Username != "" && d.Password != "" {
+		return gin.BasicAuth(gin.Accounts{d.Username: d.Password})
+	}
+	return nil
+}
+
+// setupDebug registers the debug endpoints on r, or returns the separate
+// server serving them when Port is set.
+func setupDebug(r *gin.Engine, config *Config) *http.Server {
+	d := config.DebugEndpoints.withDefaults()
+	var handlers []gin.HandlerFunc
+	if guard := d.guard(); guard != nil {
+		handlers = append(handlers, guard)
+	}
+
+	if d.Port <= 0 {
+		if len(handlers) == 0 {
+			log.Printf("[server] debug endpoints disabled: Auth or Username/Password is required on the main port")
+			return nil
+		}
+		registerDebug(r.Group(d.Path, handlers...))
+		log.Printf("[server] debug endpoints enabled under %s", d.Path)
+		return nil
+	}
+
+	e := gin.New()
+	e.Use(gin.Recovery())
+	registerDebug(e.Group(d.Path, handlers...))
+
+	addr := net.JoinHostPort(d.Host, strconv.Itoa(d.Port))
+	log.Printf("[server] debug endpoints enabled on %s%s", addr, d.Path)
+
+	// no write timeout: CPU profiles and traces stream for their whole duration
+	return &http.Server{
+		Addr:              addr,
+		Handler:           e,
+		ReadHeaderTimeout: defaultReadHeaderTimeout,
+	}
+}
+
+// registerDebug registers the endpoints:
+//
+//	/pprof/          pprof index and profiles (heap, goroutine, profile, trace, ...)
+//	/vars            expvar variables
+//	/runtime         goroutines, memory and GC summary
+//	/gc              GC statistics with recent pauses
+//	/build           build information
+func registerDebug(g *gin.RouterGroup) {
+	g.GET("/pprof/*name", pprofHandler)
+	g.GET("/vars", gin.WrapH(expvar.Handler()))
+	g.GET("/runtime", runtimeHandler)
+	g.GET("/gc", gcHandler)
+	g.GET("/build", buildHandler)
+}
+
+func pprofHandler(c *gin.Context) {
+	switch name := strings.Trim(c.Param("name"), "/"); name {
+	case "":
+		pprof.Index(c.Writer, c.Request)
+	case "cmdline":
+		pprof.Cmdline(c.Writer, c.Request)
+	case "profile":
+		pprof.Profile(c.Writer, c.Request)
+	case "symbol":
+		pprof.Symbol(c.Writer, c.Request)
+	case "trace":
+		pprof.Trace(c.Writer, c.Request)
+	default:
+		pprof.Handler(name).ServeHTTP(c.Writer, c.Request)
+	}
+}
+
+func runtimeHandler(c *gin.Context) {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+
+	c.JSON(http.StatusOK, gin.H{
+		"go_version": runtime.Version(),
+		"goroutines": runtime.NumGoroutine(),
+		"gomaxprocs": runtime.GOMAXPROCS(0),
+		"num_cpu":    runtime.NumCPU(),
+		"uptime":     time.Since(startedAt).Round(time.Second).String(),
+		"memory": gin.H{
+			"alloc_bytes":       m.Alloc,
+			"total_alloc_bytes": m.TotalAlloc,
+			"sys_bytes":         m.Sys,
+			"heap_inuse_bytes":  m.HeapInuse,
+			"heap_objects":      m.HeapObjects,
+			"stack_inuse_bytes": m.StackInuse,
+		},
+		"gc": gin.H{
+			"num_gc":        m.NumGC,
+			"pause_total":   time.Duration(m.PauseTotalNs).String(),
+			"next_gc_bytes": m.NextGC,
+			"cpu_fraction":  m.GCCPUFraction,
+			"last_gc":       lastGC(time.Unix(0, int64(m.LastGC))),
+		},
+	})
+}
+
+func gcHandler(c *gin.Context) {
+	stats := debug.GCStats{PauseQuantiles: make([]time.Duration, 5)}
+	debug.ReadGCStats(&stats)
+
+	recent := stats.Pause
+	if len(recent) > 10 {
+		recent = recent[:10]
+	}
+	pauses := make([]string, len(recent))
+	for i, p := range recent {
+		pauses[i] = p.String()
+	}
+	quantiles := make([]string, len(stats.PauseQuantiles))
+	for i, q := range stats.PauseQuantiles {
+		quantiles[i] = q.String()
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"num_gc":          stats.NumGC,
+		"last_gc":         lastGC(stats.LastGC),
+		"pause_total":     stats.PauseTotal.String(),
+		"recent_pauses":   pauses,
+		"pause_quantiles": quantiles, // min, 25%, 50%, 75%, max
+	})
+}
+
+func buildHandler(c *gin.Context) {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "build info not available"})
+		return
+	}
+
+	settings := make(map[string]string, len(info.Settings))
+	for _, s := range info.Settings {
+		settings[s.Key] = s.Value
+	}
+	deps := make([]string, 0, len(info.Deps))
+	for _, d := range info.Deps {
+		deps = append(deps, fmt.Sprintf("%s@%s", d.Path, d.Version))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"go_version": info.GoVersion,
+		"path":       info.Path,
+		"version":    info.Main.Version,
+		"settings":   settings,
+		"deps":       deps,
+	})
+}
+
+func lastGC(t time.Time) string {
+	if t.UnixNano() <= 0 {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
@@ -0,0 +1,71 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serve(h http.Handler, req *http.Request) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestDebugEndpoints_MainPort(t *testing.T) {
+	app := New(&Config{
+		IsProduction: true,
+		DebugEndpoints: DebugEndpoints{
+			Enabled:  true,
+			Path:     "/internal/debug/",
+			Username: "ops",
+			Password: "secret",
+		},
+		Setup: func(r *gin.Engine) {
+			r.NoRoute(func(c *gin.Context) { c.Status(http.StatusTeapot) })
+		},
+	})
+	assert.Nil(t, app.debug)
+
+	req := httptest.NewRequest(http.MethodGet, "/internal/debug/runtime", nil)
+	assert.Equal(t, http.StatusUnauthorized, serve(app.engine, req).Code)
+
+	for _, path := range []string{"/runtime", "/gc", "/vars", "/pprof/", "/pprof/goroutine?debug=1", "/pprof/cmdline"} {
+		req = httptest.NewRequest(http.MethodGet, "/internal/debug"+path, nil)
+		req.SetBasicAuth("ops", "secret")
+		assert.Equal(t, http.StatusOK, serve(app.engine, req).Code, path)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/internal/debug/runtime", nil)
+	req.SetBasicAuth("ops", "secret")
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(serve(app.engine, req).Body.Bytes(), &body))
+	assert.Greater(t, body["goroutines"], float64(0))
+	assert.Contains(t, body, "memory")
+}
+
+func TestDebugEndpoints_RequireAuthOnMainPort(t *testing.T) {
+	app := New(&Config{IsProduction: true, DebugEndpoints: DebugEndpoints{Enabled: true}})
+
+	rec := serve(app.engine, httptest.NewRequest(http.MethodGet, "/debug/runtime", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Empty(t, app.Routes())
+}
+
+func TestDebugEndpoints_SeparatePort(t *testing.T) {
+	app := New(&Config{IsProduction: true, DebugEndpoints: DebugEndpoints{Enabled: true, Port: 6060}})
+	require.NotNil(t, app.debug)
+	assert.Equal(t, "127.0.0.1:6060", app.debug.Addr)
+	assert.Empty(t, app.Routes(), "nothing is mounted on the main server")
+
+	assert.Equal(t, http.StatusOK, serve(app.debug.Handler, httptest.NewRequest(http.MethodGet, "/debug/gc", nil)).Code)
+	assert.Equal(t, http.StatusOK, serve(app.debug.Handler, httptest.NewRequest(http.MethodGet, "/debug/pprof/heap", nil)).Code)
+
+	disabled := New(&Config{IsProduction: true, DebugEndpoints: DebugEndpoints{Port: 6060}})
+	assert.Nil(t, disabled.debug)
+}
@@ -7,19 +7,35 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
+	"sync/atomic"
 	"syscall"
+	"time"
 
 	"github.com/BevisDev/godev/consts"
+	"github.com/BevisDev/godev/errreport"
 	"github.com/BevisDev/godev/utils"
 	"github.com/gin-gonic/gin"
 	"github.com/pkg/errors"
 )
 
+// Readiness statuses of the ReadinessPath endpoint.
+const (
+	StatusUp       = "UP"
+	StatusDraining = "DRAINING"
+)
+
 type HTTPApp struct {
 	config *Config
 	engine *gin.Engine
 	server *http.Server
+	debug  *http.Server // separate debug listener, see DebugEndpoints
 	errCh  chan error
+
+	// draining is set by Drain; drainedAt is when it started
+	draining  atomic.Bool
+	drainOnce sync.Once
+	drainedAt time.Time
 }
 
 // New creates a new HTTPApp instance with the provided configuration.
@@ -44,6 +60,38 @@ func New(cfg *Config) *HTTPApp {
 		r = gin.Default()
 	}
 
+	// Report panics, then re-panic into the recovery above
+	if config.ErrorReporter != nil {
+		r.Use(reportPanics(config.ErrorReporter))
+	}
+
+	h := &HTTPApp{
+		config: config,
+		engine: r,
+		errCh:  make(chan error, 1),
+	}
+
+	// Readiness probe, failing while draining
+	if config.ReadinessPath != "" {
+		r.GET(config.ReadinessPath, h.readiness)
+	}
+
+	// Serve static files for requests no route matched; a NoRoute handler
+	// registered in Setup replaces it
+	if len(config.Static) > 0 {
+		if h, err := staticHandler(config.Static); err != nil {
+			log.Printf("[server] static files disabled: %v", err)
+		} else {
+			r.NoRoute(h)
+		}
+	}
+
+	// Debug endpoints, registered before Setup so a NoRoute or catch-all
+	// route of the application cannot shadow them
+	if config.DebugEndpoints.Enabled {
+		h.debug = setupDebug(r, config)
+	}
+
 	// Apply setup hook if provided
 	if config.Setup != nil {
 		config.Setup(r)
@@ -54,16 +102,35 @@ func New(cfg *Config) *HTTPApp {
 		_ = r.SetTrustedProxies(config.Proxies)
 	}
 
-	srv := newHTTPServer(r, config)
+	h.server = newHTTPServer(r, config)
+	return h
+}
 
-	return &HTTPApp{
-		config: config,
-		engine: r,
-		server: srv,
-		errCh:  make(chan error, 1),
+// reportPanics captures the panics of the next handlers and panics again so
+// that the recovery middleware answers the request.
+func reportPanics(reporter *errreport.Reporter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			if p := recover(); p != nil {
+				// http.ErrAbortHandler aborts the response on purpose
+				if p != http.ErrAbortHandler {
+					reporter.CapturePanic(c.Request.Context(), p,
+						errreport.WithRequest(c.Request),
+						errreport.Tag("route", c.FullPath()),
+					)
+				}
+				panic(p)
+			}
+		}()
+		c.Next()
 	}
 }
 
+// Routes returns the routes registered on the Gin engine.
+func (h *HTTPApp) Routes() gin.RoutesInfo {
+	return h.engine.Routes()
+}
+
 // Start starts the HTTP server in a goroutine.
 // Returns immediately after starting the server.
 // Use Run() to start and wait for shutdown signals.
@@ -75,6 +142,15 @@ func (h *HTTPApp) Start() error {
 			h.errCh <- err
 		}
 	}()
+
+	if h.debug != nil {
+		go func() {
+			if err := h.debug.ListenAndServe(); err != nil &&
+				!errors.Is(err, http.ErrServerClosed) {
+				log.Printf("[server] debug endpoints: %v", err)
+			}
+		}()
+	}
 	return nil
 }
 
@@ -89,10 +165,58 @@ func newHTTPServer(handler http.Handler, config *Config) *http.Server {
 	}
 }
 
-// Stop gracefully stops the HTTP server.
-// It calls the Shutdown hook (if configured) and then shuts down the HTTP server.
+// Drain marks the server as draining: the readiness endpoint returns 503 and
+// keep-alive connections are closed after their current request, while new
+// requests are still served. It is idempotent; Stop calls it as well.
+func (h *HTTPApp) Drain() {
+	h.drainOnce.Do(func() {
+		h.drainedAt = time.Now()
+		h.draining.Store(true)
+		h.server.SetKeepAlivesEnabled(false)
+		log.Printf("[server] draining for %v", h.config.DrainTimeout)
+	})
+}
+
+// Draining reports whether Drain was called.
+func (h *HTTPApp) Draining() bool {
+	return h.draining.Load()
+}
+
+// waitDrain waits until DrainTimeout has passed since Drain, or ctx is done.
+func (h *HTTPApp) waitDrain(ctx context.Context) {
+	remaining := time.Until(h.drainedAt.Add(h.config.DrainTimeout))
+	if remaining <= 0 {
+		return
+	}
+
+	timer := time.NewTimer(remaining)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
+
+func (h *HTTPApp) readiness(c *gin.Context) {
+	if h.Draining() {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": StatusDraining})
+		return
+	}
+	if h.config.Readiness != nil {
+		h.config.Readiness.ServeHTTP(c.Writer, c.Request)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": StatusUp})
+}
+
+// Stop gracefully stops the HTTP server in two phases. It first drains
+// (see Drain) until DrainTimeout has passed, then calls the Shutdown hook
+// (if configured) and shuts down the HTTP server, waiting for in-flight requests.
 // The context is used to control the shutdown timeout.
 func (h *HTTPApp) Stop(ctx context.Context) error {
+	h.Drain()
+	h.waitDrain(ctx)
+
 	shutdownCtx, cancel := utils.NewCtxTimeout(ctx, h.config.ShutdownTimeout)
 	defer cancel()
 
@@ -103,6 +227,10 @@ func (h *HTTPApp) Stop(ctx context.Context) error {
 		}
 	}
 
+	if h.debug != nil {
+		_ = h.debug.Shutdown(shutdownCtx)
+	}
+
 	// Shutdown HTTP server
 	if err := h.server.Shutdown(shutdownCtx); err != nil {
 		_ = h.server.Close()
@@ -2,9 +2,12 @@ package server
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/BevisDev/godev/errreport"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -24,6 +27,21 @@ func TestNew_SetupCalled(t *testing.T) {
 	assert.True(t, setupCalled)
 }
 
+func TestHTTPApp_Routes(t *testing.T) {
+	app := New(&Config{
+		Port: 8080,
+		Setup: func(r *gin.Engine) {
+			r.GET("/ping", func(c *gin.Context) {})
+			r.POST("/users", func(c *gin.Context) {})
+		},
+	})
+
+	routes := app.Routes()
+	require.Len(t, routes, 2)
+	assert.ElementsMatch(t, []string{"GET /ping", "POST /users"},
+		[]string{routes[0].Method + " " + routes[0].Path, routes[1].Method + " " + routes[1].Path})
+}
+
 func TestNew_NilConfig_Panics(t *testing.T) {
 	assert.Panics(t, func() {
 		_ = New(nil)
@@ -83,3 +101,97 @@ func TestHTTPApp_Stop_ShutdownTimeout(t *testing.T) {
 	elapsed := time.Since(start)
 	assert.GreaterOrEqual(t, elapsed, 100*time.Millisecond, "shutdown timeout not respected")
 }
+
+func TestHTTPApp_Readiness(t *testing.T) {
+	app := New(&Config{ReadinessPath: "/readyz"})
+
+	w := get(app, "/readyz")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"status":"UP"}`, w.Body.String())
+
+	app.Drain()
+	assert.True(t, app.Draining())
+
+	w = get(app, "/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.JSONEq(t, `{"status":"DRAINING"}`, w.Body.String())
+}
+
+func TestHTTPApp_Readiness_CustomHandler(t *testing.T) {
+	app := New(&Config{
+		ReadinessPath: "/readyz",
+		Readiness: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}),
+	})
+	assert.Equal(t, http.StatusServiceUnavailable, get(app, "/readyz").Code)
+}
+
+func TestHTTPApp_Stop_Drains(t *testing.T) {
+	app := New(&Config{
+		ReadinessPath: "/readyz",
+		DrainTimeout:  150 * time.Millisecond,
+		Setup: func(r *gin.Engine) {
+			r.GET("/ping", func(c *gin.Context) { c.String(http.StatusOK, "pong") })
+		},
+	})
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() { done <- app.Stop(context.Background()) }()
+
+	// readiness fails at once while requests are still served
+	require.Eventually(t, app.Draining, time.Second, time.Millisecond)
+	assert.Equal(t, http.StatusServiceUnavailable, get(app, "/readyz").Code)
+	assert.Equal(t, "pong", get(app, "/ping").Body.String())
+
+	require.NoError(t, <-done)
+	assert.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+}
+
+func TestHTTPApp_Stop_DrainCancelled(t *testing.T) {
+	app := New(&Config{DrainTimeout: time.Minute})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_ = app.Stop(ctx)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+type eventRecorder struct {
+	events chan *errreport.Event
+}
+
+func (r *eventRecorder) Send(_ context.Context, ev *errreport.Event) error {
+	r.events <- ev
+	return nil
+}
+
+func TestHTTPApp_ErrorReporter(t *testing.T) {
+	rec := &eventRecorder{events: make(chan *errreport.Event, 1)}
+	reporter, err := errreport.New(rec)
+	require.NoError(t, err)
+
+	app := New(&Config{
+		IsProduction:  true,
+		ErrorReporter: reporter,
+		Setup: func(r *gin.Engine) {
+			r.GET("/orders/:id", func(c *gin.Context) { panic("nil order") })
+		},
+	})
+
+	w := httptest.NewRecorder()
+	app.engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/orders/7", nil))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	select {
+	case ev := <-rec.events:
+		assert.Equal(t, "nil order", ev.Message)
+		assert.Equal(t, "/orders/:id", ev.Tags["route"])
+		assert.Equal(t, "http://example.com/orders/7", ev.Request.URL)
+	case <-time.After(time.Second):
+		t.Fatal("panic was not reported")
+	}
+}
@@ -0,0 +1,185 @@
+package server
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultIndex             = "index.html"
+	defaultCacheControl      = "public, max-age=3600"
+	defaultIndexCacheControl = "no-cache"
+)
+
+// Static serves the files of FS (typically an embed.FS) under Prefix
+// for GET and HEAD requests no route matched.
+//
+//	//go:embed dist
+//	var dashboard embed.FS
+//
+//	server.Static{Prefix: "/", FS: dashboard, Root: "dist", SPA: true, Exclude: []string{"/api"}}
+type Static struct {
+	// Prefix is the URL path the files are served under, e.g. "/" or "/admin".
+	Prefix string
+
+	// FS holds the files.
+	FS fs.FS
+
+	// Root is the directory of FS served at Prefix, e.g. "dist". Empty serves FS itself.
+	Root string
+
+	// Index is served for directories and as the SPA fallback (default "index.html").
+	Index string
+
+	// SPA serves Index for unknown paths without a file extension,
+	// so client-side routes like "/admin/users/42" load the app.
+	SPA bool
+
+	// Exclude lists path prefixes below Prefix that never fall back to Index,
+	// e.g. "/api", so unknown API routes still return 404.
+	Exclude []string
+
+	// CacheControl is the Cache-Control header of files (default "public, max-age=3600").
+	// Use "public, max-age=31536000, immutable" for content-hashed assets.
+	CacheControl string
+
+	// IndexCacheControl is the Cache-Control header of Index (default "no-cache"),
+	// so new deployments are picked up.
+	IndexCacheControl string
+}
+
+func (s Static) withDefaults() (Static, error) {
+	if s.Prefix == "" {
+		s.Prefix = "/"
+	}
+	s.Prefix = "/" + strings.Trim(s.Prefix, "/")
+	if s.Index == "" {
+		s.Index = defaultIndex
+	}
+	if s.CacheControl == "" {
+		s.CacheControl = defaultCacheControl
+	}
+	if s.IndexCacheControl == "" {
+		s.IndexCacheControl = defaultIndexCacheControl
+	}
+	if s.Root != "" && s.Root != "." {
+		sub, err := fs.Sub(s.FS, s.Root)
+		if err != nil {
+			return s, err
+		}
+		s.FS = sub
+	}
+	return s, nil
+}
+
+// match returns the file name of urlPath below Prefix.
+func (s Static) match(urlPath string) (string, bool) {
+	if s.Prefix == "/" {
+		return strings.TrimPrefix(urlPath, "/"), true
+	}
+	if urlPath == s.Prefix {
+		return "", true
+	}
+	name, ok := strings.CutPrefix(urlPath, s.Prefix+"/")
+	return name, ok
+}
+
+func (s Static) excluded(urlPath string) bool {
+	for _, p := range s.Exclude {
+		p = path.Join(s.Prefix, p)
+		if urlPath == p || strings.HasPrefix(urlPath, p+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// serve writes the file for urlPath and reports whether it was found.
+func (s Static) serve(c *gin.Context, urlPath string) bool {
+	name, ok := s.match(urlPath)
+	if !ok {
+		return false
+	}
+
+	name = strings.TrimPrefix(path.Clean("/"+name), "/")
+	if name == "" {
+		name = "."
+	}
+	if info, err := fs.Stat(s.FS, name); err == nil && info.IsDir() {
+		name = path.Join(name, s.Index)
+	}
+	if s.serveFile(c, name) {
+		return true
+	}
+
+	if !s.SPA || path.Ext(name) != "" || s.excluded(urlPath) {
+		return false
+	}
+	return s.serveFile(c, s.Index)
+}
+
+func (s Static) serveFile(c *gin.Context, name string) bool {
+	f, err := s.FS.Open(name)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil || info.IsDir() {
+		return false
+	}
+
+	content, ok := f.(io.ReadSeeker)
+	if !ok {
+		raw, err := io.ReadAll(f)
+		if err != nil {
+			return false
+		}
+		content = bytes.NewReader(raw)
+	}
+
+	cacheControl := s.CacheControl
+	if path.Base(name) == s.Index {
+		cacheControl = s.IndexCacheControl
+	}
+	c.Header("Cache-Control", cacheControl)
+	http.ServeContent(c.Writer, c.Request, info.Name(), info.ModTime(), content)
+	return true
+}
+
+// staticHandler serves the first Static (longest prefix first) holding the
+// requested file and falls through to a 404 otherwise.
+func staticHandler(statics []Static) (gin.HandlerFunc, error) {
+	list := make([]Static, 0, len(statics))
+	for _, s := range statics {
+		s, err := s.withDefaults()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, s)
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return len(list[i].Prefix) > len(list[j].Prefix)
+	})
+
+	return func(c *gin.Context) {
+		method := c.Request.Method
+		if method != http.MethodGet && method != http.MethodHead {
+			return
+		}
+		for _, s := range list {
+			if s.serve(c, c.Request.URL.Path) {
+				c.Abort()
+				return
+			}
+		}
+	}, nil
+}
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+var dist = fstest.MapFS{
+	"dist/index.html":        {Data: []byte("<html>app</html>")},
+	"dist/assets/app.js":     {Data: []byte("console.log('app')")},
+	"dist/docs/index.html":   {Data: []byte("<html>docs</html>")},
+	"admin/index.html":       {Data: []byte("<html>admin</html>")},
+	"admin/assets/admin.css": {Data: []byte("body{}")},
+}
+
+func staticApp(statics ...Static) *HTTPApp {
+	return New(&Config{
+		IsProduction: true,
+		Static:       statics,
+		Setup: func(r *gin.Engine) {
+			r.GET("/api/ping", func(c *gin.Context) { c.String(http.StatusOK, "pong") })
+		},
+	})
+}
+
+func get(app *HTTPApp, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	app.engine.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+	return w
+}
+
+func TestStatic_Files(t *testing.T) {
+	app := staticApp(Static{FS: dist, Root: "dist"})
+
+	w := get(app, "/assets/app.js")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "console.log('app')", w.Body.String())
+	assert.Equal(t, defaultCacheControl, w.Header().Get("Cache-Control"))
+	assert.Contains(t, w.Header().Get("Content-Type"), "javascript")
+
+	w = get(app, "/")
+	assert.Equal(t, "<html>app</html>", w.Body.String())
+	assert.Equal(t, "no-cache", w.Header().Get("Cache-Control"))
+
+	w = get(app, "/docs/")
+	assert.Equal(t, "<html>docs</html>", w.Body.String())
+
+	// routes win over files, unknown paths are 404 without SPA
+	assert.Equal(t, "pong", get(app, "/api/ping").Body.String())
+	assert.Equal(t, http.StatusNotFound, get(app, "/users/42").Code)
+	assert.Equal(t, http.StatusNotFound, get(app, "/../dist/index.html").Code)
+}
+
+func TestStatic_SPA(t *testing.T) {
+	app := staticApp(Static{
+		FS:           dist,
+		Root:         "dist",
+		SPA:          true,
+		Exclude:      []string{"/api"},
+		CacheControl: "public, max-age=31536000, immutable",
+	})
+
+	w := get(app, "/users/42")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "<html>app</html>", w.Body.String())
+	assert.Equal(t, "no-cache", w.Header().Get("Cache-Control"))
+
+	assert.Equal(t, "public, max-age=31536000, immutable", get(app, "/assets/app.js").Header().Get("Cache-Control"))
+	assert.Equal(t, http.StatusNotFound, get(app, "/assets/missing.js").Code)
+	assert.Equal(t, http.StatusNotFound, get(app, "/api/unknown").Code)
+
+	w = httptest.NewRecorder()
+	app.engine.ServeHTTP(w, httptest.NewRequest("POST", "/users/42", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestStatic_Prefixes(t *testing.T) {
+	app := staticApp(
+		Static{FS: dist, Root: "dist", SPA: true},
+		Static{Prefix: "/admin/", FS: dist, Root: "admin", SPA: true},
+	)
+
+	assert.Equal(t, "<html>admin</html>", get(app, "/admin").Body.String())
+	assert.Equal(t, "<html>admin</html>", get(app, "/admin/settings").Body.String())
+	assert.Equal(t, "body{}", get(app, "/admin/assets/admin.css").Body.String())
+	assert.Equal(t, "<html>app</html>", get(app, "/administrator").Body.String())
+}
@@ -0,0 +1,56 @@
+package ws
+
+import (
+	"context"
+
+	"github.com/BevisDev/godev/redis"
+)
+
+// Broker relays hub messages between instances.
+type Broker interface {
+	// Publish sends payload to every subscriber of channel, including this instance.
+	Publish(ctx context.Context, channel string, payload []byte) error
+
+	// Subscribe calls handler for each payload on channel until ctx is done.
+	// It returns once the subscription is established.
+	Subscribe(ctx context.Context, channel string, handler func(payload []byte)) error
+}
+
+// RedisBroker is a Broker on Redis pub/sub.
+type RedisBroker struct {
+	cache *redis.Cache
+}
+
+// NewRedisBroker creates a Broker using the Redis connection of cache.
+func NewRedisBroker(cache *redis.Cache) *RedisBroker {
+	return &RedisBroker{cache: cache}
+}
+
+func (b *RedisBroker) Publish(ctx context.Context, channel string, payload []byte) error {
+	return b.cache.GetClient().Publish(ctx, channel, payload).Err()
+}
+
+func (b *RedisBroker) Subscribe(ctx context.Context, channel string, handler func(payload []byte)) error {
+	pubsub := b.cache.GetClient().Subscribe(ctx, channel)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		_ = pubsub.Close()
+		return err
+	}
+
+	ch := pubsub.Channel()
+	go func() {
+		defer pubsub.Close()
+		for {
+			select {
+			case msg, ok := <-ch:
+				if !ok {
+					return
+				}
+				handler([]byte(msg.Payload))
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,163 @@
+package ws
+
+import (
+	"context"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Client is a single websocket connection registered in a Hub.
+// Several clients may share the same ID (e.g. one user with two tabs).
+type Client struct {
+	ID string
+
+	hub  *Hub
+	conn *websocket.Conn
+	ctx  context.Context
+	send chan []byte
+	done chan struct{}
+
+	mu     sync.Mutex
+	rooms  map[string]struct{}
+	closed bool
+}
+
+func newClient(h *Hub, ctx context.Context, id string, conn *websocket.Conn) *Client {
+	return &Client{
+		ID:    id,
+		hub:   h,
+		conn:  conn,
+		ctx:   ctx,
+		send:  make(chan []byte, h.sendQueueSize),
+		done:  make(chan struct{}),
+		rooms: make(map[string]struct{}),
+	}
+}
+
+// Context returns the context of the upgrade request (carries RID, user, lang, ...).
+func (c *Client) Context() context.Context {
+	return c.ctx
+}
+
+// Send queues msg without blocking. When the queue is full the client is
+// disconnected and ErrSendQueueFull is returned.
+func (c *Client) Send(msg []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return ErrClientClosed
+	}
+	select {
+	case c.send <- msg:
+		return nil
+	default:
+		c.closeLocked()
+		return ErrSendQueueFull
+	}
+}
+
+// Join adds the client to room.
+func (c *Client) Join(room string) {
+	c.hub.join(c, room)
+}
+
+// Leave removes the client from room.
+func (c *Client) Leave(room string) {
+	c.hub.leave(c, room)
+}
+
+// Rooms returns the rooms the client has joined.
+func (c *Client) Rooms() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	rooms := make([]string, 0, len(c.rooms))
+	for r := range c.rooms {
+		rooms = append(rooms, r)
+	}
+	return rooms
+}
+
+// Close disconnects the client with a normal close frame.
+func (c *Client) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closeLocked()
+}
+
+func (c *Client) closeLocked() {
+	if !c.closed {
+		c.closed = true
+		close(c.done)
+	}
+}
+
+// readPump reads until the connection fails; it owns the read side of conn.
+func (c *Client) readPump() {
+	c.conn.SetReadLimit(c.hub.maxMessageSize)
+	_ = c.conn.SetReadDeadline(time.Now().Add(c.hub.pongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(c.hub.pongWait))
+	})
+
+	for {
+		_, msg, err := c.conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				c.hub.log.Debug("client %s read: %v", c.ID, err)
+			}
+			return
+		}
+		_ = c.conn.SetReadDeadline(time.Now().Add(c.hub.pongWait))
+		if c.hub.onMessage != nil {
+			c.handle(msg)
+		}
+	}
+}
+
+func (c *Client) handle(msg []byte) {
+	defer func() {
+		if r := recover(); r != nil {
+			c.hub.log.Error("[RECOVER] onMessage client %s: %v \npanic: %s", c.ID, r, debug.Stack())
+		}
+	}()
+	c.hub.onMessage(c, msg)
+}
+
+// writePump writes queued messages and pings; it owns the write side of conn.
+func (c *Client) writePump() {
+	ticker := time.NewTicker(c.hub.pingInterval())
+	defer func() {
+		ticker.Stop()
+		_ = c.conn.Close()
+	}()
+
+	for {
+		select {
+		case msg := <-c.send:
+			if err := c.write(websocket.TextMessage, msg); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := c.write(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		case <-c.done:
+			code := websocket.CloseNormalClosure
+			if c.hub.isClosed() {
+				code = websocket.CloseGoingAway
+			}
+			_ = c.write(websocket.CloseMessage, websocket.FormatCloseMessage(code, ""))
+			return
+		}
+	}
+}
+
+func (c *Client) write(messageType int, data []byte) error {
+	_ = c.conn.SetWriteDeadline(time.Now().Add(c.hub.writeTimeout))
+	return c.conn.WriteMessage(messageType, data)
+}
@@ -0,0 +1,11 @@
+package ws
+
+import "errors"
+
+// Errors
+var (
+	ErrHubClosed      = errors.New("[ws] hub is closed")
+	ErrClientClosed   = errors.New("[ws] client is closed")
+	ErrSendQueueFull  = errors.New("[ws] client send queue is full")
+	ErrClientNotFound = errors.New("[ws] client not found")
+)
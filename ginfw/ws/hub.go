@@ -0,0 +1,354 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/BevisDev/godev/utils/console"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+// Hub tracks websocket clients and rooms and fans messages out to them.
+// With a Broker, broadcasts are relayed to the hubs of other instances.
+type Hub struct {
+	*options
+	instanceID string
+	upgrader   websocket.Upgrader
+	log        *console.Logger
+
+	mu      sync.RWMutex
+	clients map[string]map[*Client]struct{} // by client ID
+	rooms   map[string]map[*Client]struct{}
+	closed  bool
+	wg      sync.WaitGroup
+	cancel  context.CancelFunc
+}
+
+func New(opts ...Option) *Hub {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return &Hub{
+		options:    o,
+		instanceID: uuid.NewString(),
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+			CheckOrigin:     o.checkOrigin,
+		},
+		log:     console.New("ws"),
+		clients: make(map[string]map[*Client]struct{}),
+		rooms:   make(map[string]map[*Client]struct{}),
+	}
+}
+
+// Start subscribes to the broker so broadcasts from other instances are delivered locally.
+// It is a no-op without a broker. The subscription ends on Shutdown or when ctx is done.
+func (h *Hub) Start(ctx context.Context) error {
+	if h.broker == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	if err := h.broker.Subscribe(ctx, h.channel, h.receive); err != nil {
+		cancel()
+		return err
+	}
+
+	h.mu.Lock()
+	h.cancel = cancel
+	h.mu.Unlock()
+	return nil
+}
+
+// Handler upgrades the request to a websocket and serves the client until it disconnects.
+//
+// Example:
+//
+//	hub := ws.New(ws.WithIdentify(func(c *gin.Context) (string, error) {
+//		return c.GetString("user_id"), nil
+//	}))
+//	r.GET("/ws", authMiddleware, hub.Handler())
+func (h *Hub) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if h.isClosed() {
+			c.AbortWithStatus(http.StatusServiceUnavailable)
+			return
+		}
+
+		id, err := h.identify(c)
+		if err != nil || id == "" {
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+
+		conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+		if err != nil {
+			// Upgrade has already written the error response
+			h.log.Debug("upgrade: %v", err)
+			return
+		}
+
+		client := newClient(h, c.Request.Context(), id, conn)
+		if !h.register(client) {
+			_ = conn.Close()
+			return
+		}
+		defer h.unregister(client)
+
+		go client.writePump()
+		if h.onConnect != nil {
+			h.onConnect(client)
+		}
+
+		client.readPump()
+		client.Close()
+	}
+}
+
+func (h *Hub) register(c *Client) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return false
+	}
+	set, ok := h.clients[c.ID]
+	if !ok {
+		set = make(map[*Client]struct{})
+		h.clients[c.ID] = set
+	}
+	set[c] = struct{}{}
+	h.wg.Add(1)
+	return true
+}
+
+func (h *Hub) unregister(c *Client) {
+	h.mu.Lock()
+	if set, ok := h.clients[c.ID]; ok {
+		delete(set, c)
+		if len(set) == 0 {
+			delete(h.clients, c.ID)
+		}
+	}
+	c.mu.Lock()
+	for room := range c.rooms {
+		h.removeFromRoom(c, room)
+	}
+	c.rooms = make(map[string]struct{})
+	c.mu.Unlock()
+	h.mu.Unlock()
+
+	if h.onDisconnect != nil {
+		h.onDisconnect(c)
+	}
+	h.wg.Done()
+}
+
+func (h *Hub) join(c *Client, room string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	set, ok := h.rooms[room]
+	if !ok {
+		set = make(map[*Client]struct{})
+		h.rooms[room] = set
+	}
+	set[c] = struct{}{}
+
+	c.mu.Lock()
+	c.rooms[room] = struct{}{}
+	c.mu.Unlock()
+}
+
+func (h *Hub) leave(c *Client, room string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.removeFromRoom(c, room)
+	c.mu.Lock()
+	delete(c.rooms, room)
+	c.mu.Unlock()
+}
+
+// removeFromRoom must be called with h.mu held.
+func (h *Hub) removeFromRoom(c *Client, room string) {
+	if set, ok := h.rooms[room]; ok {
+		delete(set, c)
+		if len(set) == 0 {
+			delete(h.rooms, room)
+		}
+	}
+}
+
+// Count returns the number of connected clients on this instance.
+func (h *Hub) Count() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	n := 0
+	for _, set := range h.clients {
+		n += len(set)
+	}
+	return n
+}
+
+// RoomCount returns the number of clients in room on this instance.
+func (h *Hub) RoomCount(room string) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.rooms[room])
+}
+
+// Broadcast sends msg to every client, on all instances when a broker is configured.
+func (h *Hub) Broadcast(ctx context.Context, msg []byte) error {
+	return h.dispatch(ctx, envelope{Data: msg})
+}
+
+// BroadcastRoom sends msg to every client in room.
+func (h *Hub) BroadcastRoom(ctx context.Context, room string, msg []byte) error {
+	return h.dispatch(ctx, envelope{Room: room, Data: msg})
+}
+
+// SendTo sends msg to every connection of the client ID. Without a broker it returns
+// ErrClientNotFound when the ID is not connected to this instance.
+func (h *Hub) SendTo(ctx context.Context, id string, msg []byte) error {
+	n := h.deliver(envelope{To: id, Data: msg})
+	if h.broker == nil {
+		if n == 0 {
+			return ErrClientNotFound
+		}
+		return nil
+	}
+	return h.publish(ctx, envelope{To: id, Data: msg})
+}
+
+// BroadcastJSON marshals v and broadcasts it.
+func (h *Hub) BroadcastJSON(ctx context.Context, v any) error {
+	msg, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return h.Broadcast(ctx, msg)
+}
+
+// envelope is the message relayed between instances.
+type envelope struct {
+	Origin string `json:"origin"`
+	Room   string `json:"room,omitempty"`
+	To     string `json:"to,omitempty"`
+	Data   []byte `json:"data"`
+}
+
+func (h *Hub) dispatch(ctx context.Context, env envelope) error {
+	if h.isClosed() {
+		return ErrHubClosed
+	}
+	h.deliver(env)
+	if h.broker == nil {
+		return nil
+	}
+	return h.publish(ctx, env)
+}
+
+func (h *Hub) publish(ctx context.Context, env envelope) error {
+	env.Origin = h.instanceID
+	payload, err := json.Marshal(env)
+	if err != nil {
+		return err
+	}
+	return h.broker.Publish(ctx, h.channel, payload)
+}
+
+// receive handles a message from the broker; messages published by this instance were already delivered.
+func (h *Hub) receive(payload []byte) {
+	var env envelope
+	if err := json.Unmarshal(payload, &env); err != nil {
+		h.log.Error("invalid broker message: %v", err)
+		return
+	}
+	if env.Origin == h.instanceID {
+		return
+	}
+	h.deliver(env)
+}
+
+// deliver sends env to the matching local clients and returns how many were targeted.
+func (h *Hub) deliver(env envelope) int {
+	h.mu.RLock()
+	var targets []*Client
+	switch {
+	case env.To != "":
+		targets = collect(h.clients[env.To])
+	case env.Room != "":
+		targets = collect(h.rooms[env.Room])
+	default:
+		for _, set := range h.clients {
+			targets = append(targets, collect(set)...)
+		}
+	}
+	h.mu.RUnlock()
+
+	for _, c := range targets {
+		if err := c.Send(env.Data); err != nil && err != ErrClientClosed {
+			h.log.Info("client %s dropped: %v", c.ID, err)
+		}
+	}
+	return len(targets)
+}
+
+func collect(set map[*Client]struct{}) []*Client {
+	out := make([]*Client, 0, len(set))
+	for c := range set {
+		out = append(out, c)
+	}
+	return out
+}
+
+func (h *Hub) isClosed() bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.closed
+}
+
+// Shutdown stops accepting connections, closes every client with "going away"
+// and waits until they are gone or ctx is done.
+func (h *Hub) Shutdown(ctx context.Context) error {
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		return nil
+	}
+	h.closed = true
+	if h.cancel != nil {
+		h.cancel()
+	}
+	var all []*Client
+	for _, set := range h.clients {
+		all = append(all, collect(set)...)
+	}
+	h.mu.Unlock()
+
+	for _, c := range all {
+		c.Close()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		h.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,193 @@
+package ws
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T, h *Hub) string {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/ws", h.Handler())
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+	return "ws" + strings.TrimPrefix(srv.URL, "http") + "/ws"
+}
+
+func dial(t *testing.T, url string) *websocket.Conn {
+	t.Helper()
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+func read(t *testing.T, conn *websocket.Conn) string {
+	t.Helper()
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	_, msg, err := conn.ReadMessage()
+	require.NoError(t, err)
+	return string(msg)
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	require.Eventually(t, cond, 2*time.Second, 5*time.Millisecond)
+}
+
+func testHub(opts ...Option) *Hub {
+	opts = append([]Option{
+		WithIdentify(func(c *gin.Context) (string, error) {
+			if id := c.Query("id"); id != "" {
+				return id, nil
+			}
+			return "", errors.New("missing id")
+		}),
+	}, opts...)
+	return New(opts...)
+}
+
+type roomKey struct{}
+
+func TestHub_Broadcast(t *testing.T) {
+	h := testHub(WithOnConnect(func(c *Client) {
+		if room, _ := c.Context().Value(roomKey{}).(string); room != "" {
+			c.Join(room)
+		}
+	}))
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/ws", func(c *gin.Context) {
+		ctx := context.WithValue(c.Request.Context(), roomKey{}, c.Query("room"))
+		c.Request = c.Request.WithContext(ctx)
+	}, h.Handler())
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/ws"
+
+	alice := dial(t, url+"?id=alice&room=orders")
+	bob := dial(t, url+"?id=bob")
+	waitFor(t, func() bool { return h.Count() == 2 && h.RoomCount("orders") == 1 })
+
+	require.NoError(t, h.Broadcast(context.Background(), []byte("hello all")))
+	assert.Equal(t, "hello all", read(t, alice))
+	assert.Equal(t, "hello all", read(t, bob))
+
+	require.NoError(t, h.BroadcastRoom(context.Background(), "orders", []byte("order #1")))
+	require.NoError(t, h.SendTo(context.Background(), "bob", []byte("hi bob")))
+	assert.Equal(t, "order #1", read(t, alice))
+	assert.Equal(t, "hi bob", read(t, bob))
+
+	assert.ErrorIs(t, h.SendTo(context.Background(), "carol", []byte("x")), ErrClientNotFound)
+
+	_ = alice.Close()
+	waitFor(t, func() bool { return h.Count() == 1 && h.RoomCount("orders") == 0 })
+}
+
+func TestHub_OnMessageAndReject(t *testing.T) {
+	h := testHub(WithOnMessage(func(c *Client, msg []byte) {
+		_ = c.Send(append([]byte("echo: "), msg...))
+	}))
+	url := newTestServer(t, h)
+
+	_, resp, err := websocket.DefaultDialer.Dial(url, nil)
+	require.Error(t, err)
+	assert.Equal(t, 401, resp.StatusCode)
+
+	conn := dial(t, url+"?id=alice")
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte("ping")))
+	assert.Equal(t, "echo: ping", read(t, conn))
+}
+
+func TestHub_Shutdown(t *testing.T) {
+	var mu sync.Mutex
+	var disconnected []string
+	h := testHub(WithOnDisconnect(func(c *Client) {
+		mu.Lock()
+		disconnected = append(disconnected, c.ID)
+		mu.Unlock()
+	}))
+	url := newTestServer(t, h)
+
+	conn := dial(t, url+"?id=alice")
+	waitFor(t, func() bool { return h.Count() == 1 })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	require.NoError(t, h.Shutdown(ctx))
+
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	_, _, err := conn.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, websocket.CloseGoingAway), "got %v", err)
+	assert.Equal(t, 0, h.Count())
+	assert.Equal(t, []string{"alice"}, disconnected)
+	assert.ErrorIs(t, h.Broadcast(context.Background(), []byte("x")), ErrHubClosed)
+}
+
+// memBroker is an in-process Broker shared by several hubs.
+type memBroker struct {
+	mu   sync.Mutex
+	subs []func([]byte)
+}
+
+func (b *memBroker) Publish(ctx context.Context, channel string, payload []byte) error {
+	b.mu.Lock()
+	subs := append([]func([]byte){}, b.subs...)
+	b.mu.Unlock()
+	for _, fn := range subs {
+		fn(payload)
+	}
+	return nil
+}
+
+func (b *memBroker) Subscribe(ctx context.Context, channel string, handler func([]byte)) error {
+	b.mu.Lock()
+	b.subs = append(b.subs, handler)
+	b.mu.Unlock()
+	return nil
+}
+
+func TestHub_BrokerBridge(t *testing.T) {
+	broker := &memBroker{}
+	h1, h2 := testHub(WithBroker(broker)), testHub(WithBroker(broker))
+	require.NoError(t, h1.Start(context.Background()))
+	require.NoError(t, h2.Start(context.Background()))
+
+	c1 := dial(t, newTestServer(t, h1)+"?id=alice")
+	c2 := dial(t, newTestServer(t, h2)+"?id=bob")
+	waitFor(t, func() bool { return h1.Count() == 1 && h2.Count() == 1 })
+
+	require.NoError(t, h1.Broadcast(context.Background(), []byte("from h1")))
+	assert.Equal(t, "from h1", read(t, c1))
+	assert.Equal(t, "from h1", read(t, c2))
+
+	require.NoError(t, h1.SendTo(context.Background(), "bob", []byte("to bob")))
+	assert.Equal(t, "to bob", read(t, c2))
+
+	// the origin instance must not deliver twice
+	require.NoError(t, h2.Broadcast(context.Background(), []byte("second")))
+	require.NoError(t, h2.Broadcast(context.Background(), []byte("third")))
+	assert.Equal(t, "second", read(t, c1))
+	assert.Equal(t, "second", read(t, c2))
+	assert.Equal(t, "third", read(t, c2))
+}
+
+func TestClient_SendQueueFull(t *testing.T) {
+	h := New(WithSendQueueSize(1))
+	c := newClient(h, context.Background(), "slow", nil)
+
+	require.NoError(t, c.Send([]byte("1")))
+	assert.ErrorIs(t, c.Send([]byte("2")), ErrSendQueueFull)
+	assert.ErrorIs(t, c.Send([]byte("3")), ErrClientClosed)
+}
@@ -0,0 +1,135 @@
+package ws
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Option func(*options)
+
+type options struct {
+	pongWait       time.Duration
+	writeTimeout   time.Duration
+	maxMessageSize int64
+	sendQueueSize  int
+	channel        string
+	checkOrigin    func(r *http.Request) bool
+	identify       func(c *gin.Context) (string, error)
+	onConnect      func(c *Client)
+	onMessage      func(c *Client, msg []byte)
+	onDisconnect   func(c *Client)
+	broker         Broker
+}
+
+func defaultOptions() *options {
+	return &options{
+		pongWait:       60 * time.Second,
+		writeTimeout:   10 * time.Second,
+		maxMessageSize: 64 << 10,
+		sendQueueSize:  256,
+		channel:        "ws:hub",
+		identify: func(*gin.Context) (string, error) {
+			return uuid.NewString(), nil
+		},
+	}
+}
+
+// pingInterval is how often pings are sent; it must be shorter than pongWait.
+func (o *options) pingInterval() time.Duration {
+	return o.pongWait * 9 / 10
+}
+
+// WithPongWait sets how long to wait for a pong (or any message) before the
+// connection is considered dead (default 60s). Pings are sent at 90% of it.
+func WithPongWait(d time.Duration) Option {
+	return func(o *options) {
+		if d > 0 {
+			o.pongWait = d
+		}
+	}
+}
+
+// WithWriteTimeout sets the deadline of a single write (default 10s).
+func WithWriteTimeout(d time.Duration) Option {
+	return func(o *options) {
+		if d > 0 {
+			o.writeTimeout = d
+		}
+	}
+}
+
+// WithMaxMessageSize limits the size of incoming messages (default 64KB).
+func WithMaxMessageSize(n int64) Option {
+	return func(o *options) {
+		if n > 0 {
+			o.maxMessageSize = n
+		}
+	}
+}
+
+// WithSendQueueSize sets the per-client send queue (default 256).
+// A client whose queue is full is disconnected as too slow.
+func WithSendQueueSize(n int) Option {
+	return func(o *options) {
+		if n > 0 {
+			o.sendQueueSize = n
+		}
+	}
+}
+
+// WithCheckOrigin overrides the origin check of the upgrade (default: same origin).
+func WithCheckOrigin(fn func(r *http.Request) bool) Option {
+	return func(o *options) {
+		o.checkOrigin = fn
+	}
+}
+
+// WithIdentify resolves the client ID from the request, e.g. the authenticated user ID.
+// Returning an error rejects the upgrade with 401. Default: a random UUID.
+func WithIdentify(fn func(c *gin.Context) (string, error)) Option {
+	return func(o *options) {
+		if fn != nil {
+			o.identify = fn
+		}
+	}
+}
+
+// WithOnConnect is called after a client is registered, e.g. to join rooms.
+func WithOnConnect(fn func(c *Client)) Option {
+	return func(o *options) {
+		o.onConnect = fn
+	}
+}
+
+// WithOnMessage is called for every message received from a client.
+func WithOnMessage(fn func(c *Client, msg []byte)) Option {
+	return func(o *options) {
+		o.onMessage = fn
+	}
+}
+
+// WithOnDisconnect is called after a client is unregistered.
+func WithOnDisconnect(fn func(c *Client)) Option {
+	return func(o *options) {
+		o.onDisconnect = fn
+	}
+}
+
+// WithBroker bridges broadcasts across instances through b (e.g. NewRedisBroker).
+func WithBroker(b Broker) Option {
+	return func(o *options) {
+		o.broker = b
+	}
+}
+
+// WithChannel sets the broker channel shared by all instances (default "ws:hub").
+func WithChannel(channel string) Option {
+	return func(o *options) {
+		if channel != "" {
+			o.channel = channel
+		}
+	}
+}
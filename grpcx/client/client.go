@@ -0,0 +1,244 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/BevisDev/godev/utils/console"
+	"github.com/BevisDev/godev/utils/propagation"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ErrNilConfig   = errors.New("[grpcx/client] config is nil")
+	ErrEmptyTarget = errors.New("[grpcx/client] target is empty")
+	ErrPoolClosed  = errors.New("[grpcx/client] pool closed")
+)
+
+// New creates a client connection to cfg.Target with keepalive, retries on
+// cfg.RetryableCodes, default deadlines, request ID / trace propagation and
+// logging. The connection is lazy: it connects on the first call.
+func New(cfg *Config) (*grpc.ClientConn, error) {
+	if cfg == nil {
+		return nil, ErrNilConfig
+	}
+	if cfg.Target == "" {
+		return nil, ErrEmptyTarget
+	}
+	c := cfg.clone()
+
+	opts, err := dialOptions(c)
+	if err != nil {
+		return nil, err
+	}
+	return grpc.NewClient(c.Target, opts...)
+}
+
+func dialOptions(c *Config) ([]grpc.DialOption, error) {
+	creds := insecure.NewCredentials()
+	if !c.Insecure {
+		creds = credentials.NewTLS(c.TLS)
+	}
+
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                c.KeepaliveTime,
+			Timeout:             c.KeepaliveTimeout,
+			PermitWithoutStream: c.PermitWithoutStream,
+		}),
+	}
+
+	if c.MaxAttempts > 1 {
+		sc, err := serviceConfig(c)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.WithDefaultServiceConfig(sc))
+	}
+
+	log := console.New("grpcx/client")
+	unary := []grpc.UnaryClientInterceptor{timeoutUnary(c), propagateUnary}
+	stream := []grpc.StreamClientInterceptor{propagateStream}
+	if !c.DisableLogging {
+		unary = append(unary, logUnary(log))
+	}
+	unary = append(unary, c.UnaryInterceptors...)
+	stream = append(stream, c.StreamInterceptors...)
+
+	opts = append(opts,
+		grpc.WithChainUnaryInterceptor(unary...),
+		grpc.WithChainStreamInterceptor(stream...),
+	)
+	return append(opts, c.DialOptions...), nil
+}
+
+// serviceConfig returns the service config enabling gRPC built-in retries for every method.
+func serviceConfig(c *Config) (string, error) {
+	retryable := make([]uint32, len(c.RetryableCodes))
+	for i, code := range c.RetryableCodes {
+		retryable[i] = uint32(code)
+	}
+
+	sc := map[string]any{
+		"methodConfig": []any{map[string]any{
+			"name": []any{map[string]any{}},
+			"retryPolicy": map[string]any{
+				"maxAttempts":          c.MaxAttempts,
+				"initialBackoff":       seconds(c.InitialBackoff),
+				"maxBackoff":           seconds(c.MaxBackoff),
+				"backoffMultiplier":    2,
+				"retryableStatusCodes": retryable,
+			},
+		}},
+	}
+
+	b, err := json.Marshal(sc)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%.9fs", d.Seconds())
+}
+
+// timeoutUnary sets the configured deadline on calls whose context has none.
+func timeoutUnary(c *Config) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any,
+		cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption,
+	) error {
+		if _, ok := ctx.Deadline(); !ok {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, c.timeout(method))
+			defer cancel()
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+func (c *Config) timeout(method string) time.Duration {
+	if d, ok := c.MethodTimeouts[method]; ok {
+		return d
+	}
+	if i := strings.LastIndex(method, "/"); i > 0 {
+		if d, ok := c.MethodTimeouts[method[:i+1]]; ok {
+			return d
+		}
+	}
+	return c.Timeout
+}
+
+// outgoing adds the request ID and trace headers of ctx (see utils/propagation)
+// to the outgoing metadata, keeping keys already set.
+func outgoing(ctx context.Context) context.Context {
+	h := propagation.Headers(ctx)
+	if len(h) == 0 {
+		return ctx
+	}
+
+	md, _ := metadata.FromOutgoingContext(ctx)
+	md = md.Copy()
+	for k, v := range h {
+		key := strings.ToLower(k)
+		if len(md.Get(key)) == 0 && len(v) > 0 {
+			md.Set(key, v...)
+		}
+	}
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+func propagateUnary(ctx context.Context, method string, req, reply any,
+	cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption,
+) error {
+	return invoker(outgoing(ctx), method, req, reply, cc, opts...)
+}
+
+func propagateStream(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn,
+	method string, streamer grpc.Streamer, opts ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	return streamer(outgoing(ctx), desc, cc, method, opts...)
+}
+
+// logUnary logs failed calls with their status code and duration.
+func logUnary(log *console.Logger) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any,
+		cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption,
+	) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if err != nil {
+			log.Error("%s %s code=%s duration=%s: %s",
+				cc.Target(), method, status.Code(err), time.Since(start), status.Convert(err).Message(),
+			)
+		} else {
+			log.Debug("%s %s code=OK duration=%s", cc.Target(), method, time.Since(start))
+		}
+		return err
+	}
+}
+
+// Pool shares one connection per target, all built from the same base Config.
+type Pool struct {
+	base   *Config
+	mu     sync.Mutex
+	conns  map[string]*grpc.ClientConn
+	closed bool
+}
+
+// NewPool returns a pool creating connections from base, its Target ignored.
+func NewPool(base *Config) *Pool {
+	if base == nil {
+		base = &Config{}
+	}
+	return &Pool{base: base.clone(), conns: make(map[string]*grpc.ClientConn)}
+}
+
+// Get returns the connection to target, creating it on first use.
+func (p *Pool) Get(target string) (*grpc.ClientConn, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return nil, ErrPoolClosed
+	}
+	if conn, ok := p.conns[target]; ok {
+		return conn, nil
+	}
+
+	cfg := *p.base
+	cfg.Target = target
+	conn, err := New(&cfg)
+	if err != nil {
+		return nil, err
+	}
+	p.conns[target] = conn
+	return conn, nil
+}
+
+// Close closes every connection of the pool.
+func (p *Pool) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.closed = true
+	var errs []error
+	for target, conn := range p.conns {
+		if err := conn.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("[grpcx/client] close %s: %w", target, err))
+		}
+		delete(p.conns, target)
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,150 @@
+package client
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/BevisDev/godev/utils/ctxmeta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type testServer struct {
+	lis      *bufconn.Listener
+	calls    atomic.Int32
+	failures int32
+	md       metadata.MD
+	deadline time.Duration
+}
+
+// newTestServer serves every method, failing the first failures calls with UNAVAILABLE.
+func newTestServer(t *testing.T, failures int32) *testServer {
+	t.Helper()
+
+	ts := &testServer{lis: bufconn.Listen(1 << 20), failures: failures}
+	s := grpc.NewServer(grpc.UnknownServiceHandler(func(_ any, stream grpc.ServerStream) error {
+		n := ts.calls.Add(1)
+		ts.md, _ = metadata.FromIncomingContext(stream.Context())
+		if d, ok := stream.Context().Deadline(); ok {
+			ts.deadline = time.Until(d)
+		}
+
+		var req emptypb.Empty
+		if err := stream.RecvMsg(&req); err != nil {
+			return err
+		}
+		if n <= ts.failures {
+			return status.Error(codes.Unavailable, "not ready")
+		}
+		return stream.SendMsg(&emptypb.Empty{})
+	}))
+	go func() { _ = s.Serve(ts.lis) }()
+	t.Cleanup(s.Stop)
+
+	return ts
+}
+
+func (ts *testServer) config() *Config {
+	return &Config{
+		Target:         "passthrough:///bufnet",
+		Insecure:       true,
+		InitialBackoff: time.Millisecond,
+		DialOptions: []grpc.DialOption{
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+				return ts.lis.DialContext(ctx)
+			}),
+		},
+	}
+}
+
+func invoke(ctx context.Context, conn *grpc.ClientConn, method string) error {
+	return conn.Invoke(ctx, method, &emptypb.Empty{}, &emptypb.Empty{})
+}
+
+func TestNew_Validation(t *testing.T) {
+	_, err := New(nil)
+	assert.ErrorIs(t, err, ErrNilConfig)
+
+	_, err = New(&Config{})
+	assert.ErrorIs(t, err, ErrEmptyTarget)
+}
+
+func TestNew_RetriesUnavailable(t *testing.T) {
+	ts := newTestServer(t, 2)
+	conn, err := New(ts.config())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	require.NoError(t, invoke(context.Background(), conn, "/test.Svc/Get"))
+	assert.Equal(t, int32(3), ts.calls.Load())
+}
+
+func TestNew_RetriesExhausted(t *testing.T) {
+	ts := newTestServer(t, 5)
+	cfg := ts.config()
+	cfg.MaxAttempts = 2
+	conn, err := New(cfg)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	err = invoke(context.Background(), conn, "/test.Svc/Get")
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, int32(2), ts.calls.Load())
+}
+
+func TestNew_NoRetry(t *testing.T) {
+	ts := newTestServer(t, 1)
+	cfg := ts.config()
+	cfg.MaxAttempts = 1
+	conn, err := New(cfg)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	assert.Error(t, invoke(context.Background(), conn, "/test.Svc/Get"))
+	assert.Equal(t, int32(1), ts.calls.Load())
+}
+
+func TestNew_DeadlineAndPropagation(t *testing.T) {
+	ts := newTestServer(t, 0)
+	cfg := ts.config()
+	cfg.Timeout = time.Minute
+	cfg.MethodTimeouts = map[string]time.Duration{"/test.Slow/": 30 * time.Second}
+	conn, err := New(cfg)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ctx := ctxmeta.WithRID(context.Background(), "rid-1")
+	require.NoError(t, invoke(ctx, conn, "/test.Svc/Get"))
+	assert.Equal(t, []string{"rid-1"}, ts.md.Get("x-request-id"))
+	assert.InDelta(t, time.Minute.Seconds(), ts.deadline.Seconds(), 5)
+
+	ctx = metadata.AppendToOutgoingContext(ctx, "x-request-id", "explicit")
+	require.NoError(t, invoke(ctx, conn, "/test.Slow/Get"))
+	assert.Equal(t, []string{"explicit"}, ts.md.Get("x-request-id"))
+	assert.InDelta(t, 30, ts.deadline.Seconds(), 5)
+}
+
+func TestPool(t *testing.T) {
+	ts := newTestServer(t, 0)
+	p := NewPool(ts.config())
+
+	a, err := p.Get("passthrough:///bufnet")
+	require.NoError(t, err)
+	b, err := p.Get("passthrough:///bufnet")
+	require.NoError(t, err)
+	assert.Same(t, a, b)
+	require.NoError(t, invoke(context.Background(), a, "/test.Svc/Get"))
+
+	require.NoError(t, p.Close())
+	_, err = p.Get("passthrough:///bufnet")
+	assert.ErrorIs(t, err, ErrPoolClosed)
+}
@@ -0,0 +1,117 @@
+package client
+
+import (
+	"crypto/tls"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// Defaults applied by Config.clone when fields are zero.
+const (
+	defaultTimeout          = 10 * time.Second
+	defaultKeepaliveTime    = 5 * time.Minute
+	defaultKeepaliveTimeout = 20 * time.Second
+	defaultMaxAttempts      = 3
+	defaultInitialBackoff   = 100 * time.Millisecond
+	defaultMaxBackoff       = 2 * time.Second
+)
+
+// Config defines the configuration of a gRPC client connection.
+type Config struct {
+	// Target is the server address, e.g. "orders:9090" or "dns:///orders:9090".
+	Target string
+
+	// Insecure disables transport security (plaintext), for local or mesh traffic.
+	Insecure bool
+
+	// TLS is the transport security config when Insecure is false.
+	// Nil uses the system roots.
+	TLS *tls.Config
+
+	// Timeout is the deadline of calls whose context has none.
+	Timeout time.Duration
+
+	// MethodTimeouts overrides Timeout per full method ("/pkg.Service/Method")
+	// or per service ("/pkg.Service/").
+	MethodTimeouts map[string]time.Duration
+
+	// KeepaliveTime is the idle time before the client pings the server.
+	// Servers reject pings more frequent than their enforcement policy
+	// (5m by default in grpc-go), so lower it only together with the server's.
+	KeepaliveTime time.Duration
+
+	// KeepaliveTimeout is how long to wait for a ping ack before closing the connection.
+	KeepaliveTimeout time.Duration
+
+	// PermitWithoutStream sends keepalive pings without active calls.
+	PermitWithoutStream bool
+
+	// MaxAttempts is the number of attempts of a call, including the first one
+	// (gRPC caps it at 5). 1 disables retries.
+	MaxAttempts int
+
+	// InitialBackoff and MaxBackoff bound the exponential backoff between attempts.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+
+	// RetryableCodes are the status codes that are retried (default UNAVAILABLE).
+	RetryableCodes []codes.Code
+
+	// DisableLogging turns off the logging interceptor.
+	DisableLogging bool
+
+	// UnaryInterceptors are chained after the built-in ones, in the provided order.
+	UnaryInterceptors []grpc.UnaryClientInterceptor
+
+	// StreamInterceptors are chained after the built-in ones, in the provided order.
+	StreamInterceptors []grpc.StreamClientInterceptor
+
+	// DialOptions are passed directly to grpc.NewClient.
+	DialOptions []grpc.DialOption
+}
+
+func (c *Config) clone() *Config {
+	cc := *c
+	if cc.Timeout <= 0 {
+		cc.Timeout = defaultTimeout
+	}
+	if cc.KeepaliveTime <= 0 {
+		cc.KeepaliveTime = defaultKeepaliveTime
+	}
+	if cc.KeepaliveTimeout <= 0 {
+		cc.KeepaliveTimeout = defaultKeepaliveTimeout
+	}
+	if cc.MaxAttempts <= 0 {
+		cc.MaxAttempts = defaultMaxAttempts
+	}
+	if cc.InitialBackoff <= 0 {
+		cc.InitialBackoff = defaultInitialBackoff
+	}
+	if cc.MaxBackoff <= 0 {
+		cc.MaxBackoff = defaultMaxBackoff
+	}
+	if len(cc.RetryableCodes) == 0 {
+		cc.RetryableCodes = []codes.Code{codes.Unavailable}
+	}
+
+	if len(cc.MethodTimeouts) > 0 {
+		m := make(map[string]time.Duration, len(cc.MethodTimeouts))
+		for k, v := range cc.MethodTimeouts {
+			m[k] = v
+		}
+		cc.MethodTimeouts = m
+	}
+	if len(cc.UnaryInterceptors) > 0 {
+		cc.UnaryInterceptors = append([]grpc.UnaryClientInterceptor(nil), cc.UnaryInterceptors...)
+	}
+	if len(cc.StreamInterceptors) > 0 {
+		cc.StreamInterceptors = append([]grpc.StreamClientInterceptor(nil), cc.StreamInterceptors...)
+	}
+	if len(cc.DialOptions) > 0 {
+		cc.DialOptions = append([]grpc.DialOption(nil), cc.DialOptions...)
+	}
+
+	return &cc
+}
@@ -0,0 +1,11 @@
+package healthcheck
+
+import "errors"
+
+var (
+	// ErrTimeout is reported when a check does not finish within its timeout.
+	ErrTimeout = errors.New("[healthcheck] check timed out")
+
+	// ErrPanic is reported when a check panics.
+	ErrPanic = errors.New("[healthcheck] check panicked")
+)
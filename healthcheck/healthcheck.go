@@ -0,0 +1,193 @@
+// Package healthcheck runs named dependency checks with timeouts, criticality and
+// result caching, and reports them as a Report serializable for /readyz.
+package healthcheck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/BevisDev/godev/utils/console"
+)
+
+// CheckFunc checks a dependency. Return nil if OK, otherwise an error.
+type CheckFunc func(ctx context.Context) error
+
+// Registry holds the checks of a service. It is safe for concurrent use.
+type Registry struct {
+	*options
+	log    *console.Logger
+	mu     sync.RWMutex
+	checks []*check
+}
+
+type check struct {
+	name     string
+	fn       CheckFunc
+	timeout  time.Duration
+	cacheTTL time.Duration
+	critical bool
+
+	// mu serializes runs, so concurrent probes share one cached result
+	mu   sync.Mutex
+	last *Result
+}
+
+// New creates an empty registry.
+func New(opts ...Option) *Registry {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &Registry{
+		options: o,
+		log:     console.New("healthcheck"),
+	}
+}
+
+// Register adds a check, critical unless Soft is given. A check with the same name is replaced.
+func (r *Registry) Register(name string, fn CheckFunc, opts ...CheckOption) {
+	if name == "" || fn == nil {
+		return
+	}
+
+	c := &check{
+		name:     name,
+		fn:       fn,
+		timeout:  r.timeout,
+		cacheTTL: r.cacheTTL,
+		critical: true,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, existing := range r.checks {
+		if existing.name == name {
+			r.checks[i] = c
+			return
+		}
+	}
+	r.checks = append(r.checks, c)
+}
+
+// Unregister removes the check with the given name.
+func (r *Registry) Unregister(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, c := range r.checks {
+		if c.name == name {
+			r.checks = append(r.checks[:i], r.checks[i+1:]...)
+			return
+		}
+	}
+}
+
+// Check runs every check concurrently, reusing results within their cache TTL.
+func (r *Registry) Check(ctx context.Context) *Report {
+	r.mu.RLock()
+	checks := append([]*check(nil), r.checks...)
+	r.mu.RUnlock()
+
+	results := make([]Result, len(checks))
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func(i int, c *check) {
+			defer wg.Done()
+			results[i] = r.run(ctx, c)
+		}(i, c)
+	}
+	wg.Wait()
+
+	report := &Report{
+		Status:    StatusUp,
+		Checks:    make(map[string]Result, len(checks)),
+		CheckedAt: time.Now(),
+	}
+	for i, c := range checks {
+		res := results[i]
+		report.Checks[c.name] = res
+		if res.Status == StatusUp {
+			continue
+		}
+		if res.Critical {
+			report.Status = StatusDown
+		} else if report.Status == StatusUp {
+			report.Status = StatusDegraded
+		}
+	}
+	return report
+}
+
+func (r *Registry) run(ctx context.Context, c *check) Result {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.last != nil && c.cacheTTL > 0 && time.Since(c.last.CheckedAt) < c.cacheTTL {
+		res := *c.last
+		res.Cached = true
+		return res
+	}
+
+	start := time.Now()
+	err := r.call(ctx, c)
+
+	res := Result{
+		Status:    StatusUp,
+		Critical:  c.critical,
+		Duration:  Millis(time.Since(start)),
+		CheckedAt: start,
+	}
+	if err != nil {
+		res.Status = StatusDown
+		res.Error = err.Error()
+	}
+	c.last = &res
+	return res
+}
+
+// call runs the check with its timeout, returning ErrTimeout when it does not return in time.
+func (r *Registry) call(c context.Context, chk *check) error {
+	ctx, cancel := context.WithTimeout(c, chk.timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if rec := recover(); rec != nil {
+				r.log.Error("[RECOVER] health check %s: %v \npanic: %s", chk.name, rec, debug.Stack())
+				done <- fmt.Errorf("%w: %v", ErrPanic, rec)
+			}
+		}()
+		done <- chk.fn(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		if c.Err() != nil {
+			return c.Err()
+		}
+		return ErrTimeout
+	}
+}
+
+// Handler serves the report as JSON, with status 503 when it is down, e.g. for /readyz.
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		report := r.Check(req.Context())
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(report.HTTPStatus())
+		_ = json.NewEncoder(w).Encode(report)
+	})
+}
@@ -0,0 +1,105 @@
+package healthcheck
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ok(ctx context.Context) error { return nil }
+
+func TestRegistry_Check_Status(t *testing.T) {
+	r := New()
+	r.Register("db", ok)
+	r.Register("cache", func(ctx context.Context) error { return errors.New("connection refused") }, Soft())
+
+	report := r.Check(context.Background())
+	assert.Equal(t, StatusDegraded, report.Status)
+	assert.True(t, report.Healthy())
+	assert.Equal(t, http.StatusOK, report.HTTPStatus())
+	assert.Equal(t, []string{"cache"}, report.Failed())
+	assert.Equal(t, "connection refused", report.Checks["cache"].Error)
+	assert.False(t, report.Checks["cache"].Critical)
+
+	r.Register("db", func(ctx context.Context) error { return errors.New("down") })
+	report = r.Check(context.Background())
+	assert.Equal(t, StatusDown, report.Status)
+	assert.Equal(t, http.StatusServiceUnavailable, report.HTTPStatus())
+	assert.Equal(t, []string{"cache", "db"}, report.Failed())
+
+	r.Unregister("db")
+	r.Unregister("cache")
+	assert.Equal(t, StatusUp, r.Check(context.Background()).Status)
+}
+
+func TestRegistry_Check_Timeout(t *testing.T) {
+	r := New(WithTimeout(time.Second))
+	r.Register("slow", func(ctx context.Context) error {
+		time.Sleep(time.Second) // ignores ctx
+		return nil
+	}, Timeout(20*time.Millisecond))
+
+	start := time.Now()
+	report := r.Check(context.Background())
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	assert.Equal(t, ErrTimeout.Error(), report.Checks["slow"].Error)
+}
+
+func TestRegistry_Check_Panic(t *testing.T) {
+	r := New()
+	r.Register("broken", func(ctx context.Context) error { panic("boom") })
+
+	report := r.Check(context.Background())
+	assert.Equal(t, StatusDown, report.Status)
+	assert.Contains(t, report.Checks["broken"].Error, "boom")
+}
+
+func TestRegistry_Check_Cache(t *testing.T) {
+	var calls atomic.Int32
+	r := New(WithCacheTTL(time.Hour))
+	r.Register("db", func(ctx context.Context) error {
+		calls.Add(1)
+		return nil
+	})
+	r.Register("api", func(ctx context.Context) error {
+		calls.Add(1)
+		return nil
+	}, CacheTTL(0))
+
+	first := r.Check(context.Background())
+	second := r.Check(context.Background())
+
+	assert.EqualValues(t, 3, calls.Load())
+	assert.False(t, first.Checks["db"].Cached)
+	assert.True(t, second.Checks["db"].Cached)
+	assert.False(t, second.Checks["api"].Cached)
+	assert.Equal(t, first.Checks["db"].CheckedAt, second.Checks["db"].CheckedAt)
+}
+
+func TestRegistry_Handler(t *testing.T) {
+	r := New()
+	r.Register("db", func(ctx context.Context) error { return errors.New("down") })
+
+	rec := httptest.NewRecorder()
+	r.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "DOWN", body["status"])
+
+	db := body["checks"].(map[string]any)["db"].(map[string]any)
+	assert.Equal(t, "DOWN", db["status"])
+	assert.Equal(t, "down", db["error"])
+	assert.Equal(t, true, db["critical"])
+	assert.IsType(t, float64(0), db["duration_ms"])
+}
@@ -0,0 +1,66 @@
+package healthcheck
+
+import "time"
+
+const (
+	defaultTimeout = 5 * time.Second
+)
+
+// Option configures a Registry.
+type Option func(*options)
+
+type options struct {
+	timeout  time.Duration
+	cacheTTL time.Duration
+}
+
+func defaultOptions() *options {
+	return &options{timeout: defaultTimeout}
+}
+
+// WithTimeout sets the default timeout of a check (default 5s).
+func WithTimeout(d time.Duration) Option {
+	return func(o *options) {
+		if d > 0 {
+			o.timeout = d
+		}
+	}
+}
+
+// WithCacheTTL sets how long check results are reused by default (default 0: no caching).
+func WithCacheTTL(d time.Duration) Option {
+	return func(o *options) {
+		if d >= 0 {
+			o.cacheTTL = d
+		}
+	}
+}
+
+// CheckOption configures one check.
+type CheckOption func(*check)
+
+// Timeout sets the timeout of the check, overriding WithTimeout.
+func Timeout(d time.Duration) CheckOption {
+	return func(c *check) {
+		if d > 0 {
+			c.timeout = d
+		}
+	}
+}
+
+// CacheTTL reuses the result of the check for d, overriding WithCacheTTL,
+// so frequent probes do not hit the dependency each time.
+func CacheTTL(d time.Duration) CheckOption {
+	return func(c *check) {
+		if d >= 0 {
+			c.cacheTTL = d
+		}
+	}
+}
+
+// Soft marks the check non-critical: its failure makes the report degraded, not down.
+func Soft() CheckOption {
+	return func(c *check) {
+		c.critical = false
+	}
+}
@@ -0,0 +1,82 @@
+package healthcheck
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Status is the health of a check or of a whole report.
+type Status string
+
+const (
+	StatusUp Status = "UP"
+
+	// StatusDegraded: only soft checks failed.
+	StatusDegraded Status = "DEGRADED"
+
+	// StatusDown: a critical check failed.
+	StatusDown Status = "DOWN"
+)
+
+// Result is the outcome of one check.
+type Result struct {
+	Status    Status    `json:"status"`
+	Critical  bool      `json:"critical"`
+	Error     string    `json:"error,omitempty"`
+	Duration  Millis    `json:"duration_ms"`
+	CheckedAt time.Time `json:"checked_at"`
+
+	// Cached is set when the result was reused within the cache TTL of the check.
+	Cached bool `json:"cached,omitempty"`
+}
+
+// Millis is a duration serialized as milliseconds.
+type Millis time.Duration
+
+func (m Millis) MarshalJSON() ([]byte, error) {
+	ms := float64(time.Duration(m).Microseconds()) / 1000
+	return strconv.AppendFloat(nil, ms, 'f', -1, 64), nil
+}
+
+// Report is the result of Registry.Check, serializable as the body of /readyz.
+type Report struct {
+	Status    Status            `json:"status"`
+	Checks    map[string]Result `json:"checks"`
+	CheckedAt time.Time         `json:"checked_at"`
+}
+
+// Healthy reports whether no critical check failed (the report may be degraded).
+func (r *Report) Healthy() bool {
+	return r.Status != StatusDown
+}
+
+// HTTPStatus returns 503 when the report is down, 200 otherwise.
+func (r *Report) HTTPStatus() int {
+	if r.Healthy() {
+		return http.StatusOK
+	}
+	return http.StatusServiceUnavailable
+}
+
+// Names returns the check names, sorted.
+func (r *Report) Names() []string {
+	names := make([]string, 0, len(r.Checks))
+	for name := range r.Checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Failed returns the names of the failed checks, sorted.
+func (r *Report) Failed() []string {
+	var failed []string
+	for _, name := range r.Names() {
+		if r.Checks[name].Status != StatusUp {
+			failed = append(failed, name)
+		}
+	}
+	return failed
+}
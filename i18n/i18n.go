@@ -0,0 +1,303 @@
+package i18n
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.yaml.in/yaml/v3"
+	"golang.org/x/text/language"
+
+	"github.com/BevisDev/godev/utils/ctxmeta"
+)
+
+// Bundle holds the messages of every loaded locale.
+//
+// Message files are named after their language tag (vi.yaml, en.json, en-US.yml).
+// Nested keys are flattened with dots:
+//
+//	# vi.yaml
+//	error:
+//	  not_found: "Không tìm thấy {resource}"
+//	validation:
+//	  required: "{field} là bắt buộc"
+type Bundle struct {
+	mu          sync.RWMutex
+	defaultLang string
+	messages    map[string]map[string]string
+	matcher     language.Matcher
+	tags        []string
+}
+
+// New creates an empty Bundle. defaultLang is used when a message is missing
+// in the requested language or no language can be resolved.
+func New(defaultLang string) *Bundle {
+	return &Bundle{
+		defaultLang: defaultLang,
+		messages:    make(map[string]map[string]string),
+	}
+}
+
+// DefaultLang returns the fallback language.
+func (b *Bundle) DefaultLang() string {
+	return b.defaultLang
+}
+
+// LoadDir loads every .yaml, .yml and .json file in dir (not recursive).
+func (b *Bundle) LoadDir(dir string) error {
+	return b.LoadFS(os.DirFS(dir), ".")
+}
+
+// LoadFS loads every .yaml, .yml and .json file in dir of fsys, e.g. an embed.FS:
+//
+//	//go:embed locales/*.yaml
+//	var locales embed.FS
+//	err := bundle.LoadFS(locales, "locales")
+func (b *Bundle) LoadFS(fsys fs.FS, dir string) error {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if e.IsDir() || !isMessageFile(e.Name()) {
+			continue
+		}
+		data, err := fs.ReadFile(fsys, path.Join(dir, e.Name()))
+		if err != nil {
+			return err
+		}
+		if err := b.load(e.Name(), data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadFile loads a single message file; the language comes from the file name.
+func (b *Bundle) LoadFile(file string) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	return b.load(filepath.Base(file), data)
+}
+
+func (b *Bundle) load(name string, data []byte) error {
+	ext := strings.ToLower(filepath.Ext(name))
+	lang := strings.TrimSuffix(name, filepath.Ext(name))
+
+	var raw map[string]any
+	var err error
+	switch ext {
+	case ".json":
+		err = json.Unmarshal(data, &raw)
+	default:
+		err = yaml.Unmarshal(data, &raw)
+	}
+	if err != nil {
+		return fmt.Errorf("[i18n] parse %s: %w", name, err)
+	}
+
+	flat := make(map[string]string)
+	flatten("", raw, flat)
+	b.AddMessages(lang, flat)
+	return nil
+}
+
+// AddMessages merges messages into lang, overriding existing keys.
+func (b *Bundle) AddMessages(lang string, messages map[string]string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	m, ok := b.messages[lang]
+	if !ok {
+		m = make(map[string]string, len(messages))
+		b.messages[lang] = m
+		b.rebuildMatcher()
+	}
+	for k, v := range messages {
+		m[k] = v
+	}
+}
+
+// Languages returns the loaded languages, sorted.
+func (b *Bundle) Languages() []string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return append([]string(nil), b.tags...)
+}
+
+// rebuildMatcher must be called with mu held. The default language is listed first
+// so the matcher falls back to it.
+func (b *Bundle) rebuildMatcher() {
+	b.tags = b.tags[:0]
+	for lang := range b.messages {
+		b.tags = append(b.tags, lang)
+	}
+	sort.Strings(b.tags)
+
+	ordered := make([]language.Tag, 0, len(b.tags)+1)
+	ordered = append(ordered, language.Make(b.defaultLang))
+	for _, t := range b.tags {
+		ordered = append(ordered, language.Make(t))
+	}
+	b.matcher = language.NewMatcher(ordered)
+}
+
+// Match returns the best loaded language for an Accept-Language header value
+// (e.g. "vi-VN,vi;q=0.9,en;q=0.8"), or the default language.
+func (b *Bundle) Match(acceptLanguage string) string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.matcher == nil || acceptLanguage == "" {
+		return b.defaultLang
+	}
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return b.defaultLang
+	}
+	_, idx, conf := b.matcher.Match(tags...)
+	if conf == language.No || idx == 0 {
+		return b.defaultLang
+	}
+	return b.tags[idx-1]
+}
+
+// Lookup returns the message for key in lang with params applied.
+// Missing messages fall back to the base language ("en" for "en-US") and then the default language.
+func (b *Bundle) Lookup(lang, key string, params map[string]any) (string, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, l := range b.candidates(lang) {
+		if msg, ok := b.messages[l][key]; ok {
+			return Format(msg, params), true
+		}
+	}
+	return "", false
+}
+
+// Translate is like Lookup but returns key when the message is missing.
+func (b *Bundle) Translate(lang, key string, params map[string]any) string {
+	if msg, ok := b.Lookup(lang, key, params); ok {
+		return msg
+	}
+	return key
+}
+
+// T translates key in the language stored in ctx (see WithLang).
+func (b *Bundle) T(ctx context.Context, key string, params map[string]any) string {
+	return b.Translate(LangFromContext(ctx), key, params)
+}
+
+func (b *Bundle) candidates(lang string) []string {
+	out := make([]string, 0, 3)
+	if lang != "" {
+		out = append(out, lang)
+		if base, _, ok := strings.Cut(lang, "-"); ok {
+			out = append(out, base)
+		}
+	}
+	if b.defaultLang != "" && b.defaultLang != lang {
+		out = append(out, b.defaultLang)
+	}
+	return out
+}
+
+// Format replaces {name} placeholders in msg with params. Unknown placeholders are kept.
+//
+// Example:
+//
+//	Format("{field} must be at least {min} characters", map[string]any{"field": "password", "min": 8})
+//	// "password must be at least 8 characters"
+func Format(msg string, params map[string]any) string {
+	if len(params) == 0 || !strings.Contains(msg, "{") {
+		return msg
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(msg))
+	for {
+		start := strings.IndexByte(msg, '{')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(msg[start:], '}')
+		if end < 0 {
+			break
+		}
+		end += start
+
+		sb.WriteString(msg[:start])
+		if v, ok := params[msg[start+1:end]]; ok {
+			sb.WriteString(fmt.Sprint(v))
+		} else {
+			sb.WriteString(msg[start : end+1])
+		}
+		msg = msg[end+1:]
+	}
+	sb.WriteString(msg)
+	return sb.String()
+}
+
+// WithLang stores lang in ctx.
+func WithLang(ctx context.Context, lang string) context.Context {
+	return ctxmeta.WithLocale(ctx, lang)
+}
+
+// LangFromContext returns the language stored by WithLang, or "".
+func LangFromContext(ctx context.Context) string {
+	return ctxmeta.Locale(ctx)
+}
+
+var (
+	defaultMu     sync.RWMutex
+	defaultBundle *Bundle
+)
+
+// SetDefault installs b as the bundle used by the response package.
+func SetDefault(b *Bundle) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultBundle = b
+}
+
+// Default returns the bundle installed by SetDefault, or nil.
+func Default() *Bundle {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultBundle
+}
+
+func isMessageFile(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	}
+	return false
+}
+
+func flatten(prefix string, in map[string]any, out map[string]string) {
+	for k, v := range in {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		switch val := v.(type) {
+		case map[string]any:
+			flatten(key, val, out)
+		case nil:
+			// skip empty entries
+		default:
+			out[key] = fmt.Sprint(val)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package i18n
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBundle(t *testing.T) *Bundle {
+	t.Helper()
+	b := New("en")
+	require.NoError(t, b.LoadDir("testdata"))
+	return b
+}
+
+func TestBundle_Lookup(t *testing.T) {
+	b := newTestBundle(t)
+	assert.Equal(t, []string{"en", "vi"}, b.Languages())
+
+	msg, ok := b.Lookup("vi", "error.not_found", map[string]any{"resource": "đơn hàng"})
+	require.True(t, ok)
+	assert.Equal(t, "Không tìm thấy đơn hàng", msg)
+
+	// vi-VN falls back to vi, missing keys fall back to the default language
+	msg, _ = b.Lookup("vi-VN", "validation.required", map[string]any{"field": "email"})
+	assert.Equal(t, "email là bắt buộc", msg)
+	msg, _ = b.Lookup("vi", "greeting", nil)
+	assert.Equal(t, "Hello", msg)
+
+	_, ok = b.Lookup("vi", "missing.key", nil)
+	assert.False(t, ok)
+	assert.Equal(t, "missing.key", b.Translate("vi", "missing.key", nil))
+}
+
+func TestBundle_Match(t *testing.T) {
+	b := newTestBundle(t)
+
+	assert.Equal(t, "vi", b.Match("vi-VN,vi;q=0.9,en;q=0.8"))
+	assert.Equal(t, "en", b.Match("en-US"))
+	assert.Equal(t, "en", b.Match("fr-FR"))
+	assert.Equal(t, "en", b.Match(""))
+	assert.Equal(t, "en", b.Match("not a ;; header"))
+}
+
+func TestBundle_T(t *testing.T) {
+	b := newTestBundle(t)
+
+	ctx := WithLang(context.Background(), "vi")
+	assert.Equal(t, "vi", LangFromContext(ctx))
+	assert.Equal(t, "name là bắt buộc", b.T(ctx, "validation.required", map[string]any{"field": "name"}))
+	assert.Equal(t, "name is required", b.T(context.Background(), "validation.required", map[string]any{"field": "name"}))
+}
+
+func TestFormat(t *testing.T) {
+	assert.Equal(t, "password must be at least 8 characters",
+		Format("{field} must be at least {min} characters", map[string]any{"field": "password", "min": 8}))
+	assert.Equal(t, "keep {unknown} and {open", Format("keep {unknown} and {open", map[string]any{"x": 1}))
+	assert.Equal(t, "no params {x}", Format("no params {x}", nil))
+}
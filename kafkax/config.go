@@ -58,6 +58,9 @@ type ConsumerConfig struct {
 	// Commit strategy
 	AutoCommit bool // Auto vs manual commit (default: false)
 
+	// Backpressure
+	MaxInFlight int // Max messages handled concurrently; <= 1 handles them one by one (default: 1)
+
 	// Rebalancing
 	PartitionWatchInterval time.Duration // (default: 5s)
 	SessionTimeout         time.Duration // (default: 10s)
@@ -123,6 +126,10 @@ func (c *Config) validateConsumerConfig() error {
 		return fmt.Errorf("max bytes must be >= min bytes")
 	}
 
+	if c.Consumer.MaxInFlight < 0 {
+		return fmt.Errorf("max in flight must be >= 0")
+	}
+
 	return nil
 }
 
@@ -162,6 +169,7 @@ func DefaultConfig(brokers []string) *Config {
 			MinBytes:               1,
 			MaxBytes:               10 * 1024 * 1024,
 			AutoCommit:             false,
+			MaxInFlight:            1,
 			PartitionWatchInterval: 5 * time.Second,
 			SessionTimeout:         10 * time.Second,
 			RebalanceTimeout:       30 * time.Second,
@@ -8,8 +8,6 @@ import (
 	"sync"
 	"time"
 
-	"github.com/BevisDev/godev/consts"
-	"github.com/BevisDev/godev/utils"
 	"github.com/segmentio/kafka-go"
 )
 
@@ -18,6 +16,9 @@ type Consumer struct {
 	config *ConsumerConfig
 	mu     sync.RWMutex
 	closed bool
+	resume chan struct{} // non-nil while paused
+
+	middlewares []Middleware
 }
 
 // newConsumer creates a new Consumer instance
@@ -56,8 +57,15 @@ func newConsumer(cfg *Config) (*Consumer, error) {
 	}, nil
 }
 
-// Consume starts consuming messages and calls the handler for each message
+// Consume starts consuming messages and calls the handler for each message.
+// The handler is wrapped with RID and the middlewares registered with Use.
 func (c *Consumer) Consume(ctx context.Context, handler Handler) error {
+	return c.consume(ctx, c.chain(handler))
+}
+
+// consume runs the fetch loop. Messages failing with a permanent error are
+// committed so they are not delivered again.
+func (c *Consumer) consume(ctx context.Context, handler Handler) error {
 	c.mu.RLock()
 	if c.closed {
 		c.mu.RUnlock()
@@ -65,44 +73,121 @@ func (c *Consumer) Consume(ctx context.Context, handler Handler) error {
 	}
 	c.mu.RUnlock()
 
+	handler = RID()(handler)
+
+	// MaxInFlight > 1 handles messages concurrently; fetching blocks while
+	// all slots are busy.
+	var (
+		wg  sync.WaitGroup
+		sem chan struct{}
+	)
+	if c.config.MaxInFlight > 1 {
+		sem = make(chan struct{}, c.config.MaxInFlight)
+	}
+	defer wg.Wait()
+
 	for {
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		default:
-			msg, err := c.reader.FetchMessage(ctx)
-			if err != nil {
-				if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
-					return err
-				}
-				log.Printf("[kafkax-consumer] fetching message error: %v\n", err)
-				continue
+		if err := c.waitResumed(ctx); err != nil {
+			return err
+		}
+		if sem != nil {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case sem <- struct{}{}:
 			}
+		}
 
-			var rid string
-			for _, h := range msg.Headers {
-				if consts.XRequestID == h.Key {
-					rid = string(h.Value)
-					break
-				}
+		msg, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if sem != nil {
+				<-sem
+			}
+			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+				return err
+			}
+			if c.IsClosed() {
+				return ErrConsumerClosed
 			}
+			log.Printf("[kafkax-consumer] fetching message error: %v\n", err)
+			continue
+		}
 
-			ctxNew := utils.SetValueCtx(ctx, consts.RID, rid)
-			consumed := c.convertMessage(msg)
-			err = handler(ctxNew, consumed)
+		if sem == nil {
+			c.handle(ctx, handler, msg)
+			continue
+		}
 
-			// Manual commit only after successful processing
-			if err != nil {
-				log.Printf("[kafkax-consumer] handler error: %v", err)
-			} else if !c.config.AutoCommit {
-				if err := c.reader.CommitMessages(ctx, msg); err != nil {
-					log.Printf("[kafkax-consumer] error committing message: %v", err)
-				}
-			}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			c.handle(ctx, handler, msg)
+		}()
+	}
+}
+
+// handle calls handler for msg and commits it on success or a permanent error
+// when AutoCommit is disabled.
+func (c *Consumer) handle(ctx context.Context, handler Handler, msg kafka.Message) {
+	err := handler(ctx, c.convertMessage(msg))
+	if err != nil {
+		log.Printf("[kafkax-consumer] handler error: %v", err)
+	}
+	if (err == nil || IsPermanent(err)) && !c.config.AutoCommit {
+		if err := c.reader.CommitMessages(ctx, msg); err != nil {
+			log.Printf("[kafkax-consumer] error committing message: %v", err)
 		}
 	}
 }
 
+// Pause stops fetching new messages until Resume is called, e.g. while a
+// downstream dependency is degraded. Messages being handled are not affected
+// and the consumer stays in its group.
+func (c *Consumer) Pause() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.resume == nil {
+		c.resume = make(chan struct{})
+	}
+}
+
+// Resume continues fetching after Pause.
+func (c *Consumer) Resume() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.resume != nil {
+		close(c.resume)
+		c.resume = nil
+	}
+}
+
+// IsPaused returns whether the consumer is paused
+func (c *Consumer) IsPaused() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.resume != nil
+}
+
+// waitResumed blocks while the consumer is paused.
+func (c *Consumer) waitResumed(ctx context.Context) error {
+	c.mu.RLock()
+	ch := c.resume
+	c.mu.RUnlock()
+	if ch == nil {
+		return nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-ch:
+		return nil
+	}
+}
+
 // Stats returns consumer statistics
 func (c *Consumer) Stats() kafka.ReaderStats {
 	c.mu.RLock()
@@ -144,6 +229,10 @@ func (c *Consumer) Close() error {
 	}
 
 	c.closed = true
+	if c.resume != nil {
+		close(c.resume)
+		c.resume = nil
+	}
 
 	if c.reader != nil {
 		return c.reader.Close()
@@ -185,12 +274,14 @@ func (c *Consumer) CommitMessage(ctx context.Context, msg *ConsumedMessage) erro
 // ConsumeWithRetry wraps Consume with retry logic on handler error.
 // When all retries are exhausted, the message is committed (skipped) to avoid
 // blocking the partition on a poison message; the final error is logged.
+// Permanent errors are not retried. Middlewares run on every attempt.
 func (c *Consumer) ConsumeWithRetry(
 	ctx context.Context,
 	handler Handler,
 	maxRetries int,
 	retryDelay time.Duration,
 ) error {
+	handler = c.chain(handler)
 	wrapped := func(ctx context.Context, msg *ConsumedMessage) error {
 		var err error
 		for attempt := 0; attempt <= maxRetries; attempt++ {
@@ -198,6 +289,9 @@ func (c *Consumer) ConsumeWithRetry(
 			if err == nil {
 				return nil
 			}
+			if IsPermanent(err) {
+				break
+			}
 
 			if attempt < maxRetries {
 				log.Printf("[kafkax-consumer] handler error: %v, retrying (%d/%d)", err, attempt+1, maxRetries)
@@ -209,13 +303,13 @@ func (c *Consumer) ConsumeWithRetry(
 			}
 		}
 		// Commit (skip) poison message so consumer does not block forever on this partition
-		log.Printf("[kafkax-consumer] retries exhausted for topic=%s partition=%d offset=%d: %v (message committed/skipped)",
+		log.Printf("[kafkax-consumer] giving up on topic=%s partition=%d offset=%d: %v (message committed/skipped)",
 			msg.Topic, msg.Partition, msg.Offset, err)
 		_ = msg.Commit(ctx)
 		return nil
 	}
 
-	return c.Consume(ctx, wrapped)
+	return c.consume(ctx, wrapped)
 }
 
 // convertMessage converts kafka.Message to ConsumedMessage
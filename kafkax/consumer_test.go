@@ -0,0 +1,57 @@
+package kafkax
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsumer_PauseResume(t *testing.T) {
+	c := &Consumer{}
+	require.NoError(t, c.waitResumed(context.Background()))
+
+	c.Pause()
+	c.Pause()
+	assert.True(t, c.IsPaused())
+
+	done := make(chan error, 1)
+	go func() { done <- c.waitResumed(context.Background()) }()
+
+	select {
+	case <-done:
+		t.Fatal("waitResumed returned while paused")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	c.Resume()
+	c.Resume()
+	assert.False(t, c.IsPaused())
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("waitResumed did not return after Resume")
+	}
+}
+
+func TestConsumer_PausedContextDone(t *testing.T) {
+	c := &Consumer{}
+	c.Pause()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, c.waitResumed(ctx), context.DeadlineExceeded)
+}
+
+func TestConfig_MaxInFlight(t *testing.T) {
+	cfg := DefaultConfig([]string{"localhost:9092"})
+	cfg.Consumer.GroupID = "g"
+	cfg.Consumer.Topics = []string{"t"}
+	require.NoError(t, cfg.Validate())
+
+	cfg.Consumer.MaxInFlight = -1
+	assert.Error(t, cfg.Validate())
+}
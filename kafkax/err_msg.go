@@ -14,4 +14,6 @@ var (
 	ErrNoGroupID              = errors.New("[kafkax-consumer] no group id")
 	ErrConsumerClosed         = errors.New("[kafkax-consumer] consumer closed")
 	ErrConsumerNotInitialized = errors.New("[kafkax-consumer] not initialized")
+	ErrHandlerPanic           = errors.New("[kafkax-consumer] handler panic")
+	ErrDecodeMessage          = errors.New("[kafkax-consumer] decode message")
 )
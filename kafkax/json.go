@@ -0,0 +1,82 @@
+package kafkax
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/BevisDev/godev/utils/jsonx"
+)
+
+// HeaderDecodeError is set on messages forwarded to a DLQ by JSONHandler.
+const HeaderDecodeError = "x-decode-error"
+
+// TypedHandler handles a message whose Value was decoded into T.
+type TypedHandler[T any] func(ctx context.Context, v T, msg *ConsumedMessage) error
+
+// DecodeErrorHandler is called when the Value of a message cannot be decoded.
+// Its result becomes the result of the handler.
+type DecodeErrorHandler func(ctx context.Context, msg *ConsumedMessage, err error) error
+
+type jsonOptions struct {
+	onError DecodeErrorHandler
+}
+
+// JSONOption configures JSONHandler.
+type JSONOption func(*jsonOptions)
+
+// WithDecodeError sets the callback for messages that cannot be decoded.
+// By default the error is returned as Permanent, so the message is not retried.
+func WithDecodeError(fn DecodeErrorHandler) JSONOption {
+	return func(o *jsonOptions) {
+		o.onError = fn
+	}
+}
+
+// WithDLQ forwards messages that cannot be decoded to topic, keeping key and
+// headers and adding HeaderDecodeError. The message is then considered handled.
+func WithDLQ(p *Producer, topic string) JSONOption {
+	return WithDecodeError(func(ctx context.Context, msg *ConsumedMessage, err error) error {
+		headers := make(map[string]string, len(msg.Headers)+1)
+		for k, v := range msg.Headers {
+			headers[k] = v
+		}
+		headers[HeaderDecodeError] = err.Error()
+
+		if dlqErr := p.SendWithHeaders(ctx, topic, msg.Key, msg.Value, headers); dlqErr != nil {
+			return fmt.Errorf("send to DLQ %s: %w", topic, dlqErr)
+		}
+		log.Printf("[kafkax-consumer] topic=%s partition=%d offset=%d sent to DLQ %s: %v",
+			msg.Topic, msg.Partition, msg.Offset, topic, err)
+		return nil
+	})
+}
+
+// JSONHandler adapts fn to a Handler by decoding the Value of each message into T.
+func JSONHandler[T any](fn TypedHandler[T], opts ...JSONOption) Handler {
+	o := &jsonOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return func(ctx context.Context, msg *ConsumedMessage) error {
+		v, err := jsonx.FromJSONBytes[T](msg.Value)
+		if err != nil {
+			err = fmt.Errorf("%w: %v", ErrDecodeMessage, err)
+			if o.onError != nil {
+				return o.onError(ctx, msg, err)
+			}
+			return Permanent(err)
+		}
+		return fn(ctx, v, msg)
+	}
+}
+
+// SendJSON encodes value as JSON and sends it with the RID header from ctx.
+func SendJSON[T any](ctx context.Context, p *Producer, topic, key string, value T) error {
+	data, err := jsonx.ToJSONBytes(value)
+	if err != nil {
+		return fmt.Errorf("marshal JSON: %w", err)
+	}
+	return p.Produce(ctx, topic, []byte(key), data)
+}
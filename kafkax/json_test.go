@@ -0,0 +1,57 @@
+package kafkax
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type orderEvent struct {
+	ID    int    `json:"id"`
+	State string `json:"state"`
+}
+
+func TestJSONHandler(t *testing.T) {
+	var got orderEvent
+	h := JSONHandler(func(ctx context.Context, v orderEvent, msg *ConsumedMessage) error {
+		got = v
+		return nil
+	})
+
+	require.NoError(t, h(context.Background(), &ConsumedMessage{Value: []byte(`{"id":1,"state":"paid"}`)}))
+	assert.Equal(t, orderEvent{ID: 1, State: "paid"}, got)
+
+	err := h(context.Background(), &ConsumedMessage{Value: []byte(`{bad`)})
+	assert.ErrorIs(t, err, ErrDecodeMessage)
+	assert.True(t, IsPermanent(err))
+}
+
+func TestJSONHandler_DecodeError(t *testing.T) {
+	called := false
+	var decodeErr error
+	h := JSONHandler(func(ctx context.Context, v orderEvent, msg *ConsumedMessage) error {
+		called = true
+		return nil
+	}, WithDecodeError(func(ctx context.Context, msg *ConsumedMessage, err error) error {
+		decodeErr = err
+		return nil
+	}))
+
+	require.NoError(t, h(context.Background(), &ConsumedMessage{Value: []byte(`[]`)}))
+	assert.False(t, called)
+	assert.ErrorIs(t, decodeErr, ErrDecodeMessage)
+}
+
+func TestJSONHandler_HandlerError(t *testing.T) {
+	want := errors.New("failed")
+	h := JSONHandler(func(ctx context.Context, v orderEvent, msg *ConsumedMessage) error {
+		return want
+	})
+
+	err := h(context.Background(), &ConsumedMessage{Value: []byte(`{"id":2}`)})
+	assert.ErrorIs(t, err, want)
+	assert.False(t, IsPermanent(err))
+}
@@ -115,6 +115,16 @@ func (k *Kafka) SendBatch(ctx context.Context, messages []*Message) error {
 	return producer.SendBatch(ctx, messages)
 }
 
+// Use registers consumer middlewares, see Consumer.Use.
+func (k *Kafka) Use(mws ...Middleware) error {
+	consumer, err := k.Consumer()
+	if err != nil {
+		return err
+	}
+	consumer.Use(mws...)
+	return nil
+}
+
 // Consume is a convenience method to consume messages
 func (k *Kafka) Consume(ctx context.Context, handler Handler) error {
 	consumer, err := k.Consumer()
@@ -0,0 +1,177 @@
+package kafkax
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"runtime/debug"
+	"strconv"
+	"time"
+
+	"github.com/BevisDev/godev/consts"
+	"github.com/BevisDev/godev/errreport"
+	"github.com/BevisDev/godev/metrics"
+	"github.com/BevisDev/godev/utils/ctxmeta"
+	"github.com/BevisDev/godev/utils/random"
+)
+
+// Middleware wraps a Handler, mirroring HTTP middleware for message handlers.
+type Middleware func(next Handler) Handler
+
+// Chain wraps h with mws. The first middleware is the outermost one.
+func Chain(h Handler, mws ...Middleware) Handler {
+	for i := len(mws) - 1; i >= 0; i-- {
+		if mws[i] != nil {
+			h = mws[i](h)
+		}
+	}
+	return h
+}
+
+// RID puts the request id of the message (X-Request-ID header) into the context.
+// A new id is generated when the header is missing.
+// Consume always applies it as the outermost middleware.
+func RID() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, msg *ConsumedMessage) error {
+			rid := msg.Headers[consts.XRequestID]
+			if rid == "" {
+				rid = random.NewUUID()
+			}
+			return next(ctxmeta.WithRID(ctx, rid), msg)
+		}
+	}
+}
+
+// Recover turns a panic in the handler into an error wrapping ErrHandlerPanic.
+func Recover() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, msg *ConsumedMessage) (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					log.Printf("[kafkax-consumer] panic topic=%s partition=%d offset=%d: %v\n%s",
+						msg.Topic, msg.Partition, msg.Offset, r, debug.Stack())
+					err = fmt.Errorf("%w: %v", ErrHandlerPanic, r)
+				}
+			}()
+			return next(ctx, msg)
+		}
+	}
+}
+
+// Report reports handler errors and panics to r, tagged with the topic,
+// partition and offset of the message. Panics are re-raised, so place it
+// after Recover:
+//
+//	kafkax.Chain(h, kafkax.Recover(), kafkax.Report(reporter))
+func Report(r *errreport.Reporter) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, msg *ConsumedMessage) error {
+			tags := []errreport.CaptureOption{
+				errreport.Tag("topic", msg.Topic),
+				errreport.Tag("partition", strconv.Itoa(msg.Partition)),
+				errreport.Tag("offset", strconv.FormatInt(msg.Offset, 10)),
+			}
+			defer func() {
+				if p := recover(); p != nil {
+					r.CapturePanic(ctx, p, tags...)
+					panic(p)
+				}
+			}()
+
+			err := next(ctx, msg)
+			if err != nil {
+				r.CaptureError(ctx, err, tags...)
+			}
+			return err
+		}
+	}
+}
+
+// Duration calls observe with the handling time and result of every message.
+// Use it to feed metrics (histograms, counters by topic and error).
+func Duration(observe func(msg *ConsumedMessage, d time.Duration, err error)) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, msg *ConsumedMessage) error {
+			start := time.Now()
+			err := next(ctx, msg)
+			observe(msg, time.Since(start), err)
+			return err
+		}
+	}
+}
+
+// Metrics records the handling time of every message on p, as
+// kafka_consumer_handle_duration_seconds labeled by topic and status (ok, error).
+func Metrics(p metrics.Provider) Middleware {
+	handled := p.Timer(metrics.Opts{
+		Name:   "kafka_consumer_handle_duration_seconds",
+		Help:   "Duration of Kafka message handling.",
+		Labels: []string{"topic", "status"},
+	})
+	return Duration(func(msg *ConsumedMessage, d time.Duration, err error) {
+		handled.Record(d, msg.Topic, metrics.Status(err))
+	})
+}
+
+// Logging logs every handled message with its rid, position, duration and error.
+func Logging() Middleware {
+	return Duration(func(msg *ConsumedMessage, d time.Duration, err error) {
+		log.Printf("[kafkax-consumer] topic=%s partition=%d offset=%d duration=%s err=%v",
+			msg.Topic, msg.Partition, msg.Offset, d, err)
+	})
+}
+
+// Classify marks handler errors for which isPermanent returns true as permanent.
+// Permanent errors are not retried by ConsumeWithRetry and the message is
+// committed (skipped). A nil isPermanent only keeps errors wrapped by Permanent.
+func Classify(isPermanent func(err error) bool) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, msg *ConsumedMessage) error {
+			err := next(ctx, msg)
+			if err != nil && !IsPermanent(err) && isPermanent != nil && isPermanent(err) {
+				return Permanent(err)
+			}
+			return err
+		}
+	}
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent marks err as permanent: retrying the message cannot fix it
+// (e.g. an invalid payload). It returns nil when err is nil.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent reports whether err was marked by Permanent.
+func IsPermanent(err error) bool {
+	var p *permanentError
+	return errors.As(err, &p)
+}
+
+// Use appends middlewares applied to every handler passed to Consume
+// and ConsumeWithRetry.
+func (c *Consumer) Use(mws ...Middleware) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.middlewares = append(c.middlewares, mws...)
+}
+
+// chain wraps h with the registered middlewares.
+func (c *Consumer) chain(h Handler) Handler {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return Chain(h, c.middlewares...)
+}
@@ -0,0 +1,152 @@
+package kafkax
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BevisDev/godev/consts"
+	"github.com/BevisDev/godev/errreport"
+	"github.com/BevisDev/godev/metrics/prometheus"
+	"github.com/BevisDev/godev/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChain_Order(t *testing.T) {
+	var calls []string
+	mw := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context, msg *ConsumedMessage) error {
+				calls = append(calls, name)
+				return next(ctx, msg)
+			}
+		}
+	}
+
+	h := Chain(func(ctx context.Context, msg *ConsumedMessage) error {
+		calls = append(calls, "handler")
+		return nil
+	}, mw("a"), nil, mw("b"))
+
+	require.NoError(t, h(context.Background(), &ConsumedMessage{}))
+	assert.Equal(t, []string{"a", "b", "handler"}, calls)
+}
+
+func TestRID(t *testing.T) {
+	var rid string
+	h := RID()(func(ctx context.Context, msg *ConsumedMessage) error {
+		rid = utils.GetRID(ctx)
+		return nil
+	})
+
+	msg := &ConsumedMessage{Headers: map[string]string{consts.XRequestID: "rid-1"}}
+	require.NoError(t, h(context.Background(), msg))
+	assert.Equal(t, "rid-1", rid)
+
+	require.NoError(t, h(context.Background(), &ConsumedMessage{}))
+	assert.NotEmpty(t, rid)
+	assert.NotEqual(t, "rid-1", rid)
+}
+
+func TestRecover(t *testing.T) {
+	h := Recover()(func(ctx context.Context, msg *ConsumedMessage) error {
+		panic("boom")
+	})
+
+	err := h(context.Background(), &ConsumedMessage{})
+	assert.ErrorIs(t, err, ErrHandlerPanic)
+	assert.Contains(t, err.Error(), "boom")
+}
+
+func TestDuration(t *testing.T) {
+	want := errors.New("failed")
+	var (
+		got error
+		dur time.Duration
+	)
+	h := Duration(func(msg *ConsumedMessage, d time.Duration, err error) {
+		got, dur = err, d
+	})(func(ctx context.Context, msg *ConsumedMessage) error {
+		time.Sleep(5 * time.Millisecond)
+		return want
+	})
+
+	assert.ErrorIs(t, h(context.Background(), &ConsumedMessage{}), want)
+	assert.ErrorIs(t, got, want)
+	assert.GreaterOrEqual(t, dur, 5*time.Millisecond)
+}
+
+func TestMetrics(t *testing.T) {
+	reg := prometheus.New()
+	h := Chain(func(ctx context.Context, msg *ConsumedMessage) error {
+		if msg.Offset == 1 {
+			return errors.New("failed")
+		}
+		return nil
+	}, Metrics(reg))
+
+	_ = h(context.Background(), &ConsumedMessage{Topic: "orders", Offset: 0})
+	_ = h(context.Background(), &ConsumedMessage{Topic: "orders", Offset: 1})
+
+	var out strings.Builder
+	_, _ = reg.WriteTo(&out)
+	assert.Contains(t, out.String(), `kafka_consumer_handle_duration_seconds_count{topic="orders",status="ok"} 1`)
+	assert.Contains(t, out.String(), `kafka_consumer_handle_duration_seconds_count{topic="orders",status="error"} 1`)
+}
+
+func TestClassify(t *testing.T) {
+	errInvalid := errors.New("invalid payload")
+	errTimeout := errors.New("timeout")
+
+	mw := Classify(func(err error) bool { return errors.Is(err, errInvalid) })
+	run := func(err error) error {
+		return mw(func(ctx context.Context, msg *ConsumedMessage) error {
+			return err
+		})(context.Background(), &ConsumedMessage{})
+	}
+
+	err := run(errInvalid)
+	assert.True(t, IsPermanent(err))
+	assert.ErrorIs(t, err, errInvalid)
+
+	assert.False(t, IsPermanent(run(errTimeout)))
+	assert.NoError(t, run(nil))
+	assert.Nil(t, Permanent(nil))
+}
+
+type eventRecorder struct {
+	events []*errreport.Event
+}
+
+func (r *eventRecorder) Send(_ context.Context, ev *errreport.Event) error {
+	r.events = append(r.events, ev)
+	return nil
+}
+
+func TestReport(t *testing.T) {
+	rec := &eventRecorder{}
+	reporter, err := errreport.New(rec)
+	require.NoError(t, err)
+
+	failed := errors.New("insufficient balance")
+	h := Chain(func(ctx context.Context, msg *ConsumedMessage) error {
+		if msg.Offset == 2 {
+			panic("nil account")
+		}
+		return failed
+	}, Recover(), Report(reporter))
+
+	ctx := context.Background()
+	assert.ErrorIs(t, h(ctx, &ConsumedMessage{Topic: "payments", Partition: 3, Offset: 1}), failed)
+	assert.ErrorIs(t, h(ctx, &ConsumedMessage{Topic: "payments", Partition: 3, Offset: 2}), ErrHandlerPanic)
+	require.NoError(t, reporter.Close(ctx))
+
+	require.Len(t, rec.events, 2)
+	assert.Equal(t, "insufficient balance", rec.events[0].Message)
+	assert.Equal(t, map[string]string{"topic": "payments", "partition": "3", "offset": "1"}, rec.events[0].Tags)
+	assert.Equal(t, "nil account", rec.events[1].Message)
+	assert.Equal(t, errreport.LevelFatal, rec.events[1].Level)
+}
@@ -38,6 +38,7 @@ type RequestLogger struct {
 type ResponseLogger struct {
 	RID      string
 	Duration time.Duration
+	Timing   fmt.Stringer // optional breakdown of Duration (e.g. DNS, connect, TTFB)
 	Status   int
 	Header   any
 	Body     string
@@ -469,6 +470,9 @@ func (l *Logger) logResponse(resp *ResponseLogger, message string, callerSkip in
 		zap.Int(consts.Status, resp.Status),
 		zap.String(consts.Duration, resp.Duration.String()),
 	}
+	if resp.Timing != nil {
+		fields = append(fields, zap.String(consts.Timing, resp.Timing.String()))
+	}
 	if resp.Header != nil {
 		fields = append(fields, zap.Any(consts.Header, resp.Header))
 	}
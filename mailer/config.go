@@ -1,5 +1,21 @@
 package mailer
 
+import "time"
+
+// TLSMode selects how the SMTP connection is secured.
+type TLSMode int
+
+const (
+	// TLSAuto uses implicit TLS on port 465, otherwise STARTTLS when the server advertises it.
+	TLSAuto TLSMode = iota
+	// TLSStartTLS requires STARTTLS and fails when the server does not support it.
+	TLSStartTLS
+	// TLSImplicit connects over TLS from the start (SMTPS).
+	TLSImplicit
+	// TLSNone never upgrades the connection (local relays, testing).
+	TLSNone
+)
+
 // Config holds SMTP configuration
 type Config struct {
 	Host     string
@@ -7,4 +23,30 @@ type Config struct {
 	Username string
 	Password string
 	From     string
+
+	// FromName is the optional display name of the sender.
+	FromName string
+
+	// TLS selects the connection security (default TLSAuto).
+	TLS TLSMode
+
+	// InsecureSkipVerify disables server certificate verification. Testing only.
+	InsecureSkipVerify bool
+
+	// Timeout bounds dialing and the whole SMTP session (default 30s).
+	Timeout time.Duration
+}
+
+func (c *Config) tlsMode() TLSMode {
+	if c.TLS == TLSAuto && c.Port == 465 {
+		return TLSImplicit
+	}
+	return c.TLS
+}
+
+func (c *Config) timeout() time.Duration {
+	if c.Timeout <= 0 {
+		return 30 * time.Second
+	}
+	return c.Timeout
 }
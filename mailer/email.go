@@ -1 +1,219 @@
 package mailer
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	netmail "net/mail"
+	"net/textproto"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/BevisDev/godev/consts"
+	"github.com/google/uuid"
+)
+
+// Mail represents an email message.
+type Mail struct {
+	To      []string
+	Cc      []string
+	Bcc     []string
+	Subject string
+	Body    string
+	IsHTML  bool
+
+	// TextBody is the plain-text alternative sent with an HTML Body (multipart/alternative).
+	TextBody string
+
+	Attachments []Attachment
+}
+
+// Attachment represents an email attachment.
+type Attachment struct {
+	Filename string
+	Content  []byte
+
+	// ContentType defaults to the type of the file extension.
+	ContentType string
+
+	// Inline embeds the file in the HTML body; reference it as <img src="cid:Filename">.
+	Inline bool
+}
+
+func validateMail(mail Mail) error {
+	if len(mail.To) == 0 {
+		return ErrNoRecipients
+	}
+	if mail.Subject == "" {
+		return ErrEmptySubject
+	}
+	if mail.Body == "" {
+		return ErrEmptyBody
+	}
+	return nil
+}
+
+// recipients returns every envelope recipient; Bcc is never written to the headers.
+func (mail Mail) recipients() []string {
+	rcpt := make([]string, 0, len(mail.To)+len(mail.Cc)+len(mail.Bcc))
+	rcpt = append(rcpt, mail.To...)
+	rcpt = append(rcpt, mail.Cc...)
+	return append(rcpt, mail.Bcc...)
+}
+
+// entity is a MIME entity: its headers and encoded body.
+type entity struct {
+	header textproto.MIMEHeader
+	body   []byte
+}
+
+// buildMessage constructs the full email message (headers + body, with optional attachments).
+//
+// Structure: mixed(related(alternative(text, html), inline...), attachments...),
+// where each level is only used when needed.
+func (m *Mailer) buildMessage(mail Mail) ([]byte, error) {
+	root, err := mailEntity(mail)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	m.writeHeaders(&buf, mail)
+	for _, k := range []string{consts.ContentType, consts.ContentTransferEncoding} {
+		if v := root.header.Get(k); v != "" {
+			fmt.Fprintf(&buf, "%s: %s\r\n", k, v)
+		}
+	}
+	buf.WriteString("\r\n")
+	buf.Write(root.body)
+	return buf.Bytes(), nil
+}
+
+func (m *Mailer) writeHeaders(buf *bytes.Buffer, mail Mail) {
+	from := m.cfg.From
+	if m.cfg.FromName != "" {
+		from = (&netmail.Address{Name: m.cfg.FromName, Address: m.cfg.From}).String()
+	}
+
+	fmt.Fprintf(buf, "From: %s\r\n", from)
+	fmt.Fprintf(buf, "To: %s\r\n", strings.Join(mail.To, ", "))
+	if len(mail.Cc) > 0 {
+		fmt.Fprintf(buf, "Cc: %s\r\n", strings.Join(mail.Cc, ", "))
+	}
+	fmt.Fprintf(buf, "Subject: %s\r\n", mime.QEncoding.Encode(consts.UTF8, mail.Subject))
+	fmt.Fprintf(buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(buf, "Message-ID: <%s@%s>\r\n", uuid.NewString(), m.cfg.Host)
+	fmt.Fprintf(buf, "MIME-Version: 1.0\r\n")
+}
+
+func mailEntity(mail Mail) (entity, error) {
+	var (
+		body entity
+		err  error
+	)
+	switch {
+	case mail.IsHTML && mail.TextBody != "":
+		body, err = multipartEntity("alternative",
+			textEntity("text/plain", mail.TextBody),
+			textEntity("text/html", mail.Body),
+		)
+	case mail.IsHTML:
+		body = textEntity("text/html", mail.Body)
+	default:
+		body = textEntity("text/plain", mail.Body)
+	}
+	if err != nil {
+		return entity{}, err
+	}
+
+	var inline, attached []entity
+	for _, att := range mail.Attachments {
+		if att.Inline {
+			inline = append(inline, attachmentEntity(att))
+		} else {
+			attached = append(attached, attachmentEntity(att))
+		}
+	}
+
+	if len(inline) > 0 {
+		if body, err = multipartEntity("related", append([]entity{body}, inline...)...); err != nil {
+			return entity{}, err
+		}
+	}
+	if len(attached) > 0 {
+		if body, err = multipartEntity("mixed", append([]entity{body}, attached...)...); err != nil {
+			return entity{}, err
+		}
+	}
+	return body, nil
+}
+
+func textEntity(mediaType, text string) entity {
+	var buf bytes.Buffer
+	qp := quotedprintable.NewWriter(&buf)
+	_, _ = qp.Write([]byte(text))
+	_ = qp.Close()
+
+	h := make(textproto.MIMEHeader)
+	h.Set(consts.ContentType, mediaType+"; "+consts.CharsetUTF8)
+	h.Set(consts.ContentTransferEncoding, "quoted-printable")
+	return entity{header: h, body: buf.Bytes()}
+}
+
+func attachmentEntity(att Attachment) entity {
+	contentType := att.ContentType
+	if contentType == "" {
+		contentType = mime.TypeByExtension(filepath.Ext(att.Filename))
+	}
+	if contentType == "" {
+		contentType = consts.ApplicationOctetStream
+	}
+
+	disposition := "attachment"
+	if att.Inline {
+		disposition = "inline"
+	}
+
+	h := make(textproto.MIMEHeader)
+	h.Set(consts.ContentType, mime.FormatMediaType(contentType, map[string]string{"name": att.Filename}))
+	h.Set(consts.ContentDisposition, mime.FormatMediaType(disposition, map[string]string{"filename": att.Filename}))
+	h.Set(consts.ContentTransferEncoding, "base64")
+	if att.Inline {
+		h.Set("Content-ID", "<"+att.Filename+">")
+	}
+
+	encoded := base64.StdEncoding.EncodeToString(att.Content)
+	var buf bytes.Buffer
+	const lineLen = 76
+	for i := 0; i < len(encoded); i += lineLen {
+		end := min(i+lineLen, len(encoded))
+		buf.WriteString(encoded[i:end])
+		buf.WriteString("\r\n")
+	}
+	return entity{header: h, body: buf.Bytes()}
+}
+
+func multipartEntity(subtype string, parts ...entity) (entity, error) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	for _, p := range parts {
+		w, err := mw.CreatePart(p.header)
+		if err != nil {
+			return entity{}, err
+		}
+		if _, err := w.Write(p.body); err != nil {
+			return entity{}, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return entity{}, err
+	}
+
+	h := make(textproto.MIMEHeader)
+	h.Set(consts.ContentType, mime.FormatMediaType("multipart/"+subtype, map[string]string{"boundary": mw.Boundary()}))
+	return entity{header: h, body: buf.Bytes()}, nil
+}
@@ -4,9 +4,13 @@ import "errors"
 
 // Errors
 var (
-	ErrConfigNil     = errors.New("[mailer] config is nil")
-	ErrNoRecipients  = errors.New("[mailer] no recipients specified")
-	ErrEmptySubject  = errors.New("[mailer] subject is empty")
-	ErrEmptyBody     = errors.New("[mailer] body is empty")
-	ErrTemplateParse = errors.New("[mailer] failed to parse template")
+	ErrConfigNil           = errors.New("[mailer] config is nil")
+	ErrNoRecipients        = errors.New("[mailer] no recipients specified")
+	ErrEmptySubject        = errors.New("[mailer] subject is empty")
+	ErrEmptyBody           = errors.New("[mailer] body is empty")
+	ErrTemplateParse       = errors.New("[mailer] failed to parse template")
+	ErrTemplateNotFound    = errors.New("[mailer] template not found")
+	ErrStartTLSUnsupported = errors.New("[mailer] server does not support STARTTLS")
+	ErrAuthUnsupported     = errors.New("[mailer] server does not support AUTH")
+	ErrNoQueue             = errors.New("[mailer] async queue is not configured")
 )
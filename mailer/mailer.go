@@ -1,61 +1,83 @@
 package mailer
 
 import (
-	"bytes"
-	"encoding/base64"
+	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
-	"html/template"
-	"mime"
+	"io"
+	"net"
 	"net/smtp"
-	"path/filepath"
-	"strings"
-	"time"
+	"net/textproto"
 
-	"github.com/BevisDev/godev/consts"
+	"github.com/BevisDev/godev/utils/async"
+	"github.com/BevisDev/godev/utils/console"
 )
 
 // Mailer handles email sending.
 type Mailer struct {
+	*options
 	cfg  *Config
 	auth smtp.Auth
 	addr string
-}
-
-// Mail represents an email message.
-type Mail struct {
-	To          []string
-	Cc          []string
-	Bcc         []string
-	Subject     string
-	Body        string
-	IsHTML      bool
-	Attachments []Attachment
-}
+	tmpl *templates
+	pool *async.Pool
+	log  *console.Logger
 
-// Attachment represents an email attachment.
-type Attachment struct {
-	Filename string
-	Content  []byte
+	// send delivers a built message; replaced in tests.
+	send func(ctx context.Context, from string, to []string, msg []byte) error
 }
 
 // New creates a Mailer with the given config.
-func New(cfg *Config) (*Mailer, error) {
+func New(cfg *Config, opts ...Option) (*Mailer, error) {
 	if cfg == nil {
 		return nil, ErrConfigNil
 	}
 
-	auth := smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
-	addr := fmt.Sprintf("%s:%d", cfg.Host, cfg.Port)
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	m := &Mailer{
+		options: o,
+		cfg:     cfg,
+		addr:    fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
+		log:     console.New("mailer"),
+	}
+	if cfg.Username != "" {
+		m.auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+	m.send = m.deliver
 
-	return &Mailer{
-		cfg:  cfg,
-		auth: auth,
-		addr: addr,
-	}, nil
+	if o.fsys != nil {
+		tmpl, err := loadTemplates(o.fsys, o.patterns, o.funcs)
+		if err != nil {
+			return nil, err
+		}
+		m.tmpl = tmpl
+	}
+	if o.onError == nil {
+		o.onError = func(mail Mail, err error) {
+			m.log.Error("send %q to %v failed: %v", mail.Subject, mail.To, err)
+		}
+	}
+	if o.queue == nil && o.workers > 0 {
+		m.pool = async.NewPool(context.Background(), o.workers, o.queueSize)
+		o.queue = m.pool
+	}
+
+	return m, nil
 }
 
 // Send sends an email. It validates the mail and returns an error if send fails.
 func (m *Mailer) Send(mail Mail) error {
+	return m.SendContext(context.Background(), mail)
+}
+
+// SendContext sends an email, retrying transient failures according to the retry policy.
+// ctx bounds the whole operation including retries.
+func (m *Mailer) SendContext(ctx context.Context, mail Mail) error {
 	if err := validateMail(mail); err != nil {
 		return err
 	}
@@ -65,151 +87,135 @@ func (m *Mailer) Send(mail Mail) error {
 		return err
 	}
 
-	return smtp.SendMail(
-		m.addr,
-		m.auth,
-		m.cfg.From,
-		append(mail.To, mail.Cc...),
-		message,
-	)
+	rcpt := mail.recipients()
+	return async.Retry(ctx, m.retry, func(ctx context.Context) error {
+		return m.send(ctx, m.cfg.From, rcpt, message)
+	})
 }
 
-func validateMail(mail Mail) error {
-	if len(mail.To) == 0 {
-		return ErrNoRecipients
-	}
-	if mail.Subject == "" {
-		return ErrEmptySubject
+// SendAsync validates mail and queues it on the configured queue (WithAsync or WithQueue).
+// Final failures are reported to the error handler (WithErrorHandler).
+func (m *Mailer) SendAsync(ctx context.Context, mail Mail) error {
+	if m.queue == nil {
+		return ErrNoQueue
 	}
-	if mail.Body == "" {
-		return ErrEmptyBody
+	if err := validateMail(mail); err != nil {
+		return err
 	}
-	return nil
-}
-
-// buildMessage constructs the full email message (headers + body, with optional attachments).
-func (m *Mailer) buildMessage(mail Mail) ([]byte, error) {
-	var buf bytes.Buffer
 
-	m.writeHeaders(&buf, mail)
+	return m.queue.Submit(ctx, func(ctx context.Context) {
+		if err := m.SendContext(ctx, mail); err != nil {
+			m.onError(mail, err)
+		}
+	})
+}
 
-	if len(mail.Attachments) == 0 {
-		m.writeSimpleBody(&buf, mail)
-		return buf.Bytes(), nil
+// SendViewAsync renders view (see Render) and queues the mail with SendAsync.
+func (m *Mailer) SendViewAsync(ctx context.Context, to []string, subject, view string, data any) error {
+	mail, err := m.viewMail(to, subject, view, data)
+	if err != nil {
+		return err
 	}
+	return m.SendAsync(ctx, mail)
+}
 
-	boundary := fmt.Sprintf("boundary_%d", time.Now().UnixNano())
-	fmt.Fprintf(&buf, "Content-Type: multipart/mixed; boundary=\"%s\"\r\n\r\n", boundary)
-
-	m.writeBodyPart(&buf, mail, boundary)
-	for _, att := range mail.Attachments {
-		m.writeAttachmentPart(&buf, att, boundary)
+// Close waits for queued mails of the internal pool (WithAsync) to be sent.
+// Queues passed with WithQueue are owned by the caller and left untouched.
+func (m *Mailer) Close() {
+	if m.pool != nil {
+		m.pool.Close()
 	}
-	fmt.Fprintf(&buf, "--%s--\r\n", boundary)
-
-	return buf.Bytes(), nil
 }
 
-func (m *Mailer) writeHeaders(buf *bytes.Buffer, mail Mail) {
-	fmt.Fprintf(buf, "From: %s\r\n", m.cfg.From)
-	fmt.Fprintf(buf, "To: %s\r\n", strings.Join(mail.To, ", "))
-	if len(mail.Cc) > 0 {
-		fmt.Fprintf(buf, "Cc: %s\r\n", strings.Join(mail.Cc, ", "))
+// IsTransient reports whether err is a temporary failure worth retrying:
+// SMTP 4xx replies, network errors and dropped connections.
+// 5xx replies and unknown hosts are permanent.
+func IsTransient(err error) bool {
+	var tpErr *textproto.Error
+	if errors.As(err, &tpErr) {
+		return tpErr.Code >= 400 && tpErr.Code < 500
 	}
-	fmt.Fprintf(buf, "Subject: %s\r\n", mail.Subject)
-	fmt.Fprintf(buf, "MIME-Version: 1.0\r\n")
-}
 
-func (m *Mailer) writeSimpleBody(buf *bytes.Buffer, mail Mail) {
-	if mail.IsHTML {
-		buf.WriteString("Content-Type: text/html; charset=UTF-8\r\n")
-	} else {
-		buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return !dnsErr.IsNotFound
 	}
-	buf.WriteString("\r\n")
-	buf.WriteString(mail.Body)
-}
 
-func (m *Mailer) writeBodyPart(buf *bytes.Buffer, mail Mail, boundary string) {
-	fmt.Fprintf(buf, "--%s\r\n", boundary)
-	if mail.IsHTML {
-		fmt.Fprintf(buf, "Content-Type: text/html; charset=UTF-8\r\n")
-	} else {
-		fmt.Fprintf(buf, "Content-Type: text/plain; charset=UTF-8\r\n")
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
 	}
-	fmt.Fprintf(buf, "\r\n")
-	fmt.Fprintf(buf, "%s\r\n", mail.Body)
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
 }
 
-func (m *Mailer) writeAttachmentPart(buf *bytes.Buffer, att Attachment, boundary string) {
-	fmt.Fprintf(buf, "--%s\r\n", boundary)
+// deliver runs one SMTP session: dial, TLS, auth and transfer of msg.
+func (m *Mailer) deliver(ctx context.Context, from string, to []string, msg []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, m.cfg.timeout())
+	defer cancel()
 
-	contentType := mime.TypeByExtension(filepath.Ext(att.Filename))
-	if contentType == "" {
-		contentType = consts.ApplicationOctetStream
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", m.addr)
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
 	}
 
-	fmt.Fprintf(buf, "Content-Type: %s; name=\"%s\"\r\n", contentType, att.Filename)
-	fmt.Fprintf(buf, "Content-Disposition: attachment; filename=\"%s\"\r\n", att.Filename)
-	fmt.Fprintf(buf, "Content-Transfer-Encoding: base64\r\n\r\n")
-
-	encoded := base64.StdEncoding.EncodeToString(att.Content)
-	const lineLen = 76
-	for i := 0; i < len(encoded); i += lineLen {
-		end := min(i+lineLen, len(encoded))
-		fmt.Fprintf(buf, "%s\r\n", encoded[i:end])
-		fmt.Fprintf(buf, "\r\n")
+	mode := m.cfg.tlsMode()
+	tlsCfg := &tls.Config{
+		ServerName:         m.cfg.Host,
+		InsecureSkipVerify: m.cfg.InsecureSkipVerify,
+	}
+	if mode == TLSImplicit {
+		conn = tls.Client(conn, tlsCfg)
 	}
-}
 
-// SendTemplate sends an email by rendering the template at templatePath with data.
-func (m *Mailer) SendTemplate(to []string, subject string, templatePath string, data any) error {
-	body, err := executeTemplateFile(templatePath, data)
+	c, err := smtp.NewClient(conn, m.cfg.Host)
 	if err != nil {
+		_ = conn.Close()
 		return err
 	}
-	return m.Send(Mail{
-		To:      to,
-		Subject: subject,
-		Body:    body,
-		IsHTML:  true,
-	})
-}
+	defer c.Close()
 
-// SendTemplateString sends an email by rendering the template string with data.
-func (m *Mailer) SendTemplateString(to []string, subject string, templateStr string, data any) error {
-	body, err := executeTemplateString(templateStr, data)
-	if err != nil {
-		return err
+	if mode == TLSAuto || mode == TLSStartTLS {
+		if ok, _ := c.Extension("STARTTLS"); ok {
+			if err := c.StartTLS(tlsCfg); err != nil {
+				return err
+			}
+		} else if mode == TLSStartTLS {
+			return ErrStartTLSUnsupported
+		}
 	}
-	return m.Send(Mail{
-		To:      to,
-		Subject: subject,
-		Body:    body,
-		IsHTML:  true,
-	})
-}
 
-func executeTemplateFile(templatePath string, data any) (string, error) {
-	tmpl, err := template.ParseFiles(templatePath)
-	if err != nil {
-		return "", fmt.Errorf("%w: %v", ErrTemplateParse, err)
+	// never fall back to unauthenticated mail when credentials are configured
+	if m.auth != nil {
+		if ok, _ := c.Extension("AUTH"); !ok {
+			return ErrAuthUnsupported
+		}
+		if err := c.Auth(m.auth); err != nil {
+			return err
+		}
+	}
+
+	if err := c.Mail(from); err != nil {
+		return err
 	}
-	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, data); err != nil {
-		return "", fmt.Errorf("%w: %v", ErrTemplateParse, err)
+	for _, addr := range to {
+		if err := c.Rcpt(addr); err != nil {
+			return err
+		}
 	}
-	return buf.String(), nil
-}
 
-func executeTemplateString(templateStr string, data any) (string, error) {
-	tmpl, err := template.New("email").Parse(templateStr)
+	w, err := c.Data()
 	if err != nil {
-		return "", fmt.Errorf("%w: %v", ErrTemplateParse, err)
+		return err
 	}
-	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, data); err != nil {
-		return "", fmt.Errorf("%w: %v", ErrTemplateParse, err)
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
 	}
-	return buf.String(), nil
+	return c.Quit()
 }
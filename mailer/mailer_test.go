@@ -1,9 +1,20 @@
 package mailer
 
 import (
+	"context"
 	"errors"
+	"net"
+	"net/textproto"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/BevisDev/godev/templatex"
+	"github.com/BevisDev/godev/utils/async"
 )
 
 func testConfig() *Config {
@@ -166,6 +177,311 @@ func TestSendTemplateString(t *testing.T) {
 	}
 }
 
+func TestBuildMessage_Parts(t *testing.T) {
+	m := &Mailer{cfg: &Config{From: "sender@example.com", FromName: "Shop", Host: "smtp.example.com"}}
+
+	message, err := m.buildMessage(Mail{
+		To:       []string{"a@example.com"},
+		Bcc:      []string{"hidden@example.com"},
+		Subject:  "Xin chào",
+		Body:     `<img src="cid:logo.png"> Hello`,
+		TextBody: "Hello",
+		IsHTML:   true,
+		Attachments: []Attachment{
+			{Filename: "logo.png", Content: []byte{0x89, 'P', 'N', 'G'}, Inline: true},
+			{Filename: "invoice.pdf", Content: []byte("%PDF")},
+		},
+	})
+	if err != nil {
+		t.Fatalf("buildMessage() error = %v", err)
+	}
+
+	msgStr := string(message)
+	for _, want := range []string{
+		`From: "Shop" <sender@example.com>`,
+		"Subject: =?UTF-8?q?Xin_ch=C3=A0o?=",
+		"multipart/mixed",
+		"multipart/related",
+		"multipart/alternative",
+		"Content-Id: <logo.png>",
+		`attachment; filename=invoice.pdf`,
+	} {
+		if !strings.Contains(msgStr, want) {
+			t.Errorf("message should contain %q", want)
+		}
+	}
+	if strings.Contains(msgStr, "hidden@example.com") {
+		t.Error("Bcc must not appear in headers")
+	}
+}
+
+func TestSendContext_Retry(t *testing.T) {
+	m, _ := New(testConfig(), WithRetry(async.RetryPolicy{MaxAttempts: 3, InitialDelay: time.Millisecond}))
+
+	var calls atomic.Int32
+	var rcpt []string
+	m.send = func(ctx context.Context, from string, to []string, msg []byte) error {
+		rcpt = to
+		if calls.Add(1) < 3 {
+			return &textproto.Error{Code: 421, Msg: "try again later"}
+		}
+		return nil
+	}
+
+	mail := Mail{To: []string{"a@test.com"}, Bcc: []string{"b@test.com"}, Subject: "Hi", Body: "Body"}
+	if err := m.SendContext(context.Background(), mail); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Fatalf("expected 3 attempts, got %d", calls.Load())
+	}
+	if strings.Join(rcpt, ",") != "a@test.com,b@test.com" {
+		t.Fatalf("unexpected recipients %v", rcpt)
+	}
+
+	calls.Store(0)
+	m.send = func(ctx context.Context, from string, to []string, msg []byte) error {
+		calls.Add(1)
+		return &textproto.Error{Code: 550, Msg: "mailbox unavailable"}
+	}
+	if err := m.SendContext(context.Background(), mail); err == nil {
+		t.Fatal("expected error")
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("permanent errors must not be retried, got %d attempts", calls.Load())
+	}
+}
+
+func TestIsTransient(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{&textproto.Error{Code: 451}, true},
+		{&textproto.Error{Code: 554}, false},
+		{&net.OpError{Op: "dial", Err: errors.New("connection refused")}, true},
+		{&net.DNSError{Err: "no such host", IsNotFound: true}, false},
+		{ErrEmptyBody, false},
+	}
+	for _, tt := range tests {
+		if got := IsTransient(tt.err); got != tt.want {
+			t.Errorf("IsTransient(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestSendAsync(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		failed []string
+	)
+	m, _ := New(testConfig(),
+		WithAsync(2, 10),
+		WithRetry(async.RetryPolicy{MaxAttempts: 1}),
+		WithErrorHandler(func(mail Mail, err error) {
+			mu.Lock()
+			failed = append(failed, mail.Subject)
+			mu.Unlock()
+		}),
+	)
+
+	var sent atomic.Int32
+	m.send = func(ctx context.Context, from string, to []string, msg []byte) error {
+		if strings.Contains(string(msg), "Subject: fail") {
+			return errors.New("boom")
+		}
+		sent.Add(1)
+		return nil
+	}
+
+	for i := 0; i < 5; i++ {
+		if err := m.SendAsync(context.Background(), Mail{To: []string{"a@test.com"}, Subject: "ok", Body: "x"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	_ = m.SendAsync(context.Background(), Mail{To: []string{"a@test.com"}, Subject: "fail", Body: "x"})
+	if err := m.SendAsync(context.Background(), Mail{Subject: "invalid"}); !errors.Is(err, ErrNoRecipients) {
+		t.Fatalf("expected ErrNoRecipients, got %v", err)
+	}
+	m.Close()
+
+	if sent.Load() != 5 {
+		t.Fatalf("expected 5 sent, got %d", sent.Load())
+	}
+	if len(failed) != 1 || failed[0] != "fail" {
+		t.Fatalf("unexpected failures %v", failed)
+	}
+
+	plain, _ := New(testConfig())
+	if err := plain.SendAsync(context.Background(), Mail{}); !errors.Is(err, ErrNoQueue) {
+		t.Fatalf("expected ErrNoQueue, got %v", err)
+	}
+}
+
+func TestSendView(t *testing.T) {
+	fsys := fstest.MapFS{
+		"templates/welcome.html": {Data: []byte(`<h1>Hello {{upper .Name}}</h1>`)},
+		"templates/welcome.txt":  {Data: []byte(`Hello {{upper .Name}} & co`)},
+		"templates/reset.txt":    {Data: []byte(`Code: {{.Code}}`)},
+	}
+	m, err := New(testConfig(),
+		WithTemplates(fsys, "templates/*"),
+		WithFuncs(map[string]any{"upper": strings.ToUpper}),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	html, text, err := m.Render("welcome", map[string]string{"Name": "<go>"})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if html != "<h1>Hello &lt;GO&gt;</h1>" {
+		t.Errorf("html should be escaped, got %q", html)
+	}
+	if text != "Hello <GO> & co" {
+		t.Errorf("text must not be escaped, got %q", text)
+	}
+
+	var msg string
+	m.send = func(ctx context.Context, from string, to []string, b []byte) error {
+		msg = string(b)
+		return nil
+	}
+	if err := m.SendView(context.Background(), []string{"a@test.com"}, "Reset", "reset", map[string]string{"Code": "123"}); err != nil {
+		t.Fatalf("SendView() error = %v", err)
+	}
+	if !strings.Contains(msg, "text/plain") || !strings.Contains(msg, "Code: 123") {
+		t.Errorf("text-only view should be sent as text/plain, got %q", msg)
+	}
+
+	if _, _, err := m.Render("missing", nil); !errors.Is(err, ErrTemplateNotFound) {
+		t.Fatalf("expected ErrTemplateNotFound, got %v", err)
+	}
+	if _, err := New(testConfig(), WithTemplates(fstest.MapFS{"bad.html": {Data: []byte("{{")}}, "*.html")); !errors.Is(err, ErrTemplateParse) {
+		t.Fatalf("expected ErrTemplateParse, got %v", err)
+	}
+}
+
+func TestRenderWithEngine(t *testing.T) {
+	engine, err := templatex.New(fstest.MapFS{
+		"layouts/mail.html":     {Data: []byte(`<body>{{block "content" .}}{{end}}</body>`)},
+		"emails/welcome.html":   {Data: []byte(`{{define "content"}}Hi {{.Name | upper}}{{end}}{{template "layouts/mail.html" .}}`)},
+		"emails/welcome.txt":    {Data: []byte(`Hi {{.Name}}`)},
+		"emails/statement.html": {Data: []byte(`{{.Missing.Field}}`)},
+	})
+	if err != nil {
+		t.Fatalf("templatex.New() error = %v", err)
+	}
+	m, _ := New(testConfig(), WithEngine(engine))
+
+	html, text, err := m.Render("emails/welcome", map[string]string{"Name": "lan"})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if html != "<body>Hi LAN</body>" || text != "Hi lan" {
+		t.Errorf("unexpected render html=%q text=%q", html, text)
+	}
+
+	if _, _, err := m.Render("emails/missing", nil); !errors.Is(err, ErrTemplateNotFound) {
+		t.Fatalf("expected ErrTemplateNotFound, got %v", err)
+	}
+	if _, _, err := m.Render("emails/statement", 1); !errors.Is(err, ErrTemplateParse) {
+		t.Fatalf("expected ErrTemplateParse, got %v", err)
+	}
+}
+
+// fakeSMTP accepts one session without TLS or auth and returns the received DATA.
+func fakeSMTP(t *testing.T) (port int, data <-chan string) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+
+	ch := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		tp := textproto.NewConn(conn)
+		_ = tp.PrintfLine("220 localhost ESMTP")
+		for {
+			line, err := tp.ReadLine()
+			if err != nil {
+				return
+			}
+			switch cmd := strings.ToUpper(strings.Fields(line + " x")[0]); cmd {
+			case "EHLO", "HELO":
+				_ = tp.PrintfLine("250 localhost")
+			case "DATA":
+				_ = tp.PrintfLine("354 go ahead")
+				b, _ := tp.ReadDotBytes()
+				ch <- string(b)
+				_ = tp.PrintfLine("250 queued")
+			case "QUIT":
+				_ = tp.PrintfLine("221 bye")
+				return
+			default:
+				_ = tp.PrintfLine("250 ok")
+			}
+		}
+	}()
+
+	_, p, _ := net.SplitHostPort(ln.Addr().String())
+	port, _ = strconv.Atoi(p)
+	return port, ch
+}
+
+func TestDeliver(t *testing.T) {
+	port, data := fakeSMTP(t)
+	m, _ := New(&Config{Host: "127.0.0.1", Port: port, From: "noreply@example.com", TLS: TLSNone, Timeout: 5 * time.Second})
+
+	err := m.Send(Mail{To: []string{"a@test.com"}, Subject: "Hi", Body: "Hello over SMTP"})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	select {
+	case msg := <-data:
+		if !strings.Contains(msg, "Hello over SMTP") {
+			t.Errorf("unexpected message %q", msg)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no message received")
+	}
+}
+
+func TestDeliver_StartTLSRequired(t *testing.T) {
+	port, _ := fakeSMTP(t)
+	m, _ := New(&Config{Host: "127.0.0.1", Port: port, From: "noreply@example.com", TLS: TLSStartTLS, Timeout: 5 * time.Second})
+
+	err := m.Send(Mail{To: []string{"a@test.com"}, Subject: "Hi", Body: "x"})
+	if !errors.Is(err, ErrStartTLSUnsupported) {
+		t.Fatalf("expected ErrStartTLSUnsupported, got %v", err)
+	}
+}
+
+func TestDeliver_AuthRequired(t *testing.T) {
+	port, data := fakeSMTP(t)
+	m, _ := New(&Config{Host: "127.0.0.1", Port: port, From: "noreply@example.com", Username: "user", Password: "secret",
+		TLS: TLSNone, Timeout: 5 * time.Second})
+
+	err := m.Send(Mail{To: []string{"a@test.com"}, Subject: "Hi", Body: "x"})
+	if !errors.Is(err, ErrAuthUnsupported) {
+		t.Fatalf("expected ErrAuthUnsupported, got %v", err)
+	}
+	select {
+	case <-data:
+		t.Fatal("message sent without authentication")
+	default:
+	}
+}
+
 // Benchmark tests
 func BenchmarkBuildMessage(b *testing.B) {
 	m := &Mailer{
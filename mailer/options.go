@@ -0,0 +1,104 @@
+package mailer
+
+import (
+	"context"
+	"io/fs"
+
+	"github.com/BevisDev/godev/templatex"
+	"github.com/BevisDev/godev/utils/async"
+)
+
+// Queue runs send tasks in the background. *async.Pool implements it, and any
+// job runner can be plugged in with a small adapter.
+type Queue interface {
+	Submit(ctx context.Context, task async.Task) error
+}
+
+type Option func(*options)
+
+type options struct {
+	retry     async.RetryPolicy
+	fsys      fs.FS
+	patterns  []string
+	funcs     map[string]any
+	engine    *templatex.Engine
+	queue     Queue
+	workers   int
+	queueSize int
+	onError   func(Mail, error)
+}
+
+func defaultOptions() *options {
+	retry := async.DefaultRetryPolicy()
+	retry.RetryIf = IsTransient
+	return &options{
+		retry: retry,
+	}
+}
+
+// WithRetry sets the retry policy for transient failures (default: 3 attempts,
+// exponential backoff from 100ms). A nil RetryIf defaults to IsTransient.
+// Use MaxAttempts 1 to disable retries.
+func WithRetry(policy async.RetryPolicy) Option {
+	return func(o *options) {
+		if policy.RetryIf == nil {
+			policy.RetryIf = IsTransient
+		}
+		o.retry = policy
+	}
+}
+
+// WithTemplates loads templates from fsys (typically an embed.FS) matching patterns.
+// Files ending in .txt are parsed as text/template, all others as html/template.
+// Templates are referenced by file name (see Render and SendView).
+func WithTemplates(fsys fs.FS, patterns ...string) Option {
+	return func(o *options) {
+		o.fsys = fsys
+		o.patterns = patterns
+	}
+}
+
+// WithEngine renders views (see Render and SendView) with a shared template engine,
+// so mails use the layouts, partials and functions of templatex. It takes precedence
+// over WithTemplates; views are page names without extension, e.g. "emails/welcome".
+func WithEngine(engine *templatex.Engine) Option {
+	return func(o *options) {
+		o.engine = engine
+	}
+}
+
+// WithFuncs adds template functions available to templates loaded by WithTemplates.
+func WithFuncs(funcs map[string]any) Option {
+	return func(o *options) {
+		if o.funcs == nil {
+			o.funcs = make(map[string]any, len(funcs))
+		}
+		for k, v := range funcs {
+			o.funcs[k] = v
+		}
+	}
+}
+
+// WithQueue sends SendAsync mails through q.
+func WithQueue(q Queue) Option {
+	return func(o *options) {
+		o.queue = q
+	}
+}
+
+// WithAsync starts an internal worker pool for SendAsync. Close drains it.
+func WithAsync(workers, queueSize int) Option {
+	return func(o *options) {
+		o.workers = workers
+		o.queueSize = queueSize
+	}
+}
+
+// WithErrorHandler is called when an async send finally fails (default: log the error).
+func WithErrorHandler(fn func(Mail, error)) Option {
+	return func(o *options) {
+		if fn != nil {
+			o.onError = fn
+		}
+	}
+}
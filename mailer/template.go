@@ -0,0 +1,187 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"path"
+	"strings"
+	texttemplate "text/template"
+)
+
+// templates holds the templates loaded by WithTemplates.
+type templates struct {
+	html *htmltemplate.Template
+	text *texttemplate.Template
+}
+
+func loadTemplates(fsys fs.FS, patterns []string, funcs map[string]any) (*templates, error) {
+	var htmlFiles, textFiles []string
+	for _, p := range patterns {
+		matches, err := fs.Glob(fsys, p)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrTemplateParse, err)
+		}
+		for _, f := range matches {
+			if strings.EqualFold(path.Ext(f), ".txt") {
+				textFiles = append(textFiles, f)
+			} else {
+				htmlFiles = append(htmlFiles, f)
+			}
+		}
+	}
+
+	t := &templates{}
+	if len(htmlFiles) > 0 {
+		tmpl, err := htmltemplate.New("").Funcs(funcs).ParseFS(fsys, htmlFiles...)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrTemplateParse, err)
+		}
+		t.html = tmpl
+	}
+	if len(textFiles) > 0 {
+		tmpl, err := texttemplate.New("").Funcs(funcs).ParseFS(fsys, textFiles...)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrTemplateParse, err)
+		}
+		t.text = tmpl
+	}
+	return t, nil
+}
+
+// Render executes the view templates loaded by WithTemplates: "<view>.html" for the
+// HTML body and "<view>.txt" for the plain-text body. Either may be missing, not both.
+func (m *Mailer) Render(view string, data any) (html, text string, err error) {
+	if m.engine != nil {
+		return m.renderEngine(view, data)
+	}
+	if m.tmpl == nil {
+		return "", "", fmt.Errorf("%w: %s", ErrTemplateNotFound, view)
+	}
+
+	var buf bytes.Buffer
+	if m.tmpl.html != nil && m.tmpl.html.Lookup(view+".html") != nil {
+		if err := m.tmpl.html.ExecuteTemplate(&buf, view+".html", data); err != nil {
+			return "", "", fmt.Errorf("%w: %v", ErrTemplateParse, err)
+		}
+		html = buf.String()
+		buf.Reset()
+	}
+	if m.tmpl.text != nil && m.tmpl.text.Lookup(view+".txt") != nil {
+		if err := m.tmpl.text.ExecuteTemplate(&buf, view+".txt", data); err != nil {
+			return "", "", fmt.Errorf("%w: %v", ErrTemplateParse, err)
+		}
+		text = buf.String()
+	}
+
+	if html == "" && text == "" {
+		return "", "", fmt.Errorf("%w: %s", ErrTemplateNotFound, view)
+	}
+	return html, text, nil
+}
+
+// renderEngine renders "<view>.html" and "<view>.txt" with the engine of WithEngine.
+func (m *Mailer) renderEngine(view string, data any) (html, text string, err error) {
+	if m.engine.Has(view + ".html") {
+		if html, err = m.engine.Render(view+".html", data); err != nil {
+			return "", "", fmt.Errorf("%w: %v", ErrTemplateParse, err)
+		}
+	}
+	if m.engine.Has(view + ".txt") {
+		if text, err = m.engine.Render(view+".txt", data); err != nil {
+			return "", "", fmt.Errorf("%w: %v", ErrTemplateParse, err)
+		}
+	}
+
+	if html == "" && text == "" {
+		return "", "", fmt.Errorf("%w: %s", ErrTemplateNotFound, view)
+	}
+	return html, text, nil
+}
+
+// SendView renders view (see Render) and sends it. With both templates present
+// the mail is sent as multipart/alternative.
+//
+// Example:
+//
+//	//go:embed templates
+//	var tmplFS embed.FS
+//
+//	m, _ := mailer.New(cfg, mailer.WithTemplates(tmplFS, "templates/*"))
+//	err := m.SendView(ctx, []string{"user@example.com"}, "Welcome", "welcome", data)
+func (m *Mailer) SendView(ctx context.Context, to []string, subject, view string, data any) error {
+	mail, err := m.viewMail(to, subject, view, data)
+	if err != nil {
+		return err
+	}
+	return m.SendContext(ctx, mail)
+}
+
+func (m *Mailer) viewMail(to []string, subject, view string, data any) (Mail, error) {
+	html, text, err := m.Render(view, data)
+	if err != nil {
+		return Mail{}, err
+	}
+
+	mail := Mail{To: to, Subject: subject}
+	if html != "" {
+		mail.Body, mail.IsHTML, mail.TextBody = html, true, text
+	} else {
+		mail.Body = text
+	}
+	return mail, nil
+}
+
+// SendTemplate sends an email by rendering the template at templatePath with data.
+func (m *Mailer) SendTemplate(to []string, subject string, templatePath string, data any) error {
+	body, err := executeTemplateFile(templatePath, data)
+	if err != nil {
+		return err
+	}
+	return m.Send(Mail{
+		To:      to,
+		Subject: subject,
+		Body:    body,
+		IsHTML:  true,
+	})
+}
+
+// SendTemplateString sends an email by rendering the template string with data.
+func (m *Mailer) SendTemplateString(to []string, subject string, templateStr string, data any) error {
+	body, err := executeTemplateString(templateStr, data)
+	if err != nil {
+		return err
+	}
+	return m.Send(Mail{
+		To:      to,
+		Subject: subject,
+		Body:    body,
+		IsHTML:  true,
+	})
+}
+
+func executeTemplateFile(templatePath string, data any) (string, error) {
+	tmpl, err := htmltemplate.ParseFiles(templatePath)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrTemplateParse, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrTemplateParse, err)
+	}
+	return buf.String(), nil
+}
+
+func executeTemplateString(templateStr string, data any) (string, error) {
+	tmpl, err := htmltemplate.New("email").Parse(templateStr)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrTemplateParse, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrTemplateParse, err)
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,108 @@
+// Package metrics is a vendor-neutral metrics API. Libraries record through
+// a Provider and the application chooses the backend: Prometheus
+// (metrics/prometheus), StatsD (metrics/statsd) or Nop.
+package metrics
+
+import "time"
+
+// DefBuckets are the default histogram buckets, in seconds for timers.
+var DefBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// Status label values reported by the built-in instrumentation.
+const (
+	StatusOK    = "ok"
+	StatusError = "error"
+)
+
+// Opts describes a metric.
+type Opts struct {
+	// Name in snake_case, e.g. "http_client_request_duration_seconds".
+	Name string
+
+	// Help describes the metric.
+	Help string
+
+	// Labels are the label names; label values are passed positionally, in the same order.
+	Labels []string
+
+	// Buckets are the upper bounds of a Histogram or Timer (seconds); DefBuckets when empty.
+	Buckets []float64
+}
+
+// Provider creates metrics on a backend.
+//
+// Creating a metric twice with the same name returns the same metric, so
+// several components can share one. Label values are matched to Opts.Labels
+// by position; missing values are empty and extra values are ignored.
+type Provider interface {
+	Counter(opts Opts) Counter
+	Gauge(opts Opts) Gauge
+	Histogram(opts Opts) Histogram
+	Timer(opts Opts) Timer
+}
+
+// Counter is a value that only goes up, e.g. requests served.
+type Counter interface {
+	Inc(labelValues ...string)
+	Add(v float64, labelValues ...string)
+}
+
+// Gauge is a value that goes up and down, e.g. jobs running.
+type Gauge interface {
+	Set(v float64, labelValues ...string)
+	Add(v float64, labelValues ...string)
+}
+
+// Histogram samples observations into buckets, e.g. response sizes.
+type Histogram interface {
+	Observe(v float64, labelValues ...string)
+}
+
+// Timer records durations, e.g. request latency.
+type Timer interface {
+	Record(d time.Duration, labelValues ...string)
+}
+
+// Since records the time elapsed since start on t.
+//
+//	defer metrics.Since(timer, time.Now(), "GET")
+func Since(t Timer, start time.Time, labelValues ...string) {
+	t.Record(time.Since(start), labelValues...)
+}
+
+// Status returns StatusError when err is not nil, StatusOK otherwise.
+func Status(err error) string {
+	if err != nil {
+		return StatusError
+	}
+	return StatusOK
+}
+
+// LabelValues returns values resized to the n label names of a metric:
+// missing values are empty and extra values are dropped.
+func LabelValues(n int, values []string) []string {
+	if len(values) == n {
+		return values
+	}
+	out := make([]string, n)
+	copy(out, values)
+	return out
+}
+
+// Nop returns a Provider whose metrics discard everything.
+func Nop() Provider {
+	return nop{}
+}
+
+type nop struct{}
+
+func (nop) Counter(Opts) Counter     { return nop{} }
+func (nop) Gauge(Opts) Gauge         { return nop{} }
+func (nop) Histogram(Opts) Histogram { return nop{} }
+func (nop) Timer(Opts) Timer         { return nop{} }
+
+func (nop) Inc(...string)                   {}
+func (nop) Add(float64, ...string)          {}
+func (nop) Set(float64, ...string)          {}
+func (nop) Observe(float64, ...string)      {}
+func (nop) Record(time.Duration, ...string) {}
@@ -0,0 +1,29 @@
+package prometheus
+
+type Option func(*options)
+
+type options struct {
+	// namespace prefixes every metric name, e.g. "orders" -> "orders_http_requests_total".
+	namespace string
+
+	// constLabels are added to every series.
+	constLabels map[string]string
+}
+
+func defaultOptions() *options {
+	return &options{}
+}
+
+// WithNamespace prefixes every metric name with namespace and an underscore.
+func WithNamespace(namespace string) Option {
+	return func(o *options) {
+		o.namespace = namespace
+	}
+}
+
+// WithConstLabels adds labels to every series, e.g. {"service": "orders"}.
+func WithConstLabels(labels map[string]string) Option {
+	return func(o *options) {
+		o.constLabels = labels
+	}
+}
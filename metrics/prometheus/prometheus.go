@@ -0,0 +1,325 @@
+// Package prometheus is a metrics.Provider exposing metrics in the Prometheus
+// text format, without depending on the Prometheus client library.
+package prometheus
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/BevisDev/godev/metrics"
+)
+
+// ContentType is the content type of the text exposition format.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+var nameRe = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+
+type kind string
+
+const (
+	kindCounter   kind = "counter"
+	kindGauge     kind = "gauge"
+	kindHistogram kind = "histogram"
+)
+
+// Registry holds the metrics of an application and serves them to Prometheus.
+type Registry struct {
+	*options
+	mu       sync.RWMutex
+	families map[string]*family
+}
+
+var _ metrics.Provider = (*Registry)(nil)
+
+// New creates an empty Registry.
+//
+// Example:
+//
+//	reg := prometheus.New(prometheus.WithNamespace("orders"))
+//	db.UseMetrics(reg)
+//	r.GET("/metrics", gin.WrapH(reg.Handler()))
+func New(opts ...Option) *Registry {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &Registry{options: o, families: make(map[string]*family)}
+}
+
+// Counter creates (or returns) a counter. It panics when the name is invalid
+// or already used by a metric of another type.
+func (r *Registry) Counter(opts metrics.Opts) metrics.Counter {
+	return counter{r.family(kindCounter, opts, false)}
+}
+
+// Gauge creates (or returns) a gauge.
+func (r *Registry) Gauge(opts metrics.Opts) metrics.Gauge {
+	return gauge{r.family(kindGauge, opts, false)}
+}
+
+// Histogram creates (or returns) a histogram.
+func (r *Registry) Histogram(opts metrics.Opts) metrics.Histogram {
+	return histogram{r.family(kindHistogram, opts, false)}
+}
+
+// Timer creates (or returns) a histogram of durations in seconds.
+func (r *Registry) Timer(opts metrics.Opts) metrics.Timer {
+	return timer{r.family(kindHistogram, opts, true)}
+}
+
+func (r *Registry) family(k kind, opts metrics.Opts, isTimer bool) *family {
+	name := opts.Name
+	if r.namespace != "" {
+		name = r.namespace + "_" + name
+	}
+	if !nameRe.MatchString(name) {
+		panic(fmt.Sprintf("[prometheus] invalid metric name %q", name))
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if f, ok := r.families[name]; ok {
+		if f.kind != k || f.timer != isTimer {
+			panic(fmt.Sprintf("[prometheus] metric %q already registered as another type", name))
+		}
+		return f
+	}
+
+	f := &family{
+		name:   name,
+		help:   opts.Help,
+		kind:   k,
+		timer:  isTimer,
+		labels: opts.Labels,
+		series: make(map[string]*series),
+	}
+	for l, v := range r.constLabels {
+		f.constLabels = append(f.constLabels, label{l, v})
+	}
+	sort.Slice(f.constLabels, func(i, j int) bool { return f.constLabels[i].name < f.constLabels[j].name })
+	if k == kindHistogram {
+		f.buckets = opts.Buckets
+		if len(f.buckets) == 0 {
+			f.buckets = metrics.DefBuckets
+		}
+		f.buckets = slices.Clone(f.buckets)
+		slices.Sort(f.buckets)
+	}
+	r.families[name] = f
+	return f
+}
+
+// Handler returns an http.Handler serving the metrics in the text format.
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", ContentType)
+		_, _ = r.WriteTo(w)
+	})
+}
+
+// WriteTo writes the metrics in the text format to w.
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	r.mu.RLock()
+	families := make([]*family, 0, len(r.families))
+	for _, f := range r.families {
+		families = append(families, f)
+	}
+	r.mu.RUnlock()
+	sort.Slice(families, func(i, j int) bool { return families[i].name < families[j].name })
+
+	cw := &countWriter{w: bufio.NewWriter(w)}
+	for _, f := range families {
+		f.write(cw)
+	}
+	err := cw.w.Flush()
+	if cw.err != nil {
+		err = cw.err
+	}
+	return cw.n, err
+}
+
+type label struct {
+	name, value string
+}
+
+type family struct {
+	name        string
+	help        string
+	kind        kind
+	timer       bool
+	labels      []string
+	constLabels []label
+	buckets     []float64
+
+	mu     sync.Mutex
+	series map[string]*series
+}
+
+type series struct {
+	values []string
+
+	value float64 // counter, gauge
+
+	counts []uint64 // histogram, per bucket (not cumulative)
+	sum    float64
+	count  uint64
+}
+
+// get returns the series of values, creating it. f.mu must be held.
+func (f *family) get(values []string) *series {
+	values = metrics.LabelValues(len(f.labels), values)
+	key := strings.Join(values, "\xff")
+	s, ok := f.series[key]
+	if !ok {
+		s = &series{values: slices.Clone(values)}
+		if f.kind == kindHistogram {
+			s.counts = make([]uint64, len(f.buckets))
+		}
+		f.series[key] = s
+	}
+	return s
+}
+
+func (f *family) add(v float64, values []string) {
+	f.mu.Lock()
+	f.get(values).value += v
+	f.mu.Unlock()
+}
+
+func (f *family) set(v float64, values []string) {
+	f.mu.Lock()
+	f.get(values).value = v
+	f.mu.Unlock()
+}
+
+func (f *family) observe(v float64, values []string) {
+	f.mu.Lock()
+	s := f.get(values)
+	if i := sort.SearchFloat64s(f.buckets, v); i < len(f.buckets) {
+		s.counts[i]++
+	}
+	s.sum += v
+	s.count++
+	f.mu.Unlock()
+}
+
+func (f *family) write(w *countWriter) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	keys := make([]string, 0, len(f.series))
+	for k := range f.series {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	if f.help != "" {
+		w.printf("# HELP %s %s\n", f.name, escapeHelp(f.help))
+	}
+	w.printf("# TYPE %s %s\n", f.name, f.kind)
+
+	for _, k := range keys {
+		s := f.series[k]
+		if f.kind != kindHistogram {
+			w.printf("%s%s %s\n", f.name, f.labelSet(s.values, ""), formatFloat(s.value))
+			continue
+		}
+
+		var cumulative uint64
+		for i, b := range f.buckets {
+			cumulative += s.counts[i]
+			w.printf("%s_bucket%s %d\n", f.name, f.labelSet(s.values, formatFloat(b)), cumulative)
+		}
+		w.printf("%s_bucket%s %d\n", f.name, f.labelSet(s.values, "+Inf"), s.count)
+		w.printf("%s_sum%s %s\n", f.name, f.labelSet(s.values, ""), formatFloat(s.sum))
+		w.printf("%s_count%s %d\n", f.name, f.labelSet(s.values, ""), s.count)
+	}
+}
+
+// labelSet renders {a="1",b="2"}, with an le label for histogram buckets.
+func (f *family) labelSet(values []string, le string) string {
+	var parts []string
+	for _, l := range f.constLabels {
+		parts = append(parts, l.name+`="`+escapeValue(l.value)+`"`)
+	}
+	for i, name := range f.labels {
+		parts = append(parts, name+`="`+escapeValue(values[i])+`"`)
+	}
+	if le != "" {
+		parts = append(parts, `le="`+le+`"`)
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+var (
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	valueEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(s string) string  { return helpEscaper.Replace(s) }
+func escapeValue(s string) string { return valueEscaper.Replace(s) }
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+type countWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+func (c *countWriter) printf(format string, args ...any) {
+	if c.err != nil {
+		return
+	}
+	n, err := fmt.Fprintf(c.w, format, args...)
+	c.n += int64(n)
+	c.err = err
+}
+
+type counter struct{ f *family }
+
+func (c counter) Inc(values ...string) { c.f.add(1, values) }
+
+// Add ignores negative values, counters only go up.
+func (c counter) Add(v float64, values ...string) {
+	if v >= 0 {
+		c.f.add(v, values)
+	}
+}
+
+type gauge struct{ f *family }
+
+func (g gauge) Set(v float64, values ...string) { g.f.set(v, values) }
+func (g gauge) Add(v float64, values ...string) { g.f.add(v, values) }
+
+type histogram struct{ f *family }
+
+func (h histogram) Observe(v float64, values ...string) { h.f.observe(v, values) }
+
+type timer struct{ f *family }
+
+func (t timer) Record(d time.Duration, values ...string) { t.f.observe(d.Seconds(), values) }
@@ -0,0 +1,53 @@
+package prometheus
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/BevisDev/godev/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry(t *testing.T) {
+	r := New(WithNamespace("app"), WithConstLabels(map[string]string{"service": "orders"}))
+
+	requests := r.Counter(metrics.Opts{Name: "requests_total", Help: "Requests.\nServed.", Labels: []string{"method", "path"}})
+	requests.Inc("GET", `/a"b`)
+	requests.Add(2, "GET", `/a"b`)
+	requests.Add(-5, "GET", `/a"b`)
+	r.Counter(metrics.Opts{Name: "requests_total", Labels: []string{"method", "path"}}).Inc("POST")
+
+	r.Gauge(metrics.Opts{Name: "running"}).Set(3)
+	r.Gauge(metrics.Opts{Name: "running"}).Add(-1)
+
+	latency := r.Timer(metrics.Opts{Name: "latency_seconds", Buckets: []float64{0.5, 0.1}})
+	latency.Record(50 * time.Millisecond)
+	latency.Record(200 * time.Millisecond)
+	latency.Record(2 * time.Second)
+
+	rec := httptest.NewRecorder()
+	r.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	assert.Equal(t, ContentType, rec.Header().Get("Content-Type"))
+	assert.Equal(t, `# TYPE app_latency_seconds histogram
+app_latency_seconds_bucket{service="orders",le="0.1"} 1
+app_latency_seconds_bucket{service="orders",le="0.5"} 2
+app_latency_seconds_bucket{service="orders",le="+Inf"} 3
+app_latency_seconds_sum{service="orders"} 2.25
+app_latency_seconds_count{service="orders"} 3
+# HELP app_requests_total Requests.\nServed.
+# TYPE app_requests_total counter
+app_requests_total{service="orders",method="GET",path="/a\"b"} 3
+app_requests_total{service="orders",method="POST",path=""} 1
+# TYPE app_running gauge
+app_running{service="orders"} 2
+`, rec.Body.String())
+}
+
+func TestRegistry_Conflicts(t *testing.T) {
+	r := New()
+	r.Counter(metrics.Opts{Name: "jobs"})
+
+	assert.Panics(t, func() { r.Gauge(metrics.Opts{Name: "jobs"}) })
+	assert.Panics(t, func() { r.Counter(metrics.Opts{Name: "bad-name"}) })
+}
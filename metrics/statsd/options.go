@@ -0,0 +1,75 @@
+package statsd
+
+import "time"
+
+// TagFormat is how labels are sent to the StatsD server.
+type TagFormat int
+
+const (
+	// TagsDogStatsD appends labels as tags: name:1|c|#method:GET,status:200 (DataDog, Telegraf).
+	TagsDogStatsD TagFormat = iota
+
+	// TagsInflux appends labels to the name: name,method=GET,status=200:1|c (Telegraf).
+	TagsInflux
+
+	// TagsGraphite appends label values to the name: name.GET.200:1|c (plain StatsD).
+	TagsGraphite
+)
+
+type Option func(*options)
+
+type options struct {
+	prefix        string
+	tagFormat     TagFormat
+	tags          map[string]string
+	flushInterval time.Duration
+	maxPacketSize int
+}
+
+func defaultOptions() *options {
+	return &options{
+		tagFormat:     TagsDogStatsD,
+		flushInterval: time.Second,
+		maxPacketSize: 1432,
+	}
+}
+
+// WithPrefix prefixes every metric name with prefix and a dot.
+func WithPrefix(prefix string) Option {
+	return func(o *options) {
+		o.prefix = prefix
+	}
+}
+
+// WithTagFormat sets how labels are sent (default TagsDogStatsD).
+func WithTagFormat(f TagFormat) Option {
+	return func(o *options) {
+		o.tagFormat = f
+	}
+}
+
+// WithTags adds tags to every metric, e.g. {"service": "orders", "env": "prod"}.
+// They are ignored by TagsGraphite.
+func WithTags(tags map[string]string) Option {
+	return func(o *options) {
+		o.tags = tags
+	}
+}
+
+// WithFlushInterval sets how often buffered metrics are sent (default 1s).
+func WithFlushInterval(d time.Duration) Option {
+	return func(o *options) {
+		if d > 0 {
+			o.flushInterval = d
+		}
+	}
+}
+
+// WithMaxPacketSize sets the maximum UDP payload size (default 1432, safe for a 1500 MTU).
+func WithMaxPacketSize(n int) Option {
+	return func(o *options) {
+		if n > 0 {
+			o.maxPacketSize = n
+		}
+	}
+}
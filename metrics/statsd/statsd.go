@@ -0,0 +1,273 @@
+// Package statsd is a metrics.Provider sending metrics to a StatsD server over UDP.
+package statsd
+
+import (
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/BevisDev/godev/metrics"
+)
+
+// Client buffers metrics and sends them to a StatsD server.
+type Client struct {
+	*options
+	conn       net.Conn
+	globalTags string // WithTags rendered in the tag format
+
+	mu  sync.Mutex
+	buf []byte
+
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+var _ metrics.Provider = (*Client)(nil)
+
+// New creates a Client sending to addr (host:port) and starts its flush loop.
+// Call Close to flush the remaining metrics on shutdown.
+//
+// Example:
+//
+//	sd, err := statsd.New("127.0.0.1:8125", statsd.WithPrefix("orders"))
+//	defer sd.Close()
+//	db.UseMetrics(sd)
+func New(addr string, opts ...Option) (*Client, error) {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{
+		options: o,
+		conn:    conn,
+		buf:     make([]byte, 0, o.maxPacketSize),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	c.globalTags = c.renderTags()
+	go c.loop()
+	return c, nil
+}
+
+// Counter creates a counter, sent with the "c" type.
+func (c *Client) Counter(opts metrics.Opts) metrics.Counter {
+	return counter{c.metric(opts)}
+}
+
+// Gauge creates a gauge, sent with the "g" type; Add sends a relative change.
+func (c *Client) Gauge(opts metrics.Opts) metrics.Gauge {
+	return gauge{c.metric(opts)}
+}
+
+// Histogram creates a histogram, sent with the "h" type ("ms" unless TagsDogStatsD).
+// Buckets are computed by the server.
+func (c *Client) Histogram(opts metrics.Opts) metrics.Histogram {
+	return histogram{c.metric(opts)}
+}
+
+// Timer creates a timer, sent in milliseconds with the "ms" type.
+func (c *Client) Timer(opts metrics.Opts) metrics.Timer {
+	return timer{c.metric(opts)}
+}
+
+// Flush sends the buffered metrics.
+func (c *Client) Flush() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.flush()
+}
+
+// Close stops the flush loop, sends the buffered metrics and closes the connection.
+func (c *Client) Close() error {
+	c.once.Do(func() { close(c.stop) })
+	<-c.done
+	err := c.Flush()
+	if cerr := c.conn.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+func (c *Client) loop() {
+	defer close(c.done)
+	t := time.NewTicker(c.flushInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			_ = c.Flush()
+		case <-c.stop:
+			return
+		}
+	}
+}
+
+// flush sends buf. c.mu must be held.
+func (c *Client) flush() error {
+	if len(c.buf) == 0 {
+		return nil
+	}
+	_, err := c.conn.Write(c.buf)
+	c.buf = c.buf[:0]
+	return err
+}
+
+// send buffers a line, flushing first when the packet would grow too large.
+func (c *Client) send(line string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.buf) > 0 && len(c.buf)+1+len(line) > c.maxPacketSize {
+		_ = c.flush()
+	}
+	if len(c.buf) > 0 {
+		c.buf = append(c.buf, '\n')
+	}
+	c.buf = append(c.buf, line...)
+}
+
+func (c *Client) metric(opts metrics.Opts) *metric {
+	name := opts.Name
+	if c.prefix != "" {
+		name = c.prefix + "." + name
+	}
+	return &metric{c: c, name: name, labels: opts.Labels}
+}
+
+func (c *Client) renderTags() string {
+	if len(c.options.tags) == 0 || c.tagFormat == TagsGraphite {
+		return ""
+	}
+	keys := make([]string, 0, len(c.options.tags))
+	for k := range c.options.tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = c.tag(k, c.options.tags[k])
+	}
+	return strings.Join(parts, ",")
+}
+
+func (c *Client) tag(k, v string) string {
+	if c.tagFormat == TagsInflux {
+		return k + "=" + v
+	}
+	return k + ":" + v
+}
+
+type metric struct {
+	c      *Client
+	name   string
+	labels []string
+}
+
+// line renders name:value|typ with the labels in the tag format of the client.
+func (m *metric) line(value, typ string, values []string) string {
+	values = metrics.LabelValues(len(m.labels), values)
+	c := m.c
+
+	var b strings.Builder
+	b.WriteString(m.name)
+	switch c.tagFormat {
+	case TagsGraphite:
+		for _, v := range values {
+			b.WriteByte('.')
+			b.WriteString(strings.ReplaceAll(sanitize(v), ".", "_"))
+		}
+	case TagsInflux:
+		if c.globalTags != "" {
+			b.WriteByte(',')
+			b.WriteString(c.globalTags)
+		}
+		for i, l := range m.labels {
+			b.WriteByte(',')
+			b.WriteString(c.tag(l, sanitize(values[i])))
+		}
+	}
+
+	b.WriteByte(':')
+	b.WriteString(value)
+	b.WriteByte('|')
+	b.WriteString(typ)
+
+	if c.tagFormat == TagsDogStatsD && (c.globalTags != "" || len(m.labels) > 0) {
+		b.WriteString("|#")
+		b.WriteString(c.globalTags)
+		for i, l := range m.labels {
+			if i > 0 || c.globalTags != "" {
+				b.WriteByte(',')
+			}
+			b.WriteString(c.tag(l, sanitize(values[i])))
+		}
+	}
+	return b.String()
+}
+
+// sanitize replaces the characters with a meaning in the line protocol.
+var sanitizer = strings.NewReplacer(":", "_", "|", "_", ",", "_", "#", "_", "=", "_", "\n", "_", " ", "_")
+
+func sanitize(v string) string {
+	if v == "" {
+		return "none"
+	}
+	return sanitizer.Replace(v)
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+type counter struct{ m *metric }
+
+func (c counter) Inc(values ...string) { c.Add(1, values...) }
+
+func (c counter) Add(v float64, values ...string) {
+	c.m.c.send(c.m.line(formatFloat(v), "c", values))
+}
+
+type gauge struct{ m *metric }
+
+func (g gauge) Set(v float64, values ...string) {
+	if v < 0 {
+		// a leading sign is a relative change, reset to 0 first
+		g.m.c.send(g.m.line("0", "g", values))
+	}
+	g.m.c.send(g.m.line(formatFloat(v), "g", values))
+}
+
+func (g gauge) Add(v float64, values ...string) {
+	s := formatFloat(v)
+	if v >= 0 {
+		s = "+" + s
+	}
+	g.m.c.send(g.m.line(s, "g", values))
+}
+
+type histogram struct{ m *metric }
+
+func (h histogram) Observe(v float64, values ...string) {
+	typ := "h"
+	if h.m.c.tagFormat != TagsDogStatsD {
+		typ = "ms"
+	}
+	h.m.c.send(h.m.line(formatFloat(v), typ, values))
+}
+
+type timer struct{ m *metric }
+
+func (t timer) Record(d time.Duration, values ...string) {
+	t.m.c.send(t.m.line(formatFloat(float64(d)/float64(time.Millisecond)), "ms", values))
+}
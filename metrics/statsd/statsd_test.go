@@ -0,0 +1,78 @@
+package statsd
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BevisDev/godev/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func listen(t *testing.T) (*net.UDPConn, string) {
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn, conn.LocalAddr().String()
+}
+
+func read(t *testing.T, conn *net.UDPConn) []string {
+	buf := make([]byte, 2048)
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+	n, err := conn.Read(buf)
+	require.NoError(t, err)
+	return strings.Split(string(buf[:n]), "\n")
+}
+
+func TestClient_DogStatsD(t *testing.T) {
+	conn, addr := listen(t)
+	c, err := New(addr, WithPrefix("orders"), WithTags(map[string]string{"env": "prod"}), WithFlushInterval(time.Hour))
+	require.NoError(t, err)
+
+	c.Counter(metrics.Opts{Name: "requests", Labels: []string{"method", "status"}}).Inc("GET", "200")
+	c.Gauge(metrics.Opts{Name: "running"}).Add(-1)
+	c.Histogram(metrics.Opts{Name: "size", Labels: []string{"route"}}).Observe(512, "/a:b")
+	c.Timer(metrics.Opts{Name: "latency", Labels: []string{"host"}}).Record(1500*time.Microsecond, "api.partner.vn")
+	require.NoError(t, c.Close())
+
+	assert.Equal(t, []string{
+		"orders.requests:1|c|#env:prod,method:GET,status:200",
+		"orders.running:-1|g|#env:prod",
+		"orders.size:512|h|#env:prod,route:/a_b",
+		"orders.latency:1.5|ms|#env:prod,host:api.partner.vn",
+	}, read(t, conn))
+}
+
+func TestClient_Formats(t *testing.T) {
+	conn, addr := listen(t)
+
+	influx, err := New(addr, WithTagFormat(TagsInflux), WithTags(map[string]string{"env": "prod"}))
+	require.NoError(t, err)
+	influx.Counter(metrics.Opts{Name: "jobs", Labels: []string{"job", "status"}}).Add(2, "sync")
+	require.NoError(t, influx.Close())
+	assert.Equal(t, []string{"jobs,env=prod,job=sync,status=none:2|c"}, read(t, conn))
+
+	graphite, err := New(addr, WithTagFormat(TagsGraphite), WithTags(map[string]string{"env": "prod"}))
+	require.NoError(t, err)
+	graphite.Gauge(metrics.Opts{Name: "conns", Labels: []string{"db"}}).Set(3, "main.db")
+	graphite.Histogram(metrics.Opts{Name: "size"}).Observe(1)
+	require.NoError(t, graphite.Close())
+	assert.Equal(t, []string{"conns.main_db:3|g", "size:1|ms"}, read(t, conn))
+}
+
+func TestClient_PacketSize(t *testing.T) {
+	conn, addr := listen(t)
+	c, err := New(addr, WithMaxPacketSize(20), WithFlushInterval(time.Hour))
+	require.NoError(t, err)
+
+	counter := c.Counter(metrics.Opts{Name: "hits"})
+	counter.Inc()
+	counter.Inc()
+	counter.Inc()
+
+	assert.Equal(t, []string{"hits:1|c", "hits:1|c"}, read(t, conn))
+	require.NoError(t, c.Close())
+	assert.Equal(t, []string{"hits:1|c"}, read(t, conn))
+}
@@ -22,6 +22,9 @@ type Config struct {
 
 	// Timeout sets the maximum duration allowed for each Migration operation.
 	Timeout time.Duration
+
+	// SchemaFile is a declarative schema (YAML or JSON) checked by Verify.
+	SchemaFile string
 }
 
 func (c *Config) clone() *Config {
@@ -0,0 +1,8 @@
+package migration
+
+import "errors"
+
+var (
+	ErrNoSchemaFile = errors.New("[migration] no schema file, set Config.SchemaFile")
+	ErrSchemaDrift  = errors.New("[migration] schema drift detected")
+)
@@ -14,7 +14,8 @@ import (
 // It holds configuration for the migration directory, target database type, and the active *sql.DB connection.
 // The Migration dialect and working directory are initialized via the Init method.
 type Migration struct {
-	cf *Config
+	cf        *Config
+	inspector Inspector // live schema for Verify, created from DB on first use
 }
 
 // New creates a new Migration instance with the given Migration directory,
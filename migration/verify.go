@@ -0,0 +1,321 @@
+package migration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/BevisDev/godev/database"
+	"github.com/BevisDev/godev/utils"
+	"github.com/jmoiron/sqlx"
+	"go.yaml.in/yaml/v3"
+)
+
+// Schema is the expected database schema, usually loaded from a declarative
+// schema file (see LoadSchema).
+type Schema struct {
+	Tables []Table `yaml:"tables" json:"tables"`
+}
+
+// Table is an expected table. Indexes that are not listed are reported as extra,
+// except primary key indexes.
+type Table struct {
+	Name    string   `yaml:"name" json:"name"`
+	Columns []Column `yaml:"columns" json:"columns"`
+	Indexes []Index  `yaml:"indexes" json:"indexes"`
+}
+
+// Column is an expected column. Type is compared without length or precision,
+// e.g. "varchar(255)" matches "varchar"; an empty Type or nil Nullable is not checked.
+type Column struct {
+	Name     string `yaml:"name" json:"name"`
+	Type     string `yaml:"type" json:"type"`
+	Nullable *bool  `yaml:"nullable" json:"nullable"`
+}
+
+// Index is an expected index.
+type Index struct {
+	Name    string   `yaml:"name" json:"name"`
+	Columns []string `yaml:"columns" json:"columns"`
+	Unique  bool     `yaml:"unique" json:"unique"`
+}
+
+// DriftKind classifies a difference between the expected and the live schema.
+type DriftKind string
+
+const (
+	DriftMissingTable     DriftKind = "missing_table"
+	DriftMissingColumn    DriftKind = "missing_column"
+	DriftExtraColumn      DriftKind = "extra_column"
+	DriftTypeMismatch     DriftKind = "type_mismatch"
+	DriftNullableMismatch DriftKind = "nullable_mismatch"
+	DriftMissingIndex     DriftKind = "missing_index"
+	DriftExtraIndex       DriftKind = "extra_index"
+	DriftIndexMismatch    DriftKind = "index_mismatch"
+)
+
+// Drift is one difference between the expected and the live schema.
+type Drift struct {
+	Kind     DriftKind `json:"kind"`
+	Table    string    `json:"table"`
+	Column   string    `json:"column,omitempty"`
+	Index    string    `json:"index,omitempty"`
+	Expected string    `json:"expected,omitempty"`
+	Actual   string    `json:"actual,omitempty"`
+}
+
+func (d Drift) String() string {
+	target := d.Table
+	switch {
+	case d.Column != "":
+		target += "." + d.Column
+	case d.Index != "":
+		target += " index " + d.Index
+	}
+	if d.Expected == "" && d.Actual == "" {
+		return fmt.Sprintf("%s: %s", d.Kind, target)
+	}
+	return fmt.Sprintf("%s: %s (expected %q, actual %q)", d.Kind, target, d.Expected, d.Actual)
+}
+
+// Report is the result of Verify.
+type Report struct {
+	Drifts []Drift `json:"drifts"`
+}
+
+// OK reports whether the live schema matches the expected one.
+func (r *Report) OK() bool {
+	return len(r.Drifts) == 0
+}
+
+// Err returns nil without drift, otherwise an error wrapping ErrSchemaDrift listing every drift.
+func (r *Report) Err() error {
+	if r.OK() {
+		return nil
+	}
+	lines := make([]string, len(r.Drifts))
+	for i, d := range r.Drifts {
+		lines[i] = d.String()
+	}
+	return fmt.Errorf("%w:\n  %s", ErrSchemaDrift, strings.Join(lines, "\n  "))
+}
+
+// Inspector reads the live schema; *database.DB implements it.
+type Inspector interface {
+	Columns(ctx context.Context, table string) ([]database.Column, error)
+	Indexes(ctx context.Context, table string) ([]database.Index, error)
+}
+
+// LoadSchema reads a declarative schema file in YAML or JSON (by extension).
+func LoadSchema(path string) (*Schema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("[migration] read schema file: %w", err)
+	}
+
+	var s Schema
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = json.Unmarshal(data, &s)
+	} else {
+		err = yaml.Unmarshal(data, &s)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("[migration] parse schema file %s: %w", path, err)
+	}
+	return &s, nil
+}
+
+// Verify compares the schema file of Config.SchemaFile with the live database.
+// A drift is not an error: check Report.OK or Report.Err.
+func (m *Migration) Verify(ctx context.Context) (*Report, error) {
+	if m.cf.SchemaFile == "" {
+		return nil, ErrNoSchemaFile
+	}
+	s, err := LoadSchema(m.cf.SchemaFile)
+	if err != nil {
+		return nil, err
+	}
+	return m.VerifySchema(ctx, s)
+}
+
+// VerifySchema compares s with the live database.
+func (m *Migration) VerifySchema(c context.Context, s *Schema) (*Report, error) {
+	in, err := m.getInspector()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := utils.NewCtxTimeout(c, m.cf.Timeout)
+	defer cancel()
+
+	report := &Report{}
+	for _, t := range s.Tables {
+		drifts, err := verifyTable(ctx, in, t)
+		if err != nil {
+			return nil, err
+		}
+		report.Drifts = append(report.Drifts, drifts...)
+	}
+	return report, nil
+}
+
+func (m *Migration) getInspector() (Inspector, error) {
+	if m.inspector != nil {
+		return m.inspector, nil
+	}
+
+	dbType, ok := databaseTypes[m.cf.DBType]
+	if !ok || m.cf.DB == nil {
+		return nil, fmt.Errorf("[migration] schema verification needs a supported DBType and DB")
+	}
+	db, err := database.FromSqlx(sqlx.NewDb(m.cf.DB, dbType.GetDriver()), &database.Config{
+		DBType:  dbType,
+		Timeout: m.cf.Timeout,
+	})
+	if err != nil {
+		return nil, err
+	}
+	m.inspector = db
+	return db, nil
+}
+
+var databaseTypes = map[DBType]database.DBType{
+	SqlServer: database.SqlServer,
+	Postgres:  database.Postgres,
+	MySQL:     database.MySQL,
+}
+
+func verifyTable(ctx context.Context, in Inspector, t Table) ([]Drift, error) {
+	cols, err := in.Columns(ctx, t.Name)
+	if err != nil {
+		return nil, fmt.Errorf("[migration] columns of %s: %w", t.Name, err)
+	}
+	if len(cols) == 0 {
+		return []Drift{{Kind: DriftMissingTable, Table: t.Name}}, nil
+	}
+
+	var drifts []Drift
+
+	// columns
+	live := make(map[string]database.Column, len(cols))
+	for _, c := range cols {
+		live[strings.ToLower(c.Name)] = c
+	}
+	for _, want := range t.Columns {
+		key := strings.ToLower(want.Name)
+		got, ok := live[key]
+		if !ok {
+			drifts = append(drifts, Drift{Kind: DriftMissingColumn, Table: t.Name, Column: want.Name, Expected: want.Type})
+			continue
+		}
+		delete(live, key)
+
+		if want.Type != "" && normalizeType(want.Type) != normalizeType(got.Type) {
+			drifts = append(drifts, Drift{
+				Kind: DriftTypeMismatch, Table: t.Name, Column: want.Name,
+				Expected: want.Type, Actual: got.Type,
+			})
+		}
+		if want.Nullable != nil && *want.Nullable != got.Nullable {
+			drifts = append(drifts, Drift{
+				Kind: DriftNullableMismatch, Table: t.Name, Column: want.Name,
+				Expected: nullability(*want.Nullable), Actual: nullability(got.Nullable),
+			})
+		}
+	}
+	for _, c := range cols {
+		if _, extra := live[strings.ToLower(c.Name)]; extra {
+			drifts = append(drifts, Drift{Kind: DriftExtraColumn, Table: t.Name, Column: c.Name, Actual: c.Type})
+		}
+	}
+
+	// indexes
+	idx, err := in.Indexes(ctx, t.Name)
+	if err != nil {
+		return nil, fmt.Errorf("[migration] indexes of %s: %w", t.Name, err)
+	}
+	liveIdx := make(map[string]database.Index, len(idx))
+	for _, i := range idx {
+		liveIdx[strings.ToLower(i.Name)] = i
+	}
+	for _, want := range t.Indexes {
+		key := strings.ToLower(want.Name)
+		got, ok := liveIdx[key]
+		if !ok {
+			drifts = append(drifts, Drift{
+				Kind: DriftMissingIndex, Table: t.Name, Index: want.Name,
+				Expected: describeIndex(want.Columns, want.Unique),
+			})
+			continue
+		}
+		delete(liveIdx, key)
+
+		if want.Unique != got.Unique || !slices.EqualFunc(want.Columns, got.Columns, strings.EqualFold) {
+			drifts = append(drifts, Drift{
+				Kind: DriftIndexMismatch, Table: t.Name, Index: want.Name,
+				Expected: describeIndex(want.Columns, want.Unique),
+				Actual:   describeIndex(got.Columns, got.Unique),
+			})
+		}
+	}
+	for _, i := range idx {
+		if _, extra := liveIdx[strings.ToLower(i.Name)]; extra && !i.Primary {
+			drifts = append(drifts, Drift{
+				Kind: DriftExtraIndex, Table: t.Name, Index: i.Name,
+				Actual: describeIndex(i.Columns, i.Unique),
+			})
+		}
+	}
+
+	return drifts, nil
+}
+
+var typeSize = regexp.MustCompile(`\s*\(.*\)`)
+
+// typeAliases maps database-specific spellings to one name.
+var typeAliases = map[string]string{
+	"int4":                        "integer",
+	"int":                         "integer",
+	"int8":                        "bigint",
+	"int2":                        "smallint",
+	"serial":                      "integer",
+	"bigserial":                   "bigint",
+	"bool":                        "boolean",
+	"character varying":           "varchar",
+	"character":                   "char",
+	"float8":                      "double precision",
+	"float4":                      "real",
+	"decimal":                     "numeric",
+	"timestamp without time zone": "timestamp",
+	"timestamp with time zone":    "timestamptz",
+	"time without time zone":      "time",
+}
+
+// normalizeType lower-cases t, drops the length/precision and resolves aliases.
+func normalizeType(t string) string {
+	t = strings.ToLower(strings.TrimSpace(typeSize.ReplaceAllString(t, "")))
+	if alias, ok := typeAliases[t]; ok {
+		return alias
+	}
+	return t
+}
+
+func nullability(nullable bool) string {
+	if nullable {
+		return "NULL"
+	}
+	return "NOT NULL"
+}
+
+func describeIndex(cols []string, unique bool) string {
+	s := "(" + strings.Join(cols, ", ") + ")"
+	if unique {
+		s = "UNIQUE " + s
+	}
+	return s
+}
@@ -0,0 +1,102 @@
+package migration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/BevisDev/godev/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeInspector struct {
+	columns map[string][]database.Column
+	indexes map[string][]database.Index
+}
+
+func (f fakeInspector) Columns(_ context.Context, table string) ([]database.Column, error) {
+	return f.columns[table], nil
+}
+
+func (f fakeInspector) Indexes(_ context.Context, table string) ([]database.Index, error) {
+	return f.indexes[table], nil
+}
+
+func newVerifyMigration(in Inspector, schemaFile string) *Migration {
+	return &Migration{
+		cf:        (&Config{SchemaFile: schemaFile}).clone(),
+		inspector: in,
+	}
+}
+
+func TestVerify_NoDrift(t *testing.T) {
+	in := fakeInspector{
+		columns: map[string][]database.Column{
+			"users": {
+				{Name: "id", Type: "integer"},
+				{Name: "email", Type: "character varying"},
+				{Name: "name", Type: "text", Nullable: true},
+			},
+			"orders": {{Name: "id", Type: "int8"}},
+		},
+		indexes: map[string][]database.Index{
+			"users": {
+				{Name: "users_pkey", Columns: []string{"id"}, Unique: true, Primary: true},
+				{Name: "ux_users_email", Columns: []string{"email"}, Unique: true},
+			},
+		},
+	}
+
+	report, err := newVerifyMigration(in, "testdata/schema.yaml").Verify(context.Background())
+	require.NoError(t, err)
+	assert.True(t, report.OK(), report.Drifts)
+	assert.NoError(t, report.Err())
+}
+
+func TestVerify_Drift(t *testing.T) {
+	in := fakeInspector{
+		columns: map[string][]database.Column{
+			"users": {
+				{Name: "id", Type: "integer"},
+				{Name: "email", Type: "text", Nullable: true},
+				{Name: "hotfix", Type: "boolean", Nullable: true},
+			},
+		},
+		indexes: map[string][]database.Index{
+			"users": {
+				{Name: "ux_users_email", Columns: []string{"email", "id"}, Unique: true},
+				{Name: "ix_users_hotfix", Columns: []string{"hotfix"}},
+			},
+		},
+	}
+
+	report, err := newVerifyMigration(in, "testdata/schema.yaml").Verify(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []Drift{
+		{Kind: DriftTypeMismatch, Table: "users", Column: "email", Expected: "varchar(255)", Actual: "text"},
+		{Kind: DriftNullableMismatch, Table: "users", Column: "email", Expected: "NOT NULL", Actual: "NULL"},
+		{Kind: DriftMissingColumn, Table: "users", Column: "name", Expected: "text"},
+		{Kind: DriftExtraColumn, Table: "users", Column: "hotfix", Actual: "boolean"},
+		{Kind: DriftIndexMismatch, Table: "users", Index: "ux_users_email",
+			Expected: "UNIQUE (email)", Actual: "UNIQUE (email, id)"},
+		{Kind: DriftExtraIndex, Table: "users", Index: "ix_users_hotfix", Actual: "(hotfix)"},
+		{Kind: DriftMissingTable, Table: "orders"},
+	}, report.Drifts)
+
+	err = report.Err()
+	assert.ErrorIs(t, err, ErrSchemaDrift)
+	assert.Contains(t, err.Error(), `type_mismatch: users.email (expected "varchar(255)", actual "text")`)
+}
+
+func TestVerify_NoSchemaFile(t *testing.T) {
+	_, err := newVerifyMigration(fakeInspector{}, "").Verify(context.Background())
+	assert.ErrorIs(t, err, ErrNoSchemaFile)
+}
+
+func TestNormalizeType(t *testing.T) {
+	assert.Equal(t, "varchar", normalizeType("VARCHAR(255)"))
+	assert.Equal(t, "numeric", normalizeType("decimal(10, 2)"))
+	assert.Equal(t, "timestamp", normalizeType("timestamp without time zone"))
+	assert.Equal(t, "nvarchar", normalizeType("nvarchar"))
+}
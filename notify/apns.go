@@ -0,0 +1,282 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/BevisDev/godev/utils/crypto"
+)
+
+const (
+	apnsProduction  = "https://api.push.apple.com"
+	apnsDevelopment = "https://api.sandbox.push.apple.com"
+
+	// apnsTokenTTL is how long a provider token is reused. Apple rejects tokens
+	// older than an hour and refreshes more often than every 20 minutes.
+	apnsTokenTTL = 50 * time.Minute
+)
+
+// APNsConfig configures the Apple Push Notification service provider with
+// token-based (.p8 key) authentication.
+type APNsConfig struct {
+	// KeyID and TeamID identify the signing key in the Apple developer account.
+	KeyID  string
+	TeamID string
+
+	// PrivateKey is the PEM content of the .p8 key; PrivateKeyFile is read when empty.
+	PrivateKey     []byte
+	PrivateKeyFile string
+
+	// Topic is the bundle ID of the app.
+	Topic string
+
+	// Production sends to the production gateway instead of the sandbox.
+	Production bool
+
+	// Endpoint overrides the gateway address (testing).
+	Endpoint string
+
+	// Timeout bounds one request (default 10s).
+	Timeout time.Duration
+
+	// Client is the HTTP client (default a client with Timeout). APNs requires
+	// HTTP/2, which the default transport negotiates over TLS.
+	Client *http.Client
+}
+
+// APNs sends messages with the APNs HTTP/2 API.
+type APNs struct {
+	cfg      *APNsConfig
+	key      *ecdsa.PrivateKey
+	endpoint string
+	client   *http.Client
+	mu       sync.Mutex
+	token    string
+	tokenIat time.Time
+}
+
+// NewAPNs creates an APNs provider.
+func NewAPNs(cfg *APNsConfig) (*APNs, error) {
+	if cfg == nil {
+		return nil, ErrConfigNil
+	}
+	if cfg.KeyID == "" || cfg.TeamID == "" || cfg.Topic == "" {
+		return nil, fmt.Errorf("%w: key id, team id and topic are required", ErrCredentials)
+	}
+
+	pem := cfg.PrivateKey
+	if len(pem) == 0 && cfg.PrivateKeyFile != "" {
+		var err error
+		if pem, err = os.ReadFile(cfg.PrivateKeyFile); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrCredentials, err)
+		}
+	}
+	parsed, err := crypto.ParsePrivateKeyPEM(pem)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCredentials, err)
+	}
+	key, ok := parsed.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: private key is not an EC key", ErrCredentials)
+	}
+
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = apnsDevelopment
+		if cfg.Production {
+			endpoint = apnsProduction
+		}
+	}
+
+	return &APNs{
+		cfg:      cfg,
+		key:      key,
+		endpoint: strings.TrimRight(endpoint, "/"),
+		client:   httpClient(cfg.Client, cfg.Timeout),
+	}, nil
+}
+
+func (a *APNs) Name() string {
+	return "apns"
+}
+
+// APNsPayload is the JSON payload of an APNs notification: the aps dictionary
+// and the custom keys of the message data.
+type APNsPayload struct {
+	APS    APS
+	Custom map[string]string
+}
+
+// APS is the aps dictionary of an APNs payload.
+type APS struct {
+	Alert            *APSAlert `json:"alert,omitempty"`
+	Badge            *int      `json:"badge,omitempty"`
+	Sound            string    `json:"sound,omitempty"`
+	ThreadID         string    `json:"thread-id,omitempty"`
+	Category         string    `json:"category,omitempty"`
+	ContentAvailable int       `json:"content-available,omitempty"`
+	MutableContent   int       `json:"mutable-content,omitempty"`
+}
+
+// APSAlert is the alert of an aps dictionary.
+type APSAlert struct {
+	Title string `json:"title,omitempty"`
+	Body  string `json:"body,omitempty"`
+}
+
+// MarshalJSON writes the custom keys next to aps.
+func (p APNsPayload) MarshalJSON() ([]byte, error) {
+	out := make(map[string]any, len(p.Custom)+1)
+	for k, v := range p.Custom {
+		out[k] = v
+	}
+	out["aps"] = p.APS
+	return json.Marshal(out)
+}
+
+// BuildAPNsPayload builds the APNs payload of msg. An image URL sets
+// mutable-content so a notification service extension can download it.
+func BuildAPNsPayload(msg *Message) APNsPayload {
+	p := APNsPayload{Custom: msg.Data}
+	if msg.Silent {
+		p.APS.ContentAvailable = 1
+		return p
+	}
+
+	p.APS = APS{
+		Alert:    &APSAlert{Title: msg.Title, Body: msg.Body},
+		Badge:    msg.Badge,
+		Sound:    msg.Sound,
+		ThreadID: msg.ThreadID,
+		Category: msg.Category,
+	}
+	if msg.ImageURL != "" {
+		p.APS.MutableContent = 1
+		if p.Custom == nil || p.Custom["image"] == "" {
+			p.Custom = make(map[string]string, len(msg.Data)+1)
+			for k, v := range msg.Data {
+				p.Custom[k] = v
+			}
+			p.Custom["image"] = msg.ImageURL
+		}
+	}
+	return p
+}
+
+// apnsHeaders returns the apns-* request headers of msg, without the topic.
+func apnsHeaders(msg *Message) map[string]string {
+	h := map[string]string{
+		"apns-push-type": "alert",
+		"apns-priority":  "10",
+	}
+	if msg.Silent {
+		// background pushes must use priority 5
+		h["apns-push-type"] = "background"
+		h["apns-priority"] = "5"
+	} else if !msg.high() {
+		h["apns-priority"] = "5"
+	}
+	if msg.TTL > 0 {
+		h["apns-expiration"] = strconv.FormatInt(time.Now().Add(msg.TTL).Unix(), 10)
+	}
+	if msg.CollapseKey != "" {
+		h["apns-collapse-id"] = msg.CollapseKey
+	}
+	return h
+}
+
+// apnsTokenReasons are the APNs reasons of device tokens that will never work again.
+var apnsTokenReasons = map[string]bool{
+	"BadDeviceToken":         true,
+	"Unregistered":           true,
+	"DeviceTokenNotForTopic": true,
+}
+
+// Send delivers msg to the device token.
+func (a *APNs) Send(ctx context.Context, token string, msg *Message) error {
+	body, err := json.Marshal(BuildAPNsPayload(msg))
+	if err != nil {
+		return err
+	}
+	providerToken, err := a.providerToken()
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.endpoint+"/3/device/"+token, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "bearer "+providerToken)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("apns-topic", a.cfg.Topic)
+	for k, v := range apnsHeaders(msg) {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	e := &Error{
+		Provider:   a.Name(),
+		StatusCode: resp.StatusCode,
+		RetryAfter: retryAfter(resp.Header),
+	}
+	var out struct {
+		Reason string `json:"reason"`
+	}
+	if json.Unmarshal(raw, &out) == nil {
+		e.Reason = out.Reason
+	} else {
+		e.Message = strings.TrimSpace(string(raw))
+	}
+	e.invalidToken = apnsTokenReasons[e.Reason]
+	if e.Reason == "ExpiredProviderToken" {
+		a.mu.Lock()
+		a.token = ""
+		a.mu.Unlock()
+		e.retryable = true
+	}
+	return e
+}
+
+// providerToken returns the signed provider token, renewing it after apnsTokenTTL.
+func (a *APNs) providerToken() (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.token != "" && time.Since(a.tokenIat) < apnsTokenTTL {
+		return a.token, nil
+	}
+
+	now := time.Now()
+	signed, err := crypto.CreateJWT(crypto.RegisteredClaims{
+		Issuer:   a.cfg.TeamID,
+		IssuedAt: now.Unix(),
+	}, a.key, crypto.ES256, crypto.WithKeyID(a.cfg.KeyID))
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrCredentials, err)
+	}
+	a.token, a.tokenIat = signed, now
+	return signed, nil
+}
@@ -0,0 +1,167 @@
+package notify
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BevisDev/godev/utils/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func apnsKey(t *testing.T) (*ecdsa.PrivateKey, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return key, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+func TestAPNs_Send(t *testing.T) {
+	key, keyPEM := apnsKey(t)
+
+	var (
+		header  http.Header
+		path    string
+		payload map[string]any
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header, path, payload = r.Header, r.URL.Path, nil
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+
+		claims, err := crypto.ParseAndVerifyJWT[crypto.RegisteredClaims](strings.TrimPrefix(r.Header.Get("Authorization"), "bearer "),
+			func(h crypto.JWTHeader) (any, error) {
+				assert.Equal(t, crypto.ES256, h.Alg)
+				assert.Equal(t, "KEY123", h.Kid)
+				return &key.PublicKey, nil
+			})
+		require.NoError(t, err)
+		assert.Equal(t, "TEAM456", claims.Issuer)
+
+		switch {
+		case strings.HasSuffix(path, "/gone"):
+			w.WriteHeader(http.StatusGone)
+			_, _ = w.Write([]byte(`{"reason":"Unregistered","timestamp":1700000000000}`))
+		case strings.HasSuffix(path, "/bad"):
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"reason":"BadDeviceToken"}`))
+		case strings.HasSuffix(path, "/big"):
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			_, _ = w.Write([]byte(`{"reason":"PayloadTooLarge"}`))
+		default:
+			w.Header().Set("apns-id", "id-1")
+		}
+	}))
+	defer srv.Close()
+
+	apns, err := NewAPNs(&APNsConfig{KeyID: "KEY123", TeamID: "TEAM456", PrivateKey: keyPEM, Topic: "com.example.app", Endpoint: srv.URL})
+	require.NoError(t, err)
+	assert.Equal(t, "apns", apns.Name())
+
+	var invalid []string
+	n := New(apns, fastRetry(), WithConcurrency(1), WithInvalidTokenHandler(func(_ context.Context, token string, _ error) {
+		invalid = append(invalid, token)
+	}))
+	ctx := context.Background()
+
+	msg := NewMessage("Xin chào", "Bạn có tin nhắn mới").
+		SetData("chat_id", "7").
+		SetBadge(2).
+		SetSound("default").
+		SetThread("chat-7").
+		SetCategory("MESSAGE").
+		SetCollapseKey("chat-7").
+		SetTTL(time.Hour)
+	require.NoError(t, n.Send(ctx, "abc123", msg))
+
+	assert.Equal(t, "/3/device/abc123", path)
+	assert.Equal(t, "com.example.app", header.Get("apns-topic"))
+	assert.Equal(t, "alert", header.Get("apns-push-type"))
+	assert.Equal(t, "10", header.Get("apns-priority"))
+	assert.Equal(t, "chat-7", header.Get("apns-collapse-id"))
+	exp, _ := strconv.ParseInt(header.Get("apns-expiration"), 10, 64)
+	assert.InDelta(t, time.Now().Add(time.Hour).Unix(), exp, 5)
+	assert.Equal(t, map[string]any{
+		"chat_id": "7",
+		"aps": map[string]any{
+			"alert":     map[string]any{"title": "Xin chào", "body": "Bạn có tin nhắn mới"},
+			"badge":     2.0,
+			"sound":     "default",
+			"thread-id": "chat-7",
+			"category":  "MESSAGE",
+		},
+	}, payload)
+
+	auth := header.Get("Authorization")
+	require.NoError(t, n.Send(ctx, "abc123", (&Message{}).SetData("sync", "1").SetSilent()))
+	assert.Equal(t, auth, header.Get("Authorization"), "the provider token is reused")
+	assert.Equal(t, "background", header.Get("apns-push-type"))
+	assert.Equal(t, "5", header.Get("apns-priority"))
+	assert.Equal(t, map[string]any{"sync": "1", "aps": map[string]any{"content-available": 1.0}}, payload)
+
+	resp, err := n.SendMulticast(ctx, []string{"gone", "bad", "big", "ok"}, msg)
+	require.NoError(t, err)
+	assert.Equal(t, 1, resp.SuccessCount)
+	assert.ElementsMatch(t, []string{"gone", "bad"}, resp.InvalidTokens())
+	assert.ElementsMatch(t, []string{"gone", "bad"}, invalid)
+
+	var e *Error
+	require.ErrorAs(t, resp.Results[2].Err, &e)
+	assert.Equal(t, "PayloadTooLarge", e.Reason)
+}
+
+func TestAPNs_ExpiredProviderToken(t *testing.T) {
+	_, keyPEM := apnsKey(t)
+
+	var auths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auths = append(auths, r.Header.Get("Authorization"))
+		if len(auths) == 1 {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"reason":"ExpiredProviderToken"}`))
+		}
+	}))
+	defer srv.Close()
+
+	apns, err := NewAPNs(&APNsConfig{KeyID: "K", TeamID: "T", PrivateKey: keyPEM, Topic: "app", Endpoint: srv.URL})
+	require.NoError(t, err)
+	// a token APNs considers expired although it is still within apnsTokenTTL
+	apns.token, apns.tokenIat = "stale", time.Now()
+
+	require.NoError(t, New(apns, fastRetry()).Send(context.Background(), "tok", NewMessage("a", "b")))
+	require.Len(t, auths, 2)
+	assert.Equal(t, "bearer stale", auths[0])
+	assert.NotEqual(t, auths[0], auths[1])
+}
+
+func TestBuildAPNsPayload_Image(t *testing.T) {
+	msg := NewMessage("a", "b").SetData("k", "v").SetImage("https://cdn/x.png").SetPriority(PriorityNormal)
+	p := BuildAPNsPayload(msg)
+
+	assert.Equal(t, 1, p.APS.MutableContent)
+	assert.Equal(t, map[string]string{"k": "v", "image": "https://cdn/x.png"}, p.Custom)
+	assert.Equal(t, map[string]string{"k": "v"}, msg.Data, "the message data is not modified")
+	assert.Equal(t, "5", apnsHeaders(msg)["apns-priority"])
+}
+
+func TestNewAPNs_Invalid(t *testing.T) {
+	_, err := NewAPNs(nil)
+	assert.ErrorIs(t, err, ErrConfigNil)
+
+	_, err = NewAPNs(&APNsConfig{KeyID: "K", TeamID: "T", Topic: "app", PrivateKey: []byte("nope")})
+	assert.ErrorIs(t, err, ErrCredentials)
+
+	_, err = NewAPNs(&APNsConfig{KeyID: "K", Topic: "app"})
+	assert.ErrorIs(t, err, ErrCredentials)
+}
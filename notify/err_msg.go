@@ -0,0 +1,12 @@
+package notify
+
+import "errors"
+
+// Errors
+var (
+	ErrConfigNil    = errors.New("[notify] config is nil")
+	ErrCredentials  = errors.New("[notify] invalid credentials")
+	ErrNoToken      = errors.New("[notify] device token is empty")
+	ErrEmptyMessage = errors.New("[notify] message has no title, body or data")
+	ErrInvalidToken = errors.New("[notify] device token is invalid or unregistered")
+)
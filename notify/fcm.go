@@ -0,0 +1,357 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/BevisDev/godev/utils/crypto"
+)
+
+const (
+	fcmEndpoint = "https://fcm.googleapis.com"
+	fcmScope    = "https://www.googleapis.com/auth/firebase.messaging"
+	googleToken = "https://oauth2.googleapis.com/token"
+)
+
+// FCMConfig configures the Firebase Cloud Messaging HTTP v1 provider.
+type FCMConfig struct {
+	// CredentialsJSON is the service account key; CredentialsFile is read when empty.
+	CredentialsJSON []byte
+	CredentialsFile string
+
+	// ProjectID defaults to the project of the service account.
+	ProjectID string
+
+	// Endpoint overrides the FCM API address (testing).
+	Endpoint string
+
+	// Timeout bounds one request (default 10s).
+	Timeout time.Duration
+
+	// Client is the HTTP client (default a client with Timeout).
+	Client *http.Client
+}
+
+// serviceAccount holds the fields of a Google service account key used to sign tokens.
+type serviceAccount struct {
+	ProjectID    string `json:"project_id"`
+	PrivateKeyID string `json:"private_key_id"`
+	PrivateKey   string `json:"private_key"`
+	ClientEmail  string `json:"client_email"`
+	TokenURI     string `json:"token_uri"`
+}
+
+// fcmAssertion is the JWT exchanged for an OAuth2 access token.
+type fcmAssertion struct {
+	crypto.RegisteredClaims
+	Scope string `json:"scope"`
+}
+
+// FCM sends messages with the Firebase Cloud Messaging HTTP v1 API. OAuth2
+// access tokens are obtained with the service account and cached until they expire.
+type FCM struct {
+	account  serviceAccount
+	signer   *rsa.PrivateKey
+	url      string
+	client   *http.Client
+	mu       sync.Mutex
+	token    string
+	tokenExp time.Time
+}
+
+// NewFCM creates an FCM provider from a service account key.
+func NewFCM(cfg *FCMConfig) (*FCM, error) {
+	if cfg == nil {
+		return nil, ErrConfigNil
+	}
+
+	data := cfg.CredentialsJSON
+	if len(data) == 0 && cfg.CredentialsFile != "" {
+		var err error
+		if data, err = os.ReadFile(cfg.CredentialsFile); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrCredentials, err)
+		}
+	}
+
+	var sa serviceAccount
+	if err := json.Unmarshal(data, &sa); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCredentials, err)
+	}
+	if sa.ClientEmail == "" || sa.PrivateKey == "" {
+		return nil, fmt.Errorf("%w: client_email or private_key missing", ErrCredentials)
+	}
+	parsed, err := crypto.ParsePrivateKeyPEM([]byte(sa.PrivateKey))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCredentials, err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: private key is not an RSA key", ErrCredentials)
+	}
+	if sa.TokenURI == "" {
+		sa.TokenURI = googleToken
+	}
+
+	project := cfg.ProjectID
+	if project == "" {
+		project = sa.ProjectID
+	}
+	if project == "" {
+		return nil, fmt.Errorf("%w: project id missing", ErrCredentials)
+	}
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = fcmEndpoint
+	}
+
+	return &FCM{
+		account: sa,
+		signer:  key,
+		url:     strings.TrimRight(endpoint, "/") + "/v1/projects/" + project + "/messages:send",
+		client:  httpClient(cfg.Client, cfg.Timeout),
+	}, nil
+}
+
+func httpClient(client *http.Client, timeout time.Duration) *http.Client {
+	if client != nil {
+		return client
+	}
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	return &http.Client{Timeout: timeout}
+}
+
+func (f *FCM) Name() string {
+	return "fcm"
+}
+
+// FCMMessage is the message of an FCM v1 send request.
+type FCMMessage struct {
+	Token        string            `json:"token"`
+	Notification *FCMNotification  `json:"notification,omitempty"`
+	Data         map[string]string `json:"data,omitempty"`
+	Android      *FCMAndroid       `json:"android,omitempty"`
+	APNs         *FCMAPNs          `json:"apns,omitempty"`
+}
+
+// FCMNotification is the cross-platform notification of an FCM message.
+type FCMNotification struct {
+	Title string `json:"title,omitempty"`
+	Body  string `json:"body,omitempty"`
+	Image string `json:"image,omitempty"`
+}
+
+// FCMAndroid holds the Android options of an FCM message.
+type FCMAndroid struct {
+	CollapseKey  string                  `json:"collapse_key,omitempty"`
+	Priority     string                  `json:"priority,omitempty"`
+	TTL          string                  `json:"ttl,omitempty"`
+	Notification *FCMAndroidNotification `json:"notification,omitempty"`
+}
+
+// FCMAndroidNotification holds the Android notification options.
+type FCMAndroidNotification struct {
+	Sound       string `json:"sound,omitempty"`
+	Tag         string `json:"tag,omitempty"`
+	ClickAction string `json:"click_action,omitempty"`
+}
+
+// FCMAPNs holds the APNs headers and payload of an FCM message sent to iOS.
+type FCMAPNs struct {
+	Headers map[string]string `json:"headers,omitempty"`
+	Payload APNsPayload       `json:"payload"`
+}
+
+// BuildFCMMessage builds the FCM v1 message of msg for token.
+func BuildFCMMessage(token string, msg *Message) FCMMessage {
+	out := FCMMessage{Token: token, Data: msg.Data}
+	if !msg.Silent {
+		out.Notification = &FCMNotification{Title: msg.Title, Body: msg.Body, Image: msg.ImageURL}
+	}
+
+	android := &FCMAndroid{CollapseKey: msg.CollapseKey, Priority: "normal"}
+	if msg.high() {
+		android.Priority = "high"
+	}
+	if msg.TTL > 0 {
+		android.TTL = strconv.FormatInt(int64(msg.TTL/time.Second), 10) + "s"
+	}
+	if !msg.Silent && (msg.Sound != "" || msg.ThreadID != "" || msg.Category != "") {
+		android.Notification = &FCMAndroidNotification{
+			Sound:       msg.Sound,
+			Tag:         msg.ThreadID,
+			ClickAction: msg.Category,
+		}
+	}
+	out.Android = android
+
+	out.APNs = &FCMAPNs{Headers: apnsHeaders(msg), Payload: BuildAPNsPayload(msg)}
+	// the alert, image and data come from the notification and data of the message
+	out.APNs.Payload.APS.Alert = nil
+	out.APNs.Payload.Custom = nil
+	return out
+}
+
+// fcmError is the error body of the FCM v1 API.
+type fcmError struct {
+	Error struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Status  string `json:"status"`
+		Details []struct {
+			Type      string `json:"@type"`
+			ErrorCode string `json:"errorCode"`
+		} `json:"details"`
+	} `json:"error"`
+}
+
+// Send delivers msg to the registration token.
+func (f *FCM) Send(ctx context.Context, token string, msg *Message) error {
+	accessToken, err := f.accessToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(map[string]any{"message": BuildFCMMessage(token, msg)})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, f.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+	return f.error(resp, raw)
+}
+
+func (f *FCM) error(resp *http.Response, raw []byte) error {
+	e := &Error{
+		Provider:   f.Name(),
+		StatusCode: resp.StatusCode,
+		RetryAfter: retryAfter(resp.Header),
+	}
+
+	var body fcmError
+	if json.Unmarshal(raw, &body) == nil && body.Error.Code != 0 {
+		e.Message = body.Error.Message
+		e.Reason = body.Error.Status
+		for _, d := range body.Error.Details {
+			if d.ErrorCode != "" {
+				e.Reason = d.ErrorCode
+			}
+		}
+	} else {
+		e.Message = strings.TrimSpace(string(raw))
+	}
+
+	switch {
+	case e.Reason == "UNREGISTERED", e.Reason == "SENDER_ID_MISMATCH":
+		e.invalidToken = true
+	case e.Reason == "INVALID_ARGUMENT" && strings.Contains(strings.ToLower(e.Message), "registration token"):
+		e.invalidToken = true
+	case resp.StatusCode == http.StatusUnauthorized:
+		// the access token was revoked or expired early: fetch a new one on retry
+		f.mu.Lock()
+		f.token = ""
+		f.mu.Unlock()
+		e.retryable = true
+	}
+	return e
+}
+
+// accessToken returns a cached OAuth2 access token, exchanging a signed
+// service account assertion for a new one when it is about to expire.
+func (f *FCM) accessToken(ctx context.Context) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.token != "" && time.Now().Before(f.tokenExp) {
+		return f.token, nil
+	}
+
+	now := time.Now()
+	signed, err := crypto.CreateJWT(fcmAssertion{
+		RegisteredClaims: crypto.RegisteredClaims{
+			Issuer:    f.account.ClientEmail,
+			Audience:  crypto.Audience{f.account.TokenURI},
+			IssuedAt:  now.Unix(),
+			ExpiresAt: now.Add(time.Hour).Unix(),
+		},
+		Scope: fcmScope,
+	}, f.signer, crypto.RS256, crypto.WithKeyID(f.account.PrivateKeyID))
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrCredentials, err)
+	}
+
+	form := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {signed},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, f.account.TokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		e := &Error{
+			Provider:   f.Name(),
+			StatusCode: resp.StatusCode,
+			Reason:     "TOKEN_EXCHANGE",
+			Message:    strings.TrimSpace(string(raw)),
+		}
+		return "", e
+	}
+
+	var out struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.Unmarshal(raw, &out); err != nil || out.AccessToken == "" {
+		return "", fmt.Errorf("%w: invalid token response", ErrCredentials)
+	}
+	if out.ExpiresIn <= 0 {
+		out.ExpiresIn = 3600
+	}
+
+	f.token = out.AccessToken
+	// renew a minute early so a token does not expire in flight
+	f.tokenExp = now.Add(time.Duration(out.ExpiresIn)*time.Second - time.Minute)
+	return f.token, nil
+}
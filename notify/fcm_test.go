@@ -0,0 +1,207 @@
+package notify
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/BevisDev/godev/utils/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fcmServer struct {
+	*httptest.Server
+	key     *rsa.PrivateKey
+	tokens  atomic.Int32
+	sent    []map[string]any
+	auth    []string
+	respond func(w http.ResponseWriter, token string) bool
+}
+
+func newFCMServer(t *testing.T) *fcmServer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	s := &fcmServer{key: key}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "urn:ietf:params:oauth:grant-type:jwt-bearer", r.Form.Get("grant_type"))
+
+		claims, err := crypto.ParseAndVerifyJWT[fcmAssertion](r.Form.Get("assertion"), func(h crypto.JWTHeader) (any, error) {
+			assert.Equal(t, crypto.RS256, h.Alg)
+			assert.Equal(t, "key-1", h.Kid)
+			return &key.PublicKey, nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "fcm@demo.iam.gserviceaccount.com", claims.Issuer)
+		assert.Equal(t, fcmScope, claims.Scope)
+
+		n := s.tokens.Add(1)
+		_ = json.NewEncoder(w).Encode(map[string]any{"access_token": "access-" + string(rune('0'+n)), "expires_in": 3600})
+	})
+	mux.HandleFunc("POST /v1/projects/demo/messages:send", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Message map[string]any `json:"message"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		s.sent = append(s.sent, body.Message)
+		s.auth = append(s.auth, r.Header.Get("Authorization"))
+		if s.respond != nil && s.respond(w, body.Message["token"].(string)) {
+			return
+		}
+		_, _ = w.Write([]byte(`{"name":"projects/demo/messages/1"}`))
+	})
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *fcmServer) credentials(t *testing.T) []byte {
+	der := x509.MarshalPKCS1PrivateKey(s.key)
+	data, err := json.Marshal(map[string]string{
+		"type":           "service_account",
+		"project_id":     "demo",
+		"private_key_id": "key-1",
+		"private_key":    string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: der})),
+		"client_email":   "fcm@demo.iam.gserviceaccount.com",
+		"token_uri":      s.URL + "/token",
+	})
+	require.NoError(t, err)
+	return data
+}
+
+func writeFCMError(w http.ResponseWriter, status int, code, message string) {
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{
+		"code": status, "message": message, "status": http.StatusText(status),
+		"details": []map[string]any{{"@type": "type.googleapis.com/google.firebase.fcm.v1.FcmError", "errorCode": code}},
+	}})
+}
+
+func TestFCM_Send(t *testing.T) {
+	srv := newFCMServer(t)
+	fcm, err := NewFCM(&FCMConfig{CredentialsJSON: srv.credentials(t), Endpoint: srv.URL})
+	require.NoError(t, err)
+	assert.Equal(t, "fcm", fcm.Name())
+
+	n := New(fcm)
+	msg := NewMessage("Khuyến mãi", "Giảm 50%").
+		SetData("order_id", "42").
+		SetImage("https://cdn.example.com/a.png").
+		SetSound("default").
+		SetBadge(3).
+		SetCollapseKey("promo").
+		SetTTL(time.Hour)
+
+	require.NoError(t, n.Send(context.Background(), "device-1", msg))
+	require.NoError(t, n.Send(context.Background(), "device-2", msg))
+	assert.EqualValues(t, 1, srv.tokens.Load(), "the access token is cached")
+	assert.Equal(t, []string{"Bearer access-1", "Bearer access-1"}, srv.auth)
+
+	sent := srv.sent[0]
+	assert.Equal(t, "device-1", sent["token"])
+	assert.Equal(t, map[string]any{"title": "Khuyến mãi", "body": "Giảm 50%", "image": "https://cdn.example.com/a.png"}, sent["notification"])
+	assert.Equal(t, map[string]any{"order_id": "42"}, sent["data"])
+	assert.Equal(t, map[string]any{
+		"collapse_key": "promo", "priority": "high", "ttl": "3600s",
+		"notification": map[string]any{"sound": "default"},
+	}, sent["android"])
+
+	apns := sent["apns"].(map[string]any)
+	headers := apns["headers"].(map[string]any)
+	assert.Equal(t, "alert", headers["apns-push-type"])
+	assert.Equal(t, "promo", headers["apns-collapse-id"])
+	assert.Equal(t, map[string]any{"aps": map[string]any{"badge": 3.0, "sound": "default", "mutable-content": 1.0}}, apns["payload"])
+}
+
+func TestFCM_Silent(t *testing.T) {
+	msg := (&Message{}).SetData("sync", "1").SetSilent()
+	out := BuildFCMMessage("tok", msg)
+
+	assert.Nil(t, out.Notification)
+	assert.Equal(t, "normal", out.Android.Priority)
+	assert.Nil(t, out.Android.Notification)
+	assert.Equal(t, "background", out.APNs.Headers["apns-push-type"])
+	assert.Equal(t, "5", out.APNs.Headers["apns-priority"])
+	assert.Equal(t, 1, out.APNs.Payload.APS.ContentAvailable)
+}
+
+func TestFCM_Errors(t *testing.T) {
+	srv := newFCMServer(t)
+	calls := map[string]int{}
+	srv.respond = func(w http.ResponseWriter, token string) bool {
+		calls[token]++
+		switch token {
+		case "gone":
+			writeFCMError(w, http.StatusNotFound, "UNREGISTERED", "Requested entity was not found.")
+		case "bad":
+			writeFCMError(w, http.StatusBadRequest, "INVALID_ARGUMENT", "The registration token is not a valid FCM registration token")
+		case "payload":
+			writeFCMError(w, http.StatusBadRequest, "INVALID_ARGUMENT", "Invalid value at 'message.data'")
+		case "quota":
+			if calls[token] == 1 {
+				w.Header().Set("Retry-After", "1")
+				writeFCMError(w, http.StatusTooManyRequests, "QUOTA_EXCEEDED", "Quota exceeded.")
+				return true
+			}
+			return false
+		case "revoked":
+			if calls[token] == 1 {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte("unauthorized"))
+				return true
+			}
+			return false
+		default:
+			return false
+		}
+		return true
+	}
+
+	fcm, err := NewFCM(&FCMConfig{CredentialsJSON: srv.credentials(t), Endpoint: srv.URL})
+	require.NoError(t, err)
+	n := New(fcm, fastRetry())
+	ctx := context.Background()
+	msg := NewMessage("a", "b")
+
+	err = n.Send(ctx, "gone", msg)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+	var e *Error
+	require.ErrorAs(t, err, &e)
+	assert.Equal(t, "UNREGISTERED", e.Reason)
+	assert.Equal(t, http.StatusNotFound, e.StatusCode)
+
+	assert.ErrorIs(t, n.Send(ctx, "bad", msg), ErrInvalidToken)
+
+	err = n.Send(ctx, "payload", msg)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrInvalidToken)
+	assert.Equal(t, 1, calls["payload"])
+
+	require.NoError(t, n.Send(ctx, "quota", msg))
+	assert.Equal(t, 2, calls["quota"])
+
+	require.NoError(t, n.Send(ctx, "revoked", msg))
+	assert.EqualValues(t, 2, srv.tokens.Load(), "a 401 fetches a new access token")
+}
+
+func TestNewFCM_Invalid(t *testing.T) {
+	_, err := NewFCM(nil)
+	assert.ErrorIs(t, err, ErrConfigNil)
+
+	_, err = NewFCM(&FCMConfig{CredentialsJSON: []byte(`{}`)})
+	assert.ErrorIs(t, err, ErrCredentials)
+
+	_, err = NewFCM(&FCMConfig{CredentialsFile: "/missing.json"})
+	assert.ErrorIs(t, err, ErrCredentials)
+}
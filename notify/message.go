@@ -0,0 +1,134 @@
+package notify
+
+import "time"
+
+// Priority is the delivery priority of a message.
+type Priority int
+
+const (
+	// PriorityDefault lets the provider decide: high for alerts, normal for silent messages.
+	PriorityDefault Priority = iota
+	// PriorityNormal may be delayed to save battery.
+	PriorityNormal
+	// PriorityHigh is delivered immediately and may wake the device.
+	PriorityHigh
+)
+
+// Message is a push notification independent of the provider. FCM and APNs
+// payloads are built from it by the providers.
+type Message struct {
+	Title    string
+	Body     string
+	ImageURL string
+
+	// Data is delivered to the app: FCM data fields, APNs custom keys.
+	Data map[string]string
+
+	// Sound is the sound to play, "default" for the system sound.
+	Sound string
+
+	// Badge sets the app icon badge (APNs); nil leaves it unchanged.
+	Badge *int
+
+	// Category is the APNs category, sent as click_action on Android.
+	Category string
+
+	// ThreadID groups notifications: APNs thread-id, Android tag.
+	ThreadID string
+
+	// CollapseKey replaces an undelivered message with the same key:
+	// Android collapse_key, apns-collapse-id.
+	CollapseKey string
+
+	Priority Priority
+
+	// TTL is how long the provider keeps the message for an offline device;
+	// 0 uses the provider default.
+	TTL time.Duration
+
+	// Silent sends a data-only (background) message without alert.
+	Silent bool
+}
+
+// NewMessage creates an alert message.
+func NewMessage(title, body string) *Message {
+	return &Message{Title: title, Body: body}
+}
+
+// SetData adds a data field.
+func (m *Message) SetData(key, value string) *Message {
+	if m.Data == nil {
+		m.Data = make(map[string]string)
+	}
+	m.Data[key] = value
+	return m
+}
+
+// SetImage sets the image shown with the notification.
+func (m *Message) SetImage(url string) *Message {
+	m.ImageURL = url
+	return m
+}
+
+// SetSound sets the sound to play.
+func (m *Message) SetSound(sound string) *Message {
+	m.Sound = sound
+	return m
+}
+
+// SetBadge sets the app icon badge.
+func (m *Message) SetBadge(n int) *Message {
+	m.Badge = &n
+	return m
+}
+
+// SetCategory sets the APNs category / Android click action.
+func (m *Message) SetCategory(category string) *Message {
+	m.Category = category
+	return m
+}
+
+// SetThread groups the notification with others of the same thread.
+func (m *Message) SetThread(id string) *Message {
+	m.ThreadID = id
+	return m
+}
+
+// SetCollapseKey sets the collapse key.
+func (m *Message) SetCollapseKey(key string) *Message {
+	m.CollapseKey = key
+	return m
+}
+
+// SetPriority sets the delivery priority.
+func (m *Message) SetPriority(p Priority) *Message {
+	m.Priority = p
+	return m
+}
+
+// SetTTL sets how long the message is kept for an offline device.
+func (m *Message) SetTTL(ttl time.Duration) *Message {
+	m.TTL = ttl
+	return m
+}
+
+// SetSilent turns the message into a data-only background message.
+func (m *Message) SetSilent() *Message {
+	m.Silent = true
+	return m
+}
+
+func (m *Message) validate() error {
+	if m == nil || (m.Title == "" && m.Body == "" && len(m.Data) == 0 && !m.Silent) {
+		return ErrEmptyMessage
+	}
+	return nil
+}
+
+// high reports whether the message is sent with high priority.
+func (m *Message) high() bool {
+	if m.Priority == PriorityDefault {
+		return !m.Silent
+	}
+	return m.Priority == PriorityHigh
+}
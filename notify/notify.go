@@ -0,0 +1,191 @@
+// Package notify sends push notifications through Firebase Cloud Messaging
+// (HTTP v1) and Apple Push Notification service (token-based auth).
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/BevisDev/godev/utils/async"
+)
+
+// Provider delivers one message to one device.
+type Provider interface {
+	// Name identifies the provider in errors and logs ("fcm", "apns").
+	Name() string
+
+	// Send delivers msg to the device token. Provider failures are returned as *Error.
+	Send(ctx context.Context, token string, msg *Message) error
+}
+
+// Error is a delivery failure reported by a provider.
+type Error struct {
+	Provider   string
+	StatusCode int
+
+	// Reason is the provider error code, e.g. UNREGISTERED or QUOTA_EXCEEDED (FCM),
+	// BadDeviceToken or TooManyRequests (APNs).
+	Reason  string
+	Message string
+
+	// RetryAfter is the Retry-After of a throttled response, 0 when absent.
+	RetryAfter time.Duration
+
+	invalidToken bool
+	retryable    bool
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("[notify] %s: status %d", e.Provider, e.StatusCode)
+	if e.Reason != "" {
+		msg += " " + e.Reason
+	}
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+// Is makes errors.Is(err, ErrInvalidToken) report rejected device tokens.
+func (e *Error) Is(target error) bool {
+	return target == ErrInvalidToken && e.invalidToken
+}
+
+// IsRetryable reports whether err is a temporary failure worth retrying:
+// throttling, provider 5xx responses and network errors.
+func IsRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var e *Error
+	if errors.As(err, &e) {
+		return e.retryable || e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// retryAfter parses a Retry-After header in seconds.
+func retryAfter(h http.Header) time.Duration {
+	if s, err := strconv.Atoi(h.Get("Retry-After")); err == nil && s > 0 {
+		return time.Duration(s) * time.Second
+	}
+	return 0
+}
+
+// Notifier sends messages through a provider with retries, multicast and
+// invalid token reporting.
+type Notifier struct {
+	*options
+	provider Provider
+}
+
+// New creates a Notifier for the provider (see NewFCM and NewAPNs).
+func New(provider Provider, opts ...Option) *Notifier {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &Notifier{options: o, provider: provider}
+}
+
+// Provider returns the provider of the notifier.
+func (n *Notifier) Provider() Provider {
+	return n.provider
+}
+
+// Send delivers msg to one device, retrying temporary failures. A token the
+// provider rejects is reported to the invalid token handler and the error
+// matches ErrInvalidToken.
+func (n *Notifier) Send(ctx context.Context, token string, msg *Message) error {
+	if token == "" {
+		return ErrNoToken
+	}
+	if err := msg.validate(); err != nil {
+		return err
+	}
+
+	err := async.Retry(ctx, n.retry, func(ctx context.Context) error {
+		return n.provider.Send(ctx, token, msg)
+	})
+	if err != nil && n.onInvalidToken != nil && errors.Is(err, ErrInvalidToken) {
+		n.onInvalidToken(ctx, token, err)
+	}
+	return err
+}
+
+// SendResult is the outcome for one token of a multicast.
+type SendResult struct {
+	Token string
+	Err   error
+}
+
+// BatchResponse is the outcome of a multicast.
+type BatchResponse struct {
+	SuccessCount int
+	FailureCount int
+
+	// Results are in the order of the tokens.
+	Results []SendResult
+}
+
+// InvalidTokens returns the tokens rejected as invalid or unregistered.
+func (b *BatchResponse) InvalidTokens() []string {
+	var tokens []string
+	for _, r := range b.Results {
+		if errors.Is(r.Err, ErrInvalidToken) {
+			tokens = append(tokens, r.Token)
+		}
+	}
+	return tokens
+}
+
+// Failed returns the results that failed.
+func (b *BatchResponse) Failed() []SendResult {
+	var failed []SendResult
+	for _, r := range b.Results {
+		if r.Err != nil {
+			failed = append(failed, r)
+		}
+	}
+	return failed
+}
+
+// SendMulticast delivers msg to every token, sending up to the configured
+// concurrency at once. A failing token does not stop the others; the error
+// is only returned for an invalid message.
+func (n *Notifier) SendMulticast(ctx context.Context, tokens []string, msg *Message) (*BatchResponse, error) {
+	if err := msg.validate(); err != nil {
+		return nil, err
+	}
+
+	resp := &BatchResponse{Results: make([]SendResult, len(tokens))}
+	g, _ := async.NewGroup(ctx, n.concurrency)
+	for i, token := range tokens {
+		g.Go(func() error {
+			resp.Results[i] = SendResult{Token: token, Err: n.Send(ctx, token, msg)}
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	for _, r := range resp.Results {
+		if r.Err == nil {
+			resp.SuccessCount++
+		} else {
+			resp.FailureCount++
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,115 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/BevisDev/godev/utils/async"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeProvider fails the tokens of errs, the first fail[token] times or always.
+type fakeProvider struct {
+	mu    sync.Mutex
+	calls map[string]int
+	errs  map[string]error
+	fails map[string]int
+}
+
+func (p *fakeProvider) Name() string { return "fake" }
+
+func (p *fakeProvider) Send(_ context.Context, token string, _ *Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.calls == nil {
+		p.calls = map[string]int{}
+	}
+	p.calls[token]++
+	if err, ok := p.errs[token]; ok && p.calls[token] <= p.fails[token] {
+		return err
+	}
+	return nil
+}
+
+func fastRetry() Option {
+	return WithRetry(async.RetryPolicy{MaxAttempts: 3, InitialDelay: time.Millisecond})
+}
+
+func TestNotifier_Send(t *testing.T) {
+	unavailable := &Error{Provider: "fake", StatusCode: http.StatusServiceUnavailable}
+	unregistered := &Error{Provider: "fake", StatusCode: http.StatusNotFound, Reason: "UNREGISTERED", invalidToken: true}
+	p := &fakeProvider{
+		errs:  map[string]error{"flaky": unavailable, "gone": unregistered, "down": unavailable},
+		fails: map[string]int{"flaky": 2, "gone": 10, "down": 10},
+	}
+
+	var invalid []string
+	n := New(p, fastRetry(), WithInvalidTokenHandler(func(_ context.Context, token string, err error) {
+		assert.ErrorIs(t, err, ErrInvalidToken)
+		invalid = append(invalid, token)
+	}))
+	ctx := context.Background()
+	msg := NewMessage("Đơn hàng", "Đơn hàng #1 đã được giao")
+
+	require.NoError(t, n.Send(ctx, "flaky", msg))
+	assert.Equal(t, 3, p.calls["flaky"])
+
+	err := n.Send(ctx, "gone", msg)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+	assert.Equal(t, 1, p.calls["gone"], "invalid tokens are not retried")
+
+	err = n.Send(ctx, "down", msg)
+	assert.ErrorIs(t, err, unavailable)
+	assert.Equal(t, 3, p.calls["down"])
+	assert.Equal(t, []string{"gone"}, invalid)
+
+	assert.ErrorIs(t, n.Send(ctx, "", msg), ErrNoToken)
+	assert.ErrorIs(t, n.Send(ctx, "x", &Message{}), ErrEmptyMessage)
+	assert.NoError(t, n.Send(ctx, "x", (&Message{}).SetSilent()))
+}
+
+func TestNotifier_SendMulticast(t *testing.T) {
+	p := &fakeProvider{
+		errs:  map[string]error{"t2": &Error{StatusCode: 410, Reason: "Unregistered", invalidToken: true}, "t4": errors.New("boom")},
+		fails: map[string]int{"t2": 1, "t4": 1},
+	}
+	var handled atomic.Int32
+	n := New(p, fastRetry(), WithConcurrency(2), WithInvalidTokenHandler(func(context.Context, string, error) {
+		handled.Add(1)
+	}))
+
+	tokens := []string{"t1", "t2", "t3", "t4", "t5"}
+	resp, err := n.SendMulticast(context.Background(), tokens, NewMessage("a", "b"))
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, resp.SuccessCount)
+	assert.Equal(t, 2, resp.FailureCount)
+	for i, r := range resp.Results {
+		assert.Equal(t, tokens[i], r.Token)
+	}
+	assert.Equal(t, []string{"t2"}, resp.InvalidTokens())
+	assert.Len(t, resp.Failed(), 2)
+	assert.EqualValues(t, 1, handled.Load())
+
+	_, err = n.SendMulticast(context.Background(), tokens, nil)
+	assert.ErrorIs(t, err, ErrEmptyMessage)
+}
+
+func TestIsRetryable(t *testing.T) {
+	assert.True(t, IsRetryable(&Error{StatusCode: 429}))
+	assert.True(t, IsRetryable(&Error{StatusCode: 500}))
+	assert.True(t, IsRetryable(&Error{StatusCode: 403, retryable: true}))
+	assert.False(t, IsRetryable(&Error{StatusCode: 400}))
+	assert.False(t, IsRetryable(context.Canceled))
+	assert.False(t, IsRetryable(errors.New("x")))
+
+	e := &Error{Provider: "apns", StatusCode: 400, Reason: "BadDeviceToken"}
+	assert.True(t, strings.HasPrefix(e.Error(), "[notify] apns: status 400 BadDeviceToken"))
+}
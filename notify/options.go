@@ -0,0 +1,55 @@
+package notify
+
+import (
+	"context"
+
+	"github.com/BevisDev/godev/utils/async"
+)
+
+type Option func(*options)
+
+type options struct {
+	retry          async.RetryPolicy
+	concurrency    int
+	onInvalidToken func(ctx context.Context, token string, err error)
+}
+
+func defaultOptions() *options {
+	retry := async.DefaultRetryPolicy()
+	retry.RetryIf = IsRetryable
+	retry.Jitter = true
+	return &options{
+		retry:       retry,
+		concurrency: 10,
+	}
+}
+
+// WithRetry sets the retry policy (default: 3 attempts, exponential backoff
+// from 100ms with jitter). A nil RetryIf defaults to IsRetryable.
+// Use MaxAttempts 1 to disable retries.
+func WithRetry(policy async.RetryPolicy) Option {
+	return func(o *options) {
+		if policy.RetryIf == nil {
+			policy.RetryIf = IsRetryable
+		}
+		o.retry = policy
+	}
+}
+
+// WithConcurrency sets how many messages of a multicast are sent at once (default 10).
+func WithConcurrency(n int) Option {
+	return func(o *options) {
+		if n > 0 {
+			o.concurrency = n
+		}
+	}
+}
+
+// WithInvalidTokenHandler is called with every token the provider rejects as
+// invalid or unregistered, so the app can delete the device registration.
+// It runs on the sending goroutine.
+func WithInvalidTokenHandler(fn func(ctx context.Context, token string, err error)) Option {
+	return func(o *options) {
+		o.onInvalidToken = fn
+	}
+}
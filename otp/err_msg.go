@@ -0,0 +1,15 @@
+package otp
+
+import "errors"
+
+// Errors
+var (
+	ErrCacheNil         = errors.New("[otp] redis cache is nil")
+	ErrSenderNil        = errors.New("[otp] sender is nil")
+	ErrNoRecipient      = errors.New("[otp] recipient is empty")
+	ErrNotFound         = errors.New("[otp] code not found or expired")
+	ErrInvalidCode      = errors.New("[otp] invalid code")
+	ErrTooManyAttempts  = errors.New("[otp] too many attempts")
+	ErrResendTooSoon    = errors.New("[otp] resend requested too soon")
+	ErrSendLimitReached = errors.New("[otp] send limit reached")
+)
@@ -0,0 +1,100 @@
+package otp
+
+import (
+	"fmt"
+	"time"
+)
+
+type Option func(*options)
+
+type options struct {
+	length         int
+	ttl            time.Duration
+	maxAttempts    int
+	resendInterval time.Duration
+	maxSends       int
+	sendWindow     time.Duration
+	prefix         string
+	message        func(code string, ttl time.Duration) string
+}
+
+func defaultOptions() *options {
+	return &options{
+		length:         6,
+		ttl:            5 * time.Minute,
+		maxAttempts:    5,
+		resendInterval: time.Minute,
+		maxSends:       5,
+		sendWindow:     time.Hour,
+		prefix:         "otp:",
+		message: func(code string, ttl time.Duration) string {
+			return fmt.Sprintf("Your verification code is %s. It expires in %d minutes.", code, int(ttl.Minutes()))
+		},
+	}
+}
+
+// WithLength sets the number of digits of a code (default 6, 4 to 10).
+func WithLength(n int) Option {
+	return func(o *options) {
+		if n >= 4 && n <= 10 {
+			o.length = n
+		}
+	}
+}
+
+// WithTTL sets how long a code is valid (default 5 minutes).
+func WithTTL(d time.Duration) Option {
+	return func(o *options) {
+		if d > 0 {
+			o.ttl = d
+		}
+	}
+}
+
+// WithMaxAttempts sets the number of wrong codes accepted before the code
+// is revoked (default 5).
+func WithMaxAttempts(n int) Option {
+	return func(o *options) {
+		if n > 0 {
+			o.maxAttempts = n
+		}
+	}
+}
+
+// WithResendInterval sets the minimum time between two codes sent to the
+// same recipient (default 1 minute, 0 disables).
+func WithResendInterval(d time.Duration) Option {
+	return func(o *options) {
+		if d >= 0 {
+			o.resendInterval = d
+		}
+	}
+}
+
+// WithSendLimit limits the codes sent to the same recipient within window
+// (default 5 per hour, n 0 disables).
+func WithSendLimit(n int, window time.Duration) Option {
+	return func(o *options) {
+		if n >= 0 && window > 0 {
+			o.maxSends = n
+			o.sendWindow = window
+		}
+	}
+}
+
+// WithKeyPrefix sets the prefix of the Redis keys (default "otp:").
+func WithKeyPrefix(prefix string) Option {
+	return func(o *options) {
+		o.prefix = prefix
+	}
+}
+
+// WithMessage sets the text sent with the code, e.g. a localized message
+// or one rendered with templatex.
+func WithMessage(fn func(code string, ttl time.Duration) string) Option {
+	return func(o *options) {
+		if fn != nil {
+			o.message = fn
+		}
+	}
+}
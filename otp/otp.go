@@ -0,0 +1,201 @@
+// Package otp issues and verifies one-time passwords delivered by SMS (or any
+// Sender), with codes stored in Redis, attempt counting and resend throttling.
+package otp
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/BevisDev/godev/redis"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// Sender delivers the text of a code to a recipient. sms.Provider implements it.
+type Sender interface {
+	Send(ctx context.Context, to, text string) error
+}
+
+// ThrottleError is returned when a code is requested too soon or too often.
+// It matches ErrResendTooSoon or ErrSendLimitReached with errors.Is.
+type ThrottleError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+func (e *ThrottleError) Error() string {
+	return fmt.Sprintf("%v, retry after %s", e.Err, e.RetryAfter.Round(time.Second))
+}
+
+func (e *ThrottleError) Unwrap() error {
+	return e.Err
+}
+
+// verifyScript counts an attempt on an existing code and returns the attempts
+// and the code hash, or nil when there is no code. A missing key must not be
+// created by HINCRBY, as it would never expire.
+var verifyScript = goredis.NewScript(`
+local h = redis.call('HGET', KEYS[1], 'code')
+if not h then return false end
+local n = redis.call('HINCRBY', KEYS[1], 'attempts', 1)
+return {n, h}
+`)
+
+// countScript increments the send counter, starting its window on the first
+// send, and returns the count and the remaining window in milliseconds.
+var countScript = goredis.NewScript(`
+local n = redis.call('INCR', KEYS[1])
+if n == 1 then redis.call('PEXPIRE', KEYS[1], ARGV[1]) end
+return {n, redis.call('PTTL', KEYS[1])}
+`)
+
+// Manager sends and verifies codes. Codes are scoped by purpose ("login",
+// "reset-password") and recipient, so flows do not invalidate each other.
+type Manager struct {
+	*options
+	cache  *redis.Cache
+	sender Sender
+}
+
+// New creates a Manager storing codes in cache and delivering them with sender.
+func New(cache *redis.Cache, sender Sender, opts ...Option) (*Manager, error) {
+	if cache == nil {
+		return nil, ErrCacheNil
+	}
+	if sender == nil {
+		return nil, ErrSenderNil
+	}
+
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &Manager{options: o, cache: cache, sender: sender}, nil
+}
+
+func (m *Manager) key(purpose, to, suffix string) string {
+	return m.cache.Key(m.prefix + purpose + ":" + to + suffix)
+}
+
+// Send generates a code for purpose and recipient, replacing any previous
+// one, and sends it. Requests within the resend interval or over the send
+// limit fail with a *ThrottleError.
+func (m *Manager) Send(ctx context.Context, purpose, to string) error {
+	if to == "" {
+		return ErrNoRecipient
+	}
+	client := m.cache.GetClient()
+
+	cooldown := m.key(purpose, to, ":cooldown")
+	if m.resendInterval > 0 {
+		ok, err := client.SetNX(ctx, cooldown, 1, m.resendInterval).Result()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			ttl, _ := client.PTTL(ctx, cooldown).Result()
+			return &ThrottleError{Err: ErrResendTooSoon, RetryAfter: max(ttl, 0)}
+		}
+	}
+
+	if m.maxSends > 0 {
+		res, err := countScript.Run(ctx, client, []string{m.key(purpose, to, ":sends")}, m.sendWindow.Milliseconds()).Int64Slice()
+		if err != nil {
+			return err
+		}
+		if res[0] > int64(m.maxSends) {
+			return &ThrottleError{Err: ErrSendLimitReached, RetryAfter: time.Duration(max(res[1], 0)) * time.Millisecond}
+		}
+	}
+
+	code, err := Generate(m.length)
+	if err != nil {
+		return err
+	}
+
+	key := m.key(purpose, to, "")
+	_, err = client.TxPipelined(ctx, func(p goredis.Pipeliner) error {
+		p.Del(ctx, key)
+		p.HSet(ctx, key, "code", hash(key, code), "attempts", 0)
+		p.PExpire(ctx, key, m.ttl)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := m.sender.Send(ctx, to, m.message(code, m.ttl)); err != nil {
+		// the code never arrived: allow another request right away
+		client.Del(ctx, key, cooldown)
+		return err
+	}
+	return nil
+}
+
+// Verify checks code for purpose and recipient in constant time. A correct
+// code is consumed; after too many wrong codes the code is revoked and
+// ErrTooManyAttempts returned.
+func (m *Manager) Verify(ctx context.Context, purpose, to, code string) error {
+	client := m.cache.GetClient()
+	key := m.key(purpose, to, "")
+
+	res, err := verifyScript.Run(ctx, client, []string{key}).Slice()
+	if errors.Is(err, goredis.Nil) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	attempts, _ := res[0].(int64)
+	stored, _ := res[1].(string)
+
+	if attempts > int64(m.maxAttempts) {
+		client.Del(ctx, key)
+		return ErrTooManyAttempts
+	}
+	if subtle.ConstantTimeCompare([]byte(hash(key, code)), []byte(stored)) != 1 {
+		if attempts == int64(m.maxAttempts) {
+			client.Del(ctx, key)
+			return ErrTooManyAttempts
+		}
+		return ErrInvalidCode
+	}
+
+	// only one of concurrent verifications of the same code succeeds
+	n, err := client.Del(ctx, key).Result()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Revoke deletes the code of purpose and recipient, e.g. when the flow is cancelled.
+func (m *Manager) Revoke(ctx context.Context, purpose, to string) error {
+	return m.cache.GetClient().Del(ctx, m.key(purpose, to, "")).Err()
+}
+
+// Generate returns a random numeric code of n digits from crypto/rand.
+func Generate(n int) (string, error) {
+	limit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+	v, err := rand.Int(rand.Reader, limit)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", n, v.Int64()), nil
+}
+
+// hash keeps codes out of Redis in clear text; the key salts the hash so
+// equal codes of different recipients differ.
+func hash(key, code string) string {
+	sum := sha256.Sum256([]byte(key + ":" + code))
+	return hex.EncodeToString(sum[:])
+}
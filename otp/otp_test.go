@@ -0,0 +1,200 @@
+package otp
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/BevisDev/godev/otp/sms"
+	"github.com/BevisDev/godev/redis/redistest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// inbox records the texts sent to each recipient.
+type inbox struct {
+	mu   sync.Mutex
+	sent map[string][]string
+	err  error
+}
+
+func (b *inbox) Send(_ context.Context, to, text string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.err != nil {
+		return b.err
+	}
+	if b.sent == nil {
+		b.sent = map[string][]string{}
+	}
+	b.sent[to] = append(b.sent[to], text)
+	return nil
+}
+
+var codeRe = regexp.MustCompile(`\d{4,}`)
+
+// last returns the code of the last text sent to to.
+func (b *inbox) last(t *testing.T, to string) string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	require.NotEmpty(t, b.sent[to])
+	return codeRe.FindString(b.sent[to][len(b.sent[to])-1])
+}
+
+func newManager(t *testing.T, opts ...Option) (*Manager, *inbox, *redistest.TestCache) {
+	c := redistest.New(t)
+	box := &inbox{}
+	m, err := New(c.Cache, box, opts...)
+	require.NoError(t, err)
+	return m, box, c
+}
+
+func TestSendVerify(t *testing.T) {
+	m, box, c := newManager(t)
+	ctx := context.Background()
+	const phone = "+84901234567"
+
+	require.NoError(t, m.Send(ctx, "login", phone))
+	code := box.last(t, phone)
+	assert.Len(t, code, 6)
+	assert.Contains(t, box.sent[phone][0], "expires in 5 minutes")
+
+	c.AssertTTL("otp:login:"+phone, 5*time.Minute)
+	assert.NotContains(t, c.Server.HGet("otp:login:"+phone, "code"), code, "the code is stored hashed")
+
+	assert.ErrorIs(t, m.Verify(ctx, "reset", phone, code), ErrNotFound, "codes are scoped by purpose")
+	assert.ErrorIs(t, m.Verify(ctx, "login", phone, "000000x"), ErrInvalidCode)
+	require.NoError(t, m.Verify(ctx, "login", phone, code))
+	assert.ErrorIs(t, m.Verify(ctx, "login", phone, code), ErrNotFound, "a code is used once")
+	c.AssertMissing("otp:login:" + phone)
+}
+
+func TestVerify_MaxAttempts(t *testing.T) {
+	m, box, _ := newManager(t, WithMaxAttempts(3))
+	ctx := context.Background()
+
+	require.NoError(t, m.Send(ctx, "login", "p"))
+	code := box.last(t, "p")
+
+	assert.ErrorIs(t, m.Verify(ctx, "login", "p", "x1"), ErrInvalidCode)
+	assert.ErrorIs(t, m.Verify(ctx, "login", "p", "x2"), ErrInvalidCode)
+	assert.ErrorIs(t, m.Verify(ctx, "login", "p", "x3"), ErrTooManyAttempts)
+	assert.ErrorIs(t, m.Verify(ctx, "login", "p", code), ErrNotFound, "the code is revoked")
+}
+
+func TestVerify_Expired(t *testing.T) {
+	m, box, c := newManager(t, WithTTL(time.Minute), WithLength(4))
+	ctx := context.Background()
+
+	require.NoError(t, m.Send(ctx, "login", "p"))
+	code := box.last(t, "p")
+	assert.Len(t, code, 4)
+
+	c.FastForward(time.Minute + time.Second)
+	assert.ErrorIs(t, m.Verify(ctx, "login", "p", code), ErrNotFound)
+}
+
+func TestVerify_Concurrent(t *testing.T) {
+	m, box, _ := newManager(t)
+	ctx := context.Background()
+	require.NoError(t, m.Send(ctx, "login", "p"))
+	code := box.last(t, "p")
+
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+		ok int
+	)
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if m.Verify(ctx, "login", "p", code) == nil {
+				mu.Lock()
+				ok++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 1, ok)
+}
+
+func TestSend_Throttle(t *testing.T) {
+	m, box, c := newManager(t, WithResendInterval(time.Minute), WithSendLimit(2, time.Hour))
+	ctx := context.Background()
+
+	require.NoError(t, m.Send(ctx, "login", "p"))
+	first := box.last(t, "p")
+
+	err := m.Send(ctx, "login", "p")
+	var te *ThrottleError
+	require.ErrorAs(t, err, &te)
+	assert.ErrorIs(t, err, ErrResendTooSoon)
+	assert.InDelta(t, time.Minute, te.RetryAfter, float64(time.Second))
+	require.NoError(t, m.Send(ctx, "login", "other"), "throttling is per recipient")
+
+	c.FastForward(time.Minute)
+	require.NoError(t, m.Send(ctx, "login", "p"))
+	second := box.last(t, "p")
+	if first != second {
+		assert.ErrorIs(t, m.Verify(ctx, "login", "p", first), ErrInvalidCode, "a new code replaces the previous one")
+	}
+
+	c.FastForward(time.Minute)
+	err = m.Send(ctx, "login", "p")
+	assert.ErrorIs(t, err, ErrSendLimitReached)
+	require.ErrorAs(t, err, &te)
+	assert.InDelta(t, 58*time.Minute, te.RetryAfter, float64(time.Second))
+
+	c.FastForward(time.Hour)
+	require.NoError(t, m.Send(ctx, "login", "p"))
+}
+
+func TestSend_SenderError(t *testing.T) {
+	m, box, c := newManager(t)
+	ctx := context.Background()
+
+	box.err = errors.New("gateway down")
+	assert.EqualError(t, m.Send(ctx, "login", "p"), "gateway down")
+	c.AssertMissing("otp:login:p")
+
+	box.err = nil
+	require.NoError(t, m.Send(ctx, "login", "p"), "a failed send does not start the cooldown")
+}
+
+func TestNew(t *testing.T) {
+	c := redistest.New(t)
+	_, err := New(nil, &inbox{})
+	assert.ErrorIs(t, err, ErrCacheNil)
+	_, err = New(c.Cache, nil)
+	assert.ErrorIs(t, err, ErrSenderNil)
+
+	var sent string
+	m, err := New(c.Cache, sms.ProviderFunc(func(_ context.Context, _, text string) error {
+		sent = text
+		return nil
+	}), WithMessage(func(code string, _ time.Duration) string { return "Ma OTP: " + code }), WithKeyPrefix("sms:"))
+	require.NoError(t, err)
+	require.NoError(t, m.Send(context.Background(), "login", "p"))
+	assert.Regexp(t, `^Ma OTP: \d{6}$`, sent)
+	c.AssertExists("sms:login:p")
+
+	assert.ErrorIs(t, m.Send(context.Background(), "login", ""), ErrNoRecipient)
+	require.NoError(t, m.Revoke(context.Background(), "login", "p"))
+	c.AssertMissing("sms:login:p")
+}
+
+func TestGenerate(t *testing.T) {
+	seen := map[string]bool{}
+	for range 200 {
+		code, err := Generate(6)
+		require.NoError(t, err)
+		assert.Regexp(t, `^\d{6}$`, code)
+		seen[code] = true
+	}
+	assert.Greater(t, len(seen), 190)
+}
@@ -0,0 +1,56 @@
+// Package sms sends text messages through SMS gateways.
+package sms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Errors
+var (
+	ErrConfigNil = errors.New("[sms] config is nil")
+	ErrNoPhone   = errors.New("[sms] phone number is empty")
+	ErrEmptyText = errors.New("[sms] text is empty")
+)
+
+// Provider sends one text message to a phone number in E.164 format (+84901234567).
+type Provider interface {
+	Send(ctx context.Context, to, text string) error
+}
+
+// ProviderFunc adapts a function to a Provider, e.g. to log codes in development.
+type ProviderFunc func(ctx context.Context, to, text string) error
+
+func (f ProviderFunc) Send(ctx context.Context, to, text string) error {
+	return f(ctx, to, text)
+}
+
+// Error is a message rejected by the gateway.
+type Error struct {
+	Provider   string
+	StatusCode int
+	Code       string
+	Message    string
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("[sms] %s: status %d", e.Provider, e.StatusCode)
+	if e.Code != "" {
+		msg += " code " + e.Code
+	}
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+func validate(to, text string) error {
+	if to == "" {
+		return ErrNoPhone
+	}
+	if text == "" {
+		return ErrEmptyText
+	}
+	return nil
+}
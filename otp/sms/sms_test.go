@@ -0,0 +1,63 @@
+package sms
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTwilio_Send(t *testing.T) {
+	var form map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/2010-04-01/Accounts/AC123/Messages.json", r.URL.Path)
+		user, pass, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "AC123", user)
+		assert.Equal(t, "secret", pass)
+
+		require.NoError(t, r.ParseForm())
+		form = map[string]string{}
+		for k := range r.PostForm {
+			form[k] = r.PostForm.Get(k)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if form["To"] == "+84000" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"code":21211,"message":"The 'To' number +84000 is not a valid phone number.","status":400}`))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"sid":"SM1","status":"queued"}`))
+	}))
+	defer srv.Close()
+
+	tw, err := NewTwilio(&TwilioConfig{AccountSID: "AC123", AuthToken: "secret", From: "+15005550006", BaseURL: srv.URL}, nil)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, tw.Send(ctx, "+84901234567", "Mã OTP: 123456"))
+	assert.Equal(t, map[string]string{"To": "+84901234567", "From": "+15005550006", "Body": "Mã OTP: 123456"}, form)
+
+	err = tw.Send(ctx, "+84000", "x")
+	var e *Error
+	require.ErrorAs(t, err, &e)
+	assert.Equal(t, http.StatusBadRequest, e.StatusCode)
+	assert.Equal(t, "21211", e.Code)
+	assert.Contains(t, e.Error(), "[sms] twilio: status 400 code 21211")
+
+	tw.cfg.MessagingServiceSID = "MG1"
+	require.NoError(t, tw.Send(ctx, "+84901234567", "x"))
+	assert.Equal(t, "MG1", form["MessagingServiceSid"])
+	assert.NotContains(t, form, "From")
+
+	assert.ErrorIs(t, tw.Send(ctx, "", "x"), ErrNoPhone)
+	assert.ErrorIs(t, tw.Send(ctx, "+84", ""), ErrEmptyText)
+
+	_, err = NewTwilio(nil, nil)
+	assert.ErrorIs(t, err, ErrConfigNil)
+}
@@ -0,0 +1,94 @@
+package sms
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BevisDev/godev/rest"
+)
+
+const twilioBaseURL = "https://api.twilio.com"
+
+// TwilioConfig configures the Twilio Programmable Messaging provider.
+type TwilioConfig struct {
+	AccountSID string
+	AuthToken  string
+
+	// From is the sender number; MessagingServiceSID is used instead when set.
+	From                string
+	MessagingServiceSID string
+
+	// BaseURL overrides the API address (testing).
+	BaseURL string
+}
+
+// Twilio sends messages with the Twilio Messages API.
+type Twilio struct {
+	cfg    *TwilioConfig
+	client *rest.Client
+	auth   string
+}
+
+// NewTwilio creates a Twilio provider. A nil client uses a rest client with a 10s timeout.
+func NewTwilio(cfg *TwilioConfig, client *rest.Client) (*Twilio, error) {
+	if cfg == nil {
+		return nil, ErrConfigNil
+	}
+	if client == nil {
+		client = rest.New(rest.WithTimeout(10 * time.Second))
+	}
+	return &Twilio{
+		cfg:    cfg,
+		client: client,
+		auth:   "Basic " + base64.StdEncoding.EncodeToString([]byte(cfg.AccountSID+":"+cfg.AuthToken)),
+	}, nil
+}
+
+type twilioMessage struct {
+	SID    string `json:"sid"`
+	Status string `json:"status"`
+}
+
+type twilioError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Send sends text to the phone number.
+func (t *Twilio) Send(ctx context.Context, to, text string) error {
+	if err := validate(to, text); err != nil {
+		return err
+	}
+
+	form := map[string]string{"To": to, "Body": text}
+	if t.cfg.MessagingServiceSID != "" {
+		form["MessagingServiceSid"] = t.cfg.MessagingServiceSID
+	} else {
+		form["From"] = t.cfg.From
+	}
+
+	baseURL := t.cfg.BaseURL
+	if baseURL == "" {
+		baseURL = twilioBaseURL
+	}
+
+	_, err := rest.NewRequest[twilioMessage](t.client).
+		URL(strings.TrimRight(baseURL, "/") + "/2010-04-01/Accounts/:sid/Messages.json").
+		PathParams(map[string]string{"sid": t.cfg.AccountSID}).
+		Headers(map[string]string{"Authorization": t.auth}).
+		BodyForm(form).
+		PostForm(ctx)
+	if httpErr, ok := rest.AsHTTPError(err); ok {
+		e := &Error{Provider: "twilio", StatusCode: httpErr.Status, Message: httpErr.Body}
+		var body twilioError
+		if json.Unmarshal([]byte(httpErr.Body), &body) == nil && body.Code != 0 {
+			e.Code, e.Message = strconv.Itoa(body.Code), body.Message
+		}
+		return e
+	}
+	return err
+}
@@ -0,0 +1,10 @@
+package querystore
+
+import "errors"
+
+// Errors
+var (
+	ErrNotFound  = errors.New("[querystore] query not found")
+	ErrDuplicate = errors.New("[querystore] duplicate query")
+	ErrEmptyName = errors.New("[querystore] query name is empty")
+)
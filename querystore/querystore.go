@@ -0,0 +1,279 @@
+package querystore
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/BevisDev/godev/database"
+)
+
+// Store holds named SQL statements loaded from .sql files.
+//
+// A file contains one or more queries, each starting with a "-- name:" comment.
+// A "-- dialect:" comment right after it marks a dialect-specific variant;
+// queries without it are used for every other dialect:
+//
+//	-- user.sql
+//	-- name: findByEmail
+//	SELECT id, name, email FROM users WHERE email = ?
+//
+//	-- name: search
+//	SELECT id, name FROM users WHERE 1 = 1
+//	{{if .Name}} AND name LIKE ?{{end}}
+//	ORDER BY id
+//
+//	-- name: search
+//	-- dialect: postgres
+//	SELECT id, name FROM users WHERE 1 = 1
+//	{{if .Name}} AND name ILIKE ?{{end}}
+//	ORDER BY id
+//
+// Names are prefixed with the file path without extension, using dots:
+// "user.findByEmail", "admin/report.sql" -> "admin.report.daily".
+// Queries containing "{{" are text/template templates rendered by Get.
+type Store struct {
+	mu      sync.RWMutex
+	dialect string
+	funcs   template.FuncMap
+	queries map[string]map[string]*query // name -> dialect ("" for any) -> query
+}
+
+type query struct {
+	sql  string
+	tmpl *template.Template
+}
+
+// New creates an empty Store resolving dialect-specific queries for dialect.
+func New(dialect database.DBType) *Store {
+	return &Store{
+		dialect: dialect.String(),
+		funcs:   template.FuncMap{},
+		queries: make(map[string]map[string]*query),
+	}
+}
+
+// Funcs adds functions available to query templates. It must be called before loading.
+func (s *Store) Funcs(funcs template.FuncMap) *Store {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for k, v := range funcs {
+		s.funcs[k] = v
+	}
+	return s
+}
+
+// LoadDir loads every .sql file under dir, recursively.
+func (s *Store) LoadDir(dir string) error {
+	return s.LoadFS(os.DirFS(dir), ".")
+}
+
+// LoadFS loads every .sql file under dir of fsys, recursively, e.g. an embed.FS:
+//
+//	//go:embed queries
+//	var queries embed.FS
+//	err := store.LoadFS(queries, "queries")
+func (s *Store) LoadFS(fsys fs.FS, dir string) error {
+	return fs.WalkDir(fsys, dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.EqualFold(path.Ext(p), ".sql") {
+			return nil
+		}
+		data, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return err
+		}
+
+		rel := p
+		if dir != "." {
+			rel = strings.TrimPrefix(p, dir+"/")
+		}
+		namespace := strings.ReplaceAll(strings.TrimSuffix(rel, path.Ext(rel)), "/", ".")
+		return s.Parse(namespace, data)
+	})
+}
+
+// Parse loads the queries of a single .sql source, prefixing their names with namespace
+// (no prefix when namespace is empty).
+func (s *Store) Parse(namespace string, src []byte) error {
+	var (
+		name, dialect string
+		body          strings.Builder
+		inHeader      bool
+	)
+
+	flush := func() error {
+		if name == "" {
+			return nil
+		}
+		full := name
+		if namespace != "" {
+			full = namespace + "." + name
+		}
+		return s.Add(full, dialect, body.String())
+	}
+
+	sc := bufio.NewScanner(bytes.NewReader(src))
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		line := sc.Text()
+		if v, ok := directive(line, "name"); ok {
+			if err := flush(); err != nil {
+				return err
+			}
+			name, dialect, inHeader = v, "", true
+			body.Reset()
+			continue
+		}
+		if inHeader {
+			if v, ok := directive(line, "dialect"); ok {
+				dialect = strings.ToLower(v)
+				continue
+			}
+			inHeader = false
+		}
+		if name != "" {
+			body.WriteString(line)
+			body.WriteByte('\n')
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return fmt.Errorf("[querystore] read %s: %w", namespace, err)
+	}
+	return flush()
+}
+
+// directive parses a "-- key: value" comment line.
+func directive(line, key string) (string, bool) {
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, "--") {
+		return "", false
+	}
+	line = strings.TrimSpace(strings.TrimPrefix(line, "--"))
+	k, v, ok := strings.Cut(line, ":")
+	if !ok || !strings.EqualFold(strings.TrimSpace(k), key) {
+		return "", false
+	}
+	return strings.TrimSpace(v), true
+}
+
+// Add registers sql under name for dialect ("" for any dialect).
+func (s *Store) Add(name, dialect, sql string) error {
+	if name == "" {
+		return ErrEmptyName
+	}
+	sql = strings.TrimSpace(sql)
+	if sql == "" {
+		return fmt.Errorf("[querystore] query %s is empty", name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	q := &query{sql: sql}
+	if strings.Contains(sql, "{{") {
+		tmpl, err := template.New(name).Funcs(s.funcs).Option("missingkey=error").Parse(sql)
+		if err != nil {
+			return fmt.Errorf("[querystore] parse %s: %w", name, err)
+		}
+		q.tmpl = tmpl
+	}
+
+	variants, ok := s.queries[name]
+	if !ok {
+		variants = make(map[string]*query)
+		s.queries[name] = variants
+	}
+	if _, exists := variants[dialect]; exists {
+		if dialect != "" {
+			return fmt.Errorf("%w: %s (%s)", ErrDuplicate, name, dialect)
+		}
+		return fmt.Errorf("%w: %s", ErrDuplicate, name)
+	}
+	variants[dialect] = q
+	return nil
+}
+
+// Get returns the SQL of name for the store dialect, rendering it with data when it is a template.
+func (s *Store) Get(name string, data ...any) (string, error) {
+	return s.GetFor(s.dialect, name, data...)
+}
+
+// GetFor is like Get for an explicit dialect (e.g. "postgres").
+func (s *Store) GetFor(dialect, name string, data ...any) (string, error) {
+	s.mu.RLock()
+	variants := s.queries[name]
+	q, ok := variants[dialect]
+	if !ok {
+		q, ok = variants[""]
+	}
+	s.mu.RUnlock()
+
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	if q.tmpl == nil {
+		return q.sql, nil
+	}
+
+	var d any
+	if len(data) > 0 {
+		d = data[0]
+	}
+	var buf strings.Builder
+	if err := q.tmpl.Execute(&buf, d); err != nil {
+		return "", fmt.Errorf("[querystore] render %s: %w", name, err)
+	}
+	return collapseBlankLines(buf.String()), nil
+}
+
+// MustGet is like Get but panics on error; use it for static queries checked at startup.
+func (s *Store) MustGet(name string, data ...any) string {
+	sql, err := s.Get(name, data...)
+	if err != nil {
+		panic(err)
+	}
+	return sql
+}
+
+// Has reports whether name is registered for any dialect.
+func (s *Store) Has(name string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.queries[name]
+	return ok
+}
+
+// Names returns the registered query names, sorted.
+func (s *Store) Names() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	names := make([]string, 0, len(s.queries))
+	for n := range s.queries {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// collapseBlankLines removes the empty lines left by template actions.
+func collapseBlankLines(sql string) string {
+	lines := strings.Split(sql, "\n")
+	out := lines[:0]
+	for _, l := range lines {
+		if strings.TrimSpace(l) != "" {
+			out = append(out, l)
+		}
+	}
+	return strings.Join(out, "\n")
+}
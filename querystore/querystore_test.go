@@ -0,0 +1,93 @@
+package querystore
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/BevisDev/godev/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStore(t *testing.T, dialect database.DBType) *Store {
+	t.Helper()
+	s := New(dialect)
+	require.NoError(t, s.LoadFS(os.DirFS("testdata"), "queries"))
+	return s
+}
+
+func TestStore_LoadFS(t *testing.T) {
+	s := newTestStore(t, database.MySQL)
+
+	assert.Equal(t, []string{"admin.report.daily", "user.findByEmail", "user.rename", "user.search"}, s.Names())
+	assert.True(t, s.Has("user.findByEmail"))
+	assert.False(t, s.Has("findByEmail"))
+
+	sql, err := s.Get("user.findByEmail")
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT id, name, email\nFROM users\nWHERE email = ?", sql)
+}
+
+func TestStore_Get_Template(t *testing.T) {
+	s := newTestStore(t, database.MySQL)
+
+	sql, err := s.Get("user.search", map[string]any{"Name": true})
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT id, name FROM users WHERE 1 = 1\n AND name LIKE ?\nORDER BY id", sql)
+
+	sql, err = s.Get("user.search", map[string]any{"Name": false})
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT id, name FROM users WHERE 1 = 1\nORDER BY id", sql)
+
+	_, err = s.Get("user.search", map[string]any{})
+	assert.Error(t, err, "missing key must fail")
+}
+
+func TestStore_Get_Dialect(t *testing.T) {
+	s := newTestStore(t, database.Postgres)
+
+	sql, err := s.Get("user.search", map[string]any{"Name": true})
+	require.NoError(t, err)
+	assert.Contains(t, sql, "ILIKE")
+
+	// no postgres variant: falls back to the generic query
+	sql, err = s.Get("user.rename")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(sql, "UPDATE users"))
+
+	sql, err = s.GetFor("sqlserver", "user.search", map[string]any{"Name": true})
+	require.NoError(t, err)
+	assert.NotContains(t, sql, "ILIKE")
+}
+
+func TestStore_Errors(t *testing.T) {
+	s := New(database.MySQL)
+
+	_, err := s.Get("missing")
+	assert.True(t, errors.Is(err, ErrNotFound))
+
+	require.NoError(t, s.Parse("user", []byte("-- name: a\nSELECT 1\n")))
+	err = s.Parse("user", []byte("-- name: a\nSELECT 2\n"))
+	assert.True(t, errors.Is(err, ErrDuplicate))
+
+	err = s.Parse("user", []byte("-- name: empty\n\n-- name: b\nSELECT 1"))
+	assert.Error(t, err)
+
+	err = s.Add("bad", "", "SELECT {{if .X}}")
+	assert.Error(t, err)
+
+	assert.Panics(t, func() { s.MustGet("missing") })
+}
+
+func TestStore_Funcs(t *testing.T) {
+	s := New(database.MySQL).Funcs(map[string]any{
+		"cols": func(cols []string) string { return strings.Join(cols, ", ") },
+	})
+	require.NoError(t, s.Add("user.list", "", "SELECT {{cols .Cols}} FROM users"))
+
+	sql, err := s.Get("user.list", map[string]any{"Cols": []string{"id", "name"}})
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT id, name FROM users", sql)
+}
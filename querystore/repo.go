@@ -0,0 +1,77 @@
+package querystore
+
+import (
+	"context"
+
+	"github.com/BevisDev/godev/database"
+)
+
+// Repo runs named queries of a Store on a database, using the variant of the database dialect.
+type Repo struct {
+	store *Store
+	db    *database.DB
+	data  any
+}
+
+// Bind returns a Repo running the store queries on db.
+//
+// Example:
+//
+//	repo := store.Bind(db)
+//	var u User
+//	err := repo.GetAny(ctx, &u, "user.findByEmail", email)
+//
+//	var users []User
+//	err = repo.With(map[string]any{"Name": true}).GetList(ctx, &users, "user.search", "%an%")
+func (s *Store) Bind(db *database.DB) *Repo {
+	return &Repo{store: s, db: db}
+}
+
+// With returns a copy of the Repo rendering query templates with data.
+func (r *Repo) With(data any) *Repo {
+	c := *r
+	c.data = data
+	return &c
+}
+
+// SQL returns the rendered SQL of name for the database dialect.
+func (r *Repo) SQL(name string) (string, error) {
+	return r.store.GetFor(r.db.Type().String(), name, r.data)
+}
+
+// GetList runs the named query and scans all rows into dest (see database.DB.GetList).
+func (r *Repo) GetList(ctx context.Context, dest any, name string, args ...any) error {
+	query, err := r.SQL(name)
+	if err != nil {
+		return err
+	}
+	return r.db.GetList(ctx, dest, query, args...)
+}
+
+// GetAny runs the named query and scans a single row into dest (see database.DB.GetAny).
+func (r *Repo) GetAny(ctx context.Context, dest any, name string, args ...any) error {
+	query, err := r.SQL(name)
+	if err != nil {
+		return err
+	}
+	return r.db.GetAny(ctx, dest, query, args...)
+}
+
+// Execute runs the named statement in a transaction (see database.DB.ExecuteTx).
+func (r *Repo) Execute(ctx context.Context, name string, args ...any) error {
+	query, err := r.SQL(name)
+	if err != nil {
+		return err
+	}
+	// Execute does not rebind, so convert ? to the dialect placeholders here
+	return r.db.ExecuteTx(ctx, r.db.GetDB().Rebind(query), args...)
+}
+
+// Save runs the named statement with named parameters (:name) bound from arg (see database.DB.SaveTx).
+func (r *Repo) Save(ctx context.Context, name string, arg any) error {
+	query, err := r.SQL(name)
+	if err != nil {
+		return err
+	}
+	return r.db.SaveTx(ctx, query, arg)
+}
@@ -0,0 +1,2 @@
+-- name: daily
+SELECT COUNT(1) FROM orders WHERE created_at >= ?
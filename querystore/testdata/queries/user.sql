@@ -0,0 +1,20 @@
+-- Queries of the users table.
+
+-- name: findByEmail
+SELECT id, name, email
+FROM users
+WHERE email = ?
+
+-- name: search
+SELECT id, name FROM users WHERE 1 = 1
+{{if .Name}} AND name LIKE ?{{end}}
+ORDER BY id
+
+-- name: search
+-- dialect: postgres
+SELECT id, name FROM users WHERE 1 = 1
+{{if .Name}} AND name ILIKE ?{{end}}
+ORDER BY id
+
+-- name: rename
+UPDATE users SET name = ? WHERE id = ?
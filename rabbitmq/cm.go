@@ -8,8 +8,8 @@ import (
 	"time"
 
 	"github.com/BevisDev/godev/consts"
-	"github.com/BevisDev/godev/utils"
 	"github.com/BevisDev/godev/utils/console"
+	"github.com/BevisDev/godev/utils/ctxmeta"
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
@@ -282,13 +282,13 @@ func (m *CM) handleMsg(ctx context.Context, queueName string, h Handler, msg *Ms
 	return h.Handle(ctx, msg)
 }
 
-// newMsgCtx creates a new context with correlation from msg
+// newMsgCtx creates a new context with the RID from the X-Request-ID header,
+// falling back to the correlation ID of msg
 func (m *CM) newMsgCtx(msg *MsgHandler) context.Context {
-	newCtx := utils.NewCtx()
-	newCtx = utils.SetValueCtx(newCtx,
-		consts.RID,
-		msg.CorrelationID(),
-	)
+	rid, ok := msg.Header(consts.XRequestID).(string)
+	if !ok || rid == "" {
+		rid = msg.CorrelationID()
+	}
 
-	return newCtx
+	return ctxmeta.WithRID(context.Background(), rid)
 }
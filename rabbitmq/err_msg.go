@@ -8,6 +8,8 @@ var (
 	ErrConnectionClosed  = errors.New("[rabbitmq]: connection is closed")
 	ErrClientClosed      = errors.New("[rabbitmq]: client is already closed")
 	ErrMaxRetriesReached = errors.New("[rabbitmq]: max connection retries reached")
+	ErrConnectionBlocked = errors.New("[rabbitmq]: connection blocked by broker")
+	ErrTopologyMismatch  = errors.New("[rabbitmq]: topology mismatch")
 
 	// queue
 	ErrRequiredQueue       = errors.New("[queue] at least one queue name is required")
@@ -19,4 +21,9 @@ var (
 	// producer
 	ErrMessageTooLarge = errors.New("[producer] message exceeds maximum size limit")
 	ErrInvalidMessage  = errors.New("[producer] invalid message format")
+
+	// rpc
+	ErrRPCTimeout = errors.New("[rpc] no reply before timeout")
+	ErrRPCNoRoute = errors.New("[rpc] request was not routed to a queue")
+	ErrRPCFailed  = errors.New("[rpc] handler failed")
 )
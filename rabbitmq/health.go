@@ -0,0 +1,152 @@
+package rabbitmq
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/BevisDev/godev/utils/reconnect"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// Status describes the state of the connection.
+type Status struct {
+	Connected bool
+
+	// State is the connection state of the reconnect policy.
+	State reconnect.State
+
+	// Blocked is true while the broker blocks publishing on the connection
+	// (memory or disk alarm); BlockedReason is the reason sent by the broker.
+	Blocked       bool
+	BlockedReason string
+
+	// Heartbeat is the heartbeat interval negotiated with the broker.
+	// A missed heartbeat closes the connection, which triggers a reconnect.
+	Heartbeat time.Duration
+}
+
+// Status returns the current connection status without opening a channel.
+func (r *MQ) Status() Status {
+	var st Status
+
+	r.connMu.RLock()
+	conn := r.connection
+	r.connMu.RUnlock()
+
+	if conn != nil && !conn.IsClosed() && !r.isClosed() {
+		st.Connected = true
+		st.Heartbeat = conn.Config.Heartbeat
+	}
+
+	st.State = r.State()
+
+	r.blockingMu.RLock()
+	st.Blocked = r.blocking.Active
+	st.BlockedReason = r.blocking.Reason
+	r.blockingMu.RUnlock()
+
+	return st
+}
+
+// Health checks the health status of the connection: it fails when the
+// connection is blocked by the broker or a channel cannot be used.
+func (r *MQ) Health() error {
+	if r.isClosed() {
+		return ErrClientClosed
+	}
+
+	st := r.Status()
+	if st.Blocked {
+		return fmt.Errorf("%w: %s", ErrConnectionBlocked, st.BlockedReason)
+	}
+	// fail fast instead of waiting for the reconnect
+	if st.State != reconnect.StateConnected {
+		return fmt.Errorf("%w (%s)", ErrConnectionClosed, st.State)
+	}
+
+	return r.WithChannel(func(ch *amqp.Channel) error {
+		// Try to declare a temporary queue to verify channel works
+		_, err := ch.QueueDeclare(
+			"",    // name (auto-generated)
+			false, // durable
+			true,  // delete when unused
+			true,  // exclusive
+			false, // no-wait
+			nil,   // arguments
+		)
+		return err
+	})
+}
+
+// Topology verifies that everything declared with Queue().Declare and
+// CreateQueues still exists on the broker. Queues and exchanges are checked
+// with passive declares. Bindings cannot be read over AMQP, so they are
+// re-asserted (QueueBind is idempotent) after their queue and exchange are found.
+//
+// All problems are returned joined; each wraps ErrTopologyMismatch.
+func (r *MQ) Topology() error {
+	if r.isClosed() {
+		return ErrClientClosed
+	}
+
+	spec := r.queue.spec()
+	var errs []error
+
+	missingQueues := make(map[string]bool)
+	for _, qu := range spec.Queues {
+		// a failed passive declare closes the channel, so use one per check
+		err := r.WithChannel(func(ch *amqp.Channel) error {
+			_, err := ch.QueueDeclarePassive(qu.Name, true, false, false, false, qu.Args)
+			return err
+		})
+		if err != nil {
+			missingQueues[qu.Name] = true
+			errs = append(errs, fmt.Errorf("%w: queue '%s': %v", ErrTopologyMismatch, qu.Name, err))
+		}
+	}
+
+	for _, ex := range spec.Exchanges {
+		err := r.WithChannel(func(ch *amqp.Channel) error {
+			return ch.ExchangeDeclarePassive(ex.Name, ex.Type.String(), true, false, false, false, nil)
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%w: exchange '%s': %v", ErrTopologyMismatch, ex.Name, err))
+			continue
+		}
+
+		for _, b := range ex.Bindings {
+			if missingQueues[b.Queue] {
+				continue
+			}
+			err := r.WithChannel(func(ch *amqp.Channel) error {
+				return ch.QueueBind(b.Queue, b.RoutingKey, ex.Name, false, b.Args)
+			})
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%w: binding '%s' -> '%s' (%s): %v",
+					ErrTopologyMismatch, ex.Name, b.Queue, b.RoutingKey, err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// watchBlocked records connection.blocked notifications until the
+// connection closes the channel.
+func (r *MQ) watchBlocked(ch <-chan amqp.Blocking) {
+	for b := range ch {
+		if b.Active {
+			r.log.Info("connection blocked by broker: %s", b.Reason)
+		} else {
+			r.log.Info("connection unblocked")
+		}
+		r.setBlocking(b)
+	}
+}
+
+func (r *MQ) setBlocking(b amqp.Blocking) {
+	r.blockingMu.Lock()
+	r.blocking = b
+	r.blockingMu.Unlock()
+}
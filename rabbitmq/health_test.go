@@ -0,0 +1,35 @@
+package rabbitmq
+
+import (
+	"testing"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatus_Blocked(t *testing.T) {
+	mq := &MQ{}
+
+	st := mq.Status()
+	assert.False(t, st.Connected)
+	assert.False(t, st.Blocked)
+
+	mq.setBlocking(amqp.Blocking{Active: true, Reason: "low on memory"})
+	st = mq.Status()
+	assert.True(t, st.Blocked)
+	assert.Equal(t, "low on memory", st.BlockedReason)
+
+	err := mq.Health()
+	require.ErrorIs(t, err, ErrConnectionBlocked)
+	assert.Contains(t, err.Error(), "low on memory")
+}
+
+func TestStatus_Connected(t *testing.T) {
+	mq := newTestMQ(t)
+
+	st := mq.Status()
+	assert.True(t, st.Connected)
+	assert.False(t, st.Blocked)
+	require.NoError(t, mq.Health())
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/BevisDev/godev/utils"
 	"github.com/BevisDev/godev/utils/console"
+	"github.com/BevisDev/godev/utils/reconnect"
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
@@ -29,6 +30,14 @@ type MQ struct {
 	// Connection lifecycle management
 	closeNotify chan *amqp.Error
 	reconnectCh chan struct{}
+	reconnectMu sync.Mutex // serializes reconnects
+
+	state   reconnect.State
+	stateMu sync.RWMutex
+
+	// blocking is the last connection.blocked/unblocked notification
+	blocking   amqp.Blocking
+	blockingMu sync.RWMutex
 
 	closed   bool
 	closedMu sync.RWMutex
@@ -88,6 +97,7 @@ func New(c context.Context, cfg *Config, opts ...Option) (*MQ, error) {
 	r.wg.Add(1)
 	go r.monitorConnection()
 
+	r.setState(reconnect.StateConnected, 0, nil)
 	r.log.Info("connected successfully")
 	return r, nil
 }
@@ -108,6 +118,11 @@ func (r *MQ) monitorConnection() {
 			}
 
 			r.log.Info("connection closed: %v", err)
+			var cause error
+			if err != nil {
+				cause = err
+			}
+			r.setState(reconnect.StateDisconnected, 0, cause)
 
 			// Trigger reconnection
 			select {
@@ -148,6 +163,8 @@ func (r *MQ) connect() error {
 	r.connection = conn
 	r.closeNotify = make(chan *amqp.Error, 1)
 	r.connection.NotifyClose(r.closeNotify)
+	r.setBlocking(amqp.Blocking{})
+	go r.watchBlocked(r.connection.NotifyBlocked(make(chan amqp.Blocking, 1)))
 
 	return nil
 }
@@ -203,62 +220,76 @@ func (r *MQ) Close() {
 	r.log.Info("shutdown complete")
 }
 
-// reconnect attempts to reconnect with exponential backoff
+// reconnect reconnects following the reconnect policy; it returns at once when
+// another caller has already reconnected.
 func (r *MQ) reconnect() error {
 	if r.isClosed() {
 		return ErrClientClosed
 	}
 
-	maxRetries := r.options.reconnectMaxRetries
-	baseDelay := time.Second
+	r.reconnectMu.Lock()
+	defer r.reconnectMu.Unlock()
 
-	for attempt := 1; attempt <= maxRetries; attempt++ {
-		select {
-		case <-r.ctx.Done():
-			return r.ctx.Err()
-		default:
-		}
-
-		r.log.Info("attempting %d to reconnect...", attempt)
-
-		if err := r.connect(); err != nil {
-			delay := min(baseDelay*time.Duration(1<<uint(attempt-1)), 30*time.Second)
+	if r.isConnected() {
+		return nil
+	}
 
-			r.log.Info("reconnect failed: err=%v, retry after %v", err, delay)
+	policy := r.options.reconnect
+	policy.OnStateChange = r.onState
 
-			time.Sleep(delay)
-			continue
+	err := policy.Run(r.ctx, "rabbitmq", func(ctx context.Context) error {
+		err := r.connect()
+		if err != nil {
+			r.log.Info("reconnect failed: err=%v", err)
 		}
-
-		r.log.Info("reconnected successfully")
-		return nil
+		return err
+	})
+	if errors.Is(err, reconnect.ErrMaxAttempts) {
+		return fmt.Errorf("%w: %w", ErrMaxRetriesReached, err)
+	}
+	if err != nil {
+		return err
 	}
 
-	return ErrMaxRetriesReached
+	r.log.Info("reconnected successfully")
+	return nil
 }
 
-// Health checks the health status of the connection
-func (r *MQ) Health() error {
-	if r.isClosed() {
-		return ErrClientClosed
+// isConnected reports whether the current connection is open.
+func (r *MQ) isConnected() bool {
+	r.connMu.RLock()
+	defer r.connMu.RUnlock()
+	return r.connection != nil && !r.connection.IsClosed()
+}
+
+// State returns the connection state.
+func (r *MQ) State() reconnect.State {
+	r.stateMu.RLock()
+	defer r.stateMu.RUnlock()
+	return r.state
+}
+
+// onState records the state of e and passes e to the OnStateChange of the
+// reconnect policy and to the state listeners.
+func (r *MQ) onState(e reconnect.Event) {
+	r.stateMu.Lock()
+	r.state = e.State
+	r.stateMu.Unlock()
+
+	if fn := r.options.reconnect.OnStateChange; fn != nil {
+		fn(e)
 	}
+	for _, fn := range r.options.stateListeners {
+		fn(e)
+	}
+}
 
-	return r.WithChannel(func(ch *amqp.Channel) error {
-		// Try to declare a temporary queue to verify channel works
-		_, err := ch.QueueDeclare(
-			"",    // name (auto-generated)
-			false, // durable
-			true,  // delete when unused
-			true,  // exclusive
-			false, // no-wait
-			nil,   // arguments
-		)
-		return err
-	})
+func (r *MQ) setState(state reconnect.State, attempt int, err error) {
+	r.onState(reconnect.Event{Name: "rabbitmq", State: state, Attempt: attempt, Err: err, Time: time.Now()})
 }
 
-// GetConnection returns a live connection, reconnecting if needed.
-// It retries indefinitely until a connection is established.
+// GetConnection returns a live connection, reconnecting if needed
+// following the reconnect policy (see WithReconnectPolicy).
 func (r *MQ) GetConnection() (*amqp.Connection, error) {
 	if r.isClosed() {
 		return nil, ErrClientClosed
@@ -288,13 +319,22 @@ func (r *MQ) GetConnection() (*amqp.Connection, error) {
 	return r.connection, nil
 }
 
-// GetChannel returns a new channel from the current connection.
+// GetChannel returns a new channel from the current connection, reconnecting once
+// when the connection was closed meanwhile.
 func (r *MQ) GetChannel() (*amqp.Channel, error) {
 	conn, err := r.GetConnection()
 	if err != nil {
 		return nil, err
 	}
 
+	ch, err := conn.Channel()
+	if !errors.Is(err, amqp.ErrClosed) {
+		return ch, err
+	}
+
+	if conn, err = r.GetConnection(); err != nil {
+		return nil, err
+	}
 	return conn.Channel()
 }
 
@@ -3,7 +3,11 @@ package rabbitmq
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/BevisDev/godev/utils/console"
+	"github.com/BevisDev/godev/utils/reconnect"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -50,3 +54,35 @@ func TestConnectMQ(t *testing.T) {
 
 	_ = ch.Close()
 }
+
+func TestReconnect_Policy(t *testing.T) {
+	var events []reconnect.State
+	opt := withDefaults()
+	WithReconnectPolicy(reconnect.Policy{
+		InitialDelay:  time.Millisecond,
+		MaxAttempts:   2,
+		OnStateChange: func(e reconnect.Event) { events = append(events, e.State) },
+	})(opt)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	mq := &MQ{
+		options: opt,
+		// nothing listens on port 1
+		config: &Config{Host: "127.0.0.1", Port: 1, Username: "guest", Password: "guest"},
+		ctx:    ctx,
+		cancel: cancel,
+		log:    console.New("rabbitmq"),
+	}
+
+	err := mq.reconnect()
+	assert.ErrorIs(t, err, ErrMaxRetriesReached)
+	assert.Equal(t, []reconnect.State{
+		reconnect.StateReconnecting, reconnect.StateReconnecting, reconnect.StateFailed,
+	}, events)
+	assert.Equal(t, reconnect.StateFailed, mq.State())
+
+	err = mq.Health()
+	assert.ErrorIs(t, err, ErrConnectionClosed)
+	assert.Contains(t, err.Error(), "failed")
+}
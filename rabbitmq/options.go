@@ -1,5 +1,7 @@
 package rabbitmq
 
+import "github.com/BevisDev/godev/utils/reconnect"
+
 type Option func(*options)
 
 const (
@@ -11,18 +13,24 @@ type options struct {
 	// autoCommit enables automatic message acknowledgment.
 	autoCommit bool
 
-	// reconnectMaxRetries sets max attempts for reconnect.
-	reconnectMaxRetries int
+	// reconnect is the reconnect policy of the connection.
+	reconnect reconnect.Policy
+
+	// stateListeners receive the connection state events, after reconnect.OnStateChange.
+	stateListeners []func(reconnect.Event)
 
 	producerOn bool
 	consumerOn bool
 }
 
 func withDefaults() *options {
+	policy := reconnect.DefaultPolicy()
+	policy.MaxAttempts = defaultReconnectMaxRetries
+
 	return &options{
-		reconnectMaxRetries: defaultReconnectMaxRetries,
-		producerOn:          true,
-		consumerOn:          true,
+		reconnect:  policy,
+		producerOn: true,
+		consumerOn: true,
 	}
 }
 
@@ -46,11 +54,29 @@ func WithAutoCommit() Option {
 	}
 }
 
-// WithReconnectMaxRetries sets max retry attempts when reconnecting.
+// WithReconnectMaxRetries sets max retry attempts when reconnecting (default 10).
 func WithReconnectMaxRetries(maxRetries int) Option {
 	return func(o *options) {
 		if maxRetries > 0 {
-			o.reconnectMaxRetries = maxRetries
+			o.reconnect.MaxAttempts = maxRetries
+		}
+	}
+}
+
+// WithReconnectPolicy sets the reconnect policy of the connection: backoff, jitter,
+// max attempts (0 retries until Close), OnReconnect and connection state events.
+func WithReconnectPolicy(p reconnect.Policy) Option {
+	return func(o *options) {
+		o.reconnect = p
+	}
+}
+
+// WithStateListener adds a receiver of the connection state events, in addition to
+// the OnStateChange of the reconnect policy.
+func WithStateListener(fn func(reconnect.Event)) Option {
+	return func(o *options) {
+		if fn != nil {
+			o.stateListeners = append(o.stateListeners, fn)
 		}
 	}
 }
@@ -2,6 +2,8 @@ package rabbitmq
 
 import (
 	"fmt"
+	"slices"
+	"sync"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 )
@@ -39,6 +41,8 @@ func (e ExchangeType) String() string {
 type Queue struct {
 	mq *MQ
 	Spec
+
+	mu sync.Mutex
 }
 
 // QueueSpec defines configuration for a queue
@@ -94,8 +98,12 @@ func (q *Queue) CreateQueues(names ...string) error {
 
 // Declare declares queues and exchanges according to spec
 // Execution order: 1) Queues, 2) Exchanges, 3) Bindings
+// Declared items are added to Spec, which MQ.Topology verifies.
 func (q *Queue) Declare(spec Spec) error {
-	q.Spec = spec
+	q.mu.Lock()
+	q.Spec = q.Spec.merge(spec)
+	q.mu.Unlock()
+
 	return q.mq.WithChannel(func(ch *amqp.Channel) error {
 		// 1. Declare queues first
 		if err := q.declareQueues(ch, spec.Queues); err != nil {
@@ -176,6 +184,50 @@ func (q *Queue) declareBindings(
 	return nil
 }
 
+// spec returns a copy of everything declared so far.
+func (q *Queue) spec() Spec {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return Spec{}.merge(q.Spec)
+}
+
+// merge returns s with the queues, exchanges and bindings of other added;
+// items already in s (same name, or same queue and routing key) are kept once.
+func (s Spec) merge(other Spec) Spec {
+	out := Spec{
+		Queues:    append([]QueueSpec(nil), s.Queues...),
+		Exchanges: make([]ExchangeSpec, 0, len(s.Exchanges)+len(other.Exchanges)),
+	}
+	for _, ex := range s.Exchanges {
+		ex.Bindings = append([]BindingSpec(nil), ex.Bindings...)
+		out.Exchanges = append(out.Exchanges, ex)
+	}
+
+	for _, qu := range other.Queues {
+		if !slices.ContainsFunc(out.Queues, func(x QueueSpec) bool { return x.Name == qu.Name }) {
+			out.Queues = append(out.Queues, qu)
+		}
+	}
+
+	for _, ex := range other.Exchanges {
+		i := slices.IndexFunc(out.Exchanges, func(x ExchangeSpec) bool { return x.Name == ex.Name })
+		if i < 0 {
+			ex.Bindings = append([]BindingSpec(nil), ex.Bindings...)
+			out.Exchanges = append(out.Exchanges, ex)
+			continue
+		}
+		for _, b := range ex.Bindings {
+			if !slices.ContainsFunc(out.Exchanges[i].Bindings, func(x BindingSpec) bool {
+				return x.Queue == b.Queue && x.RoutingKey == b.RoutingKey
+			}) {
+				out.Exchanges[i].Bindings = append(out.Exchanges[i].Bindings, b)
+			}
+		}
+	}
+
+	return out
+}
+
 // Delete deletes a queue
 func (q *Queue) Delete(name string, ifUnused, ifEmpty bool) error {
 	if name == "" {
@@ -69,3 +69,51 @@ func TestDeclare_FanoutExchange(t *testing.T) {
 	})
 	require.NoError(t, err)
 }
+
+func TestSpec_Merge(t *testing.T) {
+	s := Spec{}.merge(Spec{
+		Queues: []QueueSpec{{Name: "a"}},
+		Exchanges: []ExchangeSpec{{
+			Name:     "ex",
+			Type:     Topic,
+			Bindings: []BindingSpec{{Queue: "a", RoutingKey: "k1"}},
+		}},
+	})
+	s = s.merge(Spec{
+		Queues: []QueueSpec{{Name: "a"}, {Name: "b"}},
+		Exchanges: []ExchangeSpec{{
+			Name: "ex",
+			Type: Topic,
+			Bindings: []BindingSpec{
+				{Queue: "a", RoutingKey: "k1"},
+				{Queue: "b", RoutingKey: "k2"},
+			},
+		}},
+	})
+
+	require.Len(t, s.Queues, 2)
+	require.Len(t, s.Exchanges, 1)
+	require.Len(t, s.Exchanges[0].Bindings, 2)
+}
+
+func TestTopology(t *testing.T) {
+	mq := newTestMQ(t)
+
+	queue := "it.topology.queue"
+	exchange := "it.topology.exchange"
+	err := mq.Queue().Declare(Spec{
+		Queues: []QueueSpec{{Name: queue}},
+		Exchanges: []ExchangeSpec{{
+			Name:     exchange,
+			Type:     Direct,
+			Bindings: []BindingSpec{{Queue: queue, RoutingKey: "k"}},
+		}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, mq.Topology())
+
+	require.NoError(t, mq.Queue().Delete(queue, false, false))
+	err = mq.Topology()
+	require.ErrorIs(t, err, ErrTopologyMismatch)
+	require.Contains(t, err.Error(), queue)
+}
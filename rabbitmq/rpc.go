@@ -0,0 +1,164 @@
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/BevisDev/godev/consts"
+	"github.com/BevisDev/godev/utils"
+	"github.com/BevisDev/godev/utils/random"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+const (
+	// directReplyTo is the RabbitMQ pseudo-queue for replies without declaring a reply queue.
+	directReplyTo = "amq.rabbitmq.reply-to"
+
+	// HeaderRPCError carries the handler error of an RPC reply.
+	HeaderRPCError = "x-rpc-error"
+
+	defaultCallTimeout = 30 * time.Second
+)
+
+// RPCFunc handles an RPC request. The returned value is sent back as the reply body;
+// a returned error is sent back in the HeaderRPCError header.
+type RPCFunc func(ctx context.Context, msg *MsgHandler) (any, error)
+
+// Call sends payload to queueName and waits for the reply (request/reply pattern).
+//
+// Replies use RabbitMQ direct reply-to and are matched by a generated correlation ID;
+// the RID of ctx is sent in the X-Request-ID header. When ctx has no deadline,
+// the call times out after 30 seconds.
+//
+// Call returns ErrRPCNoRoute when no queue receives the request, ErrRPCTimeout when
+// no reply arrives in time, and an error wrapping ErrRPCFailed when the server handler failed.
+// The returned message is already acknowledged.
+func (p *Producer) Call(
+	ctx context.Context,
+	queueName string,
+	payload any,
+	props ...MsgProperties,
+) (*MsgHandler, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = utils.NewCtxTimeout(ctx, defaultCallTimeout)
+		defer cancel()
+	}
+
+	var reply *MsgHandler
+	err := p.mq.WithChannel(func(ch *amqp.Channel) error {
+		// must consume before publishing with direct reply-to
+		replies, err := ch.ConsumeWithContext(ctx, directReplyTo, "", true, false, false, false, nil)
+		if err != nil {
+			return fmt.Errorf("consume replies: %w", err)
+		}
+		returns := ch.NotifyReturn(make(chan amqp.Return, 1))
+
+		corrID := random.NewUUID()
+		props = append(props, WithCorrelationID(corrID), WithReplyTo(directReplyTo))
+		publishing, err := p.buildPublishing(ctx, payload, props...)
+		if err != nil {
+			return fmt.Errorf("build message: %w", err)
+		}
+		if publishing.Headers == nil {
+			publishing.Headers = amqp.Table{}
+		}
+		publishing.Headers[consts.XRequestID] = utils.GetRID(ctx)
+
+		if err := ch.PublishWithContext(ctx, "", queueName, true, false, publishing); err != nil {
+			return err
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("%w: %v", ErrRPCTimeout, ctx.Err())
+			case r := <-returns:
+				if r.CorrelationId == corrID {
+					return fmt.Errorf("%w: %s", ErrRPCNoRoute, queueName)
+				}
+			case d, ok := <-replies:
+				if !ok {
+					return ErrConnectionClosed
+				}
+				if d.CorrelationId != corrID {
+					continue
+				}
+				if e, ok := d.Headers[HeaderRPCError].(string); ok {
+					return fmt.Errorf("%w: %s", ErrRPCFailed, e)
+				}
+				reply = &MsgHandler{queueName: queueName, d: d}
+				return nil
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+// CallAs calls queueName like Producer.Call and decodes the reply body into T.
+func CallAs[T any](
+	ctx context.Context,
+	p *Producer,
+	queueName string,
+	payload any,
+	props ...MsgProperties,
+) (T, error) {
+	reply, err := p.Call(ctx, queueName, payload, props...)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return BodyAs[T](reply)
+}
+
+// rpcHandler is the server side of Call.
+type rpcHandler struct {
+	queueName string
+	fn        RPCFunc
+	producer  *Producer
+}
+
+// NewRPCHandler returns a Handler serving RPC requests on queueName: it calls fn and
+// publishes the result (or error) to the ReplyTo of the request with the same
+// correlation ID. Requests are always acknowledged, so a failing request is not redelivered.
+func NewRPCHandler(p *Producer, queueName string, fn RPCFunc) Handler {
+	return &rpcHandler{
+		queueName: queueName,
+		fn:        fn,
+		producer:  p,
+	}
+}
+
+func (h *rpcHandler) QueueName() string {
+	return h.queueName
+}
+
+func (h *rpcHandler) Handle(ctx context.Context, msg *MsgHandler) error {
+	result, err := h.fn(ctx, msg)
+
+	if msg.d.ReplyTo != "" {
+		props := []MsgProperties{WithCorrelationID(msg.CorrelationID())}
+		if err != nil {
+			result = ""
+			props = append(props, WithHeaders(map[string]any{HeaderRPCError: err.Error()}))
+		}
+		if result == nil {
+			result = ""
+		}
+		if pubErr := h.producer.publish(ctx, "", msg.d.ReplyTo, result, props...); pubErr != nil {
+			h.producer.log.Info("[%s] reply error: %v", h.queueName, pubErr)
+		}
+	}
+
+	if err != nil {
+		h.producer.log.Info("[%s] rpc error: %v", h.queueName, err)
+	}
+	if !h.producer.mq.autoCommit {
+		msg.Commit()
+	}
+	return nil
+}
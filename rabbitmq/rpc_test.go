@@ -0,0 +1,74 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/BevisDev/godev/consts"
+	"github.com/BevisDev/godev/utils"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sumReq struct {
+	A int `json:"a"`
+	B int `json:"b"`
+}
+
+type sumResp struct {
+	Sum int `json:"sum"`
+}
+
+func TestNewMsgCtx_RID(t *testing.T) {
+	m := &CM{}
+
+	msg := &MsgHandler{d: amqp.Delivery{
+		CorrelationId: "corr-1",
+		Headers:       amqp.Table{consts.XRequestID: "rid-1"},
+	}}
+	assert.Equal(t, "rid-1", utils.GetRID(m.newMsgCtx(msg)))
+
+	msg = &MsgHandler{d: amqp.Delivery{CorrelationId: "corr-1"}}
+	assert.Equal(t, "corr-1", utils.GetRID(m.newMsgCtx(msg)))
+}
+
+func TestCall(t *testing.T) {
+	mq := newTestMQ(t)
+	const queue = "godev-test-rpc-sum"
+	require.NoError(t, mq.Queue().CreateQueues(queue))
+	t.Cleanup(func() { _ = mq.Queue().Delete(queue, false, false) })
+
+	handler := NewRPCHandler(mq.Producer(), queue, func(ctx context.Context, msg *MsgHandler) (any, error) {
+		req, err := BodyAs[sumReq](msg)
+		if err != nil {
+			return nil, err
+		}
+		if req.A < 0 {
+			return nil, errors.New("negative input")
+		}
+		return sumResp{Sum: req.A + req.B}, nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = mq.Consumer().Consume(ctx, queue, &Consumer{Handler: handler})
+	}()
+
+	callCtx, callCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer callCancel()
+
+	resp, err := CallAs[sumResp](callCtx, mq.Producer(), queue, sumReq{A: 1, B: 2})
+	require.NoError(t, err)
+	assert.Equal(t, 3, resp.Sum)
+
+	_, err = CallAs[sumResp](callCtx, mq.Producer(), queue, sumReq{A: -1})
+	assert.ErrorIs(t, err, ErrRPCFailed)
+	assert.Contains(t, err.Error(), "negative input")
+
+	_, err = mq.Producer().Call(callCtx, "godev-test-rpc-missing", sumReq{})
+	assert.ErrorIs(t, err, ErrRPCNoRoute)
+}
@@ -7,8 +7,12 @@ import (
 	"github.com/BevisDev/godev/utils"
 	"github.com/BevisDev/godev/utils/str"
 	"github.com/BevisDev/godev/utils/validate"
+	"github.com/redis/go-redis/v9"
 )
 
+// defaultBatchSize is the number of keys read per MGET by GetByPrefix.
+const defaultBatchSize = 500
+
 // builder represents a builder for Redis operations with type safety.
 // It allows fluent API for building and executing Redis commands.
 type builder[T any] struct {
@@ -16,10 +20,16 @@ type builder[T any] struct {
 	key        string
 	keys       []string
 	channel    string
+	stream     bool
+	maxLen     int64
+	group      string
+	consumer   string
 	prefix     string
 	value      []byte
 	batches    map[string][]byte
 	expiration time.Duration
+	scanCount  int64
+	batchSize  int
 }
 
 // With creates a new builder for type T.
@@ -31,13 +41,16 @@ func With[T any](c *Cache) *builder[T] {
 
 // Key specifies a single key to operate on for the next execution command.
 func (c *builder[T]) Key(k string) *builder[T] {
-	c.key = k
+	c.key = c.cache.key(k)
 	return c
 }
 
 // Keys specifies multiple keys for bulk operations.
 func (c *builder[T]) Keys(keys ...string) *builder[T] {
-	c.keys = keys
+	c.keys = make([]string, len(keys))
+	for i, k := range keys {
+		c.keys[i] = c.cache.key(k)
+	}
 	return c
 }
 
@@ -58,7 +71,7 @@ func (c *builder[T]) Put(k string, v interface{}) *builder[T] {
 		c.batches = make(map[string][]byte)
 	}
 	if body, err := utils.ToBytes(v); err == nil {
-		c.batches[k] = body
+		c.batches[c.cache.key(k)] = body
 	}
 	return c
 }
@@ -70,7 +83,7 @@ func (c *builder[T]) Batch(b map[string]interface{}) *builder[T] {
 	}
 	for k, v := range b {
 		if body, err := utils.ToBytes(v); err == nil {
-			c.batches[k] = body
+			c.batches[c.cache.key(k)] = body
 		}
 	}
 	return c
@@ -88,12 +101,45 @@ func (c *builder[T]) Channel(channel string) *builder[T] {
 	return c
 }
 
-// Prefix sets a prefix to be automatically prepended to all subsequent keys in the builder.
+// Stream makes Publish append to a Redis Stream named after the channel (XADD)
+// instead of publishing, so messages survive until consumed by a Group.
+// maxLen caps the stream approximately; 0 keeps every message.
+func (c *builder[T]) Stream(maxLen int64) *builder[T] {
+	c.stream = true
+	c.maxLen = maxLen
+	return c
+}
+
+// Group makes Subscribe read the stream of the channel as consumer of group
+// (created if missing): messages are acknowledged after the handler succeeds,
+// and messages left pending by a crashed consumer are claimed.
+func (c *builder[T]) Group(group, consumer string) *builder[T] {
+	c.stream = true
+	c.group = group
+	c.consumer = consumer
+	return c
+}
+
+// Prefix sets the key prefix used by GetByPrefix, DeleteByPrefix and CountByPrefix
+// (within the namespace of the cache).
 func (c *builder[T]) Prefix(prefix string) *builder[T] {
 	c.prefix = prefix
 	return c
 }
 
+// ScanCount sets the COUNT hint of the SCAN used by the prefix operations
+// (0 lets Redis decide, usually 10).
+func (c *builder[T]) ScanCount(n int64) *builder[T] {
+	c.scanCount = n
+	return c
+}
+
+// BatchSize sets the number of keys read per MGET by GetByPrefix (default 500).
+func (c *builder[T]) BatchSize(n int) *builder[T] {
+	c.batchSize = n
+	return c
+}
+
 // Set sets a Redis key to the given value with an optional expiration time.
 // Returns an error if the key or value is missing, or if the operation fails.
 func (c *builder[T]) Set(ct context.Context) error {
@@ -135,6 +181,9 @@ func (c *builder[T]) SetMany(ct context.Context) error {
 	if validate.IsNilOrEmpty(c.batches) {
 		return ErrMissingPushOrBatch
 	}
+	if _, ok := c.batches[""]; ok {
+		return ErrMissingKey
+	}
 
 	rdb := c.cache.GetClient()
 	ctx, cancel := utils.NewCtxTimeout(ct, c.cache.cf.Timeout)
@@ -198,6 +247,9 @@ func (c *builder[T]) GetMany(ct context.Context) ([]T, error) {
 	return result, nil
 }
 
+// GetByPrefix returns the values of every key starting with the prefix.
+// Keys are found with SCAN (see ScanCount) and read with pipelined MGET batches
+// (see BatchSize); keys deleted in between are skipped.
 func (c *builder[T]) GetByPrefix(ct context.Context) ([]T, error) {
 	if str.IsEmpty(c.prefix) {
 		return nil, ErrMissingPrefix
@@ -207,110 +259,148 @@ func (c *builder[T]) GetByPrefix(ct context.Context) ([]T, error) {
 	ctx, cancel := utils.NewCtxTimeout(ct, c.cache.cf.Timeout)
 	defer cancel()
 
-	var (
-		cursor uint64
-		result []T
-	)
-	for {
-		keys, nextCursor, err := rdb.Scan(ctx, cursor, c.prefix+"*", 0).Result()
-		if err != nil {
-			return nil, err
+	var result []T
+	err := c.scanPrefix(ctx, func(keys []string) error {
+		pipe := rdb.Pipeline()
+		cmds := make([]*redis.SliceCmd, 0, len(keys)/c.mgetBatch()+1)
+		for _, batch := range chunk(keys, c.mgetBatch()) {
+			cmds = append(cmds, pipe.MGet(ctx, batch...))
 		}
-
-		for _, key := range keys {
-			clone := c
-			clone.key = key
-
-			val, err := clone.Get(ctx)
-			if err != nil {
-				return nil, err
-			}
-			result = append(result, val)
+		if _, err := pipe.Exec(ctx); err != nil {
+			return err
 		}
 
-		if nextCursor == 0 {
-			break
+		for _, cmd := range cmds {
+			for _, v := range cmd.Val() {
+				if v == nil {
+					continue
+				}
+				t, err := utils.ValueFromAny[T](v)
+				if err != nil {
+					return err
+				}
+				result = append(result, t)
+			}
 		}
-		cursor = nextCursor
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 	return result, nil
 }
 
-func (c *builder[T]) Delete(ct context.Context) error {
-	if str.IsEmpty(c.key) {
-		return ErrMissingKey
+// DeleteByPrefix deletes every key starting with the prefix and returns how many were deleted.
+func (c *builder[T]) DeleteByPrefix(ct context.Context) (int64, error) {
+	if str.IsEmpty(c.prefix) {
+		return 0, ErrMissingPrefix
 	}
 
 	rdb := c.cache.GetClient()
 	ctx, cancel := utils.NewCtxTimeout(ct, c.cache.cf.Timeout)
 	defer cancel()
 
-	return rdb.Del(ctx, c.key).Err()
+	var deleted int64
+	err := c.scanPrefix(ctx, func(keys []string) error {
+		n, err := rdb.Del(ctx, keys...).Result()
+		deleted += n
+		return err
+	})
+	return deleted, err
 }
 
-func (c *builder[T]) Exists(ct context.Context) (bool, error) {
-	if str.IsEmpty(c.key) {
-		return false, ErrMissingKey
+// CountByPrefix returns the number of keys starting with the prefix.
+func (c *builder[T]) CountByPrefix(ct context.Context) (int64, error) {
+	if str.IsEmpty(c.prefix) {
+		return 0, ErrMissingPrefix
 	}
 
-	rdb := c.cache.GetClient()
 	ctx, cancel := utils.NewCtxTimeout(ct, c.cache.cf.Timeout)
 	defer cancel()
 
-	count, err := rdb.Exists(ctx, c.key).Result()
-	if err != nil {
-		return false, err
+	var count int64
+	err := c.scanPrefix(ctx, func(keys []string) error {
+		count += int64(len(keys))
+		return nil
+	})
+	return count, err
+}
+
+// scanPrefix calls fn with each SCAN page of keys starting with the prefix.
+// Keys SCAN returns more than once are passed only once.
+func (c *builder[T]) scanPrefix(ctx context.Context, fn func(keys []string) error) error {
+	rdb := c.cache.GetClient()
+	pattern := EscapePattern(c.cache.Key(c.prefix)) + "*"
+	seen := make(map[string]struct{})
+
+	var cursor uint64
+	for {
+		keys, next, err := rdb.Scan(ctx, cursor, pattern, c.scanCount).Result()
+		if err != nil {
+			return err
+		}
+
+		page := keys[:0]
+		for _, k := range keys {
+			if _, ok := seen[k]; !ok {
+				seen[k] = struct{}{}
+				page = append(page, k)
+			}
+		}
+		if len(page) > 0 {
+			if err := fn(page); err != nil {
+				return err
+			}
+		}
+
+		if next == 0 {
+			return nil
+		}
+		cursor = next
 	}
+}
 
-	return count > 0, nil
+func (c *builder[T]) mgetBatch() int {
+	if c.batchSize <= 0 {
+		return defaultBatchSize
+	}
+	return c.batchSize
 }
 
-func (c *builder[T]) Publish(ct context.Context) error {
-	if str.IsEmpty(c.channel) {
-		return ErrMissingChannel
+func chunk(keys []string, size int) [][]string {
+	var out [][]string
+	for size < len(keys) {
+		out = append(out, keys[:size])
+		keys = keys[size:]
 	}
-	if c.value == nil {
-		return ErrMissingValue
+	return append(out, keys)
+}
+
+func (c *builder[T]) Delete(ct context.Context) error {
+	if str.IsEmpty(c.key) {
+		return ErrMissingKey
 	}
 
 	rdb := c.cache.GetClient()
 	ctx, cancel := utils.NewCtxTimeout(ct, c.cache.cf.Timeout)
 	defer cancel()
 
-	return rdb.Publish(ctx, c.channel, c.value).Err()
+	return rdb.Del(ctx, c.key).Err()
 }
 
-// Subscribe listens for messages on a given Redis channel and invokes the handler function for each message.
-// The handler receives the raw message payload as a string.
-// The subscription runs in a background goroutine until the context is canceled.
-// Returns an error if the channel is missing, or if the subscription fails.
-func (c *builder[T]) Subscribe(ctx context.Context, handler func(msg string)) error {
-	if str.IsEmpty(c.channel) {
-		return ErrMissingChannel
+func (c *builder[T]) Exists(ct context.Context) (bool, error) {
+	if str.IsEmpty(c.key) {
+		return false, ErrMissingKey
 	}
 
 	rdb := c.cache.GetClient()
-	pubsub := rdb.Subscribe(ctx, c.channel)
-	_, err := pubsub.Receive(ctx)
+	ctx, cancel := utils.NewCtxTimeout(ct, c.cache.cf.Timeout)
+	defer cancel()
+
+	count, err := rdb.Exists(ctx, c.key).Result()
 	if err != nil {
-		return err
+		return false, err
 	}
 
-	ch := pubsub.Channel()
-	go func() {
-		defer pubsub.Close()
-		for {
-			select {
-			case msg := <-ch:
-				if msg == nil {
-					continue
-				}
-
-				handler(msg.Payload)
-			case <-ctx.Done():
-				return
-			}
-		}
-	}()
-	return nil
+	return count > 0, nil
 }
@@ -103,8 +103,7 @@ func TestRedisCache_GetByPrefix(t *testing.T) {
 	ctx := context.Background()
 
 	mock.ExpectScan(0, "prefix*", int64(0)).SetVal([]string{"prefix1", "prefix2"}, 0)
-	mock.ExpectGet("prefix1").SetVal("value1")
-	mock.ExpectGet("prefix2").SetVal("value2")
+	mock.ExpectMGet("prefix1", "prefix2").SetVal([]interface{}{"value1", "value2"})
 
 	vals, err := With[string](cache).
 		Prefix("prefix").
@@ -115,6 +114,56 @@ func TestRedisCache_GetByPrefix(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestRedisCache_GetByPrefix_Batches(t *testing.T) {
+	rdb, mock := redismock.NewClientMock()
+	cache := &Cache{client: rdb, cf: &Config{Timeout: 5 * time.Second}}
+	ctx := context.Background()
+
+	mock.ExpectScan(0, "user:*", int64(100)).SetVal([]string{"user:1", "user:2", "user:3"}, 7)
+	mock.ExpectMGet("user:1", "user:2").SetVal([]interface{}{"a", nil})
+	mock.ExpectMGet("user:3").SetVal([]interface{}{"c"})
+	mock.ExpectScan(7, "user:*", int64(100)).SetVal([]string{"user:3", "user:4"}, 0)
+	mock.ExpectMGet("user:4").SetVal([]interface{}{"d"})
+
+	vals, err := With[string](cache).Prefix("user:").ScanCount(100).BatchSize(2).GetByPrefix(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "c", "d"}, vals, "deleted keys and duplicates are skipped")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRedisCache_DeleteAndCountByPrefix(t *testing.T) {
+	rdb, mock := redismock.NewClientMock()
+	cache := &Cache{client: rdb, cf: &Config{Timeout: 5 * time.Second}}
+	ctx := context.Background()
+
+	mock.ExpectScan(0, "session:*", int64(0)).SetVal([]string{"session:1", "session:2"}, 0)
+	n, err := With[string](cache).Prefix("session:").CountByPrefix(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+
+	mock.ExpectScan(0, "session:*", int64(0)).SetVal([]string{"session:1", "session:2"}, 0)
+	mock.ExpectDel("session:1", "session:2").SetVal(2)
+	n, err = With[string](cache).Prefix("session:").DeleteByPrefix(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+
+	_, err = With[string](cache).DeleteByPrefix(ctx)
+	assert.ErrorIs(t, err, ErrMissingPrefix)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRedisCache_CountByPrefix_EscapesGlob(t *testing.T) {
+	rdb, mock := redismock.NewClientMock()
+	cache := &Cache{client: rdb, cf: &Config{Timeout: 5 * time.Second}}
+	ctx := context.Background()
+
+	mock.ExpectScan(0, `user\[1\]\*:*`, int64(0)).SetVal([]string{"user[1]*:a"}, 0)
+	n, err := With[string](cache).Prefix("user[1]*:").CountByPrefix(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestRedisCache_IsNil(t *testing.T) {
 	rdb, _ := redismock.NewClientMock()
 	cache := &Cache{
@@ -406,3 +455,40 @@ func TestRedisCache_Delete_MissingKey(t *testing.T) {
 	err := With[string](cache).Delete(ctx)
 	assert.ErrorIs(t, err, ErrMissingKey)
 }
+
+func TestRedisCache_Namespace(t *testing.T) {
+	rdb, mock := redismock.NewClientMock()
+	cache := &Cache{
+		client: rdb,
+		cf:     (&Config{Timeout: 5 * time.Second, Namespace: "orders"}).clone(),
+	}
+	ctx := context.Background()
+
+	assert.Equal(t, "orders:key", cache.Key("key"))
+	assert.Equal(t, "key", cache.StripKey("orders:key"))
+
+	mock.ExpectSet("orders:key", []byte("value"), 0).SetVal("OK")
+	require.NoError(t, With[string](cache).Key("key").Value("value").Set(ctx))
+
+	mock.ExpectMGet("orders:a", "orders:b").SetVal([]interface{}{"1", "2"})
+	vals, err := With[string](cache).Keys("a", "b").GetMany(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "2"}, vals)
+
+	mock.ExpectRPush("orders:list", []byte("x")).SetVal(1)
+	require.NoError(t, WithList[string](cache).Key("list").Values([]string{"x"}).Add(ctx))
+
+	// an empty key does not fall back to the bare namespace
+	assert.ErrorIs(t, With[string](cache).Key("").Value("value").Set(ctx), ErrMissingKey)
+	assert.ErrorIs(t, With[string](cache).Key("").Delete(ctx), ErrMissingKey)
+	assert.ErrorIs(t, WithList[string](cache).Key("").Values([]string{"x"}).Add(ctx), ErrMissingKey)
+	assert.ErrorIs(t, WithSet[string](cache).Key("").Values([]string{"x"}).Add(ctx), ErrMissingKey)
+	assert.ErrorIs(t, With[string](cache).Put("", "value").SetMany(ctx), ErrMissingKey)
+
+	mock.ExpectScan(0, "orders:user:*", int64(0)).SetVal([]string{"orders:user:1", "orders:user:2"}, 0)
+	keys, err := cache.ScanKeys(ctx, "user:*")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user:1", "user:2"}, keys)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
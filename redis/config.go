@@ -2,7 +2,10 @@ package redis
 
 import (
 	"fmt"
+	"strings"
 	"time"
+
+	"github.com/BevisDev/godev/utils/reconnect"
 )
 
 const (
@@ -20,7 +23,15 @@ type Config struct {
 	Password string        // Password for authentication (if required)
 	DB       int           // Redis database index (0 by default)
 	PoolSize int           // Maximum number of connections in the pool
-	Timeout  time.Duration // timeout for Redis operations in seconds
+	Timeout  time.Duration // timeout for Redis operations, e.g. "5s" in config files
+
+	// Namespace is prepended to every key as "<Namespace>:" so several services
+	// can share one Redis. Empty means no namespace.
+	Namespace string
+
+	// Reconnect sets the retry backoff of commands (InitialDelay to MaxDelay, MaxAttempts
+	// retries) and receives the connection state events. Nil keeps the go-redis defaults.
+	Reconnect *reconnect.Policy
 }
 
 // clone applies default values to the configuration if they are not set.
@@ -32,6 +43,9 @@ func (c *Config) clone() *Config {
 	if cc.PoolSize <= 0 {
 		cc.PoolSize = defaultPoolSize
 	}
+	if cc.Namespace != "" && !strings.HasSuffix(cc.Namespace, ":") {
+		cc.Namespace += ":"
+	}
 	return &cc
 }
 
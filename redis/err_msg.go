@@ -21,6 +21,9 @@ var (
 	// ErrMissingChannel is returned when a channel is required but not provided.
 	ErrMissingChannel = errors.New("use Channel() before")
 
+	// ErrMissingGroup is returned when subscribing to a stream without a consumer group.
+	ErrMissingGroup = errors.New("use Group() before")
+
 	// ErrMissingPushOrBatch is returned when batch data is required but not provided.
 	ErrMissingPushOrBatch = errors.New("use Push() or Batch() before")
 )
@@ -30,7 +30,7 @@ func WithList[T any](c *Cache) *listBuilder[T] {
 
 // Key specifies a single key to operate on for the next execution command.
 func (c *listBuilder[T]) Key(k string) *listBuilder[T] {
-	c.key = k
+	c.key = c.cache.key(k)
 	return c
 }
 
@@ -0,0 +1,102 @@
+package redis
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	"github.com/BevisDev/godev/utils/reconnect"
+	"github.com/redis/go-redis/v9"
+)
+
+// stateHook follows the connection state from the dials of the client: go-redis
+// reconnects on demand, so a failed dial means Redis is unreachable and the next
+// successful one that it is back.
+type stateHook struct {
+	policy reconnect.Policy
+
+	mu      sync.Mutex
+	state   reconnect.State
+	attempt int // failed dials since the connection was lost
+}
+
+func newStateHook(p *reconnect.Policy) *stateHook {
+	h := &stateHook{}
+	if p != nil {
+		h.policy = *p
+	}
+	return h
+}
+
+func (h *stateHook) State() reconnect.State {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.state
+}
+
+func (h *stateHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := next(ctx, network, addr)
+		if err != nil {
+			h.failed(err)
+		} else {
+			h.connected()
+		}
+		return conn, err
+	}
+}
+
+func (h *stateHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return next
+}
+
+func (h *stateHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return next
+}
+
+func (h *stateHook) failed(err error) {
+	h.mu.Lock()
+	state := reconnect.StateReconnecting
+	switch {
+	case h.state == reconnect.StateConnected || h.state == "":
+		state = reconnect.StateDisconnected
+		h.attempt = 0
+	case h.state == reconnect.StateFailed:
+		h.mu.Unlock()
+		return
+	default:
+		h.attempt++
+		if h.policy.MaxAttempts > 0 && h.attempt >= h.policy.MaxAttempts {
+			state = reconnect.StateFailed
+		}
+	}
+	h.state = state
+	attempt := h.attempt
+	h.mu.Unlock()
+
+	h.policy.Notify("redis", state, attempt, err)
+}
+
+func (h *stateHook) connected() {
+	h.mu.Lock()
+	prev, attempt := h.state, h.attempt
+	if prev == reconnect.StateConnected {
+		h.mu.Unlock()
+		return
+	}
+	h.state, h.attempt = reconnect.StateConnected, 0
+	h.mu.Unlock()
+
+	h.policy.Notify("redis", reconnect.StateConnected, attempt, nil)
+	if prev != "" && h.policy.OnReconnect != nil {
+		h.policy.OnReconnect(attempt)
+	}
+}
+
+// State returns the connection state, updated on each dial of the client.
+func (r *Cache) State() reconnect.State {
+	if r.state == nil {
+		return ""
+	}
+	return r.state.State()
+}
@@ -0,0 +1,75 @@
+package redis
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/BevisDev/godev/utils/reconnect"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateHook(t *testing.T) {
+	var (
+		events     []reconnect.State
+		reconnects []int
+	)
+	h := newStateHook(&reconnect.Policy{
+		MaxAttempts:   3,
+		OnStateChange: func(e reconnect.Event) { events = append(events, e.State) },
+		OnReconnect:   func(attempt int) { reconnects = append(reconnects, attempt) },
+	})
+
+	h.connected()
+	assert.Equal(t, reconnect.StateConnected, h.State())
+	assert.Empty(t, reconnects, "first connection is not a reconnect")
+
+	dialErr := errors.New("connection refused")
+	h.failed(dialErr)
+	assert.Equal(t, reconnect.StateDisconnected, h.State())
+	h.failed(dialErr)
+	h.connected()
+	h.connected()
+
+	assert.Equal(t, []reconnect.State{
+		reconnect.StateConnected,
+		reconnect.StateDisconnected,
+		reconnect.StateReconnecting,
+		reconnect.StateConnected,
+	}, events)
+	assert.Equal(t, []int{1}, reconnects)
+}
+
+func TestStateHook_Failed(t *testing.T) {
+	var events []reconnect.State
+	h := newStateHook(&reconnect.Policy{
+		MaxAttempts:   2,
+		OnStateChange: func(e reconnect.Event) { events = append(events, e.State) },
+	})
+
+	dialErr := errors.New("connection refused")
+	for i := 0; i < 5; i++ {
+		h.failed(dialErr)
+	}
+	assert.Equal(t, reconnect.StateFailed, h.State())
+	assert.Equal(t, []reconnect.State{
+		reconnect.StateDisconnected,
+		reconnect.StateReconnecting,
+		reconnect.StateFailed,
+	}, events)
+
+	h.connected()
+	assert.Equal(t, reconnect.StateConnected, h.State())
+}
+
+func TestCache_State_Unreachable(t *testing.T) {
+	var last reconnect.Event
+	_, err := New(&Config{
+		Host:      "127.0.0.1",
+		Port:      1, // nothing listens on port 1
+		Reconnect: &reconnect.Policy{MaxAttempts: 1, OnStateChange: func(e reconnect.Event) { last = e }},
+	})
+	assert.Error(t, err)
+	assert.NotEqual(t, reconnect.StateConnected, last.State)
+	assert.Equal(t, "redis", last.Name)
+	assert.Error(t, last.Err)
+}
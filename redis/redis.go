@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -17,6 +18,7 @@ import (
 type Cache struct {
 	cf     *Config
 	client *redis.Client
+	state  *stateHook
 }
 
 // New initializes a Redis connection using the provided configuration.
@@ -49,14 +51,34 @@ func New(cfg *Config) (*Cache, error) {
 	return c, nil
 }
 
+// FromClient wraps an existing client, e.g. one shared with other code or a mock in tests.
+// The connection settings and PoolSize of cfg are not applied.
+func FromClient(client *redis.Client, cfg *Config) (*Cache, error) {
+	if cfg == nil {
+		return nil, errors.New("[redis] config is nil")
+	}
+	return &Cache{cf: cfg.clone(), client: client}, nil
+}
+
 // connect creates a new Redis client with the configured options.
 func (r *Cache) connect() (*redis.Client, error) {
-	rdb := redis.NewClient(&redis.Options{
+	opts := &redis.Options{
 		Addr:     r.cf.Addr(),
 		Password: r.cf.Password,
 		DB:       r.cf.DB,
 		PoolSize: r.cf.PoolSize,
-	})
+	}
+	if p := r.cf.Reconnect; p != nil {
+		opts.MinRetryBackoff = p.InitialDelay
+		opts.MaxRetryBackoff = p.MaxDelay
+		if p.MaxAttempts > 0 {
+			opts.MaxRetries = p.MaxAttempts
+		}
+	}
+
+	rdb := redis.NewClient(opts)
+	r.state = newStateHook(r.cf.Reconnect)
+	rdb.AddHook(r.state)
 
 	return rdb, nil
 }
@@ -94,3 +116,62 @@ func (r *Cache) IsNil(err error) bool {
 func (r *Cache) SetTimeout(d time.Duration) {
 	r.cf.Timeout = d
 }
+
+// Key returns k with the namespace of the config prepended ("<Namespace>:k").
+// Builders apply it to every key; use it for keys passed to GetClient directly.
+func (r *Cache) Key(k string) string {
+	if r == nil || r.cf == nil || r.cf.Namespace == "" {
+		return k
+	}
+	return r.cf.Namespace + k
+}
+
+// key is Key for the key of a builder: an empty key stays empty, so commands
+// fail with ErrMissingKey instead of acting on the bare namespace.
+func (r *Cache) key(k string) string {
+	if k == "" {
+		return ""
+	}
+	return r.Key(k)
+}
+
+// StripKey removes the namespace from a key returned by Redis, e.g. by SCAN.
+func (r *Cache) StripKey(k string) string {
+	if r == nil || r.cf == nil {
+		return k
+	}
+	return strings.TrimPrefix(k, r.cf.Namespace)
+}
+
+var globEscaper = strings.NewReplacer(`\`, `\\`, `*`, `\*`, `?`, `\?`, `[`, `\[`, `]`, `\]`)
+
+// EscapePattern escapes the glob characters of s, so it matches literally in a
+// SCAN or KEYS pattern, e.g. EscapePattern(c.Key(prefix)) + "*".
+func EscapePattern(s string) string {
+	return globEscaper.Replace(s)
+}
+
+// ScanKeys returns the keys matching the SCAN pattern within the namespace,
+// with the namespace removed.
+func (r *Cache) ScanKeys(ct context.Context, pattern string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ct, r.cf.Timeout)
+	defer cancel()
+
+	var (
+		cursor uint64
+		result []string
+	)
+	for {
+		keys, next, err := r.client.Scan(ctx, cursor, r.Key(pattern), 0).Result()
+		if err != nil {
+			return nil, err
+		}
+		for _, k := range keys {
+			result = append(result, r.StripKey(k))
+		}
+		if next == 0 {
+			return result, nil
+		}
+		cursor = next
+	}
+}
@@ -0,0 +1,174 @@
+// Package redistest provides a redis.Cache for tests, backed by an in-memory miniredis
+// or by redismock, with helpers to assert keys, values and TTLs.
+//
+//	func TestSession(t *testing.T) {
+//		c := redistest.New(t)
+//		err := redis.With[string](c.Cache).Key("session:1").Value("alice").Expire(time.Minute).Set(ctx)
+//		...
+//		c.AssertValue("session:1", "alice")
+//		c.AssertTTL("session:1", time.Minute)
+//	}
+package redistest
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/BevisDev/godev/redis"
+	"github.com/BevisDev/godev/utils"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redismock/v9"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCache is a redis.Cache for one test, closed when the test ends.
+type TestCache struct {
+	*redis.Cache
+
+	// Server is the miniredis behind the cache; nil with NewMock.
+	Server *miniredis.Miniredis
+
+	// Mock is set with NewMock; its expectations are checked on cleanup.
+	Mock redismock.ClientMock
+
+	t testing.TB
+}
+
+// Option configures New and NewMock.
+type Option func(*redis.Config)
+
+// WithNamespace prefixes every key of the cache, as Config.Namespace.
+// Assertion helpers take keys without the namespace.
+func WithNamespace(ns string) Option {
+	return func(cfg *redis.Config) {
+		cfg.Namespace = ns
+	}
+}
+
+// WithTimeout sets the timeout of the cache operations.
+func WithTimeout(d time.Duration) Option {
+	return func(cfg *redis.Config) {
+		cfg.Timeout = d
+	}
+}
+
+func config(opts []Option) *redis.Config {
+	cfg := &redis.Config{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// New returns a cache backed by a miniredis started for the test. Unlike Redis,
+// keys only expire with FastForward.
+func New(t testing.TB, opts ...Option) *TestCache {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	cache, err := redis.FromClient(goredis.NewClient(&goredis.Options{Addr: server.Addr()}), config(opts))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(cache.Close)
+
+	return &TestCache{Cache: cache, Server: server, t: t}
+}
+
+// NewMock returns a cache backed by redismock, for errors miniredis cannot produce.
+// The assertion helpers need New.
+func NewMock(t testing.TB, opts ...Option) *TestCache {
+	t.Helper()
+
+	client, mock := redismock.NewClientMock()
+	cache, err := redis.FromClient(client, config(opts))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("redistest: %v", err)
+		}
+		cache.Close()
+	})
+
+	return &TestCache{Cache: cache, Mock: mock, t: t}
+}
+
+func (c *TestCache) server() *miniredis.Miniredis {
+	c.t.Helper()
+	if c.Server == nil {
+		c.t.Fatal("redistest: assertions need a miniredis cache, use New")
+	}
+	return c.Server
+}
+
+// Keys returns the keys of the cache, sorted and without the namespace.
+func (c *TestCache) Keys() []string {
+	c.t.Helper()
+
+	keys := c.server().Keys()
+	for i, k := range keys {
+		keys[i] = c.StripKey(k)
+	}
+	return keys
+}
+
+// FastForward moves the clock of miniredis, expiring keys whose TTL has elapsed.
+func (c *TestCache) FastForward(d time.Duration) {
+	c.t.Helper()
+	c.server().FastForward(d)
+}
+
+// Flush removes every key.
+func (c *TestCache) Flush() {
+	c.t.Helper()
+	c.server().FlushAll()
+}
+
+// AssertExists asserts that key exists.
+func (c *TestCache) AssertExists(key string) bool {
+	c.t.Helper()
+	return assert.True(c.t, c.server().Exists(c.Key(key)), "redistest: key %q does not exist", key)
+}
+
+// AssertMissing asserts that key does not exist.
+func (c *TestCache) AssertMissing(key string) bool {
+	c.t.Helper()
+	return assert.False(c.t, c.server().Exists(c.Key(key)), "redistest: key %q exists", key)
+}
+
+// AssertValue asserts that the string key holds want, serialized as the builder
+// does (strings and numbers as is, other values as JSON).
+func (c *TestCache) AssertValue(key string, want any) bool {
+	c.t.Helper()
+
+	got, err := c.server().Get(c.Key(key))
+	if !assert.NoError(c.t, err, "redistest: key %q", key) {
+		return false
+	}
+	body, err := utils.ToBytes(want)
+	if !assert.NoError(c.t, err) {
+		return false
+	}
+	switch want.(type) {
+	case string, []byte:
+	default:
+		// structs, maps and slices are stored as JSON; compare them regardless of key order
+		if json.Valid(body) {
+			return assert.JSONEq(c.t, string(body), got, "redistest: key %q", key)
+		}
+	}
+	return assert.Equal(c.t, string(body), got, "redistest: key %q", key)
+}
+
+// AssertTTL asserts that the TTL of key is want; 0 asserts that key does not expire.
+func (c *TestCache) AssertTTL(key string, want time.Duration) bool {
+	c.t.Helper()
+	if !c.AssertExists(key) {
+		return false
+	}
+	return assert.Equal(c.t, want, c.server().TTL(c.Key(key)), "redistest: TTL of %q", key)
+}
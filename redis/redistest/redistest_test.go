@@ -0,0 +1,70 @@
+package redistest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/BevisDev/godev/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type user struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestNew_Builder(t *testing.T) {
+	c := New(t)
+	ctx := context.Background()
+
+	require.NoError(t, redis.With[string](c.Cache).Key("greeting").Value("hello").Set(ctx))
+	require.NoError(t, redis.With[user](c.Cache).Key("user:1").Value(user{ID: 1, Name: "Alice"}).
+		Expire(time.Minute).Set(ctx))
+
+	got, err := redis.With[user](c.Cache).Key("user:1").Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, user{ID: 1, Name: "Alice"}, got)
+
+	c.AssertValue("greeting", "hello")
+	c.AssertValue("user:1", map[string]any{"name": "Alice", "id": 1})
+	c.AssertTTL("greeting", 0)
+	c.AssertTTL("user:1", time.Minute)
+	assert.Equal(t, []string{"greeting", "user:1"}, c.Keys())
+}
+
+func TestNew_FastForward(t *testing.T) {
+	c := New(t)
+	ctx := context.Background()
+
+	require.NoError(t, redis.With[int](c.Cache).Key("counter").Value(1).Expire(time.Second).Set(ctx))
+	c.AssertValue("counter", 1)
+
+	c.FastForward(2 * time.Second)
+	c.AssertMissing("counter")
+}
+
+func TestNew_Namespace(t *testing.T) {
+	c := New(t, WithNamespace("orders"))
+	ctx := context.Background()
+
+	require.NoError(t, redis.With[string](c.Cache).Key("1").Value("paid").Set(ctx))
+
+	assert.True(t, c.Server.Exists("orders:1"))
+	c.AssertExists("1")
+	c.AssertValue("1", "paid")
+	assert.Equal(t, []string{"1"}, c.Keys())
+
+	c.Flush()
+	assert.Empty(t, c.Keys())
+}
+
+func TestNewMock(t *testing.T) {
+	c := NewMock(t)
+	c.Mock.ExpectGet("key").SetErr(errors.New("connection refused"))
+
+	_, err := redis.With[string](c.Cache).Key("key").Get(context.Background())
+	assert.EqualError(t, err, "connection refused")
+}
@@ -27,7 +27,7 @@ func WithSet[T any](c *Cache) *setBuilder[T] {
 
 // Key specifies a single key to operate on for the next execution command.
 func (c *setBuilder[T]) Key(k string) *setBuilder[T] {
-	c.key = k
+	c.key = c.cache.key(k)
 	return c
 }
 
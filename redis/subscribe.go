@@ -0,0 +1,328 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/BevisDev/godev/utils"
+	"github.com/BevisDev/godev/utils/str"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// payloadField is the stream entry field holding the message.
+	payloadField = "payload"
+
+	defaultClaimIdle = time.Minute
+	readBlock        = 5 * time.Second
+	errorBackoff     = time.Second
+)
+
+// MessageHandler handles one message; a returned error or a panic fails the attempt.
+type MessageHandler func(ctx context.Context, msg string) error
+
+type SubscribeOption func(*subscribeOptions)
+
+type subscribeOptions struct {
+	workers   int
+	attempts  int
+	backoff   time.Duration
+	claimIdle time.Duration
+	onError   func(msg string, err error)
+}
+
+func defaultSubscribeOptions() *subscribeOptions {
+	return &subscribeOptions{
+		workers:   1,
+		attempts:  1,
+		claimIdle: defaultClaimIdle,
+		onError: func(msg string, err error) {
+			log.Printf("[redis] message dropped: %v", err)
+		},
+	}
+}
+
+// WithWorkers handles up to n messages concurrently (default 1).
+func WithWorkers(n int) SubscribeOption {
+	return func(o *subscribeOptions) {
+		if n > 0 {
+			o.workers = n
+		}
+	}
+}
+
+// WithRetry tries each message up to attempts times, waiting backoff between attempts.
+func WithRetry(attempts int, backoff time.Duration) SubscribeOption {
+	return func(o *subscribeOptions) {
+		if attempts > 0 {
+			o.attempts = attempts
+		}
+		o.backoff = backoff
+	}
+}
+
+// WithClaimIdle sets how long a message stays pending on another consumer of the
+// group before it is claimed (default 1 minute).
+func WithClaimIdle(d time.Duration) SubscribeOption {
+	return func(o *subscribeOptions) {
+		if d > 0 {
+			o.claimIdle = d
+		}
+	}
+}
+
+// WithOnError is called with messages that failed every attempt, e.g. to store
+// them in a dead-letter list. By default they are logged.
+func WithOnError(fn func(msg string, err error)) SubscribeOption {
+	return func(o *subscribeOptions) {
+		if fn != nil {
+			o.onError = fn
+		}
+	}
+}
+
+// Publish publishes the value to the channel, or appends it to the stream of
+// the channel when Stream or Group was set.
+func (c *builder[T]) Publish(ct context.Context) error {
+	if str.IsEmpty(c.channel) {
+		return ErrMissingChannel
+	}
+	if c.value == nil {
+		return ErrMissingValue
+	}
+
+	rdb := c.cache.GetClient()
+	ctx, cancel := utils.NewCtxTimeout(ct, c.cache.cf.Timeout)
+	defer cancel()
+
+	if c.stream {
+		return rdb.XAdd(ctx, &redis.XAddArgs{
+			Stream: c.cache.Key(c.channel),
+			MaxLen: c.maxLen,
+			Approx: c.maxLen > 0,
+			Values: map[string]interface{}{payloadField: c.value},
+		}).Err()
+	}
+	return rdb.Publish(ctx, c.channel, c.value).Err()
+}
+
+// Subscribe listens for messages on a given Redis channel and invokes the handler function for each message.
+// The handler receives the raw message payload as a string.
+// The subscription runs in a background goroutine until the context is canceled.
+// Returns an error if the channel is missing, or if the subscription fails.
+//
+// Handler panics are recovered. With Group, messages are read from a stream and
+// acknowledged, so they are not lost when the process restarts; see Consume.
+func (c *builder[T]) Subscribe(ctx context.Context, handler func(msg string), opts ...SubscribeOption) error {
+	return c.Consume(ctx, func(_ context.Context, msg string) error {
+		handler(msg)
+		return nil
+	}, opts...)
+}
+
+// Consume is like Subscribe with a handler returning an error. Failed messages
+// (error or panic) are retried per WithRetry, then passed to WithOnError.
+//
+// By default it uses pub/sub: messages published while nobody listens are lost.
+// With Group, it reads the stream of the channel as a consumer group member:
+//   - a message is acknowledged once handled, or once it failed every attempt
+//   - messages pending on a consumer for longer than WithClaimIdle
+//     (e.g. after a crash) are claimed and handled again
+func (c *builder[T]) Consume(ctx context.Context, handler MessageHandler, opts ...SubscribeOption) error {
+	if str.IsEmpty(c.channel) {
+		return ErrMissingChannel
+	}
+	if c.stream && str.IsEmpty(c.group) {
+		return ErrMissingGroup
+	}
+
+	o := defaultSubscribeOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if c.stream {
+		return c.consumeGroup(ctx, handler, o)
+	}
+	return c.consumePubSub(ctx, handler, o)
+}
+
+func (c *builder[T]) consumePubSub(ctx context.Context, handler MessageHandler, o *subscribeOptions) error {
+	rdb := c.cache.GetClient()
+	pubsub := rdb.Subscribe(ctx, c.channel)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		_ = pubsub.Close()
+		return err
+	}
+
+	jobs := make(chan string)
+	runWorkers(o.workers, jobs, func(msg string) {
+		_ = handle(ctx, handler, msg, o)
+	})
+
+	ch := pubsub.Channel()
+	go func() {
+		defer close(jobs)
+		defer pubsub.Close()
+		for {
+			select {
+			case msg, ok := <-ch:
+				if !ok {
+					return
+				}
+				if msg == nil {
+					continue
+				}
+				select {
+				case jobs <- msg.Payload:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+func (c *builder[T]) consumeGroup(ctx context.Context, handler MessageHandler, o *subscribeOptions) error {
+	rdb := c.cache.GetClient()
+	stream := c.cache.Key(c.channel)
+	consumer := c.consumer
+	if consumer == "" {
+		consumer = c.group
+	}
+
+	err := rdb.XGroupCreateMkStream(ctx, stream, c.group, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return fmt.Errorf("[redis] failed to create consumer group: %w", err)
+	}
+
+	jobs := make(chan redis.XMessage)
+	runWorkers(o.workers, jobs, func(m redis.XMessage) {
+		msg, _ := m.Values[payloadField].(string)
+		_ = handle(ctx, handler, msg, o)
+		// on shutdown the handler may have been cut short; leave the message pending
+		// so XAUTOCLAIM hands it to another consumer
+		if ctx.Err() != nil {
+			return
+		}
+		if err := rdb.XAck(context.WithoutCancel(ctx), stream, c.group, m.ID).Err(); err != nil {
+			log.Printf("[redis] failed to ack message %s: %v", m.ID, err)
+		}
+	})
+
+	dispatch := func(msgs []redis.XMessage) bool {
+		for _, m := range msgs {
+			select {
+			case jobs <- m:
+			case <-ctx.Done():
+				return false
+			}
+		}
+		return true
+	}
+
+	go func() {
+		defer close(jobs)
+		var lastClaim time.Time
+		for ctx.Err() == nil {
+			if time.Since(lastClaim) >= o.claimIdle {
+				lastClaim = time.Now()
+				claimed, _, err := rdb.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+					Stream:   stream,
+					Group:    c.group,
+					Consumer: consumer,
+					MinIdle:  o.claimIdle,
+					Start:    "0-0",
+					Count:    int64(o.workers) * 10,
+				}).Result()
+				if err != nil && ctx.Err() == nil {
+					log.Printf("[redis] failed to claim pending messages: %v", err)
+				}
+				if !dispatch(claimed) {
+					return
+				}
+			}
+
+			res, err := rdb.XReadGroup(ctx, &redis.XReadGroupArgs{
+				Group:    c.group,
+				Consumer: consumer,
+				Streams:  []string{stream, ">"},
+				Count:    int64(o.workers),
+				Block:    readBlock,
+			}).Result()
+			if err != nil {
+				if errors.Is(err, redis.Nil) {
+					continue
+				}
+				if ctx.Err() == nil {
+					log.Printf("[redis] failed to read stream %s: %v", stream, err)
+					sleep(ctx, errorBackoff)
+				}
+				continue
+			}
+			for _, s := range res {
+				if !dispatch(s.Messages) {
+					return
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// runWorkers starts n goroutines running fn for each job until jobs is closed.
+func runWorkers[J any](n int, jobs <-chan J, fn func(J)) {
+	for i := 0; i < n; i++ {
+		go func() {
+			for j := range jobs {
+				fn(j)
+			}
+		}()
+	}
+}
+
+// handle runs handler with retries and reports the final failure to onError.
+func handle(ctx context.Context, handler MessageHandler, msg string, o *subscribeOptions) error {
+	var err error
+	for attempt := 1; attempt <= o.attempts; attempt++ {
+		if err = safeCall(ctx, handler, msg); err == nil {
+			return nil
+		}
+		if attempt < o.attempts && !sleep(ctx, o.backoff) {
+			break
+		}
+	}
+	o.onError(msg, err)
+	return err
+}
+
+func safeCall(ctx context.Context, handler MessageHandler, msg string) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("[redis] handler panic: %v", p)
+		}
+	}()
+	return handler(ctx, msg)
+}
+
+// sleep waits d and reports false when ctx is done first.
+func sleep(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,135 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redismock/v9"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandle_RetryAndPanic(t *testing.T) {
+	ctx := context.Background()
+
+	var calls int32
+	var dropped string
+	o := defaultSubscribeOptions()
+	WithRetry(3, time.Millisecond)(o)
+	WithOnError(func(msg string, err error) { dropped = msg })(o)
+
+	err := handle(ctx, func(context.Context, string) error {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			panic("boom")
+		}
+		return nil
+	}, "m1", o)
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), calls)
+	assert.Empty(t, dropped)
+
+	err = handle(ctx, func(context.Context, string) error {
+		return errors.New("always")
+	}, "m2", o)
+	assert.EqualError(t, err, "always")
+	assert.Equal(t, "m2", dropped)
+}
+
+func TestPublish_Stream(t *testing.T) {
+	rdb, mock := redismock.NewClientMock()
+	cache := &Cache{client: rdb, cf: &Config{Timeout: 5 * time.Second}}
+
+	mock.ExpectXAdd(&redis.XAddArgs{
+		Stream: "orders",
+		MaxLen: 1000,
+		Approx: true,
+		Values: map[string]interface{}{payloadField: []byte("hello")},
+	}).SetVal("1-0")
+
+	err := With[string](cache).Channel("orders").Stream(1000).Value("hello").Publish(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestConsume_Group(t *testing.T) {
+	rdb, mock := redismock.NewClientMock()
+	mock.MatchExpectationsInOrder(false)
+	cache := &Cache{client: rdb, cf: &Config{Timeout: 5 * time.Second}}
+
+	mock.ExpectXGroupCreateMkStream("orders", "billing", "0").SetErr(errors.New("BUSYGROUP Consumer Group name already exists"))
+	mock.ExpectXAutoClaim(&redis.XAutoClaimArgs{
+		Stream: "orders", Group: "billing", Consumer: "c1",
+		MinIdle: time.Minute, Start: "0-0", Count: 10,
+	}).SetVal([]redis.XMessage{{ID: "1-0", Values: map[string]interface{}{payloadField: "claimed"}}}, "0-0")
+	mock.ExpectXReadGroup(&redis.XReadGroupArgs{
+		Group: "billing", Consumer: "c1",
+		Streams: []string{"orders", ">"}, Count: 1, Block: readBlock,
+	}).SetVal([]redis.XStream{{Stream: "orders", Messages: []redis.XMessage{
+		{ID: "2-0", Values: map[string]interface{}{payloadField: "new"}},
+	}}})
+	mock.ExpectXAck("orders", "billing", "1-0").SetVal(1)
+	mock.ExpectXAck("orders", "billing", "2-0").SetVal(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	got := make(chan string, 2)
+	err := With[string](cache).Channel("orders").Group("billing", "c1").
+		Subscribe(ctx, func(msg string) { got <- msg })
+	require.NoError(t, err)
+
+	assert.Equal(t, "claimed", <-got)
+	assert.Equal(t, "new", <-got)
+	assert.Eventually(t, func() bool { return mock.ExpectationsWereMet() == nil }, time.Second, 10*time.Millisecond)
+	cancel()
+}
+
+func TestConsume_GroupShutdownLeavesPending(t *testing.T) {
+	rdb, mock := redismock.NewClientMock()
+	mock.MatchExpectationsInOrder(false)
+	cache := &Cache{client: rdb, cf: &Config{Timeout: 5 * time.Second}}
+
+	mock.ExpectXGroupCreateMkStream("orders", "billing", "0").SetVal("OK")
+	mock.ExpectXAutoClaim(&redis.XAutoClaimArgs{
+		Stream: "orders", Group: "billing", Consumer: "c1",
+		MinIdle: time.Minute, Start: "0-0", Count: 10,
+	}).SetVal(nil, "0-0")
+	mock.ExpectXReadGroup(&redis.XReadGroupArgs{
+		Group: "billing", Consumer: "c1",
+		Streams: []string{"orders", ">"}, Count: 1, Block: readBlock,
+	}).SetVal([]redis.XStream{{Stream: "orders", Messages: []redis.XMessage{
+		{ID: "1-0", Values: map[string]interface{}{payloadField: "slow"}},
+	}}})
+	mock.ExpectXAck("orders", "billing", "1-0").SetVal(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	err := With[string](cache).Channel("orders").Group("billing", "c1").
+		Subscribe(ctx, func(string) {
+			close(started)
+			<-release
+		})
+	require.NoError(t, err)
+
+	<-started
+	cancel()
+	close(release)
+
+	// the XAck expectation stays unmet: the message is left for XAUTOCLAIM
+	assert.Never(t, func() bool { return mock.ExpectationsWereMet() == nil }, 200*time.Millisecond, 10*time.Millisecond)
+}
+
+func TestConsume_StreamWithoutGroup(t *testing.T) {
+	rdb, _ := redismock.NewClientMock()
+	cache := &Cache{client: rdb, cf: &Config{Timeout: 5 * time.Second}}
+
+	err := With[string](cache).Channel("orders").Stream(0).Subscribe(context.Background(), func(string) {})
+	assert.ErrorIs(t, err, ErrMissingGroup)
+}
@@ -0,0 +1,205 @@
+package report
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Document is a report: a title block, key/value fields and tables.
+type Document struct {
+	// Title and Subtitle open the first page (e.g. "Sao kê tài khoản", "01/05/2024 - 31/05/2024").
+	Title    string
+	Subtitle string
+
+	// Header and Footer are printed on every page, e.g. the company name and
+	// a disclaimer; the footer also carries the page number.
+	Header string
+	Footer string
+
+	// Author is written to the PDF metadata.
+	Author string
+
+	// Info is printed under the title as label: value lines (account, period, ...).
+	Info []Field
+
+	Tables []*Table
+
+	// Summary is printed after the tables (totals, closing balance, ...).
+	Summary []Field
+}
+
+// Field is a label and its value.
+type Field struct {
+	Label string
+	Value string
+}
+
+// Align is the horizontal alignment of a column.
+type Align int
+
+const (
+	// AlignAuto right-aligns numbers and left-aligns everything else.
+	AlignAuto Align = iota
+	AlignLeft
+	AlignCenter
+	AlignRight
+)
+
+// Column describes a table column.
+type Column struct {
+	Header string
+
+	// Width is relative to the other columns (0 counts as 1); the table
+	// spans the printable width.
+	Width float64
+
+	Align Align
+
+	// Decimals of float and decimal values (default 0).
+	Decimals int
+
+	// Layout of time.Time values (default "2006-01-02 15:04:05").
+	Layout string
+}
+
+// Table is a titled table. Cells hold raw values formatted on rendering:
+// strings, integers, floats, decimal.Decimal, money.Amount, time.Time,
+// fmt.Stringer and pointers to them (nil prints empty).
+type Table struct {
+	Title   string
+	Columns []Column
+	Rows    [][]any
+
+	// Total is an optional bold last row, e.g. {"Total", "", sum}.
+	Total []any
+}
+
+// NewTable creates a table with the given column headers.
+func NewTable(title string, headers ...string) *Table {
+	t := &Table{Title: title}
+	for _, h := range headers {
+		t.Columns = append(t.Columns, Column{Header: h})
+	}
+	return t
+}
+
+// AddRow appends a row of values.
+func (t *Table) AddRow(values ...any) *Table {
+	t.Rows = append(t.Rows, values)
+	return t
+}
+
+// SetTotal sets the total row.
+func (t *Table) SetTotal(values ...any) *Table {
+	t.Total = values
+	return t
+}
+
+const tagName = "report"
+
+// TableOf builds a table from structs or pointers to structs, such as the
+// results of a database query or rows read with csvx. Columns come from the
+// `report` tag, falling back to the `csv` tag and then the field name:
+//
+//	type Txn struct {
+//		PostedAt time.Time       `report:"Ngày;width=2;layout=02/01/2006"`
+//		Content  string          `report:"Nội dung;width=5"`
+//		Amount   decimal.Decimal `report:"Số tiền;width=2;decimals=0"`
+//		Ref      string          `report:"-"`
+//	}
+//
+// Options are separated by ';': width, align (left, center, right), decimals and layout.
+func TableOf[T any](title string, rows []T) (*Table, error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	ptr := t.Kind() == reflect.Pointer
+	if ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: %s", ErrNotStruct, t)
+	}
+
+	cols, index, err := structColumns(t)
+	if err != nil {
+		return nil, err
+	}
+
+	table := &Table{Title: title, Columns: cols, Rows: make([][]any, 0, len(rows))}
+	for _, row := range rows {
+		v := reflect.ValueOf(row)
+		if ptr {
+			if v.IsNil() {
+				continue
+			}
+			v = v.Elem()
+		}
+		values := make([]any, len(index))
+		for i, idx := range index {
+			values[i] = v.FieldByIndex(idx).Interface()
+		}
+		table.Rows = append(table.Rows, values)
+	}
+	return table, nil
+}
+
+func structColumns(t reflect.Type) ([]Column, [][]int, error) {
+	var (
+		cols  []Column
+		index [][]int
+	)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		tag, ok := f.Tag.Lookup(tagName)
+		if !ok {
+			tag, _, _ = strings.Cut(f.Tag.Get("csv"), ",")
+		}
+		if tag == "-" {
+			continue
+		}
+
+		parts := strings.Split(tag, ";")
+		col := Column{Header: parts[0]}
+		if col.Header == "" {
+			col.Header = f.Name
+		}
+		for _, p := range parts[1:] {
+			key, val, _ := strings.Cut(p, "=")
+			val = strings.TrimSpace(val)
+			switch strings.TrimSpace(key) {
+			case "width":
+				w, err := strconv.ParseFloat(val, 64)
+				if err != nil {
+					return nil, nil, fmt.Errorf("[report] field %s: invalid width %q", f.Name, val)
+				}
+				col.Width = w
+			case "decimals":
+				d, err := strconv.Atoi(val)
+				if err != nil {
+					return nil, nil, fmt.Errorf("[report] field %s: invalid decimals %q", f.Name, val)
+				}
+				col.Decimals = d
+			case "align":
+				switch val {
+				case "left":
+					col.Align = AlignLeft
+				case "center":
+					col.Align = AlignCenter
+				case "right":
+					col.Align = AlignRight
+				default:
+					return nil, nil, fmt.Errorf("[report] field %s: invalid align %q", f.Name, val)
+				}
+			case "layout":
+				col.Layout = val
+			}
+		}
+		cols = append(cols, col)
+		index = append(index, f.Index)
+	}
+	return cols, index, nil
+}
@@ -0,0 +1,9 @@
+package report
+
+import "errors"
+
+var (
+	ErrFont      = errors.New("[report] failed to load font")
+	ErrNotStruct = errors.New("[report] rows must be structs or pointers to structs")
+	ErrRender    = errors.New("[report] failed to render report")
+)
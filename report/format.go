@@ -0,0 +1,100 @@
+package report
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/BevisDev/godev/utils/datetime"
+	"github.com/BevisDev/godev/utils/money"
+	"github.com/shopspring/decimal"
+)
+
+// cell is a formatted value.
+type cell struct {
+	text    string
+	numeric bool
+}
+
+// format renders v for col; numbers are grouped with the separators of o.
+func (o *options) format(v any, col Column) cell {
+	rv := reflect.ValueOf(v)
+	for rv.IsValid() && rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return cell{}
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return cell{}
+	}
+	v = rv.Interface()
+
+	switch x := v.(type) {
+	case string:
+		return cell{text: x}
+	case money.Amount:
+		return cell{text: x.Format(), numeric: true}
+	case decimal.Decimal:
+		return cell{text: o.number(x, col.Decimals), numeric: true}
+	case time.Time:
+		if x.IsZero() {
+			return cell{}
+		}
+		layout := col.Layout
+		if layout == "" {
+			layout = datetime.DateTimeLayout
+		}
+		return cell{text: datetime.ToString(x, layout)}
+	case fmt.Stringer:
+		return cell{text: x.String()}
+	}
+
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cell{text: o.number(decimal.NewFromInt(rv.Int()), 0), numeric: true}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return cell{text: o.number(decimal.NewFromUint64(rv.Uint()), 0), numeric: true}
+	case reflect.Float32, reflect.Float64:
+		return cell{text: o.number(decimal.NewFromFloat(rv.Float()), col.Decimals), numeric: true}
+	case reflect.Bool:
+		return cell{text: fmt.Sprint(v)}
+	default:
+		return cell{text: fmt.Sprint(v)}
+	}
+}
+
+func (o *options) number(d decimal.Decimal, places int) string {
+	return money.FormatWithSeparators(d, int32(places), o.thousandSep, o.decimalSep)
+}
+
+// align resolves the alignment of a formatted cell.
+func (c cell) align(a Align) Align {
+	if a != AlignAuto {
+		return a
+	}
+	if c.numeric {
+		return AlignRight
+	}
+	return AlignLeft
+}
+
+// widths splits total over the relative column widths.
+func widths(cols []Column, total float64) []float64 {
+	sum := 0.0
+	for _, c := range cols {
+		sum += weight(c)
+	}
+	out := make([]float64, len(cols))
+	for i, c := range cols {
+		out[i] = total * weight(c) / sum
+	}
+	return out
+}
+
+func weight(c Column) float64 {
+	if c.Width <= 0 {
+		return 1
+	}
+	return c.Width
+}
@@ -0,0 +1,142 @@
+package report
+
+import (
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/BevisDev/godev/templatex"
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+var (
+	engineOnce sync.Once
+	engine     *templatex.Engine
+	engineErr  error
+)
+
+func htmlEngine() (*templatex.Engine, error) {
+	engineOnce.Do(func() {
+		engine, engineErr = templatex.New(templatesFS, templatex.WithRoot("templates"), templatex.WithStrict())
+	})
+	return engine, engineErr
+}
+
+type htmlCell struct {
+	Text  string
+	Align string
+}
+
+type htmlTable struct {
+	Title   string
+	Widths  []string
+	Headers []string
+	Rows    [][]htmlCell
+	Total   []htmlCell
+}
+
+type htmlDocument struct {
+	*Document
+	Tables      []htmlTable
+	PageSize    string
+	PageCounter htmltemplate.CSS
+	Margin      string
+	FontSize    string
+}
+
+// HTML renders doc to w as a standalone, printable HTML page with the same
+// layout as the PDF. Page numbers are set with CSS paged media for
+// HTML-to-PDF engines.
+func (g *Generator) HTML(w io.Writer, doc *Document) error {
+	e, err := htmlEngine()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrRender, err)
+	}
+
+	view := htmlDocument{
+		Document:    doc,
+		PageSize:    g.pageSize,
+		PageCounter: htmltemplate.CSS(g.pageCounter()),
+		Margin:      strconv.FormatFloat(g.margin, 'f', -1, 64),
+		FontSize:    strconv.FormatFloat(g.fontSize, 'f', -1, 64),
+	}
+	if g.landscape {
+		view.PageSize += " landscape"
+	}
+	for _, t := range doc.Tables {
+		view.Tables = append(view.Tables, g.htmlTable(t))
+	}
+
+	if err := e.Execute(w, "report.html", view); err != nil {
+		return fmt.Errorf("%w: %v", ErrRender, err)
+	}
+	return nil
+}
+
+func (g *Generator) htmlTable(t *Table) htmlTable {
+	ht := htmlTable{Title: t.Title}
+	for i, w := range widths(t.Columns, 100) {
+		ht.Widths = append(ht.Widths, strconv.FormatFloat(w, 'f', 2, 64))
+		ht.Headers = append(ht.Headers, t.Columns[i].Header)
+	}
+	row := func(values []any) []htmlCell {
+		cells := make([]htmlCell, len(t.Columns))
+		for i, col := range t.Columns {
+			var c cell
+			if i < len(values) {
+				c = g.format(values[i], col)
+			}
+			cells[i] = htmlCell{Text: c.text, Align: alignClass(c.align(col.Align))}
+		}
+		return cells
+	}
+	for _, values := range t.Rows {
+		ht.Rows = append(ht.Rows, row(values))
+	}
+	if len(t.Total) > 0 {
+		ht.Total = row(t.Total)
+	}
+	return ht
+}
+
+// pageCounter converts the page number format to a CSS content value.
+func (g *Generator) pageCounter() string {
+	if g.pageNumber == "" {
+		return "none"
+	}
+	var parts []string
+	rest := g.pageNumber
+	for rest != "" {
+		i := strings.IndexByte(rest, '{')
+		switch {
+		case strings.HasPrefix(rest, "{pages}"):
+			parts, rest = append(parts, "counter(pages)"), rest[len("{pages}"):]
+		case strings.HasPrefix(rest, "{page}"):
+			parts, rest = append(parts, "counter(page)"), rest[len("{page}"):]
+		case i < 0:
+			parts, rest = append(parts, strconv.Quote(rest)), ""
+		case i == 0:
+			parts, rest = append(parts, `"{"`), rest[1:]
+		default:
+			parts, rest = append(parts, strconv.Quote(rest[:i])), rest[i:]
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func alignClass(a Align) string {
+	switch a {
+	case AlignCenter:
+		return "center"
+	case AlignRight:
+		return "right"
+	default:
+		return "left"
+	}
+}
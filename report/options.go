@@ -0,0 +1,127 @@
+package report
+
+import "os"
+
+type Option func(*options)
+
+type options struct {
+	fontFamily  string
+	fontRegular []byte
+	fontBold    []byte
+	fontFiles   [2]string
+
+	pageSize   string
+	landscape  bool
+	margin     float64
+	fontSize   float64
+	pageNumber string
+
+	thousandSep string
+	decimalSep  string
+
+	// compress PDF streams; disabled in tests to inspect the output
+	compress bool
+}
+
+func defaultOptions() *options {
+	return &options{
+		pageSize:    "A4",
+		margin:      15,
+		fontSize:    9,
+		pageNumber:  "{page}/{pages}",
+		thousandSep: ",",
+		decimalSep:  ".",
+		compress:    true,
+	}
+}
+
+// WithFont embeds a TrueType font for PDF output, e.g. DejaVu Sans, Roboto or
+// Noto Sans for Vietnamese text. bold may be nil to use regular for bold text.
+// Without a font the built-in Helvetica is used and accents are removed,
+// since it only covers Latin-1.
+func WithFont(family string, regular, bold []byte) Option {
+	return func(o *options) {
+		o.fontFamily = family
+		o.fontRegular = regular
+		o.fontBold = bold
+	}
+}
+
+// WithFontFile is WithFont with font files read by New; boldPath may be empty.
+func WithFontFile(regularPath, boldPath string) Option {
+	return func(o *options) {
+		o.fontFiles = [2]string{regularPath, boldPath}
+	}
+}
+
+// WithPageSize sets the PDF page size: "A3", "A4" (default), "A5", "Letter" or "Legal".
+func WithPageSize(size string) Option {
+	return func(o *options) {
+		if size != "" {
+			o.pageSize = size
+		}
+	}
+}
+
+// WithLandscape lays PDF pages out in landscape, for wide tables.
+func WithLandscape() Option {
+	return func(o *options) {
+		o.landscape = true
+	}
+}
+
+// WithMargin sets the PDF page margins in millimetres (default 15).
+func WithMargin(mm float64) Option {
+	return func(o *options) {
+		if mm >= 0 {
+			o.margin = mm
+		}
+	}
+}
+
+// WithFontSize sets the body font size in points (default 9).
+func WithFontSize(pt float64) Option {
+	return func(o *options) {
+		if pt > 0 {
+			o.fontSize = pt
+		}
+	}
+}
+
+// WithPageNumber sets the page number text of the footer, with {page} and
+// {pages} placeholders (default "{page}/{pages}", e.g. "Trang {page}/{pages}").
+// An empty format hides page numbers.
+func WithPageNumber(format string) Option {
+	return func(o *options) {
+		o.pageNumber = format
+	}
+}
+
+// WithSeparators sets the thousand and decimal separators of numbers
+// (default "," and "."; "." and "," for the Vietnamese style 1.500.000,5).
+func WithSeparators(thousand, decimal string) Option {
+	return func(o *options) {
+		o.thousandSep, o.decimalSep = thousand, decimal
+	}
+}
+
+func (o *options) loadFonts() error {
+	if o.fontFiles[0] == "" {
+		return nil
+	}
+	regular, err := os.ReadFile(o.fontFiles[0])
+	if err != nil {
+		return err
+	}
+	var bold []byte
+	if o.fontFiles[1] != "" {
+		if bold, err = os.ReadFile(o.fontFiles[1]); err != nil {
+			return err
+		}
+	}
+	if o.fontFamily == "" {
+		o.fontFamily = "body"
+	}
+	o.fontRegular, o.fontBold = regular, bold
+	return nil
+}
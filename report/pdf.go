@@ -0,0 +1,288 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/BevisDev/godev/utils/str"
+	"github.com/go-pdf/fpdf"
+)
+
+const (
+	coreFont   = "Helvetica"
+	lineHeight = 1.45 // multiple of the font size
+	cellPad    = 1.5  // mm
+)
+
+// pdfWriter draws one document.
+type pdfWriter struct {
+	*options
+	pdf    *fpdf.Fpdf
+	family string
+	bold   string // style of bold text: "B", or "" when the font has no bold face
+	lineH  float64
+	width  float64 // printable width
+}
+
+// PDF renders doc to w: the title block on the first page, the tables with
+// their header row repeated after page breaks, and the header, footer and
+// page number on every page.
+func (g *Generator) PDF(w io.Writer, doc *Document) error {
+	orientation := "P"
+	if g.landscape {
+		orientation = "L"
+	}
+	pdf := fpdf.New(orientation, "mm", g.pageSize, "")
+	pdf.SetCompression(g.compress)
+	pdf.SetMargins(g.margin, g.margin, g.margin)
+	pdf.SetAutoPageBreak(true, g.margin+6)
+	pdf.AliasNbPages("{nb}")
+
+	p := &pdfWriter{options: g.options, pdf: pdf, family: coreFont, bold: "B"}
+	if g.fontRegular != nil {
+		if !isTrueType(g.fontRegular) || (g.fontBold != nil && !isTrueType(g.fontBold)) {
+			return fmt.Errorf("%w: not a TrueType font", ErrFont)
+		}
+		p.family = g.fontFamily
+		pdf.AddUTF8FontFromBytes(p.family, "", g.fontRegular)
+		if g.fontBold != nil {
+			pdf.AddUTF8FontFromBytes(p.family, "B", g.fontBold)
+		} else {
+			p.bold = ""
+		}
+	}
+	if err := pdf.Error(); err != nil {
+		return fmt.Errorf("%w: %v", ErrFont, err)
+	}
+
+	pageW, _ := pdf.GetPageSize()
+	p.width = pageW - 2*g.margin
+	p.lineH = g.fontSize * lineHeight * 25.4 / 72
+
+	if doc.Title != "" {
+		pdf.SetTitle(doc.Title, true)
+	}
+	if doc.Author != "" {
+		pdf.SetAuthor(doc.Author, true)
+	}
+	pdf.SetHeaderFuncMode(func() { p.header(doc) }, true)
+	pdf.SetFooterFunc(func() { p.footer(doc) })
+
+	pdf.AddPage()
+	p.body(doc)
+
+	if err := pdf.Output(w); err != nil {
+		return fmt.Errorf("%w: %v", ErrRender, err)
+	}
+	return nil
+}
+
+// isTrueType reports whether b starts with a TrueType signature; fpdf panics
+// later on, rather than failing, when given anything else.
+func isTrueType(b []byte) bool {
+	return len(b) >= 4 && (string(b[:4]) == "\x00\x01\x00\x00" || string(b[:4]) == "true")
+}
+
+// text prepares s for the font: the core font only covers Latin-1, so accents
+// are removed and other characters replaced.
+func (p *pdfWriter) text(s string) string {
+	if p.family != coreFont {
+		return s
+	}
+	s = str.RemoveAccents(s)
+	return strings.Map(func(r rune) rune {
+		if r > 126 || (r < 32 && r != '\n') {
+			return '?'
+		}
+		return r
+	}, s)
+}
+
+func (p *pdfWriter) setFont(bold bool, size float64) {
+	style := ""
+	if bold {
+		style = p.bold
+	}
+	p.pdf.SetFont(p.family, style, size)
+}
+
+func (p *pdfWriter) header(doc *Document) {
+	if doc.Header == "" {
+		return
+	}
+	p.setFont(false, p.fontSize-1)
+	p.pdf.SetTextColor(110, 110, 110)
+	p.pdf.CellFormat(p.width, p.lineH, p.text(doc.Header), "B", 1, "L", false, 0, "")
+	p.pdf.SetTextColor(0, 0, 0)
+	p.pdf.Ln(p.lineH / 2)
+}
+
+func (p *pdfWriter) footer(doc *Document) {
+	p.pdf.SetY(-(p.margin + 4))
+	p.setFont(false, p.fontSize-1)
+	p.pdf.SetTextColor(110, 110, 110)
+
+	pageNo := ""
+	if p.pageNumber != "" {
+		pageNo = strings.NewReplacer(
+			"{page}", strconv.Itoa(p.pdf.PageNo()),
+			"{pages}", "{nb}",
+		).Replace(p.text(p.pageNumber))
+	}
+	x := p.pdf.GetX()
+	p.pdf.CellFormat(p.width, p.lineH, p.text(doc.Footer), "T", 0, "L", false, 0, "")
+	p.pdf.SetX(x)
+	p.pdf.CellFormat(p.width, p.lineH, pageNo, "", 0, "R", false, 0, "")
+	p.pdf.SetTextColor(0, 0, 0)
+}
+
+func (p *pdfWriter) body(doc *Document) {
+	if doc.Title != "" {
+		p.setFont(true, p.fontSize+7)
+		p.pdf.MultiCell(p.width, p.lineH*1.6, p.text(doc.Title), "", "C", false)
+	}
+	if doc.Subtitle != "" {
+		p.setFont(false, p.fontSize+1)
+		p.pdf.MultiCell(p.width, p.lineH, p.text(doc.Subtitle), "", "C", false)
+	}
+	if doc.Title != "" || doc.Subtitle != "" {
+		p.pdf.Ln(p.lineH)
+	}
+
+	p.fields(doc.Info)
+	for _, t := range doc.Tables {
+		p.table(t)
+	}
+	p.fields(doc.Summary)
+}
+
+func (p *pdfWriter) fields(fields []Field) {
+	if len(fields) == 0 {
+		return
+	}
+	labelW := p.width * 0.3
+	for _, f := range fields {
+		p.setFont(true, p.fontSize)
+		y := p.pdf.GetY()
+		p.pdf.MultiCell(labelW, p.lineH, p.text(f.Label), "", "L", false)
+		labelEnd := p.pdf.GetY()
+
+		p.pdf.SetXY(p.margin+labelW, y)
+		p.setFont(false, p.fontSize)
+		p.pdf.MultiCell(p.width-labelW, p.lineH, p.text(f.Value), "", "L", false)
+		if labelEnd > p.pdf.GetY() {
+			p.pdf.SetY(labelEnd)
+		}
+	}
+	p.pdf.Ln(p.lineH / 2)
+}
+
+func (p *pdfWriter) table(t *Table) {
+	if len(t.Columns) == 0 {
+		return
+	}
+	ws := widths(t.Columns, p.width)
+
+	if t.Title != "" {
+		p.setFont(true, p.fontSize+2)
+		p.pdf.MultiCell(p.width, p.lineH*1.3, p.text(t.Title), "", "L", false)
+	}
+
+	headers := make([]cell, len(t.Columns))
+	for i, c := range t.Columns {
+		headers[i] = cell{text: c.Header}
+	}
+	drawHeader := func() {
+		p.row(t.Columns, ws, headers, true, true)
+	}
+	drawHeader()
+
+	for _, values := range t.Rows {
+		cells := p.cells(t.Columns, values)
+		if p.breaks(t.Columns, ws, cells) {
+			p.pdf.AddPage()
+			drawHeader()
+		}
+		p.row(t.Columns, ws, cells, false, false)
+	}
+	if len(t.Total) > 0 {
+		cells := p.cells(t.Columns, t.Total)
+		if p.breaks(t.Columns, ws, cells) {
+			p.pdf.AddPage()
+			drawHeader()
+		}
+		p.row(t.Columns, ws, cells, true, false)
+	}
+	p.pdf.Ln(p.lineH)
+}
+
+func (p *pdfWriter) cells(cols []Column, values []any) []cell {
+	cells := make([]cell, len(cols))
+	for i := range cols {
+		if i < len(values) {
+			cells[i] = p.format(values[i], cols[i])
+		}
+	}
+	return cells
+}
+
+// lines wraps every cell of a row to its column width.
+func (p *pdfWriter) lines(ws []float64, cells []cell, bold bool) ([][]string, int) {
+	p.setFont(bold, p.fontSize)
+	out := make([][]string, len(cells))
+	max := 1
+	for i, c := range cells {
+		lines := p.pdf.SplitText(p.text(c.text), ws[i]-2*cellPad+2*p.pdf.GetCellMargin())
+		if len(lines) == 0 {
+			lines = []string{""}
+		}
+		out[i] = lines
+		if len(lines) > max {
+			max = len(lines)
+		}
+	}
+	return out, max
+}
+
+// breaks reports whether the row does not fit on the current page.
+func (p *pdfWriter) breaks(cols []Column, ws []float64, cells []cell) bool {
+	_, n := p.lines(ws, cells, false)
+	_, pageH := p.pdf.GetPageSize()
+	_, _, _, bottom := p.pdf.GetMargins()
+	return p.pdf.GetY()+float64(n)*p.lineH+2*cellPad > pageH-bottom
+}
+
+func (p *pdfWriter) row(cols []Column, ws []float64, cells []cell, bold, fill bool) {
+	lines, n := p.lines(ws, cells, bold)
+	h := float64(n)*p.lineH + 2*cellPad
+
+	x, y := p.margin, p.pdf.GetY()
+	p.pdf.SetFillColor(235, 239, 245)
+	p.pdf.SetDrawColor(190, 190, 190)
+	for i, c := range cells {
+		style := "D"
+		if fill {
+			style = "FD"
+		}
+		p.pdf.Rect(x, y, ws[i], h, style)
+
+		align := "L"
+		switch c.align(cols[i].Align) {
+		case AlignCenter:
+			align = "C"
+		case AlignRight:
+			align = "R"
+		}
+		if bold && fill {
+			align = "C"
+		}
+		for j, line := range lines[i] {
+			p.pdf.SetXY(x+cellPad-p.pdf.GetCellMargin(), y+cellPad+float64(j)*p.lineH)
+			p.pdf.CellFormat(ws[i]-2*cellPad+2*p.pdf.GetCellMargin(), p.lineH, line, "", 0, align, false, 0, "")
+		}
+		x += ws[i]
+	}
+	p.pdf.SetXY(p.margin, y+h)
+}
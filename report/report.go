@@ -0,0 +1,44 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Generator renders documents to PDF and HTML. It is safe for concurrent use.
+type Generator struct {
+	*options
+}
+
+// New creates a Generator, reading the font files of WithFontFile.
+func New(opts ...Option) (*Generator, error) {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	if err := o.loadFonts(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrFont, err)
+	}
+	if o.fontRegular != nil && o.fontFamily == "" {
+		o.fontFamily = "body"
+	}
+	return &Generator{options: o}, nil
+}
+
+// PDFBytes renders doc to PDF in memory.
+func (g *Generator) PDFBytes(doc *Document) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := g.PDF(&buf, doc); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// HTMLString renders doc to HTML in memory.
+func (g *Generator) HTMLString(doc *Document) (string, error) {
+	var buf bytes.Buffer
+	if err := g.HTML(&buf, doc); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,178 @@
+package report
+
+import (
+	"bytes"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BevisDev/godev/utils/money"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type txn struct {
+	PostedAt time.Time       `report:"Ngày;width=2;layout=02/01/2006"`
+	Content  string          `report:"Nội dung;width=5"`
+	Amount   decimal.Decimal `report:"Số tiền;width=2;decimals=0"`
+	Rate     float64         `report:"Lãi suất;decimals=2;align=center"`
+	Ref      string          `report:"-"`
+	Note     *string         `csv:"note"`
+	Count    int
+}
+
+func sampleTxns(n int) []*txn {
+	note := "ghi chú"
+	at := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	out := make([]*txn, 0, n)
+	for i := 0; i < n; i++ {
+		out = append(out, &txn{
+			PostedAt: at.AddDate(0, 0, i),
+			Content:  "Chuyển khoản tiền điện tháng 5 cho Công ty Điện lực Hà Nội",
+			Amount:   decimal.NewFromInt(1500000 + int64(i)),
+			Rate:     5.5,
+			Note:     &note,
+			Count:    1000 * i,
+		})
+	}
+	return out
+}
+
+func TestTableOf(t *testing.T) {
+	table, err := TableOf("Giao dịch", append(sampleTxns(2), nil))
+	require.NoError(t, err)
+
+	var headers []string
+	for _, c := range table.Columns {
+		headers = append(headers, c.Header)
+	}
+	assert.Equal(t, []string{"Ngày", "Nội dung", "Số tiền", "Lãi suất", "note", "Count"}, headers)
+	assert.Equal(t, 5.0, table.Columns[1].Width)
+	assert.Equal(t, AlignCenter, table.Columns[3].Align)
+	assert.Equal(t, 2, table.Columns[3].Decimals)
+	assert.Len(t, table.Rows, 2)
+
+	_, err = TableOf("x", []int{1})
+	assert.ErrorIs(t, err, ErrNotStruct)
+
+	type bad struct {
+		A int `report:"A;width=x"`
+	}
+	_, err = TableOf("x", []bad{{}})
+	assert.Error(t, err)
+}
+
+func TestFormat(t *testing.T) {
+	o := defaultOptions()
+	vn := defaultOptions()
+	vn.thousandSep, vn.decimalSep = ".", ","
+	s := "x"
+	var nilPtr *string
+
+	cases := []struct {
+		o    *options
+		v    any
+		col  Column
+		want cell
+	}{
+		{o, "a", Column{}, cell{text: "a"}},
+		{o, &s, Column{}, cell{text: "x"}},
+		{o, nilPtr, Column{}, cell{}},
+		{o, nil, Column{}, cell{}},
+		{o, 1500000, Column{}, cell{text: "1,500,000", numeric: true}},
+		{vn, 1500000.5, Column{Decimals: 1}, cell{text: "1.500.000,5", numeric: true}},
+		{o, decimal.RequireFromString("-1234.567"), Column{Decimals: 2}, cell{text: "-1,234.57", numeric: true}},
+		{o, money.New(money.FromInt(1500000), money.VND), Column{}, cell{text: "1.500.000 ₫", numeric: true}},
+		{o, time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC), Column{}, cell{text: "2024-05-01 09:00:00"}},
+		{o, time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC), Column{Layout: "02/01/2006"}, cell{text: "01/05/2024"}},
+		{o, time.Time{}, Column{}, cell{}},
+		{o, true, Column{}, cell{text: "true"}},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.want, c.o.format(c.v, c.col), "%v", c.v)
+	}
+}
+
+func testDocument(t *testing.T, rows int) *Document {
+	table, err := TableOf("Giao dịch", sampleTxns(rows))
+	require.NoError(t, err)
+	table.SetTotal("Tổng cộng", "", decimal.NewFromInt(int64(rows)*1500000))
+
+	return &Document{
+		Title:    "Sao kê tài khoản",
+		Subtitle: "01/05/2024 - 31/05/2024",
+		Header:   "Ngân hàng Bevis",
+		Footer:   "Tài liệu được tạo tự động",
+		Author:   "godev",
+		Info:     []Field{{"Chủ tài khoản", "Nguyễn Văn Đức"}, {"Số tài khoản", "0123456789"}},
+		Tables:   []*Table{table, NewTable("Phí", "Loại", "Số tiền").AddRow("SMS", 11000)},
+		Summary:  []Field{{"Số dư cuối kỳ", "15.000.000 ₫"}},
+	}
+}
+
+var pageRe = regexp.MustCompile(`/Type /Page\b`)
+
+func TestPDF_CoreFont(t *testing.T) {
+	g, err := New(WithPageNumber("Trang {page}/{pages}"))
+	require.NoError(t, err)
+	g.compress = false
+
+	out, err := g.PDFBytes(testDocument(t, 120))
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(out, []byte("%PDF-")))
+
+	pages := len(pageRe.FindAll(out, -1))
+	assert.Greater(t, pages, 2, "long tables break across pages")
+	// accents are removed for the core font; the page alias is replaced
+	assert.Contains(t, string(out), "(Sao ke tai khoan)")
+	assert.Contains(t, string(out), "Nguyen Van Duc")
+	assert.Contains(t, string(out), "Trang 1/"+strconv.Itoa(pages))
+	assert.NotContains(t, string(out), "{nb}")
+}
+
+func TestPDF_UTF8Font(t *testing.T) {
+	const regular = "/usr/share/fonts/truetype/dejavu/DejaVuSans.ttf"
+	if _, err := os.Stat(regular); err != nil {
+		t.Skip("DejaVu font not installed")
+	}
+
+	g, err := New(WithFontFile(regular, strings.Replace(regular, "Sans.ttf", "Sans-Bold.ttf", 1)), WithLandscape())
+	require.NoError(t, err)
+
+	out, err := g.PDFBytes(testDocument(t, 30))
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(out, []byte("%PDF-")))
+	assert.Contains(t, string(out), "FontFile2", "the font is embedded")
+
+	_, err = New(WithFontFile("/missing.ttf", ""))
+	assert.ErrorIs(t, err, ErrFont)
+
+	g, _ = New(WithFont("bad", []byte("not a font"), nil))
+	_, err = g.PDFBytes(testDocument(t, 1))
+	assert.ErrorIs(t, err, ErrFont)
+}
+
+func TestHTML(t *testing.T) {
+	g, err := New(WithSeparators(".", ","), WithPageNumber("Trang {page}/{pages}"), WithLandscape())
+	require.NoError(t, err)
+
+	doc := testDocument(t, 2)
+	doc.Info = append(doc.Info, Field{"Ghi chú", "<script>"})
+	html, err := g.HTMLString(doc)
+	require.NoError(t, err)
+
+	assert.Contains(t, html, "<h1>Sao kê tài khoản</h1>")
+	assert.Contains(t, html, `<div class="header">Ngân hàng Bevis</div>`)
+	assert.Contains(t, html, `<th>Nội dung</th>`)
+	assert.Contains(t, html, `<td class="right">1.500.000</td>`)
+	assert.Contains(t, html, `<td class="center">5,50</td>`)
+	assert.Contains(t, html, `<tr class="total"><td class="left">Tổng cộng</td>`)
+	assert.Contains(t, html, `<td class="right">11.000</td>`)
+	assert.Contains(t, html, "&lt;script&gt;")
+	assert.Contains(t, html, `content: "Trang " counter(page) "/" counter(pages);`)
+	assert.Contains(t, html, "size: A4 landscape;")
+}
@@ -0,0 +1,213 @@
+package rest
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Strategy selects how requests are spread over the base URLs.
+type Strategy int
+
+const (
+	// RoundRobin cycles through healthy hosts in order.
+	RoundRobin Strategy = iota
+
+	// Weighted distributes requests proportionally to Endpoint.Weight (smooth weighted round-robin).
+	Weighted
+
+	// LeastFailures prefers the healthy host with the fewest recent failures.
+	LeastFailures
+)
+
+const (
+	defaultMaxFailures = 3
+	defaultEjectFor    = 30 * time.Second
+)
+
+// Endpoint is a base URL with its weight for the Weighted strategy (default 1).
+type Endpoint struct {
+	URL    string
+	Weight int
+}
+
+// HostStatus is a snapshot of a balanced host (see Client.Hosts).
+type HostStatus struct {
+	URL          string
+	Weight       int
+	Failures     int // consecutive failures
+	EjectedUntil time.Time
+}
+
+// Ejected reports whether the host is currently excluded from balancing.
+func (s HostStatus) Ejected() bool {
+	return time.Now().Before(s.EjectedUntil)
+}
+
+type host struct {
+	url          string
+	weight       int
+	current      int // smooth weighted round-robin state
+	failures     int
+	ejectedUntil time.Time
+}
+
+// balancer picks a host per request and tracks passive health:
+// a host failing maxFailures times in a row is ejected for ejectFor.
+type balancer struct {
+	strategy    Strategy
+	maxFailures int
+	ejectFor    time.Duration
+
+	mu    sync.Mutex
+	hosts []*host
+	next  int
+}
+
+func newBalancer(endpoints []Endpoint, strategy Strategy, maxFailures int, ejectFor time.Duration) *balancer {
+	b := &balancer{
+		strategy:    strategy,
+		maxFailures: maxFailures,
+		ejectFor:    ejectFor,
+	}
+	for _, e := range endpoints {
+		if e.URL == "" {
+			continue
+		}
+		w := e.Weight
+		if w <= 0 {
+			w = 1
+		}
+		b.hosts = append(b.hosts, &host{url: strings.TrimRight(e.URL, "/"), weight: w})
+	}
+	return b
+}
+
+func (b *balancer) size() int {
+	return len(b.hosts)
+}
+
+// pick returns the next host, skipping hosts in tried. When every candidate is
+// ejected the one whose ejection ends first is used, so requests never fail
+// only because all hosts were ejected.
+func (b *balancer) pick(tried map[*host]struct{}) *host {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	var healthy []*host
+	var fallback *host
+	for _, h := range b.hosts {
+		if _, ok := tried[h]; ok {
+			continue
+		}
+		if now.Before(h.ejectedUntil) {
+			if fallback == nil || h.ejectedUntil.Before(fallback.ejectedUntil) {
+				fallback = h
+			}
+			continue
+		}
+		healthy = append(healthy, h)
+	}
+	if len(healthy) == 0 {
+		return fallback
+	}
+
+	switch b.strategy {
+	case Weighted:
+		return b.pickWeighted(healthy)
+	case LeastFailures:
+		return b.pickLeastFailures(healthy)
+	default:
+		h := healthy[b.next%len(healthy)]
+		b.next++
+		return h
+	}
+}
+
+func (b *balancer) pickWeighted(hosts []*host) *host {
+	total := 0
+	var best *host
+	for _, h := range hosts {
+		h.current += h.weight
+		total += h.weight
+		if best == nil || h.current > best.current {
+			best = h
+		}
+	}
+	best.current -= total
+	return best
+}
+
+func (b *balancer) pickLeastFailures(hosts []*host) *host {
+	// rotate the start so ties are spread round-robin
+	start := b.next % len(hosts)
+	b.next++
+
+	best := hosts[start]
+	for i := 1; i < len(hosts); i++ {
+		h := hosts[(start+i)%len(hosts)]
+		if h.failures < best.failures {
+			best = h
+		}
+	}
+	return best
+}
+
+// report records the outcome of a request: transport errors and 5xx responses count as failures.
+func (b *balancer) report(h *host, status int, err error) {
+	failed := status >= 500
+	if err != nil {
+		if _, ok := AsHTTPError(err); !ok {
+			failed = true
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !failed {
+		h.failures = 0
+		h.ejectedUntil = time.Time{}
+		return
+	}
+	h.failures++
+	if h.failures >= b.maxFailures {
+		h.ejectedUntil = time.Now().Add(b.ejectFor)
+	}
+}
+
+func (b *balancer) status() []HostStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	out := make([]HostStatus, len(b.hosts))
+	for i, h := range b.hosts {
+		out[i] = HostStatus{URL: h.url, Weight: h.weight, Failures: h.failures, EjectedUntil: h.ejectedUntil}
+	}
+	return out
+}
+
+// resolve joins a relative request path to the host URL.
+func (h *host) resolve(path string) string {
+	if path == "" || strings.HasPrefix(path, "?") {
+		return h.url + path
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return h.url + path
+}
+
+func isAbsoluteURL(u string) bool {
+	return strings.HasPrefix(u, "http://") || strings.HasPrefix(u, "https://")
+}
+
+// isDialError reports whether the request never reached the host, so it is
+// safe to retry on another host even for non-idempotent methods.
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
@@ -0,0 +1,124 @@
+package rest
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func countingServer(t *testing.T, status int, hits *atomic.Int32) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		assert.Equal(t, "/users/42", r.URL.Path)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"message":"ok"}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestBalancer_RoundRobin(t *testing.T) {
+	var a, b atomic.Int32
+	srvA := countingServer(t, http.StatusOK, &a)
+	srvB := countingServer(t, http.StatusOK, &b)
+
+	c := New(WithBaseURLs(srvA.URL, srvB.URL+"/"))
+	for i := 0; i < 6; i++ {
+		_, err := NewRequest[MockResponse](c).
+			URL("/users/:id").
+			PathParams(map[string]string{"id": "42"}).
+			GET(context.Background())
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(3), a.Load())
+	assert.Equal(t, int32(3), b.Load())
+}
+
+func TestBalancer_Weighted(t *testing.T) {
+	bl := newBalancer([]Endpoint{{URL: "http://a", Weight: 3}, {URL: "http://b", Weight: 1}}, Weighted, 3, time.Second)
+
+	counts := map[string]int{}
+	for i := 0; i < 8; i++ {
+		counts[bl.pick(nil).url]++
+	}
+	assert.Equal(t, map[string]int{"http://a": 6, "http://b": 2}, counts)
+}
+
+func TestBalancer_EjectsFailingHost(t *testing.T) {
+	var good, bad atomic.Int32
+	srvGood := countingServer(t, http.StatusOK, &good)
+	srvBad := countingServer(t, http.StatusServiceUnavailable, &bad)
+
+	c := New(
+		WithBaseURLs(srvBad.URL, srvGood.URL),
+		WithEjection(2, time.Minute),
+	)
+	for i := 0; i < 10; i++ {
+		_, _ = NewRequest[MockResponse](c).URL("/users/42").GET(context.Background())
+	}
+
+	assert.Equal(t, int32(2), bad.Load(), "host must be ejected after 2 failures")
+	assert.Equal(t, int32(8), good.Load())
+
+	hosts := c.Hosts()
+	require.Len(t, hosts, 2)
+	assert.True(t, hosts[0].Ejected())
+	assert.False(t, hosts[1].Ejected())
+}
+
+func TestBalancer_LeastFailures(t *testing.T) {
+	var good, bad atomic.Int32
+	srvGood := countingServer(t, http.StatusOK, &good)
+	srvBad := countingServer(t, http.StatusInternalServerError, &bad)
+
+	c := New(WithBaseURLs(srvBad.URL, srvGood.URL), WithBalancer(LeastFailures))
+	for i := 0; i < 10; i++ {
+		_, _ = NewRequest[MockResponse](c).URL("/users/42").GET(context.Background())
+	}
+
+	assert.Equal(t, int32(1), bad.Load(), "a failing host loses to a healthy one")
+	assert.Equal(t, int32(9), good.Load())
+}
+
+func TestBalancer_FailoverOnDialError(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	deadURL := "http://" + ln.Addr().String()
+	_ = ln.Close()
+
+	var hits atomic.Int32
+	srv := countingServer(t, http.StatusOK, &hits)
+
+	c := New(WithBaseURLs(deadURL, srv.URL))
+	for i := 0; i < 4; i++ {
+		res, err := NewRequest[MockResponse](c).URL("/users/42").POST(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "ok", res.Data.Message)
+	}
+	assert.Equal(t, int32(4), hits.Load())
+}
+
+func TestBalancer_AbsoluteURLAndFallback(t *testing.T) {
+	var hits atomic.Int32
+	srv := countingServer(t, http.StatusOK, &hits)
+
+	c := New(WithBaseURL("http://127.0.0.1:1"))
+	_, err := NewRequest[MockResponse](c).URL(srv.URL + "/users/42").GET(context.Background())
+	require.NoError(t, err, "absolute URLs bypass the balancer")
+	assert.Equal(t, int32(1), hits.Load())
+
+	bl := newBalancer([]Endpoint{{URL: "http://a"}, {URL: "http://b"}}, RoundRobin, 1, time.Minute)
+	a := bl.pick(nil)
+	bl.report(a, 0, &net.OpError{Op: "dial"})
+	b := bl.pick(nil)
+	bl.report(b, http.StatusBadGateway, nil)
+	assert.NotNil(t, bl.pick(nil), "all ejected still returns a host")
+}
@@ -0,0 +1,285 @@
+package rest
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	headerCacheControl    = "Cache-Control"
+	headerETag            = "ETag"
+	headerLastModified    = "Last-Modified"
+	headerIfNoneMatch     = "If-None-Match"
+	headerIfModifiedSince = "If-Modified-Since"
+	headerAuthorization   = "Authorization"
+
+	// staleTTL keeps a stale response with validators for revalidation.
+	staleTTL = 24 * time.Hour
+)
+
+// CachedResponse is a response kept by the HTTP cache.
+type CachedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+
+	// Expires is the end of freshness; later the response is revalidated.
+	Expires time.Time `json:"expires"`
+
+	// Vary holds the request headers named by the Vary response header.
+	Vary map[string]string `json:"vary,omitempty"`
+}
+
+// CacheStore keeps cached responses; MemoryCache and RedisCache implement it.
+// Get returns nil without an error on a miss.
+type CacheStore interface {
+	Get(ctx context.Context, key string) (*CachedResponse, error)
+	Set(ctx context.Context, key string, resp *CachedResponse, ttl time.Duration) error
+	Delete(ctx context.Context, key string) error
+}
+
+// CacheTTL caches the response of this request for d, whatever its Cache-Control
+// or Expires headers say. It has no effect without WithCache.
+func (r *HTTPRequest[T]) CacheTTL(d time.Duration) *HTTPRequest[T] {
+	r.cacheTTL = d
+	return r
+}
+
+// cached serves a GET from the cache, revalidates a stale entry with its
+// ETag/Last-Modified, and stores cacheable responses. A successful unsafe
+// request invalidates the cached GET of its URL.
+func (r *HTTPRequest[T]) cached(ctx context.Context, isFormData bool, raw []byte, body string) (HTTPResponse[T], error) {
+	store := r.client.cache
+	key := http.MethodGet + " " + r.url
+
+	if r.method != http.MethodGet {
+		resp, err := r.dispatch(ctx, isFormData, raw, body)
+		if err == nil {
+			if err := store.Delete(ctx, key); err != nil {
+				log.Printf("[rest] cache delete %s: %v", key, err)
+			}
+		}
+		return resp, err
+	}
+
+	reqCC := parseCacheControl(r.header(headerCacheControl))
+	if reqCC.has("no-store") {
+		return r.dispatch(ctx, isFormData, raw, body)
+	}
+
+	entry, err := store.Get(ctx, key)
+	if err != nil {
+		log.Printf("[rest] cache get %s: %v", key, err)
+		entry = nil
+	}
+	if entry != nil && !r.varyMatches(entry) {
+		entry = nil
+	}
+	if entry != nil && !reqCC.has("no-cache") && time.Now().Before(entry.Expires) {
+		return r.fromCache(entry)
+	}
+
+	// revalidate the stale entry; the caller's own conditional headers win
+	var conditional []string
+	if entry != nil {
+		if etag := entry.Header.Get(headerETag); etag != "" && r.header(headerIfNoneMatch) == "" {
+			r.headers[headerIfNoneMatch] = etag
+			conditional = append(conditional, headerIfNoneMatch)
+		}
+		if lm := entry.Header.Get(headerLastModified); lm != "" && r.header(headerIfModifiedSince) == "" {
+			r.headers[headerIfModifiedSince] = lm
+			conditional = append(conditional, headerIfModifiedSince)
+		}
+	}
+
+	resp, err := r.dispatch(ctx, isFormData, raw, body)
+	for _, h := range conditional {
+		delete(r.headers, h)
+	}
+	if err != nil {
+		return resp, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && entry != nil && len(conditional) > 0 {
+		// the 304 headers refresh the stored ones (RFC 7234 4.3.4)
+		for k, v := range resp.Header {
+			entry.Header[k] = v
+		}
+		r.store(ctx, key, entry)
+
+		hit, err := r.fromCache(entry)
+		hit.Duration = resp.Duration
+		hit.Timing = resp.Timing
+		return hit, err
+	}
+
+	if isCacheableStatus(resp.StatusCode) {
+		r.store(ctx, key, &CachedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header,
+			Body:       resp.RawBody,
+		})
+	}
+	return resp, nil
+}
+
+// store computes the freshness of entry from its headers (or CacheTTL) and keeps
+// it while it is fresh, or for staleTTL more when it can be revalidated.
+// The key has no credentials, so the response to a request with Authorization
+// is only kept when the server marks it public (RFC 7234 3.2).
+func (r *HTTPRequest[T]) store(ctx context.Context, key string, entry *CachedResponse) {
+	now := time.Now()
+	cc := parseCacheControl(entry.Header.Get(headerCacheControl))
+	if r.header(headerAuthorization) != "" && !cc.has("public") {
+		return
+	}
+
+	var fresh time.Duration
+	switch {
+	case r.cacheTTL > 0:
+		fresh = r.cacheTTL
+	case cc.has("no-store"):
+		return
+	case cc.has("no-cache"):
+	case cc.has("max-age"):
+		fresh = cc.seconds("max-age") - headerSeconds(entry.Header, "Age")
+	default:
+		if exp, err := http.ParseTime(entry.Header.Get("Expires")); err == nil {
+			date, err := http.ParseTime(entry.Header.Get("Date"))
+			if err != nil {
+				date = now
+			}
+			fresh = exp.Sub(date)
+		}
+	}
+	if fresh < 0 {
+		fresh = 0
+	}
+
+	ttl := fresh
+	if entry.Header.Get(headerETag) != "" || entry.Header.Get(headerLastModified) != "" {
+		ttl += staleTTL
+	}
+	if ttl <= 0 {
+		return
+	}
+
+	vary := entry.Header.Values("Vary")
+	entry.Vary = nil
+	for _, v := range vary {
+		for _, name := range strings.Split(v, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+			if name == "*" {
+				return
+			}
+			if entry.Vary == nil {
+				entry.Vary = make(map[string]string)
+			}
+			entry.Vary[http.CanonicalHeaderKey(name)] = r.header(name)
+		}
+	}
+
+	entry.Expires = now.Add(fresh)
+	if err := r.client.cache.Set(ctx, key, entry, ttl); err != nil {
+		log.Printf("[rest] cache set %s: %v", key, err)
+	}
+}
+
+// fromCache builds the response of a cache hit.
+func (r *HTTPRequest[T]) fromCache(entry *CachedResponse) (HTTPResponse[T], error) {
+	resp := HTTPResponse[T]{
+		StatusCode: entry.StatusCode,
+		Header:     entry.Header,
+		RawBody:    entry.Body,
+		Body:       string(entry.Body),
+		HasBody:    len(entry.Body) > 0,
+		Duration:   time.Since(r.startTime),
+		Cached:     true,
+	}
+	if !resp.HasBody {
+		return resp, nil
+	}
+
+	data, err := r.decode(entry.Body)
+	if err != nil {
+		return resp, err
+	}
+	resp.Data = data
+	return resp, nil
+}
+
+// varyMatches reports whether the request has the Vary headers of entry.
+func (r *HTTPRequest[T]) varyMatches(entry *CachedResponse) bool {
+	for name, v := range entry.Vary {
+		if r.header(name) != v {
+			return false
+		}
+	}
+	return true
+}
+
+// header returns a request header set with Headers, case-insensitively.
+func (r *HTTPRequest[T]) header(name string) string {
+	if v, ok := r.headers[name]; ok {
+		return v
+	}
+	for k, v := range r.headers {
+		if strings.EqualFold(k, name) {
+			return v
+		}
+	}
+	return ""
+}
+
+func isCacheableStatus(status int) bool {
+	switch status {
+	case http.StatusOK, http.StatusNonAuthoritativeInfo, http.StatusNoContent:
+		return true
+	default:
+		return false
+	}
+}
+
+// cacheControl holds Cache-Control directives, lower-cased, with their values.
+type cacheControl map[string]string
+
+func parseCacheControl(v string) cacheControl {
+	cc := make(cacheControl)
+	for _, part := range strings.Split(v, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, value, _ := strings.Cut(part, "=")
+		cc[strings.ToLower(strings.TrimSpace(name))] = strings.Trim(strings.TrimSpace(value), `"`)
+	}
+	return cc
+}
+
+func (cc cacheControl) has(directive string) bool {
+	_, ok := cc[directive]
+	return ok
+}
+
+func (cc cacheControl) seconds(directive string) time.Duration {
+	n, err := strconv.Atoi(cc[directive])
+	if err != nil || n < 0 {
+		return 0
+	}
+	return time.Duration(n) * time.Second
+}
+
+func headerSeconds(h http.Header, name string) time.Duration {
+	n, err := strconv.Atoi(h.Get(name))
+	if err != nil || n < 0 {
+		return 0
+	}
+	return time.Duration(n) * time.Second
+}
@@ -0,0 +1,126 @@
+package rest
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/BevisDev/godev/redis"
+)
+
+const defaultCacheEntries = 1000
+
+// MemoryCache keeps up to maxEntries responses in process, evicting the least
+// recently used one.
+type MemoryCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	ll         *list.List
+	items      map[string]*list.Element
+}
+
+type memoryItem struct {
+	key       string
+	resp      *CachedResponse
+	expiresAt time.Time
+}
+
+// NewMemoryCache creates a MemoryCache (maxEntries <= 0 uses 1000).
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	if maxEntries <= 0 {
+		maxEntries = defaultCacheEntries
+	}
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		ll:         list.New(),
+		items:      make(map[string]*list.Element),
+	}
+}
+
+func (m *MemoryCache) Get(_ context.Context, key string) (*CachedResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	el, ok := m.items[key]
+	if !ok {
+		return nil, nil
+	}
+	item := el.Value.(*memoryItem)
+	if time.Now().After(item.expiresAt) {
+		m.remove(el)
+		return nil, nil
+	}
+	m.ll.MoveToFront(el)
+
+	// callers update the entry on revalidation, so hand out a copy
+	cp := *item.resp
+	cp.Header = item.resp.Header.Clone()
+	return &cp, nil
+}
+
+func (m *MemoryCache) Set(_ context.Context, key string, resp *CachedResponse, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	item := &memoryItem{key: key, resp: resp, expiresAt: time.Now().Add(ttl)}
+	if el, ok := m.items[key]; ok {
+		el.Value = item
+		m.ll.MoveToFront(el)
+		return nil
+	}
+
+	m.items[key] = m.ll.PushFront(item)
+	for m.ll.Len() > m.maxEntries {
+		m.remove(m.ll.Back())
+	}
+	return nil
+}
+
+func (m *MemoryCache) Delete(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if el, ok := m.items[key]; ok {
+		m.remove(el)
+	}
+	return nil
+}
+
+// Len returns the number of entries, including expired ones not yet evicted.
+func (m *MemoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.ll.Len()
+}
+
+func (m *MemoryCache) remove(el *list.Element) {
+	m.ll.Remove(el)
+	delete(m.items, el.Value.(*memoryItem).key)
+}
+
+// RedisCache keeps responses in Redis under "rest:cache:<method> <url>",
+// so instances of a service share them.
+type RedisCache struct {
+	cache *redis.Cache
+}
+
+func NewRedisCache(cache *redis.Cache) *RedisCache {
+	return &RedisCache{cache: cache}
+}
+
+func (r *RedisCache) Get(ctx context.Context, key string) (*CachedResponse, error) {
+	return redis.With[*CachedResponse](r.cache).Key(r.key(key)).Get(ctx)
+}
+
+func (r *RedisCache) Set(ctx context.Context, key string, resp *CachedResponse, ttl time.Duration) error {
+	return redis.With[*CachedResponse](r.cache).Key(r.key(key)).Value(resp).Expire(ttl).Set(ctx)
+}
+
+func (r *RedisCache) Delete(ctx context.Context, key string) error {
+	return redis.With[*CachedResponse](r.cache).Key(r.key(key)).Delete(ctx)
+}
+
+func (r *RedisCache) key(key string) string {
+	return "rest:cache:" + key
+}
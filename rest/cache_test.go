@@ -0,0 +1,219 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/BevisDev/godev/redis/redistest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func cacheServer(t *testing.T, handler func(w http.ResponseWriter, r *http.Request)) (*httptest.Server, *atomic.Int32) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func TestCache_MaxAge(t *testing.T) {
+	server, calls := cacheServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "public, max-age=60")
+		_, _ = fmt.Fprint(w, `{"message":"banks"}`)
+	})
+	c := New(WithCache(NewMemoryCache(0)))
+
+	for i := 0; i < 3; i++ {
+		resp, err := NewRequest[MockResponse](c).URL(server.URL).GET(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "banks", resp.Data.Message)
+		assert.Equal(t, i > 0, resp.Cached)
+	}
+	assert.Equal(t, int32(1), calls.Load())
+
+	// the caller can bypass the cache
+	_, err := NewRequest[MockResponse](c).URL(server.URL).
+		Headers(map[string]string{"cache-control": "no-cache"}).
+		GET(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestCache_ETagRevalidation(t *testing.T) {
+	server, calls := cacheServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Cache-Control", "no-cache")
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"message":"rates"}`)
+	})
+	c := New(WithCache(NewMemoryCache(0)))
+
+	first, err := NewRequest[MockResponse](c).URL(server.URL).GET(context.Background())
+	require.NoError(t, err)
+	assert.False(t, first.Cached)
+
+	second, err := NewRequest[MockResponse](c).URL(server.URL).GET(context.Background())
+	require.NoError(t, err)
+	assert.True(t, second.Cached)
+	assert.Equal(t, http.StatusOK, second.StatusCode)
+	assert.Equal(t, "rates", second.Data.Message)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestCache_NotStored(t *testing.T) {
+	server, calls := cacheServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/no-store":
+			w.Header().Set("Cache-Control", "no-store, max-age=60")
+		case "/error":
+			w.Header().Set("Cache-Control", "max-age=60")
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_, _ = fmt.Fprint(w, `{}`)
+	})
+	c := New(WithCache(NewMemoryCache(0)))
+
+	for _, path := range []string{"/no-store", "/no-store", "/plain", "/plain", "/error", "/error"} {
+		_, _ = NewRequest[MockResponse](c).URL(server.URL + path).GET(context.Background())
+	}
+	assert.Equal(t, int32(6), calls.Load())
+}
+
+func TestCache_Authorization(t *testing.T) {
+	server, calls := cacheServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/public" {
+			w.Header().Set("Cache-Control", "public, max-age=60")
+		} else {
+			w.Header().Set("Cache-Control", "max-age=60")
+		}
+		_, _ = fmt.Fprintf(w, `{"message":%q}`, r.Header.Get("Authorization"))
+	})
+	c := New(WithCache(NewMemoryCache(0)))
+
+	get := func(path, auth string) HTTPResponse[MockResponse] {
+		resp, err := NewRequest[MockResponse](c).URL(server.URL + path).
+			Headers(map[string]string{"Authorization": auth}).
+			CacheTTL(time.Minute).
+			GET(context.Background())
+		require.NoError(t, err)
+		return resp
+	}
+
+	// private responses to authorized requests are never stored
+	assert.Equal(t, "Bearer alice", get("/me", "Bearer alice").Data.Message)
+	bob := get("/me", "Bearer bob")
+	assert.False(t, bob.Cached)
+	assert.Equal(t, "Bearer bob", bob.Data.Message)
+	assert.Equal(t, int32(2), calls.Load())
+
+	// public ones are
+	get("/public", "Bearer alice")
+	assert.True(t, get("/public", "Bearer bob").Cached)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestCache_CacheTTL(t *testing.T) {
+	server, calls := cacheServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "no-cache")
+		_, _ = fmt.Fprint(w, `{"message":"provinces"}`)
+	})
+	c := New(WithCache(NewMemoryCache(0)))
+
+	for i := 0; i < 3; i++ {
+		resp, err := NewRequest[MockResponse](c).URL(server.URL).CacheTTL(time.Hour).GET(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "provinces", resp.Data.Message)
+	}
+	assert.Equal(t, int32(1), calls.Load())
+
+	// without a cache the override does nothing
+	for i := 0; i < 2; i++ {
+		_, err := NewRequest[MockResponse](client).URL(server.URL).CacheTTL(time.Hour).GET(context.Background())
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestCache_InvalidateOnWrite(t *testing.T) {
+	server, calls := cacheServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "max-age=60")
+		_, _ = fmt.Fprint(w, `{}`)
+	})
+	c := New(WithCache(NewMemoryCache(0)))
+	ctx := context.Background()
+
+	_, _ = NewRequest[MockResponse](c).URL(server.URL + "/config").GET(ctx)
+	_, _ = NewRequest[MockResponse](c).URL(server.URL + "/config").GET(ctx)
+	_, _ = NewRequest[MockResponse](c).URL(server.URL + "/config").Body(map[string]string{"a": "b"}).PUT(ctx)
+	_, _ = NewRequest[MockResponse](c).URL(server.URL + "/config").GET(ctx)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestCache_Vary(t *testing.T) {
+	server, calls := cacheServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "max-age=60")
+		w.Header().Set("Vary", "Accept-Language")
+		_, _ = fmt.Fprintf(w, `{"message":%q}`, r.Header.Get("Accept-Language"))
+	})
+	c := New(WithCache(NewMemoryCache(0)))
+	get := func(lang string) string {
+		resp, err := NewRequest[MockResponse](c).URL(server.URL).
+			Headers(map[string]string{"Accept-Language": lang}).
+			GET(context.Background())
+		require.NoError(t, err)
+		return resp.Data.Message
+	}
+
+	assert.Equal(t, "vi", get("vi"))
+	assert.Equal(t, "vi", get("vi"))
+	assert.Equal(t, "en", get("en"))
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestCache_Redis(t *testing.T) {
+	server, calls := cacheServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "max-age=60")
+		_, _ = fmt.Fprint(w, `{"message":"shared"}`)
+	})
+	rc := redistest.New(t)
+
+	for i := 0; i < 2; i++ {
+		// separate clients share the Redis cache
+		c := New(WithCache(NewRedisCache(rc.Cache)))
+		resp, err := NewRequest[MockResponse](c).URL(server.URL).GET(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "shared", resp.Data.Message)
+	}
+	assert.Equal(t, int32(1), calls.Load())
+	rc.AssertExists("rest:cache:GET " + server.URL)
+}
+
+func TestMemoryCache_Evicts(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemoryCache(2)
+
+	require.NoError(t, m.Set(ctx, "a", &CachedResponse{StatusCode: 200}, time.Minute))
+	require.NoError(t, m.Set(ctx, "b", &CachedResponse{StatusCode: 200}, time.Minute))
+	got, _ := m.Get(ctx, "a") // a is now the most recently used
+	require.NotNil(t, got)
+	require.NoError(t, m.Set(ctx, "c", &CachedResponse{StatusCode: 200}, time.Minute))
+
+	assert.Equal(t, 2, m.Len())
+	got, _ = m.Get(ctx, "b")
+	assert.Nil(t, got)
+
+	require.NoError(t, m.Set(ctx, "d", &CachedResponse{StatusCode: 200}, -time.Second))
+	got, _ = m.Get(ctx, "d")
+	assert.Nil(t, got)
+}
@@ -0,0 +1,167 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/BevisDev/godev/consts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// verbs lists every execution method so they cannot drift apart.
+var verbs = []struct {
+	name   string
+	method string
+	form   bool
+	call   func(*HTTPRequest[MockResponse], context.Context) (HTTPResponse[MockResponse], error)
+}{
+	{"GET", http.MethodGet, false, (*HTTPRequest[MockResponse]).GET},
+	{"POST", http.MethodPost, false, (*HTTPRequest[MockResponse]).POST},
+	{"PostForm", http.MethodPost, true, (*HTTPRequest[MockResponse]).PostForm},
+	{"PUT", http.MethodPut, false, (*HTTPRequest[MockResponse]).PUT},
+	{"PATCH", http.MethodPatch, false, (*HTTPRequest[MockResponse]).PATCH},
+	{"DELETE", http.MethodDelete, false, (*HTTPRequest[MockResponse]).DELETE},
+}
+
+func newConformanceRequest(url string, form bool) *HTTPRequest[MockResponse] {
+	req := NewRequest[MockResponse](client).
+		URL(url + "/items/:id").
+		PathParams(map[string]string{"id": "7"}).
+		QueryParams(map[string]string{"q": "x"}).
+		Headers(map[string]string{"X-Test": "1"})
+	if form {
+		return req.BodyForm(map[string]string{"k": "v"})
+	}
+	return req.Body(map[string]string{"k": "v"})
+}
+
+func TestConformance_Success(t *testing.T) {
+	for _, v := range verbs {
+		t.Run(v.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, v.method, r.Method)
+				assert.Equal(t, "/items/7", r.URL.Path)
+				assert.Equal(t, "x", r.URL.Query().Get("q"))
+				assert.Equal(t, "1", r.Header.Get("X-Test"))
+
+				body, _ := io.ReadAll(r.Body)
+				if v.form {
+					assert.Equal(t, consts.ApplicationFormData, r.Header.Get(consts.ContentType))
+					assert.Equal(t, "k=v", string(body))
+				} else {
+					assert.Equal(t, consts.ApplicationJSON, r.Header.Get(consts.ContentType))
+					assert.JSONEq(t, `{"k":"v"}`, string(body))
+				}
+
+				w.Header().Set(consts.ContentType, consts.ApplicationJSON)
+				_ = json.NewEncoder(w).Encode(MockResponse{Message: v.name})
+			}))
+			defer server.Close()
+
+			resp, err := v.call(newConformanceRequest(server.URL, v.form), context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, v.name, resp.Data.Message)
+			assert.True(t, resp.HasBody)
+			assert.NotEmpty(t, resp.RawBody)
+			assert.NotNil(t, resp.Header)
+			assert.Positive(t, resp.Duration)
+		})
+	}
+}
+
+func TestConformance_HTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("bad"))
+	}))
+	defer server.Close()
+
+	for _, v := range verbs {
+		t.Run(v.name, func(t *testing.T) {
+			resp, err := v.call(newConformanceRequest(server.URL, v.form), context.Background())
+			var httpErr *HTTPError
+			require.True(t, errors.As(err, &httpErr))
+			assert.Equal(t, http.StatusBadRequest, httpErr.Status)
+			assert.Equal(t, "bad", httpErr.Body)
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+			assert.Positive(t, resp.Duration)
+		})
+	}
+}
+
+func TestConformance_TransportError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	for _, v := range verbs {
+		t.Run(v.name, func(t *testing.T) {
+			resp, err := v.call(newConformanceRequest(url, v.form), context.Background())
+			require.Error(t, err)
+			assert.Positive(t, resp.Duration)
+		})
+	}
+}
+
+func TestRequestAlias(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "x", r.URL.Query().Get("q"))
+		assert.Equal(t, "1", r.Header.Get("X-Test"))
+		_ = json.NewEncoder(w).Encode(MockResponse{Message: "ok"})
+	}))
+	defer server.Close()
+
+	var req *Request[MockResponse] = NewRequest[MockResponse](client)
+	resp, err := req.URL(server.URL).
+		QueryParams(map[string]string{"q": "x"}).
+		Headers(map[string]string{"X-Test": "1"}).
+		GET(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "ok", resp.Data.Message)
+}
+
+// stubClient answers every request without a server.
+type stubClient struct {
+	requests []*http.Request
+}
+
+func (s *stubClient) Do(req *http.Request) (*http.Response, error) {
+	s.requests = append(s.requests, req)
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{consts.ContentType: {consts.ApplicationJSON}},
+		Body:       io.NopCloser(strings.NewReader(`{"message":"stubbed"}`)),
+		Request:    req,
+	}, nil
+}
+
+func TestConformance_HTTPClient(t *testing.T) {
+	stub := &stubClient{}
+	c := New(WithHTTPClient(stub))
+
+	for _, v := range verbs {
+		t.Run(v.name, func(t *testing.T) {
+			req := NewRequest[MockResponse](c).URL("http://api.test/items/:id").
+				PathParams(map[string]string{"id": "7"})
+			if v.form {
+				req.BodyForm(map[string]string{"k": "v"})
+			}
+			resp, err := v.call(req, context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, "stubbed", resp.Data.Message)
+
+			last := stub.requests[len(stub.requests)-1]
+			assert.Equal(t, v.method, last.Method)
+			assert.Equal(t, "/items/7", last.URL.Path)
+		})
+	}
+	assert.Len(t, stub.requests, len(verbs))
+}
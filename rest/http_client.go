@@ -16,10 +16,13 @@ import (
 	"github.com/BevisDev/godev/utils"
 	"github.com/BevisDev/godev/utils/datetime"
 	"github.com/BevisDev/godev/utils/jsonx"
+	"github.com/BevisDev/godev/utils/propagation"
 	"github.com/BevisDev/godev/utils/str"
 	"github.com/BevisDev/godev/utils/validate"
 )
 
+// HTTPRequest builds and executes a single request whose response body is
+// decoded into T. Every HTTP method goes through the same implementation.
 type HTTPRequest[T any] struct {
 	client *Client
 
@@ -29,6 +32,9 @@ type HTTPRequest[T any] struct {
 	// queryParams contains query parameters to be appended to the URL (?key=value).
 	queryParams map[string]string
 
+	// queryValues contains multi-valued query parameters set by Params.
+	queryValues url.Values
+
 	// pathParams contains path parameters to replace placeholders in the URL (e.g., ":id").
 	pathParams map[string]string
 
@@ -51,18 +57,38 @@ type HTTPRequest[T any] struct {
 
 	// startTime time begin request
 	startTime time.Time
+
+	// err is a build error (e.g. from Params) returned on execution.
+	err error
+
+	// timing of the last attempt, set with WithTiming
+	timing *Timing
+
+	// cacheTTL overrides the freshness of the cached response (see CacheTTL)
+	cacheTTL time.Duration
+
+	// encoding of the body and response, set with XML or SOAP (default JSON)
+	encoding bodyEncoding
+
+	// soapAction is the operation called with SOAP
+	soapAction string
 }
 
+// HTTPResponse is the result of an HTTPRequest. Duration is always set,
+// including when the request fails.
 type HTTPResponse[T any] struct {
 	StatusCode int
 	Header     http.Header
 	Data       T
 	Duration   time.Duration
+	Timing     *Timing // set with WithTiming
+	Cached     bool    // served from the cache (see WithCache)
 	RawBody    []byte
 	Body       string
 	HasBody    bool
 }
 
+// NewRequest creates a request on c, or on a default client when c is nil.
 func NewRequest[T any](c *Client) *HTTPRequest[T] {
 	if c == nil {
 		c = New()
@@ -134,6 +160,10 @@ func (r *HTTPRequest[T]) DELETE(c context.Context) (HTTPResponse[T], error) {
 }
 
 func (r *HTTPRequest[T]) restTemplate(c context.Context) (HTTPResponse[T], error) {
+	if r.err != nil {
+		return HTTPResponse[T]{}, r.err
+	}
+
 	// set metadata
 	r.rid = utils.GetRID(c)
 	r.startTime = time.Now()
@@ -141,6 +171,7 @@ func (r *HTTPRequest[T]) restTemplate(c context.Context) (HTTPResponse[T], error
 	// determine HTTPRequest shape and prepare URL/body/headers
 	isFormData := !validate.IsNilOrEmpty(r.bodyForm)
 	r.setContentType(isFormData)
+	r.setSOAPAction()
 	r.buildURL()
 
 	// serialise body for transport and logging
@@ -149,12 +180,46 @@ func (r *HTTPRequest[T]) restTemplate(c context.Context) (HTTPResponse[T], error
 		return HTTPResponse[T]{}, err
 	}
 
-	// log HTTPRequest
-	r.logRequest(body)
-
 	ctx, cancel := utils.NewCtxTimeout(c, r.client.timeout)
 	defer cancel()
 
+	if r.client.cache != nil {
+		return r.cached(ctx, isFormData, raw, body)
+	}
+	return r.dispatch(ctx, isFormData, raw, body)
+}
+
+// dispatch sends the request, balanced over the base URLs of the client.
+func (r *HTTPRequest[T]) dispatch(ctx context.Context, isFormData bool, raw []byte, body string) (HTTPResponse[T], error) {
+	lb := r.client.balancer
+	if lb == nil || lb.size() == 0 || isAbsoluteURL(r.url) {
+		return r.send(ctx, isFormData, raw, body)
+	}
+
+	// balance over base URLs; a host that refused the connection is skipped
+	// for the next one since the request never reached it
+	path := r.url
+	tried := make(map[*host]struct{}, lb.size())
+	for {
+		h := lb.pick(tried)
+		r.url = h.resolve(path)
+
+		resp, err := r.send(ctx, isFormData, raw, body)
+		lb.report(h, resp.StatusCode, err)
+
+		tried[h] = struct{}{}
+		if err != nil && isDialError(err) && len(tried) < lb.size() && ctx.Err() == nil {
+			continue
+		}
+		return resp, err
+	}
+}
+
+// send logs, builds and executes the request against r.url.
+func (r *HTTPRequest[T]) send(ctx context.Context, isFormData bool, raw []byte, body string) (HTTPResponse[T], error) {
+	// log HTTPRequest
+	r.logRequest(body)
+
 	// create HTTPRequest
 	request, err := r.createHTTPRequest(ctx, isFormData, raw, body)
 	if err != nil {
@@ -164,6 +229,17 @@ func (r *HTTPRequest[T]) restTemplate(c context.Context) (HTTPResponse[T], error
 	// set headers
 	r.setHeaders(request)
 
+	// sign the final request
+	if r.client.signer != nil {
+		signed := raw
+		if isFormData {
+			signed = []byte(body)
+		}
+		if err := r.client.signer.SignRequest(request, signed); err != nil {
+			return HTTPResponse[T]{}, err
+		}
+	}
+
 	// Execute the HTTP HTTPRequest
 	return r.execute(request)
 }
@@ -182,6 +258,11 @@ func (r *HTTPRequest[T]) serializeBody(isFormData bool) ([]byte, string, error)
 		return nil, formValues.Encode(), nil
 	}
 
+	// CASE: XML and SOAP
+	if r.encoding != encodingJSON {
+		return r.serializeXML()
+	}
+
 	// CASE: []byte and JSON
 	if !validate.IsNilOrEmpty(r.body) {
 		switch b := r.body.(type) {
@@ -259,17 +340,33 @@ func (r *HTTPRequest[T]) logRequest(body string) {
 }
 
 func (r *HTTPRequest[T]) execute(request *http.Request) (HTTPResponse[T], error) {
-	client := r.client.GetClient()
-	response, err := client.Do(request)
+	var timing *timingTrace
+	r.timing = nil
+	request = r.client.stats.trace(request)
+	if r.client.timing {
+		timing = new(timingTrace)
+		request = timing.trace(request)
+	}
+
+	start := time.Now()
+	response, err := r.client.doer.Do(request)
+	r.client.meter.record(request, start, response)
 	if err != nil {
-		return HTTPResponse[T]{}, err
+		return HTTPResponse[T]{Duration: time.Since(r.startTime)}, err
 	}
 	defer response.Body.Close()
 
 	// READ BODY
 	raw, err := io.ReadAll(response.Body)
 	if err != nil {
-		return HTTPResponse[T]{}, err
+		return HTTPResponse[T]{
+			StatusCode: response.StatusCode,
+			Header:     response.Header,
+			Duration:   time.Since(r.startTime),
+		}, err
+	}
+	if timing != nil {
+		r.timing = timing.done()
 	}
 
 	// BUILD RESPONSE
@@ -280,24 +377,29 @@ func (r *HTTPRequest[T]) execute(request *http.Request) (HTTPResponse[T], error)
 		Header:     response.Header,
 		HasBody:    len(raw) > 0,
 		Duration:   time.Since(r.startTime),
+		Timing:     r.timing,
 	}
 
 	// log response
-	r.logResponse(response, resp.Body)
+	r.logResponse(response, resp.Body, resp.Duration)
 
 	// check error
 	if resp.StatusCode >= 400 {
-		return resp, &HTTPError{
+		httpErr := &HTTPError{
 			Status: resp.StatusCode,
 			Body:   resp.Body,
 		}
+		if fault := r.soapFault(raw, httpErr); fault != nil {
+			return resp, fault
+		}
+		return resp, httpErr
 	}
 
 	if !resp.HasBody {
 		return resp, nil
 	}
 
-	result, err := utils.ValueFromBytes[T](raw)
+	result, err := r.decode(raw)
 	if err != nil {
 		return resp, err
 	}
@@ -306,12 +408,15 @@ func (r *HTTPRequest[T]) execute(request *http.Request) (HTTPResponse[T], error)
 	return resp, nil
 }
 
-func (r *HTTPRequest[T]) logResponse(response *http.Response, body string) {
+func (r *HTTPRequest[T]) logResponse(response *http.Response, body string, duration time.Duration) {
 	if r.client.useLog {
 		respLogger := &logger.ResponseLogger{
 			RID:      r.rid,
 			Status:   response.StatusCode,
-			Duration: time.Since(r.startTime),
+			Duration: duration,
+		}
+		if r.timing != nil {
+			respLogger.Timing = r.timing
 		}
 		if !r.client.skipHeader {
 			respLogger.Header = response.Header
@@ -325,7 +430,10 @@ func (r *HTTPRequest[T]) logResponse(response *http.Response, body string) {
 		sb.WriteString("\n========== RESPONSE INFO ==========\n")
 		fmt.Fprintf(&sb, "%s: %s\n", consts.RID, r.rid)
 		fmt.Fprintf(&sb, "%s: %d\n", consts.Status, response.StatusCode)
-		fmt.Fprintf(&sb, "%s: %s\n", consts.Duration, time.Since(r.startTime))
+		fmt.Fprintf(&sb, "%s: %s\n", consts.Duration, duration)
+		if r.timing != nil {
+			fmt.Fprintf(&sb, "%s: %s\n", consts.Timing, r.timing)
+		}
 		if !r.client.skipHeader {
 			fmt.Fprintf(&sb, "%s: %s\n", consts.Header, response.Header)
 		}
@@ -389,11 +497,16 @@ func (r *HTTPRequest[T]) buildURL() {
 		}
 	}
 
-	if !validate.IsNilOrEmpty(r.queryParams) {
+	if !validate.IsNilOrEmpty(r.queryParams) || len(r.queryValues) > 0 {
 		q := url.Values{}
 		for k, v := range r.queryParams {
 			q.Add(k, v)
 		}
+		for k, vals := range r.queryValues {
+			for _, v := range vals {
+				q.Add(k, v)
+			}
+		}
 
 		if strings.Contains(r.url, "?") {
 			r.url += "&" + q.Encode()
@@ -409,9 +522,12 @@ func (r *HTTPRequest[T]) setContentType(isFormData bool) {
 	}
 
 	if r.headers[consts.ContentType] == "" {
-		if isFormData {
+		switch {
+		case isFormData:
 			r.headers[consts.ContentType] = consts.ApplicationFormData
-		} else {
+		case r.encoding != encodingJSON:
+			r.headers[consts.ContentType] = r.xmlContentType()
+		default:
 			r.headers[consts.ContentType] = consts.ApplicationJSON
 		}
 	}
@@ -421,4 +537,12 @@ func (r *HTTPRequest[T]) setHeaders(rq *http.Request) {
 	for key, value := range r.headers {
 		rq.Header.Set(key, value)
 	}
+
+	// forward the request ID, trace context and correlation headers of the caller
+	if r.client.propagate {
+		propagation.Inject(rq.Context(), rq.Header)
+		if rq.Header.Get(consts.XRequestID) == "" {
+			rq.Header.Set(consts.XRequestID, r.rid)
+		}
+	}
 }
@@ -0,0 +1,193 @@
+package httptestx
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"time"
+)
+
+// Expectation is an expected request and its response, built by Server.Expect.
+// The request matchers and response setters are chained:
+//
+//	srv.Expect(http.MethodGet, "/users/1").
+//		Query("lang", "en").
+//		Delay(100 * time.Millisecond).
+//		Respond(http.StatusOK, User{ID: 1})
+type Expectation struct {
+	// request
+	method   string
+	path     string
+	headers  map[string]string
+	query    map[string]string
+	matchers []func([]byte) bool
+	times    int // 0 or less: any number of times
+	calls    int
+
+	// response
+	status int
+	header http.Header
+	body   []byte
+	delay  time.Duration
+	fail   bool
+}
+
+func (e *Expectation) String() string {
+	return e.method + " " + e.path
+}
+
+// Header requires the request header key to equal value.
+func (e *Expectation) Header(key, value string) *Expectation {
+	if e.headers == nil {
+		e.headers = make(map[string]string)
+	}
+	e.headers[key] = value
+	return e
+}
+
+// Query requires the query parameter key to equal value.
+func (e *Expectation) Query(key, value string) *Expectation {
+	if e.query == nil {
+		e.query = make(map[string]string)
+	}
+	e.query[key] = value
+	return e
+}
+
+// Body requires the request body to equal body.
+func (e *Expectation) Body(body string) *Expectation {
+	return e.MatchBody(func(b []byte) bool {
+		return string(b) == body
+	})
+}
+
+// JSONBody requires the request body to be JSON equal to v, regardless of key order.
+func (e *Expectation) JSONBody(v any) *Expectation {
+	want, err := normalizeJSON(v)
+	return e.MatchBody(func(b []byte) bool {
+		var got any
+		return err == nil && json.Unmarshal(b, &got) == nil && reflect.DeepEqual(want, got)
+	})
+}
+
+// MatchBody requires fn to accept the request body.
+func (e *Expectation) MatchBody(fn func(body []byte) bool) *Expectation {
+	e.matchers = append(e.matchers, fn)
+	return e
+}
+
+// Times expects the request n times (default 1).
+func (e *Expectation) Times(n int) *Expectation {
+	e.times = n
+	return e
+}
+
+// AnyTimes lets the request match any number of times, including never.
+func (e *Expectation) AnyTimes() *Expectation {
+	e.times = 0
+	return e
+}
+
+// Respond sets the response status and body. A string or []byte body is sent as is,
+// any other value as JSON with a JSON Content-Type. The default response is an empty 200.
+func (e *Expectation) Respond(status int, body any) *Expectation {
+	e.status = status
+	switch b := body.(type) {
+	case nil:
+		e.body = nil
+	case string:
+		e.body = []byte(b)
+	case []byte:
+		e.body = b
+	default:
+		data, err := json.Marshal(b)
+		if err != nil {
+			panic("httptestx: response body: " + err.Error())
+		}
+		e.body = data
+		if e.header.Get("Content-Type") == "" {
+			e.header.Set("Content-Type", "application/json")
+		}
+	}
+	return e
+}
+
+// RespondHeader adds a response header.
+func (e *Expectation) RespondHeader(key, value string) *Expectation {
+	e.header.Add(key, value)
+	return e
+}
+
+// Delay waits d before responding, or until the client gives up.
+func (e *Expectation) Delay(d time.Duration) *Expectation {
+	e.delay = d
+	return e
+}
+
+// Fail closes the connection without a response, as a network failure.
+func (e *Expectation) Fail() *Expectation {
+	e.fail = true
+	return e
+}
+
+func (e *Expectation) matches(r *http.Request, body []byte) bool {
+	if r.Method != e.method || r.URL.Path != e.path {
+		return false
+	}
+	for k, v := range e.headers {
+		if r.Header.Get(k) != v {
+			return false
+		}
+	}
+	q := r.URL.Query()
+	for k, v := range e.query {
+		if q.Get(k) != v {
+			return false
+		}
+	}
+	for _, fn := range e.matchers {
+		if !fn(body) {
+			return false
+		}
+	}
+	return true
+}
+
+func (e *Expectation) respond(w http.ResponseWriter, r *http.Request) {
+	if e.delay > 0 {
+		select {
+		case <-time.After(e.delay):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	if e.fail {
+		if hj, ok := w.(http.Hijacker); ok {
+			if conn, _, err := hj.Hijack(); err == nil {
+				_ = conn.Close()
+				return
+			}
+		}
+		panic(http.ErrAbortHandler)
+	}
+
+	for k, values := range e.header {
+		for _, v := range values {
+			w.Header().Add(k, v)
+		}
+	}
+	w.WriteHeader(e.status)
+	_, _ = w.Write(e.body)
+}
+
+// normalizeJSON converts v to the generic form of encoding/json (maps, slices, float64).
+func normalizeJSON(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out any
+	err = json.Unmarshal(data, &out)
+	return out, err
+}
@@ -0,0 +1,138 @@
+// Package httptestx is a mock HTTP server for tests of API clients: declare the expected
+// requests and their canned responses, and unmet expectations fail the test.
+//
+//	srv := httptestx.NewServer(t)
+//	srv.Expect(http.MethodPost, "/users").
+//		Header("Authorization", "Bearer token").
+//		JSONBody(map[string]any{"name": "Alice"}).
+//		Respond(http.StatusCreated, User{ID: 1, Name: "Alice"})
+//
+//	client := NewUserClient(srv.URL)
+package httptestx
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Server is an httptest.Server answering the expected requests, closed when the test ends.
+type Server struct {
+	*httptest.Server
+
+	t            testing.TB
+	mu           sync.Mutex
+	expectations []*Expectation
+	requests     []*Request
+}
+
+// Request is a request received by the server.
+type Request struct {
+	Method string
+	Path   string
+	Query  string
+	Header http.Header
+	Body   []byte
+}
+
+func (r *Request) String() string {
+	if r.Query == "" {
+		return r.Method + " " + r.Path
+	}
+	return r.Method + " " + r.Path + "?" + r.Query
+}
+
+// NewServer starts a server for the test. On cleanup it is closed and every
+// expectation must have been met (see AssertExpectations).
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+
+	s := &Server{t: t}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(func() {
+		s.Close()
+		s.AssertExpectations()
+	})
+	return s
+}
+
+// Expect adds an expected request. Expectations are matched in the order they were added;
+// each matches once unless Times or AnyTimes is set.
+func (s *Server) Expect(method, path string) *Expectation {
+	e := &Expectation{
+		method: strings.ToUpper(method),
+		path:   path,
+		times:  1,
+		status: http.StatusOK,
+		header: make(http.Header),
+	}
+
+	s.mu.Lock()
+	s.expectations = append(s.expectations, e)
+	s.mu.Unlock()
+	return e
+}
+
+// Requests returns the requests received so far, matched or not.
+func (s *Server) Requests() []*Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*Request(nil), s.requests...)
+}
+
+// AssertExpectations fails the test for each expectation matched fewer times than expected.
+func (s *Server) AssertExpectations() bool {
+	s.t.Helper()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ok := true
+	for _, e := range s.expectations {
+		if e.times > 0 && e.calls < e.times {
+			s.t.Errorf("httptestx: expected %s %d time(s), got %d", e, e.times, e.calls)
+			ok = false
+		}
+	}
+	return ok
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	req := &Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.RawQuery,
+		Header: r.Header.Clone(),
+		Body:   body,
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	s.mu.Lock()
+	s.requests = append(s.requests, req)
+	e := s.match(r, body)
+	s.mu.Unlock()
+
+	if e == nil {
+		s.t.Errorf("httptestx: unexpected request %s", req)
+		http.Error(w, fmt.Sprintf("httptestx: unexpected request %s", req), http.StatusNotImplemented)
+		return
+	}
+	e.respond(w, r)
+}
+
+// match returns the first expectation matching r that has calls left, counting the call.
+func (s *Server) match(r *http.Request, body []byte) *Expectation {
+	for _, e := range s.expectations {
+		if (e.times <= 0 || e.calls < e.times) && e.matches(r, body) {
+			e.calls++
+			return e
+		}
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package httptestx
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/BevisDev/godev/rest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeT records the failures of a server instead of failing the test.
+type fakeT struct {
+	testing.TB
+	mu       sync.Mutex
+	errors   []string
+	cleanups []func()
+}
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Errorf(format string, args ...any) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func (f *fakeT) Cleanup(fn func()) {
+	f.cleanups = append(f.cleanups, fn)
+}
+
+func (f *fakeT) finish() {
+	for i := len(f.cleanups) - 1; i >= 0; i-- {
+		f.cleanups[i]()
+	}
+}
+
+type user struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestServer_RestClient(t *testing.T) {
+	srv := NewServer(t)
+	srv.Expect(http.MethodPost, "/users").
+		Header("Authorization", "Bearer token").
+		JSONBody(map[string]any{"name": "Alice", "id": 0}).
+		Respond(http.StatusCreated, user{ID: 1, Name: "Alice"})
+	srv.Expect(http.MethodGet, "/users/1").
+		Query("lang", "en").
+		Respond(http.StatusOK, user{ID: 1, Name: "Alice"})
+
+	client := rest.New()
+	ctx := context.Background()
+
+	created, err := rest.NewRequest[user](client).
+		URL(srv.URL + "/users").
+		Headers(map[string]string{"Authorization": "Bearer token"}).
+		Body(user{Name: "Alice"}).
+		POST(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, created.StatusCode)
+	assert.Equal(t, user{ID: 1, Name: "Alice"}, created.Data)
+
+	got, err := rest.NewRequest[user](client).
+		URL(srv.URL + "/users/1").
+		QueryParams(map[string]string{"lang": "en"}).
+		GET(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", got.Data.Name)
+
+	require.Len(t, srv.Requests(), 2)
+	assert.Equal(t, "GET /users/1?lang=en", srv.Requests()[1].String())
+}
+
+func TestServer_Times(t *testing.T) {
+	srv := NewServer(t)
+	srv.Expect(http.MethodGet, "/health").Times(2).Respond(http.StatusOK, "ok")
+	srv.Expect(http.MethodGet, "/health").AnyTimes().Respond(http.StatusServiceUnavailable, "down")
+
+	var statuses []int
+	for i := 0; i < 3; i++ {
+		resp, err := http.Get(srv.URL + "/health")
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		statuses = append(statuses, resp.StatusCode)
+	}
+	assert.Equal(t, []int{http.StatusOK, http.StatusOK, http.StatusServiceUnavailable}, statuses)
+}
+
+func TestServer_UnexpectedAndUnmet(t *testing.T) {
+	ft := &fakeT{TB: t}
+	srv := NewServer(ft)
+	srv.Expect(http.MethodPost, "/orders").Body(`{"id":1}`)
+
+	resp, err := http.Post(srv.URL+"/orders", "application/json", strings.NewReader(`{"id":2}`))
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusNotImplemented, resp.StatusCode)
+
+	ft.finish()
+	assert.Equal(t, []string{
+		"httptestx: unexpected request POST /orders",
+		"httptestx: expected POST /orders 1 time(s), got 0",
+	}, ft.errors)
+}
+
+func TestServer_DelayAndFail(t *testing.T) {
+	srv := NewServer(t)
+	srv.Expect(http.MethodGet, "/slow").Delay(200*time.Millisecond).Respond(http.StatusOK, "late")
+	srv.Expect(http.MethodGet, "/broken").Fail()
+
+	client := &http.Client{Timeout: 50 * time.Millisecond}
+	_, err := client.Get(srv.URL + "/slow")
+	assert.Error(t, err)
+
+	resp, err := http.Get(srv.URL + "/broken")
+	if err == nil {
+		_, err = io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+	}
+	assert.Error(t, err)
+}
+
+func TestServer_RespondHeader(t *testing.T) {
+	srv := NewServer(t)
+	srv.Expect(http.MethodGet, "/file").
+		RespondHeader("Content-Type", "text/csv").
+		Respond(http.StatusOK, []byte("id,name\n1,Alice\n"))
+
+	resp, err := http.Get(srv.URL + "/file")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, "text/csv", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "id,name\n1,Alice\n", string(body))
+}
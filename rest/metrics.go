@@ -0,0 +1,40 @@
+package rest
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/BevisDev/godev/metrics"
+)
+
+// clientMetrics are the metrics recorded with WithMetrics.
+type clientMetrics struct {
+	requests metrics.Timer
+}
+
+func newClientMetrics(p metrics.Provider) *clientMetrics {
+	if p == nil {
+		return nil
+	}
+	return &clientMetrics{
+		requests: p.Timer(metrics.Opts{
+			Name:   "http_client_request_duration_seconds",
+			Help:   "Duration of outgoing HTTP requests.",
+			Labels: []string{"host", "method", "status"},
+		}),
+	}
+}
+
+// record records a request; the status is the response code, or "error"
+// when no response was received.
+func (m *clientMetrics) record(req *http.Request, start time.Time, resp *http.Response) {
+	if m == nil {
+		return
+	}
+	status := metrics.StatusError
+	if resp != nil {
+		status = strconv.Itoa(resp.StatusCode)
+	}
+	m.requests.Record(time.Since(start), req.URL.Host, req.Method, status)
+}
@@ -0,0 +1,47 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/BevisDev/godev/metrics/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Metrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"message":"ok"}`))
+	}))
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	reg := prometheus.New()
+	c := New(WithMetrics(reg))
+
+	_, err := NewRequest[MockResponse](c).URL(server.URL).GET(context.Background())
+	require.NoError(t, err)
+	_, err = NewRequest[MockResponse](c).URL(server.URL + "/missing").GET(context.Background())
+	assert.Error(t, err)
+
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+	_, err = NewRequest[MockResponse](c).URL(closed.URL).GET(context.Background())
+	assert.Error(t, err)
+	u, _ := url.Parse(closed.URL)
+
+	var out strings.Builder
+	_, err = reg.WriteTo(&out)
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), `http_client_request_duration_seconds_count{host="`+host+`",method="GET",status="200"} 1`)
+	assert.Contains(t, out.String(), `http_client_request_duration_seconds_count{host="`+host+`",method="GET",status="404"} 1`)
+	assert.Contains(t, out.String(), `http_client_request_duration_seconds_count{host="`+u.Host+`",method="GET",status="error"} 1`)
+}
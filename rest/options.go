@@ -1,9 +1,11 @@
 package rest
 
 import (
+	"net/http"
 	"time"
 
 	"github.com/BevisDev/godev/logger"
+	"github.com/BevisDev/godev/metrics"
 )
 
 const defaultClientTimeout = 5 * time.Second
@@ -31,6 +33,44 @@ type options struct {
 
 	// skipDefaultContentTypeCheck disables the default content-type based body logging checks.
 	skipDefaultContentTypeCheck bool
+
+	// endpoints are the base URLs relative request URLs are balanced over.
+	endpoints []Endpoint
+
+	// strategy selects the balancing strategy across endpoints.
+	strategy Strategy
+
+	// maxFailures consecutive failures eject a host for ejectFor.
+	maxFailures int
+	ejectFor    time.Duration
+
+	// transport replaces the transport of the HTTP client (e.g. a Recorder).
+	transport http.RoundTripper
+
+	// httpClient replaces the HTTP client that sends the requests.
+	httpClient HTTPClient
+
+	// connection pool of the default transport, applied when tunePool is set.
+	tunePool          bool
+	maxIdleConns      int
+	maxConnsPerHost   int
+	idleConnTimeout   time.Duration
+	disableKeepAlives bool
+
+	// timing captures a per-request latency breakdown (HTTPResponse.Timing).
+	timing bool
+
+	// propagate forwards the propagated headers of the request context (default true).
+	propagate bool
+
+	// cache stores GET responses, set with WithCache.
+	cache CacheStore
+
+	// signer signs every request after its headers are set.
+	signer RequestSigner
+
+	// metrics records the outgoing requests, set with WithMetrics.
+	metrics metrics.Provider
 }
 
 func withDefaults() *options {
@@ -38,6 +78,9 @@ func withDefaults() *options {
 		timeout:                defaultClientTimeout,
 		skipBodyByPaths:        make(map[string]struct{}),
 		skipBodyByContentTypes: make(map[string]struct{}),
+		maxFailures:            defaultMaxFailures,
+		ejectFor:               defaultEjectFor,
+		propagate:              true,
 	}
 }
 
@@ -85,3 +128,141 @@ func WithSkipDefaultContentTypeCheck() Option {
 		o.skipDefaultContentTypeCheck = true
 	}
 }
+
+// WithBaseURL sets the base URL prepended to relative request URLs (e.g. "/users/:id").
+func WithBaseURL(baseURL string) Option {
+	return WithBaseURLs(baseURL)
+}
+
+// WithBaseURLs balances relative request URLs over several base URLs with equal weight.
+// Absolute request URLs are sent as-is.
+func WithBaseURLs(baseURLs ...string) Option {
+	return func(o *options) {
+		for _, u := range baseURLs {
+			o.endpoints = append(o.endpoints, Endpoint{URL: u, Weight: 1})
+		}
+	}
+}
+
+// WithEndpoints balances relative request URLs over weighted endpoints (see Weighted).
+func WithEndpoints(endpoints ...Endpoint) Option {
+	return func(o *options) {
+		o.endpoints = append(o.endpoints, endpoints...)
+	}
+}
+
+// WithBalancer sets the balancing strategy (default RoundRobin).
+func WithBalancer(strategy Strategy) Option {
+	return func(o *options) {
+		o.strategy = strategy
+	}
+}
+
+// WithEjection configures passive health checking: a host failing maxFailures times
+// in a row (transport error or 5xx) is skipped for ejectFor (default 3 failures, 30s).
+func WithEjection(maxFailures int, ejectFor time.Duration) Option {
+	return func(o *options) {
+		if maxFailures > 0 {
+			o.maxFailures = maxFailures
+		}
+		if ejectFor > 0 {
+			o.ejectFor = ejectFor
+		}
+	}
+}
+
+// WithTransport sets the transport of the HTTP client, e.g. a Recorder in tests.
+// The pool options below are then ignored.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(o *options) {
+		o.transport = rt
+	}
+}
+
+// WithHTTPClient sends the requests with hc instead of the client built by New,
+// e.g. a stub in tests. The transport options then only apply to GetClient.
+func WithHTTPClient(hc HTTPClient) Option {
+	return func(o *options) {
+		o.httpClient = hc
+	}
+}
+
+// WithMaxIdleConns keeps up to n idle connections, in total and per host
+// (http.DefaultTransport keeps only 2 per host, which causes connection churn under load).
+func WithMaxIdleConns(n int) Option {
+	return func(o *options) {
+		o.tunePool = true
+		o.maxIdleConns = n
+	}
+}
+
+// WithMaxConnsPerHost limits the connections per host, including those in use (default unlimited).
+func WithMaxConnsPerHost(n int) Option {
+	return func(o *options) {
+		o.tunePool = true
+		o.maxConnsPerHost = n
+	}
+}
+
+// WithIdleConnTimeout closes connections idle for longer than d (default 90s).
+func WithIdleConnTimeout(d time.Duration) Option {
+	return func(o *options) {
+		o.tunePool = true
+		o.idleConnTimeout = d
+	}
+}
+
+// WithDisableKeepAlives opens a new connection for every request.
+func WithDisableKeepAlives() Option {
+	return func(o *options) {
+		o.tunePool = true
+		o.disableKeepAlives = true
+	}
+}
+
+// WithTiming captures the DNS, connect, TLS, TTFB and total durations of every request
+// in HTTPResponse.Timing and the response log.
+func WithTiming() Option {
+	return func(o *options) {
+		o.timing = true
+	}
+}
+
+// WithoutPropagation stops forwarding X-Request-ID, traceparent and the correlation
+// headers captured by ginfw/middleware/propagation on outgoing requests.
+func WithoutPropagation() Option {
+	return func(o *options) {
+		o.propagate = false
+	}
+}
+
+// WithCache caches GET responses in store, honoring Cache-Control, Expires and
+// ETag/Last-Modified revalidation (see CacheTTL to override the freshness per request).
+func WithCache(store CacheStore) Option {
+	return func(o *options) {
+		o.cache = store
+	}
+}
+
+// RequestSigner signs an outgoing request whose body is body, e.g. by
+// setting signature headers. signing.Signer implements it.
+type RequestSigner interface {
+	SignRequest(req *http.Request, body []byte) error
+}
+
+// WithSigner signs every request with s, e.g. for partner APIs requiring
+// HMAC or RSA request signatures.
+func WithSigner(s RequestSigner) Option {
+	return func(o *options) {
+		o.signer = s
+	}
+}
+
+// WithMetrics records the duration of every request on p, as
+// http_client_request_duration_seconds labeled by host, method and status
+// (the response code, or "error" when the request failed).
+func WithMetrics(p metrics.Provider) Option {
+	return func(o *options) {
+		o.metrics = p
+	}
+}
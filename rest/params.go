@@ -0,0 +1,207 @@
+package rest
+
+import (
+	"encoding"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Params fills the path and query parameters from the tagged fields of a struct:
+//
+//	type ListOrders struct {
+//		ShopID int       `path:"shop_id"`
+//		Status []string  `query:"status,omitempty"`          // ?status=a&status=b
+//		From   time.Time `query:"from,omitempty" layout:"2006-01-02"`
+//		Page   int       `query:"page"`
+//	}
+//
+//	rest.NewRequest[[]Order](client).URL("/shops/:shop_id/orders").Params(ListOrders{...})
+//
+// Values are strings, numbers, bools, time.Time (RFC 3339 unless a layout tag is set),
+// fmt.Stringer or encoding.TextMarshaler, pointers to them and slices of them.
+// Nil pointers are skipped; "omitempty" also skips zero values. Path values are escaped.
+// They are added to the PathParams and QueryParams of the request.
+func (r *HTTPRequest[T]) Params(v any) *HTTPRequest[T] {
+	path, query, err := encodeParams(v)
+	if err != nil {
+		r.err = err
+		return r
+	}
+
+	if r.pathParams == nil {
+		r.pathParams = make(map[string]string, len(path))
+	}
+	for k, val := range path {
+		r.pathParams[k] = val
+	}
+
+	if r.queryValues == nil {
+		r.queryValues = url.Values{}
+	}
+	for k, vals := range query {
+		r.queryValues[k] = append(r.queryValues[k], vals...)
+	}
+	return r
+}
+
+// encodeParams reads the `path` and `query` tagged fields of the struct v.
+func encodeParams(v any) (map[string]string, url.Values, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil, nil, nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, nil, fmt.Errorf("[rest] params must be a struct, got %T", v)
+	}
+
+	path := make(map[string]string)
+	query := url.Values{}
+	if err := collectParams(rv, path, query); err != nil {
+		return nil, nil, err
+	}
+	return path, query, nil
+}
+
+func collectParams(rv reflect.Value, path map[string]string, query url.Values) error {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		fv := rv.Field(i)
+
+		if f.Anonymous && f.Tag.Get("path") == "" && f.Tag.Get("query") == "" {
+			for fv.Kind() == reflect.Ptr {
+				if fv.IsNil() {
+					break
+				}
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct {
+				if err := collectParams(fv, path, query); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+
+		layout := f.Tag.Get("layout")
+		if tag, ok := f.Tag.Lookup("path"); ok {
+			name, omitEmpty := parseParamTag(tag, f.Name)
+			vals, err := paramValues(fv, layout, omitEmpty)
+			if err != nil {
+				return fmt.Errorf("[rest] path param %q: %w", name, err)
+			}
+			if len(vals) > 0 {
+				for i := range vals {
+					vals[i] = url.PathEscape(vals[i])
+				}
+				path[name] = strings.Join(vals, ",")
+			}
+		}
+		if tag, ok := f.Tag.Lookup("query"); ok {
+			name, omitEmpty := parseParamTag(tag, f.Name)
+			vals, err := paramValues(fv, layout, omitEmpty)
+			if err != nil {
+				return fmt.Errorf("[rest] query param %q: %w", name, err)
+			}
+			if len(vals) > 0 {
+				query[name] = append(query[name], vals...)
+			}
+		}
+	}
+	return nil
+}
+
+func parseParamTag(tag, field string) (name string, omitEmpty bool) {
+	parts := strings.Split(tag, ",")
+	name = parts[0]
+	if name == "" {
+		name = field
+	}
+	for _, p := range parts[1:] {
+		if p == "omitempty" {
+			omitEmpty = true
+		}
+	}
+	return name, omitEmpty
+}
+
+// paramValues formats fv; slices and arrays give one value per element.
+func paramValues(fv reflect.Value, layout string, omitEmpty bool) ([]string, error) {
+	if fv.Kind() == reflect.Ptr {
+		if fv.IsNil() {
+			return nil, nil
+		}
+		fv = fv.Elem()
+	}
+	if omitEmpty && fv.IsZero() {
+		return nil, nil
+	}
+
+	if (fv.Kind() == reflect.Slice || fv.Kind() == reflect.Array) && fv.Type().Elem().Kind() != reflect.Uint8 {
+		vals := make([]string, 0, fv.Len())
+		for i := 0; i < fv.Len(); i++ {
+			s, err := formatParam(fv.Index(i), layout)
+			if err != nil {
+				return nil, err
+			}
+			vals = append(vals, s)
+		}
+		return vals, nil
+	}
+
+	s, err := formatParam(fv, layout)
+	if err != nil {
+		return nil, err
+	}
+	return []string{s}, nil
+}
+
+func formatParam(v reflect.Value, layout string) (string, error) {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return "", nil
+		}
+		v = v.Elem()
+	}
+
+	switch x := v.Interface().(type) {
+	case time.Time:
+		if layout == "" {
+			layout = time.RFC3339
+		}
+		return x.Format(layout), nil
+	case encoding.TextMarshaler:
+		b, err := x.MarshalText()
+		return string(b), err
+	case fmt.Stringer:
+		return x.String(), nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits()), nil
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return string(v.Bytes()), nil
+		}
+	}
+	return "", fmt.Errorf("unsupported type %s", v.Type())
+}
@@ -0,0 +1,78 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Paging struct {
+	Page int `query:"page"`
+	Size int `query:"size,omitempty"`
+}
+
+type ListOrders struct {
+	Paging
+	ShopID string     `path:"shop_id"`
+	Status []string   `query:"status,omitempty"`
+	From   time.Time  `query:"from,omitempty" layout:"2006-01-02"`
+	To     *time.Time `query:"to"`
+	Paid   *bool      `query:"paid"`
+	Note   string     `json:"note"`
+}
+
+func TestEncodeParams(t *testing.T) {
+	paid := true
+	path, query, err := encodeParams(&ListOrders{
+		Paging: Paging{Page: 0},
+		ShopID: "a/b",
+		Status: []string{"new", "paid"},
+		From:   time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		Paid:   &paid,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"shop_id": "a%2Fb"}, path)
+	assert.Equal(t, []string{"new", "paid"}, query["status"])
+	assert.Equal(t, "2025-03-01", query.Get("from"))
+	assert.Equal(t, "0", query.Get("page"), "zero values are kept without omitempty")
+	assert.Equal(t, "true", query.Get("paid"))
+	assert.NotContains(t, query, "size")
+	assert.NotContains(t, query, "to", "nil pointers are skipped")
+	assert.NotContains(t, query, "note")
+
+	_, _, err = encodeParams("x")
+	assert.Error(t, err)
+
+	_, _, err = encodeParams(struct {
+		C chan int `query:"c"`
+	}{C: make(chan int)})
+	assert.Error(t, err)
+}
+
+func TestRestClient_Params(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/shops/42/orders", r.URL.Path)
+		assert.Equal(t, []string{"new", "paid"}, r.URL.Query()["status"])
+		assert.Equal(t, "2", r.URL.Query().Get("page"))
+		assert.Equal(t, "vi", r.URL.Query().Get("lang"))
+		_, _ = w.Write([]byte(`{"message":"ok"}`))
+	}))
+	defer server.Close()
+
+	res, err := NewRequest[MockResponse](client).
+		URL(server.URL + "/shops/:shop_id/orders").
+		QueryParams(map[string]string{"lang": "vi"}).
+		Params(ListOrders{ShopID: "42", Status: []string{"new", "paid"}, Paging: Paging{Page: 2}}).
+		GET(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "ok", res.Data.Message)
+
+	_, err = NewRequest[MockResponse](client).URL(server.URL).Params(42).GET(context.Background())
+	assert.Error(t, err)
+}
@@ -0,0 +1,64 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/BevisDev/godev/utils/ctxmeta"
+	"github.com/BevisDev/godev/utils/propagation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func headerServer(t *testing.T) (*httptest.Server, *http.Header) {
+	var got http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+	return server, &got
+}
+
+func TestPropagation_ForwardsHeaders(t *testing.T) {
+	server, got := headerServer(t)
+
+	traceparent := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	ctx := ctxmeta.WithRID(context.Background(), "rid-1")
+	ctx = propagation.WithHeaders(ctx, http.Header{
+		"X-Request-Id":     {"rid-1"},
+		"Traceparent":      {traceparent},
+		"X-Correlation-Id": {"c-1"},
+	})
+
+	_, err := NewRequest[any](New()).URL(server.URL).GET(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "rid-1", got.Get("X-Request-ID"))
+	assert.Equal(t, traceparent, got.Get("traceparent"))
+	assert.Equal(t, "c-1", got.Get("X-Correlation-ID"))
+}
+
+func TestPropagation_ExplicitHeaderWins(t *testing.T) {
+	server, got := headerServer(t)
+
+	ctx := propagation.WithHeaders(context.Background(), http.Header{"X-Correlation-Id": {"c-1"}})
+	_, err := NewRequest[any](New()).
+		URL(server.URL).
+		Headers(map[string]string{"X-Correlation-ID": "explicit"}).
+		GET(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "explicit", got.Get("X-Correlation-ID"))
+	// the generated request ID is sent even without inbound headers
+	assert.NotEmpty(t, got.Get("X-Request-ID"))
+}
+
+func TestPropagation_Disabled(t *testing.T) {
+	server, got := headerServer(t)
+
+	ctx := ctxmeta.WithRID(context.Background(), "rid-1")
+	_, err := NewRequest[any](New(WithoutPropagation())).URL(server.URL).GET(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, got.Get("X-Request-ID"))
+}
@@ -6,13 +6,28 @@ import (
 	"time"
 )
 
+// HTTPClient sends the requests of a Client; *http.Client implements it.
+// Substitute it with WithHTTPClient, e.g. to stub responses in tests.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Request is the former name of HTTPRequest.
+//
+// Deprecated: use HTTPRequest.
+type Request[T any] = HTTPRequest[T]
+
 // Client wraps an HTTP client with a configurable timeout and optional logger.
 //
 // It is intended for making REST API calls with consistent timeout settings
 // and optional logging support via AppLogger.
 type Client struct {
 	*options
-	client *http.Client
+	client   *http.Client
+	doer     HTTPClient
+	balancer *balancer
+	stats    connStats
+	meter    *clientMetrics
 }
 
 // New creates a new Client instance using the provided Options.
@@ -24,8 +39,16 @@ func New(opts ...Option) *Client {
 	}
 
 	c := &Client{
-		client:  new(http.Client),
+		client:  &http.Client{Transport: newTransport(opt)},
 		options: opt,
+		meter:   newClientMetrics(opt.metrics),
+	}
+	c.doer = c.client
+	if opt.httpClient != nil {
+		c.doer = opt.httpClient
+	}
+	if len(opt.endpoints) > 0 {
+		c.balancer = newBalancer(opt.endpoints, opt.strategy, opt.maxFailures, opt.ejectFor)
 	}
 
 	log.Printf("[rest] client started successfully")
@@ -41,3 +64,11 @@ func (r *Client) SetTimeout(timeout time.Duration) {
 		r.timeout = timeout
 	}
 }
+
+// Hosts returns the health of each balanced base URL (nil without base URLs).
+func (r *Client) Hosts() []HostStatus {
+	if r.balancer == nil {
+		return nil
+	}
+	return r.balancer.status()
+}
@@ -0,0 +1,154 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/BevisDev/godev/consts"
+	"github.com/BevisDev/godev/utils"
+	"github.com/BevisDev/godev/utils/validate"
+	"github.com/BevisDev/godev/utils/xmlx"
+)
+
+// bodyEncoding selects how Body is sent and the response is decoded.
+type bodyEncoding int
+
+const (
+	encodingJSON bodyEncoding = iota
+	encodingXML
+	encodingSOAP
+)
+
+const charsetUTF8 = "; charset=utf-8"
+
+// XML sends Body as XML (text/xml unless a Content-Type header is set) and decodes
+// the response body as XML into T. A []byte or string Body is sent as-is.
+func (r *HTTPRequest[T]) XML() *HTTPRequest[T] {
+	r.encoding = encodingXML
+	return r
+}
+
+// SOAP calls a SOAP operation:
+//
+//	resp, err := rest.NewRequest[GetRateResponse](client).
+//		URL("/RateService.asmx").
+//		Body(GetRate{From: "USD", To: "VND"}).
+//		SOAP("http://tempuri.org/GetRate").
+//		POST(ctx)
+//
+// Body is wrapped in a SOAP 1.1 envelope with the SOAPAction header; pass an
+// *xmlx.Envelope to declare namespaces, add headers or use SOAP 1.2 (the action
+// then goes in the Content-Type). The first element of the response Body is
+// decoded into T. A soap:Fault is returned as an error matched by AsSOAPFault,
+// and by AsHTTPError when it came with a 4xx/5xx status.
+func (r *HTTPRequest[T]) SOAP(action string) *HTTPRequest[T] {
+	r.encoding = encodingSOAP
+	r.soapAction = action
+	return r
+}
+
+// envelope returns the SOAP envelope of Body.
+func (r *HTTPRequest[T]) envelope() *xmlx.Envelope {
+	if env, ok := r.body.(*xmlx.Envelope); ok {
+		return env
+	}
+	return xmlx.NewEnvelope(r.body)
+}
+
+// xmlContentType returns the default Content-Type for XML and SOAP bodies.
+func (r *HTTPRequest[T]) xmlContentType() string {
+	if r.encoding == encodingSOAP && r.envelope().IsSOAP12() {
+		return fmt.Sprintf(`%s%s; action="%s"`, consts.ApplicationSOAPXML, charsetUTF8, r.soapAction)
+	}
+	return consts.TextXML + charsetUTF8
+}
+
+// setSOAPAction sets the SOAPAction header of a SOAP 1.1 call.
+func (r *HTTPRequest[T]) setSOAPAction() {
+	if r.encoding != encodingSOAP || r.envelope().IsSOAP12() {
+		return
+	}
+	if r.headers[consts.SOAPAction] == "" {
+		r.headers[consts.SOAPAction] = `"` + r.soapAction + `"`
+	}
+}
+
+// serializeXML encodes Body as XML or as a SOAP envelope.
+func (r *HTTPRequest[T]) serializeXML() ([]byte, string, error) {
+	var (
+		raw []byte
+		err error
+	)
+	switch {
+	case r.encoding == encodingSOAP:
+		raw, err = r.envelope().Marshal()
+	case validate.IsNilOrEmpty(r.body):
+		return nil, "", nil
+	default:
+		switch b := r.body.(type) {
+		case []byte:
+			raw = b
+		case string:
+			raw = []byte(b)
+		default:
+			raw, err = xmlx.ToXMLBytes(b)
+		}
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return raw, string(raw), nil
+}
+
+// decode decodes a response body into T according to the request encoding.
+func (r *HTTPRequest[T]) decode(raw []byte) (T, error) {
+	switch r.encoding {
+	case encodingSOAP:
+		return xmlx.DecodeEnvelope[T](raw)
+	case encodingXML:
+		var zero T
+		switch any(zero).(type) {
+		case string, []byte:
+			return utils.ValueFromBytes[T](raw)
+		}
+		return xmlx.FromXMLBytes[T](raw)
+	default:
+		return utils.ValueFromBytes[T](raw)
+	}
+}
+
+// soapFault returns the fault of an error response of a SOAP call, or nil.
+func (r *HTTPRequest[T]) soapFault(raw []byte, httpErr *HTTPError) error {
+	if r.encoding != encodingSOAP {
+		return nil
+	}
+	_, err := xmlx.DecodeEnvelope[string](raw)
+	fault, ok := xmlx.AsFault(err)
+	if !ok {
+		return nil
+	}
+	return &faultError{fault: fault, http: httpErr}
+}
+
+// faultError is a SOAP fault returned with an error status.
+type faultError struct {
+	fault *xmlx.Fault
+	http  *HTTPError
+}
+
+func (e *faultError) Error() string {
+	return fmt.Sprintf("status %d: %s", e.http.Status, e.fault.Error())
+}
+
+func (e *faultError) Unwrap() []error {
+	return []error{e.fault, e.http}
+}
+
+// AsSOAPFault attempts to cast a generic error to *xmlx.Fault using errors.As.
+//
+// Returns the typed fault and true if the call returned a SOAP fault.
+func AsSOAPFault(err error) (*xmlx.Fault, bool) {
+	var fault *xmlx.Fault
+	ok := errors.As(err, &fault)
+	return fault, ok
+}
@@ -0,0 +1,132 @@
+package rest
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/BevisDev/godev/utils/xmlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type getRate struct {
+	XMLName xml.Name `xml:"tem:GetRate"`
+	From    string   `xml:"tem:from"`
+}
+
+type getRateResponse struct {
+	Rate float64 `xml:"GetRateResult"`
+}
+
+const soapResponse = `<?xml version="1.0" encoding="utf-8"?>
+<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">
+<soap:Body><GetRateResponse xmlns="http://tempuri.org/"><GetRateResult>25400</GetRateResult></GetRateResponse></soap:Body>
+</soap:Envelope>`
+
+func TestSOAP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "text/xml; charset=utf-8", r.Header.Get("Content-Type"))
+		assert.Equal(t, `"http://tempuri.org/GetRate"`, r.Header.Get("SOAPAction"))
+		body, _ := io.ReadAll(r.Body)
+		assert.Contains(t, string(body), `xmlns:tem="http://tempuri.org/"`)
+		assert.Contains(t, string(body), `<soap:Body><tem:GetRate><tem:from>USD</tem:from></tem:GetRate></soap:Body>`)
+
+		w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+		_, _ = io.WriteString(w, soapResponse)
+	}))
+	defer server.Close()
+
+	resp, err := NewRequest[getRateResponse](client).
+		URL(server.URL).
+		Body(xmlx.NewEnvelope(getRate{From: "USD"}).Namespace("tem", "http://tempuri.org/")).
+		SOAP("http://tempuri.org/GetRate").
+		POST(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, float64(25400), resp.Data.Rate)
+}
+
+func TestSOAP12(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, `application/soap+xml; charset=utf-8; action="urn:Ping"`, r.Header.Get("Content-Type"))
+		assert.Empty(t, r.Header.Get("SOAPAction"))
+		_, _ = io.WriteString(w, `<Envelope><Body><Pong>ok</Pong></Body></Envelope>`)
+	}))
+	defer server.Close()
+
+	resp, err := NewRequest[string](client).
+		URL(server.URL).
+		Body(xmlx.NewEnvelope("<Ping/>").SOAP12()).
+		SOAP("urn:Ping").
+		POST(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "<Pong>ok</Pong>", resp.Data)
+}
+
+func TestSOAP_Fault(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = io.WriteString(w, `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body>
+<soap:Fault><faultcode>soap:Server</faultcode><faultstring>Rate not found</faultstring></soap:Fault>
+</soap:Body></soap:Envelope>`)
+	}))
+	defer server.Close()
+
+	_, err := NewRequest[getRateResponse](client).
+		URL(server.URL).
+		Body(getRate{From: "XXX"}).
+		SOAP("http://tempuri.org/GetRate").
+		POST(context.Background())
+	require.Error(t, err)
+
+	fault, ok := AsSOAPFault(err)
+	require.True(t, ok)
+	assert.Equal(t, "soap:Server", fault.Code)
+	assert.Equal(t, "Rate not found", fault.Message)
+
+	httpErr, ok := AsHTTPError(err)
+	require.True(t, ok)
+	assert.True(t, httpErr.IsServerError())
+}
+
+func TestSOAP_NonFaultError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad gateway", http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	_, err := NewRequest[getRateResponse](client).URL(server.URL).SOAP("urn:x").POST(context.Background())
+	_, ok := AsSOAPFault(err)
+	assert.False(t, ok)
+	httpErr, ok := AsHTTPError(err)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusBadGateway, httpErr.Status)
+}
+
+func TestXML(t *testing.T) {
+	type order struct {
+		XMLName xml.Name `xml:"order"`
+		ID      int      `xml:"id,attr"`
+		Status  string   `xml:"status"`
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "text/xml; charset=utf-8", r.Header.Get("Content-Type"))
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, `<order id="7"><status>new</status></order>`, string(body))
+		_, _ = io.WriteString(w, `<order id="7"><status>paid</status></order>`)
+	}))
+	defer server.Close()
+
+	resp, err := NewRequest[order](client).
+		URL(server.URL).
+		Body(order{ID: 7, Status: "new"}).
+		XML().
+		POST(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 7, resp.Data.ID)
+	assert.Equal(t, "paid", resp.Data.Status)
+}
@@ -0,0 +1,89 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptrace"
+	"sync/atomic"
+)
+
+// Stats are connection counters of a Client since it was created.
+//
+// A high NewConns/Requests ratio means connections are not reused, e.g. because
+// MaxIdleConns is too low for the concurrency (see WithMaxIdleConns).
+type Stats struct {
+	Requests    int64 // requests sent
+	ReusedConns int64 // requests sent on a pooled connection
+	NewConns    int64 // requests that needed a new connection
+	IdleConns   int64 // reused connections that were idle in the pool
+	Dials       int64 // TCP connections dialed
+	DialErrors  int64 // failed dials
+}
+
+type connStats struct {
+	requests, reused, created, idle, dials, dialErrors atomic.Int64
+}
+
+func (s *connStats) snapshot() Stats {
+	return Stats{
+		Requests:    s.requests.Load(),
+		ReusedConns: s.reused.Load(),
+		NewConns:    s.created.Load(),
+		IdleConns:   s.idle.Load(),
+		Dials:       s.dials.Load(),
+		DialErrors:  s.dialErrors.Load(),
+	}
+}
+
+// trace returns req with a client trace counting connection usage.
+func (s *connStats) trace(req *http.Request) *http.Request {
+	s.requests.Add(1)
+	t := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			if info.Reused {
+				s.reused.Add(1)
+			} else {
+				s.created.Add(1)
+			}
+			if info.WasIdle {
+				s.idle.Add(1)
+			}
+		},
+		ConnectDone: func(_, _ string, err error) {
+			s.dials.Add(1)
+			if err != nil {
+				s.dialErrors.Add(1)
+			}
+		},
+	}
+	return req.WithContext(httptrace.WithClientTrace(req.Context(), t))
+}
+
+// Stats returns the connection counters of the client.
+func (r *Client) Stats() Stats {
+	return r.stats.snapshot()
+}
+
+// newTransport returns the transport of the client: the one of WithTransport, or a
+// clone of http.DefaultTransport tuned by the pool options, or nil for the default.
+func newTransport(o *options) http.RoundTripper {
+	if o.transport != nil {
+		return o.transport
+	}
+	if !o.tunePool {
+		return nil
+	}
+
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	if o.maxIdleConns > 0 {
+		t.MaxIdleConns = o.maxIdleConns
+		t.MaxIdleConnsPerHost = o.maxIdleConns
+	}
+	if o.maxConnsPerHost > 0 {
+		t.MaxConnsPerHost = o.maxConnsPerHost
+	}
+	if o.idleConnTimeout > 0 {
+		t.IdleConnTimeout = o.idleConnTimeout
+	}
+	t.DisableKeepAlives = o.disableKeepAlives
+	return t
+}
@@ -0,0 +1,54 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Stats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"message":"ok"}`))
+	}))
+	defer server.Close()
+
+	run := func(c *Client) Stats {
+		for i := 0; i < 3; i++ {
+			_, err := NewRequest[MockResponse](c).URL(server.URL).GET(context.Background())
+			require.NoError(t, err)
+		}
+		return c.Stats()
+	}
+
+	s := run(New(WithMaxIdleConns(10)))
+	assert.Equal(t, int64(3), s.Requests)
+	assert.Equal(t, int64(1), s.NewConns)
+	assert.Equal(t, int64(2), s.ReusedConns)
+	assert.Equal(t, int64(1), s.Dials)
+
+	s = run(New(WithDisableKeepAlives()))
+	assert.Equal(t, int64(3), s.NewConns)
+	assert.Zero(t, s.ReusedConns)
+}
+
+func TestNewTransport(t *testing.T) {
+	assert.Nil(t, newTransport(withDefaults()))
+
+	o := withDefaults()
+	WithMaxIdleConns(100)(o)
+	WithMaxConnsPerHost(20)(o)
+	WithIdleConnTimeout(time.Minute)(o)
+
+	tr, ok := newTransport(o).(*http.Transport)
+	require.True(t, ok)
+	assert.Equal(t, 100, tr.MaxIdleConns)
+	assert.Equal(t, 100, tr.MaxIdleConnsPerHost)
+	assert.Equal(t, 20, tr.MaxConnsPerHost)
+	assert.Equal(t, time.Minute, tr.IdleConnTimeout)
+	assert.False(t, tr.DisableKeepAlives)
+}
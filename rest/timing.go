@@ -0,0 +1,63 @@
+package rest
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/http/httptrace"
+	"time"
+)
+
+// Timing is the latency breakdown of a request, captured with WithTiming.
+// Phases that did not happen (e.g. DNS and Connect on a reused connection) are 0.
+type Timing struct {
+	DNS     time.Duration // DNS lookup
+	Connect time.Duration // TCP connect
+	TLS     time.Duration // TLS handshake
+	TTFB    time.Duration // from sending the request to the first response byte
+	Total   time.Duration // from sending the request to the end of the body
+	Reused  bool          // the connection came from the pool
+}
+
+func (t *Timing) String() string {
+	return fmt.Sprintf("dns=%s connect=%s tls=%s ttfb=%s total=%s reused=%t",
+		t.DNS, t.Connect, t.TLS, t.TTFB, t.Total, t.Reused)
+}
+
+// timingTrace collects a Timing through httptrace hooks.
+type timingTrace struct {
+	start, dnsStart, connectStart, tlsStart time.Time
+	timing                                  Timing
+}
+
+// trace returns req with hooks filling t; start counts from now.
+func (t *timingTrace) trace(req *http.Request) *http.Request {
+	t.start = time.Now()
+	ct := &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) { t.dnsStart = time.Now() },
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			t.timing.DNS = time.Since(t.dnsStart)
+		},
+		ConnectStart: func(_, _ string) { t.connectStart = time.Now() },
+		ConnectDone: func(_, _ string, _ error) {
+			t.timing.Connect = time.Since(t.connectStart)
+		},
+		TLSHandshakeStart: func() { t.tlsStart = time.Now() },
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			t.timing.TLS = time.Since(t.tlsStart)
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			t.timing.Reused = info.Reused
+		},
+		GotFirstResponseByte: func() {
+			t.timing.TTFB = time.Since(t.start)
+		},
+	}
+	return req.WithContext(httptrace.WithClientTrace(req.Context(), ct))
+}
+
+// done sets Total and returns the timing.
+func (t *timingTrace) done() *Timing {
+	t.timing.Total = time.Since(t.start)
+	return &t.timing
+}
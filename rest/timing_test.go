@@ -0,0 +1,41 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Timing(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(20 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"message":"ok"}`))
+	}))
+	defer server.Close()
+
+	c := New(WithTiming(), WithTransport(server.Client().Transport))
+
+	res, err := NewRequest[MockResponse](c).URL(server.URL).GET(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, res.Timing)
+	assert.False(t, res.Timing.Reused)
+	assert.Positive(t, res.Timing.Connect)
+	assert.Positive(t, res.Timing.TLS)
+	assert.GreaterOrEqual(t, res.Timing.TTFB, 20*time.Millisecond)
+	assert.GreaterOrEqual(t, res.Timing.Total, res.Timing.TTFB)
+	assert.Contains(t, res.Timing.String(), "ttfb=")
+
+	res, err = NewRequest[MockResponse](c).URL(server.URL).GET(context.Background())
+	require.NoError(t, err)
+	assert.True(t, res.Timing.Reused)
+	assert.Zero(t, res.Timing.Connect)
+
+	res, err = NewRequest[MockResponse](client).URL(server.URL).GET(context.Background())
+	assert.Error(t, err, "default client does not trust the test certificate")
+	assert.Nil(t, res.Timing)
+}
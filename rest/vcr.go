@@ -0,0 +1,260 @@
+package rest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// VCRMode selects how a Recorder uses its fixtures.
+type VCRMode int
+
+const (
+	// VCRAuto replays recorded interactions and records the missing ones.
+	VCRAuto VCRMode = iota
+
+	// VCRRecord always sends requests and overwrites the fixtures.
+	VCRRecord
+
+	// VCRReplay never sends requests; a request without fixture fails with ErrNoRecording.
+	VCRReplay
+)
+
+// Redacted replaces redacted header values in fixtures.
+const Redacted = "REDACTED"
+
+var (
+	// ErrNoRecording is returned in VCRReplay mode for a request that was never recorded.
+	ErrNoRecording = errors.New("[rest] no recorded interaction for request")
+)
+
+// Interaction is one recorded request and its response, stored as a JSON fixture.
+type Interaction struct {
+	Key      string           `json:"key"`
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Recorder is an http.RoundTripper recording responses to fixture files and
+// replaying them, so tests of API clients run without the real API:
+//
+//	rec := rest.NewRecorder("testdata/fixtures", rest.WithVCRMode(rest.VCRReplay))
+//	client := rest.New(rest.WithTransport(rec))
+//
+// Requests are matched on method, URL and a hash of the body.
+type Recorder struct {
+	dir          string
+	mode         VCRMode
+	transport    http.RoundTripper
+	redactHeader map[string]struct{}
+	redactors    []func(*Interaction)
+	mu           sync.Mutex
+}
+
+type VCROption func(*Recorder)
+
+// WithVCRMode sets the mode (default VCRAuto).
+func WithVCRMode(mode VCRMode) VCROption {
+	return func(r *Recorder) {
+		r.mode = mode
+	}
+}
+
+// WithVCRTransport sets the transport used to record (default http.DefaultTransport).
+func WithVCRTransport(rt http.RoundTripper) VCROption {
+	return func(r *Recorder) {
+		if rt != nil {
+			r.transport = rt
+		}
+	}
+}
+
+// WithRedactHeaders replaces the values of these request and response headers
+// in fixtures (default Authorization, Cookie, Set-Cookie, X-Api-Key).
+func WithRedactHeaders(names ...string) VCROption {
+	return func(r *Recorder) {
+		for _, n := range names {
+			r.redactHeader[http.CanonicalHeaderKey(n)] = struct{}{}
+		}
+	}
+}
+
+// WithRedactor edits interactions before they are written, e.g. to mask tokens in bodies.
+// Matching uses the original request, so redaction does not break replay.
+func WithRedactor(fn func(*Interaction)) VCROption {
+	return func(r *Recorder) {
+		if fn != nil {
+			r.redactors = append(r.redactors, fn)
+		}
+	}
+}
+
+// NewRecorder creates a Recorder storing fixtures in dir.
+func NewRecorder(dir string, opts ...VCROption) *Recorder {
+	r := &Recorder{
+		dir:          dir,
+		transport:    http.DefaultTransport,
+		redactHeader: make(map[string]struct{}),
+	}
+	WithRedactHeaders("Authorization", "Cookie", "Set-Cookie", "X-Api-Key")(r)
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+	key := requestKey(req.Method, req.URL.String(), body)
+
+	if r.mode != VCRRecord {
+		it, err := r.load(key)
+		if err == nil {
+			return it.toResponse(req), nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		if r.mode == VCRReplay {
+			return nil, fmt.Errorf("%w: %s %s", ErrNoRecording, req.Method, req.URL)
+		}
+	}
+
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	it := &Interaction{
+		Key: key,
+		Request: RecordedRequest{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Header: req.Header.Clone(),
+			Body:   string(body),
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header.Clone(),
+			Body:       string(respBody),
+		},
+	}
+	if err := r.save(it); err != nil {
+		return nil, err
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	return resp, nil
+}
+
+// Path returns the fixture file of the interaction with key.
+func (r *Recorder) Path(key string) string {
+	return filepath.Join(r.dir, key+".json")
+}
+
+func (r *Recorder) load(key string) (*Interaction, error) {
+	raw, err := os.ReadFile(r.Path(key))
+	if err != nil {
+		return nil, err
+	}
+	var it Interaction
+	if err := json.Unmarshal(raw, &it); err != nil {
+		return nil, fmt.Errorf("[rest] invalid fixture %s: %w", r.Path(key), err)
+	}
+	return &it, nil
+}
+
+func (r *Recorder) save(it *Interaction) error {
+	r.redactHeaders(it.Request.Header)
+	r.redactHeaders(it.Response.Header)
+	for _, fn := range r.redactors {
+		fn(it)
+	}
+
+	raw, err := json.MarshalIndent(it, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := os.MkdirAll(r.dir, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.Path(it.Key), raw, 0o644)
+}
+
+func (r *Recorder) redactHeaders(h http.Header) {
+	for name := range h {
+		if _, ok := r.redactHeader[http.CanonicalHeaderKey(name)]; ok {
+			h[name] = []string{Redacted}
+		}
+	}
+}
+
+func (it *Interaction) toResponse(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", it.Response.StatusCode, http.StatusText(it.Response.StatusCode)),
+		StatusCode:    it.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        it.Response.Header.Clone(),
+		Body:          io.NopCloser(strings.NewReader(it.Response.Body)),
+		ContentLength: int64(len(it.Response.Body)),
+		Request:       req,
+	}
+}
+
+// readBody reads the request body and restores it for the real transport.
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	_ = req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// requestKey names the fixture of a request: "<method>-<sha256 of method, URL and body>".
+func requestKey(method, url string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method + " " + url + "\n"))
+	h.Write(body)
+	return strings.ToLower(method) + "-" + hex.EncodeToString(h.Sum(nil))[:16]
+}
@@ -0,0 +1,85 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder_RecordAndReplay(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Header().Set("Set-Cookie", "session=secret")
+		_ = json.NewEncoder(w).Encode(MockResponse{Message: "hello", Status: "token-123"})
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	rec := NewRecorder(dir, WithRedactor(func(it *Interaction) {
+		it.Response.Body = strings.ReplaceAll(it.Response.Body, "token-123", "***")
+	}))
+	c := New(WithTransport(rec))
+
+	call := func(c *Client, name string) (HTTPResponse[MockResponse], error) {
+		return NewRequest[MockResponse](c).
+			URL(server.URL).
+			Headers(map[string]string{"Authorization": "Bearer secret"}).
+			Body(map[string]string{"name": name}).
+			POST(context.Background())
+	}
+
+	res, err := call(c, "a")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", res.Data.Message)
+	assert.Equal(t, "token-123", res.Data.Status, "the live response is not redacted")
+
+	// second call is replayed from the fixture
+	res, err = call(c, "a")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", res.Data.Message)
+	assert.Equal(t, "***", res.Data.Status)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&hits))
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	require.Len(t, files, 1)
+	raw, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), "Bearer secret")
+	assert.NotContains(t, string(raw), "session=secret")
+	assert.Contains(t, string(raw), Redacted)
+
+	// replay mode never hits the network
+	replay := New(WithTransport(NewRecorder(dir, WithVCRMode(VCRReplay))))
+	_, err = call(replay, "a")
+	require.NoError(t, err)
+
+	_, err = call(replay, "b")
+	assert.ErrorIs(t, err, ErrNoRecording)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&hits))
+}
+
+func TestRecorder_RecordMode(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		_, _ = w.Write([]byte(`{"message":"hi"}`))
+	}))
+	defer server.Close()
+
+	c := New(WithTransport(NewRecorder(t.TempDir(), WithVCRMode(VCRRecord))))
+	for i := 0; i < 2; i++ {
+		_, err := NewRequest[MockResponse](c).URL(server.URL).GET(context.Background())
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&hits))
+}
@@ -0,0 +1,56 @@
+package saga
+
+import (
+	"fmt"
+	"time"
+)
+
+// Status is the state of a saga instance.
+type Status string
+
+const (
+	StatusRunning      Status = "running"      // steps are executing
+	StatusAwaiting     Status = "awaiting"     // the current step waits for Complete or Fail
+	StatusCompensating Status = "compensating" // a step failed, compensations are executing
+	StatusCompleted    Status = "completed"    // every step succeeded
+	StatusCompensated  Status = "compensated"  // a step failed and every compensation succeeded
+	StatusFailed       Status = "failed"       // a compensation failed, manual action is needed
+)
+
+// Done reports whether the saga reached a final status.
+func (s Status) Done() bool {
+	return s == StatusCompleted || s == StatusCompensated || s == StatusFailed
+}
+
+// Instance is the persisted state of one execution of a saga.
+type Instance struct {
+	ID     string `db:"id" json:"id"`
+	Name   string `db:"name" json:"name"`
+	Status Status `db:"status" json:"status"`
+
+	// Step is the index of the step to run, or to compensate while compensating.
+	Step int `db:"step" json:"step"`
+
+	Data     string     `db:"data" json:"data"`        // JSON of the saga data
+	Error    string     `db:"last_error" json:"error"` // error that triggered the compensation
+	Deadline *time.Time `db:"deadline" json:"deadline,omitempty"`
+
+	// Version is incremented on every save to detect concurrent updates.
+	Version   int       `db:"version" json:"version"`
+	CreatedAt time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt time.Time `db:"updated_at" json:"updatedAt"`
+}
+
+// StepError is a step failure that made the saga compensate.
+type StepError struct {
+	Step string
+	Err  error
+}
+
+func (e *StepError) Error() string {
+	return fmt.Sprintf("[saga] step %s: %v", e.Step, e.Err)
+}
+
+func (e *StepError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,48 @@
+package saga
+
+import (
+	"context"
+	"time"
+)
+
+type Option func(*options)
+
+type options struct {
+	stepTimeout  time.Duration
+	recoverAfter time.Duration
+	onFinish     func(ctx context.Context, inst *Instance)
+}
+
+func defaultOptions() *options {
+	return &options{
+		recoverAfter: 5 * time.Minute,
+	}
+}
+
+// WithStepTimeout sets the timeout of steps and compensations without their own Timeout.
+// By default they have none.
+func WithStepTimeout(d time.Duration) Option {
+	return func(o *options) {
+		if d > 0 {
+			o.stepTimeout = d
+		}
+	}
+}
+
+// WithRecoverAfter sets how long a running or compensating instance must
+// be idle before Recover resumes it (default 5m). Keep it above the longest
+// step timeout, so Recover does not resume an instance another process is running.
+func WithRecoverAfter(d time.Duration) Option {
+	return func(o *options) {
+		if d > 0 {
+			o.recoverAfter = d
+		}
+	}
+}
+
+// WithOnFinish calls fn when an instance reaches a final status.
+func WithOnFinish(fn func(ctx context.Context, inst *Instance)) Option {
+	return func(o *options) {
+		o.onFinish = fn
+	}
+}
@@ -0,0 +1,369 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/BevisDev/godev/utils/console"
+	"github.com/BevisDev/godev/utils/idgen"
+	"github.com/BevisDev/godev/utils/jsonx"
+)
+
+var (
+	// ErrAwait is returned by a step Action that triggered remote work (e.g. published
+	// a command) and waits for Complete or Fail, usually called by a message consumer.
+	ErrAwait = errors.New("[saga] await step completion")
+
+	ErrNotFound    = errors.New("[saga] instance not found")
+	ErrConflict    = errors.New("[saga] instance was updated concurrently")
+	ErrNotAwaiting = errors.New("[saga] instance is not awaiting")
+	ErrStepTimeout = errors.New("[saga] step timed out")
+	ErrNoSteps     = errors.New("[saga] no steps")
+)
+
+// Step is a local transaction of a saga with the compensation that undoes it.
+type Step[T any] struct {
+	Name string
+
+	// Action runs the step. It may change data, which is persisted after the step.
+	// Actions are retried from the last persisted step after a crash, so they
+	// must be idempotent.
+	Action func(ctx context.Context, data *T) error
+
+	// Compensate undoes the step; nil when there is nothing to undo.
+	Compensate func(ctx context.Context, data *T) error
+
+	// Timeout bounds Action and Compensate, and the wait of an awaited step.
+	// Zero uses WithStepTimeout.
+	Timeout time.Duration
+}
+
+// Saga orchestrates the steps of a named distributed transaction over data T,
+// persisting the state of every instance in a Store.
+type Saga[T any] struct {
+	*options
+	name  string
+	steps []Step[T]
+	store Store
+	log   *console.Logger
+}
+
+// New returns the saga name with steps run in order.
+func New[T any](name string, store Store, steps []Step[T], opts ...Option) (*Saga[T], error) {
+	if len(steps) == 0 {
+		return nil, ErrNoSteps
+	}
+
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return &Saga[T]{
+		options: o,
+		name:    name,
+		steps:   steps,
+		store:   store,
+		log:     console.New("saga"),
+	}, nil
+}
+
+// Name returns the name of the saga.
+func (s *Saga[T]) Name() string {
+	return s.name
+}
+
+// Start creates an instance with data and runs its steps until the saga is done
+// or a step awaits. An empty id generates one; use a business key (e.g. the
+// order ID) to make Start idempotent, a duplicate then fails in the store.
+//
+// It returns a *StepError when a step failed, after compensation.
+func (s *Saga[T]) Start(ctx context.Context, id string, data T) (*Instance, error) {
+	if id == "" {
+		id = idgen.NewULID()
+	}
+
+	raw, err := jsonx.ToJSONBytes(data)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	inst := &Instance{
+		ID:        id,
+		Name:      s.name,
+		Status:    StatusRunning,
+		Data:      string(raw),
+		Version:   1,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.store.Create(ctx, inst); err != nil {
+		return nil, err
+	}
+
+	return inst, s.run(ctx, inst, &data)
+}
+
+// Complete resumes an instance awaiting its current step, typically from the
+// consumer of the step's reply message. update, when set, applies the reply to data.
+func (s *Saga[T]) Complete(ctx context.Context, id string, update func(data *T) error) (*Instance, error) {
+	inst, data, err := s.awaiting(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if update != nil {
+		if err := update(data); err != nil {
+			return inst, s.compensate(ctx, inst, data, inst.Step, &StepError{Step: s.steps[inst.Step].Name, Err: err})
+		}
+	}
+
+	inst.Status = StatusRunning
+	inst.Deadline = nil
+	if err := s.next(ctx, inst, data); err != nil {
+		return inst, err
+	}
+	return inst, s.run(ctx, inst, data)
+}
+
+// Fail marks the awaited step of an instance as failed with reason and compensates,
+// including the awaited step since its remote work may have happened.
+func (s *Saga[T]) Fail(ctx context.Context, id string, reason error) (*Instance, error) {
+	inst, data, err := s.awaiting(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return inst, s.compensate(ctx, inst, data, inst.Step, &StepError{Step: s.steps[inst.Step].Name, Err: reason})
+}
+
+// Get returns the instance with id and its data.
+func (s *Saga[T]) Get(ctx context.Context, id string) (*Instance, *T, error) {
+	inst, err := s.store.Get(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := s.decode(inst)
+	return inst, data, err
+}
+
+// Recover resumes unfinished instances after a crash or restart: running ones re-run
+// their current step, compensating ones continue compensating (both once idle for
+// WithRecoverAfter), and awaiting ones past their deadline fail with ErrStepTimeout.
+// Call it on start and periodically, e.g. from a scheduler job.
+func (s *Saga[T]) Recover(ctx context.Context) (int, error) {
+	list, err := s.store.Unfinished(ctx, s.name)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		n    int
+		errs []error
+		now  = time.Now()
+	)
+	for _, inst := range list {
+		if ctx.Err() != nil {
+			break
+		}
+
+		var resumed bool
+		switch inst.Status {
+		case StatusAwaiting:
+			resumed = inst.Deadline != nil && now.After(*inst.Deadline)
+		default:
+			resumed = now.Sub(inst.UpdatedAt) >= s.recoverAfter
+		}
+		if !resumed {
+			continue
+		}
+
+		err := s.resume(ctx, inst)
+		var stepErr *StepError
+		if err != nil && !errors.As(err, &stepErr) {
+			errs = append(errs, fmt.Errorf("[saga] recover %s: %w", inst.ID, err))
+			continue
+		}
+		n++
+	}
+	return n, errors.Join(errs...)
+}
+
+func (s *Saga[T]) resume(ctx context.Context, inst *Instance) error {
+	data, err := s.decode(inst)
+	if err != nil {
+		return err
+	}
+	if inst.Step >= len(s.steps) {
+		return fmt.Errorf("[saga] step %d out of range", inst.Step)
+	}
+
+	s.log.Info("recover %s %s status=%s step=%d", s.name, inst.ID, inst.Status, inst.Step)
+	switch inst.Status {
+	case StatusAwaiting:
+		return s.compensate(ctx, inst, data, inst.Step,
+			&StepError{Step: s.steps[inst.Step].Name, Err: ErrStepTimeout})
+	case StatusCompensating:
+		return s.compensate(ctx, inst, data, inst.Step, errors.New(inst.Error))
+	default:
+		return s.run(ctx, inst, data)
+	}
+}
+
+// run executes the steps from inst.Step while the instance is running.
+func (s *Saga[T]) run(ctx context.Context, inst *Instance, data *T) error {
+	for inst.Status == StatusRunning {
+		step := s.steps[inst.Step]
+
+		err := s.call(ctx, step, step.Action, data)
+		if errors.Is(err, ErrAwait) {
+			inst.Status = StatusAwaiting
+			if timeout := s.timeout(step); timeout > 0 {
+				deadline := time.Now().Add(timeout)
+				inst.Deadline = &deadline
+			}
+			return s.save(ctx, inst, data)
+		}
+		if err != nil {
+			// the failed step is not compensated, its local transaction did not happen
+			return s.compensate(ctx, inst, data, inst.Step-1, &StepError{Step: step.Name, Err: err})
+		}
+
+		if err := s.next(ctx, inst, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// next moves inst past its current step and saves it.
+func (s *Saga[T]) next(ctx context.Context, inst *Instance, data *T) error {
+	inst.Step++
+	if inst.Step == len(s.steps) {
+		inst.Status = StatusCompleted
+		inst.Step--
+	}
+	if err := s.save(ctx, inst, data); err != nil {
+		return err
+	}
+	if inst.Status == StatusCompleted {
+		s.finish(ctx, inst)
+	}
+	return nil
+}
+
+// compensate runs the compensations of steps from down to the first, in reverse
+// order, and returns cause once the saga is compensated.
+func (s *Saga[T]) compensate(ctx context.Context, inst *Instance, data *T, from int, cause error) error {
+	s.log.Error("%s %s compensating: %v", s.name, inst.ID, cause)
+
+	inst.Status = StatusCompensating
+	inst.Step = from
+	inst.Error = cause.Error()
+	inst.Deadline = nil
+	if from < 0 {
+		// nothing completed, nothing to undo
+		inst.Status = StatusCompensated
+		inst.Step = 0
+	}
+	if err := s.save(ctx, inst, data); err != nil {
+		return err
+	}
+
+	for i := from; i >= 0; i-- {
+		step := s.steps[i]
+		if step.Compensate != nil {
+			if err := s.call(ctx, step, step.Compensate, data); err != nil {
+				inst.Status = StatusFailed
+				inst.Error = fmt.Sprintf("%s; compensate %s: %v", cause, step.Name, err)
+				if err := s.save(ctx, inst, data); err != nil {
+					return err
+				}
+				s.finish(ctx, inst)
+				return errors.Join(cause, &StepError{Step: step.Name, Err: err})
+			}
+		}
+
+		if i > 0 {
+			inst.Step = i - 1
+		} else {
+			inst.Status = StatusCompensated
+		}
+		if err := s.save(ctx, inst, data); err != nil {
+			return err
+		}
+	}
+
+	s.finish(ctx, inst)
+	return cause
+}
+
+// call runs fn with the timeout of step; a panic fails the step.
+func (s *Saga[T]) call(ctx context.Context, step Step[T], fn func(context.Context, *T) error, data *T) (err error) {
+	if timeout := s.timeout(step); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("[saga] panic: %v", r)
+		}
+	}()
+
+	if err := fn(ctx, data); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) && ctx.Err() != nil {
+			return ErrStepTimeout
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *Saga[T]) timeout(step Step[T]) time.Duration {
+	if step.Timeout > 0 {
+		return step.Timeout
+	}
+	return s.stepTimeout
+}
+
+func (s *Saga[T]) awaiting(ctx context.Context, id string) (*Instance, *T, error) {
+	inst, data, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if inst.Name != s.name || inst.Status != StatusAwaiting {
+		return inst, nil, ErrNotAwaiting
+	}
+	return inst, data, nil
+}
+
+// save persists inst with data, bumping its version.
+func (s *Saga[T]) save(ctx context.Context, inst *Instance, data *T) error {
+	raw, err := jsonx.ToJSONBytes(data)
+	if err != nil {
+		return err
+	}
+
+	inst.Data = string(raw)
+	inst.Version++
+	inst.UpdatedAt = time.Now()
+	return s.store.Update(ctx, inst)
+}
+
+func (s *Saga[T]) decode(inst *Instance) (*T, error) {
+	data, err := jsonx.FromJSON[T](inst.Data)
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+func (s *Saga[T]) finish(ctx context.Context, inst *Instance) {
+	if s.onFinish != nil {
+		s.onFinish(ctx, inst)
+	}
+}
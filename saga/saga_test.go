@@ -0,0 +1,218 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type order struct {
+	ID        string
+	PaymentID string
+	Log       []string
+}
+
+type flow struct {
+	failAt     string
+	failUndo   string
+	await      string
+	undone     []string
+	executions map[string]int
+}
+
+func (f *flow) step(name string) Step[order] {
+	return Step[order]{
+		Name: name,
+		Action: func(ctx context.Context, o *order) error {
+			f.executions[name]++
+			if name == f.failAt {
+				return errors.New(name + " failed")
+			}
+			if name == f.await {
+				return ErrAwait
+			}
+			o.Log = append(o.Log, name)
+			return nil
+		},
+		Compensate: func(ctx context.Context, o *order) error {
+			if name == f.failUndo {
+				return errors.New("undo failed")
+			}
+			f.undone = append(f.undone, name)
+			return nil
+		},
+	}
+}
+
+func newSaga(t *testing.T, f *flow, store Store, opts ...Option) *Saga[order] {
+	t.Helper()
+	f.executions = make(map[string]int)
+	s, err := New("order", store, []Step[order]{f.step("reserve"), f.step("pay"), f.step("ship")}, opts...)
+	require.NoError(t, err)
+	return s
+}
+
+func TestSaga_Completed(t *testing.T) {
+	store := NewMemoryStore()
+	var finished *Instance
+	s := newSaga(t, &flow{}, store, WithOnFinish(func(_ context.Context, inst *Instance) { finished = inst }))
+
+	inst, err := s.Start(context.Background(), "o-1", order{ID: "o-1"})
+	require.NoError(t, err)
+	assert.Equal(t, StatusCompleted, inst.Status)
+	require.NotNil(t, finished)
+
+	_, data, err := s.Get(context.Background(), "o-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"reserve", "pay", "ship"}, data.Log)
+
+	_, err = s.Start(context.Background(), "o-1", order{})
+	assert.ErrorIs(t, err, ErrConflict)
+}
+
+func TestSaga_Compensated(t *testing.T) {
+	f := &flow{failAt: "ship"}
+	s := newSaga(t, f, NewMemoryStore())
+
+	inst, err := s.Start(context.Background(), "", order{})
+	var stepErr *StepError
+	require.ErrorAs(t, err, &stepErr)
+	assert.Equal(t, "ship", stepErr.Step)
+	assert.Equal(t, StatusCompensated, inst.Status)
+	assert.Equal(t, []string{"pay", "reserve"}, f.undone)
+	assert.Contains(t, inst.Error, "ship failed")
+	assert.NotEmpty(t, inst.ID)
+}
+
+func TestSaga_FirstStepFails(t *testing.T) {
+	f := &flow{failAt: "reserve"}
+	s := newSaga(t, f, NewMemoryStore())
+
+	inst, err := s.Start(context.Background(), "", order{})
+	require.Error(t, err)
+	assert.Equal(t, StatusCompensated, inst.Status)
+	assert.Empty(t, f.undone)
+}
+
+func TestSaga_CompensationFails(t *testing.T) {
+	f := &flow{failAt: "ship", failUndo: "pay"}
+	s := newSaga(t, f, NewMemoryStore())
+
+	inst, err := s.Start(context.Background(), "", order{})
+	require.Error(t, err)
+	assert.Equal(t, StatusFailed, inst.Status)
+	assert.Equal(t, 1, inst.Step)
+	assert.Contains(t, inst.Error, "undo failed")
+	assert.Empty(t, f.undone)
+}
+
+func TestSaga_AwaitComplete(t *testing.T) {
+	f := &flow{await: "pay"}
+	s := newSaga(t, f, NewMemoryStore())
+	ctx := context.Background()
+
+	inst, err := s.Start(ctx, "o-1", order{})
+	require.NoError(t, err)
+	assert.Equal(t, StatusAwaiting, inst.Status)
+	assert.Equal(t, 1, inst.Step)
+
+	inst, err = s.Complete(ctx, "o-1", func(o *order) error {
+		o.PaymentID = "pay-1"
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, StatusCompleted, inst.Status)
+
+	_, data, err := s.Get(ctx, "o-1")
+	require.NoError(t, err)
+	assert.Equal(t, "pay-1", data.PaymentID)
+	assert.Equal(t, []string{"reserve", "ship"}, data.Log)
+
+	_, err = s.Complete(ctx, "o-1", nil)
+	assert.ErrorIs(t, err, ErrNotAwaiting)
+	_, err = s.Complete(ctx, "missing", nil)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestSaga_AwaitFail(t *testing.T) {
+	f := &flow{await: "pay"}
+	s := newSaga(t, f, NewMemoryStore())
+	ctx := context.Background()
+
+	_, err := s.Start(ctx, "o-1", order{})
+	require.NoError(t, err)
+
+	declined := errors.New("card declined")
+	inst, err := s.Fail(ctx, "o-1", declined)
+	assert.ErrorIs(t, err, declined)
+	assert.Equal(t, StatusCompensated, inst.Status)
+	assert.Equal(t, []string{"pay", "reserve"}, f.undone)
+}
+
+func TestSaga_StepTimeout(t *testing.T) {
+	steps := []Step[order]{{
+		Name: "slow",
+		Action: func(ctx context.Context, _ *order) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+		Timeout: 10 * time.Millisecond,
+	}}
+	s, err := New("order", NewMemoryStore(), steps)
+	require.NoError(t, err)
+
+	_, err = s.Start(context.Background(), "", order{})
+	assert.ErrorIs(t, err, ErrStepTimeout)
+}
+
+func TestSaga_Recover(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	f := &flow{await: "pay"}
+	s := newSaga(t, f, store, WithStepTimeout(time.Millisecond), WithRecoverAfter(time.Minute))
+
+	// awaiting past its deadline
+	_, err := s.Start(ctx, "o-await", order{})
+	require.NoError(t, err)
+
+	// crashed while running "ship"
+	stale := time.Now().Add(-time.Hour)
+	require.NoError(t, store.Create(ctx, &Instance{
+		ID: "o-crashed", Name: "order", Status: StatusRunning, Step: 2,
+		Data: `{"Log":["reserve","pay"]}`, Version: 1, CreatedAt: stale, UpdatedAt: stale,
+	}))
+
+	// running in another process
+	require.NoError(t, store.Create(ctx, &Instance{
+		ID: "o-live", Name: "order", Status: StatusRunning, Step: 2,
+		Data: `{}`, Version: 1, CreatedAt: time.Now(), UpdatedAt: time.Now(),
+	}))
+
+	time.Sleep(5 * time.Millisecond)
+	n, err := s.Recover(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	inst, _, err := s.Get(ctx, "o-await")
+	require.NoError(t, err)
+	assert.Equal(t, StatusCompensated, inst.Status)
+	assert.Contains(t, inst.Error, ErrStepTimeout.Error())
+
+	inst, data, err := s.Get(ctx, "o-crashed")
+	require.NoError(t, err)
+	assert.Equal(t, StatusCompleted, inst.Status)
+	assert.Equal(t, []string{"reserve", "pay", "ship"}, data.Log)
+
+	inst, _, err = s.Get(ctx, "o-live")
+	require.NoError(t, err)
+	assert.Equal(t, StatusRunning, inst.Status)
+}
+
+func TestNew_NoSteps(t *testing.T) {
+	_, err := New[order]("order", NewMemoryStore(), nil)
+	assert.ErrorIs(t, err, ErrNoSteps)
+}
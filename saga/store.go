@@ -0,0 +1,176 @@
+package saga
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/BevisDev/godev/database"
+)
+
+// Store persists saga instances.
+type Store interface {
+	// Create inserts a new instance.
+	Create(ctx context.Context, inst *Instance) error
+
+	// Update saves inst when the stored version is inst.Version-1,
+	// otherwise it returns ErrConflict.
+	Update(ctx context.Context, inst *Instance) error
+
+	// Get returns the instance with id, or ErrNotFound.
+	Get(ctx context.Context, id string) (*Instance, error)
+
+	// Unfinished returns the instances of the saga name that are not done.
+	Unfinished(ctx context.Context, name string) ([]*Instance, error)
+}
+
+// MemoryStore keeps instances in memory; it suits tests and single-process tools.
+type MemoryStore struct {
+	mu        sync.Mutex
+	instances map[string]Instance
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{instances: make(map[string]Instance)}
+}
+
+func (m *MemoryStore) Create(_ context.Context, inst *Instance) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.instances[inst.ID]; ok {
+		return ErrConflict
+	}
+	m.instances[inst.ID] = *inst
+	return nil
+}
+
+func (m *MemoryStore) Update(_ context.Context, inst *Instance) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	cur, ok := m.instances[inst.ID]
+	if !ok || cur.Version != inst.Version-1 {
+		return ErrConflict
+	}
+	m.instances[inst.ID] = *inst
+	return nil
+}
+
+func (m *MemoryStore) Get(_ context.Context, id string) (*Instance, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	inst, ok := m.instances[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &inst, nil
+}
+
+func (m *MemoryStore) Unfinished(_ context.Context, name string) ([]*Instance, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var list []*Instance
+	for _, inst := range m.instances {
+		if inst.Name == name && !inst.Status.Done() {
+			list = append(list, &inst)
+		}
+	}
+	return list, nil
+}
+
+const columns = "id, name, status, step, data, last_error, deadline, version, created_at, updated_at"
+
+// DBStore keeps instances in a database table created with Schema.
+type DBStore struct {
+	db                              *database.DB
+	insert, update, get, unfinished string
+}
+
+// NewDBStore returns a store using table (default "saga_instances").
+func NewDBStore(db *database.DB, table string) *DBStore {
+	if table == "" {
+		table = "saga_instances"
+	}
+	return &DBStore{
+		db: db,
+		insert: fmt.Sprintf("INSERT INTO %s (%s) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+			table, columns),
+		update: fmt.Sprintf("UPDATE %s SET status = ?, step = ?, data = ?, last_error = ?, deadline = ?, "+
+			"version = ?, updated_at = ? WHERE id = ? AND version = ?", table),
+		get: fmt.Sprintf("SELECT %s FROM %s WHERE id = ?", columns, table),
+		unfinished: fmt.Sprintf("SELECT %s FROM %s WHERE name = ? AND status IN (?)",
+			columns, table),
+	}
+}
+
+func (d *DBStore) Create(ctx context.Context, inst *Instance) error {
+	return d.db.Execute(ctx, d.db.GetDB().Rebind(d.insert), nil,
+		inst.ID, inst.Name, string(inst.Status), inst.Step, inst.Data, inst.Error,
+		inst.Deadline, inst.Version, inst.CreatedAt, inst.UpdatedAt,
+	)
+}
+
+func (d *DBStore) Update(ctx context.Context, inst *Instance) error {
+	res, err := d.db.ExecuteResult(ctx, d.db.GetDB().Rebind(d.update), nil,
+		string(inst.Status), inst.Step, inst.Data, inst.Error, inst.Deadline,
+		inst.Version, inst.UpdatedAt, inst.ID, inst.Version-1,
+	)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return ErrConflict
+	}
+	return nil
+}
+
+func (d *DBStore) Get(ctx context.Context, id string) (*Instance, error) {
+	var inst Instance
+	if err := d.db.GetAny(ctx, &inst, d.get, id); err != nil {
+		if d.db.IsNoResult(err) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &inst, nil
+}
+
+func (d *DBStore) Unfinished(ctx context.Context, name string) ([]*Instance, error) {
+	var list []*Instance
+	statuses := []string{string(StatusRunning), string(StatusAwaiting), string(StatusCompensating)}
+	if err := d.db.GetList(ctx, &list, d.unfinished, name, statuses); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// Schema returns the CREATE TABLE statement of the saga table for dbType.
+func Schema(dbType database.DBType, table string) string {
+	var text, ts string
+	switch dbType {
+	case database.SqlServer:
+		text, ts = "NVARCHAR(MAX)", "DATETIME2"
+	case database.Postgres:
+		text, ts = "TEXT", "TIMESTAMP"
+	case database.Oracle:
+		text, ts = "CLOB", "TIMESTAMP"
+	default: // mysql
+		text, ts = "LONGTEXT", "DATETIME(6)"
+	}
+
+	return fmt.Sprintf(`CREATE TABLE %s (
+    id VARCHAR(64) PRIMARY KEY,
+    name VARCHAR(128) NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    step INT NOT NULL,
+    data %s,
+    last_error %s,
+    deadline %s,
+    version INT NOT NULL,
+    created_at %s NOT NULL,
+    updated_at %s NOT NULL
+)`, table, text, text, ts, ts, ts)
+}
@@ -0,0 +1,56 @@
+package saga
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/BevisDev/godev/database"
+	"github.com/BevisDev/godev/database/dbtest"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDBStore(t *testing.T) {
+	db := dbtest.NewMock(t)
+	store := NewDBStore(db.DB, "")
+	ctx := context.Background()
+	now := time.Now()
+
+	inst := &Instance{ID: "o-1", Name: "order", Status: StatusRunning, Data: "{}", Version: 2, UpdatedAt: now}
+	update := regexp.QuoteMeta("UPDATE saga_instances SET status = ?, step = ?, data = ?, last_error = ?, " +
+		"deadline = ?, version = ?, updated_at = ? WHERE id = ? AND version = ?")
+
+	db.Mock.ExpectExec(update).
+		WithArgs("running", 0, "{}", "", nil, 2, now, "o-1", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	db.Mock.ExpectExec(update).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	db.Mock.ExpectQuery(regexp.QuoteMeta("SELECT " + columns + " FROM saga_instances WHERE id = ?")).
+		WithArgs("missing").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	db.Mock.ExpectQuery(regexp.QuoteMeta("SELECT "+columns+" FROM saga_instances WHERE name = ? AND status IN (?, ?, ?)")).
+		WithArgs("order", "running", "awaiting", "compensating").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "status", "step", "data", "last_error", "deadline",
+			"version", "created_at", "updated_at"}).
+			AddRow("o-1", "order", "awaiting", 1, "{}", "", now, 3, now, now))
+
+	require.NoError(t, store.Update(ctx, inst))
+	assert.ErrorIs(t, store.Update(ctx, inst), ErrConflict)
+
+	_, err := store.Get(ctx, "missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	list, err := store.Unfinished(ctx, "order")
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, StatusAwaiting, list[0].Status)
+	assert.NotNil(t, list[0].Deadline)
+}
+
+func TestSchema(t *testing.T) {
+	assert.Contains(t, Schema(database.Postgres, "saga_instances"), "data TEXT")
+	assert.Contains(t, Schema(database.MySQL, "saga_instances"), "deadline DATETIME(6)")
+}
@@ -0,0 +1,8 @@
+package scheduler
+
+import "errors"
+
+var (
+	ErrJobNotFound = errors.New("[scheduler] job not found")
+	ErrNilFunc     = errors.New("[scheduler] func is nil")
+)
@@ -13,4 +13,8 @@ type Job struct {
 	Handler Handler
 	Cron    string // cron expression
 	IsOn    bool   // enable / disable job
+
+	// Misfire is applied on Start to fire times missed while the process
+	// was down; it needs WithRunStore. Default MisfireSkip.
+	Misfire Misfire
 }
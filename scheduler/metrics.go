@@ -0,0 +1,51 @@
+package scheduler
+
+import (
+	"time"
+
+	"github.com/BevisDev/godev/metrics"
+)
+
+// Status label value of a job run that panicked.
+const statusPanic = "panic"
+
+// schedulerMetrics are the metrics recorded with WithMetrics.
+type schedulerMetrics struct {
+	runs    metrics.Timer
+	running metrics.Gauge
+}
+
+func newSchedulerMetrics(p metrics.Provider) *schedulerMetrics {
+	if p == nil {
+		return nil
+	}
+	return &schedulerMetrics{
+		runs: p.Timer(metrics.Opts{
+			Name:   "scheduler_job_duration_seconds",
+			Help:   "Duration of scheduler job runs.",
+			Labels: []string{"job", "status"},
+		}),
+		running: p.Gauge(metrics.Opts{
+			Name:   "scheduler_jobs_running",
+			Help:   "Scheduler job runs in progress.",
+			Labels: []string{"job"},
+		}),
+	}
+}
+
+// start records the start of a run of job and returns the function ending it.
+func (m *schedulerMetrics) start(job string) func(panicked bool) {
+	if m == nil {
+		return func(bool) {}
+	}
+	begin := time.Now()
+	m.running.Add(1, job)
+	return func(panicked bool) {
+		m.running.Add(-1, job)
+		status := metrics.StatusOK
+		if panicked {
+			status = statusPanic
+		}
+		m.runs.Record(time.Since(begin), job, status)
+	}
+}
@@ -0,0 +1,37 @@
+package scheduler
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BevisDev/godev/metrics/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduler_Metrics(t *testing.T) {
+	reg := prometheus.New()
+	s := New(WithMetrics(reg))
+
+	for _, fn := range []func(ctx context.Context){
+		func(ctx context.Context) {},
+		func(ctx context.Context) { panic("boom") },
+	} {
+		task, err := s.ScheduleOnce(context.Background(), 0, fn)
+		require.NoError(t, err)
+		select {
+		case <-task.Done():
+		case <-time.After(time.Second):
+			t.Fatal("task did not run")
+		}
+	}
+
+	var out strings.Builder
+	_, err := reg.WriteTo(&out)
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), `scheduler_job_duration_seconds_count{job="once",status="ok"} 1`)
+	assert.Contains(t, out.String(), `scheduler_job_duration_seconds_count{job="once",status="panic"} 1`)
+	assert.Contains(t, out.String(), `scheduler_jobs_running{job="once"} 0`)
+}
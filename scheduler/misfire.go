@@ -0,0 +1,157 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/BevisDev/godev/utils"
+	"github.com/robfig/cron/v3"
+)
+
+// Misfire decides what happens on Start to fire times a job missed while
+// the process was down. It requires a RunStore (see WithRunStore).
+type Misfire int
+
+const (
+	// MisfireSkip drops missed fire times; the job waits for its next schedule.
+	MisfireSkip Misfire = iota
+
+	// MisfireRunOnce runs the job once for the latest missed fire time.
+	MisfireRunOnce
+
+	// MisfireCatchUp runs the job for every missed fire time, oldest first,
+	// up to WithMaxCatchUp runs.
+	MisfireCatchUp
+)
+
+func (m Misfire) String() string {
+	switch m {
+	case MisfireRunOnce:
+		return "run-once"
+	case MisfireCatchUp:
+		return "catch-up"
+	default:
+		return "skip"
+	}
+}
+
+type fireTimeKey struct{}
+
+type catchUpKey struct{}
+
+// FireTime returns the scheduled fire time of the current run,
+// or the zero time when ctx does not come from a scheduled run.
+func FireTime(ctx context.Context) time.Time {
+	t, _ := ctx.Value(fireTimeKey{}).(time.Time)
+	return t
+}
+
+// IsCatchUp reports whether the current run replays a missed fire time.
+func IsCatchUp(ctx context.Context) bool {
+	ok, _ := ctx.Value(catchUpKey{}).(bool)
+	return ok
+}
+
+func runCtx(fireTime time.Time, catchUp bool) context.Context {
+	ctx := context.WithValue(utils.NewCtx(), fireTimeKey{}, fireTime)
+	if catchUp {
+		ctx = context.WithValue(ctx, catchUpKey{}, true)
+	}
+	return ctx
+}
+
+// missed returns the fire times of spec after last and not after now,
+// keeping at most max of the latest ones.
+func (s *Scheduler) missed(spec string, last, now time.Time, max int) ([]time.Time, int, error) {
+	fields := cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor
+	if s.useSeconds {
+		fields |= cron.Second
+	}
+
+	schedule, err := cron.NewParser(fields).Parse(spec)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var (
+		times []time.Time
+		total int
+	)
+	for t := schedule.Next(last.In(s.location)); !t.IsZero() && !t.After(now); t = schedule.Next(t) {
+		total++
+		times = append(times, t)
+		if len(times) > max {
+			times = times[1:]
+		}
+	}
+	return times, total, nil
+}
+
+// handleMisfire applies the misfire policy of job for fire times missed since its last run.
+func (s *Scheduler) handleMisfire(ctx context.Context, name string, job *Job) {
+	last, err := s.runStore.LastRun(ctx, name)
+	if err != nil {
+		s.log.Error("error get last run of job %s: %v", name, err)
+		return
+	}
+
+	now := time.Now()
+	if last.IsZero() {
+		// first start with a store: nothing to recover, start counting from now
+		s.record(name, now)
+		return
+	}
+
+	times, total, err := s.missed(job.Cron, last, now, s.maxCatchUp)
+	if err != nil || total == 0 {
+		return
+	}
+
+	latest := times[len(times)-1]
+	s.log.Info("job %s missed %d run(s) since %s, policy=%s",
+		name, total, last.In(s.location).Format(time.RFC3339), job.Misfire,
+	)
+
+	switch job.Misfire {
+	case MisfireRunOnce:
+		s.fire(name, job, latest, true)
+	case MisfireCatchUp:
+		if total > len(times) {
+			s.log.Info("job %s catch-up limited to the latest %d run(s)", name, len(times))
+		}
+		for _, t := range times {
+			if ctx.Err() != nil {
+				return
+			}
+			s.fire(name, job, t, true)
+		}
+	default:
+		s.record(name, latest)
+	}
+}
+
+// fire runs job for fireTime and records it as the last run.
+func (s *Scheduler) fire(name string, job *Job, fireTime time.Time, catchUp bool) {
+	s.safeRun(runCtx(fireTime, catchUp), name, job.Handler.Handle)
+	s.record(name, fireTime)
+}
+
+// record persists fireTime as the last run of job, never moving it backwards
+// when a catch-up run finishes after a live run.
+func (s *Scheduler) record(name string, fireTime time.Time) {
+	if s.runStore == nil {
+		return
+	}
+
+	s.mu.Lock()
+	if prev, ok := s.lastRuns[name]; ok && !fireTime.After(prev) {
+		s.mu.Unlock()
+		return
+	}
+	s.lastRuns[name] = fireTime
+	s.mu.Unlock()
+
+	if err := s.runStore.SetLastRun(utils.NewCtx(), name, fireTime); err != nil {
+		s.log.Error("error set last run of job %s: %v", name, err)
+	}
+}
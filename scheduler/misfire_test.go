@@ -0,0 +1,133 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/BevisDev/godev/redis/redistest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fireJob struct {
+	name string
+	mu   sync.Mutex
+	runs []time.Time
+}
+
+func (f *fireJob) Handle(ctx context.Context) {
+	if !IsCatchUp(ctx) {
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.runs = append(f.runs, FireTime(ctx))
+}
+
+func (f *fireJob) JobName() string {
+	return f.name
+}
+
+func (f *fireJob) fired() []time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]time.Time(nil), f.runs...)
+}
+
+// startMissed starts a daily job whose last run was 3 midnights ago.
+func startMissed(t *testing.T, policy Misfire, opts ...Option) (*fireJob, *MemoryStore, time.Time) {
+	t.Helper()
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	store := NewMemoryStore()
+	require.NoError(t, store.SetLastRun(context.Background(), "nightly", today.AddDate(0, 0, -3)))
+
+	job := &fireJob{name: "nightly"}
+	s := New(append([]Option{WithRunStore(store)}, opts...)...)
+	s.Register(&Job{Handler: job, Cron: "0 0 * * *", IsOn: true, Misfire: policy})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	s.Start(ctx)
+
+	return job, store, today
+}
+
+func lastRun(t *testing.T, store RunStore) time.Time {
+	last, err := store.LastRun(context.Background(), "nightly")
+	require.NoError(t, err)
+	return last
+}
+
+func TestMisfire_Skip(t *testing.T) {
+	job, store, today := startMissed(t, MisfireSkip)
+
+	require.Eventually(t, func() bool {
+		return lastRun(t, store).Equal(today)
+	}, time.Second, 10*time.Millisecond)
+	assert.Empty(t, job.fired())
+}
+
+func TestMisfire_RunOnce(t *testing.T) {
+	job, store, today := startMissed(t, MisfireRunOnce)
+
+	require.Eventually(t, func() bool {
+		return lastRun(t, store).Equal(today)
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []time.Time{today}, job.fired())
+}
+
+func TestMisfire_CatchUp(t *testing.T) {
+	job, store, today := startMissed(t, MisfireCatchUp)
+
+	require.Eventually(t, func() bool {
+		return lastRun(t, store).Equal(today)
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []time.Time{today.AddDate(0, 0, -2), today.AddDate(0, 0, -1), today}, job.fired())
+}
+
+func TestMisfire_CatchUpLimit(t *testing.T) {
+	job, store, today := startMissed(t, MisfireCatchUp, WithMaxCatchUp(2))
+
+	require.Eventually(t, func() bool {
+		return lastRun(t, store).Equal(today)
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []time.Time{today.AddDate(0, 0, -1), today}, job.fired())
+}
+
+func TestMisfire_FirstStart(t *testing.T) {
+	store := NewMemoryStore()
+	job := &fireJob{name: "nightly"}
+
+	s := New(WithRunStore(store))
+	s.Register(&Job{Handler: job, Cron: "0 0 * * *", IsOn: true, Misfire: MisfireCatchUp})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.Start(ctx)
+
+	require.Eventually(t, func() bool {
+		return !lastRun(t, store).IsZero()
+	}, time.Second, 10*time.Millisecond)
+	assert.Empty(t, job.fired())
+}
+
+func TestRedisStore(t *testing.T) {
+	cache := redistest.New(t)
+	store := NewRedisStore(cache.Cache)
+	ctx := context.Background()
+
+	last, err := store.LastRun(ctx, "nightly")
+	require.NoError(t, err)
+	assert.True(t, last.IsZero())
+
+	now := time.Now().Truncate(time.Second)
+	require.NoError(t, store.SetLastRun(ctx, "nightly", now))
+	cache.AssertExists("scheduler:last_run:nightly")
+
+	last, err = store.LastRun(ctx, "nightly")
+	require.NoError(t, err)
+	assert.True(t, now.Equal(last))
+}
@@ -3,6 +3,9 @@ package scheduler
 import (
 	"log"
 	"time"
+
+	"github.com/BevisDev/godev/errreport"
+	"github.com/BevisDev/godev/metrics"
 )
 
 type Option func(*options)
@@ -10,12 +13,17 @@ type Option func(*options)
 type options struct {
 	location   *time.Location
 	useSeconds bool
+	runStore   RunStore
+	maxCatchUp int
+	metrics    metrics.Provider
+	reporter   *errreport.Reporter
 }
 
 func defaultOptions() *options {
 	return &options{
 		location:   time.UTC,
 		useSeconds: false,
+		maxCatchUp: 100,
 	}
 }
 
@@ -49,3 +57,36 @@ func WithTimezone(tz string) Option {
 		o.location = loc
 	}
 }
+
+// WithRunStore persists the last fire time of each job, enabling the
+// Misfire policy of jobs on Start.
+func WithRunStore(store RunStore) Option {
+	return func(o *options) {
+		o.runStore = store
+	}
+}
+
+// WithMaxCatchUp caps the runs of a MisfireCatchUp job on Start;
+// only the latest n missed fire times are replayed. Default 100.
+func WithMaxCatchUp(n int) Option {
+	return func(o *options) {
+		if n > 0 {
+			o.maxCatchUp = n
+		}
+	}
+}
+
+// WithMetrics records every job run on p, as scheduler_job_duration_seconds
+// labeled by job and status (ok, panic), and scheduler_jobs_running by job.
+func WithMetrics(p metrics.Provider) Option {
+	return func(o *options) {
+		o.metrics = p
+	}
+}
+
+// WithErrorReporter reports job panics to r, tagged with the job name.
+func WithErrorReporter(r *errreport.Reporter) Option {
+	return func(o *options) {
+		o.reporter = r
+	}
+}
@@ -4,8 +4,9 @@ import (
 	"context"
 	"runtime/debug"
 	"sync"
+	"time"
 
-	"github.com/BevisDev/godev/utils"
+	"github.com/BevisDev/godev/errreport"
 	"github.com/BevisDev/godev/utils/console"
 	"github.com/robfig/cron/v3"
 )
@@ -14,9 +15,13 @@ type Scheduler struct {
 	*options
 	cron    *cron.Cron
 	jobs    map[string]*Job
-	started bool
-	mu      sync.Mutex
-	log     *console.Logger
+	entries map[string]cron.EntryID
+	// lastRuns caches the fire times recorded in runStore
+	lastRuns map[string]time.Time
+	started  bool
+	mu       sync.Mutex
+	log      *console.Logger
+	meter    *schedulerMetrics
 }
 
 func New(opts ...Option) *Scheduler {
@@ -33,10 +38,13 @@ func New(opts ...Option) *Scheduler {
 	}
 
 	return &Scheduler{
-		options: options,
-		cron:    cron.New(cronOpts...),
-		jobs:    make(map[string]*Job),
-		log:     console.New("scheduler"),
+		options:  options,
+		cron:     cron.New(cronOpts...),
+		jobs:     make(map[string]*Job),
+		entries:  make(map[string]cron.EntryID),
+		lastRuns: make(map[string]time.Time),
+		log:      console.New("scheduler"),
+		meter:    newSchedulerMetrics(options.metrics),
 	}
 }
 
@@ -98,25 +106,55 @@ func (s *Scheduler) run() {
 			continue
 		}
 
-		_, err := s.cron.AddFunc(job.Cron, func() {
-			ctx := utils.NewCtx()
-
-			defer func() {
-				if r := recover(); r != nil {
-					s.log.Error("[RECOVER] job %s: %v \npanic: %s",
-						name, r, debug.Stack(),
-					)
-				}
-			}()
-
-			job.Handler.Handle(ctx)
+		id, err := s.cron.AddFunc(job.Cron, func() {
+			s.fire(name, job, time.Now().In(s.location).Truncate(time.Second), false)
 		})
 		if err != nil {
 			s.log.Error("error register job %s: %v", name, err)
+			continue
 		}
+
+		s.mu.Lock()
+		s.entries[name] = id
+		s.mu.Unlock()
+	}
+}
+
+// misfire applies the misfire policy of each scheduled job in the background.
+func (s *Scheduler) misfire(ctx context.Context) {
+	if s.runStore == nil {
+		return
+	}
+
+	s.mu.Lock()
+	jobs := make(map[string]*Job, len(s.entries))
+	for name := range s.entries {
+		jobs[name] = s.jobs[name]
+	}
+	s.mu.Unlock()
+
+	for name, job := range jobs {
+		go s.handleMisfire(ctx, name, job)
 	}
 }
 
+// safeRun runs fn and recovers from panic.
+func (s *Scheduler) safeRun(ctx context.Context, name string, fn func(ctx context.Context)) {
+	done := s.meter.start(name)
+	defer func() {
+		r := recover()
+		done(r != nil)
+		if r != nil {
+			s.log.Error("[RECOVER] job %s: %v \npanic: %s",
+				name, r, debug.Stack(),
+			)
+			s.reporter.CapturePanic(ctx, r, errreport.Tag("job", name))
+		}
+	}()
+
+	fn(ctx)
+}
+
 // Start run all jobs, starts the cron scheduler,
 // and stops it gracefully when the context is canceled.
 func (s *Scheduler) Start(ctx context.Context) {
@@ -130,6 +168,7 @@ func (s *Scheduler) Start(ctx context.Context) {
 	s.mu.Unlock()
 
 	s.run()
+	s.misfire(ctx)
 
 	if len(s.cron.Entries()) == 0 {
 		s.log.Info("no jobs registered")
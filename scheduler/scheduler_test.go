@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/BevisDev/godev/errreport"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -243,3 +244,31 @@ func TestScheduler_Start_NoJobsRegistered(t *testing.T) {
 	assert.NotPanics(t, func() { s.Start(ctx) })
 	assert.Len(t, s.cron.Entries(), 0)
 }
+
+type eventRecorder struct {
+	events chan *errreport.Event
+}
+
+func (r *eventRecorder) Send(_ context.Context, ev *errreport.Event) error {
+	r.events <- ev
+	return nil
+}
+
+func TestScheduler_ErrorReporter(t *testing.T) {
+	rec := &eventRecorder{events: make(chan *errreport.Event, 1)}
+	reporter, err := errreport.New(rec)
+	require.NoError(t, err)
+
+	s := New(WithErrorReporter(reporter))
+	s.safeRun(context.Background(), "job1", func(ctx context.Context) {
+		panic("boom")
+	})
+
+	select {
+	case ev := <-rec.events:
+		assert.Equal(t, "boom", ev.Message)
+		assert.Equal(t, "job1", ev.Tags["job"])
+	case <-time.After(time.Second):
+		t.Fatal("panic was not reported")
+	}
+}
@@ -0,0 +1,125 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/BevisDev/godev/database"
+	"github.com/BevisDev/godev/redis"
+)
+
+// RunStore persists the last fire time of each job, so misfires across
+// restarts can be detected (see WithRunStore and Misfire).
+type RunStore interface {
+	// LastRun returns the last fire time of job, or the zero time when it never ran.
+	LastRun(ctx context.Context, job string) (time.Time, error)
+
+	// SetLastRun records the fire time of a run of job.
+	SetLastRun(ctx context.Context, job string, t time.Time) error
+}
+
+// MemoryStore keeps last runs in memory; it only survives restarts within
+// the same process and suits tests.
+type MemoryStore struct {
+	mu   sync.Mutex
+	runs map[string]time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{runs: make(map[string]time.Time)}
+}
+
+func (m *MemoryStore) LastRun(_ context.Context, job string) (time.Time, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.runs[job], nil
+}
+
+func (m *MemoryStore) SetLastRun(_ context.Context, job string, t time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.runs[job] = t
+	return nil
+}
+
+// RedisStore keeps last runs in Redis under "scheduler:last_run:<job>".
+type RedisStore struct {
+	cache *redis.Cache
+}
+
+func NewRedisStore(cache *redis.Cache) *RedisStore {
+	return &RedisStore{cache: cache}
+}
+
+func (r *RedisStore) LastRun(ctx context.Context, job string) (time.Time, error) {
+	return redis.With[time.Time](r.cache).Key(r.key(job)).Get(ctx)
+}
+
+func (r *RedisStore) SetLastRun(ctx context.Context, job string, t time.Time) error {
+	return redis.With[time.Time](r.cache).Key(r.key(job)).Value(t).Set(ctx)
+}
+
+func (r *RedisStore) key(job string) string {
+	return "scheduler:last_run:" + job
+}
+
+// DBStore keeps last runs in a database table created with Schema.
+type DBStore struct {
+	db                  *database.DB
+	get, update, insert string
+}
+
+// NewDBStore returns a store using table (default "scheduler_runs").
+func NewDBStore(db *database.DB, table string) *DBStore {
+	if table == "" {
+		table = "scheduler_runs"
+	}
+	return &DBStore{
+		db:     db,
+		get:    fmt.Sprintf("SELECT last_run FROM %s WHERE job_name = ?", table),
+		update: fmt.Sprintf("UPDATE %s SET last_run = ? WHERE job_name = ?", table),
+		insert: fmt.Sprintf("INSERT INTO %s (job_name, last_run) VALUES (?, ?)", table),
+	}
+}
+
+func (d *DBStore) LastRun(ctx context.Context, job string) (time.Time, error) {
+	var t time.Time
+	if err := d.db.GetAny(ctx, &t, d.get, job); err != nil {
+		if d.db.IsNoResult(err) {
+			return time.Time{}, nil
+		}
+		return time.Time{}, err
+	}
+	return t, nil
+}
+
+func (d *DBStore) SetLastRun(ctx context.Context, job string, t time.Time) error {
+	res, err := d.db.ExecuteResult(ctx, d.db.GetDB().Rebind(d.update), nil, t, job)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n > 0 {
+		return nil
+	}
+	return d.db.Execute(ctx, d.db.GetDB().Rebind(d.insert), nil, job, t)
+}
+
+// Schema returns the CREATE TABLE statement of the last-run table for dbType.
+func Schema(dbType database.DBType, table string) string {
+	var ts string
+	switch dbType {
+	case database.SqlServer:
+		ts = "DATETIME2"
+	case database.Postgres, database.Oracle:
+		ts = "TIMESTAMP"
+	default: // mysql
+		ts = "DATETIME(6)"
+	}
+
+	return fmt.Sprintf(`CREATE TABLE %s (
+    job_name VARCHAR(128) PRIMARY KEY,
+    last_run %s NOT NULL
+)`, table, ts)
+}
@@ -0,0 +1,102 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/BevisDev/godev/utils"
+)
+
+// onceTaskName is used in logs for tasks created by ScheduleOnce.
+const onceTaskName = "once"
+
+// Task is a handle to a one-off task created by ScheduleOnce.
+type Task struct {
+	mu      sync.Mutex
+	timer   *time.Timer
+	done    chan struct{}
+	stopCtx func() bool
+}
+
+// Cancel stops the task before it runs. It returns false when the task
+// already started (or finished) or was canceled before.
+func (t *Task) Cancel() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.timer.Stop() {
+		return false
+	}
+	t.stopCtx()
+	close(t.done)
+	return true
+}
+
+// Done is closed when the task finished running or was canceled.
+func (t *Task) Done() <-chan struct{} {
+	return t.done
+}
+
+// ScheduleOnce runs fn once after delay with ctx, independently of Start.
+// The task is canceled when ctx is done before delay elapses; pass a
+// long-lived context for work that must outlive the current request.
+// Panics in fn are recovered.
+func (s *Scheduler) ScheduleOnce(ctx context.Context, delay time.Duration, fn func(ctx context.Context)) (*Task, error) {
+	if fn == nil {
+		return nil, ErrNilFunc
+	}
+
+	t := &Task{done: make(chan struct{})}
+
+	// hold the lock until both fields are set, a short delay may fire first
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.timer = time.AfterFunc(delay, func() {
+		defer close(t.done)
+
+		t.mu.Lock()
+		t.stopCtx()
+		t.mu.Unlock()
+
+		if ctx.Err() != nil {
+			return
+		}
+		s.safeRun(ctx, onceTaskName, fn)
+	})
+	t.stopCtx = context.AfterFunc(ctx, func() { t.Cancel() })
+
+	return t, nil
+}
+
+// TriggerNow runs the registered job jobName immediately in a new goroutine,
+// outside its cron schedule. It works whether the job is on or off and
+// whether the scheduler was started.
+func (s *Scheduler) TriggerNow(jobName string) error {
+	s.mu.Lock()
+	job, ok := s.jobs[jobName]
+	s.mu.Unlock()
+	if !ok {
+		return ErrJobNotFound
+	}
+
+	go s.safeRun(utils.NewCtx(), jobName, job.Handler.Handle)
+	return nil
+}
+
+// Cancel removes the cron schedule of the registered job jobName; runs in
+// progress are not interrupted. It returns false when the job is not scheduled.
+func (s *Scheduler) Cancel(jobName string) bool {
+	s.mu.Lock()
+	id, ok := s.entries[jobName]
+	delete(s.entries, jobName)
+	s.mu.Unlock()
+	if !ok {
+		return false
+	}
+
+	s.cron.Remove(id)
+	s.log.Info("job %s canceled", jobName)
+	return true
+}
@@ -0,0 +1,107 @@
+package scheduler
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleOnce_Runs(t *testing.T) {
+	s := New()
+	var called int32
+
+	task, err := s.ScheduleOnce(context.Background(), 20*time.Millisecond, func(ctx context.Context) {
+		atomic.AddInt32(&called, 1)
+	})
+	require.NoError(t, err)
+
+	select {
+	case <-task.Done():
+	case <-time.After(time.Second):
+		t.Fatal("task did not run")
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&called))
+	assert.False(t, task.Cancel(), "finished task cannot be canceled")
+}
+
+func TestScheduleOnce_Cancel(t *testing.T) {
+	s := New()
+	var called int32
+
+	task, err := s.ScheduleOnce(context.Background(), 50*time.Millisecond, func(ctx context.Context) {
+		atomic.AddInt32(&called, 1)
+	})
+	require.NoError(t, err)
+
+	assert.True(t, task.Cancel())
+	assert.False(t, task.Cancel())
+	<-task.Done()
+
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&called))
+}
+
+func TestScheduleOnce_ContextCanceled(t *testing.T) {
+	s := New()
+	var called int32
+
+	ctx, cancel := context.WithCancel(context.Background())
+	task, err := s.ScheduleOnce(ctx, 50*time.Millisecond, func(ctx context.Context) {
+		atomic.AddInt32(&called, 1)
+	})
+	require.NoError(t, err)
+	cancel()
+
+	select {
+	case <-task.Done():
+	case <-time.After(time.Second):
+		t.Fatal("task was not canceled")
+	}
+	assert.Equal(t, int32(0), atomic.LoadInt32(&called))
+}
+
+func TestScheduleOnce_PanicRecovered(t *testing.T) {
+	s := New()
+
+	task, err := s.ScheduleOnce(context.Background(), 0, func(ctx context.Context) {
+		panic("boom")
+	})
+	require.NoError(t, err)
+	<-task.Done()
+
+	_, err = s.ScheduleOnce(context.Background(), 0, nil)
+	assert.ErrorIs(t, err, ErrNilFunc)
+}
+
+func TestTriggerNow(t *testing.T) {
+	s := New()
+	job := &mockJob{name: "job1", done: make(chan struct{})}
+	s.Register(&Job{Handler: job, Cron: "0 0 1 1 *", IsOn: false})
+
+	require.NoError(t, s.TriggerNow("job1"))
+	select {
+	case <-job.done:
+	case <-time.After(time.Second):
+		t.Fatal("job was not triggered")
+	}
+
+	assert.ErrorIs(t, s.TriggerNow("missing"), ErrJobNotFound)
+}
+
+func TestScheduler_Cancel(t *testing.T) {
+	s := New()
+	s.Register(&Job{Handler: &mockJob{name: "job1"}, Cron: "@every 1s", IsOn: true})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.Start(ctx)
+	require.Len(t, s.cron.Entries(), 1)
+
+	assert.True(t, s.Cancel("job1"))
+	assert.False(t, s.Cancel("job1"))
+	assert.Len(t, s.cron.Entries(), 0)
+}
@@ -0,0 +1,11 @@
+package session
+
+import "errors"
+
+// Errors
+var (
+	ErrCacheNil        = errors.New("[session] redis cache is nil")
+	ErrNoUser          = errors.New("[session] user id is empty")
+	ErrNotFound        = errors.New("[session] session not found or expired")
+	ErrTooManySessions = errors.New("[session] too many concurrent sessions")
+)
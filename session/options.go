@@ -0,0 +1,59 @@
+package session
+
+import "time"
+
+type Option func(*options)
+
+type options struct {
+	idleTimeout time.Duration
+	maxLifetime time.Duration
+	maxPerUser  int
+	rejectNew   bool
+	prefix      string
+}
+
+func defaultOptions() *options {
+	return &options{
+		idleTimeout: 30 * time.Minute,
+		prefix:      "session:",
+	}
+}
+
+// WithIdleTimeout sets how long a session lives without activity (default
+// 30 minutes). Every Get slides the expiration.
+func WithIdleTimeout(d time.Duration) Option {
+	return func(o *options) {
+		if d > 0 {
+			o.idleTimeout = d
+		}
+	}
+}
+
+// WithMaxLifetime caps the lifetime of a session however active it is
+// (default 0, no cap), e.g. 12h to force a daily login.
+func WithMaxLifetime(d time.Duration) Option {
+	return func(o *options) {
+		if d >= 0 {
+			o.maxLifetime = d
+		}
+	}
+}
+
+// WithMaxPerUser limits the concurrent sessions of a user (default 0, no
+// limit). Creating one more ends the oldest sessions of the user, or
+// fails with ErrTooManySessions when reject is true.
+func WithMaxPerUser(n int, reject bool) Option {
+	return func(o *options) {
+		if n >= 0 {
+			o.maxPerUser = n
+			o.rejectNew = reject
+		}
+	}
+}
+
+// WithKeyPrefix sets the prefix of the Redis keys (default "session:").
+func WithKeyPrefix(prefix string) Option {
+	return func(o *options) {
+		o.prefix = prefix
+	}
+}
@@ -0,0 +1,281 @@
+// Package session keeps server-side sessions in Redis for browser-based
+// portals: opaque IDs, typed data, sliding expiration, concurrent session
+// limits and invalidation of every session of a user.
+//
+// The ginfw/middleware/session middleware loads and saves sessions from a cookie.
+package session
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/BevisDev/godev/redis"
+	"github.com/BevisDev/godev/utils/ctxmeta"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// Session is a session of a user with typed data T.
+type Session[T any] struct {
+	ID        string    `json:"-"`
+	UserID    string    `json:"user_id"`
+	Data      T         `json:"data"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// createScript drops the index entries of expired sessions, applies the
+// session limit and stores the new session, atomically so concurrent logins
+// cannot exceed the limit. It returns the evicted IDs, or nil when the
+// limit rejects the session.
+//
+// KEYS: index, session. ARGV: id, score, payload, ttl ms, max, reject,
+// session key prefix, index ttl ms.
+var createScript = goredis.NewScript(`
+local ids = redis.call('ZRANGE', KEYS[1], 0, -1)
+local live = {}
+for _, id in ipairs(ids) do
+	if redis.call('EXISTS', ARGV[7] .. id) == 1 then
+		table.insert(live, id)
+	else
+		redis.call('ZREM', KEYS[1], id)
+	end
+end
+local max = tonumber(ARGV[5])
+local evicted = {}
+if max > 0 and #live >= max then
+	if ARGV[6] == '1' then return false end
+	for i = 1, #live - max + 1 do
+		redis.call('DEL', ARGV[7] .. live[i])
+		redis.call('ZREM', KEYS[1], live[i])
+		table.insert(evicted, live[i])
+	end
+end
+redis.call('SET', KEYS[2], ARGV[3], 'PX', ARGV[4])
+redis.call('ZADD', KEYS[1], ARGV[2], ARGV[1])
+redis.call('PEXPIRE', KEYS[1], ARGV[8])
+return evicted
+`)
+
+// Manager creates, loads and ends sessions with data T.
+type Manager[T any] struct {
+	*options
+	cache *redis.Cache
+}
+
+// New creates a Manager storing sessions in cache.
+func New[T any](cache *redis.Cache, opts ...Option) (*Manager[T], error) {
+	if cache == nil {
+		return nil, ErrCacheNil
+	}
+
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &Manager[T]{options: o, cache: cache}, nil
+}
+
+func (m *Manager[T]) key(id string) string {
+	return m.cache.Key(m.prefix + id)
+}
+
+func (m *Manager[T]) indexKey(userID string) string {
+	return m.cache.Key(m.prefix + "user:" + userID)
+}
+
+// ttl returns the remaining lifetime of s: the idle timeout, capped by the
+// max lifetime.
+func (m *Manager[T]) ttl(s *Session[T]) time.Duration {
+	ttl := m.idleTimeout
+	if m.maxLifetime > 0 {
+		ttl = min(ttl, time.Until(s.CreatedAt.Add(m.maxLifetime)))
+	}
+	return ttl
+}
+
+// Create starts a session of userID with data. Over the session limit, the
+// oldest sessions of the user end, or ErrTooManySessions is returned.
+func (m *Manager[T]) Create(ctx context.Context, userID string, data T) (*Session[T], error) {
+	if userID == "" {
+		return nil, ErrNoUser
+	}
+	id, err := NewID()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Session[T]{ID: id, UserID: userID, Data: data, CreatedAt: time.Now()}
+	payload, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+
+	reject := "0"
+	if m.rejectNew {
+		reject = "1"
+	}
+	err = createScript.Run(ctx, m.cache.GetClient(),
+		[]string{m.indexKey(userID), m.key(id)},
+		id, s.CreatedAt.UnixMilli(), payload, m.ttl(s).Milliseconds(),
+		m.maxPerUser, reject, m.cache.Key(m.prefix), m.idleTimeout.Milliseconds(),
+	).Err()
+	if errors.Is(err, goredis.Nil) {
+		return nil, ErrTooManySessions
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Get loads the session id and slides its expiration. A missing or
+// expired session returns ErrNotFound.
+func (m *Manager[T]) Get(ctx context.Context, id string) (*Session[T], error) {
+	if id == "" {
+		return nil, ErrNotFound
+	}
+	client := m.cache.GetClient()
+
+	raw, err := client.Get(ctx, m.key(id)).Bytes()
+	if errors.Is(err, goredis.Nil) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Session[T]{ID: id}
+	if err := json.Unmarshal(raw, s); err != nil {
+		return nil, err
+	}
+
+	ttl := m.ttl(s)
+	if ttl <= 0 {
+		_ = m.Destroy(ctx, s)
+		return nil, ErrNotFound
+	}
+	_, err = client.Pipelined(ctx, func(p goredis.Pipeliner) error {
+		p.PExpire(ctx, m.key(id), ttl)
+		p.PExpire(ctx, m.indexKey(s.UserID), m.idleTimeout)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Save stores the data of s and slides its expiration. A session ended
+// meanwhile is not recreated: Save returns ErrNotFound.
+func (m *Manager[T]) Save(ctx context.Context, s *Session[T]) error {
+	payload, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	ttl := m.ttl(s)
+	if ttl <= 0 {
+		return ErrNotFound
+	}
+
+	err = m.cache.GetClient().SetArgs(ctx, m.key(s.ID), payload, goredis.SetArgs{Mode: "XX", TTL: ttl}).Err()
+	if errors.Is(err, goredis.Nil) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// Destroy ends s, e.g. on logout.
+func (m *Manager[T]) Destroy(ctx context.Context, s *Session[T]) error {
+	_, err := m.cache.GetClient().TxPipelined(ctx, func(p goredis.Pipeliner) error {
+		p.Del(ctx, m.key(s.ID))
+		p.ZRem(ctx, m.indexKey(s.UserID), s.ID)
+		return nil
+	})
+	return err
+}
+
+// DestroyUser ends every session of userID, e.g. after a password change or
+// when an administrator disables the account, and returns how many ended.
+func (m *Manager[T]) DestroyUser(ctx context.Context, userID string) (int, error) {
+	client := m.cache.GetClient()
+	index := m.indexKey(userID)
+
+	ids, err := client.ZRange(ctx, index, 0, -1).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	keys := make([]string, 0, len(ids)+1)
+	for _, id := range ids {
+		keys = append(keys, m.key(id))
+	}
+	n, err := client.Del(ctx, append(keys, index)...).Result()
+	if err != nil {
+		return 0, err
+	}
+	if len(ids) > 0 {
+		// the index itself was deleted too
+		n--
+	}
+	return int(n), nil
+}
+
+// List returns the live sessions of userID, oldest first, e.g. for a
+// "signed in devices" page.
+func (m *Manager[T]) List(ctx context.Context, userID string) ([]*Session[T], error) {
+	client := m.cache.GetClient()
+
+	ids, err := client.ZRange(ctx, m.indexKey(userID), 0, -1).Result()
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = m.key(id)
+	}
+	values, err := client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*Session[T], 0, len(ids))
+	for i, v := range values {
+		raw, ok := v.(string)
+		if !ok {
+			continue
+		}
+		s := &Session[T]{ID: ids[i]}
+		if err := json.Unmarshal([]byte(raw), s); err != nil {
+			return nil, err
+		}
+		out = append(out, s)
+	}
+	return out, nil
+}
+
+// NewID returns a random 256-bit session ID in URL-safe base64.
+func NewID() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+type ctxKey struct{}
+
+// WithSession stores s in ctx, with its user as ctxmeta.UserID.
+func WithSession[T any](ctx context.Context, s *Session[T]) context.Context {
+	ctx = ctxmeta.WithUserID(ctx, s.UserID)
+	return context.WithValue(ctx, ctxKey{}, s)
+}
+
+// FromContext returns the session stored by WithSession, or nil.
+func FromContext[T any](ctx context.Context) *Session[T] {
+	s, _ := ctx.Value(ctxKey{}).(*Session[T])
+	return s
+}
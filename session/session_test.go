@@ -0,0 +1,198 @@
+package session
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/BevisDev/godev/redis/redistest"
+	"github.com/BevisDev/godev/utils/ctxmeta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type portal struct {
+	Name  string   `json:"name"`
+	Roles []string `json:"roles"`
+}
+
+func newManager(t *testing.T, opts ...Option) (*Manager[portal], *redistest.TestCache) {
+	c := redistest.New(t)
+	m, err := New[portal](c.Cache, opts...)
+	require.NoError(t, err)
+	return m, c
+}
+
+func TestCreateGet(t *testing.T) {
+	m, c := newManager(t, WithIdleTimeout(10*time.Minute))
+	ctx := context.Background()
+
+	s, err := m.Create(ctx, "u1", portal{Name: "Alice", Roles: []string{"admin"}})
+	require.NoError(t, err)
+	assert.Len(t, s.ID, 43)
+	c.AssertTTL("session:"+s.ID, 10*time.Minute)
+	c.AssertExists("session:user:u1")
+
+	c.FastForward(9 * time.Minute)
+	got, err := m.Get(ctx, s.ID)
+	require.NoError(t, err)
+	assert.Equal(t, s.ID, got.ID)
+	assert.Equal(t, "u1", got.UserID)
+	assert.Equal(t, portal{Name: "Alice", Roles: []string{"admin"}}, got.Data)
+	c.AssertTTL("session:"+s.ID, 10*time.Minute)
+
+	c.FastForward(9 * time.Minute)
+	_, err = m.Get(ctx, s.ID)
+	require.NoError(t, err, "activity slides the expiration")
+
+	c.FastForward(11 * time.Minute)
+	_, err = m.Get(ctx, s.ID)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = m.Get(ctx, "")
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = m.Create(ctx, "", portal{})
+	assert.ErrorIs(t, err, ErrNoUser)
+}
+
+func TestMaxLifetime(t *testing.T) {
+	m, c := newManager(t, WithMaxLifetime(time.Hour))
+	ctx := context.Background()
+
+	s, err := m.Create(ctx, "u1", portal{})
+	require.NoError(t, err)
+
+	// as if the session were created 59 minutes ago: one minute left
+	s.CreatedAt = time.Now().Add(-59 * time.Minute)
+	require.NoError(t, m.Save(ctx, s))
+	_, err = m.Get(ctx, s.ID)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, c.Server.TTL("session:"+s.ID), time.Minute)
+
+	s.CreatedAt = time.Now().Add(-61 * time.Minute)
+	assert.ErrorIs(t, m.Save(ctx, s), ErrNotFound)
+}
+
+func TestSaveDestroy(t *testing.T) {
+	m, c := newManager(t)
+	ctx := context.Background()
+
+	s, err := m.Create(ctx, "u1", portal{Name: "Alice"})
+	require.NoError(t, err)
+
+	s.Data.Roles = []string{"viewer"}
+	require.NoError(t, m.Save(ctx, s))
+	got, err := m.Get(ctx, s.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"viewer"}, got.Data.Roles)
+
+	require.NoError(t, m.Destroy(ctx, s))
+	c.AssertMissing("session:" + s.ID)
+	assert.ErrorIs(t, m.Save(ctx, s), ErrNotFound, "an ended session is not recreated")
+	c.AssertMissing("session:" + s.ID)
+}
+
+func TestMaxPerUser_Evict(t *testing.T) {
+	m, c := newManager(t, WithMaxPerUser(2, false))
+	ctx := context.Background()
+
+	s1, err := m.Create(ctx, "u1", portal{Name: "laptop"})
+	require.NoError(t, err)
+	time.Sleep(2 * time.Millisecond)
+	s2, err := m.Create(ctx, "u1", portal{Name: "phone"})
+	require.NoError(t, err)
+	time.Sleep(2 * time.Millisecond)
+	s3, err := m.Create(ctx, "u1", portal{Name: "tablet"})
+	require.NoError(t, err)
+
+	c.AssertMissing("session:" + s1.ID)
+	list, err := m.List(ctx, "u1")
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, s2.ID, list[0].ID)
+	assert.Equal(t, s3.ID, list[1].ID)
+
+	// ended sessions free their slot
+	require.NoError(t, m.Destroy(ctx, s2))
+	_, err = m.Create(ctx, "u1", portal{})
+	require.NoError(t, err)
+	c.AssertExists("session:" + s3.ID)
+}
+
+func TestMaxPerUser_Reject(t *testing.T) {
+	m, c := newManager(t, WithMaxPerUser(1, true), WithIdleTimeout(time.Minute))
+	ctx := context.Background()
+
+	_, err := m.Create(ctx, "u1", portal{})
+	require.NoError(t, err)
+	_, err = m.Create(ctx, "u1", portal{})
+	assert.ErrorIs(t, err, ErrTooManySessions)
+	_, err = m.Create(ctx, "u2", portal{})
+	require.NoError(t, err, "the limit is per user")
+
+	c.FastForward(2 * time.Minute)
+	_, err = m.Create(ctx, "u1", portal{})
+	require.NoError(t, err)
+}
+
+func TestMaxPerUser_Concurrent(t *testing.T) {
+	m, _ := newManager(t, WithMaxPerUser(3, true))
+	ctx := context.Background()
+
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+		ok int
+	)
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := m.Create(ctx, "u1", portal{}); err == nil {
+				mu.Lock()
+				ok++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 3, ok)
+}
+
+func TestDestroyUser(t *testing.T) {
+	m, c := newManager(t)
+	ctx := context.Background()
+
+	a, _ := m.Create(ctx, "u1", portal{})
+	b, _ := m.Create(ctx, "u1", portal{})
+	other, _ := m.Create(ctx, "u2", portal{})
+
+	n, err := m.DestroyUser(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	c.AssertMissing("session:" + a.ID)
+	c.AssertMissing("session:" + b.ID)
+	c.AssertMissing("session:user:u1")
+	c.AssertExists("session:" + other.ID)
+
+	n, err = m.DestroyUser(ctx, "u1")
+	require.NoError(t, err)
+	assert.Zero(t, n)
+	list, err := m.List(ctx, "u1")
+	require.NoError(t, err)
+	assert.Empty(t, list)
+}
+
+func TestContext(t *testing.T) {
+	s := &Session[portal]{ID: "s1", UserID: "u1"}
+	ctx := WithSession(context.Background(), s)
+
+	assert.Same(t, s, FromContext[portal](ctx))
+	assert.Equal(t, "u1", ctxmeta.UserID(ctx))
+	assert.Nil(t, FromContext[string](ctx), "the data type must match")
+	assert.Nil(t, FromContext[portal](context.Background()))
+
+	_, err := New[portal](nil)
+	assert.ErrorIs(t, err, ErrCacheNil)
+}
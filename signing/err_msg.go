@@ -0,0 +1,15 @@
+package signing
+
+import "errors"
+
+// Errors
+var (
+	ErrKeyNil           = errors.New("[signing] key is nil")
+	ErrMissingSignature = errors.New("[signing] missing signature")
+	ErrMalformed        = errors.New("[signing] malformed signature header")
+	ErrUnknownKey       = errors.New("[signing] unknown key id")
+	ErrExpired          = errors.New("[signing] timestamp outside the allowed clock skew")
+	ErrBodyMismatch     = errors.New("[signing] body does not match its hash")
+	ErrInvalidSignature = errors.New("[signing] invalid signature")
+	ErrUnsignedHeader   = errors.New("[signing] required header not signed")
+)
@@ -0,0 +1,45 @@
+package signing
+
+import "time"
+
+type Option func(*options)
+
+type options struct {
+	headers []string
+	skew    time.Duration
+	now     func() time.Time
+}
+
+func defaultOptions() *options {
+	return &options{
+		skew: 5 * time.Minute,
+		now:  time.Now,
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	o.headers = normalizeHeaders(o.headers)
+	return o
+}
+
+// WithSignedHeaders sets the headers covered by the signature, e.g. "host"
+// and "content-type". A Verifier rejects signatures that do not cover them.
+func WithSignedHeaders(names ...string) Option {
+	return func(o *options) {
+		o.headers = append(o.headers, names...)
+	}
+}
+
+// WithSkew sets how far the timestamp of a request may be from the clock of
+// the Verifier, in either direction (default 5 minutes).
+func WithSkew(d time.Duration) Option {
+	return func(o *options) {
+		if d > 0 {
+			o.skew = d
+		}
+	}
+}
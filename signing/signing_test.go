@@ -0,0 +1,164 @@
+package signing
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStringToSign(t *testing.T) {
+	req := httptest.NewRequest("post", "http://api.partner.vn/v1/orders%2F1?b=2&a=x+y&a=1", nil)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Add("X-Tag", " a ")
+	req.Header.Add("X-Tag", "b")
+
+	got := StringToSign(req, []string{"host", "content-type", "x-tag"}, "1700000000", HashBody([]byte("{}")))
+	assert.Equal(t, strings.Join([]string{
+		"POST",
+		"/v1/orders%2F1",
+		"a=1&a=x%20y&b=2",
+		"host:api.partner.vn",
+		"content-type:application/json",
+		"x-tag:a,b",
+		"1700000000",
+		"44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a",
+	}, "\n"), got)
+	assert.Equal(t, []string{" a ", "b"}, req.Header.Values("X-Tag"), "headers are not modified")
+
+	reordered := httptest.NewRequest("POST", "http://api.partner.vn/v1/orders%2F1?a=1&b=2&a=x%20y", nil)
+	reordered.Header = req.Header
+	assert.Equal(t, got, StringToSign(reordered, []string{"host", "content-type", "x-tag"}, "1700000000", HashBody([]byte("{}"))))
+}
+
+func newRequest(body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "http://api.partner.vn/v1/payments?ref=42", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
+
+func TestHMAC(t *testing.T) {
+	secret := []byte("shared-secret")
+	signer, err := NewHMACSigner("partner-1", secret, WithSignedHeaders("Host", "Content-Type"))
+	require.NoError(t, err)
+	verifier, err := NewVerifier(StaticKeys(map[string]any{"partner-1": secret}), WithSignedHeaders("content-type"))
+	require.NoError(t, err)
+
+	body := []byte(`{"amount":1000}`)
+	req := newRequest(string(body))
+	require.NoError(t, signer.SignRequest(req, body))
+	assert.True(t, strings.HasPrefix(req.Header.Get(HeaderSignature), "HMAC-SHA256 KeyId=partner-1, SignedHeaders=host;content-type, Signature="))
+	assert.Equal(t, HashBody(body), req.Header.Get(HeaderContentSHA256))
+
+	keyID, err := verifier.Verify(req, body)
+	require.NoError(t, err)
+	assert.Equal(t, "partner-1", keyID)
+
+	_, err = verifier.Verify(req, []byte(`{"amount":9000}`))
+	assert.ErrorIs(t, err, ErrBodyMismatch)
+
+	tampered := req.Clone(context.Background())
+	tampered.URL.RawQuery = "ref=43"
+	_, err = verifier.Verify(tampered, body)
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+
+	tampered = req.Clone(context.Background())
+	tampered.Header.Set("Content-Type", "text/plain")
+	_, err = verifier.Verify(tampered, body)
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+
+	other, _ := NewVerifier(StaticKeys(map[string]any{"partner-1": []byte("other")}))
+	_, err = other.Verify(req, body)
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+
+	unknown, _ := NewVerifier(StaticKeys(nil))
+	_, err = unknown.Verify(req, body)
+	assert.ErrorIs(t, err, ErrUnknownKey)
+}
+
+func TestRSA(t *testing.T) {
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	verifier, _ := NewVerifier(func(_ context.Context, keyID string, alg Algorithm) (any, error) {
+		if keyID != "bank" {
+			return nil, ErrUnknownKey
+		}
+		return &priv.PublicKey, nil
+	})
+
+	for _, alg := range []Algorithm{RSASHA256, RSAPSS} {
+		signer, err := NewRSASigner("bank", priv, alg)
+		require.NoError(t, err)
+
+		req := newRequest("")
+		require.NoError(t, signer.SignRequest(req, nil))
+		_, err = verifier.Verify(req, nil)
+		assert.NoError(t, err, alg)
+
+		req.Header.Set(HeaderSignature, strings.Replace(req.Header.Get(HeaderSignature), string(alg), string(HMACSHA256), 1))
+		_, err = verifier.Verify(req, nil)
+		assert.Error(t, err, "an RSA key does not verify HMAC")
+	}
+
+	_, err = NewRSASigner("bank", priv, HMACSHA256)
+	assert.Error(t, err)
+	_, err = NewRSASigner("bank", nil, RSASHA256)
+	assert.ErrorIs(t, err, ErrKeyNil)
+}
+
+func TestVerify_Skew(t *testing.T) {
+	secret := []byte("s")
+	signer, _ := NewHMACSigner("k", secret)
+	verifier, _ := NewVerifier(StaticKeys(map[string]any{"k": secret}), WithSkew(time.Minute))
+
+	for _, tt := range []struct {
+		offset time.Duration
+		err    error
+	}{
+		{0, nil},
+		{50 * time.Second, nil},
+		{-50 * time.Second, nil},
+		{2 * time.Minute, ErrExpired},
+		{-2 * time.Minute, ErrExpired},
+	} {
+		signer.now = func() time.Time { return time.Now().Add(tt.offset) }
+		req := newRequest("")
+		require.NoError(t, signer.SignRequest(req, nil))
+		_, err := verifier.Verify(req, nil)
+		if tt.err == nil {
+			assert.NoError(t, err, tt.offset)
+		} else {
+			assert.ErrorIs(t, err, tt.err, tt.offset)
+		}
+	}
+}
+
+func TestVerify_Malformed(t *testing.T) {
+	verifier, _ := NewVerifier(StaticKeys(map[string]any{"k": []byte("s")}), WithSignedHeaders("host"))
+	signer, _ := NewHMACSigner("k", []byte("s"))
+
+	_, err := verifier.Verify(newRequest(""), nil)
+	assert.ErrorIs(t, err, ErrMissingSignature)
+
+	req := newRequest("")
+	req.Header.Set(HeaderSignature, "garbage")
+	_, err = verifier.Verify(req, nil)
+	assert.ErrorIs(t, err, ErrMalformed)
+
+	req = newRequest("")
+	require.NoError(t, signer.SignRequest(req, nil))
+	_, err = verifier.Verify(req, nil)
+	assert.ErrorIs(t, err, ErrUnsignedHeader)
+
+	_, err = NewVerifier(nil)
+	assert.ErrorIs(t, err, ErrKeyNil)
+	_, err = NewHMACSigner("k", nil)
+	assert.ErrorIs(t, err, ErrKeyNil)
+}
@@ -0,0 +1,128 @@
+package signing
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/BevisDev/godev/utils/crypto"
+)
+
+// KeyFunc returns the verification key of a key ID: []byte for HMACSHA256,
+// *rsa.PublicKey for RSASHA256 and RSAPSS. It returns ErrUnknownKey (or a
+// nil key) for unknown IDs.
+type KeyFunc func(ctx context.Context, keyID string, alg Algorithm) (any, error)
+
+// StaticKeys returns a KeyFunc looking keys up in a map of key ID to key.
+func StaticKeys(keys map[string]any) KeyFunc {
+	return func(_ context.Context, keyID string, _ Algorithm) (any, error) {
+		return keys[keyID], nil
+	}
+}
+
+// Verifier verifies the signatures of inbound requests.
+type Verifier struct {
+	*options
+	keys KeyFunc
+}
+
+// NewVerifier creates a Verifier looking keys up with keys.
+func NewVerifier(keys KeyFunc, opts ...Option) (*Verifier, error) {
+	if keys == nil {
+		return nil, ErrKeyNil
+	}
+	return &Verifier{options: newOptions(opts), keys: keys}, nil
+}
+
+// Verify checks the signature of req whose body is body and returns the key
+// ID of the signer.
+func (v *Verifier) Verify(req *http.Request, body []byte) (string, error) {
+	raw := req.Header.Get(HeaderSignature)
+	if raw == "" {
+		return "", ErrMissingSignature
+	}
+	s, err := parseSignature(raw)
+	if err != nil {
+		return "", err
+	}
+	for _, h := range v.headers {
+		if !slices.Contains(s.headers, h) {
+			return s.keyID, fmt.Errorf("%w: %s", ErrUnsignedHeader, h)
+		}
+	}
+
+	timestamp := req.Header.Get(HeaderTimestamp)
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return s.keyID, ErrMalformed
+	}
+	if d := v.now().Sub(time.Unix(ts, 0)).Abs(); d > v.skew {
+		return s.keyID, ErrExpired
+	}
+
+	bodyHash := HashBody(body)
+	if subtle.ConstantTimeCompare([]byte(bodyHash), []byte(req.Header.Get(HeaderContentSHA256))) != 1 {
+		return s.keyID, ErrBodyMismatch
+	}
+
+	key, err := v.keys(req.Context(), s.keyID, s.alg)
+	if err != nil {
+		return s.keyID, err
+	}
+	if key == nil {
+		return s.keyID, ErrUnknownKey
+	}
+
+	data := []byte(StringToSign(req, s.headers, timestamp, bodyHash))
+	if err := verify(s.alg, key, data, s.sig); err != nil {
+		return s.keyID, err
+	}
+	return s.keyID, nil
+}
+
+func verify(alg Algorithm, key any, data []byte, sig string) error {
+	switch alg {
+	case HMACSHA256:
+		secret, ok := key.([]byte)
+		if !ok {
+			return fmt.Errorf("[signing] %s needs a []byte key, got %T", alg, key)
+		}
+		got, err := base64.StdEncoding.DecodeString(sig)
+		if err != nil {
+			return ErrInvalidSignature
+		}
+		mac := hmac.New(sha256.New, secret)
+		mac.Write(data)
+		if !hmac.Equal(got, mac.Sum(nil)) {
+			return ErrInvalidSignature
+		}
+		return nil
+
+	case RSASHA256, RSAPSS:
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("[signing] %s needs a *rsa.PublicKey key, got %T", alg, key)
+		}
+		var err error
+		if alg == RSAPSS {
+			err = crypto.VerifyPSS(pub, data, sig)
+		} else {
+			err = crypto.VerifyPKCS1v15(pub, data, sig)
+		}
+		if err != nil {
+			return ErrInvalidSignature
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("%w: unsupported algorithm %q", ErrMalformed, alg)
+	}
+}
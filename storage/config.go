@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	defaultPartSize      = 16 << 20 // 16 MiB
+	defaultPresignExpiry = 15 * time.Minute
+)
+
+// Config holds configuration for an S3-compatible object storage (AWS S3, MinIO, ...).
+type Config struct {
+	Endpoint  string // host[:port] without scheme, e.g. "s3.amazonaws.com" or "localhost:9000"
+	AccessKey string
+	SecretKey string
+	Region    string // e.g. "ap-southeast-1"; avoids a bucket location lookup when set
+	Bucket    string // default bucket for all operations
+	UseSSL    bool
+
+	// CreateBucket creates Bucket on startup when it does not exist.
+	CreateBucket bool
+
+	// PartSize is the multipart upload part size; uploads larger than it are sent
+	// as multipart uploads (default 16 MiB, minimum 5 MiB).
+	PartSize uint64
+
+	// PresignExpiry is the default lifetime of presigned URLs (default 15m).
+	PresignExpiry time.Duration
+}
+
+// clone applies default values to the configuration if they are not set.
+func (c *Config) clone() *Config {
+	cc := *c
+	if cc.PartSize == 0 {
+		cc.PartSize = defaultPartSize
+	}
+	if cc.PresignExpiry <= 0 {
+		cc.PresignExpiry = defaultPresignExpiry
+	}
+	return &cc
+}
+
+// Validate checks the required fields.
+func (c *Config) Validate() error {
+	if c.Endpoint == "" {
+		return errors.New("endpoint is required")
+	}
+	if c.Bucket == "" {
+		return errors.New("bucket is required")
+	}
+	if c.PartSize < 5<<20 {
+		return errors.New("part size must be at least 5 MiB")
+	}
+	return nil
+}
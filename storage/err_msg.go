@@ -0,0 +1,10 @@
+package storage
+
+import "errors"
+
+// Errors
+var (
+	ErrConfigNil = errors.New("[storage] config is nil")
+	ErrNotFound  = errors.New("[storage] object not found")
+	ErrEmptyKey  = errors.New("[storage] object key is empty")
+)
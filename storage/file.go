@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/BevisDev/godev/consts"
+	"github.com/BevisDev/godev/utils/filex"
+)
+
+// Upload uploads the local file src under key. The size is known up front, so
+// large files are sent as multipart uploads by providers that support it.
+func Upload(ctx context.Context, p Provider, key, src string, opts ...PutOption) (*Object, error) {
+	f, err := os.Open(src)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	return p.Put(ctx, key, f, info.Size(), opts...)
+}
+
+// Download writes the object key to the local file dest, creating parent directories.
+func Download(ctx context.Context, p Provider, key, dest string) (*Object, error) {
+	r, obj, err := p.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		return nil, err
+	}
+	f, err := os.Create(dest)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return obj, f.Close()
+}
+
+// UploadDir uploads every file under dir to prefix, keeping the relative paths.
+// filex options (WithInclude, WithExclude, WithMaxDepth, ...) filter the files.
+//
+// Example:
+//
+//	// archive today's reports
+//	objs, err := storage.UploadDir(ctx, s3, "reports/2024-05-01", "./out", filex.WithInclude("*.xlsx", "*.csv"))
+func UploadDir(ctx context.Context, p Provider, prefix, dir string, opts ...filex.Option) ([]Object, error) {
+	var out []Object
+	err := filex.Walk(dir, func(file string, d fs.DirEntry) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, file)
+		if err != nil {
+			return err
+		}
+		obj, err := Upload(ctx, p, path.Join(prefix, filepath.ToSlash(rel)), file)
+		if err != nil {
+			return err
+		}
+		out = append(out, *obj)
+		return nil
+	}, opts...)
+	return out, err
+}
+
+func detectContentType(key string) string {
+	if ct := mime.TypeByExtension(path.Ext(key)); ct != "" {
+		return ct
+	}
+	return consts.ApplicationOctetStream
+}
@@ -0,0 +1,136 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Memory is an in-memory Provider for tests and local development.
+type Memory struct {
+	mu      sync.RWMutex
+	objects map[string]memObject
+}
+
+type memObject struct {
+	info Object
+	data []byte
+}
+
+// NewMemory creates an empty in-memory provider.
+func NewMemory() *Memory {
+	return &Memory{objects: make(map[string]memObject)}
+}
+
+func (m *Memory) Put(ctx context.Context, key string, r io.Reader, size int64, opts ...PutOption) (*Object, error) {
+	if key == "" {
+		return nil, ErrEmptyKey
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	o := newPutOptions(key, opts)
+
+	sum := md5.Sum(data)
+	info := Object{
+		Key:          key,
+		Size:         int64(len(data)),
+		ContentType:  o.contentType,
+		ETag:         hex.EncodeToString(sum[:]),
+		LastModified: time.Now(),
+		Metadata:     o.metadata,
+	}
+
+	m.mu.Lock()
+	m.objects[key] = memObject{info: info, data: data}
+	m.mu.Unlock()
+	return &info, nil
+}
+
+func (m *Memory) Get(ctx context.Context, key string) (io.ReadCloser, *Object, error) {
+	obj, err := m.get(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	info := obj.info
+	return io.NopCloser(bytes.NewReader(obj.data)), &info, nil
+}
+
+func (m *Memory) Stat(ctx context.Context, key string) (*Object, error) {
+	obj, err := m.get(key)
+	if err != nil {
+		return nil, err
+	}
+	info := obj.info
+	return &info, nil
+}
+
+func (m *Memory) get(key string) (memObject, error) {
+	if key == "" {
+		return memObject{}, ErrEmptyKey
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	obj, ok := m.objects[key]
+	if !ok {
+		return memObject{}, fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	return obj, nil
+}
+
+func (m *Memory) Delete(ctx context.Context, key string) error {
+	if key == "" {
+		return ErrEmptyKey
+	}
+	m.mu.Lock()
+	delete(m.objects, key)
+	m.mu.Unlock()
+	return nil
+}
+
+func (m *Memory) List(ctx context.Context, prefix string) ([]Object, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var out []Object
+	for k, obj := range m.objects {
+		if strings.HasPrefix(k, prefix) {
+			out = append(out, obj.info)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Key < out[j].Key })
+	return out, nil
+}
+
+// PresignGet returns a memory:// URL; it is only meaningful for assertions in tests.
+func (m *Memory) PresignGet(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	return m.presign("GET", key, expiry)
+}
+
+// PresignPut returns a memory:// URL; it is only meaningful for assertions in tests.
+func (m *Memory) PresignPut(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	return m.presign("PUT", key, expiry)
+}
+
+func (m *Memory) presign(method, key string, expiry time.Duration) (string, error) {
+	if key == "" {
+		return "", ErrEmptyKey
+	}
+	if expiry <= 0 {
+		expiry = defaultPresignExpiry
+	}
+	q := url.Values{}
+	q.Set("method", method)
+	q.Set("expires", time.Now().Add(expiry).UTC().Format(time.RFC3339))
+	return (&url.URL{Scheme: "memory", Path: "/" + key, RawQuery: q.Encode()}).String(), nil
+}
@@ -0,0 +1,225 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// S3 is a Provider backed by an S3-compatible service (AWS S3, MinIO, Ceph RGW, ...).
+type S3 struct {
+	cfg    *Config
+	client *minio.Client
+}
+
+// NewS3 creates an S3 provider. With Config.CreateBucket the bucket is created
+// when missing, which requires a network round trip.
+// Config is cloned so later changes to cfg do not affect the client.
+func NewS3(ctx context.Context, cfg *Config) (*S3, error) {
+	if cfg == nil {
+		return nil, ErrConfigNil
+	}
+	cfg = cfg.clone()
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("[storage] invalid config: %w", err)
+	}
+
+	client, err := minio.New(cfg.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, ""),
+		Secure: cfg.UseSSL,
+		Region: cfg.Region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("[storage] %w", err)
+	}
+
+	s := &S3{cfg: cfg, client: client}
+	if cfg.CreateBucket {
+		if err := s.ensureBucket(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func (s *S3) ensureBucket(ctx context.Context) error {
+	ok, err := s.client.BucketExists(ctx, s.cfg.Bucket)
+	if err != nil {
+		return fmt.Errorf("[storage] check bucket %s: %w", s.cfg.Bucket, err)
+	}
+	if ok {
+		return nil
+	}
+	if err := s.client.MakeBucket(ctx, s.cfg.Bucket, minio.MakeBucketOptions{Region: s.cfg.Region}); err != nil {
+		return fmt.Errorf("[storage] create bucket %s: %w", s.cfg.Bucket, err)
+	}
+	return nil
+}
+
+// Client returns the underlying minio client for operations not covered by Provider.
+func (s *S3) Client() *minio.Client {
+	return s.client
+}
+
+// Bucket returns the configured bucket.
+func (s *S3) Bucket() string {
+	return s.cfg.Bucket
+}
+
+// Ping checks that the bucket is reachable.
+func (s *S3) Ping(ctx context.Context) error {
+	ok, err := s.client.BucketExists(ctx, s.cfg.Bucket)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("[storage] bucket %s does not exist", s.cfg.Bucket)
+	}
+	return nil
+}
+
+// Put uploads r under key. Objects larger than Config.PartSize, or of unknown
+// size (-1), are sent as multipart uploads with parts uploaded in parallel.
+func (s *S3) Put(ctx context.Context, key string, r io.Reader, size int64, opts ...PutOption) (*Object, error) {
+	if key == "" {
+		return nil, ErrEmptyKey
+	}
+	o := newPutOptions(key, opts)
+
+	info, err := s.client.PutObject(ctx, s.cfg.Bucket, key, r, size, minio.PutObjectOptions{
+		ContentType:        o.contentType,
+		ContentDisposition: o.contentDisposition,
+		CacheControl:       o.cacheControl,
+		UserMetadata:       o.metadata,
+		PartSize:           s.cfg.PartSize,
+	})
+	if err != nil {
+		return nil, s.wrap(key, err)
+	}
+
+	return &Object{
+		Key:          key,
+		Size:         info.Size,
+		ContentType:  o.contentType,
+		ETag:         info.ETag,
+		LastModified: info.LastModified,
+		Metadata:     o.metadata,
+	}, nil
+}
+
+// Get streams the object. The caller must close the reader.
+func (s *S3) Get(ctx context.Context, key string) (io.ReadCloser, *Object, error) {
+	if key == "" {
+		return nil, nil, ErrEmptyKey
+	}
+	obj, err := s.client.GetObject(ctx, s.cfg.Bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, nil, s.wrap(key, err)
+	}
+	// GetObject is lazy: Stat issues the request so a missing key fails here
+	info, err := obj.Stat()
+	if err != nil {
+		_ = obj.Close()
+		return nil, nil, s.wrap(key, err)
+	}
+	return obj, toObject(info), nil
+}
+
+// Stat returns the object info without its content.
+func (s *S3) Stat(ctx context.Context, key string) (*Object, error) {
+	if key == "" {
+		return nil, ErrEmptyKey
+	}
+	info, err := s.client.StatObject(ctx, s.cfg.Bucket, key, minio.StatObjectOptions{})
+	if err != nil {
+		return nil, s.wrap(key, err)
+	}
+	return toObject(info), nil
+}
+
+// Delete removes the object.
+func (s *S3) Delete(ctx context.Context, key string) error {
+	if key == "" {
+		return ErrEmptyKey
+	}
+	if err := s.client.RemoveObject(ctx, s.cfg.Bucket, key, minio.RemoveObjectOptions{}); err != nil {
+		return s.wrap(key, err)
+	}
+	return nil
+}
+
+// List returns all objects under prefix (recursively), sorted by key.
+func (s *S3) List(ctx context.Context, prefix string) ([]Object, error) {
+	var out []Object
+	for info := range s.client.ListObjects(ctx, s.cfg.Bucket, minio.ListObjectsOptions{
+		Prefix:    prefix,
+		Recursive: true,
+	}) {
+		if info.Err != nil {
+			return nil, fmt.Errorf("[storage] list %s: %w", prefix, info.Err)
+		}
+		out = append(out, *toObject(info))
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Key < out[j].Key })
+	return out, nil
+}
+
+// PresignGet returns a URL to download key without credentials.
+func (s *S3) PresignGet(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	if key == "" {
+		return "", ErrEmptyKey
+	}
+	u, err := s.client.PresignedGetObject(ctx, s.cfg.Bucket, key, s.expiry(expiry), nil)
+	if err != nil {
+		return "", s.wrap(key, err)
+	}
+	return u.String(), nil
+}
+
+// PresignPut returns a URL to upload key without credentials.
+func (s *S3) PresignPut(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	if key == "" {
+		return "", ErrEmptyKey
+	}
+	u, err := s.client.PresignedPutObject(ctx, s.cfg.Bucket, key, s.expiry(expiry))
+	if err != nil {
+		return "", s.wrap(key, err)
+	}
+	return u.String(), nil
+}
+
+func (s *S3) expiry(d time.Duration) time.Duration {
+	if d <= 0 {
+		return s.cfg.PresignExpiry
+	}
+	return d
+}
+
+func (s *S3) wrap(key string, err error) error {
+	resp := minio.ToErrorResponse(err)
+	if resp.StatusCode == http.StatusNotFound || resp.Code == "NoSuchKey" {
+		return fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+	return fmt.Errorf("[storage] %s: %w", key, err)
+}
+
+func toObject(info minio.ObjectInfo) *Object {
+	return &Object{
+		Key:          info.Key,
+		Size:         info.Size,
+		ContentType:  info.ContentType,
+		ETag:         info.ETag,
+		LastModified: info.LastModified,
+		Metadata:     info.UserMetadata,
+	}
+}
@@ -0,0 +1,103 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// Provider is an object storage backend. Keys are slash-separated paths inside the bucket.
+type Provider interface {
+	// Put uploads r under key. size may be -1 when unknown; large or unknown
+	// sizes are uploaded in parts.
+	Put(ctx context.Context, key string, r io.Reader, size int64, opts ...PutOption) (*Object, error)
+
+	// Get streams the object. The caller must close the reader.
+	// It returns ErrNotFound when the key does not exist.
+	Get(ctx context.Context, key string) (io.ReadCloser, *Object, error)
+
+	// Stat returns the object info without its content.
+	Stat(ctx context.Context, key string) (*Object, error)
+
+	// Delete removes the object. Deleting a missing key is not an error.
+	Delete(ctx context.Context, key string) error
+
+	// List returns all objects whose key starts with prefix, sorted by key.
+	List(ctx context.Context, prefix string) ([]Object, error)
+
+	// PresignGet returns a URL to download key without credentials.
+	// A zero expiry uses the configured default.
+	PresignGet(ctx context.Context, key string, expiry time.Duration) (string, error)
+
+	// PresignPut returns a URL to upload key without credentials.
+	PresignPut(ctx context.Context, key string, expiry time.Duration) (string, error)
+}
+
+var (
+	_ Provider = (*S3)(nil)
+	_ Provider = (*Memory)(nil)
+)
+
+// Object describes a stored object.
+type Object struct {
+	Key          string
+	Size         int64
+	ContentType  string
+	ETag         string
+	LastModified time.Time
+	Metadata     map[string]string
+}
+
+// PutOption configures a single upload.
+type PutOption func(*putOptions)
+
+type putOptions struct {
+	contentType        string
+	contentDisposition string
+	cacheControl       string
+	metadata           map[string]string
+}
+
+// WithContentType sets the Content-Type of the object (default: detected from the key extension).
+func WithContentType(contentType string) PutOption {
+	return func(o *putOptions) {
+		o.contentType = contentType
+	}
+}
+
+// WithContentDisposition sets the Content-Disposition, e.g. `attachment; filename="report.xlsx"`.
+func WithContentDisposition(disposition string) PutOption {
+	return func(o *putOptions) {
+		o.contentDisposition = disposition
+	}
+}
+
+// WithCacheControl sets the Cache-Control header returned on download.
+func WithCacheControl(cacheControl string) PutOption {
+	return func(o *putOptions) {
+		o.cacheControl = cacheControl
+	}
+}
+
+// WithMetadata attaches user metadata to the object.
+func WithMetadata(metadata map[string]string) PutOption {
+	return func(o *putOptions) {
+		if o.metadata == nil {
+			o.metadata = make(map[string]string, len(metadata))
+		}
+		for k, v := range metadata {
+			o.metadata[k] = v
+		}
+	}
+}
+
+func newPutOptions(key string, opts []PutOption) *putOptions {
+	o := &putOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.contentType == "" {
+		o.contentType = detectContentType(key)
+	}
+	return o
+}
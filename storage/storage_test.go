@@ -0,0 +1,128 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BevisDev/godev/utils/filex"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemory_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	p := NewMemory()
+
+	obj, err := p.Put(ctx, "reports/a.csv", strings.NewReader("id,name\n1,a\n"), -1,
+		WithMetadata(map[string]string{"owner": "ops"}))
+	require.NoError(t, err)
+	assert.Equal(t, int64(12), obj.Size)
+	assert.Contains(t, obj.ContentType, "text/csv")
+	assert.NotEmpty(t, obj.ETag)
+
+	_, err = p.Put(ctx, "reports/b.bin", strings.NewReader("x"), 1, WithContentType("application/x-custom"))
+	require.NoError(t, err)
+	_, err = p.Put(ctx, "other/c.txt", strings.NewReader("y"), 1)
+	require.NoError(t, err)
+
+	r, info, err := p.Get(ctx, "reports/a.csv")
+	require.NoError(t, err)
+	data, _ := io.ReadAll(r)
+	_ = r.Close()
+	assert.Equal(t, "id,name\n1,a\n", string(data))
+	assert.Equal(t, "ops", info.Metadata["owner"])
+
+	list, err := p.List(ctx, "reports/")
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, "reports/a.csv", list[0].Key)
+	assert.Equal(t, "application/x-custom", list[1].ContentType)
+
+	require.NoError(t, p.Delete(ctx, "reports/a.csv"))
+	_, err = p.Stat(ctx, "reports/a.csv")
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, _, err = p.Get(ctx, "")
+	assert.ErrorIs(t, err, ErrEmptyKey)
+
+	u, err := p.PresignGet(ctx, "other/c.txt", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(u, "memory:///other/c.txt?"))
+}
+
+func TestUploadDownloadDir(t *testing.T) {
+	ctx := context.Background()
+	p := NewMemory()
+
+	src := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(src, "daily"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "daily", "sales.csv"), []byte("1,2"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "summary.xlsx"), []byte("xlsx"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "debug.log"), []byte("log"), 0o644))
+
+	objs, err := UploadDir(ctx, p, "archive/2024-05-01", src, filex.WithExclude("*.log"))
+	require.NoError(t, err)
+	require.Len(t, objs, 2)
+	assert.Equal(t, "archive/2024-05-01/daily/sales.csv", objs[0].Key)
+	assert.Equal(t, "archive/2024-05-01/summary.xlsx", objs[1].Key)
+
+	dest := filepath.Join(t.TempDir(), "nested", "sales.csv")
+	obj, err := Download(ctx, p, "archive/2024-05-01/daily/sales.csv", dest)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), obj.Size)
+	data, err := os.ReadFile(dest)
+	require.NoError(t, err)
+	assert.Equal(t, "1,2", string(data))
+
+	_, err = Download(ctx, p, "missing", dest)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestS3_Config(t *testing.T) {
+	_, err := NewS3(context.Background(), nil)
+	assert.ErrorIs(t, err, ErrConfigNil)
+
+	_, err = NewS3(context.Background(), &Config{Endpoint: "localhost:9000"})
+	assert.ErrorContains(t, err, "bucket is required")
+
+	_, err = NewS3(context.Background(), &Config{Endpoint: "localhost:9000", Bucket: "b", PartSize: 1024})
+	assert.ErrorContains(t, err, "part size")
+}
+
+func TestS3_PresignAndNotFound(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		w.WriteHeader(http.StatusNotFound)
+		if r.Method != http.MethodHead {
+			_, _ = io.WriteString(w, `<?xml version="1.0" encoding="UTF-8"?><Error><Code>NoSuchKey</Code><Message>missing</Message></Error>`)
+		}
+	}))
+	defer srv.Close()
+
+	srvURL, _ := url.Parse(srv.URL)
+	s, err := NewS3(context.Background(), &Config{
+		Endpoint:  srvURL.Host,
+		AccessKey: "key",
+		SecretKey: "secret",
+		Region:    "us-east-1",
+		Bucket:    "reports",
+	})
+	require.NoError(t, err)
+
+	u, err := s.PresignGet(context.Background(), "2024/a.xlsx", 0)
+	require.NoError(t, err)
+	assert.Contains(t, u, "/reports/2024/a.xlsx")
+	assert.Contains(t, u, "X-Amz-Expires=900")
+
+	_, err = s.Stat(context.Background(), "2024/a.xlsx")
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, _, err = s.Get(context.Background(), "2024/a.xlsx")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,9 @@
+package templatex
+
+import "errors"
+
+var (
+	ErrNotFound = errors.New("[templatex] template not found")
+	ErrParse    = errors.New("[templatex] failed to parse template")
+	ErrExecute  = errors.New("[templatex] failed to execute template")
+)
@@ -0,0 +1,94 @@
+package templatex
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/BevisDev/godev/utils/datetime"
+	"github.com/BevisDev/godev/utils/jsonx"
+	"github.com/BevisDev/godev/utils/money"
+	"github.com/BevisDev/godev/utils/str"
+	"github.com/BevisDev/godev/utils/validate"
+)
+
+// Funcs returns the built-in template functions, available in every Engine.
+// Arguments are ordered so the value can be piped: {{.Name | truncate 20}}.
+func Funcs() map[string]any {
+	return map[string]any{
+		// strings
+		"upper":         strings.ToUpper,
+		"lower":         strings.ToLower,
+		"trim":          strings.TrimSpace,
+		"contains":      func(sub, s string) bool { return strings.Contains(s, sub) },
+		"replace":       func(old, new, s string) string { return strings.ReplaceAll(s, old, new) },
+		"join":          func(sep string, elems []string) string { return strings.Join(elems, sep) },
+		"truncate":      func(n int, s string) string { return str.Truncate(s, n) },
+		"removeAccents": str.RemoveAccents,
+		"default":       defaultValue,
+
+		// datetime
+		"now":        time.Now,
+		"formatTime": func(layout string, t time.Time) string { return datetime.ToString(t, layout) },
+		"date":       func(t time.Time) string { return datetime.ToString(t, datetime.DateLayoutDMYSlash) },
+		"datetime":   func(t time.Time) string { return datetime.ToString(t, datetime.DateTimeLayout) },
+		"toVN":       datetime.ToVN,
+		"humanize":   datetime.Humanize,
+		"duration":   datetime.FormatDuration,
+
+		// money
+		"money":   func(a money.Amount) string { return a.Format() },
+		"decimal": formatDecimal,
+		"number":  formatNumber,
+
+		// data
+		"json": jsonx.ToJSON,
+		"dict": dict,
+		"list": func(v ...any) []any { return v },
+		"add":  func(a, b int) int { return a + b },
+		"sub":  func(a, b int) int { return a - b },
+	}
+}
+
+// defaultValue returns def when v is nil or empty: {{.Note | default "-"}}.
+func defaultValue(def, v any) any {
+	if validate.IsNilOrEmpty(v) {
+		return def
+	}
+	return v
+}
+
+// formatDecimal formats a number with a fixed number of decimals: {{.Rate | decimal 2}}.
+func formatDecimal(places int, v any) (string, error) {
+	m, err := money.ToDecimal(v)
+	if err != nil {
+		return "", err
+	}
+	return money.Format(m, int32(places)), nil
+}
+
+// formatNumber formats a number with thousand separators: {{.Total | number 0}} -> "1,500,000".
+func formatNumber(places int, v any) (string, error) {
+	m, err := money.ToDecimal(v)
+	if err != nil {
+		return "", err
+	}
+	return money.FormatWithSeparators(m, int32(places), ",", "."), nil
+}
+
+// dict builds a map from key/value pairs, to pass several values to a partial:
+// {{template "partials/button.html" dict "URL" .Link "Label" "Confirm"}}.
+func dict(kv ...any) (map[string]any, error) {
+	if len(kv)%2 != 0 {
+		return nil, fmt.Errorf("dict: odd number of arguments")
+	}
+	m := make(map[string]any, len(kv)/2)
+	for i := 0; i < len(kv); i += 2 {
+		k, ok := kv[i].(string)
+		if !ok {
+			return nil, fmt.Errorf("dict: key %v is not a string", kv[i])
+		}
+		m[k] = kv[i+1]
+	}
+	return m, nil
+}
@@ -0,0 +1,71 @@
+package templatex
+
+type Option func(*options)
+
+type options struct {
+	root       string
+	sharedDirs []string
+	funcs      map[string]any
+	left       string
+	right      string
+	strict     bool
+	reload     bool
+}
+
+func defaultOptions() *options {
+	return &options{
+		root:       ".",
+		sharedDirs: []string{"layouts", "partials"},
+		funcs:      Funcs(),
+	}
+}
+
+// WithRoot loads templates under dir of the file system (default ".").
+// Template names are relative to it.
+func WithRoot(dir string) Option {
+	return func(o *options) {
+		if dir != "" {
+			o.root = dir
+		}
+	}
+}
+
+// WithSharedDirs sets the directories whose templates are available to every
+// page, such as layouts and partials (default "layouts" and "partials").
+func WithSharedDirs(dirs ...string) Option {
+	return func(o *options) {
+		o.sharedDirs = dirs
+	}
+}
+
+// WithFuncs adds template functions, overriding the built-in ones of the same name.
+func WithFuncs(funcs map[string]any) Option {
+	return func(o *options) {
+		for k, v := range funcs {
+			o.funcs[k] = v
+		}
+	}
+}
+
+// WithDelims sets the action delimiters (default "{{" and "}}"), e.g. for
+// templates of documents that contain "{{" themselves.
+func WithDelims(left, right string) Option {
+	return func(o *options) {
+		o.left, o.right = left, right
+	}
+}
+
+// WithStrict fails execution on a missing map key instead of printing "<no value>".
+func WithStrict() Option {
+	return func(o *options) {
+		o.strict = true
+	}
+}
+
+// WithReload parses the templates again on every render, so edits show up
+// without a restart. Meant for development with os.DirFS.
+func WithReload() Option {
+	return func(o *options) {
+		o.reload = true
+	}
+}
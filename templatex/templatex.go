@@ -0,0 +1,309 @@
+package templatex
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	texttemplate "text/template"
+)
+
+// Engine renders templates loaded from a file system, typically an embed.FS:
+//
+//	templates/
+//	├── layouts/base.html      shared: defines the page skeleton, calls {{block "content" .}}
+//	├── partials/footer.html   shared: {{template "partials/footer.html" .}}
+//	├── emails/welcome.html    page:   {{define "content"}}...{{end}}{{template "layouts/base.html" .}}
+//	├── emails/welcome.txt     page (text/template)
+//	└── webhooks/order.json    page (text/template)
+//
+// Templates are named by their path relative to the root. Files ending in
+// .html, .htm or .gohtml (optionally followed by .tmpl) use html/template and
+// are escaped for HTML; all others use text/template.
+//
+// Each page is compiled once, together with the shared layouts and partials
+// of its kind, so pages can define the same block names without clashing.
+// An Engine is safe for concurrent use.
+type Engine struct {
+	*options
+	fsys fs.FS
+
+	mu       sync.RWMutex
+	pages    map[string]*page
+	htmlBase *htmltemplate.Template
+	textBase *texttemplate.Template
+	inline   map[string]*page
+}
+
+// page is a compiled template set, either html or text.
+type page struct {
+	html *htmltemplate.Template
+	text *texttemplate.Template
+}
+
+func (p *page) has(name string) bool {
+	if p.html != nil {
+		return p.html.Lookup(name) != nil
+	}
+	return p.text.Lookup(name) != nil
+}
+
+func (p *page) execute(w io.Writer, name string, data any) error {
+	if p.html != nil {
+		return p.html.ExecuteTemplate(w, name, data)
+	}
+	return p.text.ExecuteTemplate(w, name, data)
+}
+
+// New loads and compiles the templates of fsys. A nil fsys creates an engine
+// for RenderString and RenderHTMLString only.
+func New(fsys fs.FS, opts ...Option) (*Engine, error) {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	e := &Engine{options: o, fsys: fsys}
+	if err := e.Reload(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Reload parses the templates again and drops the compiled inline templates.
+func (e *Engine) Reload() error {
+	htmlBase := htmltemplate.New("").Funcs(e.funcs).Delims(e.left, e.right)
+	textBase := texttemplate.New("").Funcs(e.funcs).Delims(e.left, e.right)
+	if e.strict {
+		htmlBase.Option("missingkey=error")
+		textBase.Option("missingkey=error")
+	}
+
+	type file struct {
+		name string
+		src  string
+	}
+	var files []file
+	if e.fsys != nil {
+		err := fs.WalkDir(e.fsys, e.root, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if strings.HasPrefix(d.Name(), ".") && p != e.root {
+				if d.IsDir() {
+					return fs.SkipDir
+				}
+				return nil
+			}
+			if d.IsDir() {
+				return nil
+			}
+
+			raw, err := fs.ReadFile(e.fsys, p)
+			if err != nil {
+				return err
+			}
+			name := e.name(p)
+			if !e.isShared(name) {
+				files = append(files, file{name: name, src: string(raw)})
+				return nil
+			}
+
+			if isHTML(name) {
+				_, err = htmlBase.New(name).Parse(string(raw))
+			} else {
+				_, err = textBase.New(name).Parse(string(raw))
+			}
+			if err != nil {
+				return fmt.Errorf("%w: %v", ErrParse, err)
+			}
+			return nil
+		})
+		if err != nil {
+			if !errors.Is(err, ErrParse) {
+				err = fmt.Errorf("%w: %v", ErrParse, err)
+			}
+			return err
+		}
+	}
+
+	pages := make(map[string]*page, len(files))
+	for _, f := range files {
+		p, err := compile(htmlBase, textBase, f.name, f.src, isHTML(f.name))
+		if err != nil {
+			return err
+		}
+		pages[f.name] = p
+	}
+
+	e.mu.Lock()
+	e.pages = pages
+	e.htmlBase, e.textBase = htmlBase, textBase
+	e.inline = make(map[string]*page)
+	e.mu.Unlock()
+	return nil
+}
+
+// compile parses src as name on a copy of the shared templates.
+func compile(htmlBase *htmltemplate.Template, textBase *texttemplate.Template, name, src string, html bool) (*page, error) {
+	if html {
+		t, err := htmlBase.Clone()
+		if err == nil {
+			_, err = t.New(name).Parse(src)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrParse, err)
+		}
+		return &page{html: t}, nil
+	}
+
+	t, err := textBase.Clone()
+	if err == nil {
+		_, err = t.New(name).Parse(src)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrParse, err)
+	}
+	return &page{text: t}, nil
+}
+
+// Execute renders the page name to w.
+func (e *Engine) Execute(w io.Writer, name string, data any) error {
+	return e.execute(w, name, name, data)
+}
+
+// Render renders the page name.
+func (e *Engine) Render(name string, data any) (string, error) {
+	var buf bytes.Buffer
+	if err := e.Execute(&buf, name, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// RenderLayout renders the shared template layout with the blocks defined by
+// the page name, for pages that only {{define}} blocks:
+//
+//	html, err := engine.RenderLayout("layouts/base.html", "emails/welcome.html", data)
+func (e *Engine) RenderLayout(layout, name string, data any) (string, error) {
+	var buf bytes.Buffer
+	if err := e.execute(&buf, name, layout, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// RenderString renders src as a text/template with the functions and the
+// shared text templates of the engine, e.g. a webhook payload template kept
+// in the database. Compiled templates are cached by source.
+func (e *Engine) RenderString(src string, data any) (string, error) {
+	return e.renderInline(src, data, false)
+}
+
+// RenderHTMLString is RenderString for html/template.
+func (e *Engine) RenderHTMLString(src string, data any) (string, error) {
+	return e.renderInline(src, data, true)
+}
+
+// Has reports whether the page name exists.
+func (e *Engine) Has(name string) bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	_, ok := e.pages[name]
+	return ok
+}
+
+// Names returns the sorted page names.
+func (e *Engine) Names() []string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	names := make([]string, 0, len(e.pages))
+	for name := range e.pages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// execute runs the template tmpl of the page name.
+func (e *Engine) execute(w io.Writer, name, tmpl string, data any) error {
+	if e.reload {
+		if err := e.Reload(); err != nil {
+			return err
+		}
+	}
+
+	e.mu.RLock()
+	p, ok := e.pages[name]
+	e.mu.RUnlock()
+	if !ok || !p.has(tmpl) {
+		return fmt.Errorf("%w: %s", ErrNotFound, tmpl)
+	}
+
+	if err := p.execute(w, tmpl, data); err != nil {
+		return fmt.Errorf("%w: %v", ErrExecute, err)
+	}
+	return nil
+}
+
+func (e *Engine) renderInline(src string, data any, html bool) (string, error) {
+	key := "text:" + src
+	if html {
+		key = "html:" + src
+	}
+
+	e.mu.RLock()
+	p, ok := e.inline[key]
+	htmlBase, textBase := e.htmlBase, e.textBase
+	e.mu.RUnlock()
+
+	if !ok {
+		var err error
+		p, err = compile(htmlBase, textBase, "inline", src, html)
+		if err != nil {
+			return "", err
+		}
+		e.mu.Lock()
+		e.inline[key] = p
+		e.mu.Unlock()
+	}
+
+	var buf bytes.Buffer
+	if err := p.execute(&buf, "inline", data); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrExecute, err)
+	}
+	return buf.String(), nil
+}
+
+// name returns the template name of the file p: its path relative to the root.
+func (e *Engine) name(p string) string {
+	if e.root == "." {
+		return p
+	}
+	return strings.TrimPrefix(p, strings.TrimSuffix(e.root, "/")+"/")
+}
+
+func (e *Engine) isShared(name string) bool {
+	for _, dir := range e.sharedDirs {
+		if strings.HasPrefix(name, strings.TrimSuffix(dir, "/")+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func isHTML(name string) bool {
+	switch strings.ToLower(path.Ext(strings.TrimSuffix(name, ".tmpl"))) {
+	case ".html", ".htm", ".gohtml":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,175 @@
+package templatex
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/BevisDev/godev/utils/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testFS = fstest.MapFS{
+	"tpl/layouts/base.html":    {Data: []byte(`<html><body>{{block "content" .}}empty{{end}}{{template "partials/footer.html" .}}</body></html>`)},
+	"tpl/partials/footer.html": {Data: []byte(`<footer>{{.Company}}</footer>`)},
+	"tpl/partials/sign.txt":    {Data: []byte(`-- {{.Company}}`)},
+	"tpl/emails/welcome.html":  {Data: []byte(`{{define "content"}}<h1>Hi {{.Name}}</h1>{{end}}{{template "layouts/base.html" .}}`)},
+	"tpl/emails/welcome.txt":   {Data: []byte("Hi {{.Name}}\n{{template \"partials/sign.txt\" .}}")},
+	"tpl/emails/reset.html":    {Data: []byte(`{{define "content"}}<p>Code {{.Code}}</p>{{end}}`)},
+	"tpl/webhooks/order.json":  {Data: []byte(`{"id":{{json .ID}},"total":"{{.Total | number 0}}"}`)},
+	"tpl/.hidden/skip.html":    {Data: []byte(`{{`)},
+}
+
+func newEngine(t *testing.T, opts ...Option) *Engine {
+	t.Helper()
+	e, err := New(testFS, append([]Option{WithRoot("tpl")}, opts...)...)
+	require.NoError(t, err)
+	return e
+}
+
+func TestRender_LayoutsAndPartials(t *testing.T) {
+	e := newEngine(t)
+	data := map[string]any{"Name": "<Lan>", "Company": "Bevis"}
+
+	html, err := e.Render("emails/welcome.html", data)
+	require.NoError(t, err)
+	assert.Equal(t, `<html><body><h1>Hi &lt;Lan&gt;</h1><footer>Bevis</footer></body></html>`, html)
+
+	text, err := e.Render("emails/welcome.txt", data)
+	require.NoError(t, err)
+	assert.Equal(t, "Hi <Lan>\n-- Bevis", text)
+
+	// a page defining only blocks, rendered inside a layout
+	html, err = e.RenderLayout("layouts/base.html", "emails/reset.html", map[string]any{"Code": 42, "Company": "Bevis"})
+	require.NoError(t, err)
+	assert.Equal(t, `<html><body><p>Code 42</p><footer>Bevis</footer></body></html>`, html)
+
+	// both pages define "content" without clashing
+	html, err = e.Render("emails/welcome.html", data)
+	require.NoError(t, err)
+	assert.Contains(t, html, "Hi &lt;Lan&gt;")
+
+	var buf bytes.Buffer
+	require.NoError(t, e.Execute(&buf, "webhooks/order.json", map[string]any{"ID": "A-1", "Total": 1500000}))
+	assert.Equal(t, `{"id":"A-1","total":"1,500,000"}`, buf.String())
+
+	assert.Equal(t, []string{"emails/reset.html", "emails/welcome.html", "emails/welcome.txt", "webhooks/order.json"}, e.Names())
+	assert.True(t, e.Has("emails/welcome.txt"))
+	assert.False(t, e.Has("partials/footer.html"))
+}
+
+func TestRender_Errors(t *testing.T) {
+	e := newEngine(t, WithStrict())
+
+	_, err := e.Render("emails/missing.html", nil)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = e.RenderLayout("layouts/none.html", "emails/reset.html", nil)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = e.Render("emails/welcome.txt", map[string]any{"Company": "x"})
+	assert.ErrorIs(t, err, ErrExecute)
+
+	_, err = New(fstest.MapFS{"bad.txt": {Data: []byte(`{{.X`)}})
+	assert.ErrorIs(t, err, ErrParse)
+
+	_, err = New(fstest.MapFS{}, WithRoot("missing"))
+	assert.ErrorIs(t, err, ErrParse)
+}
+
+func TestRenderString(t *testing.T) {
+	e := newEngine(t)
+
+	out, err := e.RenderString(`{{.Name | upper}} {{template "partials/sign.txt" .}}`, map[string]any{"Name": "lan", "Company": "Bevis"})
+	require.NoError(t, err)
+	assert.Equal(t, "LAN -- Bevis", out)
+
+	out, err = e.RenderHTMLString(`<b>{{.}}</b>`, "<i>")
+	require.NoError(t, err)
+	assert.Equal(t, "<b>&lt;i&gt;</b>", out)
+
+	_, err = e.RenderString(`{{`, nil)
+	assert.ErrorIs(t, err, ErrParse)
+
+	// engines without a file system render strings only
+	plain, err := New(nil)
+	require.NoError(t, err)
+	out, err = plain.RenderString(`{{.A}}-{{.B}}`, map[string]int{"A": 1, "B": 2})
+	require.NoError(t, err)
+	assert.Equal(t, "1-2", out)
+}
+
+func TestReload(t *testing.T) {
+	fsys := fstest.MapFS{"a.txt": {Data: []byte(`v1`)}}
+	e, err := New(fsys, WithReload())
+	require.NoError(t, err)
+
+	out, _ := e.Render("a.txt", nil)
+	assert.Equal(t, "v1", out)
+
+	fsys["a.txt"] = &fstest.MapFile{Data: []byte(`v2`)}
+	out, _ = e.Render("a.txt", nil)
+	assert.Equal(t, "v2", out)
+}
+
+func TestOptions(t *testing.T) {
+	fsys := fstest.MapFS{
+		"doc.tex":         {Data: []byte(`\textbf{[[.Name]]} {{literal}}`)},
+		"shared/head.tex": {Data: []byte(`head`)},
+		"page.tex":        {Data: []byte(`[[template "shared/head.tex"]] [[shout .Name]]`)},
+	}
+	e, err := New(fsys,
+		WithDelims("[[", "]]"),
+		WithSharedDirs("shared"),
+		WithFuncs(map[string]any{"shout": func(s string) string { return s + "!" }}),
+	)
+	require.NoError(t, err)
+
+	out, err := e.Render("doc.tex", map[string]string{"Name": "Q1"})
+	require.NoError(t, err)
+	assert.Equal(t, `\textbf{Q1} {{literal}}`, out)
+
+	out, err = e.Render("page.tex", map[string]string{"Name": "hi"})
+	require.NoError(t, err)
+	assert.Equal(t, "head hi!", out)
+}
+
+func TestFuncs(t *testing.T) {
+	e, err := New(nil)
+	require.NoError(t, err)
+
+	at := time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)
+	cases := []struct {
+		src  string
+		data any
+		want string
+	}{
+		{`{{.T | date}}`, map[string]any{"T": at}, "01/05/2024"},
+		{`{{.T | datetime}}`, map[string]any{"T": at}, "2024-05-01 09:30:00"},
+		{`{{.T | formatTime "2006"}}`, map[string]any{"T": at}, "2024"},
+		{`{{.T | toVN | formatTime "15:04"}}`, map[string]any{"T": at}, "16:30"},
+		{`{{.D | duration}}`, map[string]any{"D": 90 * time.Minute}, "1h 30m"},
+		{`{{.A | money}}`, map[string]any{"A": money.New(money.FromInt(1500000), money.VND)}, "1.500.000 ₫"},
+		{`{{.V | decimal 2}}`, map[string]any{"V": 3.14159}, "3.14"},
+		{`{{.V | number 2}}`, map[string]any{"V": money.FromFloat(1234.5)}, "1,234.50"},
+		{`{{.S | truncate 3}}`, map[string]any{"S": "Nguyễn"}, "Ngu"},
+		{`{{.S | removeAccents}}`, map[string]any{"S": "Nguyễn"}, "Nguyen"},
+		{`{{.S | default "-"}}`, map[string]any{"S": ""}, "-"},
+		{`{{.S | replace "a" "o" | lower}}`, map[string]any{"S": "BANANA"}, "banana"},
+		{`{{join ", " .L}}`, map[string]any{"L": []string{"a", "b"}}, "a, b"},
+		{`{{json .}}`, map[string]int{"a": 1}, `{"a":1}`},
+		{`{{with dict "k" 1 "v" "x"}}{{.k}}{{.v}}{{end}}`, nil, "1x"},
+		{`{{range list 1 2}}{{add . 1}}{{end}}`, nil, "23"},
+	}
+	for _, c := range cases {
+		got, err := e.RenderString(c.src, c.data)
+		require.NoError(t, err, c.src)
+		assert.Equal(t, c.want, got, c.src)
+	}
+
+	_, err = e.RenderString(`{{dict "k"}}`, nil)
+	assert.True(t, errors.Is(err, ErrExecute))
+}
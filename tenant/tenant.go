@@ -0,0 +1,37 @@
+// Package tenant carries the tenant of a request through context.Context.
+//
+// The ginfw/middleware/tenant middleware resolves it from the request and
+// database uses it to scope queries of tenant tables (see database.DB.ScopeTenant).
+package tenant
+
+import (
+	"context"
+
+	"github.com/BevisDev/godev/utils/ctxmeta"
+)
+
+type bypassKey struct{}
+
+// WithTenant stores the tenant ID in ctx.
+func WithTenant(ctx context.Context, id string) context.Context {
+	return ctxmeta.WithTenant(ctx, id)
+}
+
+// FromContext returns the tenant ID stored by WithTenant, or "".
+func FromContext(ctx context.Context) string {
+	return ctxmeta.Tenant(ctx)
+}
+
+// Bypass marks ctx to run queries across every tenant, e.g. for back-office jobs.
+func Bypass(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bypassKey{}, true)
+}
+
+// IsBypassed reports whether ctx was marked by Bypass.
+func IsBypassed(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	ok, _ := ctx.Value(bypassKey{}).(bool)
+	return ok
+}
@@ -0,0 +1,74 @@
+package transfer
+
+import (
+	"crypto/tls"
+	"fmt"
+	"time"
+)
+
+// Protocol is the file transfer protocol of a Config.
+type Protocol string
+
+const (
+	SFTP Protocol = "sftp"
+	FTPS Protocol = "ftps" // FTP with explicit TLS (AUTH TLS), or implicit TLS with ImplicitTLS
+	FTP  Protocol = "ftp"  // plain FTP, only for trusted networks
+)
+
+const defaultTimeout = 30 * time.Second
+
+// Config holds the connection settings of a partner file server.
+type Config struct {
+	Protocol Protocol // default SFTP
+	Host     string
+	Port     int // default 22 for SFTP, 990 for implicit FTPS, 21 otherwise
+	User     string
+	Password string
+
+	// PrivateKey (PEM) or PrivateKeyFile authenticate SFTP with a key,
+	// decrypted with Passphrase when set. Password is tried as well when set.
+	PrivateKey     []byte
+	PrivateKeyFile string
+	Passphrase     string
+
+	// The SFTP host key is verified against KnownHostsFile, or against
+	// HostKeyFingerprint ("SHA256:..."); InsecureSkipHostKey disables the check.
+	KnownHostsFile      string
+	HostKeyFingerprint  string
+	InsecureSkipHostKey bool
+
+	// TLS configures FTPS; nil uses ServerName=Host and the system roots.
+	TLS         *tls.Config
+	ImplicitTLS bool
+
+	// Timeout bounds dialing and the handshake (default 30s).
+	Timeout time.Duration
+}
+
+func (c *Config) clone() *Config {
+	cc := *c
+	if cc.Protocol == "" {
+		cc.Protocol = SFTP
+	}
+	if cc.Port == 0 {
+		switch {
+		case cc.Protocol == SFTP:
+			cc.Port = 22
+		case cc.Protocol == FTPS && cc.ImplicitTLS:
+			cc.Port = 990
+		default:
+			cc.Port = 21
+		}
+	}
+	if cc.Timeout <= 0 {
+		cc.Timeout = defaultTimeout
+	}
+	if cc.TLS == nil {
+		cc.TLS = &tls.Config{ServerName: cc.Host, MinVersion: tls.VersionTLS12}
+	}
+	return &cc
+}
+
+func (c *Config) addr() string {
+	return fmt.Sprintf("%s:%d", c.Host, c.Port)
+}
@@ -0,0 +1,13 @@
+package transfer
+
+import "errors"
+
+// Errors
+var (
+	ErrConfigNil  = errors.New("[transfer] config is nil")
+	ErrNotFound   = errors.New("[transfer] file not found")
+	ErrNoAuth     = errors.New("[transfer] no password or private key")
+	ErrNoHostKey  = errors.New("[transfer] no host key verification configured")
+	ErrProtocol   = errors.New("[transfer] unsupported protocol")
+	ErrUnsafeName = errors.New("[transfer] unsafe remote file name")
+)
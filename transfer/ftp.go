@@ -0,0 +1,168 @@
+package transfer
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/textproto"
+	"path"
+	"strings"
+
+	"github.com/jlaffaye/ftp"
+)
+
+// FTPClient is a Client over FTP or FTPS.
+// The context of its operations is only used when dialing.
+type FTPClient struct {
+	conn *ftp.ServerConn
+}
+
+var _ Client = (*FTPClient)(nil)
+
+// DialFTP connects and logs in. FTPS uses explicit TLS (AUTH TLS) unless
+// cfg.ImplicitTLS; FTP is plaintext.
+func DialFTP(ctx context.Context, cfg *Config) (*FTPClient, error) {
+	if cfg == nil {
+		return nil, ErrConfigNil
+	}
+	c := cfg.clone()
+
+	opts := []ftp.DialOption{
+		ftp.DialWithContext(ctx),
+		ftp.DialWithTimeout(c.Timeout),
+	}
+	switch c.Protocol {
+	case FTPS:
+		if c.ImplicitTLS {
+			opts = append(opts, ftp.DialWithTLS(c.TLS))
+		} else {
+			opts = append(opts, ftp.DialWithExplicitTLS(c.TLS))
+		}
+	case FTP:
+	default:
+		return nil, ErrProtocol
+	}
+
+	conn, err := ftp.Dial(c.addr(), opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := conn.Login(c.User, c.Password); err != nil {
+		_ = conn.Quit()
+		return nil, err
+	}
+	return &FTPClient{conn: conn}, nil
+}
+
+// Conn returns the underlying FTP connection.
+func (f *FTPClient) Conn() *ftp.ServerConn {
+	return f.conn
+}
+
+func (f *FTPClient) List(_ context.Context, dir string) ([]File, error) {
+	entries, err := f.conn.List(dir)
+	if err != nil {
+		return nil, f.wrap(err)
+	}
+
+	files := make([]File, 0, len(entries))
+	for _, e := range entries {
+		if e.Name == "." || e.Name == ".." {
+			continue
+		}
+		files = append(files, File{
+			Name:    e.Name,
+			Path:    path.Join(dir, e.Name),
+			Size:    int64(e.Size),
+			ModTime: e.Time,
+			IsDir:   e.Type == ftp.EntryTypeFolder,
+		})
+	}
+	return files, nil
+}
+
+func (f *FTPClient) Stat(_ context.Context, p string) (*File, error) {
+	// MLST is not supported by every server, SIZE is
+	if e, err := f.conn.GetEntry(p); err == nil {
+		return &File{
+			Name:    path.Base(p),
+			Path:    p,
+			Size:    int64(e.Size),
+			ModTime: e.Time,
+			IsDir:   e.Type == ftp.EntryTypeFolder,
+		}, nil
+	}
+
+	size, err := f.conn.FileSize(p)
+	if err != nil {
+		return nil, f.wrap(err)
+	}
+	file := &File{Name: path.Base(p), Path: p, Size: size}
+	if t, err := f.conn.GetTime(p); err == nil {
+		file.ModTime = t
+	}
+	return file, nil
+}
+
+func (f *FTPClient) Open(_ context.Context, p string, offset int64) (io.ReadCloser, error) {
+	r, err := f.conn.RetrFrom(p, uint64(offset))
+	if err != nil {
+		return nil, f.wrap(err)
+	}
+	return r, nil
+}
+
+func (f *FTPClient) Write(_ context.Context, p string, r io.Reader, offset int64) error {
+	if offset == 0 {
+		return f.wrap(f.conn.Stor(p, r))
+	}
+	return f.wrap(f.conn.StorFrom(p, r, uint64(offset)))
+}
+
+func (f *FTPClient) Rename(_ context.Context, from, to string) error {
+	err := f.conn.Rename(from, to)
+	if err == nil {
+		return nil
+	}
+	// some servers refuse to replace an existing file
+	if f.conn.Delete(to) == nil {
+		return f.wrap(f.conn.Rename(from, to))
+	}
+	return f.wrap(err)
+}
+
+func (f *FTPClient) Remove(_ context.Context, p string) error {
+	return f.wrap(f.conn.Delete(p))
+}
+
+func (f *FTPClient) MkdirAll(_ context.Context, dir string) error {
+	var cur string
+	if strings.HasPrefix(dir, "/") {
+		cur = "/"
+	}
+	for _, part := range strings.Split(strings.Trim(dir, "/"), "/") {
+		if part == "" {
+			continue
+		}
+		cur = path.Join(cur, part)
+		if err := f.conn.MakeDir(cur); err != nil {
+			// the directory may already exist
+			if _, serr := f.conn.List(cur); serr != nil {
+				return f.wrap(err)
+			}
+		}
+	}
+	return nil
+}
+
+func (f *FTPClient) Close() error {
+	return f.conn.Quit()
+}
+
+func (f *FTPClient) wrap(err error) error {
+	var te *textproto.Error
+	if errors.As(err, &te) && te.Code == ftp.StatusFileUnavailable {
+		return errors.Join(ErrNotFound, err)
+	}
+	return err
+}
@@ -0,0 +1,70 @@
+package transfer
+
+import (
+	"context"
+
+	"github.com/BevisDev/godev/scheduler"
+	"github.com/BevisDev/godev/utils/console"
+)
+
+// PullJob is a scheduler.Handler that connects to a partner server, pulls the
+// files of a remote directory (see Pull) and disconnects, on every run.
+type PullJob struct {
+	name      string
+	cfg       *Config
+	remoteDir string
+	localDir  string
+	opts      []Option
+	log       *console.Logger
+}
+
+var _ scheduler.Handler = (*PullJob)(nil)
+
+// NewPullJob returns a job named name pulling remoteDir into localDir.
+//
+// Example:
+//
+//	s.Register(&scheduler.Job{
+//		Cron: "*/15 * * * *",
+//		IsOn: true,
+//		Handler: transfer.NewPullJob("bank-settlement", cfg, "/outbox", "./data/inbox",
+//			transfer.WithInclude("SETTLE_*.csv"),
+//			transfer.WithArchiveDir("/outbox/done"),
+//			transfer.WithOnFile(importSettlement),
+//		),
+//	})
+func NewPullJob(name string, cfg *Config, remoteDir, localDir string, opts ...Option) *PullJob {
+	return &PullJob{
+		name:      name,
+		cfg:       cfg,
+		remoteDir: remoteDir,
+		localDir:  localDir,
+		opts:      opts,
+		log:       console.New("transfer"),
+	}
+}
+
+func (j *PullJob) JobName() string {
+	return j.name
+}
+
+func (j *PullJob) Handle(ctx context.Context) {
+	if _, err := j.Run(ctx); err != nil {
+		j.log.Error("job %s: %v", j.name, err)
+	}
+}
+
+// Run pulls once and returns the pulled files.
+func (j *PullJob) Run(ctx context.Context) ([]File, error) {
+	c, err := Dial(ctx, j.cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	files, err := Pull(ctx, c, j.remoteDir, j.localDir, j.opts...)
+	if len(files) > 0 {
+		j.log.Info("job %s pulled %d file(s) from %s", j.name, len(files), j.remoteDir)
+	}
+	return files, err
+}
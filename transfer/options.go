@@ -0,0 +1,98 @@
+package transfer
+
+import (
+	"context"
+
+	"github.com/BevisDev/godev/utils/filex"
+)
+
+// Option configures Upload, Download, Find and Pull.
+type Option func(*options)
+
+type options struct {
+	resume     bool
+	atomic     bool
+	tempSuffix string
+	include    []string
+	exclude    []string
+	overwrite  bool
+	remove     bool
+	archiveDir string
+	onFile     func(ctx context.Context, local string, f File) error
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{resume: true, atomic: true, tempSuffix: ".part"}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithoutResume restarts interrupted transfers from the beginning.
+func WithoutResume() Option {
+	return func(o *options) { o.resume = false }
+}
+
+// WithoutAtomic writes directly to the destination name instead of a temporary
+// name renamed when complete. Some servers do not allow renames.
+func WithoutAtomic() Option {
+	return func(o *options) { o.atomic = false }
+}
+
+// WithTempSuffix sets the suffix of temporary names (default ".part").
+func WithTempSuffix(suffix string) Option {
+	return func(o *options) {
+		if suffix != "" {
+			o.tempSuffix = suffix
+		}
+	}
+}
+
+// WithInclude only keeps remote files whose name matches one of the globs (see filex.Match).
+func WithInclude(globs ...string) Option {
+	return func(o *options) { o.include = append(o.include, globs...) }
+}
+
+// WithExclude drops remote files whose name matches one of the globs. Exclude wins over include.
+func WithExclude(globs ...string) Option {
+	return func(o *options) { o.exclude = append(o.exclude, globs...) }
+}
+
+// WithOverwrite makes Pull download files that already exist locally with the same size.
+func WithOverwrite() Option {
+	return func(o *options) { o.overwrite = true }
+}
+
+// WithRemove makes Pull delete remote files once downloaded (and handled by WithOnFile).
+func WithRemove() Option {
+	return func(o *options) { o.remove = true }
+}
+
+// WithArchiveDir makes Pull move remote files into dir once downloaded (and handled by WithOnFile).
+func WithArchiveDir(dir string) Option {
+	return func(o *options) { o.archiveDir = dir }
+}
+
+// WithOnFile calls fn for each file downloaded by Pull. When fn fails the
+// remote file is neither removed nor archived, and Pull stops with the error.
+func WithOnFile(fn func(ctx context.Context, local string, f File) error) Option {
+	return func(o *options) { o.onFile = fn }
+}
+
+func (o *options) match(name string) bool {
+	for _, g := range o.exclude {
+		if filex.Match(g, name) {
+			return false
+		}
+	}
+	if len(o.include) == 0 {
+		return true
+	}
+	for _, g := range o.include {
+		if filex.Match(g, name) {
+			return true
+		}
+	}
+	return false
+}
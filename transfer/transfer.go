@@ -0,0 +1,260 @@
+package transfer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Client is a connection to a file server. Paths are slash-separated.
+// A Client is not safe for concurrent use.
+type Client interface {
+	// List returns the entries of dir.
+	List(ctx context.Context, dir string) ([]File, error)
+
+	// Stat returns the entry at p, or ErrNotFound.
+	Stat(ctx context.Context, p string) (*File, error)
+
+	// Open streams the file at p from offset. The caller must close the reader.
+	Open(ctx context.Context, p string, offset int64) (io.ReadCloser, error)
+
+	// Write writes r to the file at p from offset, truncating it when offset is 0.
+	Write(ctx context.Context, p string, r io.Reader, offset int64) error
+
+	// Rename moves from to to, replacing to when it exists.
+	Rename(ctx context.Context, from, to string) error
+
+	// Remove deletes the file at p.
+	Remove(ctx context.Context, p string) error
+
+	// MkdirAll creates dir and its missing parents.
+	MkdirAll(ctx context.Context, dir string) error
+
+	Close() error
+}
+
+// File describes a remote file or directory.
+type File struct {
+	Name    string
+	Path    string
+	Size    int64
+	ModTime time.Time
+	IsDir   bool
+}
+
+// Dial connects to the server of cfg with its protocol.
+func Dial(ctx context.Context, cfg *Config) (Client, error) {
+	if cfg == nil {
+		return nil, ErrConfigNil
+	}
+	c := cfg.clone()
+
+	switch c.Protocol {
+	case SFTP:
+		return DialSFTP(ctx, c)
+	case FTPS, FTP:
+		return DialFTP(ctx, c)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrProtocol, c.Protocol)
+	}
+}
+
+// Upload sends the local file to remote. It writes to remote plus the temp
+// suffix and renames it when complete, and resumes from an existing partial
+// upload (see WithoutAtomic, WithoutResume).
+func Upload(ctx context.Context, c Client, local, remote string, opts ...Option) error {
+	o := newOptions(opts)
+
+	f, err := os.Open(local)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	target := remote
+	if o.atomic {
+		target = remote + o.tempSuffix
+	}
+
+	var offset int64
+	if o.resume && o.atomic {
+		if st, err := c.Stat(ctx, target); err == nil && st.Size <= info.Size() {
+			offset = st.Size
+		}
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+
+	if err := c.Write(ctx, target, f, offset); err != nil {
+		return fmt.Errorf("[transfer] upload %s: %w", remote, err)
+	}
+	if o.atomic {
+		return c.Rename(ctx, target, remote)
+	}
+	return nil
+}
+
+// Download fetches remote into the local file, creating parent directories.
+// It writes to local plus the temp suffix and renames it when complete, and
+// resumes from an existing partial download (see WithoutAtomic, WithoutResume).
+func Download(ctx context.Context, c Client, remote, local string, opts ...Option) error {
+	o := newOptions(opts)
+
+	if err := os.MkdirAll(filepath.Dir(local), 0o755); err != nil {
+		return err
+	}
+
+	target := local
+	if o.atomic {
+		target = local + o.tempSuffix
+	}
+
+	var offset int64
+	if o.resume && o.atomic {
+		if info, err := os.Stat(target); err == nil {
+			offset = info.Size()
+			if st, err := c.Stat(ctx, remote); err != nil || st.Size < offset {
+				offset = 0
+			}
+		}
+	}
+
+	flags := os.O_CREATE | os.O_WRONLY
+	if offset == 0 {
+		flags |= os.O_TRUNC
+	}
+	f, err := os.OpenFile(target, flags, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	r, err := c.Open(ctx, remote, offset)
+	if err != nil {
+		_ = f.Close()
+		return fmt.Errorf("[transfer] download %s: %w", remote, err)
+	}
+	_, err = io.Copy(f, r)
+	if cerr := r.Close(); err == nil {
+		err = cerr
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("[transfer] download %s: %w", remote, err)
+	}
+
+	if o.atomic {
+		return os.Rename(target, local)
+	}
+	return nil
+}
+
+// Find returns the files of dir whose name matches WithInclude / WithExclude,
+// sorted by name. Directories and partial uploads (temp suffix) are left out.
+func Find(ctx context.Context, c Client, dir string, opts ...Option) ([]File, error) {
+	o := newOptions(opts)
+
+	entries, err := c.List(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []File
+	for _, e := range entries {
+		if e.IsDir || strings.HasSuffix(e.Name, o.tempSuffix) || !o.match(e.Name) {
+			continue
+		}
+		files = append(files, e)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Name < files[j].Name })
+	return files, nil
+}
+
+// Pull downloads the files of remoteDir selected by Find into localDir and
+// returns them. Files already present locally with the same size are skipped
+// unless WithOverwrite. Each downloaded file is then passed to WithOnFile and
+// removed (WithRemove) or moved (WithArchiveDir) on the server.
+// A remote name that is not a plain local file name (a path separator, "..")
+// stops Pull with ErrUnsafeName before anything is written.
+//
+// Example:
+//
+//	files, err := transfer.Pull(ctx, c, "/outbox", "./inbox",
+//		transfer.WithInclude("SETTLE_*.csv"),
+//		transfer.WithArchiveDir("/outbox/done"),
+//	)
+func Pull(ctx context.Context, c Client, remoteDir, localDir string, opts ...Option) ([]File, error) {
+	o := newOptions(opts)
+
+	files, err := Find(ctx, c, remoteDir, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if o.archiveDir != "" {
+		if err := c.MkdirAll(ctx, o.archiveDir); err != nil {
+			return nil, err
+		}
+	}
+
+	var pulled []File
+	for _, f := range files {
+		if err := ctx.Err(); err != nil {
+			return pulled, err
+		}
+
+		if !isLocalName(f.Name) {
+			return pulled, fmt.Errorf("%w: %q", ErrUnsafeName, f.Name)
+		}
+		local := filepath.Join(localDir, f.Name)
+		if info, err := os.Stat(local); err == nil && info.Size() == f.Size && !o.overwrite {
+			continue
+		}
+
+		if err := Download(ctx, c, f.Path, local, opts...); err != nil {
+			return pulled, err
+		}
+		if o.onFile != nil {
+			if err := o.onFile(ctx, local, f); err != nil {
+				return pulled, fmt.Errorf("[transfer] handle %s: %w", f.Name, err)
+			}
+		}
+
+		switch {
+		case o.archiveDir != "":
+			err = c.Rename(ctx, f.Path, path.Join(o.archiveDir, f.Name))
+		case o.remove:
+			err = c.Remove(ctx, f.Path)
+		}
+		if err != nil {
+			return pulled, err
+		}
+		pulled = append(pulled, f)
+	}
+	return pulled, nil
+}
+
+// isLocalName reports whether name, as listed by a server, is a single file
+// name that stays inside the local directory.
+func isLocalName(name string) bool {
+	return name != "" && name != "." &&
+		!strings.ContainsAny(name, `/\`) &&
+		!strings.Contains(name, "..") &&
+		filepath.IsLocal(name)
+}
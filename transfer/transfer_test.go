@@ -0,0 +1,217 @@
+package transfer
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pkg/sftp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+// newMemSFTP returns a client of an in-memory SFTP server.
+func newMemSFTP(t *testing.T) *SFTPClient {
+	t.Helper()
+
+	cr, sw := io.Pipe()
+	sr, cw := io.Pipe()
+
+	server := sftp.NewRequestServer(struct {
+		io.Reader
+		io.WriteCloser
+	}{sr, sw}, sftp.InMemHandler())
+	go func() { _ = server.Serve() }()
+
+	client, err := sftp.NewClientPipe(cr, cw)
+	require.NoError(t, err)
+
+	c := NewSFTPClient(client)
+	t.Cleanup(func() {
+		_ = server.Close() // ends the client's reader first
+		_ = c.Close()
+	})
+	return c
+}
+
+func putRemote(t *testing.T, c Client, p, content string) {
+	t.Helper()
+	require.NoError(t, c.Write(context.Background(), p, strings.NewReader(content), 0))
+}
+
+func readRemote(t *testing.T, c Client, p string) string {
+	t.Helper()
+	r, err := c.Open(context.Background(), p, 0)
+	require.NoError(t, err)
+	defer r.Close()
+	b, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return string(b)
+}
+
+func TestUploadDownload(t *testing.T) {
+	c := newMemSFTP(t)
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	local := filepath.Join(dir, "report.csv")
+	require.NoError(t, os.WriteFile(local, []byte("a,b\n1,2\n"), 0o644))
+	require.NoError(t, c.MkdirAll(ctx, "/inbox"))
+
+	require.NoError(t, Upload(ctx, c, local, "/inbox/report.csv"))
+	assert.Equal(t, "a,b\n1,2\n", readRemote(t, c, "/inbox/report.csv"))
+	_, err := c.Stat(ctx, "/inbox/report.csv.part")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	dest := filepath.Join(dir, "out", "report.csv")
+	require.NoError(t, Download(ctx, c, "/inbox/report.csv", dest))
+	b, err := os.ReadFile(dest)
+	require.NoError(t, err)
+	assert.Equal(t, "a,b\n1,2\n", string(b))
+}
+
+func TestUpload_Resume(t *testing.T) {
+	c := newMemSFTP(t)
+	ctx := context.Background()
+
+	local := filepath.Join(t.TempDir(), "big.dat")
+	require.NoError(t, os.WriteFile(local, []byte("0123456789"), 0o644))
+
+	// a previous upload stopped after 4 bytes
+	putRemote(t, c, "/big.dat.part", "0123")
+
+	require.NoError(t, Upload(ctx, c, local, "/big.dat"))
+	assert.Equal(t, "0123456789", readRemote(t, c, "/big.dat"))
+}
+
+func TestDownload_Resume(t *testing.T) {
+	c := newMemSFTP(t)
+	ctx := context.Background()
+	putRemote(t, c, "/big.dat", "0123456789")
+
+	dest := filepath.Join(t.TempDir(), "big.dat")
+	require.NoError(t, os.WriteFile(dest+".part", []byte("01234"), 0o644))
+
+	require.NoError(t, Download(ctx, c, "/big.dat", dest))
+	b, err := os.ReadFile(dest)
+	require.NoError(t, err)
+	assert.Equal(t, "0123456789", string(b))
+}
+
+func TestFindAndPull(t *testing.T) {
+	c := newMemSFTP(t)
+	ctx := context.Background()
+
+	require.NoError(t, c.MkdirAll(ctx, "/outbox/sub"))
+	putRemote(t, c, "/outbox/SETTLE_0501.csv", "day1")
+	putRemote(t, c, "/outbox/SETTLE_0502.csv", "day2")
+	putRemote(t, c, "/outbox/SETTLE_0503.csv.part", "uploading")
+	putRemote(t, c, "/outbox/readme.txt", "hi")
+
+	files, err := Find(ctx, c, "/outbox", WithInclude("SETTLE_*.csv"))
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	assert.Equal(t, "SETTLE_0501.csv", files[0].Name)
+	assert.Equal(t, "/outbox/SETTLE_0501.csv", files[0].Path)
+
+	localDir := t.TempDir()
+	var handled []string
+	pulled, err := Pull(ctx, c, "/outbox", localDir,
+		WithInclude("SETTLE_*.csv"),
+		WithExclude("*0502*"),
+		WithArchiveDir("/outbox/done"),
+		WithOnFile(func(_ context.Context, local string, f File) error {
+			handled = append(handled, filepath.Base(local))
+			return nil
+		}),
+	)
+	require.NoError(t, err)
+	require.Len(t, pulled, 1)
+	assert.Equal(t, []string{"SETTLE_0501.csv"}, handled)
+	assert.Equal(t, "day1", readRemote(t, c, "/outbox/done/SETTLE_0501.csv"))
+	_, err = c.Stat(ctx, "/outbox/SETTLE_0501.csv")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// a failing handler keeps the remote file
+	pulled, err = Pull(ctx, c, "/outbox", localDir, WithRemove(),
+		WithOnFile(func(context.Context, string, File) error { return errors.New("bad file") }),
+	)
+	assert.ErrorContains(t, err, "bad file")
+	assert.Empty(t, pulled)
+	assert.Equal(t, "day2", readRemote(t, c, "/outbox/SETTLE_0502.csv"))
+}
+
+// listClient lists fixed entries over a real client, like a hostile server would.
+type listClient struct {
+	Client
+	entries []File
+}
+
+func (c listClient) List(context.Context, string) ([]File, error) { return c.entries, nil }
+
+func TestPull_UnsafeName(t *testing.T) {
+	ctx := context.Background()
+	mem := newMemSFTP(t)
+	require.NoError(t, mem.MkdirAll(ctx, "/outbox"))
+	putRemote(t, mem, "/outbox/evil", "pwned")
+
+	localDir := t.TempDir()
+	for _, name := range []string{"../evil", "..", "sub/evil", `..\evil`, "/etc/evil", "a..b"} {
+		c := listClient{Client: mem, entries: []File{{Name: name, Path: "/outbox/evil", Size: 5}}}
+
+		pulled, err := Pull(ctx, c, "/outbox", localDir)
+		assert.ErrorIs(t, err, ErrUnsafeName, name)
+		assert.Empty(t, pulled, name)
+	}
+	entries, err := os.ReadDir(filepath.Dir(localDir))
+	require.NoError(t, err)
+	for _, e := range entries {
+		assert.NotEqual(t, "evil", e.Name())
+	}
+	assert.Equal(t, "pwned", readRemote(t, mem, "/outbox/evil"), "remote file is kept")
+}
+
+func TestSSHConfig(t *testing.T) {
+	_, err := sshConfig((&Config{InsecureSkipHostKey: true}).clone())
+	assert.ErrorIs(t, err, ErrNoAuth)
+
+	_, err = sshConfig((&Config{Password: "secret"}).clone())
+	assert.ErrorIs(t, err, ErrNoHostKey)
+
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	block, err := ssh.MarshalPrivateKey(priv, "")
+	require.NoError(t, err)
+
+	cfg, err := sshConfig((&Config{
+		User:               "partner",
+		PrivateKey:         pem.EncodeToMemory(block),
+		HostKeyFingerprint: "SHA256:abc",
+	}).clone())
+	require.NoError(t, err)
+	assert.Len(t, cfg.Auth, 1)
+
+	signer, err := ssh.NewSignerFromKey(priv)
+	require.NoError(t, err)
+	assert.ErrorContains(t, cfg.HostKeyCallback("bank:22", nil, signer.PublicKey()), "host key mismatch")
+}
+
+func TestDial_Protocol(t *testing.T) {
+	_, err := Dial(context.Background(), nil)
+	assert.ErrorIs(t, err, ErrConfigNil)
+
+	_, err = Dial(context.Background(), &Config{Protocol: "scp"})
+	assert.ErrorIs(t, err, ErrProtocol)
+
+	cfg := (&Config{Protocol: FTPS, ImplicitTLS: true, Host: "bank"}).clone()
+	assert.Equal(t, 990, cfg.Port)
+	assert.Equal(t, "bank", cfg.TLS.ServerName)
+}
@@ -0,0 +1,152 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+// AppError is a domain error with a stable code, the HTTP status it maps to,
+// a message key for translation and an optional wrapped cause.
+//
+// Define each error once (usually as a package-level var) with RegisterError,
+// then return it — or a wrapped copy — from services:
+//
+//	var ErrOrderNotFound = types.RegisterError("ORDER_404", http.StatusNotFound,
+//		"order.not_found", "Order not found")
+//
+//	return types.ErrOrderNotFound.Wrap(err)
+//
+// errors.Is matches by code, so wrapped copies still match the definition.
+type AppError struct {
+	Code       string
+	Status     int
+	MessageKey string
+	Message    string
+
+	// Params are template values used when rendering the message (e.g. {"field": "email"}).
+	Params map[string]any
+
+	cause error
+}
+
+func (e *AppError) Error() string {
+	if e.cause != nil {
+		return fmt.Sprintf("%s: %s: %v", e.Code, e.Message, e.cause)
+	}
+	return e.Code + ": " + e.Message
+}
+
+// Unwrap returns the wrapped cause.
+func (e *AppError) Unwrap() error {
+	return e.cause
+}
+
+// Is reports whether target is an AppError with the same code.
+func (e *AppError) Is(target error) bool {
+	t, ok := target.(*AppError)
+	return ok && t.Code == e.Code
+}
+
+// Wrap returns a copy of e with cause attached. The definition itself is not modified.
+func (e *AppError) Wrap(cause error) *AppError {
+	c := e.clone()
+	c.cause = cause
+	return c
+}
+
+// WithMessage returns a copy of e with a custom message.
+// The message key is cleared so the custom message is not replaced by a translation.
+func (e *AppError) WithMessage(format string, args ...any) *AppError {
+	c := e.clone()
+	c.Message = fmt.Sprintf(format, args...)
+	c.MessageKey = ""
+	return c
+}
+
+// WithParams returns a copy of e with message template params.
+func (e *AppError) WithParams(params map[string]any) *AppError {
+	c := e.clone()
+	c.Params = params
+	return c
+}
+
+func (e *AppError) clone() *AppError {
+	c := *e
+	return &c
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]*AppError)
+)
+
+// RegisterError defines an AppError and adds it to the registry.
+// It panics when code is empty or already registered, so duplicates are caught at startup.
+// A zero status defaults to 500.
+func RegisterError(code string, status int, messageKey, message string) *AppError {
+	if code == "" {
+		panic("types: error code is empty")
+	}
+	if status == 0 {
+		status = http.StatusInternalServerError
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, ok := registry[code]; ok {
+		panic(fmt.Sprintf("types: error code %q already registered", code))
+	}
+	e := &AppError{Code: code, Status: status, MessageKey: messageKey, Message: message}
+	registry[code] = e
+	return e
+}
+
+// LookupError returns the registered AppError for code.
+func LookupError(code string) (*AppError, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	e, ok := registry[code]
+	return e, ok
+}
+
+// RegisteredErrors returns all registered errors sorted by code (useful for API docs).
+func RegisteredErrors() []*AppError {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	out := make([]*AppError, 0, len(registry))
+	for _, e := range registry {
+		out = append(out, e)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Code < out[j].Code })
+	return out
+}
+
+// AsAppError finds the first AppError in err's chain.
+func AsAppError(err error) (*AppError, bool) {
+	var e *AppError
+	if errors.As(err, &e) {
+		return e, true
+	}
+	return nil, false
+}
+
+// Generic errors, using the HTTP status as code (same codes as the response package).
+var (
+	ErrBadRequest         = RegisterError("400", http.StatusBadRequest, "error.bad_request", "Invalid Request")
+	ErrUnauthorized       = RegisterError("401", http.StatusUnauthorized, "error.unauthorized", "Unauthorized")
+	ErrForbidden          = RegisterError("403", http.StatusForbidden, "error.forbidden", "Forbidden")
+	ErrNotFound           = RegisterError("404", http.StatusNotFound, "error.not_found", "Not Found")
+	ErrMethodNotAllowed   = RegisterError("405", http.StatusMethodNotAllowed, "error.method_not_allowed", "Method Not Allowed")
+	ErrRequestTimeout     = RegisterError("408", http.StatusRequestTimeout, "error.request_timeout", "Request Timeout")
+	ErrConflict           = RegisterError("409", http.StatusConflict, "error.conflict", "Conflict")
+	ErrTooManyRequests    = RegisterError("429", http.StatusTooManyRequests, "error.too_many_requests", "Too Many Requests")
+	ErrInternal           = RegisterError("500", http.StatusInternalServerError, "error.internal", "Internal Server Error")
+	ErrServiceUnavailable = RegisterError("503", http.StatusServiceUnavailable, "error.service_unavailable", "Service Unavailable")
+	ErrGatewayTimeout     = RegisterError("504", http.StatusGatewayTimeout, "error.gateway_timeout", "Gateway Timeout")
+)
@@ -0,0 +1,221 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/BevisDev/godev/utils/datetime"
+)
+
+// DateOnly is a calendar date for DATE columns, as datetime.DateLayoutISO
+// ("2006-01-02") in JSON and queries. The time is midnight UTC.
+//
+// The zero DateOnly is null in JSON and written as NULL.
+type DateOnly struct {
+	time.Time
+}
+
+// NewDateOnly returns the date year-month-day.
+func NewDateOnly(year int, month time.Month, day int) DateOnly {
+	return DateOnly{Time: time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+// DateOnlyOf returns the date of t in its location.
+func DateOnlyOf(t time.Time) DateOnly {
+	if t.IsZero() {
+		return DateOnly{}
+	}
+	return NewDateOnly(t.Date())
+}
+
+// ParseDateOnly parses s in datetime.DateLayoutISO.
+func ParseDateOnly(s string) (DateOnly, error) {
+	t, err := time.Parse(datetime.DateLayoutISO, s)
+	if err != nil {
+		return DateOnly{}, fmt.Errorf("[types] invalid date %q: %w", s, err)
+	}
+	return DateOnly{Time: t}, nil
+}
+
+// String returns the date in datetime.DateLayoutISO, or "" for the zero date.
+func (d DateOnly) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.Format(datetime.DateLayoutISO)
+}
+
+// MarshalText implements encoding.TextMarshaler, for query and form binding.
+func (d DateOnly) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler; "" is the zero date.
+func (d *DateOnly) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*d = DateOnly{}
+		return nil
+	}
+	v, err := ParseDateOnly(string(text))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// MarshalJSON writes the date string, or null for the zero date.
+func (d DateOnly) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON reads a date string; null and "" are the zero date.
+func (d *DateOnly) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*d = DateOnly{}
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return d.UnmarshalText([]byte(s))
+}
+
+// Scan implements sql.Scanner for time.Time values and date text, e.g. of
+// MySQL without parseTime. A time part in the text is ignored.
+func (d *DateOnly) Scan(src any) error {
+	switch x := src.(type) {
+	case nil:
+		*d = DateOnly{}
+		return nil
+	case time.Time:
+		*d = DateOnlyOf(x)
+		return nil
+	case string:
+		return d.UnmarshalText([]byte(datePart(x)))
+	case []byte:
+		return d.UnmarshalText([]byte(datePart(string(x))))
+	default:
+		return fmt.Errorf("[types] cannot scan %T into DateOnly", src)
+	}
+}
+
+// Value implements driver.Valuer with the date string.
+func (d DateOnly) Value() (driver.Value, error) {
+	if d.IsZero() {
+		return nil, nil
+	}
+	return d.String(), nil
+}
+
+// datePart drops the time of "2006-01-02 15:04:05" and "2006-01-02T15:04:05Z".
+func datePart(s string) string {
+	if len(s) > len(datetime.DateLayoutISO) {
+		if c := s[len(datetime.DateLayoutISO)]; c == ' ' || c == 'T' {
+			return s[:len(datetime.DateLayoutISO)]
+		}
+	}
+	return s
+}
+
+// TimeOnly is a time of day for TIME columns, as datetime.TimeLayout
+// ("15:04:05") in JSON and queries. Fractions of a second are dropped.
+//
+// Midnight is the zero TimeOnly and a valid value; use Null[TimeOnly] for
+// nullable columns.
+type TimeOnly struct {
+	Hour, Minute, Second int
+}
+
+// NewTimeOnly returns the time of day hour:minute:second.
+func NewTimeOnly(hour, minute, second int) TimeOnly {
+	return TimeOnly{Hour: hour, Minute: minute, Second: second}
+}
+
+// TimeOnlyOf returns the time of day of t in its location.
+func TimeOnlyOf(t time.Time) TimeOnly {
+	return NewTimeOnly(t.Clock())
+}
+
+// ParseTimeOnly parses s in datetime.TimeLayout, with optional fractions of a second.
+func ParseTimeOnly(s string) (TimeOnly, error) {
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		s = s[:i]
+	}
+	t, err := time.Parse(datetime.TimeLayout, s)
+	if err != nil {
+		return TimeOnly{}, fmt.Errorf("[types] invalid time %q: %w", s, err)
+	}
+	return TimeOnlyOf(t), nil
+}
+
+// On returns the time of day t on date d, in loc.
+func (t TimeOnly) On(d DateOnly, loc *time.Location) time.Time {
+	year, month, day := d.Date()
+	return time.Date(year, month, day, t.Hour, t.Minute, t.Second, 0, loc)
+}
+
+// String returns the time in datetime.TimeLayout.
+func (t TimeOnly) String() string {
+	return time.Date(0, 1, 1, t.Hour, t.Minute, t.Second, 0, time.UTC).Format(datetime.TimeLayout)
+}
+
+// MarshalText implements encoding.TextMarshaler, for query and form binding.
+func (t TimeOnly) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *TimeOnly) UnmarshalText(text []byte) error {
+	v, err := ParseTimeOnly(string(text))
+	if err != nil {
+		return err
+	}
+	*t = v
+	return nil
+}
+
+// MarshalJSON writes the time string.
+func (t TimeOnly) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+// UnmarshalJSON reads a time string.
+func (t *TimeOnly) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return t.UnmarshalText([]byte(s))
+}
+
+// Scan implements sql.Scanner for time.Time values (SQL Server) and time
+// text (Postgres, MySQL). NULL is midnight; scan into Null[TimeOnly] to keep it.
+func (t *TimeOnly) Scan(src any) error {
+	switch x := src.(type) {
+	case nil:
+		*t = TimeOnly{}
+		return nil
+	case time.Time:
+		*t = TimeOnlyOf(x)
+		return nil
+	case string:
+		return t.UnmarshalText([]byte(x))
+	case []byte:
+		return t.UnmarshalText(x)
+	default:
+		return fmt.Errorf("[types] cannot scan %T into TimeOnly", src)
+	}
+}
+
+// Value implements driver.Valuer with the time string.
+func (t TimeOnly) Value() (driver.Value, error) {
+	return t.String(), nil
+}
@@ -0,0 +1,125 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDateOnly(t *testing.T) {
+	d, err := ParseDateOnly("2026-10-15")
+	require.NoError(t, err)
+	assert.Equal(t, NewDateOnly(2026, time.October, 15), d)
+	assert.Equal(t, "2026-10-15", d.String())
+
+	_, err = ParseDateOnly("15/10/2026")
+	assert.Error(t, err)
+
+	hcm := time.FixedZone("ICT", 7*3600)
+	assert.Equal(t, d, DateOnlyOf(time.Date(2026, 10, 15, 23, 30, 0, 0, hcm)), "date in the location of t")
+}
+
+func TestDateOnly_ScanValue(t *testing.T) {
+	want := NewDateOnly(2026, time.October, 15)
+
+	for _, src := range []any{
+		"2026-10-15",
+		[]byte("2026-10-15 00:00:00"),
+		"2026-10-15T00:00:00Z",
+		time.Date(2026, 10, 15, 0, 0, 0, 0, time.Local),
+	} {
+		var d DateOnly
+		require.NoError(t, d.Scan(src), "%v", src)
+		assert.Equal(t, want, d, "%v", src)
+	}
+
+	var d DateOnly
+	require.NoError(t, d.Scan(nil))
+	assert.True(t, d.IsZero())
+	assert.Error(t, d.Scan(1))
+
+	v, err := want.Value()
+	require.NoError(t, err)
+	assert.Equal(t, "2026-10-15", v)
+
+	v, err = DateOnly{}.Value()
+	require.NoError(t, err)
+	assert.Nil(t, v)
+}
+
+func TestDateOnly_JSON(t *testing.T) {
+	type booking struct {
+		From DateOnly `json:"from"`
+		To   DateOnly `json:"to"`
+	}
+
+	out, err := json.Marshal(booking{From: NewDateOnly(2026, time.October, 15)})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"from":"2026-10-15","to":null}`, string(out))
+
+	var b booking
+	require.NoError(t, json.Unmarshal([]byte(`{"from":"2026-10-15","to":""}`), &b))
+	assert.Equal(t, booking{From: NewDateOnly(2026, time.October, 15)}, b)
+	assert.Error(t, json.Unmarshal([]byte(`{"from":"2026-10-15T10:00:00Z"}`), &b))
+}
+
+func TestTimeOnly(t *testing.T) {
+	tm, err := ParseTimeOnly("08:30:15.250")
+	require.NoError(t, err)
+	assert.Equal(t, NewTimeOnly(8, 30, 15), tm)
+	assert.Equal(t, "08:30:15", tm.String())
+	assert.Equal(t, "00:00:00", TimeOnly{}.String())
+
+	_, err = ParseTimeOnly("8h30")
+	assert.Error(t, err)
+
+	hcm := time.FixedZone("ICT", 7*3600)
+	assert.Equal(t, time.Date(2026, 10, 15, 8, 30, 15, 0, hcm), tm.On(NewDateOnly(2026, time.October, 15), hcm))
+}
+
+func TestTimeOnly_ScanValue(t *testing.T) {
+	want := NewTimeOnly(8, 30, 0)
+
+	for _, src := range []any{
+		"08:30:00",
+		[]byte("08:30:00.000000"),
+		time.Date(1, 1, 1, 8, 30, 0, 0, time.UTC),
+	} {
+		var tm TimeOnly
+		require.NoError(t, tm.Scan(src), "%v", src)
+		assert.Equal(t, want, tm, "%v", src)
+	}
+
+	v, err := want.Value()
+	require.NoError(t, err)
+	assert.Equal(t, "08:30:00", v)
+
+	// nullable TIME column
+	var n Null[TimeOnly]
+	require.NoError(t, n.Scan(nil))
+	assert.False(t, n.Valid)
+	require.NoError(t, n.Scan([]byte("00:00:00")))
+	assert.Equal(t, NewNull(TimeOnly{}), n)
+
+	v, err = n.Value()
+	require.NoError(t, err)
+	assert.Equal(t, "00:00:00", v)
+}
+
+func TestTimeOnly_JSON(t *testing.T) {
+	type shift struct {
+		Start TimeOnly       `json:"start"`
+		End   Null[TimeOnly] `json:"end"`
+	}
+
+	out, err := json.Marshal(shift{Start: NewTimeOnly(8, 0, 0)})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"start":"08:00:00","end":null}`, string(out))
+
+	var s shift
+	require.NoError(t, json.Unmarshal([]byte(`{"start":"08:00:00","end":"17:30:00"}`), &s))
+	assert.Equal(t, shift{Start: NewTimeOnly(8, 0, 0), End: NewNull(NewTimeOnly(17, 30, 0))}, s)
+}
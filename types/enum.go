@@ -0,0 +1,206 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// ErrInvalidEnum is wrapped by the errors of Enum for values outside the set.
+var ErrInvalidEnum = errors.New("invalid enum value")
+
+// Enum is the set of values of a string-backed enum type E. Create it once
+// with RegisterEnum and let the methods of E delegate to it:
+//
+//	type Status string
+//
+//	const (
+//		StatusPending  Status = "pending"
+//		StatusApproved Status = "approved"
+//	)
+//
+//	var Statuses = types.RegisterEnum(StatusPending, StatusApproved)
+//
+//	func (s *Status) UnmarshalJSON(b []byte) error { return Statuses.UnmarshalInto(b, s) }
+//	func (s *Status) Scan(src any) error           { return Statuses.ScanInto(src, s) }
+//	func (s Status) Value() (driver.Value, error)  { return Statuses.ValueOf(s) }
+type Enum[E ~string] struct {
+	name   string
+	values []E
+	index  map[E]struct{}
+}
+
+// enumSet is the untyped view of an Enum, for validation by reflect.Type.
+type enumSet interface {
+	contains(s string) bool
+	Strings() []string
+}
+
+var (
+	enumMu       sync.RWMutex
+	enumRegistry = make(map[reflect.Type]enumSet)
+)
+
+// RegisterEnum defines the values of E, in order. It panics when values is
+// empty, a value is empty or repeated, or E is already registered, so
+// mistakes are caught at startup.
+func RegisterEnum[E ~string](values ...E) *Enum[E] {
+	t := reflect.TypeFor[E]()
+	if len(values) == 0 {
+		panic(fmt.Sprintf("types: enum %s has no values", t))
+	}
+
+	e := &Enum[E]{
+		name:   t.Name(),
+		values: append([]E(nil), values...),
+		index:  make(map[E]struct{}, len(values)),
+	}
+	for _, v := range values {
+		if v == "" {
+			panic(fmt.Sprintf("types: enum %s has an empty value", t))
+		}
+		if _, ok := e.index[v]; ok {
+			panic(fmt.Sprintf("types: enum %s value %q repeated", t, v))
+		}
+		e.index[v] = struct{}{}
+	}
+
+	enumMu.Lock()
+	defer enumMu.Unlock()
+
+	if _, ok := enumRegistry[t]; ok {
+		panic(fmt.Sprintf("types: enum %s already registered", t))
+	}
+	enumRegistry[t] = e
+	return e
+}
+
+// Values returns the values in registration order.
+func (e *Enum[E]) Values() []E {
+	return append([]E(nil), e.values...)
+}
+
+// Strings returns the values as strings, in registration order.
+func (e *Enum[E]) Strings() []string {
+	out := make([]string, len(e.values))
+	for i, v := range e.values {
+		out[i] = string(v)
+	}
+	return out
+}
+
+// IsValid reports whether v is one of the values.
+func (e *Enum[E]) IsValid(v E) bool {
+	_, ok := e.index[v]
+	return ok
+}
+
+// Validate returns an error wrapping ErrInvalidEnum when v is not one of the values.
+func (e *Enum[E]) Validate(v E) error {
+	if e.IsValid(v) {
+		return nil
+	}
+	return fmt.Errorf("[types] %w: %s %q, must be one of [%s]",
+		ErrInvalidEnum, e.name, v, strings.Join(e.Strings(), ", "))
+}
+
+// Parse returns s as E when it is one of the values; the match is exact.
+func (e *Enum[E]) Parse(s string) (E, error) {
+	v := E(s)
+	if err := e.Validate(v); err != nil {
+		return "", err
+	}
+	return v, nil
+}
+
+// UnmarshalInto reads a JSON string into dst, rejecting values outside the set.
+// null leaves dst unchanged, like encoding/json.
+func (e *Enum[E]) UnmarshalInto(data []byte, dst *E) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	v, err := e.Parse(s)
+	if err != nil {
+		return err
+	}
+	*dst = v
+	return nil
+}
+
+// ScanInto reads a string or []byte column into dst, rejecting values outside the
+// set. NULL is read as the empty value.
+func (e *Enum[E]) ScanInto(src any, dst *E) error {
+	var s string
+	switch x := src.(type) {
+	case nil:
+		*dst = ""
+		return nil
+	case string:
+		s = x
+	case []byte:
+		s = string(x)
+	default:
+		return fmt.Errorf("[types] cannot scan %T into %s", src, e.name)
+	}
+	v, err := e.Parse(s)
+	if err != nil {
+		return err
+	}
+	*dst = v
+	return nil
+}
+
+// ValueOf returns v as a query argument, rejecting values outside the set.
+// The empty value is written as NULL.
+func (e *Enum[E]) ValueOf(v E) (driver.Value, error) {
+	if v == "" {
+		return nil, nil
+	}
+	if err := e.Validate(v); err != nil {
+		return nil, err
+	}
+	return string(v), nil
+}
+
+func (e *Enum[E]) contains(s string) bool {
+	return e.IsValid(E(s))
+}
+
+// EnumValues returns the values registered for the enum type t.
+func EnumValues(t reflect.Type) ([]string, bool) {
+	set, ok := lookupEnum(t)
+	if !ok {
+		return nil, false
+	}
+	return set.Strings(), true
+}
+
+// IsEnumValue reports whether v is a value of its registered enum type.
+// registered is false when the type of v was not passed to RegisterEnum.
+func IsEnumValue(v any) (valid, registered bool) {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() || rv.Kind() != reflect.String {
+		return false, false
+	}
+	set, ok := lookupEnum(rv.Type())
+	if !ok {
+		return false, false
+	}
+	return set.contains(rv.String()), true
+}
+
+func lookupEnum(t reflect.Type) (enumSet, bool) {
+	enumMu.RLock()
+	defer enumMu.RUnlock()
+
+	set, ok := enumRegistry[t]
+	return set, ok
+}
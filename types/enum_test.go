@@ -0,0 +1,98 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type orderStatus string
+
+const (
+	orderNew  orderStatus = "new"
+	orderPaid orderStatus = "paid"
+)
+
+var orderStatuses = RegisterEnum(orderNew, orderPaid)
+
+func (s *orderStatus) UnmarshalJSON(b []byte) error { return orderStatuses.UnmarshalInto(b, s) }
+func (s *orderStatus) Scan(src any) error           { return orderStatuses.ScanInto(src, s) }
+func (s orderStatus) Value() (driver.Value, error)  { return orderStatuses.ValueOf(s) }
+
+func TestEnum_Parse(t *testing.T) {
+	assert.Equal(t, []orderStatus{orderNew, orderPaid}, orderStatuses.Values())
+	assert.Equal(t, []string{"new", "paid"}, orderStatuses.Strings())
+	assert.True(t, orderStatuses.IsValid(orderPaid))
+
+	v, err := orderStatuses.Parse("paid")
+	require.NoError(t, err)
+	assert.Equal(t, orderPaid, v)
+
+	_, err = orderStatuses.Parse("PAID")
+	assert.ErrorIs(t, err, ErrInvalidEnum)
+	assert.EqualError(t, err, `[types] invalid enum value: orderStatus "PAID", must be one of [new, paid]`)
+}
+
+func TestEnum_JSON(t *testing.T) {
+	var req struct {
+		Status orderStatus `json:"status"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{"status":"paid"}`), &req))
+	assert.Equal(t, orderPaid, req.Status)
+
+	assert.ErrorIs(t, json.Unmarshal([]byte(`{"status":"shipped"}`), &req), ErrInvalidEnum)
+
+	out, err := json.Marshal(req)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"status":"paid"}`, string(out))
+}
+
+func TestEnum_ScanValue(t *testing.T) {
+	var s orderStatus
+	require.NoError(t, s.Scan([]byte("new")))
+	assert.Equal(t, orderNew, s)
+	require.NoError(t, s.Scan(nil))
+	assert.Equal(t, orderStatus(""), s)
+	assert.ErrorIs(t, s.Scan("lost"), ErrInvalidEnum)
+	assert.Error(t, s.Scan(1))
+
+	v, err := orderPaid.Value()
+	require.NoError(t, err)
+	assert.Equal(t, "paid", v)
+
+	v, err = orderStatus("").Value()
+	require.NoError(t, err)
+	assert.Nil(t, v)
+
+	_, err = orderStatus("lost").Value()
+	assert.ErrorIs(t, err, ErrInvalidEnum)
+}
+
+func TestEnum_Registry(t *testing.T) {
+	valid, registered := IsEnumValue(orderPaid)
+	assert.True(t, valid)
+	assert.True(t, registered)
+
+	valid, registered = IsEnumValue(orderStatus("lost"))
+	assert.False(t, valid)
+	assert.True(t, registered)
+
+	_, registered = IsEnumValue("paid")
+	assert.False(t, registered, "plain strings are not enums")
+
+	values, ok := EnumValues(reflect.TypeFor[orderStatus]())
+	assert.True(t, ok)
+	assert.Equal(t, []string{"new", "paid"}, values)
+}
+
+func TestRegisterEnum_Panics(t *testing.T) {
+	type color string
+	assert.PanicsWithValue(t, "types: enum types.color has no values", func() { RegisterEnum[color]() })
+	assert.Panics(t, func() { RegisterEnum(color("red"), color("")) })
+	assert.Panics(t, func() { RegisterEnum(color("red"), color("red")) })
+	assert.Panics(t, func() { RegisterEnum(orderNew) }, "already registered")
+}
@@ -0,0 +1,76 @@
+package types
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// JSON is a T stored as JSON in one column: JSON/JSONB in Postgres and
+// MySQL, NVARCHAR(MAX) in SQL Server. It is written as JSON text and
+// unmarshaled into T on read:
+//
+//	type Order struct {
+//		ID      int64                `db:"id" json:"id"`
+//		Address types.JSON[Address]  `db:"address" json:"address"`
+//		Tags    types.JSON[[]string] `db:"tags" json:"tags"`
+//	}
+//
+// In API JSON the field is T itself. A nil map, slice or pointer is written
+// as NULL, and NULL is read as the zero T.
+type JSON[T any] struct {
+	V T
+}
+
+// NewJSON returns a JSON holding v.
+func NewJSON[T any](v T) JSON[T] {
+	return JSON[T]{V: v}
+}
+
+// Scan implements sql.Scanner for JSON text of []byte or string columns.
+func (j *JSON[T]) Scan(src any) error {
+	var data []byte
+	switch s := src.(type) {
+	case nil:
+		var zero T
+		j.V = zero
+		return nil
+	case []byte:
+		data = s
+	case string:
+		data = []byte(s)
+	default:
+		return fmt.Errorf("[types] cannot scan %T into JSON[%T]", src, j.V)
+	}
+
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return fmt.Errorf("[types] invalid JSON column: %w", err)
+	}
+	j.V = v
+	return nil
+}
+
+// Value implements driver.Valuer. The JSON is a string, which every driver
+// writes to JSON and text columns.
+func (j JSON[T]) Value() (driver.Value, error) {
+	data, err := json.Marshal(j.V)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.Equal(data, []byte("null")) {
+		return nil, nil
+	}
+	return string(data), nil
+}
+
+// MarshalJSON writes the value as T.
+func (j JSON[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(j.V)
+}
+
+// UnmarshalJSON reads the value as T.
+func (j *JSON[T]) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &j.V)
+}
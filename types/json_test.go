@@ -0,0 +1,63 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type address struct {
+	City   string `json:"city"`
+	Street string `json:"street,omitempty"`
+}
+
+func TestJSON_Scan(t *testing.T) {
+	var a JSON[address]
+	require.NoError(t, a.Scan([]byte(`{"city":"Hanoi","street":"Trang Tien"}`)))
+	assert.Equal(t, address{City: "Hanoi", Street: "Trang Tien"}, a.V)
+
+	// NVARCHAR columns scan as string
+	var tags JSON[[]string]
+	require.NoError(t, tags.Scan(`["vip","new"]`))
+	assert.Equal(t, []string{"vip", "new"}, tags.V)
+
+	require.NoError(t, tags.Scan(nil))
+	assert.Nil(t, tags.V)
+
+	assert.ErrorContains(t, a.Scan([]byte(`{"city":`)), "invalid JSON column")
+	assert.ErrorContains(t, a.Scan(int64(1)), "cannot scan int64")
+}
+
+func TestJSON_Value(t *testing.T) {
+	v, err := NewJSON(address{City: "Hanoi"}).Value()
+	require.NoError(t, err)
+	assert.Equal(t, `{"city":"Hanoi"}`, v)
+
+	v, err = JSON[map[string]int]{}.Value()
+	require.NoError(t, err)
+	assert.Nil(t, v, "nil map is NULL")
+
+	v, err = NewJSON([]string{}).Value()
+	require.NoError(t, err)
+	assert.Equal(t, "[]", v)
+
+	_, err = NewJSON(func() {}).Value()
+	assert.Error(t, err)
+}
+
+func TestJSON_MarshalJSON(t *testing.T) {
+	type order struct {
+		ID      int64         `json:"id"`
+		Address JSON[address] `json:"address"`
+	}
+
+	out, err := json.Marshal(order{ID: 1, Address: NewJSON(address{City: "Hanoi"})})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":1,"address":{"city":"Hanoi"}}`, string(out))
+
+	var o order
+	require.NoError(t, json.Unmarshal(out, &o))
+	assert.Equal(t, "Hanoi", o.Address.V.City)
+}
@@ -0,0 +1,99 @@
+package types
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+)
+
+// Null is a nullable T for entity fields, in place of sql.NullString and
+// friends, pointers or zero values. It scans NULL as invalid, writes NULL when
+// invalid, and is null in JSON:
+//
+//	type User struct {
+//		ID       int64                 `db:"id" json:"id"`
+//		Nickname types.Null[string]    `db:"nickname" json:"nickname"`
+//		BornAt   types.Null[time.Time] `db:"born_at" json:"born_at,omitzero"`
+//	}
+//
+// The zero value is NULL.
+type Null[T any] struct {
+	V     T
+	Valid bool
+}
+
+// NewNull returns a valid Null holding v.
+func NewNull[T any](v T) Null[T] {
+	return Null[T]{V: v, Valid: true}
+}
+
+// NullFromPtr returns a Null holding *p, or NULL when p is nil.
+func NullFromPtr[T any](p *T) Null[T] {
+	if p == nil {
+		return Null[T]{}
+	}
+	return NewNull(*p)
+}
+
+// Ptr returns a pointer to a copy of the value, or nil when n is NULL.
+func (n Null[T]) Ptr() *T {
+	if !n.Valid {
+		return nil
+	}
+	v := n.V
+	return &v
+}
+
+// ValueOr returns the value, or def when n is NULL.
+func (n Null[T]) ValueOr(def T) T {
+	if !n.Valid {
+		return def
+	}
+	return n.V
+}
+
+// IsZero reports whether n is NULL, for the omitzero JSON option.
+func (n Null[T]) IsZero() bool {
+	return !n.Valid
+}
+
+// Scan implements sql.Scanner with the conversions of database/sql.
+func (n *Null[T]) Scan(src any) error {
+	var s sql.Null[T]
+	if err := s.Scan(src); err != nil {
+		return err
+	}
+	n.V, n.Valid = s.V, s.Valid
+	return nil
+}
+
+// Value implements driver.Valuer. Values are converted like query args, so
+// e.g. Null[int32] and named string types can be written.
+func (n Null[T]) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(n.V)
+}
+
+// MarshalJSON writes null, or the value.
+func (n Null[T]) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.V)
+}
+
+// UnmarshalJSON reads null as NULL, or the value.
+func (n *Null[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*n = Null[T]{}
+		return nil
+	}
+	if err := json.Unmarshal(data, &n.V); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
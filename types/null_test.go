@@ -0,0 +1,86 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNull_Scan(t *testing.T) {
+	var s Null[string]
+	require.NoError(t, s.Scan([]byte("An")))
+	assert.Equal(t, NewNull("An"), s)
+
+	require.NoError(t, s.Scan(nil))
+	assert.False(t, s.Valid)
+	assert.Empty(t, s.V, "NULL resets the value")
+
+	var n Null[int32]
+	require.NoError(t, n.Scan(int64(42)))
+	assert.Equal(t, NewNull(int32(42)), n)
+	assert.Error(t, n.Scan("not a number"))
+
+	var at Null[time.Time]
+	now := time.Date(2026, 10, 15, 10, 0, 0, 0, time.UTC)
+	require.NoError(t, at.Scan(now))
+	assert.Equal(t, now, at.V)
+}
+
+func TestNull_Value(t *testing.T) {
+	type status string
+
+	for _, tt := range []struct {
+		name string
+		in   driver.Valuer
+		want driver.Value
+	}{
+		{"null", Null[string]{}, nil},
+		{"string", NewNull("An"), "An"},
+		{"int32", NewNull(int32(7)), int64(7)},
+		{"named string", NewNull(status("active")), "active"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.in.Value()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNull_JSON(t *testing.T) {
+	type user struct {
+		Nickname Null[string] `json:"nickname"`
+		Age      Null[int]    `json:"age,omitzero"`
+	}
+
+	out, err := json.Marshal(user{Nickname: NewNull("An")})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"nickname":"An"}`, string(out))
+
+	out, err = json.Marshal(user{Age: NewNull(0)})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"nickname":null,"age":0}`, string(out))
+
+	var u user
+	require.NoError(t, json.Unmarshal([]byte(`{"nickname":null,"age":30}`), &u))
+	assert.Equal(t, user{Age: NewNull(30)}, u)
+	assert.Error(t, json.Unmarshal([]byte(`{"age":"x"}`), &u))
+}
+
+func TestNull_Ptr(t *testing.T) {
+	v := "An"
+	n := NullFromPtr(&v)
+	assert.Equal(t, NewNull("An"), n)
+	assert.Equal(t, &v, n.Ptr())
+	assert.NotSame(t, &v, n.Ptr())
+
+	assert.False(t, NullFromPtr[string](nil).Valid)
+	assert.Nil(t, Null[string]{}.Ptr())
+
+	assert.Equal(t, "guest", Null[string]{}.ValueOr("guest"))
+	assert.Equal(t, "An", n.ValueOr("guest"))
+}
@@ -0,0 +1,159 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// UUID is a UUID for ID fields and columns, stored as text: UUID in
+// Postgres, CHAR(36) in MySQL. Use BinaryUUID for MySQL BINARY(16) columns
+// and SqlServerUUID for SQL Server UNIQUEIDENTIFIER columns.
+//
+// In JSON it is the canonical string; the zero UUID is null and written as
+// NULL.
+type UUID uuid.UUID
+
+// NewUUID returns a random (version 4) UUID.
+func NewUUID() UUID {
+	return UUID(uuid.New())
+}
+
+// ParseUUID parses the canonical form, with or without braces or urn:uuid:.
+func ParseUUID(s string) (UUID, error) {
+	u, err := uuid.Parse(s)
+	if err != nil {
+		return UUID{}, fmt.Errorf("[types] invalid UUID %q: %w", s, err)
+	}
+	return UUID(u), nil
+}
+
+// String returns the canonical lower-case form, or "" for the zero UUID.
+func (u UUID) String() string {
+	if u.IsZero() {
+		return ""
+	}
+	return uuid.UUID(u).String()
+}
+
+// IsZero reports whether u is the zero (nil) UUID.
+func (u UUID) IsZero() bool {
+	return u == UUID{}
+}
+
+// Binary returns u for a BINARY(16) column.
+func (u UUID) Binary() BinaryUUID {
+	return BinaryUUID{UUID: u}
+}
+
+// SqlServer returns u for a UNIQUEIDENTIFIER column.
+func (u UUID) SqlServer() SqlServerUUID {
+	return SqlServerUUID{UUID: u}
+}
+
+// MarshalText implements encoding.TextMarshaler, for query and form binding.
+func (u UUID) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler; "" is the zero UUID.
+func (u *UUID) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*u = UUID{}
+		return nil
+	}
+	v, err := ParseUUID(string(text))
+	if err != nil {
+		return err
+	}
+	*u = v
+	return nil
+}
+
+// MarshalJSON writes the canonical string, or null for the zero UUID.
+func (u UUID) MarshalJSON() ([]byte, error) {
+	if u.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(u.String())
+}
+
+// UnmarshalJSON reads a UUID string; null and "" are the zero UUID.
+func (u *UUID) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*u = UUID{}
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return u.UnmarshalText([]byte(s))
+}
+
+// Scan implements sql.Scanner for text columns and 16-byte binary columns
+// in RFC 4122 byte order (MySQL UUID_TO_BIN, Postgres binary).
+func (u *UUID) Scan(src any) error {
+	switch x := src.(type) {
+	case nil:
+		*u = UUID{}
+		return nil
+	case string:
+		return u.UnmarshalText([]byte(x))
+	case []byte:
+		if len(x) == 16 {
+			copy(u[:], x)
+			return nil
+		}
+		return u.UnmarshalText(x)
+	default:
+		return fmt.Errorf("[types] cannot scan %T into UUID", src)
+	}
+}
+
+// Value implements driver.Valuer with the canonical string.
+func (u UUID) Value() (driver.Value, error) {
+	if u.IsZero() {
+		return nil, nil
+	}
+	return u.String(), nil
+}
+
+// BinaryUUID is a UUID stored in 16 bytes, for MySQL BINARY(16) columns
+// (UUID_TO_BIN without swap). It reads like UUID, and in JSON it is the
+// canonical string as well.
+type BinaryUUID struct {
+	UUID
+}
+
+// Value implements driver.Valuer with the 16 bytes.
+func (u BinaryUUID) Value() (driver.Value, error) {
+	if u.IsZero() {
+		return nil, nil
+	}
+	return u.UUID[:], nil
+}
+
+// SqlServerUUID is a UUID for SQL Server UNIQUEIDENTIFIER columns, which the
+// driver returns as 16 bytes with the first three groups little-endian. It
+// writes like UUID, and in JSON it is the canonical string as well.
+type SqlServerUUID struct {
+	UUID
+}
+
+// Scan implements sql.Scanner, swapping the first three groups of 16-byte values.
+func (u *SqlServerUUID) Scan(src any) error {
+	b, ok := src.([]byte)
+	if !ok || len(b) != 16 {
+		return u.UUID.Scan(src)
+	}
+	u.UUID = UUID{
+		b[3], b[2], b[1], b[0],
+		b[5], b[4],
+		b[7], b[6],
+		b[8], b[9], b[10], b[11], b[12], b[13], b[14], b[15],
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testUUID = "3f2504e0-4f89-11d3-9a0c-0305e82c3301"
+
+func TestUUID_Parse(t *testing.T) {
+	u, err := ParseUUID("{3F2504E0-4F89-11D3-9A0C-0305E82C3301}")
+	require.NoError(t, err)
+	assert.Equal(t, testUUID, u.String())
+
+	_, err = ParseUUID("not-a-uuid")
+	assert.ErrorContains(t, err, `invalid UUID "not-a-uuid"`)
+
+	assert.True(t, UUID{}.IsZero())
+	assert.Empty(t, UUID{}.String())
+	assert.NotEqual(t, NewUUID(), NewUUID())
+}
+
+func TestUUID_ScanValue(t *testing.T) {
+	want, _ := ParseUUID(testUUID)
+
+	var u UUID
+	require.NoError(t, u.Scan(testUUID))
+	assert.Equal(t, want, u)
+
+	require.NoError(t, u.Scan([]byte(testUUID)))
+	assert.Equal(t, want, u)
+
+	// BINARY(16)
+	require.NoError(t, u.Scan(want[:]))
+	assert.Equal(t, want, u)
+
+	require.NoError(t, u.Scan(nil))
+	assert.True(t, u.IsZero())
+	assert.Error(t, u.Scan(42))
+
+	v, err := want.Value()
+	require.NoError(t, err)
+	assert.Equal(t, testUUID, v)
+
+	v, err = want.Binary().Value()
+	require.NoError(t, err)
+	assert.Equal(t, want[:], v)
+
+	v, err = UUID{}.Value()
+	require.NoError(t, err)
+	assert.Nil(t, v)
+
+	var b BinaryUUID
+	require.NoError(t, b.Scan(want[:]))
+	assert.Equal(t, want, b.UUID)
+}
+
+func TestSqlServerUUID_Scan(t *testing.T) {
+	want, _ := ParseUUID(testUUID)
+
+	// UNIQUEIDENTIFIER bytes: first three groups little-endian
+	raw := []byte{
+		want[3], want[2], want[1], want[0],
+		want[5], want[4],
+		want[7], want[6],
+	}
+	raw = append(raw, want[8:]...)
+
+	var u SqlServerUUID
+	require.NoError(t, u.Scan(raw))
+	assert.Equal(t, want, u.UUID)
+
+	require.NoError(t, u.Scan(testUUID))
+	assert.Equal(t, want, u.UUID)
+
+	require.NoError(t, u.Scan(nil))
+	assert.True(t, u.IsZero())
+
+	v, err := want.SqlServer().Value()
+	require.NoError(t, err)
+	assert.Equal(t, testUUID, v)
+}
+
+func TestUUID_JSON(t *testing.T) {
+	type order struct {
+		ID     UUID       `json:"id"`
+		Parent UUID       `json:"parent"`
+		Ref    BinaryUUID `json:"ref"`
+	}
+	id, _ := ParseUUID(testUUID)
+
+	out, err := json.Marshal(order{ID: id, Ref: id.Binary()})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":"`+testUUID+`","parent":null,"ref":"`+testUUID+`"}`, string(out))
+
+	var o order
+	require.NoError(t, json.Unmarshal(out, &o))
+	assert.Equal(t, order{ID: id, Ref: id.Binary()}, o)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"id":"x"}`), &o))
+}
@@ -0,0 +1,216 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPool_RunsAllTasks(t *testing.T) {
+	p := NewPool(context.Background(), 3, 10)
+
+	var n atomic.Int32
+	for i := 0; i < 20; i++ {
+		require.NoError(t, p.Submit(context.Background(), func(ctx context.Context) {
+			n.Add(1)
+		}))
+	}
+	p.Close()
+
+	assert.Equal(t, int32(20), n.Load())
+	assert.ErrorIs(t, p.Submit(context.Background(), func(ctx context.Context) {}), ErrPoolClosed)
+	assert.False(t, p.TrySubmit(func(ctx context.Context) {}))
+}
+
+func TestPool_RecoversPanic(t *testing.T) {
+	p := NewPool(context.Background(), 1, 1)
+
+	var ran atomic.Bool
+	require.NoError(t, p.Submit(context.Background(), func(ctx context.Context) { panic("boom") }))
+	require.NoError(t, p.Submit(context.Background(), func(ctx context.Context) { ran.Store(true) }))
+	p.Close()
+
+	assert.True(t, ran.Load())
+}
+
+func TestPool_BoundedConcurrency(t *testing.T) {
+	p := NewPool(context.Background(), 2, 0)
+
+	var cur, peak atomic.Int32
+	for i := 0; i < 8; i++ {
+		require.NoError(t, p.Submit(context.Background(), func(ctx context.Context) {
+			c := cur.Add(1)
+			for {
+				old := peak.Load()
+				if c <= old || peak.CompareAndSwap(old, c) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			cur.Add(-1)
+		}))
+	}
+	p.Close()
+
+	assert.LessOrEqual(t, peak.Load(), int32(2))
+}
+
+func TestPool_StopCancelsTasks(t *testing.T) {
+	p := NewPool(context.Background(), 1, 1)
+
+	started := make(chan struct{})
+	var canceled atomic.Bool
+	require.NoError(t, p.Submit(context.Background(), func(ctx context.Context) {
+		close(started)
+		<-ctx.Done()
+		canceled.Store(true)
+	}))
+	<-started
+	p.Stop()
+
+	assert.True(t, canceled.Load())
+}
+
+func TestParallelMap_KeepsOrder(t *testing.T) {
+	items := []int{5, 4, 3, 2, 1}
+	out, err := ParallelMap(context.Background(), items, 2, func(ctx context.Context, n int) (int, error) {
+		time.Sleep(time.Duration(n) * time.Millisecond)
+		return n * 10, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{50, 40, 30, 20, 10}, out)
+}
+
+func TestParallelMap_StopsOnError(t *testing.T) {
+	boom := errors.New("boom")
+	var calls atomic.Int32
+
+	_, err := ParallelMap(context.Background(), make([]int, 100), 1, func(ctx context.Context, _ int) (int, error) {
+		if calls.Add(1) == 3 {
+			return 0, boom
+		}
+		return 0, nil
+	})
+	assert.ErrorIs(t, err, boom)
+	assert.Less(t, calls.Load(), int32(100))
+}
+
+func TestParallelMap_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := ParallelMap(ctx, []int{1, 2, 3}, 2, func(ctx context.Context, n int) (int, error) {
+		return n, nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestGroup_RecoversPanic(t *testing.T) {
+	g, _ := NewGroup(context.Background(), 2)
+	g.Go(func() error { panic("oops") })
+	g.Go(func() error { return nil })
+
+	err := g.Wait()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "oops")
+}
+
+func TestForEach(t *testing.T) {
+	var mu sync.Mutex
+	seen := map[int]bool{}
+	err := ForEach(context.Background(), []int{1, 2, 3}, 0, func(ctx context.Context, n int) error {
+		mu.Lock()
+		seen[n] = true
+		mu.Unlock()
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Len(t, seen, 3)
+}
+
+func TestRetry(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 4, InitialDelay: time.Millisecond, Jitter: true}
+
+	var calls int
+	v, err := RetryValue(context.Background(), policy, func(ctx context.Context) (string, error) {
+		calls++
+		if calls < 3 {
+			return "", errors.New("temporary")
+		}
+		return "ok", nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "ok", v)
+	assert.Equal(t, 3, calls)
+}
+
+func TestRetry_ExhaustedAndNotRetryable(t *testing.T) {
+	boom := errors.New("boom")
+	fatal := errors.New("fatal")
+
+	calls := 0
+	err := Retry(context.Background(), RetryPolicy{MaxAttempts: 2, InitialDelay: time.Millisecond},
+		func(ctx context.Context) error {
+			calls++
+			return boom
+		})
+	assert.ErrorIs(t, err, boom)
+	assert.Equal(t, 2, calls)
+
+	calls = 0
+	err = Retry(context.Background(), RetryPolicy{
+		MaxAttempts:  5,
+		InitialDelay: time.Millisecond,
+		RetryIf:      func(err error) bool { return !errors.Is(err, fatal) },
+	}, func(ctx context.Context) error {
+		calls++
+		return fatal
+	})
+	assert.ErrorIs(t, err, fatal)
+	assert.Equal(t, 1, calls)
+}
+
+func TestRetry_ContextDone(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := Retry(ctx, RetryPolicy{MaxAttempts: 100, InitialDelay: time.Second}, func(ctx context.Context) error {
+		return errors.New("fail")
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestDebouncer(t *testing.T) {
+	var n atomic.Int32
+	d := NewDebouncer(20*time.Millisecond, func() { n.Add(1) })
+
+	for i := 0; i < 5; i++ {
+		d.Call()
+		time.Sleep(2 * time.Millisecond)
+	}
+	time.Sleep(60 * time.Millisecond)
+	assert.Equal(t, int32(1), n.Load())
+
+	d.Call()
+	d.Cancel()
+	time.Sleep(40 * time.Millisecond)
+	assert.Equal(t, int32(1), n.Load())
+}
+
+func TestThrottler(t *testing.T) {
+	var n atomic.Int32
+	th := NewThrottler(50*time.Millisecond, func() { n.Add(1) })
+
+	assert.True(t, th.Call())
+	assert.False(t, th.Call())
+	assert.False(t, th.Call())
+	time.Sleep(60 * time.Millisecond)
+	assert.True(t, th.Call())
+	assert.Equal(t, int32(2), n.Load())
+}
@@ -0,0 +1,74 @@
+package async
+
+import (
+	"sync"
+	"time"
+)
+
+// Debouncer delays fn until no call has been made for the configured wait.
+type Debouncer struct {
+	mu    sync.Mutex
+	wait  time.Duration
+	fn    func()
+	timer *time.Timer
+}
+
+// NewDebouncer returns a Debouncer that runs fn once calls stop for wait.
+//
+// Example:
+//
+//	d := async.NewDebouncer(500*time.Millisecond, reloadConfig)
+//	watcher.OnChange(d.Call) // many events -> one reload
+func NewDebouncer(wait time.Duration, fn func()) *Debouncer {
+	return &Debouncer{wait: wait, fn: fn}
+}
+
+// Call (re)starts the wait period.
+func (d *Debouncer) Call() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.timer != nil {
+		d.timer.Stop()
+	}
+	d.timer = time.AfterFunc(d.wait, d.fn)
+}
+
+// Cancel drops a pending call, if any.
+func (d *Debouncer) Cancel() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+}
+
+// Throttler runs fn at most once per interval; extra calls inside the interval are dropped.
+type Throttler struct {
+	mu       sync.Mutex
+	interval time.Duration
+	fn       func()
+	last     time.Time
+}
+
+// NewThrottler returns a Throttler that runs fn on the leading edge of each interval.
+func NewThrottler(interval time.Duration, fn func()) *Throttler {
+	return &Throttler{interval: interval, fn: fn}
+}
+
+// Call runs fn if the interval since the last run has elapsed and reports whether it ran.
+func (t *Throttler) Call() bool {
+	t.mu.Lock()
+	now := time.Now()
+	if !t.last.IsZero() && now.Sub(t.last) < t.interval {
+		t.mu.Unlock()
+		return false
+	}
+	t.last = now
+	t.mu.Unlock()
+
+	t.fn()
+	return true
+}
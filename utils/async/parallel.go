@@ -0,0 +1,94 @@
+package async
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// Group runs functions concurrently with an optional concurrency limit.
+// The first error cancels the group's context; panics are converted into errors.
+type Group struct {
+	g *errgroup.Group
+}
+
+// NewGroup returns a Group and a context canceled when any function fails.
+// If limit < 1 there is no limit on concurrent goroutines.
+func NewGroup(ctx context.Context, limit int) (*Group, context.Context) {
+	g, gCtx := errgroup.WithContext(ctx)
+	if limit > 0 {
+		g.SetLimit(limit)
+	}
+	return &Group{g: g}, gCtx
+}
+
+// Go runs fn in a new goroutine, blocking while the limit is reached.
+func (g *Group) Go(fn func() error) {
+	g.g.Go(func() error {
+		return safeCall(fn)
+	})
+}
+
+// Wait blocks until all functions have returned and returns the first error.
+func (g *Group) Wait() error {
+	return g.g.Wait()
+}
+
+// ParallelMap applies fn to every item with at most limit concurrent calls
+// and returns results in input order. The first error cancels the remaining work.
+//
+// Example:
+//
+//	users, err := async.ParallelMap(ctx, ids, 8, func(ctx context.Context, id int64) (*User, error) {
+//		return repo.FindByID(ctx, id)
+//	})
+func ParallelMap[T any, R any](ctx context.Context, items []T, limit int,
+	fn func(context.Context, T) (R, error)) ([]R, error) {
+	results := make([]R, len(items))
+
+	g, gCtx := NewGroup(ctx, limit)
+	for i, item := range items {
+		if gCtx.Err() != nil {
+			break
+		}
+		g.Go(func() error {
+			if err := gCtx.Err(); err != nil {
+				return err
+			}
+			r, err := fn(gCtx, item)
+			if err != nil {
+				return err
+			}
+			results[i] = r
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// ForEach calls fn for every item with at most limit concurrent calls.
+// The first error cancels the remaining work and is returned.
+func ForEach[T any](ctx context.Context, items []T, limit int, fn func(context.Context, T) error) error {
+	_, err := ParallelMap(ctx, items, limit, func(ctx context.Context, item T) (struct{}, error) {
+		return struct{}{}, fn(ctx, item)
+	})
+	return err
+}
+
+func safeCall(fn func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v\n%s", r, debug.Stack())
+		}
+	}()
+	return fn()
+}
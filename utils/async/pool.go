@@ -0,0 +1,142 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"runtime/debug"
+	"sync"
+
+	"github.com/BevisDev/godev/utils/console"
+)
+
+// ErrPoolClosed is returned when submitting to a pool that has been closed.
+var ErrPoolClosed = errors.New("pool is closed")
+
+// Task is a unit of work executed by a Pool.
+type Task func(ctx context.Context)
+
+// Pool is a bounded worker pool: a fixed number of workers consume tasks
+// from a bounded queue. Panics inside tasks are recovered and logged.
+type Pool struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	tasks  chan Task
+	wg     sync.WaitGroup
+	mu     sync.RWMutex
+	closed bool
+	log    *console.Logger
+}
+
+// NewPool starts a pool with workers goroutines and a queue of queueSize pending tasks.
+// Tasks receive a context derived from ctx which is canceled by Stop.
+// If workers < 1 it is treated as 1; if queueSize < 0 it is treated as 0.
+func NewPool(ctx context.Context, workers, queueSize int) *Pool {
+	if workers < 1 {
+		workers = 1
+	}
+	if queueSize < 0 {
+		queueSize = 0
+	}
+
+	pCtx, cancel := context.WithCancel(ctx)
+	p := &Pool{
+		ctx:    pCtx,
+		cancel: cancel,
+		tasks:  make(chan Task, queueSize),
+		log:    console.New("async"),
+	}
+
+	p.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go p.worker()
+	}
+	return p
+}
+
+func (p *Pool) worker() {
+	defer p.wg.Done()
+	for task := range p.tasks {
+		if p.ctx.Err() != nil {
+			// drain without running once stopped
+			continue
+		}
+		p.run(task)
+	}
+}
+
+func (p *Pool) run(task Task) {
+	defer func() {
+		if r := recover(); r != nil {
+			p.log.Error("[RECOVER] task: %v \npanic: %s", r, debug.Stack())
+		}
+	}()
+	task(p.ctx)
+}
+
+// Submit queues task, blocking while the queue is full.
+// It returns ErrPoolClosed after Close/Stop, or ctx.Err() if ctx is done first.
+func (p *Pool) Submit(ctx context.Context, task Task) error {
+	if task == nil {
+		return nil
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return ErrPoolClosed
+	}
+
+	select {
+	case p.tasks <- task:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-p.ctx.Done():
+		return ErrPoolClosed
+	}
+}
+
+// TrySubmit queues task without blocking and reports whether it was accepted.
+func (p *Pool) TrySubmit(task Task) bool {
+	if task == nil {
+		return false
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return false
+	}
+
+	select {
+	case p.tasks <- task:
+		return true
+	default:
+		return false
+	}
+}
+
+// Close stops accepting tasks and waits until all queued tasks have finished.
+func (p *Pool) Close() {
+	p.shutdown()
+	p.wg.Wait()
+	p.cancel()
+}
+
+// Stop cancels the context passed to running tasks, discards queued tasks
+// and waits for workers to exit.
+func (p *Pool) Stop() {
+	p.cancel()
+	p.shutdown()
+	p.wg.Wait()
+}
+
+func (p *Pool) shutdown() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return
+	}
+	p.closed = true
+	close(p.tasks)
+}
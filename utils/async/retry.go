@@ -0,0 +1,102 @@
+package async
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+)
+
+// RetryPolicy controls how Retry re-runs a failing function.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of calls, including the first one (default 3).
+	MaxAttempts int
+
+	// InitialDelay is the wait before the second attempt (default 100ms).
+	InitialDelay time.Duration
+
+	// MaxDelay caps the wait between attempts (0 means no cap).
+	MaxDelay time.Duration
+
+	// Multiplier grows the delay after each attempt (default 2, 1 means constant delay).
+	Multiplier float64
+
+	// Jitter randomizes each delay in [delay/2, delay) to avoid thundering herds.
+	Jitter bool
+
+	// RetryIf decides whether an error is retryable. Nil retries every error.
+	RetryIf func(error) bool
+}
+
+// DefaultRetryPolicy returns 3 attempts with exponential backoff starting at 100ms.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:  3,
+		InitialDelay: 100 * time.Millisecond,
+		Multiplier:   2,
+	}
+}
+
+func (p RetryPolicy) normalize() RetryPolicy {
+	if p.MaxAttempts < 1 {
+		p.MaxAttempts = 3
+	}
+	if p.InitialDelay <= 0 {
+		p.InitialDelay = 100 * time.Millisecond
+	}
+	if p.Multiplier < 1 {
+		p.Multiplier = 2
+	}
+	return p
+}
+
+// Retry calls fn until it succeeds, the policy is exhausted, the error is not
+// retryable, or ctx is done. It returns the last error from fn, or ctx.Err().
+func Retry(ctx context.Context, policy RetryPolicy, fn func(context.Context) error) error {
+	_, err := RetryValue(ctx, policy, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, fn(ctx)
+	})
+	return err
+}
+
+// RetryValue is like Retry but returns the value produced by the successful call.
+func RetryValue[T any](ctx context.Context, policy RetryPolicy, fn func(context.Context) (T, error)) (T, error) {
+	p := policy.normalize()
+	delay := p.InitialDelay
+
+	var (
+		zero T
+		err  error
+	)
+	for attempt := 1; ; attempt++ {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return zero, ctxErr
+		}
+
+		var v T
+		v, err = fn(ctx)
+		if err == nil {
+			return v, nil
+		}
+		if attempt >= p.MaxAttempts || (p.RetryIf != nil && !p.RetryIf(err)) {
+			return zero, err
+		}
+
+		wait := delay
+		if p.Jitter {
+			wait = wait/2 + rand.N(wait/2+1)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return zero, ctx.Err()
+		case <-timer.C:
+		}
+
+		delay = time.Duration(float64(delay) * p.Multiplier)
+		if p.MaxDelay > 0 && delay > p.MaxDelay {
+			delay = p.MaxDelay
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package breaker
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrOpen is returned when the circuit is open and calls are rejected.
+	ErrOpen = errors.New("circuit breaker is open")
+
+	// ErrTooManyRequests is returned when the half-open trial quota is used up.
+	ErrTooManyRequests = errors.New("circuit breaker: too many requests in half-open state")
+)
+
+// State is the state of a circuit breaker.
+type State int
+
+const (
+	StateClosed State = iota
+	StateHalfOpen
+	StateOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateHalfOpen:
+		return "half-open"
+	case StateOpen:
+		return "open"
+	default:
+		return "unknown"
+	}
+}
+
+// Breaker is a circuit breaker.
+//
+// Closed: calls pass through; consecutive failures are counted and reaching the
+// threshold opens the circuit.
+// Open: calls fail fast with ErrOpen until the open timeout elapses.
+// Half-open: a limited number of trial calls pass; any failure re-opens the
+// circuit, all trials succeeding closes it.
+type Breaker struct {
+	*options
+	name string
+
+	mu         sync.Mutex
+	state      State
+	generation uint64
+	failures   int
+	inFlight   int
+	successes  int
+	openedAt   time.Time
+	now        func() time.Time
+
+	// transitions made under mu, reported by unlock
+	pending []transition
+}
+
+type transition struct {
+	from, to State
+}
+
+// New creates a circuit breaker identified by name (used in callbacks).
+func New(name string, opts ...Option) *Breaker {
+	o := withDefaults()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return &Breaker{
+		options: o,
+		name:    name,
+		state:   StateClosed,
+		now:     time.Now,
+	}
+}
+
+// Name returns the breaker name.
+func (b *Breaker) Name() string {
+	return b.name
+}
+
+// State returns the current state, moving open to half-open when the timeout has elapsed.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.unlock()
+
+	b.refresh()
+	return b.state
+}
+
+// Execute runs fn if the circuit allows it and records the outcome.
+func (b *Breaker) Execute(fn func() error) error {
+	done, err := b.Allow()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			done(errors.New("panic"))
+			panic(r)
+		}
+	}()
+
+	err = fn()
+	done(err)
+	return err
+}
+
+// Do is the generic form of Execute returning fn's value.
+//
+// Example:
+//
+//	user, err := breaker.Do(cb, func() (*User, error) {
+//		return client.GetUser(ctx, id)
+//	})
+func Do[T any](b *Breaker, fn func() (T, error)) (T, error) {
+	var out T
+	err := b.Execute(func() error {
+		v, err := fn()
+		out = v
+		return err
+	})
+	return out, err
+}
+
+// Allow is the two-step form of Execute for wrappers that cannot pass a closure.
+// On success it returns a done callback that must be called exactly once with the call's error.
+func (b *Breaker) Allow() (done func(err error), err error) {
+	b.mu.Lock()
+	defer b.unlock()
+
+	b.refresh()
+	switch b.state {
+	case StateOpen:
+		return nil, ErrOpen
+	case StateHalfOpen:
+		if b.inFlight+b.successes >= b.halfOpenRequests {
+			return nil, ErrTooManyRequests
+		}
+	}
+
+	b.inFlight++
+	gen := b.generation
+
+	var once sync.Once
+	return func(err error) {
+		once.Do(func() { b.record(gen, err) })
+	}, nil
+}
+
+// Reset forces the breaker back to closed and clears all counters.
+func (b *Breaker) Reset() {
+	b.mu.Lock()
+	defer b.unlock()
+
+	b.setState(StateClosed)
+}
+
+func (b *Breaker) record(gen uint64, err error) {
+	b.mu.Lock()
+	defer b.unlock()
+
+	// result of a call started before the last transition: ignore
+	if gen != b.generation {
+		return
+	}
+	b.inFlight--
+
+	if b.isFailure(err) {
+		b.onFailure()
+		return
+	}
+	b.onSuccess()
+}
+
+func (b *Breaker) onSuccess() {
+	switch b.state {
+	case StateClosed:
+		b.failures = 0
+	case StateHalfOpen:
+		b.successes++
+		if b.successes >= b.halfOpenRequests {
+			b.setState(StateClosed)
+		}
+	}
+}
+
+func (b *Breaker) onFailure() {
+	switch b.state {
+	case StateClosed:
+		b.failures++
+		if b.failures >= b.failureThreshold {
+			b.setState(StateOpen)
+		}
+	case StateHalfOpen:
+		b.setState(StateOpen)
+	}
+}
+
+// refresh moves open to half-open once the open timeout has elapsed. Caller holds mu.
+func (b *Breaker) refresh() {
+	if b.state == StateOpen && b.now().Sub(b.openedAt) >= b.openTimeout {
+		b.setState(StateHalfOpen)
+	}
+}
+
+// setState transitions and resets counters. Caller holds mu.
+func (b *Breaker) setState(to State) {
+	from := b.state
+	b.state = to
+	b.generation++
+	b.failures = 0
+	b.inFlight = 0
+	b.successes = 0
+	if to == StateOpen {
+		b.openedAt = b.now()
+	}
+
+	if from != to && b.onStateChange != nil {
+		b.pending = append(b.pending, transition{from: from, to: to})
+	}
+}
+
+// unlock releases mu, then calls onStateChange for the transitions made while
+// it was held, so the callback may use the breaker.
+func (b *Breaker) unlock() {
+	pending := b.pending
+	b.pending = nil
+	b.mu.Unlock()
+
+	for _, t := range pending {
+		b.onStateChange(b.name, t.from, t.to)
+	}
+}
@@ -0,0 +1,159 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errBoom = errors.New("boom")
+
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time          { return c.t }
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestBreaker(opts ...Option) (*Breaker, *fakeClock) {
+	clock := &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	b := New("test", opts...)
+	b.now = clock.now
+	return b, clock
+}
+
+func fail() error    { return errBoom }
+func succeed() error { return nil }
+
+func TestBreaker_OpensAfterThreshold(t *testing.T) {
+	b, _ := newTestBreaker(WithFailureThreshold(3))
+
+	for i := 0; i < 2; i++ {
+		assert.ErrorIs(t, b.Execute(fail), errBoom)
+	}
+	assert.Equal(t, StateClosed, b.State())
+
+	assert.ErrorIs(t, b.Execute(fail), errBoom)
+	assert.Equal(t, StateOpen, b.State())
+
+	called := false
+	err := b.Execute(func() error { called = true; return nil })
+	assert.ErrorIs(t, err, ErrOpen)
+	assert.False(t, called)
+}
+
+func TestBreaker_SuccessResetsFailures(t *testing.T) {
+	b, _ := newTestBreaker(WithFailureThreshold(2))
+
+	_ = b.Execute(fail)
+	_ = b.Execute(succeed)
+	_ = b.Execute(fail)
+	assert.Equal(t, StateClosed, b.State())
+}
+
+func TestBreaker_HalfOpenCloses(t *testing.T) {
+	b, clock := newTestBreaker(WithFailureThreshold(1), WithOpenTimeout(time.Second), WithHalfOpenRequests(2))
+
+	_ = b.Execute(fail)
+	require.Equal(t, StateOpen, b.State())
+
+	clock.advance(time.Second)
+	assert.Equal(t, StateHalfOpen, b.State())
+
+	done1, err := b.Allow()
+	require.NoError(t, err)
+	done2, err := b.Allow()
+	require.NoError(t, err)
+	_, err = b.Allow()
+	assert.ErrorIs(t, err, ErrTooManyRequests)
+
+	done1(nil)
+	assert.Equal(t, StateHalfOpen, b.State())
+	done2(nil)
+	assert.Equal(t, StateClosed, b.State())
+}
+
+func TestBreaker_HalfOpenFailureReopens(t *testing.T) {
+	b, clock := newTestBreaker(WithFailureThreshold(1), WithOpenTimeout(time.Second))
+
+	_ = b.Execute(fail)
+	clock.advance(time.Second)
+	assert.ErrorIs(t, b.Execute(fail), errBoom)
+	assert.Equal(t, StateOpen, b.State())
+}
+
+func TestBreaker_IsFailureAndCallbacks(t *testing.T) {
+	var transitions []string
+	b, clock := newTestBreaker(
+		WithFailureThreshold(1),
+		WithOpenTimeout(time.Second),
+		WithIsFailure(func(err error) bool { return !errors.Is(err, context.Canceled) }),
+		WithOnStateChange(func(name string, from, to State) {
+			transitions = append(transitions, name+":"+from.String()+"->"+to.String())
+		}),
+	)
+
+	_ = b.Execute(func() error { return context.Canceled })
+	assert.Equal(t, StateClosed, b.State())
+
+	_ = b.Execute(fail)
+	clock.advance(time.Second)
+	_ = b.Execute(succeed)
+
+	assert.Equal(t, []string{
+		"test:closed->open",
+		"test:open->half-open",
+		"test:half-open->closed",
+	}, transitions)
+}
+
+func TestBreaker_CallbackUsesBreaker(t *testing.T) {
+	var states []State
+	var b *Breaker
+	b, clock := newTestBreaker(
+		WithFailureThreshold(1),
+		WithOpenTimeout(time.Second),
+		WithOnStateChange(func(_ string, _, to State) {
+			// would deadlock if called with mu held
+			states = append(states, b.State())
+			if to == StateHalfOpen {
+				_, err := b.Allow()
+				assert.NoError(t, err)
+			}
+		}),
+	)
+
+	_ = b.Execute(fail)
+	clock.advance(time.Second)
+	assert.Equal(t, StateHalfOpen, b.State())
+	assert.Equal(t, []State{StateOpen, StateHalfOpen}, states)
+}
+
+func TestBreaker_StaleResultIgnored(t *testing.T) {
+	b, _ := newTestBreaker(WithFailureThreshold(1))
+
+	done, err := b.Allow()
+	require.NoError(t, err)
+	b.Reset()
+	_ = b.Execute(succeed)
+
+	done(errBoom)
+	assert.Equal(t, StateClosed, b.State())
+}
+
+func TestDo(t *testing.T) {
+	b, _ := newTestBreaker()
+
+	v, err := Do(b, func() (int, error) { return 42, nil })
+	require.NoError(t, err)
+	assert.Equal(t, 42, v)
+}
+
+func TestState_String(t *testing.T) {
+	assert.Equal(t, "closed", StateClosed.String())
+	assert.Equal(t, "open", StateOpen.String())
+	assert.Equal(t, "half-open", StateHalfOpen.String())
+	assert.Equal(t, "unknown", State(99).String())
+}
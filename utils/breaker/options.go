@@ -0,0 +1,86 @@
+package breaker
+
+import "time"
+
+const (
+	defaultFailureThreshold = 5
+	defaultOpenTimeout      = 30 * time.Second
+	defaultHalfOpenRequests = 1
+)
+
+type Option func(*options)
+
+type options struct {
+	// failureThreshold is the number of consecutive failures that opens the circuit.
+	failureThreshold int
+
+	// openTimeout is how long the circuit stays open before moving to half-open.
+	openTimeout time.Duration
+
+	// halfOpenRequests is the number of trial requests allowed in half-open state;
+	// the circuit closes once all of them succeed.
+	halfOpenRequests int
+
+	// isFailure decides whether an error counts as a failure (nil error never does).
+	isFailure func(error) bool
+
+	// onStateChange is called after every state transition.
+	onStateChange func(name string, from, to State)
+}
+
+func withDefaults() *options {
+	return &options{
+		failureThreshold: defaultFailureThreshold,
+		openTimeout:      defaultOpenTimeout,
+		halfOpenRequests: defaultHalfOpenRequests,
+		isFailure:        func(err error) bool { return err != nil },
+	}
+}
+
+// WithFailureThreshold sets how many consecutive failures open the circuit.
+func WithFailureThreshold(n int) Option {
+	return func(o *options) {
+		if n > 0 {
+			o.failureThreshold = n
+		}
+	}
+}
+
+// WithOpenTimeout sets how long the circuit stays open before allowing trial requests.
+func WithOpenTimeout(d time.Duration) Option {
+	return func(o *options) {
+		if d > 0 {
+			o.openTimeout = d
+		}
+	}
+}
+
+// WithHalfOpenRequests sets how many trial requests are allowed while half-open.
+func WithHalfOpenRequests(n int) Option {
+	return func(o *options) {
+		if n > 0 {
+			o.halfOpenRequests = n
+		}
+	}
+}
+
+// WithIsFailure overrides which errors count as failures,
+// e.g. to ignore context.Canceled or 4xx responses.
+func WithIsFailure(fn func(error) bool) Option {
+	return func(o *options) {
+		if fn != nil {
+			o.isFailure = func(err error) bool {
+				return err != nil && fn(err)
+			}
+		}
+	}
+}
+
+// WithOnStateChange registers a callback invoked on every state transition.
+// It runs synchronously after the breaker lock is released, so it may call the
+// breaker but must not block.
+func WithOnStateChange(fn func(name string, from, to State)) Option {
+	return func(o *options) {
+		o.onStateChange = fn
+	}
+}
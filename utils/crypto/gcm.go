@@ -0,0 +1,227 @@
+package crypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// envelopeVersion is the current version tag of the Keyring envelope format.
+const envelopeVersion = "v1"
+
+var (
+	// ErrCiphertextTooShort is returned when ciphertext is shorter than the GCM nonce.
+	ErrCiphertextTooShort = errors.New("ciphertext too short")
+
+	// ErrUnknownKey is returned when an envelope references a key ID not in the keyring.
+	ErrUnknownKey = errors.New("unknown key id")
+
+	// ErrInvalidEnvelope is returned when an envelope string is malformed.
+	ErrInvalidEnvelope = errors.New("invalid envelope")
+)
+
+// EncryptGCM encrypts plaintext with AES-GCM and returns nonce||ciphertext||tag.
+// aad is authenticated but not encrypted (may be nil); the same aad is required to decrypt.
+// key must be 16, 24 or 32 bytes (AES-128/192/256).
+func EncryptGCM(plaintext, key, aad []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize(), gcm.NonceSize()+len(plaintext)+gcm.Overhead())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plaintext, aad), nil
+}
+
+// DecryptGCM decrypts data produced by EncryptGCM. It fails if the data, key or aad were tampered with.
+func DecryptGCM(data, key, aad []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < gcm.NonceSize() {
+		return nil, ErrCiphertextTooShort
+	}
+	nonce, ct := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, ct, aad)
+}
+
+// EncryptAESGCM encrypts plaintext with AES-GCM and returns base64-encoded ciphertext.
+// Prefer it over EncryptAES, which provides no integrity protection.
+func EncryptAESGCM(plaintext string, key []byte) (string, error) {
+	out, err := EncryptGCM([]byte(plaintext), key, nil)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(out), nil
+}
+
+// DecryptAESGCM decrypts base64-encoded ciphertext produced by EncryptAESGCM.
+func DecryptAESGCM(ciphertext string, key []byte) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", err
+	}
+	out, err := DecryptGCM(data, key, nil)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Keyring holds versioned AES keys so ciphertexts survive key rotation.
+//
+// Encrypt always uses the primary key and produces an envelope of the form
+//
+//	v1:<keyID>:<base64(nonce||ciphertext||tag)>
+//
+// Decrypt picks the key named in the envelope, so data written with an older key
+// stays readable after a new primary key is set. The key ID is bound into the
+// authenticated data, so an envelope cannot be relabeled to another key.
+type Keyring struct {
+	mu      sync.RWMutex
+	primary string
+	keys    map[string][]byte
+}
+
+// NewKeyring creates a keyring with a single primary key.
+func NewKeyring(keyID string, key []byte) (*Keyring, error) {
+	kr := &Keyring{keys: make(map[string][]byte)}
+	if err := kr.AddKey(keyID, key); err != nil {
+		return nil, err
+	}
+	kr.primary = keyID
+	return kr, nil
+}
+
+// AddKey registers a key that can be used to decrypt (and become primary later).
+func (kr *Keyring) AddKey(keyID string, key []byte) error {
+	if keyID == "" || strings.Contains(keyID, ":") {
+		return fmt.Errorf("invalid key id %q", keyID)
+	}
+	if _, err := aes.NewCipher(key); err != nil {
+		return err
+	}
+
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+	kr.keys[keyID] = append([]byte(nil), key...)
+	return nil
+}
+
+// SetPrimary switches the key used for new encryptions. The key must already be registered.
+func (kr *Keyring) SetPrimary(keyID string) error {
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+
+	if _, ok := kr.keys[keyID]; !ok {
+		return ErrUnknownKey
+	}
+	kr.primary = keyID
+	return nil
+}
+
+// Primary returns the current primary key ID.
+func (kr *Keyring) Primary() string {
+	kr.mu.RLock()
+	defer kr.mu.RUnlock()
+	return kr.primary
+}
+
+// Encrypt seals plaintext with the primary key and returns an envelope string.
+func (kr *Keyring) Encrypt(plaintext, aad []byte) (string, error) {
+	kr.mu.RLock()
+	keyID, key := kr.primary, kr.keys[kr.primary]
+	kr.mu.RUnlock()
+
+	ct, err := EncryptGCM(plaintext, key, envelopeAAD(keyID, aad))
+	if err != nil {
+		return "", err
+	}
+	return envelopeVersion + ":" + keyID + ":" + base64.RawURLEncoding.EncodeToString(ct), nil
+}
+
+// Decrypt opens an envelope produced by Encrypt using the key it references.
+func (kr *Keyring) Decrypt(envelope string, aad []byte) ([]byte, error) {
+	keyID, ct, err := parseEnvelope(envelope)
+	if err != nil {
+		return nil, err
+	}
+
+	kr.mu.RLock()
+	key, ok := kr.keys[keyID]
+	kr.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKey, keyID)
+	}
+
+	return DecryptGCM(ct, key, envelopeAAD(keyID, aad))
+}
+
+// NeedsRotation reports whether envelope was sealed with a key other than the primary.
+func (kr *Keyring) NeedsRotation(envelope string) bool {
+	keyID, _, err := parseEnvelope(envelope)
+	return err == nil && keyID != kr.Primary()
+}
+
+// Rotate re-encrypts envelope with the primary key. It is a no-op when already current.
+func (kr *Keyring) Rotate(envelope string, aad []byte) (string, error) {
+	if !kr.NeedsRotation(envelope) {
+		if _, _, err := parseEnvelope(envelope); err != nil {
+			return "", err
+		}
+		return envelope, nil
+	}
+
+	plain, err := kr.Decrypt(envelope, aad)
+	if err != nil {
+		return "", err
+	}
+	return kr.Encrypt(plain, aad)
+}
+
+// KeyID returns the key ID referenced by an envelope.
+func KeyID(envelope string) (string, error) {
+	keyID, _, err := parseEnvelope(envelope)
+	return keyID, err
+}
+
+func parseEnvelope(envelope string) (string, []byte, error) {
+	parts := strings.SplitN(envelope, ":", 3)
+	if len(parts) != 3 || parts[0] != envelopeVersion || parts[1] == "" {
+		return "", nil, ErrInvalidEnvelope
+	}
+
+	ct, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", nil, ErrInvalidEnvelope
+	}
+	return parts[1], ct, nil
+}
+
+func envelopeAAD(keyID string, aad []byte) []byte {
+	out := make([]byte, 0, len(envelopeVersion)+len(keyID)+len(aad)+2)
+	out = append(out, envelopeVersion...)
+	out = append(out, ':')
+	out = append(out, keyID...)
+	out = append(out, ':')
+	return append(out, aad...)
+}
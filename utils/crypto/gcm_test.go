@@ -0,0 +1,133 @@
+package crypto
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	gcmKey1 = bytes.Repeat([]byte{1}, 32)
+	gcmKey2 = bytes.Repeat([]byte{2}, 32)
+)
+
+func TestEncryptDecryptGCM(t *testing.T) {
+	aad := []byte("user:42")
+
+	ct, err := EncryptGCM([]byte("secret"), gcmKey1, aad)
+	require.NoError(t, err)
+
+	plain, err := DecryptGCM(ct, gcmKey1, aad)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", string(plain))
+
+	_, err = DecryptGCM(ct, gcmKey1, []byte("user:43"))
+	assert.Error(t, err, "aad mismatch must fail")
+
+	_, err = DecryptGCM(ct, gcmKey2, aad)
+	assert.Error(t, err, "wrong key must fail")
+
+	ct[len(ct)-1] ^= 0xff
+	_, err = DecryptGCM(ct, gcmKey1, aad)
+	assert.Error(t, err, "tampered ciphertext must fail")
+
+	_, err = DecryptGCM([]byte{1, 2}, gcmKey1, nil)
+	assert.ErrorIs(t, err, ErrCiphertextTooShort)
+
+	_, err = EncryptGCM([]byte("x"), []byte("short"), nil)
+	assert.Error(t, err)
+}
+
+func TestEncryptDecryptAESGCM(t *testing.T) {
+	ct, err := EncryptAESGCM("hello", gcmKey1)
+	require.NoError(t, err)
+
+	plain, err := DecryptAESGCM(ct, gcmKey1)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", plain)
+
+	_, err = DecryptAESGCM("%%%", gcmKey1)
+	assert.Error(t, err)
+}
+
+func TestKeyring_Rotation(t *testing.T) {
+	kr, err := NewKeyring("k1", gcmKey1)
+	require.NoError(t, err)
+
+	env, err := kr.Encrypt([]byte("card"), []byte("row-1"))
+	require.NoError(t, err)
+	keyID, err := KeyID(env)
+	require.NoError(t, err)
+	assert.Equal(t, "k1", keyID)
+
+	require.NoError(t, kr.AddKey("k2", gcmKey2))
+	require.NoError(t, kr.SetPrimary("k2"))
+	assert.Equal(t, "k2", kr.Primary())
+
+	// old data still readable
+	plain, err := kr.Decrypt(env, []byte("row-1"))
+	require.NoError(t, err)
+	assert.Equal(t, "card", string(plain))
+
+	assert.True(t, kr.NeedsRotation(env))
+	rotated, err := kr.Rotate(env, []byte("row-1"))
+	require.NoError(t, err)
+	assert.False(t, kr.NeedsRotation(rotated))
+
+	same, err := kr.Rotate(rotated, []byte("row-1"))
+	require.NoError(t, err)
+	assert.Equal(t, rotated, same)
+
+	plain, err = kr.Decrypt(rotated, []byte("row-1"))
+	require.NoError(t, err)
+	assert.Equal(t, "card", string(plain))
+}
+
+func TestKeyring_Errors(t *testing.T) {
+	kr, err := NewKeyring("k1", gcmKey1)
+	require.NoError(t, err)
+
+	_, err = NewKeyring("bad:id", gcmKey1)
+	assert.Error(t, err)
+	assert.ErrorIs(t, kr.SetPrimary("missing"), ErrUnknownKey)
+
+	_, err = kr.Decrypt("garbage", nil)
+	assert.ErrorIs(t, err, ErrInvalidEnvelope)
+
+	env, err := kr.Encrypt([]byte("x"), nil)
+	require.NoError(t, err)
+
+	other, err := NewKeyring("k9", gcmKey1)
+	require.NoError(t, err)
+	_, err = other.Decrypt(env, nil)
+	assert.ErrorIs(t, err, ErrUnknownKey)
+
+	// relabeling the envelope to another key ID with the same key material must fail
+	require.NoError(t, other.AddKey("k1x", gcmKey1))
+	relabeled := "v1:k1x:" + env[len("v1:k1:"):]
+	_, err = other.Decrypt(relabeled, nil)
+	assert.Error(t, err)
+}
+
+func TestDeriveKeys(t *testing.T) {
+	salt, err := NewSalt(16)
+	require.NoError(t, err)
+	assert.Len(t, salt, 16)
+
+	k1, err := DeriveKeyPBKDF2([]byte("pass"), salt, 1000, 32)
+	require.NoError(t, err)
+	k2, err := DeriveKeyPBKDF2([]byte("pass"), salt, 1000, 32)
+	require.NoError(t, err)
+	assert.Len(t, k1, 32)
+	assert.Equal(t, k1, k2)
+
+	ks, err := DeriveKeyScrypt([]byte("pass"), salt, 32)
+	require.NoError(t, err)
+	assert.Len(t, ks, 32)
+
+	ka := DeriveKeyArgon2id([]byte("pass"), salt, 32)
+	assert.Len(t, ka, 32)
+	assert.NotEqual(t, ka, DeriveKeyArgon2id([]byte("other"), salt, 32))
+}
@@ -0,0 +1,343 @@
+package crypto
+
+import (
+	stdcrypto "crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+)
+
+// JWT signing algorithms.
+type Alg string
+
+const (
+	HS256 Alg = "HS256"
+	RS256 Alg = "RS256"
+	ES256 Alg = "ES256"
+)
+
+var (
+	ErrTokenMalformed    = errors.New("token is malformed")
+	ErrTokenUnverifiable = errors.New("token is unverifiable")
+	ErrTokenSignature    = errors.New("token signature is invalid")
+	ErrTokenExpired      = errors.New("token is expired")
+	ErrTokenNotYetValid  = errors.New("token is not valid yet")
+	ErrTokenAudience     = errors.New("token has invalid audience")
+	ErrTokenIssuer       = errors.New("token has invalid issuer")
+	ErrUnsupportedAlg    = errors.New("unsupported signing algorithm")
+)
+
+// JWTHeader is the JOSE header of a token.
+type JWTHeader struct {
+	Alg Alg    `json:"alg"`
+	Typ string `json:"typ,omitempty"`
+	Kid string `json:"kid,omitempty"`
+}
+
+// Audience is the "aud" claim; it accepts both a single string and an array in JSON.
+type Audience []string
+
+func (a *Audience) UnmarshalJSON(b []byte) error {
+	var single string
+	if err := json.Unmarshal(b, &single); err == nil {
+		*a = Audience{single}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(b, &many); err != nil {
+		return err
+	}
+	*a = many
+	return nil
+}
+
+func (a Audience) MarshalJSON() ([]byte, error) {
+	if len(a) == 1 {
+		return json.Marshal(a[0])
+	}
+	return json.Marshal([]string(a))
+}
+
+// RegisteredClaims are the standard RFC 7519 claims. Embed it in custom claim structs.
+// Times are Unix seconds; zero means the claim is absent.
+type RegisteredClaims struct {
+	Issuer    string   `json:"iss,omitempty"`
+	Subject   string   `json:"sub,omitempty"`
+	Audience  Audience `json:"aud,omitempty"`
+	ExpiresAt int64    `json:"exp,omitempty"`
+	NotBefore int64    `json:"nbf,omitempty"`
+	IssuedAt  int64    `json:"iat,omitempty"`
+	ID        string   `json:"jti,omitempty"`
+}
+
+// NewRegisteredClaims returns claims issued now and expiring after ttl.
+func NewRegisteredClaims(issuer, subject string, ttl time.Duration, audience ...string) RegisteredClaims {
+	now := time.Now()
+	return RegisteredClaims{
+		Issuer:    issuer,
+		Subject:   subject,
+		Audience:  audience,
+		IssuedAt:  now.Unix(),
+		NotBefore: now.Unix(),
+		ExpiresAt: now.Add(ttl).Unix(),
+	}
+}
+
+// KeyFunc returns the verification key for a token: []byte for HS256,
+// *rsa.PublicKey for RS256 and *ecdsa.PublicKey for ES256.
+type KeyFunc func(header JWTHeader) (any, error)
+
+// StaticKey returns a KeyFunc that always returns key.
+func StaticKey(key any) KeyFunc {
+	return func(JWTHeader) (any, error) { return key, nil }
+}
+
+type JWTOption func(*jwtOptions)
+
+type jwtOptions struct {
+	keyID    string
+	audience string
+	issuer   string
+	leeway   time.Duration
+	now      func() time.Time
+}
+
+// WithKeyID sets the "kid" header when creating a token.
+func WithKeyID(kid string) JWTOption {
+	return func(o *jwtOptions) { o.keyID = kid }
+}
+
+// WithAudience requires the token's "aud" claim to contain aud.
+func WithAudience(aud string) JWTOption {
+	return func(o *jwtOptions) { o.audience = aud }
+}
+
+// WithIssuer requires the token's "iss" claim to equal iss.
+func WithIssuer(iss string) JWTOption {
+	return func(o *jwtOptions) { o.issuer = iss }
+}
+
+// WithLeeway allows clock skew when checking "exp" and "nbf".
+func WithLeeway(d time.Duration) JWTOption {
+	return func(o *jwtOptions) { o.leeway = d }
+}
+
+func newJWTOptions(opts []JWTOption) *jwtOptions {
+	o := &jwtOptions{now: time.Now}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// CreateJWT signs claims (any JSON-encodable value, usually a struct embedding
+// RegisteredClaims) with key using alg: []byte for HS256, *rsa.PrivateKey for RS256,
+// *ecdsa.PrivateKey (P-256) for ES256.
+//
+// Example:
+//
+//	type Claims struct {
+//		crypto.RegisteredClaims
+//		Role string `json:"role"`
+//	}
+//	token, err := crypto.CreateJWT(Claims{
+//		RegisteredClaims: crypto.NewRegisteredClaims("order-svc", "user-1", time.Hour, "payment-svc"),
+//		Role:             "admin",
+//	}, secret, crypto.HS256)
+func CreateJWT(claims any, key any, alg Alg, opts ...JWTOption) (string, error) {
+	o := newJWTOptions(opts)
+
+	header, err := json.Marshal(JWTHeader{Alg: alg, Typ: "JWT", Kid: o.keyID})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := b64url(header) + "." + b64url(payload)
+	sig, err := signJWT(alg, key, []byte(signingInput))
+	if err != nil {
+		return "", err
+	}
+	return signingInput + "." + b64url(sig), nil
+}
+
+// ParseAndVerifyJWT verifies token's signature with the key returned by keyFunc,
+// validates exp/nbf (and aud/iss when required by options), and decodes the claims into T.
+func ParseAndVerifyJWT[T any](token string, keyFunc KeyFunc, opts ...JWTOption) (T, error) {
+	var zero T
+	o := newJWTOptions(opts)
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return zero, ErrTokenMalformed
+	}
+
+	var header JWTHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return zero, err
+	}
+
+	key, err := keyFunc(header)
+	if err != nil {
+		return zero, fmt.Errorf("%w: %v", ErrTokenUnverifiable, err)
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return zero, ErrTokenMalformed
+	}
+	if err := verifyJWT(header.Alg, key, []byte(parts[0]+"."+parts[1]), sig); err != nil {
+		return zero, err
+	}
+
+	var registered RegisteredClaims
+	if err := decodeSegment(parts[1], &registered); err != nil {
+		return zero, err
+	}
+	if err := validateClaims(registered, o); err != nil {
+		return zero, err
+	}
+
+	var claims T
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return zero, err
+	}
+	return claims, nil
+}
+
+func validateClaims(c RegisteredClaims, o *jwtOptions) error {
+	now := o.now()
+	if c.ExpiresAt != 0 && !now.Before(time.Unix(c.ExpiresAt, 0).Add(o.leeway)) {
+		return ErrTokenExpired
+	}
+	if c.NotBefore != 0 && now.Add(o.leeway).Before(time.Unix(c.NotBefore, 0)) {
+		return ErrTokenNotYetValid
+	}
+	if o.audience != "" && !slices.Contains(c.Audience, o.audience) {
+		return ErrTokenAudience
+	}
+	if o.issuer != "" && c.Issuer != o.issuer {
+		return ErrTokenIssuer
+	}
+	return nil
+}
+
+func signJWT(alg Alg, key any, input []byte) ([]byte, error) {
+	switch alg {
+	case HS256:
+		secret, ok := key.([]byte)
+		if !ok || len(secret) == 0 {
+			return nil, fmt.Errorf("%s requires a []byte key", alg)
+		}
+		h := hmac.New(sha256.New, secret)
+		h.Write(input)
+		return h.Sum(nil), nil
+
+	case RS256:
+		priv, ok := key.(*rsa.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("%s requires *rsa.PrivateKey", alg)
+		}
+		digest := sha256.Sum256(input)
+		return rsa.SignPKCS1v15(rand.Reader, priv, stdcrypto.SHA256, digest[:])
+
+	case ES256:
+		priv, ok := key.(*ecdsa.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("%s requires *ecdsa.PrivateKey", alg)
+		}
+		if priv.Curve != elliptic.P256() {
+			return nil, fmt.Errorf("%s requires a P-256 key", alg)
+		}
+		digest := sha256.Sum256(input)
+		r, s, err := ecdsa.Sign(rand.Reader, priv, digest[:])
+		if err != nil {
+			return nil, err
+		}
+		// JWS uses fixed-size R||S rather than ASN.1
+		out := make([]byte, 64)
+		r.FillBytes(out[:32])
+		s.FillBytes(out[32:])
+		return out, nil
+
+	default:
+		return nil, ErrUnsupportedAlg
+	}
+}
+
+// verifyJWT checks sig, and rejects keys whose type does not match alg
+// (prevents algorithm-confusion attacks such as HS256 with an RSA public key).
+func verifyJWT(alg Alg, key any, input, sig []byte) error {
+	switch alg {
+	case HS256:
+		secret, ok := key.([]byte)
+		if !ok || len(secret) == 0 {
+			return ErrTokenUnverifiable
+		}
+		h := hmac.New(sha256.New, secret)
+		h.Write(input)
+		if !hmac.Equal(h.Sum(nil), sig) {
+			return ErrTokenSignature
+		}
+		return nil
+
+	case RS256:
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return ErrTokenUnverifiable
+		}
+		digest := sha256.Sum256(input)
+		if rsa.VerifyPKCS1v15(pub, stdcrypto.SHA256, digest[:], sig) != nil {
+			return ErrTokenSignature
+		}
+		return nil
+
+	case ES256:
+		pub, ok := key.(*ecdsa.PublicKey)
+		if !ok || pub.Curve != elliptic.P256() {
+			return ErrTokenUnverifiable
+		}
+		if len(sig) != 64 {
+			return ErrTokenSignature
+		}
+		digest := sha256.Sum256(input)
+		r := new(big.Int).SetBytes(sig[:32])
+		s := new(big.Int).SetBytes(sig[32:])
+		if !ecdsa.Verify(pub, digest[:], r, s) {
+			return ErrTokenSignature
+		}
+		return nil
+
+	default:
+		return ErrUnsupportedAlg
+	}
+}
+
+func b64url(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeSegment(seg string, v any) error {
+	raw, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return ErrTokenMalformed
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return fmt.Errorf("%w: %v", ErrTokenMalformed, err)
+	}
+	return nil
+}
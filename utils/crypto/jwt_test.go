@@ -0,0 +1,140 @@
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testClaims struct {
+	RegisteredClaims
+	Role string `json:"role"`
+}
+
+func TestJWT_AllAlgorithms(t *testing.T) {
+	secret := []byte("super-secret")
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tests := []struct {
+		alg     Alg
+		signKey any
+		pubKey  any
+	}{
+		{HS256, secret, secret},
+		{RS256, rsaKey, &rsaKey.PublicKey},
+		{ES256, ecKey, &ecKey.PublicKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.alg), func(t *testing.T) {
+			claims := testClaims{
+				RegisteredClaims: NewRegisteredClaims("issuer", "user-1", time.Hour, "svc-a", "svc-b"),
+				Role:             "admin",
+			}
+			token, err := CreateJWT(claims, tt.signKey, tt.alg, WithKeyID("k1"))
+			require.NoError(t, err)
+			assert.Equal(t, 2, strings.Count(token, "."))
+
+			var gotKid string
+			out, err := ParseAndVerifyJWT[testClaims](token, func(h JWTHeader) (any, error) {
+				gotKid = h.Kid
+				return tt.pubKey, nil
+			}, WithAudience("svc-b"), WithIssuer("issuer"))
+			require.NoError(t, err)
+			assert.Equal(t, "k1", gotKid)
+			assert.Equal(t, "admin", out.Role)
+			assert.Equal(t, "user-1", out.Subject)
+			assert.Equal(t, Audience{"svc-a", "svc-b"}, out.Audience)
+
+			tampered := token[:len(token)-4] + "AAAA"
+			_, err = ParseAndVerifyJWT[testClaims](tampered, StaticKey(tt.pubKey))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestJWT_ClaimValidation(t *testing.T) {
+	secret := []byte("s")
+	now := time.Now()
+
+	expired, err := CreateJWT(RegisteredClaims{ExpiresAt: now.Add(-time.Minute).Unix()}, secret, HS256)
+	require.NoError(t, err)
+	_, err = ParseAndVerifyJWT[RegisteredClaims](expired, StaticKey(secret))
+	assert.ErrorIs(t, err, ErrTokenExpired)
+
+	_, err = ParseAndVerifyJWT[RegisteredClaims](expired, StaticKey(secret), WithLeeway(2*time.Minute))
+	assert.NoError(t, err)
+
+	future, err := CreateJWT(RegisteredClaims{NotBefore: now.Add(time.Hour).Unix()}, secret, HS256)
+	require.NoError(t, err)
+	_, err = ParseAndVerifyJWT[RegisteredClaims](future, StaticKey(secret))
+	assert.ErrorIs(t, err, ErrTokenNotYetValid)
+
+	single, err := CreateJWT(RegisteredClaims{Audience: Audience{"a"}, Issuer: "x"}, secret, HS256)
+	require.NoError(t, err)
+	assert.Contains(t, single, ".")
+	_, err = ParseAndVerifyJWT[RegisteredClaims](single, StaticKey(secret), WithAudience("b"))
+	assert.ErrorIs(t, err, ErrTokenAudience)
+	_, err = ParseAndVerifyJWT[RegisteredClaims](single, StaticKey(secret), WithIssuer("y"))
+	assert.ErrorIs(t, err, ErrTokenIssuer)
+}
+
+func TestJWT_Errors(t *testing.T) {
+	secret := []byte("s")
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	_, err = ParseAndVerifyJWT[RegisteredClaims]("a.b", StaticKey(secret))
+	assert.ErrorIs(t, err, ErrTokenMalformed)
+
+	_, err = CreateJWT(RegisteredClaims{}, secret, "none")
+	assert.ErrorIs(t, err, ErrUnsupportedAlg)
+
+	_, err = CreateJWT(RegisteredClaims{}, rsaKey, HS256)
+	assert.Error(t, err)
+
+	// algorithm confusion: HS256 token verified against an RSA public key must fail
+	token, err := CreateJWT(RegisteredClaims{}, secret, HS256)
+	require.NoError(t, err)
+	_, err = ParseAndVerifyJWT[RegisteredClaims](token, StaticKey(&rsaKey.PublicKey))
+	assert.ErrorIs(t, err, ErrTokenUnverifiable)
+
+	_, err = ParseAndVerifyJWT[RegisteredClaims](token, func(JWTHeader) (any, error) {
+		return nil, errors.New("no key")
+	})
+	assert.ErrorIs(t, err, ErrTokenUnverifiable)
+
+	_, err = ParseAndVerifyJWT[RegisteredClaims](token, StaticKey([]byte("other")))
+	assert.ErrorIs(t, err, ErrTokenSignature)
+
+	// ES256 is P-256 only; other curves do not fit its 64-byte signature
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	assert.NotPanics(t, func() {
+		_, err = CreateJWT(RegisteredClaims{}, p384, ES256)
+	})
+	assert.Error(t, err)
+	p256, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	esToken, err := CreateJWT(RegisteredClaims{}, p256, ES256)
+	require.NoError(t, err)
+	_, err = ParseAndVerifyJWT[RegisteredClaims](esToken, StaticKey(&p384.PublicKey))
+	assert.ErrorIs(t, err, ErrTokenUnverifiable)
+
+	// a missing secret must not verify tokens signed with an empty key
+	for _, key := range [][]byte{nil, {}} {
+		_, err = ParseAndVerifyJWT[RegisteredClaims](token, StaticKey(key))
+		assert.ErrorIs(t, err, ErrTokenUnverifiable)
+	}
+}
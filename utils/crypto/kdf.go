@@ -0,0 +1,58 @@
+package crypto
+
+import (
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Recommended key-derivation parameters (OWASP 2023).
+const (
+	PBKDF2Iterations = 600_000
+
+	ScryptN = 1 << 15
+	ScryptR = 8
+	ScryptP = 1
+
+	Argon2Time    uint32 = 2
+	Argon2Memory  uint32 = 19 * 1024 // KiB
+	Argon2Threads uint8  = 1
+)
+
+// NewSalt returns n cryptographically random bytes.
+func NewSalt(n int) ([]byte, error) {
+	salt := make([]byte, n)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	return salt, nil
+}
+
+// DeriveKeyPBKDF2 derives a keyLen-byte key from password using PBKDF2-HMAC-SHA256.
+// Use iterations <= 0 for PBKDF2Iterations.
+func DeriveKeyPBKDF2(password, salt []byte, iterations, keyLen int) ([]byte, error) {
+	if iterations <= 0 {
+		iterations = PBKDF2Iterations
+	}
+	return pbkdf2.Key(sha256.New, string(password), salt, iterations, keyLen)
+}
+
+// DeriveKeyScrypt derives a keyLen-byte key from password using scrypt
+// with ScryptN, ScryptR and ScryptP.
+func DeriveKeyScrypt(password, salt []byte, keyLen int) ([]byte, error) {
+	return scrypt.Key(password, salt, ScryptN, ScryptR, ScryptP, keyLen)
+}
+
+// DeriveKeyArgon2id derives a keyLen-byte key from password using Argon2id
+// with Argon2Time, Argon2Memory and Argon2Threads.
+//
+// Example:
+//
+//	salt, _ := NewSalt(16)
+//	key := DeriveKeyArgon2id([]byte(passphrase), salt, 32) // AES-256 key
+func DeriveKeyArgon2id(password, salt []byte, keyLen uint32) []byte {
+	return argon2.IDKey(password, salt, Argon2Time, Argon2Memory, Argon2Threads, keyLen)
+}
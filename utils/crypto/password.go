@@ -0,0 +1,172 @@
+package crypto
+
+import (
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	// ErrPasswordMismatch is returned when a password does not match its hash.
+	ErrPasswordMismatch = errors.New("password does not match")
+
+	// ErrUnknownHashFormat is returned for hash strings that are neither argon2id nor bcrypt.
+	ErrUnknownHashFormat = errors.New("unknown password hash format")
+)
+
+// Argon2Params are the argon2id parameters encoded into password hashes.
+type Argon2Params struct {
+	Time    uint32
+	Memory  uint32 // KiB
+	Threads uint8
+	SaltLen uint32
+	KeyLen  uint32
+}
+
+// DefaultArgon2Params follows the OWASP recommendation (m=19MiB, t=2, p=1).
+var DefaultArgon2Params = Argon2Params{
+	Time:    Argon2Time,
+	Memory:  Argon2Memory,
+	Threads: Argon2Threads,
+	SaltLen: 16,
+	KeyLen:  32,
+}
+
+// HashPassword hashes password with argon2id using DefaultArgon2Params and returns a
+// PHC-formatted string: $argon2id$v=19$m=19456,t=2,p=1$<salt>$<hash>
+//
+// Example:
+//
+//	if !validate.IsStrongPassword(pw, 8) { ... }
+//	hash, err := crypto.HashPassword(pw)
+func HashPassword(password string) (string, error) {
+	return HashPasswordWithParams(password, DefaultArgon2Params)
+}
+
+// HashPasswordWithParams hashes password with argon2id using p.
+func HashPasswordWithParams(password string, p Argon2Params) (string, error) {
+	salt, err := NewSalt(int(p.SaltLen))
+	if err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, p.Time, p.Memory, p.Threads, p.KeyLen)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, p.Memory, p.Time, p.Threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// HashPasswordBcrypt hashes password with bcrypt at the given cost (bcrypt.DefaultCost when <= 0).
+// Use it only for systems that cannot verify argon2id.
+func HashPasswordBcrypt(password string, cost int) (string, error) {
+	if cost <= 0 {
+		cost = bcrypt.DefaultCost
+	}
+	b, err := bcrypt.GenerateFromPassword([]byte(password), cost)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// VerifyPassword checks password against an argon2id or bcrypt hash.
+// It returns nil on match and ErrPasswordMismatch otherwise.
+func VerifyPassword(password, hash string) error {
+	switch {
+	case strings.HasPrefix(hash, "$argon2id$"):
+		p, salt, key, err := decodeArgon2Hash(hash)
+		if err != nil {
+			return err
+		}
+		other := argon2.IDKey([]byte(password), salt, p.Time, p.Memory, p.Threads, p.KeyLen)
+		if subtle.ConstantTimeCompare(key, other) != 1 {
+			return ErrPasswordMismatch
+		}
+		return nil
+
+	case isBcrypt(hash):
+		err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return ErrPasswordMismatch
+		}
+		return err
+
+	default:
+		return ErrUnknownHashFormat
+	}
+}
+
+// NeedsRehash reports whether hash should be replaced after a successful login:
+// it is bcrypt, or argon2id with parameters different from DefaultArgon2Params.
+func NeedsRehash(hash string) bool {
+	return NeedsRehashWithParams(hash, DefaultArgon2Params)
+}
+
+// NeedsRehashWithParams is like NeedsRehash but compares against p.
+func NeedsRehashWithParams(hash string, p Argon2Params) bool {
+	if !strings.HasPrefix(hash, "$argon2id$") {
+		return true
+	}
+	cur, salt, key, err := decodeArgon2Hash(hash)
+	if err != nil {
+		return true
+	}
+	return cur.Time != p.Time ||
+		cur.Memory != p.Memory ||
+		cur.Threads != p.Threads ||
+		uint32(len(salt)) != p.SaltLen ||
+		uint32(len(key)) != p.KeyLen
+}
+
+func decodeArgon2Hash(hash string) (Argon2Params, []byte, []byte, error) {
+	var p Argon2Params
+
+	// "", "argon2id", "v=19", "m=...,t=...,p=...", salt, key
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 {
+		return p, nil, nil, ErrUnknownHashFormat
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return p, nil, nil, ErrUnknownHashFormat
+	}
+	if version != argon2.Version {
+		return p, nil, nil, fmt.Errorf("unsupported argon2 version %d", version)
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.Memory, &p.Time, &p.Threads); err != nil {
+		return p, nil, nil, ErrUnknownHashFormat
+	}
+	// argon2 panics on zero time or threads, and an empty key matches any password
+	if p.Time == 0 || p.Memory == 0 || p.Threads == 0 {
+		return p, nil, nil, ErrUnknownHashFormat
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return p, nil, nil, ErrUnknownHashFormat
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return p, nil, nil, ErrUnknownHashFormat
+	}
+	if len(salt) == 0 || len(key) == 0 {
+		return p, nil, nil, ErrUnknownHashFormat
+	}
+	p.SaltLen = uint32(len(salt))
+	p.KeyLen = uint32(len(key))
+	return p, salt, key, nil
+}
+
+func isBcrypt(hash string) bool {
+	return strings.HasPrefix(hash, "$2a$") ||
+		strings.HasPrefix(hash, "$2b$") ||
+		strings.HasPrefix(hash, "$2y$")
+}
@@ -0,0 +1,71 @@
+package crypto
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestHashVerifyPassword(t *testing.T) {
+	hash, err := HashPassword("S3cure!pass")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=19456,t=2,p=1$"))
+
+	assert.NoError(t, VerifyPassword("S3cure!pass", hash))
+	assert.ErrorIs(t, VerifyPassword("wrong", hash), ErrPasswordMismatch)
+	assert.False(t, NeedsRehash(hash))
+
+	other, err := HashPassword("S3cure!pass")
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, other, "salt must differ")
+}
+
+func TestVerifyPassword_Bcrypt(t *testing.T) {
+	hash, err := HashPasswordBcrypt("legacy", bcrypt.MinCost)
+	require.NoError(t, err)
+
+	assert.NoError(t, VerifyPassword("legacy", hash))
+	assert.ErrorIs(t, VerifyPassword("nope", hash), ErrPasswordMismatch)
+	assert.True(t, NeedsRehash(hash))
+}
+
+func TestNeedsRehash_Params(t *testing.T) {
+	weak := Argon2Params{Time: 1, Memory: 8 * 1024, Threads: 1, SaltLen: 16, KeyLen: 32}
+	hash, err := HashPasswordWithParams("pw", weak)
+	require.NoError(t, err)
+
+	assert.NoError(t, VerifyPassword("pw", hash))
+	assert.True(t, NeedsRehash(hash))
+	assert.False(t, NeedsRehashWithParams(hash, weak))
+}
+
+func TestVerifyPassword_InvalidHash(t *testing.T) {
+	assert.ErrorIs(t, VerifyPassword("pw", "plain"), ErrUnknownHashFormat)
+	assert.ErrorIs(t, VerifyPassword("pw", "$argon2id$v=19$bad"), ErrUnknownHashFormat)
+	assert.Error(t, VerifyPassword("pw", "$argon2id$v=16$m=1,t=1,p=1$AAAA$AAAA"))
+	assert.True(t, NeedsRehash("garbage"))
+}
+
+func TestVerifyPassword_MalformedArgon2(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		hash string
+	}{
+		{"zero time", "$argon2id$v=19$m=19456,t=0,p=1$c2FsdHNhbHQ$a2V5a2V5"},
+		{"zero memory", "$argon2id$v=19$m=0,t=2,p=1$c2FsdHNhbHQ$a2V5a2V5"},
+		{"zero threads", "$argon2id$v=19$m=19456,t=2,p=0$c2FsdHNhbHQ$a2V5a2V5"},
+		{"empty salt", "$argon2id$v=19$m=19456,t=2,p=1$$a2V5a2V5"},
+		{"empty key", "$argon2id$v=19$m=19456,t=2,p=1$c2FsdHNhbHQ$"},
+		{"bad base64", "$argon2id$v=19$m=19456,t=2,p=1$c2FsdHNhbHQ$!!!"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NotPanics(t, func() {
+				assert.ErrorIs(t, VerifyPassword("pw", tt.hash), ErrUnknownHashFormat)
+			})
+			assert.True(t, NeedsRehash(tt.hash))
+		})
+	}
+}
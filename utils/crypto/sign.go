@@ -0,0 +1,181 @@
+package crypto
+
+import (
+	stdcrypto "crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// ErrInvalidSignature is returned when a signature does not match the data.
+var ErrInvalidSignature = errors.New("invalid signature")
+
+// SignPKCS1v15 signs data with RSA PKCS#1 v1.5 over SHA-256 and returns a base64 signature.
+func SignPKCS1v15(priv *rsa.PrivateKey, data []byte) (string, error) {
+	digest := sha256.Sum256(data)
+	sig, err := rsa.SignPKCS1v15(rand.Reader, priv, stdcrypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("PKCS1 sign failed: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(sig), nil
+}
+
+// VerifyPKCS1v15 verifies a base64 RSA PKCS#1 v1.5 SHA-256 signature of data.
+func VerifyPKCS1v15(pub *rsa.PublicKey, data []byte, signatureB64 string) error {
+	sig, err := base64.StdEncoding.DecodeString(signatureB64)
+	if err != nil {
+		return fmt.Errorf("base64 decode failed: %w", err)
+	}
+
+	digest := sha256.Sum256(data)
+	if err := rsa.VerifyPKCS1v15(pub, stdcrypto.SHA256, digest[:], sig); err != nil {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// SignPSS signs data with RSA-PSS over SHA-256 (salt length = hash length) and returns a base64 signature.
+func SignPSS(priv *rsa.PrivateKey, data []byte) (string, error) {
+	digest := sha256.Sum256(data)
+	sig, err := rsa.SignPSS(rand.Reader, priv, stdcrypto.SHA256, digest[:],
+		&rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+	if err != nil {
+		return "", fmt.Errorf("PSS sign failed: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(sig), nil
+}
+
+// VerifyPSS verifies a base64 RSA-PSS SHA-256 signature of data.
+// Any salt length is accepted so signatures from other implementations verify.
+func VerifyPSS(pub *rsa.PublicKey, data []byte, signatureB64 string) error {
+	sig, err := base64.StdEncoding.DecodeString(signatureB64)
+	if err != nil {
+		return fmt.Errorf("base64 decode failed: %w", err)
+	}
+
+	digest := sha256.Sum256(data)
+	err = rsa.VerifyPSS(pub, stdcrypto.SHA256, digest[:], sig,
+		&rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthAuto})
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// SignECDSA signs data with ECDSA over SHA-256 and returns a base64 ASN.1 DER signature.
+func SignECDSA(priv *ecdsa.PrivateKey, data []byte) (string, error) {
+	digest := sha256.Sum256(data)
+	sig, err := ecdsa.SignASN1(rand.Reader, priv, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("ECDSA sign failed: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(sig), nil
+}
+
+// VerifyECDSA verifies a base64 ASN.1 DER ECDSA SHA-256 signature of data.
+func VerifyECDSA(pub *ecdsa.PublicKey, data []byte, signatureB64 string) error {
+	sig, err := base64.StdEncoding.DecodeString(signatureB64)
+	if err != nil {
+		return fmt.Errorf("base64 decode failed: %w", err)
+	}
+
+	digest := sha256.Sum256(data)
+	if !ecdsa.VerifyASN1(pub, digest[:], sig) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// ParsePrivateKeyPEM parses a PEM private key in PKCS#1 ("RSA PRIVATE KEY"),
+// SEC 1 ("EC PRIVATE KEY") or PKCS#8 ("PRIVATE KEY") form.
+// The result is *rsa.PrivateKey, *ecdsa.PrivateKey or ed25519.PrivateKey.
+func ParsePrivateKeyPEM(data []byte) (stdcrypto.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("failed to parse PEM block containing the private key")
+	}
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		return x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported private key type %q", block.Type)
+	}
+}
+
+// ParsePublicKeyPEM parses a PEM public key in PKIX ("PUBLIC KEY") or PKCS#1 ("RSA PUBLIC KEY") form,
+// or extracts the public key from a certificate ("CERTIFICATE").
+func ParsePublicKeyPEM(data []byte) (stdcrypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("failed to parse PEM block containing the public key")
+	}
+
+	switch block.Type {
+	case "PUBLIC KEY":
+		return x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return cert.PublicKey, nil
+	default:
+		return nil, fmt.Errorf("unsupported public key type %q", block.Type)
+	}
+}
+
+// ReadPKCS8PrivateKey reads a PEM private key file in any form accepted by ParsePrivateKeyPEM.
+func ReadPKCS8PrivateKey(path string) (stdcrypto.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read private key file: %w", err)
+	}
+	return ParsePrivateKeyPEM(data)
+}
+
+// ReadECPrivateKey reads an ECDSA private key (SEC 1 or PKCS#8 PEM) from path.
+func ReadECPrivateKey(path string) (*ecdsa.PrivateKey, error) {
+	key, err := ReadPKCS8PrivateKey(path)
+	if err != nil {
+		return nil, err
+	}
+
+	ecKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("not ECDSA private key")
+	}
+	return ecKey, nil
+}
+
+// ReadECPublicKey reads an ECDSA public key (PKIX PEM or certificate) from path.
+func ReadECPublicKey(path string) (*ecdsa.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read public key file: %w", err)
+	}
+
+	key, err := ParsePublicKeyPEM(data)
+	if err != nil {
+		return nil, err
+	}
+
+	ecKey, ok := key.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("not ECDSA public key")
+	}
+	return ecKey, nil
+}
@@ -0,0 +1,100 @@
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignVerifyPKCS1v15(t *testing.T) {
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	data := []byte(`{"amount":1000}`)
+
+	sig, err := SignPKCS1v15(priv, data)
+	require.NoError(t, err)
+	assert.NoError(t, VerifyPKCS1v15(&priv.PublicKey, data, sig))
+	assert.ErrorIs(t, VerifyPKCS1v15(&priv.PublicKey, []byte("tampered"), sig), ErrInvalidSignature)
+	assert.Error(t, VerifyPKCS1v15(&priv.PublicKey, data, "%%"))
+}
+
+func TestSignVerifyPSS(t *testing.T) {
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	data := []byte("payload")
+
+	sig, err := SignPSS(priv, data)
+	require.NoError(t, err)
+	assert.NoError(t, VerifyPSS(&priv.PublicKey, data, sig))
+	assert.ErrorIs(t, VerifyPSS(&priv.PublicKey, []byte("other"), sig), ErrInvalidSignature)
+
+	pkcs1Sig, err := SignPKCS1v15(priv, data)
+	require.NoError(t, err)
+	assert.ErrorIs(t, VerifyPSS(&priv.PublicKey, data, pkcs1Sig), ErrInvalidSignature)
+}
+
+func TestSignVerifyECDSA(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	data := []byte("payload")
+
+	sig, err := SignECDSA(priv, data)
+	require.NoError(t, err)
+	assert.NoError(t, VerifyECDSA(&priv.PublicKey, data, sig))
+	assert.ErrorIs(t, VerifyECDSA(&priv.PublicKey, []byte("x"), sig), ErrInvalidSignature)
+}
+
+func TestReadECKeys(t *testing.T) {
+	dir := t.TempDir()
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	sec1, err := x509.MarshalECPrivateKey(priv)
+	require.NoError(t, err)
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(priv)
+	require.NoError(t, err)
+	pkix, err := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+	require.NoError(t, err)
+
+	write := func(name, typ string, der []byte) string {
+		p := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(p, pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der}), 0o600))
+		return p
+	}
+
+	k1, err := ReadECPrivateKey(write("sec1.pem", "EC PRIVATE KEY", sec1))
+	require.NoError(t, err)
+	assert.True(t, k1.Equal(priv))
+
+	k2, err := ReadECPrivateKey(write("pkcs8.pem", "PRIVATE KEY", pkcs8))
+	require.NoError(t, err)
+	assert.True(t, k2.Equal(priv))
+
+	pub, err := ReadECPublicKey(write("pub.pem", "PUBLIC KEY", pkix))
+	require.NoError(t, err)
+	assert.True(t, pub.Equal(&priv.PublicKey))
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	rsaPath := write("rsa.pem", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey))
+	_, err = ReadECPrivateKey(rsaPath)
+	assert.Error(t, err)
+
+	anyKey, err := ReadPKCS8PrivateKey(rsaPath)
+	require.NoError(t, err)
+	assert.IsType(t, &rsa.PrivateKey{}, anyKey)
+
+	_, err = ParsePrivateKeyPEM([]byte("not pem"))
+	assert.Error(t, err)
+	_, err = ParsePublicKeyPEM(pem.EncodeToMemory(&pem.Block{Type: "FOO", Bytes: []byte{1}}))
+	assert.Error(t, err)
+}
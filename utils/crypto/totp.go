@@ -0,0 +1,304 @@
+package crypto
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OTPAlgorithm is the HMAC hash of HOTP/TOTP codes.
+type OTPAlgorithm string
+
+const (
+	OTPSHA1   OTPAlgorithm = "SHA1"
+	OTPSHA256 OTPAlgorithm = "SHA256"
+	OTPSHA512 OTPAlgorithm = "SHA512"
+)
+
+var (
+	ErrOTPInvalid  = errors.New("otp code is invalid")
+	ErrOTPReplayed = errors.New("otp code was already used")
+	ErrOTPSecret   = errors.New("otp secret is invalid")
+)
+
+type OTPOption func(*otpOptions)
+
+type otpOptions struct {
+	digits    int
+	algorithm OTPAlgorithm
+	period    time.Duration
+	skew      int
+	replay    func(counter uint64) error
+}
+
+// WithDigits sets the length of a code (default 6, 6 to 8).
+func WithDigits(n int) OTPOption {
+	return func(o *otpOptions) {
+		if n >= 6 && n <= 8 {
+			o.digits = n
+		}
+	}
+}
+
+// WithOTPAlgorithm sets the HMAC hash (default SHA1, the only one most
+// authenticator apps support).
+func WithOTPAlgorithm(alg OTPAlgorithm) OTPOption {
+	return func(o *otpOptions) { o.algorithm = alg }
+}
+
+// WithPeriod sets the TOTP time step (default 30s).
+func WithPeriod(d time.Duration) OTPOption {
+	return func(o *otpOptions) {
+		if d >= time.Second {
+			o.period = d
+		}
+	}
+}
+
+// WithSkew sets the drift window (default 1): TOTP codes up to n steps before
+// or after the current one are accepted, HOTP codes up to n counters ahead.
+func WithSkew(n int) OTPOption {
+	return func(o *otpOptions) {
+		if n >= 0 {
+			o.skew = n
+		}
+	}
+}
+
+// WithReplayCheck sets a hook called with the counter (HOTP) or time step
+// (TOTP) of a matching code before it is accepted. It should return
+// ErrOTPReplayed when the counter is not greater than the last one used by
+// the account, and record it otherwise.
+func WithReplayCheck(fn func(counter uint64) error) OTPOption {
+	return func(o *otpOptions) { o.replay = fn }
+}
+
+func newOTPOptions(opts []OTPOption) *otpOptions {
+	o := &otpOptions{
+		digits:    6,
+		algorithm: OTPSHA1,
+		period:    30 * time.Second,
+		skew:      1,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+func (o *otpOptions) hash() (func() hash.Hash, error) {
+	switch o.algorithm {
+	case OTPSHA1:
+		return sha1.New, nil
+	case OTPSHA256:
+		return sha256.New, nil
+	case OTPSHA512:
+		return sha512.New, nil
+	default:
+		return nil, fmt.Errorf("unsupported otp algorithm %q", o.algorithm)
+	}
+}
+
+// GenerateOTPSecret returns a random 160-bit secret in unpadded base32, the
+// form expected by authenticator apps.
+func GenerateOTPSecret() (string, error) {
+	b, err := NewSalt(20)
+	if err != nil {
+		return "", err
+	}
+	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b), nil
+}
+
+// decodeOTPSecret decodes a base32 secret, ignoring case, spaces and padding.
+func decodeOTPSecret(secret string) ([]byte, error) {
+	s := strings.ToUpper(strings.ReplaceAll(secret, " ", ""))
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(strings.TrimRight(s, "="))
+	if err != nil || len(key) == 0 {
+		return nil, ErrOTPSecret
+	}
+	return key, nil
+}
+
+// HOTP returns the RFC 4226 code of the base32 secret for counter.
+func HOTP(secret string, counter uint64, opts ...OTPOption) (string, error) {
+	o := newOTPOptions(opts)
+	key, err := decodeOTPSecret(secret)
+	if err != nil {
+		return "", err
+	}
+	return hotp(key, counter, o)
+}
+
+func hotp(key []byte, counter uint64, o *otpOptions) (string, error) {
+	h, err := o.hash()
+	if err != nil {
+		return "", err
+	}
+
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], counter)
+	mac := hmac.New(h, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	// dynamic truncation
+	off := sum[len(sum)-1] & 0x0f
+	v := binary.BigEndian.Uint32(sum[off:off+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for range o.digits {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", o.digits, v%mod), nil
+}
+
+// VerifyHOTP checks code against counter and the next WithSkew counters in
+// constant time. It returns the counter to store for the next verification
+// (the matching counter + 1).
+func VerifyHOTP(secret, code string, counter uint64, opts ...OTPOption) (uint64, error) {
+	o := newOTPOptions(opts)
+	key, err := decodeOTPSecret(secret)
+	if err != nil {
+		return counter, err
+	}
+
+	for c := counter; c <= counter+uint64(o.skew); c++ {
+		ok, err := matchOTP(key, code, c, o)
+		if err != nil {
+			return counter, err
+		}
+		if ok {
+			return c + 1, nil
+		}
+	}
+	return counter, ErrOTPInvalid
+}
+
+// TOTP returns the RFC 6238 code of the base32 secret at t.
+func TOTP(secret string, t time.Time, opts ...OTPOption) (string, error) {
+	o := newOTPOptions(opts)
+	key, err := decodeOTPSecret(secret)
+	if err != nil {
+		return "", err
+	}
+	return hotp(key, totpStep(t, o.period), o)
+}
+
+// VerifyTOTP checks code at the current time. It returns the time step of
+// the matching code, e.g. to store as the last used step.
+//
+// Example:
+//
+//	step, err := crypto.VerifyTOTP(user.TOTPSecret, input, crypto.WithReplayCheck(func(step uint64) error {
+//		if step <= user.LastTOTPStep {
+//			return crypto.ErrOTPReplayed
+//		}
+//		return repo.SetLastTOTPStep(ctx, user.ID, step)
+//	}))
+func VerifyTOTP(secret, code string, opts ...OTPOption) (uint64, error) {
+	return VerifyTOTPAt(secret, code, time.Now(), opts...)
+}
+
+// VerifyTOTPAt checks code at t, accepting WithSkew steps of clock drift on
+// either side.
+func VerifyTOTPAt(secret, code string, t time.Time, opts ...OTPOption) (uint64, error) {
+	o := newOTPOptions(opts)
+	key, err := decodeOTPSecret(secret)
+	if err != nil {
+		return 0, err
+	}
+
+	now := totpStep(t, o.period)
+	steps := []uint64{now}
+	for d := uint64(1); d <= uint64(o.skew); d++ {
+		if d <= now {
+			steps = append(steps, now-d)
+		}
+		steps = append(steps, now+d)
+	}
+
+	for _, step := range steps {
+		ok, err := matchOTP(key, code, step, o)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			return step, nil
+		}
+	}
+	return 0, ErrOTPInvalid
+}
+
+// matchOTP compares code with the code of counter and runs the replay hook on a match.
+func matchOTP(key []byte, code string, counter uint64, o *otpOptions) (bool, error) {
+	want, err := hotp(key, counter, o)
+	if err != nil {
+		return false, err
+	}
+	if subtle.ConstantTimeCompare([]byte(want), []byte(code)) != 1 {
+		return false, nil
+	}
+	if o.replay != nil {
+		if err := o.replay(counter); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func totpStep(t time.Time, period time.Duration) uint64 {
+	return uint64(t.Unix()) / uint64(period/time.Second)
+}
+
+// TOTPURI returns the otpauth:// URI of a TOTP secret, the payload of the QR
+// code scanned by authenticator apps.
+//
+// Example:
+//
+//	secret, _ := crypto.GenerateOTPSecret()
+//	uri := crypto.TOTPURI("GoDev Admin", "alice@example.com", secret)
+//	// otpauth://totp/GoDev%20Admin:alice@example.com?algorithm=SHA1&digits=6&issuer=GoDev+Admin&period=30&secret=...
+func TOTPURI(issuer, account, secret string, opts ...OTPOption) string {
+	o := newOTPOptions(opts)
+	q := otpQuery(issuer, secret, o)
+	q.Set("period", strconv.Itoa(int(o.period/time.Second)))
+	return otpURI("totp", issuer, account, q)
+}
+
+// HOTPURI returns the otpauth:// URI of a HOTP secret starting at counter.
+func HOTPURI(issuer, account, secret string, counter uint64, opts ...OTPOption) string {
+	o := newOTPOptions(opts)
+	q := otpQuery(issuer, secret, o)
+	q.Set("counter", strconv.FormatUint(counter, 10))
+	return otpURI("hotp", issuer, account, q)
+}
+
+func otpQuery(issuer, secret string, o *otpOptions) url.Values {
+	q := url.Values{}
+	q.Set("secret", strings.TrimRight(strings.ToUpper(strings.ReplaceAll(secret, " ", "")), "="))
+	if issuer != "" {
+		q.Set("issuer", issuer)
+	}
+	q.Set("algorithm", string(o.algorithm))
+	q.Set("digits", strconv.Itoa(o.digits))
+	return q
+}
+
+func otpURI(kind, issuer, account string, q url.Values) string {
+	label := url.PathEscape(account)
+	if issuer != "" {
+		label = url.PathEscape(issuer) + ":" + label
+	}
+	return "otpauth://" + kind + "/" + label + "?" + q.Encode()
+}
@@ -0,0 +1,153 @@
+package crypto
+
+import (
+	"encoding/base32"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func b32(s string) string {
+	return base32.StdEncoding.EncodeToString([]byte(s))
+}
+
+// RFC 4226 Appendix D
+func TestHOTP_RFC4226(t *testing.T) {
+	secret := b32("12345678901234567890")
+	for i, want := range []string{"755224", "287082", "359152", "969429", "338314", "254676"} {
+		code, err := HOTP(secret, uint64(i))
+		require.NoError(t, err)
+		assert.Equal(t, want, code, "counter %d", i)
+	}
+}
+
+// RFC 6238 Appendix B
+func TestTOTP_RFC6238(t *testing.T) {
+	secrets := map[OTPAlgorithm]string{
+		OTPSHA1:   b32("12345678901234567890"),
+		OTPSHA256: b32("12345678901234567890123456789012"),
+		OTPSHA512: b32("1234567890123456789012345678901234567890123456789012345678901234"),
+	}
+	tests := []struct {
+		unix int64
+		alg  OTPAlgorithm
+		want string
+	}{
+		{59, OTPSHA1, "94287082"},
+		{59, OTPSHA256, "46119246"},
+		{59, OTPSHA512, "90693936"},
+		{1111111109, OTPSHA1, "07081804"},
+		{1234567890, OTPSHA256, "91819424"},
+		{20000000000, OTPSHA512, "47863826"},
+	}
+	for _, tt := range tests {
+		code, err := TOTP(secrets[tt.alg], time.Unix(tt.unix, 0), WithDigits(8), WithOTPAlgorithm(tt.alg))
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, code, "%s at %d", tt.alg, tt.unix)
+	}
+
+	_, err := TOTP(secrets[OTPSHA1], time.Now(), WithOTPAlgorithm("MD5"))
+	assert.Error(t, err)
+	_, err = TOTP("not base32!", time.Now())
+	assert.ErrorIs(t, err, ErrOTPSecret)
+}
+
+func TestVerifyTOTP_Skew(t *testing.T) {
+	secret, err := GenerateOTPSecret()
+	require.NoError(t, err)
+	assert.Len(t, secret, 32)
+
+	now := time.Unix(1_700_000_000, 0)
+	code, err := TOTP(secret, now)
+	require.NoError(t, err)
+
+	step, err := VerifyTOTPAt(secret, code, now)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1_700_000_000/30), step)
+
+	_, err = VerifyTOTPAt(secret, code, now.Add(30*time.Second))
+	assert.NoError(t, err, "one step of drift is accepted")
+	_, err = VerifyTOTPAt(secret, code, now.Add(-30*time.Second))
+	assert.NoError(t, err)
+	_, err = VerifyTOTPAt(secret, code, now.Add(90*time.Second))
+	assert.ErrorIs(t, err, ErrOTPInvalid)
+	_, err = VerifyTOTPAt(secret, code, now.Add(30*time.Second), WithSkew(0))
+	assert.ErrorIs(t, err, ErrOTPInvalid)
+
+	_, err = VerifyTOTP(secret, "000000x")
+	assert.ErrorIs(t, err, ErrOTPInvalid)
+
+	// lower case and spaces, as users type secrets
+	code, err = TOTP(secret, time.Now())
+	require.NoError(t, err)
+	_, err = VerifyTOTP(secret[:4]+" "+secret[4:], code)
+	assert.NoError(t, err)
+}
+
+func TestVerifyTOTP_Replay(t *testing.T) {
+	secret, _ := GenerateOTPSecret()
+	now := time.Now()
+	code, _ := TOTP(secret, now)
+
+	var last uint64
+	guard := WithReplayCheck(func(step uint64) error {
+		if step <= last {
+			return ErrOTPReplayed
+		}
+		last = step
+		return nil
+	})
+
+	step, err := VerifyTOTPAt(secret, code, now, guard)
+	require.NoError(t, err)
+	assert.Equal(t, step, last)
+
+	_, err = VerifyTOTPAt(secret, code, now, guard)
+	assert.ErrorIs(t, err, ErrOTPReplayed)
+}
+
+func TestVerifyHOTP(t *testing.T) {
+	secret := b32("12345678901234567890")
+
+	next, err := VerifyHOTP(secret, "755224", 0)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), next)
+
+	next, err = VerifyHOTP(secret, "359152", 1)
+	require.NoError(t, err, "one counter of look-ahead")
+	assert.Equal(t, uint64(3), next)
+
+	next, err = VerifyHOTP(secret, "338314", 3, WithSkew(0))
+	assert.ErrorIs(t, err, ErrOTPInvalid)
+	assert.Equal(t, uint64(3), next)
+
+	_, err = VerifyHOTP(secret, "287082", 2)
+	assert.ErrorIs(t, err, ErrOTPInvalid, "past counters are rejected")
+}
+
+func TestOTPURI(t *testing.T) {
+	secret := "JBSWY3DPEHPK3PXP"
+
+	u, err := url.Parse(TOTPURI("GoDev Admin", "alice@example.com", secret))
+	require.NoError(t, err)
+	assert.Equal(t, "otpauth", u.Scheme)
+	assert.Equal(t, "totp", u.Host)
+	assert.Equal(t, "/GoDev Admin:alice@example.com", u.Path)
+	q := u.Query()
+	assert.Equal(t, secret, q.Get("secret"))
+	assert.Equal(t, "GoDev Admin", q.Get("issuer"))
+	assert.Equal(t, "SHA1", q.Get("algorithm"))
+	assert.Equal(t, "6", q.Get("digits"))
+	assert.Equal(t, "30", q.Get("period"))
+
+	u, err = url.Parse(HOTPURI("", "bob", secret, 7, WithDigits(8)))
+	require.NoError(t, err)
+	assert.Equal(t, "hotp", u.Host)
+	assert.Equal(t, "/bob", u.Path)
+	assert.Equal(t, "7", u.Query().Get("counter"))
+	assert.Equal(t, "8", u.Query().Get("digits"))
+	assert.False(t, u.Query().Has("issuer"))
+}
@@ -0,0 +1,205 @@
+package csvx
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ParseError reports a cell that could not be converted into its field.
+type ParseError struct {
+	Line   int
+	Column string
+	Value  string
+	Err    error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("csvx: line %d, column %q: cannot parse %q: %v", e.Line, e.Column, e.Value, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+type column struct {
+	name  string
+	index []int
+}
+
+type schemaKey struct {
+	typ reflect.Type
+	tag string
+}
+
+var schemaCache sync.Map // schemaKey -> []column
+
+var (
+	timeType            = reflect.TypeOf(time.Time{})
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// schemaOf returns the columns of struct type t in declaration order.
+// Embedded structs are flattened; fields tagged "-" and unexported fields are skipped.
+func schemaOf(t reflect.Type, tag string) ([]column, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("csvx: %s is not a struct", t)
+	}
+
+	key := schemaKey{t, tag}
+	if cols, ok := schemaCache.Load(key); ok {
+		return cols.([]column), nil
+	}
+
+	var cols []column
+	var walk func(t reflect.Type, parent []int)
+	walk = func(t reflect.Type, parent []int) {
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			name, _, _ := strings.Cut(f.Tag.Get(tag), ",")
+			if name == "-" {
+				continue
+			}
+			index := append(append([]int{}, parent...), i)
+			if f.Anonymous && f.Type.Kind() == reflect.Struct && name == "" && !isScalarStruct(f.Type) {
+				walk(f.Type, index)
+				continue
+			}
+			if name == "" {
+				name = f.Name
+			}
+			cols = append(cols, column{name: name, index: index})
+		}
+	}
+	walk(t, nil)
+
+	schemaCache.Store(key, cols)
+	return cols, nil
+}
+
+// isScalarStruct reports struct types stored in a single cell (time.Time, decimal.Decimal, ...).
+func isScalarStruct(t reflect.Type) bool {
+	return t == timeType ||
+		reflect.PointerTo(t).Implements(textUnmarshalerType) ||
+		t.Implements(textMarshalerType)
+}
+
+// decode parses s into v.
+func decode(s string, v reflect.Value, o *options) error {
+	if v.Kind() == reflect.Pointer {
+		if s == "" {
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		}
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+
+	if v.Type() == timeType {
+		if s == "" {
+			v.Set(reflect.Zero(timeType))
+			return nil
+		}
+		t, err := time.Parse(o.timeLayout, s)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	if v.CanAddr() && v.Addr().Type().Implements(textUnmarshalerType) {
+		if s == "" && v.Kind() != reflect.String {
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		}
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+	}
+
+	if v.Kind() == reflect.String {
+		v.SetString(s)
+		return nil
+	}
+	if s == "" {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+// encode formats v as a cell value.
+func encode(v reflect.Value, o *options) (string, error) {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return "", nil
+		}
+		v = v.Elem()
+	}
+
+	if v.Type() == timeType {
+		t := v.Interface().(time.Time)
+		if t.IsZero() {
+			return "", nil
+		}
+		return t.Format(o.timeLayout), nil
+	}
+
+	if v.Type().Implements(textMarshalerType) {
+		b, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		return string(b), err
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits()), nil
+	default:
+		return "", fmt.Errorf("csvx: unsupported type %s", v.Type())
+	}
+}
@@ -0,0 +1,150 @@
+package csvx
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Audit struct {
+	CreatedAt time.Time `csv:"created_at"`
+}
+
+type order struct {
+	ID     int64           `csv:"id"`
+	Name   string          `csv:"name"`
+	Amount decimal.Decimal `csv:"amount"`
+	Paid   bool            `csv:"paid"`
+	Note   *string         `csv:"note"`
+	Secret string          `csv:"-"`
+	Audit
+}
+
+func TestRoundTrip(t *testing.T) {
+	note := "vip"
+	created := time.Date(2024, 5, 1, 8, 30, 0, 0, time.UTC)
+	rows := []order{
+		{ID: 1, Name: "Nguyễn Văn A", Amount: decimal.RequireFromString("1000.50"), Paid: true, Note: &note, Audit: Audit{created}},
+		{ID: 2, Name: "has, comma", Amount: decimal.NewFromInt(7), Secret: "x"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteTo(&buf, rows, WithBOM()))
+	assert.True(t, strings.HasPrefix(buf.String(), bom+"id,name,amount,paid,note,created_at\n"))
+
+	got, err := ReadAll[order](&buf)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "Nguyễn Văn A", got[0].Name)
+	assert.True(t, got[0].Amount.Equal(rows[0].Amount))
+	assert.Equal(t, "vip", *got[0].Note)
+	assert.True(t, got[0].CreatedAt.Equal(created))
+	assert.Equal(t, "has, comma", got[1].Name)
+	assert.Nil(t, got[1].Note)
+	assert.Empty(t, got[1].Secret)
+	assert.True(t, got[1].CreatedAt.IsZero())
+}
+
+func TestReader_HeaderDetection(t *testing.T) {
+	// header in a different order, with unknown and differently-cased columns
+	in := "Name;EXTRA;id\nAlice;x;10\nBob;y;20\n"
+	got, err := ReadAll[order](strings.NewReader(in), WithDelimiter(';'))
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, order{ID: 10, Name: "Alice"}, got[0])
+
+	// no header: declaration order
+	got, err = ReadAll[order](strings.NewReader("5,Carol,12.5,true\n"))
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, int64(5), got[0].ID)
+	assert.True(t, got[0].Paid)
+
+	// forced header skips the first row even when it does not match
+	got, err = ReadAll[order](strings.NewReader("a,b\n6,Dan\n"), WithHeader(true))
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Empty(t, got[0].Name, "unknown header columns are ignored")
+}
+
+func TestReader_StreamingErrors(t *testing.T) {
+	in := "# exported\nid,name,amount\n1,ok,1\nx,bad,abc\n3,  spaced  ,2\n"
+	r, err := NewReader[order](strings.NewReader(in), WithComment('#'), WithTrimSpace())
+	require.NoError(t, err)
+
+	row, err := r.Read()
+	require.NoError(t, err)
+	assert.Equal(t, "ok", row.Name)
+	assert.Equal(t, []string{"id", "name", "amount"}, r.Header())
+
+	row, err = r.Read()
+	require.Error(t, err)
+	var pe *ParseError
+	require.ErrorAs(t, err, &pe)
+	assert.Equal(t, 4, pe.Line)
+	assert.Equal(t, "id", pe.Column)
+	assert.Equal(t, "bad", row.Name)
+	assert.Contains(t, err.Error(), `column "amount"`)
+
+	row, err = r.Read()
+	require.NoError(t, err)
+	assert.Equal(t, "spaced", row.Name)
+	assert.Equal(t, 5, r.Line())
+
+	_, err = r.Read()
+	assert.True(t, errors.Is(err, io.EOF))
+}
+
+func TestReader_MalformedRecordLine(t *testing.T) {
+	in := "id,name,amount\n1,ok,1\n2,\"broken\"x,2\n3,next,3\n"
+	r, err := NewReader[order](strings.NewReader(in))
+	require.NoError(t, err)
+
+	_, err = r.Read()
+	require.NoError(t, err)
+
+	_, err = r.Read()
+	var ce *csv.ParseError
+	require.ErrorAs(t, err, &ce)
+	assert.Equal(t, 3, r.Line(), "line of the malformed record, not the previous one")
+
+	row, err := r.Read()
+	require.NoError(t, err)
+	assert.Equal(t, "next", row.Name)
+	assert.Equal(t, 4, r.Line())
+}
+
+func TestWriteFileReadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.tsv")
+	rows := []order{{ID: 1, Name: "tab"}}
+	require.NoError(t, WriteFile(path, rows, WithDelimiter('\t'), WithHeader(false)))
+
+	got, err := ReadFile[order](path, WithDelimiter('\t'), WithHeader(false))
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "tab", got[0].Name)
+	assert.True(t, got[0].Amount.IsZero())
+
+	_, err = ReadFile[order](filepath.Join(t.TempDir(), "missing.csv"))
+	assert.Error(t, err)
+}
+
+func TestNewReader_NotStruct(t *testing.T) {
+	_, err := NewReader[int](strings.NewReader(""))
+	assert.Error(t, err)
+
+	var buf bytes.Buffer
+	w, err := NewWriter[order](&buf)
+	require.NoError(t, err)
+	require.NoError(t, w.Flush())
+	assert.Equal(t, "id,name,amount,paid,note,created_at\n", buf.String())
+}
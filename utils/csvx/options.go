@@ -0,0 +1,103 @@
+package csvx
+
+import "time"
+
+const defaultTagName = "csv"
+
+type Option func(*options)
+
+type options struct {
+	// comma is the field delimiter (default ',').
+	comma rune
+
+	// comment, if not 0, marks lines to ignore when reading.
+	comment rune
+
+	// header forces header handling; nil means auto-detect on read and write a header on write.
+	header *bool
+
+	// tagName is the struct tag holding the column name (default "csv").
+	tagName string
+
+	// timeLayout is used for time.Time fields (default RFC3339).
+	timeLayout string
+
+	// trimSpace trims leading/trailing spaces of every cell when reading.
+	trimSpace bool
+
+	// bom writes a UTF-8 byte order mark so Excel opens UTF-8 files correctly.
+	bom bool
+}
+
+func withDefaults() *options {
+	return &options{
+		comma:      ',',
+		tagName:    defaultTagName,
+		timeLayout: time.RFC3339,
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := withDefaults()
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithDelimiter sets the field delimiter (e.g. ';' or '\t').
+func WithDelimiter(r rune) Option {
+	return func(o *options) {
+		if r != 0 {
+			o.comma = r
+		}
+	}
+}
+
+// WithComment ignores lines starting with r when reading (e.g. '#').
+func WithComment(r rune) Option {
+	return func(o *options) {
+		o.comment = r
+	}
+}
+
+// WithHeader forces whether the first row is a header.
+// By default the reader detects a header by matching the first row against
+// column names, and the writer always writes one.
+func WithHeader(has bool) Option {
+	return func(o *options) {
+		o.header = &has
+	}
+}
+
+// WithTagName sets the struct tag used for column names (e.g. "json").
+func WithTagName(tag string) Option {
+	return func(o *options) {
+		if tag != "" {
+			o.tagName = tag
+		}
+	}
+}
+
+// WithTimeLayout sets the layout used for time.Time columns.
+func WithTimeLayout(layout string) Option {
+	return func(o *options) {
+		if layout != "" {
+			o.timeLayout = layout
+		}
+	}
+}
+
+// WithTrimSpace trims surrounding spaces from every cell when reading.
+func WithTrimSpace() Option {
+	return func(o *options) {
+		o.trimSpace = true
+	}
+}
+
+// WithBOM prefixes written files with a UTF-8 BOM (needed for Excel to show Vietnamese text correctly).
+func WithBOM() Option {
+	return func(o *options) {
+		o.bom = true
+	}
+}
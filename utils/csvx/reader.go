@@ -0,0 +1,237 @@
+package csvx
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+)
+
+const bom = "\ufeff"
+
+// RecordFunc returns the next raw record and its line number, or io.EOF at the end.
+// With an error for a malformed record, the line is that of the error.
+// It lets Reader decode records from sources other than CSV (e.g. Excel rows).
+type RecordFunc func() (record []string, line int, err error)
+
+// Reader streams CSV records into values of struct type T.
+//
+// Columns are matched to fields by the "csv" tag (or field name). When the file
+// has a header, columns may appear in any order and unknown columns are ignored;
+// without a header, columns map to fields in declaration order.
+type Reader[T any] struct {
+	next   RecordFunc
+	opts   *options
+	cols   []column
+	header []string
+
+	// mapping[i] is the column index for record cell i, or -1 when ignored.
+	mapping []int
+	pending []string
+	started bool
+	line    int
+}
+
+// NewReader returns a Reader that reads from r.
+// T must be a struct type.
+func NewReader[T any](r io.Reader, opts ...Option) (*Reader[T], error) {
+	o := newOptions(opts)
+
+	br := bufio.NewReader(r)
+	if b, err := br.Peek(len(bom)); err == nil && string(b) == bom {
+		_, _ = br.Discard(len(bom))
+	}
+
+	cr := csv.NewReader(br)
+	cr.Comma = o.comma
+	cr.Comment = o.comment
+	cr.FieldsPerRecord = -1
+	cr.ReuseRecord = true
+
+	return newReader[T](func() ([]string, int, error) {
+		record, err := cr.Read()
+		if err != nil {
+			var pe *csv.ParseError
+			if errors.As(err, &pe) {
+				return nil, pe.Line, err
+			}
+			return nil, 0, err
+		}
+		line, _ := cr.FieldPos(0)
+		return record, line, nil
+	}, o)
+}
+
+// NewRecordReader returns a Reader that decodes records produced by next,
+// with the same header detection and field mapping as NewReader.
+// Delimiter, comment and BOM options do not apply.
+//
+// Example:
+//
+//	rows, err := e.Reader.IterRows("Sheet1")
+//	defer rows.Close()
+//	r, err := csvx.NewRecordReader[Row](rows.Next)
+func NewRecordReader[T any](next RecordFunc, opts ...Option) (*Reader[T], error) {
+	return newReader[T](next, newOptions(opts))
+}
+
+func newReader[T any](next RecordFunc, o *options) (*Reader[T], error) {
+	cols, err := schemaOf(reflect.TypeOf((*T)(nil)).Elem(), o.tagName)
+	if err != nil {
+		return nil, err
+	}
+	return &Reader[T]{next: next, opts: o, cols: cols}, nil
+}
+
+// Header returns the header row, or nil when the input has no header.
+// It is available after the first call to Read.
+func (r *Reader[T]) Header() []string {
+	return r.header
+}
+
+// Line returns the line number of the record returned by the last Read, or of
+// the malformed record it failed on.
+func (r *Reader[T]) Line() int {
+	return r.line
+}
+
+// Read returns the next record. It returns io.EOF at the end of the input.
+//
+// A *ParseError is returned for cells that cannot be converted; the partially
+// filled value is returned with it and reading may continue with the next call.
+func (r *Reader[T]) Read() (T, error) {
+	var out T
+
+	if !r.started {
+		if err := r.start(); err != nil {
+			return out, err
+		}
+	}
+
+	var record []string
+	if r.pending != nil {
+		record, r.pending = r.pending, nil
+	} else {
+		rec, line, err := r.next()
+		if err != nil {
+			if line > 0 {
+				r.line = line
+			}
+			return out, err
+		}
+		record, r.line = rec, line
+	}
+
+	v := reflect.ValueOf(&out).Elem()
+	var errs []error
+	for i, cell := range record {
+		if i >= len(r.mapping) || r.mapping[i] < 0 {
+			continue
+		}
+		if r.opts.trimSpace {
+			cell = strings.TrimSpace(cell)
+		}
+		col := r.cols[r.mapping[i]]
+		if err := decode(cell, v.FieldByIndex(col.index), r.opts); err != nil {
+			errs = append(errs, &ParseError{Line: r.line, Column: col.name, Value: cell, Err: err})
+		}
+	}
+	return out, errors.Join(errs...)
+}
+
+// start reads the first record and decides whether it is a header.
+func (r *Reader[T]) start() error {
+	r.started = true
+
+	first, line, err := r.next()
+	if err != nil {
+		if line > 0 {
+			r.line = line
+		}
+		return err
+	}
+	first = append([]string(nil), first...)
+	r.line = line
+
+	byName := make(map[string]int, len(r.cols))
+	for i, c := range r.cols {
+		byName[normalize(c.name)] = i
+	}
+
+	hasHeader := false
+	if r.opts.header != nil {
+		hasHeader = *r.opts.header
+	} else {
+		for _, cell := range first {
+			if _, ok := byName[normalize(cell)]; ok {
+				hasHeader = true
+				break
+			}
+		}
+	}
+
+	if !hasHeader {
+		r.mapping = make([]int, len(r.cols))
+		for i := range r.mapping {
+			r.mapping[i] = i
+		}
+		r.pending = first
+		return nil
+	}
+
+	r.header = first
+	r.mapping = make([]int, len(first))
+	for i, name := range first {
+		idx, ok := byName[normalize(name)]
+		if !ok {
+			idx = -1
+		}
+		r.mapping[i] = idx
+	}
+	return nil
+}
+
+func normalize(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+// ReadAll reads every record from r. It stops at the first error.
+func ReadAll[T any](r io.Reader, opts ...Option) ([]T, error) {
+	cr, err := NewReader[T](r, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []T
+	for {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+}
+
+// ReadFile reads every record of the CSV file at path.
+//
+// Example:
+//
+//	type Row struct {
+//		Name   string          `csv:"name"`
+//		Amount decimal.Decimal `csv:"amount"`
+//	}
+//	rows, err := csvx.ReadFile[Row]("orders.csv", csvx.WithDelimiter(';'))
+func ReadFile[T any](path string, opts ...Option) ([]T, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadAll[T](f, opts...)
+}
@@ -0,0 +1,127 @@
+package csvx
+
+import (
+	"encoding/csv"
+	"io"
+	"os"
+	"reflect"
+)
+
+// Writer writes values of struct type T as CSV records.
+// The header row is written before the first record unless WithHeader(false) is set.
+type Writer[T any] struct {
+	w      io.Writer
+	cw     *csv.Writer
+	opts   *options
+	cols   []column
+	record []string
+	begun  bool
+}
+
+// NewWriter returns a Writer that writes to w. Call Flush when done.
+func NewWriter[T any](w io.Writer, opts ...Option) (*Writer[T], error) {
+	o := newOptions(opts)
+	cols, err := schemaOf(reflect.TypeOf((*T)(nil)).Elem(), o.tagName)
+	if err != nil {
+		return nil, err
+	}
+
+	cw := csv.NewWriter(w)
+	cw.Comma = o.comma
+
+	return &Writer[T]{
+		w:      w,
+		cw:     cw,
+		opts:   o,
+		cols:   cols,
+		record: make([]string, len(cols)),
+	}, nil
+}
+
+// Header returns the column names written as the header row.
+func (w *Writer[T]) Header() []string {
+	names := make([]string, len(w.cols))
+	for i, c := range w.cols {
+		names[i] = c.name
+	}
+	return names
+}
+
+// Write writes one record. Output is buffered; call Flush to send it to the underlying writer.
+func (w *Writer[T]) Write(row T) error {
+	if err := w.begin(); err != nil {
+		return err
+	}
+
+	v := reflect.ValueOf(row)
+	for i, c := range w.cols {
+		s, err := encode(v.FieldByIndex(c.index), w.opts)
+		if err != nil {
+			return err
+		}
+		w.record[i] = s
+	}
+	return w.cw.Write(w.record)
+}
+
+// WriteAll writes all rows and flushes.
+func (w *Writer[T]) WriteAll(rows []T) error {
+	for _, row := range rows {
+		if err := w.Write(row); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+// Flush writes any buffered data (including the header when nothing was written yet).
+func (w *Writer[T]) Flush() error {
+	if err := w.begin(); err != nil {
+		return err
+	}
+	w.cw.Flush()
+	return w.cw.Error()
+}
+
+func (w *Writer[T]) begin() error {
+	if w.begun {
+		return nil
+	}
+	w.begun = true
+
+	if w.opts.bom {
+		if _, err := io.WriteString(w.w, bom); err != nil {
+			return err
+		}
+	}
+	if w.opts.header == nil || *w.opts.header {
+		return w.cw.Write(w.Header())
+	}
+	return nil
+}
+
+// WriteTo writes rows as CSV to out (e.g. http.ResponseWriter).
+func WriteTo[T any](out io.Writer, rows []T, opts ...Option) error {
+	w, err := NewWriter[T](out, opts...)
+	if err != nil {
+		return err
+	}
+	return w.WriteAll(rows)
+}
+
+// WriteFile writes rows as CSV to path, creating or truncating the file.
+//
+// Example:
+//
+//	err := csvx.WriteFile("report.csv", rows, csvx.WithBOM())
+func WriteFile[T any](path string, rows []T, opts ...Option) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := WriteTo(f, rows, opts...); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
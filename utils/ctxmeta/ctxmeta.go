@@ -0,0 +1,143 @@
+// Package ctxmeta stores request-scoped metadata (request ID, user, tenant,
+// locale, trace ID) in context.Context under unexported, typed keys.
+//
+// Getters fall back to the legacy string keys in consts (consts.RID,
+// consts.Actor, consts.TenantID, consts.Lang), so values set with
+// gin.Context.Set or utils.SetValueCtx are still found.
+package ctxmeta
+
+import (
+	"context"
+	"maps"
+
+	"github.com/BevisDev/godev/consts"
+)
+
+type key int
+
+const (
+	ridKey key = iota
+	userIDKey
+	tenantKey
+	localeKey
+	traceIDKey
+	bagKey
+)
+
+// Metadata keys returned by Metadata.
+const (
+	KeyRID     = consts.RID
+	KeyUserID  = "user_id"
+	KeyTenant  = consts.TenantID
+	KeyLocale  = "locale"
+	KeyTraceID = "trace_id"
+)
+
+// WithRID stores the request ID in ctx.
+func WithRID(ctx context.Context, rid string) context.Context {
+	return context.WithValue(ctx, ridKey, rid)
+}
+
+// RID returns the request ID, or "" when missing.
+func RID(ctx context.Context) string {
+	return get(ctx, ridKey, consts.RID)
+}
+
+// WithUserID stores the ID of the authenticated user in ctx.
+func WithUserID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, userIDKey, id)
+}
+
+// UserID returns the user ID, or "" when missing.
+func UserID(ctx context.Context) string {
+	return get(ctx, userIDKey, consts.Actor)
+}
+
+// WithTenant stores the tenant ID in ctx.
+func WithTenant(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, tenantKey, id)
+}
+
+// Tenant returns the tenant ID, or "" when missing.
+func Tenant(ctx context.Context) string {
+	return get(ctx, tenantKey, consts.TenantID)
+}
+
+// WithLocale stores the locale (language tag) in ctx.
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey, locale)
+}
+
+// Locale returns the locale, or "" when missing.
+func Locale(ctx context.Context) string {
+	return get(ctx, localeKey, consts.Lang)
+}
+
+// WithTraceID stores the distributed trace ID in ctx.
+func WithTraceID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, traceIDKey, id)
+}
+
+// TraceID returns the trace ID, or "" when missing.
+func TraceID(ctx context.Context) string {
+	return get(ctx, traceIDKey, "")
+}
+
+// WithValue adds a custom key/value to the metadata bag of ctx.
+// The bag is copied, so parent contexts are not affected.
+func WithValue(ctx context.Context, k, v string) context.Context {
+	bag, _ := ctx.Value(bagKey).(map[string]string)
+	next := make(map[string]string, len(bag)+1)
+	maps.Copy(next, bag)
+	next[k] = v
+	return context.WithValue(ctx, bagKey, next)
+}
+
+// Value returns a custom value added by WithValue, or "".
+func Value(ctx context.Context, k string) string {
+	if ctx == nil {
+		return ""
+	}
+	bag, _ := ctx.Value(bagKey).(map[string]string)
+	return bag[k]
+}
+
+// Metadata returns every non-empty value of ctx, e.g. as logging fields.
+// Typed values take precedence over custom values with the same key.
+func Metadata(ctx context.Context) map[string]string {
+	m := make(map[string]string)
+	if ctx == nil {
+		return m
+	}
+
+	if bag, ok := ctx.Value(bagKey).(map[string]string); ok {
+		maps.Copy(m, bag)
+	}
+
+	for k, v := range map[string]string{
+		KeyRID:     RID(ctx),
+		KeyUserID:  UserID(ctx),
+		KeyTenant:  Tenant(ctx),
+		KeyLocale:  Locale(ctx),
+		KeyTraceID: TraceID(ctx),
+	} {
+		if v != "" {
+			m[k] = v
+		}
+	}
+	return m
+}
+
+func get(ctx context.Context, k key, legacy string) string {
+	if ctx == nil {
+		return ""
+	}
+	if v, ok := ctx.Value(k).(string); ok {
+		return v
+	}
+	if legacy != "" {
+		v, _ := ctx.Value(legacy).(string)
+		return v
+	}
+	return ""
+}
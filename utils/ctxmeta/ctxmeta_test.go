@@ -0,0 +1,60 @@
+package ctxmeta
+
+import (
+	"context"
+	"testing"
+
+	"github.com/BevisDev/godev/consts"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSettersAndGetters(t *testing.T) {
+	ctx := context.Background()
+	ctx = WithRID(ctx, "rid-1")
+	ctx = WithUserID(ctx, "user-1")
+	ctx = WithTenant(ctx, "acme")
+	ctx = WithLocale(ctx, "vi")
+	ctx = WithTraceID(ctx, "trace-1")
+
+	assert.Equal(t, "rid-1", RID(ctx))
+	assert.Equal(t, "user-1", UserID(ctx))
+	assert.Equal(t, "acme", Tenant(ctx))
+	assert.Equal(t, "vi", Locale(ctx))
+	assert.Equal(t, "trace-1", TraceID(ctx))
+}
+
+func TestGetters_Missing(t *testing.T) {
+	assert.Empty(t, RID(context.Background()))
+	assert.Empty(t, TraceID(nil))
+	assert.Empty(t, Metadata(nil))
+}
+
+func TestGetters_LegacyStringKeys(t *testing.T) {
+	ctx := context.WithValue(context.Background(), consts.RID, "legacy-rid")
+	ctx = context.WithValue(ctx, consts.TenantID, "legacy-tenant")
+	assert.Equal(t, "legacy-rid", RID(ctx))
+	assert.Equal(t, "legacy-tenant", Tenant(ctx))
+
+	// typed key wins over the legacy key
+	ctx = WithRID(ctx, "typed-rid")
+	assert.Equal(t, "typed-rid", RID(ctx))
+}
+
+func TestNoCollisionWithStringKeys(t *testing.T) {
+	ctx := WithTraceID(context.Background(), "trace-1")
+	assert.Nil(t, ctx.Value(KeyTraceID))
+}
+
+func TestValueAndMetadata(t *testing.T) {
+	parent := WithValue(WithRID(context.Background(), "rid-1"), "order_id", "42")
+	child := WithValue(parent, "step", "pay")
+
+	assert.Equal(t, "42", Value(child, "order_id"))
+	assert.Empty(t, Value(parent, "step"), "parent bag must not change")
+
+	assert.Equal(t, map[string]string{
+		KeyRID:     "rid-1",
+		"order_id": "42",
+		"step":     "pay",
+	}, Metadata(child))
+}
@@ -0,0 +1,152 @@
+package datetime
+
+import (
+	"sync"
+	"time"
+)
+
+// HolidayCalendar decides whether a date is a public holiday.
+// Implement it to plug in holidays from a database or config.
+type HolidayCalendar interface {
+	IsHoliday(t time.Time) bool
+}
+
+type monthDay struct {
+	month time.Month
+	day   int
+}
+
+// Calendar is an in-memory HolidayCalendar holding one-off dates
+// and holidays that repeat every year on the same solar date.
+type Calendar struct {
+	mu        sync.RWMutex
+	dates     map[string]string
+	recurring map[monthDay]string
+}
+
+// NewCalendar returns an empty holiday calendar.
+func NewCalendar() *Calendar {
+	return &Calendar{
+		dates:     make(map[string]string),
+		recurring: make(map[monthDay]string),
+	}
+}
+
+// NewVNCalendar returns a calendar preloaded with Vietnam's fixed solar holidays:
+// New Year (01/01), Reunification Day (30/04), Labour Day (01/05) and National Day (02/09).
+//
+// Lunar holidays (Tet, Hung Kings) and yearly bridge days change every year;
+// add them with AddHoliday from the government announcement.
+func NewVNCalendar() *Calendar {
+	c := NewCalendar()
+	c.AddRecurring(time.January, 1, "New Year's Day")
+	c.AddRecurring(time.April, 30, "Reunification Day")
+	c.AddRecurring(time.May, 1, "International Labour Day")
+	c.AddRecurring(time.September, 2, "National Day")
+	return c
+}
+
+// AddHoliday registers a one-off holiday on the date of t.
+func (c *Calendar) AddHoliday(t time.Time, name string) *Calendar {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.dates[t.Format(DateLayoutISO)] = name
+	return c
+}
+
+// AddHolidays registers one-off holidays on each date in ts.
+func (c *Calendar) AddHolidays(ts ...time.Time) *Calendar {
+	for _, t := range ts {
+		c.AddHoliday(t, "")
+	}
+	return c
+}
+
+// AddRecurring registers a holiday repeated every year on month/day.
+func (c *Calendar) AddRecurring(month time.Month, day int, name string) *Calendar {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.recurring[monthDay{month: month, day: day}] = name
+	return c
+}
+
+// IsHoliday reports whether the date of t is a registered holiday.
+func (c *Calendar) IsHoliday(t time.Time) bool {
+	_, ok := c.HolidayName(t)
+	return ok
+}
+
+// HolidayName returns the holiday name for the date of t, if any.
+func (c *Calendar) HolidayName(t time.Time) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if name, ok := c.dates[t.Format(DateLayoutISO)]; ok {
+		return name, true
+	}
+	name, ok := c.recurring[monthDay{month: t.Month(), day: t.Day()}]
+	return name, ok
+}
+
+// IsHoliday reports whether t is a holiday in cal. A nil calendar has no holidays.
+func IsHoliday(t time.Time, cal HolidayCalendar) bool {
+	return cal != nil && cal.IsHoliday(t)
+}
+
+// IsBusinessDay reports whether t is neither a weekend nor a holiday in cal.
+func IsBusinessDay(t time.Time, cal HolidayCalendar) bool {
+	return !IsWeekend(t) && !IsHoliday(t, cal)
+}
+
+// NextBusinessDay returns the first business day strictly after t, keeping the time of day.
+func NextBusinessDay(t time.Time, cal HolidayCalendar) time.Time {
+	return AddBusinessDays(t, 1, cal)
+}
+
+// PrevBusinessDay returns the last business day strictly before t, keeping the time of day.
+func PrevBusinessDay(t time.Time, cal HolidayCalendar) time.Time {
+	return AddBusinessDays(t, -1, cal)
+}
+
+// AddBusinessDays moves t by n business days, skipping weekends and holidays.
+// A negative n moves backwards; n == 0 returns t unchanged.
+//
+// Example:
+//
+//	cal := NewVNCalendar()
+//	fri := time.Date(2024, 4, 26, 9, 0, 0, 0, VNLocation())
+//	AddBusinessDays(fri, 1, cal) // Mon 29/04 (30/04 and 01/05 are holidays)
+//	AddBusinessDays(fri, 2, cal) // Thu 02/05
+func AddBusinessDays(t time.Time, n int, cal HolidayCalendar) time.Time {
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+
+	for n > 0 {
+		t = t.AddDate(0, 0, step)
+		if IsBusinessDay(t, cal) {
+			n--
+		}
+	}
+	return t
+}
+
+// BusinessDaysBetween counts business days in the half-open range [from, to).
+// If to is before from, the result is negative.
+func BusinessDaysBetween(from, to time.Time, cal HolidayCalendar) int {
+	sign := 1
+	if to.Before(from) {
+		from, to = to, from
+		sign = -1
+	}
+
+	count := 0
+	end := BeginDay(to)
+	for d := BeginDay(from); d.Before(end); d = d.AddDate(0, 0, 1) {
+		if IsBusinessDay(d, cal) {
+			count++
+		}
+	}
+	return sign * count
+}
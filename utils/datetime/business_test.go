@@ -0,0 +1,89 @@
+package datetime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToTimeInLocation(t *testing.T) {
+	tm, err := ToTimeInLocation("2024-01-02 08:00:00", DateTimeLayout, VNLocation())
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 2, 1, 0, 0, 0, time.UTC), tm.UTC())
+
+	tm, err = ToTimeInZone("2024-01-02 08:00:00", DateTimeLayout, TimezoneUTC)
+	require.NoError(t, err)
+	assert.Equal(t, 8, tm.Hour())
+
+	_, err = ToTimeInZone("2024-01-02", DateLayoutISO, "Not/AZone")
+	assert.Error(t, err)
+
+	_, err = ToTimeInLocation("bad", DateLayoutISO, nil)
+	assert.Error(t, err)
+}
+
+func TestConvertZone(t *testing.T) {
+	utc := time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC)
+
+	vn, err := ConvertZone(utc, TimezoneVN)
+	require.NoError(t, err)
+	assert.True(t, vn.Equal(utc))
+	assert.Equal(t, 3, vn.Hour())
+	assert.Equal(t, 2, vn.Day())
+
+	assert.Equal(t, 3, ToVN(utc).Hour())
+	assert.Equal(t, 2, BeginDayIn(utc, VNLocation()).Day())
+	assert.Equal(t, 23, EndDayIn(utc, VNLocation()).Hour())
+}
+
+func TestCalendar(t *testing.T) {
+	cal := NewVNCalendar()
+	cal.AddHoliday(time.Date(2024, 2, 12, 0, 0, 0, 0, time.UTC), "Tet")
+
+	assert.True(t, cal.IsHoliday(time.Date(2025, 9, 2, 10, 0, 0, 0, time.UTC)))
+	assert.True(t, cal.IsHoliday(time.Date(2024, 2, 12, 15, 0, 0, 0, time.UTC)))
+	assert.False(t, cal.IsHoliday(time.Date(2025, 2, 12, 0, 0, 0, 0, time.UTC)))
+
+	name, ok := cal.HolidayName(time.Date(2024, 4, 30, 0, 0, 0, 0, time.UTC))
+	assert.True(t, ok)
+	assert.Equal(t, "Reunification Day", name)
+
+	assert.False(t, IsHoliday(time.Date(2024, 4, 30, 0, 0, 0, 0, time.UTC), nil))
+}
+
+func TestAddBusinessDays(t *testing.T) {
+	cal := NewVNCalendar()
+	fri := time.Date(2024, 4, 26, 9, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2024, 4, 29, 9, 0, 0, 0, time.UTC), AddBusinessDays(fri, 1, cal))
+	assert.Equal(t, time.Date(2024, 5, 2, 9, 0, 0, 0, time.UTC), AddBusinessDays(fri, 2, cal))
+	assert.Equal(t, time.Date(2024, 4, 29, 9, 0, 0, 0, time.UTC), AddBusinessDays(fri, 1, nil))
+	assert.Equal(t, fri, AddBusinessDays(fri, 0, cal))
+
+	thu := time.Date(2024, 5, 2, 9, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2024, 4, 29, 9, 0, 0, 0, time.UTC), PrevBusinessDay(thu, cal))
+	assert.Equal(t, time.Date(2024, 4, 26, 9, 0, 0, 0, time.UTC), AddBusinessDays(thu, -2, cal))
+
+	sat := time.Date(2024, 4, 27, 9, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2024, 4, 29, 9, 0, 0, 0, time.UTC), NextBusinessDay(sat, cal))
+}
+
+func TestIsBusinessDay(t *testing.T) {
+	cal := NewVNCalendar()
+	assert.True(t, IsBusinessDay(time.Date(2024, 4, 29, 0, 0, 0, 0, time.UTC), cal))
+	assert.False(t, IsBusinessDay(time.Date(2024, 4, 30, 0, 0, 0, 0, time.UTC), cal))
+	assert.False(t, IsBusinessDay(time.Date(2024, 4, 27, 0, 0, 0, 0, time.UTC), cal))
+}
+
+func TestBusinessDaysBetween(t *testing.T) {
+	cal := NewVNCalendar()
+	from := time.Date(2024, 4, 26, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC)
+
+	// Fri 26, Mon 29, Thu 02
+	assert.Equal(t, 3, BusinessDaysBetween(from, to, cal))
+	assert.Equal(t, -3, BusinessDaysBetween(to, from, cal))
+	assert.Equal(t, 0, BusinessDaysBetween(from, from, cal))
+}
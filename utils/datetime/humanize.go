@@ -0,0 +1,150 @@
+package datetime
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// FlexibleLayouts is the prioritized list of layouts tried by ParseFlexible.
+// Zone-aware layouts come first so explicit offsets are never discarded.
+var FlexibleLayouts = []string{
+	time.RFC3339Nano,
+	DateTimeLayoutRFC3339,
+	DateTimeLayoutUTC,
+	DateTimeLayoutMilli,
+	DateTimeLayout,
+	DateTimeLayoutLocal,
+	DateTimeLayoutCompact,
+	DateLayoutISO,
+	DateLayoutDMYSlash,
+	DateLayoutDMYDash,
+	DateLayoutDMYMonth,
+	DateLayoutCompact,
+	time.RFC1123Z,
+	time.RFC1123,
+}
+
+// ParseFlexible parses s with the first matching layout in FlexibleLayouts.
+// Values without zone information are interpreted as UTC.
+//
+// Example:
+//
+//	t, err := ParseFlexible("2024-01-15T10:30:45+07:00")
+//	t, err := ParseFlexible("15/01/2024")
+func ParseFlexible(s string) (time.Time, error) {
+	return ParseFlexibleInLocation(s, time.UTC)
+}
+
+// ParseFlexibleInLocation is like ParseFlexible but interprets zone-less values in loc.
+func ParseFlexibleInLocation(s string, loc *time.Location) (time.Time, error) {
+	t, _, err := ParseFlexibleLayout(s, loc)
+	return t, err
+}
+
+// ParseFlexibleLayout is like ParseFlexibleInLocation and also returns the layout that matched.
+func ParseFlexibleLayout(s string, loc *time.Location) (time.Time, string, error) {
+	s = strings.TrimSpace(s)
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	for _, layout := range FlexibleLayouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, layout, nil
+		}
+	}
+	return time.Time{}, "", fmt.Errorf("cannot parse %q with any known layout", s)
+}
+
+// FormatDuration formats d as a compact human string, e.g. "1h 23m 45s".
+// Zero units are omitted; durations under a second are shown in milliseconds.
+//
+// Example:
+//
+//	FormatDuration(90 * time.Minute)          // "1h 30m"
+//	FormatDuration(26*time.Hour + time.Second) // "1d 2h 1s"
+//	FormatDuration(350 * time.Millisecond)    // "350ms"
+func FormatDuration(d time.Duration) string {
+	if d == 0 {
+		return "0s"
+	}
+
+	sign := ""
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+	if d < time.Second {
+		return sign + fmt.Sprintf("%dms", d.Milliseconds())
+	}
+
+	units := []struct {
+		size   time.Duration
+		suffix string
+	}{
+		{24 * time.Hour, "d"},
+		{time.Hour, "h"},
+		{time.Minute, "m"},
+		{time.Second, "s"},
+	}
+
+	parts := make([]string, 0, len(units))
+	for _, u := range units {
+		if n := d / u.size; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d%s", n, u.suffix))
+			d -= n * u.size
+		}
+	}
+	return sign + strings.Join(parts, " ")
+}
+
+// Humanize describes t relative to now, e.g. "3 minutes ago" or "in 2 days".
+func Humanize(t time.Time) string {
+	return HumanizeAt(t, time.Now())
+}
+
+// HumanizeAt describes t relative to ref.
+//
+// Example:
+//
+//	ref := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+//	HumanizeAt(ref.Add(-3*time.Minute), ref) // "3 minutes ago"
+//	HumanizeAt(ref.Add(49*time.Hour), ref)   // "in 2 days"
+func HumanizeAt(t, ref time.Time) string {
+	d := ref.Sub(t)
+	future := d < 0
+	if future {
+		d = -d
+	}
+
+	var phrase string
+	switch {
+	case d < 10*time.Second:
+		return "just now"
+	case d < time.Minute:
+		phrase = plural(int(d/time.Second), "second")
+	case d < time.Hour:
+		phrase = plural(int(d/time.Minute), "minute")
+	case d < 24*time.Hour:
+		phrase = plural(int(d/time.Hour), "hour")
+	case d < 30*24*time.Hour:
+		phrase = plural(int(d/(24*time.Hour)), "day")
+	case d < 365*24*time.Hour:
+		phrase = plural(int(d/(30*24*time.Hour)), "month")
+	default:
+		phrase = plural(int(d/(365*24*time.Hour)), "year")
+	}
+
+	if future {
+		return "in " + phrase
+	}
+	return phrase + " ago"
+}
+
+func plural(n int, unit string) string {
+	if n == 1 {
+		return "1 " + unit
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
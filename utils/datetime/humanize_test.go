@@ -0,0 +1,70 @@
+package datetime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFlexible(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected time.Time
+		layout   string
+	}{
+		{"2024-01-15T10:30:45Z", time.Date(2024, 1, 15, 10, 30, 45, 0, time.UTC), time.RFC3339Nano},
+		{"2024-01-15T10:30:45+07:00", time.Date(2024, 1, 15, 3, 30, 45, 0, time.UTC), time.RFC3339Nano},
+		{"2024-01-15 10:30:45.123", time.Date(2024, 1, 15, 10, 30, 45, 123000000, time.UTC), DateTimeLayoutMilli},
+		{"2024-01-15 10:30:45", time.Date(2024, 1, 15, 10, 30, 45, 0, time.UTC), DateTimeLayout},
+		{"2024-01-15T10:30:45", time.Date(2024, 1, 15, 10, 30, 45, 0, time.UTC), DateTimeLayoutLocal},
+		{"20240115103045", time.Date(2024, 1, 15, 10, 30, 45, 0, time.UTC), DateTimeLayoutCompact},
+		{"2024-01-15", time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), DateLayoutISO},
+		{"15/01/2024", time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), DateLayoutDMYSlash},
+		{"15-01-2024", time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), DateLayoutDMYDash},
+		{"15-Jan-2024", time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), DateLayoutDMYMonth},
+		{" 20240115 ", time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), DateLayoutCompact},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, layout, err := ParseFlexibleLayout(tt.input, nil)
+			require.NoError(t, err)
+			assert.True(t, tt.expected.Equal(got), "got %v", got)
+			assert.Equal(t, tt.layout, layout)
+		})
+	}
+
+	_, err := ParseFlexible("not a date")
+	assert.Error(t, err)
+}
+
+func TestParseFlexibleInLocation(t *testing.T) {
+	got, err := ParseFlexibleInLocation("2024-01-15 08:00:00", VNLocation())
+	require.NoError(t, err)
+	assert.Equal(t, 1, got.UTC().Hour())
+}
+
+func TestFormatDuration(t *testing.T) {
+	assert.Equal(t, "0s", FormatDuration(0))
+	assert.Equal(t, "350ms", FormatDuration(350*time.Millisecond))
+	assert.Equal(t, "45s", FormatDuration(45*time.Second))
+	assert.Equal(t, "1h 23m 45s", FormatDuration(time.Hour+23*time.Minute+45*time.Second))
+	assert.Equal(t, "1h 30m", FormatDuration(90*time.Minute))
+	assert.Equal(t, "1d 2h 1s", FormatDuration(26*time.Hour+time.Second))
+	assert.Equal(t, "-2m", FormatDuration(-2*time.Minute))
+}
+
+func TestHumanizeAt(t *testing.T) {
+	ref := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, "just now", HumanizeAt(ref.Add(-5*time.Second), ref))
+	assert.Equal(t, "30 seconds ago", HumanizeAt(ref.Add(-30*time.Second), ref))
+	assert.Equal(t, "1 minute ago", HumanizeAt(ref.Add(-time.Minute), ref))
+	assert.Equal(t, "3 minutes ago", HumanizeAt(ref.Add(-3*time.Minute), ref))
+	assert.Equal(t, "5 hours ago", HumanizeAt(ref.Add(-5*time.Hour), ref))
+	assert.Equal(t, "in 2 days", HumanizeAt(ref.Add(49*time.Hour), ref))
+	assert.Equal(t, "2 months ago", HumanizeAt(ref.AddDate(0, -2, 0), ref))
+	assert.Equal(t, "1 year ago", HumanizeAt(ref.AddDate(-1, 0, -1), ref))
+}
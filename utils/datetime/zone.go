@@ -0,0 +1,91 @@
+package datetime
+
+import (
+	"sync"
+	"time"
+)
+
+// Common IANA zone names.
+const (
+	TimezoneUTC = "UTC"
+	TimezoneVN  = "Asia/Ho_Chi_Minh"
+)
+
+var locCache sync.Map // map[string]*time.Location
+
+// LoadLocation returns the location for an IANA zone name, caching results.
+// If the tz database is missing on the host, TimezoneVN falls back to a fixed UTC+7 zone.
+func LoadLocation(name string) (*time.Location, error) {
+	if v, ok := locCache.Load(name); ok {
+		return v.(*time.Location), nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		if name != TimezoneVN {
+			return nil, err
+		}
+		loc = time.FixedZone("ICT", 7*60*60)
+	}
+
+	locCache.Store(name, loc)
+	return loc, nil
+}
+
+// VNLocation returns the Asia/Ho_Chi_Minh location (UTC+7).
+func VNLocation() *time.Location {
+	loc, _ := LoadLocation(TimezoneVN)
+	return loc
+}
+
+// ToTimeInLocation parses str using layout, interpreting it in loc when the
+// layout carries no zone information.
+//
+// Example:
+//
+//	t, err := ToTimeInLocation("2024-01-02 08:00:00", DateTimeLayout, VNLocation())
+//	// 2024-01-02 08:00:00 +0700, i.e. 01:00 UTC
+func ToTimeInLocation(str string, layout string, loc *time.Location) (*time.Time, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	t, err := time.ParseInLocation(layout, str, loc)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// ToTimeInZone is like ToTimeInLocation but takes an IANA zone name.
+func ToTimeInZone(str string, layout string, zone string) (*time.Time, error) {
+	loc, err := LoadLocation(zone)
+	if err != nil {
+		return nil, err
+	}
+	return ToTimeInLocation(str, layout, loc)
+}
+
+// ConvertZone returns t expressed in the given IANA zone (same instant).
+func ConvertZone(t time.Time, zone string) (time.Time, error) {
+	loc, err := LoadLocation(zone)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.In(loc), nil
+}
+
+// ToVN returns t expressed in Vietnam time (same instant).
+func ToVN(t time.Time) time.Time {
+	return t.In(VNLocation())
+}
+
+// BeginDayIn returns the start of the day of t as observed in loc.
+// Useful when servers run in UTC but the business day is local.
+func BeginDayIn(t time.Time, loc *time.Location) time.Time {
+	return BeginDay(t.In(loc))
+}
+
+// EndDayIn returns the end of the day of t as observed in loc.
+func EndDayIn(t time.Time, loc *time.Location) time.Time {
+	return EndDay(t.In(loc))
+}
@@ -49,6 +49,49 @@ func (r *Reader) ReadSheetAt(index int) ([][]string, error) {
 	return r.ReadSheet(names[index])
 }
 
+// RowIterator streams the rows of a sheet without loading the whole sheet into memory.
+type RowIterator struct {
+	rows *excelize.Rows
+	line int
+}
+
+// IterRows returns an iterator over the rows of sheetName. Call Close when done.
+// Its Next method matches csvx.RecordFunc, so rows can be decoded into structs:
+//
+//	it, err := e.Reader.IterRows("Sheet1")
+//	defer it.Close()
+//	r, err := csvx.NewRecordReader[Row](it.Next)
+func (r *Reader) IterRows(sheetName string) (*RowIterator, error) {
+	rows, err := r.f.Rows(sheetName)
+	if err != nil {
+		return nil, err
+	}
+	return &RowIterator{rows: rows}, nil
+}
+
+// Next returns the next non-empty row and its 1-based row number, or io.EOF after the last row.
+func (it *RowIterator) Next() ([]string, int, error) {
+	for it.rows.Next() {
+		it.line++
+		cols, err := it.rows.Columns()
+		if err != nil {
+			return nil, it.line, err
+		}
+		if len(cols) > 0 {
+			return cols, it.line, nil
+		}
+	}
+	if err := it.rows.Error(); err != nil {
+		return nil, it.line, err
+	}
+	return nil, it.line, io.EOF
+}
+
+// Close releases the iterator.
+func (it *RowIterator) Close() error {
+	return it.rows.Close()
+}
+
 // GetCell returns the value of a single cell (e.g. "A1", "B2").
 func (r *Reader) GetCell(sheetName, cell string) (string, error) {
 	return r.f.GetCellValue(sheetName, cell)
@@ -2,6 +2,7 @@ package excel
 
 import (
 	"bytes"
+	"io"
 	"path/filepath"
 	"testing"
 
@@ -156,3 +157,31 @@ func TestReader_Close(t *testing.T) {
 	e.Reader = nil
 	assert.Nil(t, e.Close())
 }
+
+func TestReader_IterRows(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "iter.xlsx")
+	require.NoError(t, ExportFile(path, [][]string{{"H1", "H2"}, {}, {"a", "b"}}))
+
+	e, r := openExcelForRead(t, path)
+	defer e.Close()
+
+	it, err := r.IterRows("Sheet1")
+	require.NoError(t, err)
+	defer it.Close()
+
+	row, line, err := it.Next()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"H1", "H2"}, row)
+	assert.Equal(t, 1, line)
+
+	row, line, err = it.Next()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, row)
+	assert.Equal(t, 3, line)
+
+	_, _, err = it.Next()
+	assert.ErrorIs(t, err, io.EOF)
+
+	_, err = r.IterRows("Missing")
+	assert.Error(t, err)
+}
@@ -0,0 +1,230 @@
+package excel
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	tagName = "excel"
+
+	// DefaultTimeFormat is the number format applied to time.Time columns without a format.
+	DefaultTimeFormat = "yyyy-mm-dd hh:mm:ss"
+)
+
+var (
+	timeType    = reflect.TypeOf(time.Time{})
+	decimalType = reflect.TypeOf(decimal.Decimal{})
+)
+
+// structColumn describes one exported column, parsed from the `excel` tag:
+//
+//	`excel:"Header;width=18;format=#,##0.00"`
+//
+// Options are separated by ';' because number formats contain commas.
+// Use `excel:"-"` to skip a field.
+type structColumn struct {
+	header string
+	index  []int
+	width  float64
+	format string
+}
+
+func structColumns(t reflect.Type) ([]structColumn, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("excel: %s is not a struct", t)
+	}
+
+	var cols []structColumn
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		tag := f.Tag.Get(tagName)
+		if tag == "-" {
+			continue
+		}
+
+		parts := strings.Split(tag, ";")
+		col := structColumn{header: parts[0], index: f.Index}
+		if col.header == "" {
+			col.header = f.Name
+		}
+		for _, p := range parts[1:] {
+			key, val, _ := strings.Cut(p, "=")
+			switch strings.TrimSpace(key) {
+			case "width":
+				w, err := strconv.ParseFloat(val, 64)
+				if err != nil {
+					return nil, fmt.Errorf("excel: field %s: invalid width %q", f.Name, val)
+				}
+				col.width = w
+			case "format":
+				col.format = val
+			}
+		}
+
+		ft := f.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if col.format == "" && ft == timeType {
+			col.format = DefaultTimeFormat
+		}
+		cols = append(cols, col)
+	}
+	return cols, nil
+}
+
+// cellValue converts a field into a value excelize stores with the right cell type:
+// numbers stay numeric, decimals become numbers, nil pointers become empty cells.
+func cellValue(v reflect.Value) any {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	switch v.Type() {
+	case decimalType:
+		return v.Interface().(decimal.Decimal).InexactFloat64()
+	case timeType:
+		if t := v.Interface().(time.Time); !t.IsZero() {
+			return t
+		}
+		return nil
+	}
+	return v.Interface()
+}
+
+// WriteStructs writes rows to sheetName as a typed table: a bold, frozen header row
+// built from `excel` tags, followed by one row per element. Numeric fields are stored
+// as numbers, time.Time as dates; per-column width and number format come from the tag.
+//
+// Example:
+//
+//	type Row struct {
+//		Code   string          `excel:"Order code;width=18"`
+//		Amount decimal.Decimal `excel:"Amount;width=16;format=#,##0.00"`
+//		PaidAt time.Time       `excel:"Paid at;width=20"`
+//		Note   string          `excel:"-"`
+//	}
+//	e := excel.NewFile()
+//	defer e.Close()
+//	err := excel.WriteStructs(e.Writer, "Orders", rows)
+func WriteStructs[T any](w *Writer, sheetName string, rows []T) error {
+	cols, err := structColumns(reflect.TypeOf((*T)(nil)).Elem())
+	if err != nil {
+		return err
+	}
+	if sheetName == "" {
+		sheetName = "Sheet1"
+	}
+	if idx, err := w.f.GetSheetIndex(sheetName); err != nil || idx < 0 {
+		if _, err := w.f.NewSheet(sheetName); err != nil {
+			return err
+		}
+	}
+
+	header := make([]any, len(cols))
+	for i, c := range cols {
+		header[i] = c.header
+	}
+	if err := w.f.SetSheetRow(sheetName, "A1", &header); err != nil {
+		return err
+	}
+
+	values := make([]any, len(cols))
+	for r, row := range rows {
+		v := reflect.ValueOf(row)
+		for i, c := range cols {
+			values[i] = cellValue(v.FieldByIndex(c.index))
+		}
+		cell, err := excelize.CoordinatesToCellName(1, r+2)
+		if err != nil {
+			return err
+		}
+		if err := w.f.SetSheetRow(sheetName, cell, &values); err != nil {
+			return err
+		}
+	}
+
+	return w.formatTable(sheetName, cols, len(rows))
+}
+
+func (w *Writer) formatTable(sheetName string, cols []structColumn, rowCount int) error {
+	headerStyle, err := w.f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true},
+		Fill: excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"D9E1F2"}},
+	})
+	if err != nil {
+		return err
+	}
+	last, err := excelize.CoordinatesToCellName(len(cols), 1)
+	if err != nil {
+		return err
+	}
+	if err := w.f.SetCellStyle(sheetName, "A1", last, headerStyle); err != nil {
+		return err
+	}
+
+	for i, c := range cols {
+		name, err := excelize.ColumnNumberToName(i + 1)
+		if err != nil {
+			return err
+		}
+		if c.width > 0 {
+			if err := w.f.SetColWidth(sheetName, name, name, c.width); err != nil {
+				return err
+			}
+		}
+		if c.format != "" && rowCount > 0 {
+			format := c.format
+			style, err := w.f.NewStyle(&excelize.Style{CustomNumFmt: &format})
+			if err != nil {
+				return err
+			}
+			err = w.f.SetCellStyle(sheetName, name+"2", name+strconv.Itoa(rowCount+1), style)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return w.f.SetPanes(sheetName, &excelize.Panes{
+		Freeze:      true,
+		YSplit:      1,
+		TopLeftCell: "A2",
+		ActivePane:  "bottomLeft",
+	})
+}
+
+// ExportStructs writes rows to "Sheet1" of a new workbook and saves it to path.
+func ExportStructs[T any](path string, rows []T) error {
+	e := NewFile()
+	defer e.Close()
+	if err := WriteStructs(e.Writer, "Sheet1", rows); err != nil {
+		return err
+	}
+	return e.Save(path)
+}
+
+// ExportStructsTo writes rows to "Sheet1" of a new workbook and streams it to out (e.g. http response).
+func ExportStructsTo[T any](out io.Writer, rows []T) error {
+	e := NewFile()
+	defer e.Close()
+	if err := WriteStructs(e.Writer, "Sheet1", rows); err != nil {
+		return err
+	}
+	_, err := e.WriteTo(out)
+	return err
+}
@@ -0,0 +1,81 @@
+package excel
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type exportRow struct {
+	Code   string          `excel:"Order code;width=18"`
+	Qty    int             `excel:"Qty"`
+	Amount decimal.Decimal `excel:"Amount;width=16;format=#,##0.00"`
+	PaidAt *time.Time      `excel:"Paid at;width=20"`
+	Note   string          `excel:"-"`
+	Plain  bool
+}
+
+func TestWriteStructs(t *testing.T) {
+	paid := time.Date(2024, 5, 1, 8, 30, 0, 0, time.UTC)
+	rows := []exportRow{
+		{Code: "ORD-1", Qty: 2, Amount: decimal.RequireFromString("1234.5"), PaidAt: &paid, Note: "x", Plain: true},
+		{Code: "ORD-2", Qty: 1, Amount: decimal.NewFromInt(10)},
+	}
+
+	path := filepath.Join(t.TempDir(), "orders.xlsx")
+	require.NoError(t, ExportStructs(path, rows))
+
+	e, err := Open(path)
+	require.NoError(t, err)
+	defer e.Close()
+
+	got, err := e.Reader.ReadSheet("Sheet1")
+	require.NoError(t, err)
+	require.Len(t, got, 3)
+	assert.Equal(t, []string{"Order code", "Qty", "Amount", "Paid at", "Plain"}, got[0])
+	assert.Equal(t, []string{"ORD-1", "2", "1,234.50", "2024-05-01 08:30:00", "TRUE"}, got[1])
+	assert.Equal(t, "ORD-2", got[2][0])
+	assert.Equal(t, "", got[2][3])
+
+	f := e.Reader.f
+	typ, err := f.GetCellType("Sheet1", "C2")
+	require.NoError(t, err)
+	assert.NotEqual(t, "s", string(typ), "amounts must be stored as numbers")
+
+	width, err := f.GetColWidth("Sheet1", "A")
+	require.NoError(t, err)
+	assert.Equal(t, 18.0, width)
+
+	panes, err := f.GetPanes("Sheet1")
+	require.NoError(t, err)
+	assert.True(t, panes.Freeze)
+}
+
+func TestExportStructsTo(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, ExportStructsTo(&buf, []exportRow{{Code: "A"}}))
+
+	e, err := OpenReader(&buf)
+	require.NoError(t, err)
+	defer e.Close()
+	v, err := e.Reader.GetCell("Sheet1", "A2")
+	require.NoError(t, err)
+	assert.Equal(t, "A", v)
+}
+
+func TestWriteStructs_Errors(t *testing.T) {
+	e := NewFile()
+	defer e.Close()
+
+	assert.Error(t, WriteStructs(e.Writer, "", []int{1}))
+
+	type badWidth struct {
+		A string `excel:"A;width=wide"`
+	}
+	assert.Error(t, WriteStructs(e.Writer, "", []badWidth{{}}))
+}
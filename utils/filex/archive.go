@@ -0,0 +1,347 @@
+package filex
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	// ErrUnsafePath is returned when an archive entry would be written outside the destination directory.
+	ErrUnsafePath = errors.New("archive entry escapes destination directory")
+
+	// ErrArchiveTooLarge is returned when extracted content exceeds the configured limit.
+	ErrArchiveTooLarge = errors.New("archive exceeds maximum extracted size")
+)
+
+// Zip writes the contents of srcDir into a zip file at dest.
+// Entry names are relative to srcDir and use forward slashes.
+//
+// Example:
+//
+//	err := filex.Zip("./reports", "/tmp/reports.zip", filex.WithInclude("*.csv"))
+func Zip(srcDir, dest string, opts ...Option) error {
+	return writeArchiveFile(dest, func(w io.Writer) error {
+		return ZipTo(w, srcDir, opts...)
+	})
+}
+
+// ZipTo streams a zip of srcDir into w, e.g. an HTTP response.
+func ZipTo(w io.Writer, srcDir string, opts ...Option) error {
+	o := newOptions(opts)
+	zw := zip.NewWriter(w)
+
+	err := walkArchive(srcDir, o, func(p, rel string, info fs.FileInfo) error {
+		header, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return err
+		}
+		header.Name = rel
+		if info.IsDir() {
+			header.Name += "/"
+		} else {
+			header.Method = zip.Deflate
+		}
+
+		entry, err := zw.CreateHeader(header)
+		if err != nil || info.IsDir() {
+			return err
+		}
+		return copyFileTo(entry, p)
+	})
+	if err != nil {
+		zw.Close()
+		return err
+	}
+	return zw.Close()
+}
+
+// Unzip extracts the zip file src into destDir.
+// Entries that would escape destDir (e.g. "../../etc/passwd") fail with ErrUnsafePath.
+func Unzip(src, destDir string, opts ...Option) error {
+	o := newOptions(opts)
+
+	zr, err := zip.OpenReader(src)
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+
+	ex := newExtractor(destDir, o)
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			if err := ex.mkdir(f.Name); err != nil {
+				return err
+			}
+			continue
+		}
+		if !f.Mode().IsRegular() {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return err
+		}
+		err = ex.writeFile(f.Name, f.Mode(), rc)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TarGz writes the contents of srcDir into a gzip-compressed tarball at dest.
+//
+// Example:
+//
+//	err := filex.TarGz("./data", "/tmp/data.tar.gz", filex.WithExclude("*.tmp", ".git"))
+func TarGz(srcDir, dest string, opts ...Option) error {
+	return writeArchiveFile(dest, func(w io.Writer) error {
+		return TarGzTo(w, srcDir, opts...)
+	})
+}
+
+// TarGzTo streams a gzip-compressed tarball of srcDir into w.
+func TarGzTo(w io.Writer, srcDir string, opts ...Option) error {
+	o := newOptions(opts)
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	err := walkArchive(srcDir, o, func(p, rel string, info fs.FileInfo) error {
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = rel
+		if info.IsDir() {
+			header.Name += "/"
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		return copyFileTo(tw, p)
+	})
+	if err != nil {
+		tw.Close()
+		gw.Close()
+		return err
+	}
+	if err := tw.Close(); err != nil {
+		gw.Close()
+		return err
+	}
+	return gw.Close()
+}
+
+// UntarGz extracts the gzip-compressed tarball src into destDir.
+func UntarGz(src, destDir string, opts ...Option) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return UntarGzFrom(f, destDir, opts...)
+}
+
+// UntarGzFrom extracts a gzip-compressed tarball read from r into destDir.
+// Entries are streamed one by one, so r can be an upload body of any size.
+// Symlinks, devices and other special entries are skipped.
+func UntarGzFrom(r io.Reader, destDir string, opts ...Option) error {
+	o := newOptions(opts)
+
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer gr.Close()
+
+	ex := newExtractor(destDir, o)
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := ex.mkdir(header.Name); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := ex.writeFile(header.Name, header.FileInfo().Mode(), tr); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// walkArchive walks srcDir and calls fn for every directory and regular file
+// that passes the include/exclude filters. rel is slash-separated.
+func walkArchive(srcDir string, o *options, fn func(p, rel string, info fs.FileInfo) error) error {
+	return filepath.WalkDir(srcDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(srcDir, p)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+
+		if o.excluded(rel) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			// directories are only written when no include filter is set;
+			// otherwise they are implied by the files inside them
+			if len(o.include) > 0 {
+				return nil
+			}
+			return fn(p, rel, info)
+		}
+		if !info.Mode().IsRegular() || !o.included(rel) {
+			return nil
+		}
+		return fn(p, rel, info)
+	})
+}
+
+func writeArchiveFile(dest string, fn func(w io.Writer) error) error {
+	if err := CreateDir(filepath.Dir(dest)); err != nil {
+		return err
+	}
+	f, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	if err := fn(f); err != nil {
+		f.Close()
+		os.Remove(dest)
+		return err
+	}
+	return f.Close()
+}
+
+func copyFileTo(w io.Writer, p string) error {
+	f, err := os.Open(p)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(w, f)
+	return err
+}
+
+type extractor struct {
+	root    string
+	opts    *options
+	written int64
+}
+
+func newExtractor(destDir string, o *options) *extractor {
+	return &extractor{root: filepath.Clean(destDir), opts: o}
+}
+
+// target resolves name inside the destination, rejecting absolute paths and "..".
+func (e *extractor) target(name string) (string, error) {
+	clean := path.Clean(strings.ReplaceAll(name, `\`, "/"))
+	if path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") {
+		return "", fmt.Errorf("%w: %s", ErrUnsafePath, name)
+	}
+
+	p := filepath.Join(e.root, filepath.FromSlash(clean))
+	if p != e.root && !strings.HasPrefix(p, e.root+string(os.PathSeparator)) {
+		return "", fmt.Errorf("%w: %s", ErrUnsafePath, name)
+	}
+	return p, nil
+}
+
+func (e *extractor) mkdir(name string) error {
+	p, err := e.target(name)
+	if err != nil {
+		return err
+	}
+	rel := strings.TrimSuffix(path.Clean(name), "/")
+	if e.opts.excluded(rel) {
+		return nil
+	}
+	return CreateDir(p)
+}
+
+func (e *extractor) writeFile(name string, mode fs.FileMode, r io.Reader) error {
+	p, err := e.target(name)
+	if err != nil {
+		return err
+	}
+	rel := path.Clean(name)
+	if e.opts.excluded(rel) || e.excludedParent(rel) || !e.opts.included(rel) {
+		return nil
+	}
+
+	if err := CreateDir(filepath.Dir(p)); err != nil {
+		return err
+	}
+	perm := mode.Perm()
+	if perm == 0 {
+		perm = OwnerWrite
+	}
+	f, err := os.OpenFile(p, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+
+	if e.opts.maxSize > 0 {
+		r = io.LimitReader(r, e.opts.maxSize-e.written+1)
+	}
+	n, err := io.Copy(f, r)
+	e.written += n
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	if e.opts.maxSize > 0 && e.written > e.opts.maxSize {
+		os.Remove(p)
+		return ErrArchiveTooLarge
+	}
+	return nil
+}
+
+// excludedParent reports whether any parent directory of rel is excluded.
+func (e *extractor) excludedParent(rel string) bool {
+	for dir := path.Dir(rel); dir != "." && dir != "/"; dir = path.Dir(dir) {
+		if e.opts.excluded(dir) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,135 @@
+package filex
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func createTree(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"a.csv":          "a",
+		"b.txt":          "b",
+		"sub/c.csv":      "c",
+		"sub/d.tmp":      "d",
+		".git/config":    "git",
+		"sub/deep/e.csv": "e",
+	} {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), Full); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), OwnerWrite); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func assertFile(t *testing.T, path, want string) {
+	t.Helper()
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read %s: %v", path, err)
+	}
+	if string(got) != want {
+		t.Errorf("%s = %q, want %q", path, got, want)
+	}
+}
+
+func assertMissing(t *testing.T, path string) {
+	t.Helper()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("%s should not exist", path)
+	}
+}
+
+func TestZipUnzip(t *testing.T) {
+	src := createTree(t)
+	out := t.TempDir()
+	archive := filepath.Join(out, "nested", "out.zip")
+
+	if err := Zip(src, archive, WithExclude(".git", "*.tmp")); err != nil {
+		t.Fatalf("Zip: %v", err)
+	}
+
+	dest := filepath.Join(out, "extract")
+	if err := Unzip(archive, dest); err != nil {
+		t.Fatalf("Unzip: %v", err)
+	}
+	assertFile(t, filepath.Join(dest, "a.csv"), "a")
+	assertFile(t, filepath.Join(dest, "sub", "deep", "e.csv"), "e")
+	assertMissing(t, filepath.Join(dest, "sub", "d.tmp"))
+	assertMissing(t, filepath.Join(dest, ".git"))
+}
+
+func TestTarGzUntarGz_Include(t *testing.T) {
+	src := createTree(t)
+	var buf bytes.Buffer
+	if err := TarGzTo(&buf, src, WithInclude("*.csv")); err != nil {
+		t.Fatalf("TarGzTo: %v", err)
+	}
+
+	dest := t.TempDir()
+	if err := UntarGzFrom(&buf, dest); err != nil {
+		t.Fatalf("UntarGzFrom: %v", err)
+	}
+	assertFile(t, filepath.Join(dest, "sub", "c.csv"), "c")
+	assertFile(t, filepath.Join(dest, "sub", "deep", "e.csv"), "e")
+	assertMissing(t, filepath.Join(dest, "b.txt"))
+	assertMissing(t, filepath.Join(dest, ".git"))
+}
+
+func TestUnzip_PathTraversal(t *testing.T) {
+	dir := t.TempDir()
+	archive := filepath.Join(dir, "evil.zip")
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, _ := zw.Create("../../evil.txt")
+	w.Write([]byte("pwned"))
+	zw.Close()
+	if err := os.WriteFile(archive, buf.Bytes(), OwnerWrite); err != nil {
+		t.Fatal(err)
+	}
+
+	err := Unzip(archive, filepath.Join(dir, "out"))
+	if !errors.Is(err, ErrUnsafePath) {
+		t.Fatalf("Unzip err = %v, want ErrUnsafePath", err)
+	}
+	assertMissing(t, filepath.Join(filepath.Dir(dir), "evil.txt"))
+}
+
+func TestUntarGz_PathTraversalAndSize(t *testing.T) {
+	build := func(name, content string) *bytes.Buffer {
+		var buf bytes.Buffer
+		gw := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(gw)
+		tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg})
+		tw.Write([]byte(content))
+		tw.Close()
+		gw.Close()
+		return &buf
+	}
+
+	dest := t.TempDir()
+	if err := UntarGzFrom(build("/etc/passwd", "x"), dest); !errors.Is(err, ErrUnsafePath) {
+		t.Errorf("absolute path err = %v, want ErrUnsafePath", err)
+	}
+	if err := UntarGzFrom(build("a/../../x", "x"), dest); !errors.Is(err, ErrUnsafePath) {
+		t.Errorf("dotdot err = %v, want ErrUnsafePath", err)
+	}
+
+	err := UntarGzFrom(build("big.bin", "0123456789"), dest, WithMaxSize(5))
+	if !errors.Is(err, ErrArchiveTooLarge) {
+		t.Errorf("max size err = %v, want ErrArchiveTooLarge", err)
+	}
+	assertMissing(t, filepath.Join(dest, "big.bin"))
+}
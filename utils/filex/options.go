@@ -0,0 +1,117 @@
+package filex
+
+import (
+	"path"
+	"strings"
+	"time"
+)
+
+// Option configures the archive, walk and watch helpers.
+type Option func(*options)
+
+type options struct {
+	include   []string
+	exclude   []string
+	maxSize   int64
+	maxDepth  int
+	debounce  time.Duration
+	recursive bool
+}
+
+// WithInclude only keeps entries matching at least one glob.
+// Globs use path.Match syntax plus "**" for any number of directories, and are
+// matched against both the slash-separated relative path and the base name
+// (e.g. "*.csv", "reports/*", "**/certs/*.pem").
+func WithInclude(globs ...string) Option {
+	return func(o *options) { o.include = append(o.include, globs...) }
+}
+
+// WithExclude drops entries matching any glob. Exclude wins over include.
+// A directory that matches is skipped entirely.
+func WithExclude(globs ...string) Option {
+	return func(o *options) { o.exclude = append(o.exclude, globs...) }
+}
+
+// WithMaxSize limits the total number of bytes written during extraction
+// (protects against decompression bombs). Zero means unlimited.
+func WithMaxSize(n int64) Option {
+	return func(o *options) { o.maxSize = n }
+}
+
+// WithMaxDepth limits how deep Walk and FindFiles descend; files directly
+// in the root are depth 1. Zero means unlimited.
+func WithMaxDepth(n int) Option {
+	return func(o *options) { o.maxDepth = n }
+}
+
+// WithDebounce sets how long Watch waits for events to settle before calling the handler.
+func WithDebounce(d time.Duration) Option {
+	return func(o *options) { o.debounce = d }
+}
+
+// WithRecursive makes Watch also watch subdirectories, including ones created later.
+func WithRecursive() Option {
+	return func(o *options) { o.recursive = true }
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{debounce: 100 * time.Millisecond}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+func (o *options) excluded(rel string) bool {
+	return matchAny(o.exclude, rel)
+}
+
+func (o *options) included(rel string) bool {
+	if len(o.include) == 0 {
+		return true
+	}
+	return matchAny(o.include, rel)
+}
+
+func matchAny(globs []string, rel string) bool {
+	base := path.Base(rel)
+	for _, g := range globs {
+		if Match(g, rel) || Match(g, base) {
+			return true
+		}
+	}
+	return false
+}
+
+// Match reports whether the slash-separated name matches pattern.
+// It behaves like path.Match, except that a "**" segment matches
+// zero or more directories.
+//
+// Example:
+//
+//	Match("**/*.pem", "certs/prod/tls.pem") // true
+//	Match("logs/*.log", "logs/app.log")     // true
+func Match(pattern, name string) bool {
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
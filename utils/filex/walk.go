@@ -0,0 +1,69 @@
+package filex
+
+import (
+	"io/fs"
+	"path/filepath"
+	"strings"
+)
+
+// WalkFunc is called by Walk for each matching file. path includes root.
+type WalkFunc func(path string, d fs.DirEntry) error
+
+// Walk calls fn for every regular file under root that passes the
+// include/exclude filters, in lexical order. Excluded directories are not descended into.
+// Returning filepath.SkipAll from fn stops the walk without an error.
+//
+// Example:
+//
+//	err := filex.Walk("./data", func(path string, d fs.DirEntry) error {
+//		fmt.Println(path)
+//		return nil
+//	}, filex.WithInclude("*.json"), filex.WithExclude("node_modules", ".*"))
+func Walk(root string, fn WalkFunc, opts ...Option) error {
+	o := newOptions(opts)
+
+	return filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+		depth := strings.Count(rel, "/") + 1
+
+		if d.IsDir() {
+			if o.excluded(rel) || (o.maxDepth > 0 && depth >= o.maxDepth) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() || o.excluded(rel) || !o.included(rel) {
+			return nil
+		}
+		return fn(p, d)
+	})
+}
+
+// FindFiles returns the paths of all regular files under root matching pattern
+// (see Match; patterns without "/" match the base name at any depth).
+//
+// Example:
+//
+//	certs, err := filex.FindFiles("/etc/app", "**/*.pem")
+func FindFiles(root, pattern string, opts ...Option) ([]string, error) {
+	var files []string
+	opts = append(opts, WithInclude(pattern))
+	err := Walk(root, func(p string, _ fs.DirEntry) error {
+		files = append(files, p)
+		return nil
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
@@ -0,0 +1,168 @@
+package filex
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+func relPaths(t *testing.T, root string, paths []string) []string {
+	t.Helper()
+	out := make([]string, 0, len(paths))
+	for _, p := range paths {
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out = append(out, filepath.ToSlash(rel))
+	}
+	sort.Strings(out)
+	return out
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern, name string
+		want          bool
+	}{
+		{"*.pem", "tls.pem", true},
+		{"*.pem", "certs/tls.pem", false},
+		{"**/*.pem", "tls.pem", true},
+		{"**/*.pem", "certs/prod/tls.pem", true},
+		{"certs/**", "certs/a/b", true},
+		{"certs/**/b", "certs/b", true},
+		{"logs/*.log", "logs/app.log", true},
+		{"logs/*.log", "logs/old/app.log", false},
+	}
+	for _, tt := range tests {
+		if got := Match(tt.pattern, tt.name); got != tt.want {
+			t.Errorf("Match(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestWalk(t *testing.T) {
+	root := createTree(t)
+
+	var got []string
+	err := Walk(root, func(p string, _ fs.DirEntry) error {
+		got = append(got, p)
+		return nil
+	}, WithExclude(".git", "*.tmp"))
+	if err != nil {
+		t.Fatalf("Walk: %v", err)
+	}
+	want := []string{"a.csv", "b.txt", "sub/c.csv", "sub/deep/e.csv"}
+	if r := relPaths(t, root, got); !reflect.DeepEqual(r, want) {
+		t.Errorf("Walk = %v, want %v", r, want)
+	}
+
+	got = nil
+	err = Walk(root, func(p string, _ fs.DirEntry) error {
+		got = append(got, p)
+		return nil
+	}, WithMaxDepth(1))
+	if err != nil {
+		t.Fatalf("Walk depth: %v", err)
+	}
+	if r := relPaths(t, root, got); !reflect.DeepEqual(r, []string{"a.csv", "b.txt"}) {
+		t.Errorf("Walk depth = %v", r)
+	}
+}
+
+func TestFindFiles(t *testing.T) {
+	root := createTree(t)
+
+	files, err := FindFiles(root, "*.csv")
+	if err != nil {
+		t.Fatalf("FindFiles: %v", err)
+	}
+	want := []string{"a.csv", "sub/c.csv", "sub/deep/e.csv"}
+	if r := relPaths(t, root, files); !reflect.DeepEqual(r, want) {
+		t.Errorf("FindFiles = %v, want %v", r, want)
+	}
+
+	files, err = FindFiles(root, "sub/**/*.csv")
+	if err != nil {
+		t.Fatalf("FindFiles: %v", err)
+	}
+	if r := relPaths(t, root, files); !reflect.DeepEqual(r, []string{"sub/c.csv", "sub/deep/e.csv"}) {
+		t.Errorf("FindFiles ** = %v", r)
+	}
+
+	if _, err := FindFiles(filepath.Join(root, "missing"), "*"); err == nil {
+		t.Error("expected error for missing root")
+	}
+}
+
+func TestWatch_Debounce(t *testing.T) {
+	dir := t.TempDir()
+
+	var (
+		mu    sync.Mutex
+		calls [][]Event
+	)
+	w, err := Watch(dir, func(events []Event) {
+		mu.Lock()
+		calls = append(calls, events)
+		mu.Unlock()
+	}, WithInclude("*.pem"), WithDebounce(100*time.Millisecond), WithRecursive())
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+	defer w.Close()
+
+	certs := filepath.Join(dir, "certs")
+	if err := os.Mkdir(certs, Full); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(50 * time.Millisecond) // let the new directory be registered
+
+	for i := 0; i < 3; i++ {
+		createTempFile(t, certs, "tls.pem", "v")
+		createTempFile(t, dir, "ignored.txt", "x")
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		mu.Lock()
+		n := len(calls)
+		mu.Unlock()
+		if n > 0 {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	time.Sleep(200 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(calls) != 1 {
+		t.Fatalf("handler called %d times, want 1: %v", len(calls), calls)
+	}
+	if len(calls[0]) != 1 || calls[0][0].Path != filepath.Join(certs, "tls.pem") {
+		t.Errorf("events = %v", calls[0])
+	}
+}
+
+func TestWatch_Close(t *testing.T) {
+	w, err := Watch(t.TempDir(), func([]Event) {})
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Errorf("Close: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Errorf("second Close: %v", err)
+	}
+
+	if _, err := Watch(filepath.Join(t.TempDir(), "missing"), func([]Event) {}); err == nil {
+		t.Error("expected error for missing dir")
+	}
+}
@@ -0,0 +1,212 @@
+package filex
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/BevisDev/godev/utils/console"
+	"github.com/fsnotify/fsnotify"
+)
+
+// Event is a file change reported by Watch. Op may combine several
+// operations (e.g. Create|Write) when they happened within the debounce window.
+type Event struct {
+	Path string
+	Op   fsnotify.Op
+}
+
+// WatchHandler receives the events collected during one debounce window,
+// in the order the paths were first seen.
+type WatchHandler func(events []Event)
+
+// Watcher watches a directory and delivers debounced change events.
+type Watcher struct {
+	root    string
+	opts    *options
+	handler WatchHandler
+	fw      *fsnotify.Watcher
+	log     *console.Logger
+
+	mu      sync.Mutex
+	pending map[string]fsnotify.Op
+	order   []string
+	timer   *time.Timer
+	closed  bool
+
+	handlerMu sync.Mutex
+	done      chan struct{}
+}
+
+// Watch starts watching dir and calls handler with batched events once no
+// new event has arrived for the debounce period (WithDebounce, default 100ms).
+// Include/exclude filters are matched against paths relative to dir.
+//
+// To watch a single file (e.g. a certificate that is replaced by rename or
+// a Kubernetes symlink swap), watch its directory and include the file name.
+//
+// Example:
+//
+//	w, err := filex.Watch("/etc/app/certs", func(events []filex.Event) {
+//		reloadCertificates()
+//	}, filex.WithInclude("*.pem"), filex.WithDebounce(500*time.Millisecond))
+//	if err != nil {
+//		return err
+//	}
+//	defer w.Close()
+func Watch(dir string, handler WatchHandler, opts ...Option) (*Watcher, error) {
+	if handler == nil {
+		return nil, errors.New("watch handler is nil")
+	}
+
+	fw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Watcher{
+		root:    filepath.Clean(dir),
+		opts:    newOptions(opts),
+		handler: handler,
+		fw:      fw,
+		log:     console.New("filex"),
+		pending: make(map[string]fsnotify.Op),
+		done:    make(chan struct{}),
+	}
+
+	if err := w.add(w.root); err != nil {
+		fw.Close()
+		return nil, err
+	}
+
+	go w.loop()
+	return w, nil
+}
+
+// Close stops watching and drops any pending events.
+// It waits for a running handler to return.
+func (w *Watcher) Close() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil
+	}
+	w.closed = true
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+	w.mu.Unlock()
+
+	err := w.fw.Close()
+	<-w.done
+
+	w.handlerMu.Lock()
+	defer w.handlerMu.Unlock()
+	return err
+}
+
+// add watches dir and, in recursive mode, every non-excluded subdirectory.
+func (w *Watcher) add(dir string) error {
+	if !w.opts.recursive {
+		return w.fw.Add(dir)
+	}
+	return filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if rel, ok := w.rel(p); ok && rel != "." && w.opts.excluded(rel) {
+			return filepath.SkipDir
+		}
+		return w.fw.Add(p)
+	})
+}
+
+func (w *Watcher) loop() {
+	defer close(w.done)
+
+	for {
+		select {
+		case ev, ok := <-w.fw.Events:
+			if !ok {
+				return
+			}
+			w.handle(ev)
+
+		case err, ok := <-w.fw.Errors:
+			if !ok {
+				return
+			}
+			w.log.Error("watch %s: %v", w.root, err)
+		}
+	}
+}
+
+func (w *Watcher) handle(ev fsnotify.Event) {
+	rel, ok := w.rel(ev.Name)
+	if !ok || w.opts.excluded(rel) {
+		return
+	}
+
+	if w.opts.recursive && ev.Has(fsnotify.Create) && IsDir(ev.Name) {
+		if err := w.add(ev.Name); err != nil {
+			w.log.Error("watch %s: %v", ev.Name, err)
+		}
+	}
+	if !w.opts.included(rel) {
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return
+	}
+
+	if _, seen := w.pending[ev.Name]; !seen {
+		w.order = append(w.order, ev.Name)
+	}
+	w.pending[ev.Name] |= ev.Op
+
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+	w.timer = time.AfterFunc(w.opts.debounce, w.flush)
+}
+
+func (w *Watcher) flush() {
+	w.handlerMu.Lock()
+	defer w.handlerMu.Unlock()
+
+	w.mu.Lock()
+	if w.closed || len(w.order) == 0 {
+		w.mu.Unlock()
+		return
+	}
+	events := make([]Event, 0, len(w.order))
+	for _, p := range w.order {
+		events = append(events, Event{Path: p, Op: w.pending[p]})
+	}
+	w.pending = make(map[string]fsnotify.Op)
+	w.order = nil
+	w.mu.Unlock()
+
+	defer func() {
+		if r := recover(); r != nil {
+			w.log.Error("[RECOVER] watch handler panic: %v", r)
+		}
+	}()
+	w.handler(events)
+}
+
+func (w *Watcher) rel(p string) (string, bool) {
+	rel, err := filepath.Rel(w.root, p)
+	if err != nil {
+		return "", false
+	}
+	return filepath.ToSlash(rel), true
+}
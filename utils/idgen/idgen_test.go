@@ -0,0 +1,158 @@
+package idgen
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestULID_RoundTrip(t *testing.T) {
+	now := time.Now().Truncate(time.Millisecond)
+	u := MakeULID(now)
+
+	s := u.String()
+	assert.Len(t, s, 26)
+
+	parsed, err := ParseULID(s)
+	require.NoError(t, err)
+	assert.Equal(t, u, parsed)
+	assert.True(t, now.Equal(parsed.Time()))
+
+	lower, err := ParseULID(toLower(s))
+	require.NoError(t, err)
+	assert.Equal(t, u, lower)
+}
+
+func toLower(s string) string {
+	b := []byte(s)
+	for i, c := range b {
+		if c >= 'A' && c <= 'Z' {
+			b[i] = c + 32
+		}
+	}
+	return string(b)
+}
+
+func TestULID_Monotonic(t *testing.T) {
+	ids := make([]string, 1000)
+	for i := range ids {
+		ids[i] = NewULID()
+	}
+	assert.True(t, sort.StringsAreSorted(ids))
+
+	seen := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		seen[id] = struct{}{}
+	}
+	assert.Len(t, seen, len(ids))
+}
+
+func TestParseULID_Invalid(t *testing.T) {
+	for _, s := range []string{"", "short", "8ZZZZZZZZZZZZZZZZZZZZZZZZZ", "01HRZ8J3Q7K6W2S1N0B9X4V5YU"} {
+		_, err := ParseULID(s)
+		assert.ErrorIs(t, err, ErrInvalidULID, s)
+	}
+	assert.True(t, IsULID("01HRZ8J3Q7K6W2S1N0B9X4V5YT"))
+}
+
+func TestSnowflake_UniqueAndOrdered(t *testing.T) {
+	sf, err := NewSnowflake(7)
+	require.NoError(t, err)
+
+	const n = 5000
+	ids := make([]int64, n)
+	for i := range ids {
+		ids[i] = sf.Next()
+	}
+	for i := 1; i < n; i++ {
+		require.Greater(t, ids[i], ids[i-1])
+	}
+
+	parts := sf.Decompose(ids[0])
+	assert.Equal(t, int64(7), parts.NodeID)
+	assert.WithinDuration(t, time.Now(), parts.Time, time.Second)
+}
+
+func TestSnowflake_Concurrent(t *testing.T) {
+	sf, err := NewSnowflake(1)
+	require.NoError(t, err)
+
+	var (
+		mu   sync.Mutex
+		seen = make(map[int64]struct{})
+		wg   sync.WaitGroup
+	)
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				id := sf.Next()
+				mu.Lock()
+				seen[id] = struct{}{}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Len(t, seen, 4000)
+}
+
+func TestSnowflake_ClockBackwards(t *testing.T) {
+	sf, err := NewSnowflake(1)
+	require.NoError(t, err)
+
+	base := time.Now()
+	var offset atomic.Int64
+	sf.now = func() time.Time { return base.Add(time.Duration(offset.Load())) }
+	first := sf.Next()
+
+	// clock jumps back, then recovers shortly after
+	offset.Store(int64(-2 * time.Millisecond))
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		offset.Store(int64(time.Millisecond))
+	}()
+
+	second := sf.Next()
+	assert.Greater(t, second, first)
+}
+
+func TestNewSnowflake_InvalidNode(t *testing.T) {
+	_, err := NewSnowflake(-1)
+	assert.ErrorIs(t, err, ErrInvalidNodeID)
+	_, err = NewSnowflake(MaxNodeID + 1)
+	assert.ErrorIs(t, err, ErrInvalidNodeID)
+}
+
+func TestNodeIDFromHostname(t *testing.T) {
+	id, err := NodeIDFromHostname()
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, id, int64(0))
+	assert.LessOrEqual(t, id, int64(MaxNodeID))
+}
+
+func TestPrefixed(t *testing.T) {
+	id := NewPrefixed("ord")
+	assert.True(t, HasPrefix(id, "ord"))
+	assert.False(t, HasPrefix(id, "usr"))
+
+	prefix, u, err := ParsePrefixed(id)
+	require.NoError(t, err)
+	assert.Equal(t, "ord", prefix)
+	assert.WithinDuration(t, time.Now(), u.Time(), time.Second)
+
+	prefix, _, err = ParsePrefixed("line_item_" + NewULID())
+	require.NoError(t, err)
+	assert.Equal(t, "line_item", prefix)
+
+	_, _, err = ParsePrefixed("nope")
+	assert.ErrorIs(t, err, ErrInvalidPrefixedID)
+	_, _, err = ParsePrefixed("ord_notaulid")
+	assert.ErrorIs(t, err, ErrInvalidPrefixedID)
+}
@@ -0,0 +1,43 @@
+package idgen
+
+import (
+	"errors"
+	"strings"
+)
+
+// prefixSep separates the prefix from the ULID part ("ord_01h...").
+const prefixSep = "_"
+
+// ErrInvalidPrefixedID is returned when a prefixed ID cannot be parsed.
+var ErrInvalidPrefixedID = errors.New("invalid prefixed id")
+
+// NewPrefixed returns a type-prefixed, sortable ID such as "ord_01hrz8j3q7k6w2s1n0b9x4v5yt".
+// The ULID part is lower-cased for readability in URLs and logs.
+//
+// Example:
+//
+//	orderID := NewPrefixed("ord")
+//	userID := NewPrefixed("usr")
+func NewPrefixed(prefix string) string {
+	return prefix + prefixSep + strings.ToLower(NewULID())
+}
+
+// ParsePrefixed splits a prefixed ID into its prefix and ULID.
+func ParsePrefixed(id string) (string, ULID, error) {
+	i := strings.LastIndex(id, prefixSep)
+	if i <= 0 {
+		return "", ULID{}, ErrInvalidPrefixedID
+	}
+
+	u, err := ParseULID(id[i+1:])
+	if err != nil {
+		return "", ULID{}, ErrInvalidPrefixedID
+	}
+	return id[:i], u, nil
+}
+
+// HasPrefix reports whether id is a valid prefixed ID with the given prefix.
+func HasPrefix(id, prefix string) bool {
+	p, _, err := ParsePrefixed(id)
+	return err == nil && p == prefix
+}
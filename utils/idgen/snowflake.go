@@ -0,0 +1,160 @@
+package idgen
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	nodeBits     = 10
+	sequenceBits = 12
+
+	// MaxNodeID is the largest node ID a Snowflake generator accepts.
+	MaxNodeID = 1<<nodeBits - 1
+
+	maxSequence = 1<<sequenceBits - 1
+	timeShift   = nodeBits + sequenceBits
+)
+
+// DefaultEpoch is the custom epoch (2024-01-01 UTC) used for Snowflake timestamps,
+// giving roughly 69 years of IDs from that date.
+var DefaultEpoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// ErrInvalidNodeID is returned when a node ID is outside [0, MaxNodeID].
+var ErrInvalidNodeID = fmt.Errorf("node id must be between 0 and %d", MaxNodeID)
+
+// Snowflake generates 63-bit, time-ordered IDs:
+// 41 bits of milliseconds since epoch | 10 bits node ID | 12 bits sequence.
+//
+// Each process must use a distinct node ID; see NodeIDFromIP and NodeIDFromHostname.
+type Snowflake struct {
+	mu       sync.Mutex
+	epoch    time.Time
+	node     int64
+	lastMs   int64
+	sequence int64
+	now      func() time.Time
+}
+
+// NewSnowflake creates a generator for nodeID using DefaultEpoch.
+func NewSnowflake(nodeID int64) (*Snowflake, error) {
+	return NewSnowflakeWithEpoch(nodeID, DefaultEpoch)
+}
+
+// NewSnowflakeWithEpoch creates a generator for nodeID with a custom epoch.
+func NewSnowflakeWithEpoch(nodeID int64, epoch time.Time) (*Snowflake, error) {
+	if nodeID < 0 || nodeID > MaxNodeID {
+		return nil, ErrInvalidNodeID
+	}
+	return &Snowflake{
+		epoch: epoch,
+		node:  nodeID,
+		now:   time.Now,
+	}, nil
+}
+
+// NodeID returns the generator's node ID.
+func (s *Snowflake) NodeID() int64 {
+	return s.node
+}
+
+// Next returns the next ID. It blocks for up to a millisecond when the sequence
+// is exhausted, and waits out small clock regressions instead of issuing duplicates.
+func (s *Snowflake) Next() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ms := s.elapsed()
+	if ms < s.lastMs {
+		// clock moved backwards: wait until it catches up
+		time.Sleep(time.Duration(s.lastMs-ms) * time.Millisecond)
+		ms = s.waitAfter(s.lastMs - 1)
+	}
+
+	if ms == s.lastMs {
+		s.sequence = (s.sequence + 1) & maxSequence
+		if s.sequence == 0 {
+			ms = s.waitAfter(s.lastMs)
+		}
+	} else {
+		s.sequence = 0
+	}
+	s.lastMs = ms
+
+	return ms<<timeShift | s.node<<sequenceBits | s.sequence
+}
+
+func (s *Snowflake) elapsed() int64 {
+	return s.now().Sub(s.epoch).Milliseconds()
+}
+
+func (s *Snowflake) waitAfter(ms int64) int64 {
+	cur := s.elapsed()
+	for cur <= ms {
+		time.Sleep(100 * time.Microsecond)
+		cur = s.elapsed()
+	}
+	return cur
+}
+
+// SnowflakeParts is the decoded form of a Snowflake ID.
+type SnowflakeParts struct {
+	Time     time.Time
+	NodeID   int64
+	Sequence int64
+}
+
+// Decompose splits an ID produced by this generator into its parts.
+func (s *Snowflake) Decompose(id int64) SnowflakeParts {
+	return DecomposeSnowflake(id, s.epoch)
+}
+
+// DecomposeSnowflake splits id into its parts using epoch.
+func DecomposeSnowflake(id int64, epoch time.Time) SnowflakeParts {
+	return SnowflakeParts{
+		Time:     epoch.Add(time.Duration(id>>timeShift) * time.Millisecond),
+		NodeID:   (id >> sequenceBits) & MaxNodeID,
+		Sequence: id & maxSequence,
+	}
+}
+
+// NodeIDFromIP derives a node ID from the low 10 bits of the first private IPv4 address.
+// IDs are unique as long as all nodes live in the same /22 (or smaller) network,
+// which is the usual case for pods or VMs in one subnet.
+func NodeIDFromIP() (int64, error) {
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return 0, err
+	}
+
+	for _, a := range addrs {
+		ipNet, ok := a.(*net.IPNet)
+		if !ok {
+			continue
+		}
+		ip := ipNet.IP.To4()
+		if ip == nil || ip.IsLoopback() || !ip.IsPrivate() {
+			continue
+		}
+		return (int64(ip[2])<<8 | int64(ip[3])) & MaxNodeID, nil
+	}
+	return 0, errors.New("no private ipv4 address found")
+}
+
+// NodeIDFromHostname derives a node ID by hashing the hostname.
+// Collisions are possible; prefer NodeIDFromIP or an explicit ID from config when
+// running more than a handful of nodes.
+func NodeIDFromHostname() (int64, error) {
+	host, err := os.Hostname()
+	if err != nil {
+		return 0, err
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(host))
+	return int64(h.Sum32()) & MaxNodeID, nil
+}
@@ -0,0 +1,127 @@
+package idgen
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+// crockford is the Crockford base32 alphabet used by ULID.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ulidLen is the length of the canonical ULID string.
+const ulidLen = 26
+
+// ErrInvalidULID is returned when a string is not a valid ULID.
+var ErrInvalidULID = errors.New("invalid ulid")
+
+// ULID is a 128-bit lexicographically sortable identifier:
+// 48 bits of Unix milliseconds followed by 80 random bits.
+type ULID [16]byte
+
+var (
+	ulidMu   sync.Mutex
+	lastMs   uint64
+	lastRand [10]byte
+)
+
+// NewULID returns a new ULID as a 26-character string.
+// IDs created within the same millisecond are monotonically increasing.
+//
+// Example:
+//
+//	id := NewULID() // "01HRZ8J3Q7K6W2S1N0B9X4V5YT"
+func NewULID() string {
+	return MakeULID(time.Now()).String()
+}
+
+// MakeULID returns a ULID for t. Calls within the same millisecond increment
+// the random part so ordering is preserved inside one process.
+func MakeULID(t time.Time) ULID {
+	ms := uint64(t.UnixMilli())
+
+	ulidMu.Lock()
+	defer ulidMu.Unlock()
+
+	if ms == lastMs {
+		incr(lastRand[:])
+	} else {
+		_, _ = rand.Read(lastRand[:])
+		lastMs = ms
+	}
+
+	var u ULID
+	u[0] = byte(ms >> 40)
+	u[1] = byte(ms >> 32)
+	binary.BigEndian.PutUint32(u[2:6], uint32(ms))
+	copy(u[6:], lastRand[:])
+	return u
+}
+
+// incr adds one to a big-endian byte slice, wrapping on overflow.
+func incr(b []byte) {
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i]++
+		if b[i] != 0 {
+			return
+		}
+	}
+}
+
+// Time returns the timestamp encoded in u.
+func (u ULID) Time() time.Time {
+	ms := uint64(u[0])<<40 | uint64(u[1])<<32 | uint64(binary.BigEndian.Uint32(u[2:6]))
+	return time.UnixMilli(int64(ms))
+}
+
+// String returns the canonical Crockford base32 representation.
+func (u ULID) String() string {
+	var out [ulidLen]byte
+
+	// 128 bits encoded as 26 chars of 5 bits; the first char holds the top 3 bits.
+	hi := binary.BigEndian.Uint64(u[:8])
+	lo := binary.BigEndian.Uint64(u[8:])
+	for i := ulidLen - 1; i >= 0; i-- {
+		out[i] = crockford[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(out[:])
+}
+
+// ParseULID decodes a canonical (case-insensitive) ULID string.
+func ParseULID(s string) (ULID, error) {
+	var u ULID
+	if len(s) != ulidLen {
+		return u, ErrInvalidULID
+	}
+
+	s = strings.ToUpper(s)
+	if s[0] > '7' {
+		// first char may only carry 3 bits
+		return u, ErrInvalidULID
+	}
+
+	var hi, lo uint64
+	for i := 0; i < ulidLen; i++ {
+		v := strings.IndexByte(crockford, s[i])
+		if v < 0 {
+			return u, ErrInvalidULID
+		}
+		hi = hi<<5 | lo>>59
+		lo = lo<<5 | uint64(v)
+	}
+
+	binary.BigEndian.PutUint64(u[:8], hi)
+	binary.BigEndian.PutUint64(u[8:], lo)
+	return u, nil
+}
+
+// IsULID reports whether s is a valid ULID string.
+func IsULID(s string) bool {
+	_, err := ParseULID(s)
+	return err == nil
+}
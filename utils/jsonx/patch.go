@@ -0,0 +1,504 @@
+package jsonx
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// JSON Patch operation names (RFC 6902).
+const (
+	OpAdd     = "add"
+	OpRemove  = "remove"
+	OpReplace = "replace"
+	OpMove    = "move"
+	OpCopy    = "copy"
+	OpTest    = "test"
+)
+
+var (
+	// ErrInvalidPointer is returned when a JSON Pointer (RFC 6901) is malformed.
+	ErrInvalidPointer = errors.New("invalid json pointer")
+
+	// ErrTestFailed is returned when a "test" operation does not match.
+	ErrTestFailed = errors.New("json patch test failed")
+)
+
+// Operation is a single RFC 6902 JSON Patch operation.
+type Operation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// Patch is an ordered list of JSON Patch operations.
+type Patch []Operation
+
+// Diff compares two JSON documents and returns an RFC 6902 patch that turns a into b.
+//
+// Objects are compared key by key (in sorted order for stable output).
+// Arrays are compared index by index; extra elements are removed from the end
+// and new elements are appended.
+func Diff(a, b []byte) (Patch, error) {
+	left, err := decodeNode(a)
+	if err != nil {
+		return nil, err
+	}
+	right, err := decodeNode(b)
+	if err != nil {
+		return nil, err
+	}
+
+	var patch Patch
+	if err := diffNode("", left, right, &patch); err != nil {
+		return nil, err
+	}
+	return patch, nil
+}
+
+func diffNode(path string, a, b any, patch *Patch) error {
+	if jsonEqual(a, b) {
+		return nil
+	}
+
+	switch av := a.(type) {
+	case map[string]any:
+		bv, ok := b.(map[string]any)
+		if !ok {
+			break
+		}
+		for _, k := range sortedKeys(av) {
+			child := path + "/" + escapePointer(k)
+			if _, ok := bv[k]; !ok {
+				*patch = append(*patch, Operation{Op: OpRemove, Path: child})
+				continue
+			}
+			if err := diffNode(child, av[k], bv[k], patch); err != nil {
+				return err
+			}
+		}
+		for _, k := range sortedKeys(bv) {
+			if _, ok := av[k]; ok {
+				continue
+			}
+			if err := appendValueOp(patch, OpAdd, path+"/"+escapePointer(k), bv[k]); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case []any:
+		bv, ok := b.([]any)
+		if !ok {
+			break
+		}
+		n := min(len(av), len(bv))
+		for i := 0; i < n; i++ {
+			if err := diffNode(path+"/"+strconv.Itoa(i), av[i], bv[i], patch); err != nil {
+				return err
+			}
+		}
+		for i := len(av) - 1; i >= n; i-- {
+			*patch = append(*patch, Operation{Op: OpRemove, Path: path + "/" + strconv.Itoa(i)})
+		}
+		for i := n; i < len(bv); i++ {
+			if err := appendValueOp(patch, OpAdd, path+"/-", bv[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return appendValueOp(patch, OpReplace, path, b)
+}
+
+func appendValueOp(patch *Patch, op, path string, v any) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	*patch = append(*patch, Operation{Op: op, Path: path, Value: raw})
+	return nil
+}
+
+// ApplyPatch applies an RFC 6902 patch to doc and returns the resulting document.
+// Operations are applied in order; the first failing operation aborts the patch.
+func ApplyPatch(doc []byte, patch Patch) ([]byte, error) {
+	node, err := decodeNode(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, op := range patch {
+		node, err = applyOperation(node, op)
+		if err != nil {
+			return nil, fmt.Errorf("operation %d (%s %s): %w", i, op.Op, op.Path, err)
+		}
+	}
+
+	return json.Marshal(node)
+}
+
+// ApplyPatchJSON decodes a JSON-encoded patch and applies it to doc.
+func ApplyPatchJSON(doc, patch []byte) ([]byte, error) {
+	p, err := FromJSONBytes[Patch](patch)
+	if err != nil {
+		return nil, err
+	}
+	return ApplyPatch(doc, p)
+}
+
+func applyOperation(node any, op Operation) (any, error) {
+	tokens, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case OpAdd, OpReplace, OpTest:
+		if op.Value == nil {
+			return nil, errors.New("missing value")
+		}
+		value, err := decodeNode(op.Value)
+		if err != nil {
+			return nil, err
+		}
+		switch op.Op {
+		case OpAdd:
+			return mutate(node, tokens, value, addValue)
+		case OpReplace:
+			return mutate(node, tokens, value, replaceValue)
+		default:
+			cur, err := lookup(node, tokens)
+			if err != nil {
+				return nil, err
+			}
+			if !jsonEqual(cur, value) {
+				return nil, ErrTestFailed
+			}
+			return node, nil
+		}
+
+	case OpRemove:
+		if len(tokens) == 0 {
+			return nil, errors.New("cannot remove document root")
+		}
+		return mutate(node, tokens, nil, removeValue)
+
+	case OpMove, OpCopy:
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		value, err := lookup(node, from)
+		if err != nil {
+			return nil, err
+		}
+		if op.Op == OpMove {
+			if strings.HasPrefix(op.Path+"/", op.From+"/") && op.Path != op.From {
+				return nil, errors.New("cannot move a value into its own child")
+			}
+			node, err = mutate(node, from, nil, removeValue)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			raw, err := json.Marshal(value)
+			if err != nil {
+				return nil, err
+			}
+			if value, err = decodeNode(raw); err != nil {
+				return nil, err
+			}
+		}
+		return mutate(node, tokens, value, addValue)
+
+	default:
+		return nil, fmt.Errorf("unsupported operation %q", op.Op)
+	}
+}
+
+// containerOp applies a change to the last token of a pointer inside its parent container.
+type containerOp func(parent any, token string, value any) (any, error)
+
+// mutate walks tokens and applies fn at the last step, rebuilding parents
+// along the way since slices cannot be modified in place.
+func mutate(node any, tokens []string, value any, fn containerOp) (any, error) {
+	if len(tokens) == 0 {
+		return value, nil
+	}
+	if len(tokens) == 1 {
+		return fn(node, tokens[0], value)
+	}
+
+	child, err := child(node, tokens[0])
+	if err != nil {
+		return nil, err
+	}
+	updated, err := mutate(child, tokens[1:], value, fn)
+	if err != nil {
+		return nil, err
+	}
+
+	switch c := node.(type) {
+	case map[string]any:
+		c[tokens[0]] = updated
+	case []any:
+		idx, _ := arrayIndex(tokens[0], len(c), false)
+		c[idx] = updated
+	}
+	return node, nil
+}
+
+func addValue(parent any, token string, value any) (any, error) {
+	switch c := parent.(type) {
+	case map[string]any:
+		c[token] = value
+		return c, nil
+	case []any:
+		if token == "-" {
+			return append(c, value), nil
+		}
+		idx, err := arrayIndex(token, len(c), true)
+		if err != nil {
+			return nil, err
+		}
+		c = append(c, nil)
+		copy(c[idx+1:], c[idx:])
+		c[idx] = value
+		return c, nil
+	default:
+		return nil, ErrPathNotFound
+	}
+}
+
+func replaceValue(parent any, token string, value any) (any, error) {
+	switch c := parent.(type) {
+	case map[string]any:
+		if _, ok := c[token]; !ok {
+			return nil, ErrPathNotFound
+		}
+		c[token] = value
+		return c, nil
+	case []any:
+		idx, err := arrayIndex(token, len(c), false)
+		if err != nil {
+			return nil, err
+		}
+		c[idx] = value
+		return c, nil
+	default:
+		return nil, ErrPathNotFound
+	}
+}
+
+func removeValue(parent any, token string, _ any) (any, error) {
+	switch c := parent.(type) {
+	case map[string]any:
+		if _, ok := c[token]; !ok {
+			return nil, ErrPathNotFound
+		}
+		delete(c, token)
+		return c, nil
+	case []any:
+		idx, err := arrayIndex(token, len(c), false)
+		if err != nil {
+			return nil, err
+		}
+		return append(c[:idx], c[idx+1:]...), nil
+	default:
+		return nil, ErrPathNotFound
+	}
+}
+
+func lookup(node any, tokens []string) (any, error) {
+	var err error
+	for _, tok := range tokens {
+		node, err = child(node, tok)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return node, nil
+}
+
+func child(node any, token string) (any, error) {
+	switch c := node.(type) {
+	case map[string]any:
+		v, ok := c[token]
+		if !ok {
+			return nil, ErrPathNotFound
+		}
+		return v, nil
+	case []any:
+		idx, err := arrayIndex(token, len(c), false)
+		if err != nil {
+			return nil, err
+		}
+		return c[idx], nil
+	default:
+		return nil, ErrPathNotFound
+	}
+}
+
+// arrayIndex parses an array token. When insert is true the index may equal n.
+func arrayIndex(token string, n int, insert bool) (int, error) {
+	idx, err := strconv.Atoi(token)
+	if err != nil || idx < 0 || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("%w: bad array index %q", ErrInvalidPointer, token)
+	}
+	if idx > n || (!insert && idx == n) {
+		return 0, ErrPathNotFound
+	}
+	return idx, nil
+}
+
+// parsePointer splits an RFC 6901 JSON Pointer into unescaped tokens.
+func parsePointer(p string) ([]string, error) {
+	if p == "" {
+		return nil, nil
+	}
+	if p[0] != '/' {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidPointer, p)
+	}
+
+	parts := strings.Split(p[1:], "/")
+	for i, part := range parts {
+		parts[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(part)
+	}
+	return parts, nil
+}
+
+func escapePointer(s string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(s)
+}
+
+// MergePatch applies an RFC 7386 JSON Merge Patch to doc.
+// Null values in patch delete the corresponding keys; objects are merged recursively;
+// any other value replaces the target.
+func MergePatch(doc, patch []byte) ([]byte, error) {
+	target, err := decodeNode(doc)
+	if err != nil {
+		return nil, err
+	}
+	p, err := decodeNode(patch)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(mergeNode(target, p))
+}
+
+func mergeNode(target, patch any) any {
+	p, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	t, ok := target.(map[string]any)
+	if !ok {
+		t = make(map[string]any, len(p))
+	}
+	for k, v := range p {
+		if v == nil {
+			delete(t, k)
+			continue
+		}
+		t[k] = mergeNode(t[k], v)
+	}
+	return t
+}
+
+// CreateMergePatch returns an RFC 7386 merge patch that turns a into b.
+func CreateMergePatch(a, b []byte) ([]byte, error) {
+	left, err := decodeNode(a)
+	if err != nil {
+		return nil, err
+	}
+	right, err := decodeNode(b)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(mergeDiff(left, right))
+}
+
+func mergeDiff(a, b any) any {
+	am, aok := a.(map[string]any)
+	bm, bok := b.(map[string]any)
+	if !aok || !bok {
+		return b
+	}
+
+	out := make(map[string]any)
+	for k, av := range am {
+		bv, ok := bm[k]
+		if !ok {
+			out[k] = nil
+			continue
+		}
+		if jsonEqual(av, bv) {
+			continue
+		}
+		out[k] = mergeDiff(av, bv)
+	}
+	for k, bv := range bm {
+		if _, ok := am[k]; !ok {
+			out[k] = bv
+		}
+	}
+	return out
+}
+
+// jsonEqual compares two decoded JSON trees, treating numbers by value.
+func jsonEqual(a, b any) bool {
+	switch av := a.(type) {
+	case json.Number:
+		bv, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		if av == bv {
+			return true
+		}
+		af, err1 := av.Float64()
+		bf, err2 := bv.Float64()
+		return err1 == nil && err2 == nil && af == bf
+	case map[string]any:
+		bv, ok := b.(map[string]any)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for k, v := range av {
+			w, ok := bv[k]
+			if !ok || !jsonEqual(v, w) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		bv, ok := b.([]any)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !jsonEqual(av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return a == b
+	}
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,104 @@
+package jsonx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff_RoundTrip(t *testing.T) {
+	a := []byte(`{"name":"Alice","age":30,"tags":["a","b","c"],"addr":{"city":"HN","zip":"100"},"a/b":1}`)
+	b := []byte(`{"name":"Alice","age":31,"tags":["a","x"],"addr":{"city":"HCM"},"email":"a@x.com","a/b":1}`)
+
+	patch, err := Diff(a, b)
+	require.NoError(t, err)
+	assert.NotEmpty(t, patch)
+
+	out, err := ApplyPatch(a, patch)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(b), string(out))
+}
+
+func TestDiff_Operations(t *testing.T) {
+	patch, err := Diff([]byte(`{"a":1,"b":2}`), []byte(`{"a":1,"c":3}`))
+	require.NoError(t, err)
+
+	require.Len(t, patch, 2)
+	assert.Equal(t, Operation{Op: OpRemove, Path: "/b"}, patch[0])
+	assert.Equal(t, OpAdd, patch[1].Op)
+	assert.Equal(t, "/c", patch[1].Path)
+	assert.JSONEq(t, `3`, string(patch[1].Value))
+}
+
+func TestDiff_Equal(t *testing.T) {
+	patch, err := Diff([]byte(`{"a":[1,2],"b":1.0}`), []byte(`{"b":1,"a":[1,2]}`))
+	require.NoError(t, err)
+	assert.Empty(t, patch)
+}
+
+func TestDiff_EscapesPointer(t *testing.T) {
+	patch, err := Diff([]byte(`{"a/b":1,"c~d":1}`), []byte(`{"a/b":2,"c~d":2}`))
+	require.NoError(t, err)
+	require.Len(t, patch, 2)
+	assert.Equal(t, "/a~1b", patch[0].Path)
+	assert.Equal(t, "/c~0d", patch[1].Path)
+}
+
+func TestApplyPatchJSON(t *testing.T) {
+	doc := []byte(`{"foo":"bar","list":[1,2,3],"obj":{"x":1}}`)
+	patch := []byte(`[
+		{"op":"test","path":"/foo","value":"bar"},
+		{"op":"replace","path":"/foo","value":"baz"},
+		{"op":"add","path":"/list/1","value":9},
+		{"op":"remove","path":"/list/0"},
+		{"op":"copy","from":"/obj","path":"/copy"},
+		{"op":"move","from":"/obj/x","path":"/moved"},
+		{"op":"add","path":"/nil","value":null}
+	]`)
+
+	out, err := ApplyPatchJSON(doc, patch)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"foo":"baz","list":[9,2,3],"obj":{},"copy":{"x":1},"moved":1,"nil":null}`, string(out))
+}
+
+func TestApplyPatch_Errors(t *testing.T) {
+	doc := []byte(`{"a":[1]}`)
+
+	_, err := ApplyPatch(doc, Patch{{Op: OpTest, Path: "/a/0", Value: []byte(`2`)}})
+	assert.ErrorIs(t, err, ErrTestFailed)
+
+	_, err = ApplyPatch(doc, Patch{{Op: OpRemove, Path: "/missing"}})
+	assert.ErrorIs(t, err, ErrPathNotFound)
+
+	_, err = ApplyPatch(doc, Patch{{Op: OpReplace, Path: "/a/5", Value: []byte(`1`)}})
+	assert.ErrorIs(t, err, ErrPathNotFound)
+
+	_, err = ApplyPatch(doc, Patch{{Op: OpAdd, Path: "a", Value: []byte(`1`)}})
+	assert.ErrorIs(t, err, ErrInvalidPointer)
+
+	_, err = ApplyPatch(doc, Patch{{Op: "bogus", Path: "/a"}})
+	assert.Error(t, err)
+}
+
+func TestMergePatch(t *testing.T) {
+	doc := []byte(`{"title":"Goodbye!","author":{"givenName":"John","familyName":"Doe"},"tags":["example","sample"],"content":"This will be unchanged"}`)
+	patch := []byte(`{"title":"Hello!","phoneNumber":"+01-123-456-7890","author":{"familyName":null},"tags":["example"]}`)
+
+	out, err := MergePatch(doc, patch)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"title":"Hello!","author":{"givenName":"John"},"tags":["example"],"content":"This will be unchanged","phoneNumber":"+01-123-456-7890"}`, string(out))
+}
+
+func TestCreateMergePatch(t *testing.T) {
+	a := []byte(`{"a":1,"b":{"c":2,"d":3},"e":[1]}`)
+	b := []byte(`{"a":1,"b":{"c":5},"e":[1,2],"f":true}`)
+
+	patch, err := CreateMergePatch(a, b)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"b":{"c":5,"d":null},"e":[1,2],"f":true}`, string(patch))
+
+	out, err := MergePatch(a, patch)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(b), string(out))
+}
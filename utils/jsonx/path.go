@@ -0,0 +1,221 @@
+package jsonx
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidPath is returned when a path expression cannot be parsed.
+	ErrInvalidPath = errors.New("invalid json path")
+
+	// ErrPathNotFound is returned when a path does not resolve to a value.
+	ErrPathNotFound = errors.New("json path not found")
+)
+
+// segment is one step of a parsed path: either an object key or an array index.
+type segment struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+// Get extracts the value at path from raw JSON and decodes it into type T.
+//
+// The path uses a dot-notation subset of JSONPath:
+//
+//	"data.items[0].id"
+//	"$.data.items[-1].name"   // negative index counts from the end
+//	"data[\"key.with.dots\"]" // bracket-quoted keys
+//
+// An empty path or "$" returns the whole document.
+func Get[T any](data []byte, path string) (T, error) {
+	var zero T
+
+	raw, err := GetRaw(data, path)
+	if err != nil {
+		return zero, err
+	}
+
+	return FromJSONBytes[T](raw)
+}
+
+// GetOrDefault is like Get but returns def when the path cannot be resolved or decoded.
+func GetOrDefault[T any](data []byte, path string, def T) T {
+	v, err := Get[T](data, path)
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+// GetRaw returns the raw JSON bytes of the value at path.
+func GetRaw(data []byte, path string) (json.RawMessage, error) {
+	segs, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	node, err := decodeNode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, seg := range segs {
+		node, err = step(node, seg)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", err, formatPath(segs[:i+1]))
+		}
+	}
+
+	return json.Marshal(node)
+}
+
+// Exists reports whether path resolves to a value in data (including JSON null).
+func Exists(data []byte, path string) bool {
+	_, err := GetRaw(data, path)
+	return err == nil
+}
+
+// decodeNode decodes data into a generic tree, keeping numbers as json.Number
+// so large integers survive the round trip.
+func decodeNode(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var node any
+	if err := dec.Decode(&node); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+func step(node any, seg segment) (any, error) {
+	if seg.isIndex {
+		arr, ok := node.([]any)
+		if !ok {
+			return nil, ErrPathNotFound
+		}
+		idx := seg.index
+		if idx < 0 {
+			idx += len(arr)
+		}
+		if idx < 0 || idx >= len(arr) {
+			return nil, ErrPathNotFound
+		}
+		return arr[idx], nil
+	}
+
+	obj, ok := node.(map[string]any)
+	if !ok {
+		return nil, ErrPathNotFound
+	}
+	v, ok := obj[seg.key]
+	if !ok {
+		return nil, ErrPathNotFound
+	}
+	return v, nil
+}
+
+// parsePath splits a path expression into segments.
+func parsePath(path string) ([]segment, error) {
+	p := strings.TrimSpace(path)
+	p = strings.TrimPrefix(p, "$")
+	p = strings.TrimPrefix(p, ".")
+
+	var (
+		segs []segment
+		cur  strings.Builder
+	)
+
+	flush := func() {
+		if cur.Len() > 0 {
+			segs = append(segs, segment{key: cur.String()})
+			cur.Reset()
+		}
+	}
+
+	for i := 0; i < len(p); i++ {
+		c := p[i]
+		switch c {
+		case '.':
+			if cur.Len() == 0 && (i == 0 || p[i-1] != ']') {
+				return nil, fmt.Errorf("%w: empty key in %q", ErrInvalidPath, path)
+			}
+			flush()
+		case '[':
+			flush()
+			end := closingBracket(p[i:])
+			if end < 0 {
+				return nil, fmt.Errorf("%w: unclosed bracket in %q", ErrInvalidPath, path)
+			}
+			inner := strings.TrimSpace(p[i+1 : i+end])
+			seg, err := parseBracket(inner)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %q", err, path)
+			}
+			segs = append(segs, seg)
+			i += end
+		default:
+			cur.WriteByte(c)
+		}
+	}
+	if strings.HasSuffix(p, ".") {
+		return nil, fmt.Errorf("%w: empty key in %q", ErrInvalidPath, path)
+	}
+	flush()
+
+	return segs, nil
+}
+
+// closingBracket returns the index of the ']' closing the bracket s starts with,
+// skipping a quoted key so it may contain ']', or -1.
+func closingBracket(s string) int {
+	i := 1
+	for i < len(s) && s[i] == ' ' {
+		i++
+	}
+	if i < len(s) && (s[i] == '"' || s[i] == '\'') {
+		q := strings.IndexByte(s[i+1:], s[i])
+		if q < 0 {
+			return -1
+		}
+		i += q + 2
+	}
+	if end := strings.IndexByte(s[i:], ']'); end >= 0 {
+		return i + end
+	}
+	return -1
+}
+
+func parseBracket(inner string) (segment, error) {
+	if len(inner) >= 2 {
+		q := inner[0]
+		if (q == '"' || q == '\'') && inner[len(inner)-1] == q {
+			return segment{key: inner[1 : len(inner)-1]}, nil
+		}
+	}
+
+	idx, err := strconv.Atoi(inner)
+	if err != nil {
+		return segment{}, fmt.Errorf("%w: bad index %q", ErrInvalidPath, inner)
+	}
+	return segment{index: idx, isIndex: true}, nil
+}
+
+func formatPath(segs []segment) string {
+	var sb strings.Builder
+	sb.WriteByte('$')
+	for _, s := range segs {
+		if s.isIndex {
+			sb.WriteString("[" + strconv.Itoa(s.index) + "]")
+			continue
+		}
+		sb.WriteString("." + s.key)
+	}
+	return sb.String()
+}
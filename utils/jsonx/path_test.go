@@ -0,0 +1,115 @@
+package jsonx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var pathDoc = []byte(`{
+	"data": {
+		"total": 2,
+		"items": [
+			{"id": 9007199254740993, "name": "first", "tags": ["a", "b"]},
+			{"id": 2, "name": "second", "tags": []}
+		],
+		"key.with.dots": "dotted",
+		"a]b": "bracket"
+	}
+}`)
+
+func TestGet_Scalars(t *testing.T) {
+	name, err := Get[string](pathDoc, "data.items[0].name")
+	require.NoError(t, err)
+	assert.Equal(t, "first", name)
+
+	total, err := Get[int](pathDoc, "$.data.total")
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+
+	id, err := Get[int64](pathDoc, "data.items[0].id")
+	require.NoError(t, err)
+	assert.Equal(t, int64(9007199254740993), id)
+
+	tag, err := Get[string](pathDoc, "data.items[0].tags[1]")
+	require.NoError(t, err)
+	assert.Equal(t, "b", tag)
+}
+
+func TestGet_NegativeIndexAndQuotedKey(t *testing.T) {
+	name, err := Get[string](pathDoc, "data.items[-1].name")
+	require.NoError(t, err)
+	assert.Equal(t, "second", name)
+
+	v, err := Get[string](pathDoc, `data["key.with.dots"]`)
+	require.NoError(t, err)
+	assert.Equal(t, "dotted", v)
+
+	v, err = Get[string](pathDoc, `data["a]b"]`)
+	require.NoError(t, err)
+	assert.Equal(t, "bracket", v)
+
+	v, err = Get[string](pathDoc, `data[ 'a]b' ]`)
+	require.NoError(t, err)
+	assert.Equal(t, "bracket", v)
+}
+
+func TestGet_Struct(t *testing.T) {
+	type item struct {
+		ID   int64    `json:"id"`
+		Name string   `json:"name"`
+		Tags []string `json:"tags"`
+	}
+
+	it, err := Get[item](pathDoc, "data.items[1]")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), it.ID)
+	assert.Equal(t, "second", it.Name)
+
+	items, err := Get[[]item](pathDoc, "data.items")
+	require.NoError(t, err)
+	assert.Len(t, items, 2)
+}
+
+func TestGet_WholeDocument(t *testing.T) {
+	m, err := Get[map[string]any](pathDoc, "$")
+	require.NoError(t, err)
+	assert.Contains(t, m, "data")
+}
+
+func TestGet_NotFound(t *testing.T) {
+	_, err := Get[string](pathDoc, "data.items[5].name")
+	assert.ErrorIs(t, err, ErrPathNotFound)
+
+	_, err = Get[string](pathDoc, "data.missing")
+	assert.ErrorIs(t, err, ErrPathNotFound)
+
+	_, err = Get[string](pathDoc, "data.total.x")
+	assert.ErrorIs(t, err, ErrPathNotFound)
+}
+
+func TestGet_InvalidPath(t *testing.T) {
+	_, err := Get[string](pathDoc, "data.items[x]")
+	assert.ErrorIs(t, err, ErrInvalidPath)
+
+	_, err = Get[string](pathDoc, "data.items[0")
+	assert.ErrorIs(t, err, ErrInvalidPath)
+
+	_, err = Get[string](pathDoc, "data..total")
+	assert.ErrorIs(t, err, ErrInvalidPath)
+
+	_, err = Get[string](pathDoc, "data.")
+	assert.ErrorIs(t, err, ErrInvalidPath)
+
+	_, err = Get[string](pathDoc, `data["a]b`)
+	assert.ErrorIs(t, err, ErrInvalidPath)
+}
+
+func TestGetOrDefault_And_Exists(t *testing.T) {
+	assert.Equal(t, "fallback", GetOrDefault(pathDoc, "data.nope", "fallback"))
+	assert.Equal(t, 2, GetOrDefault(pathDoc, "data.total", 0))
+
+	assert.True(t, Exists(pathDoc, "data.items[1].tags"))
+	assert.False(t, Exists(pathDoc, "data.items[1].tags[0]"))
+}
@@ -0,0 +1,447 @@
+package mapper
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Copy maps src into a new value of type D.
+//
+// Fields are matched by the "mapper" tag (or the tag set via WithTagName),
+// falling back to the field name, case-insensitively. Values are converted
+// when types differ: string <-> number/bool, time.Time <-> string,
+// pointer <-> value, nested structs, slices and maps.
+//
+// Example:
+//
+//	dto, err := mapper.Copy[User, UserDTO](user,
+//		mapper.WithTimeLayout(datetime.DateTimeLayout),
+//		mapper.WithConverter("Status", func(v any) (any, error) {
+//			return statusName(v.(int)), nil
+//		}),
+//	)
+func Copy[S any, D any](src S, opts ...Option) (D, error) {
+	var dst D
+	err := CopyTo(src, &dst, opts...)
+	return dst, err
+}
+
+// CopySlice maps every element of src into a new slice of D.
+func CopySlice[S any, D any](src []S, opts ...Option) ([]D, error) {
+	if src == nil {
+		return nil, nil
+	}
+
+	out := make([]D, len(src))
+	for i := range src {
+		d, err := Copy[S, D](src[i], opts...)
+		if err != nil {
+			return nil, fmt.Errorf("index %d: %w", i, err)
+		}
+		out[i] = d
+	}
+	return out, nil
+}
+
+// CopyTo maps src into the existing value pointed to by dst.
+// Destination fields without a matching source field are left untouched.
+func CopyTo(src any, dst any, opts ...Option) error {
+	o := withDefaults()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Pointer || dv.IsNil() {
+		return fmt.Errorf("dst must be a non-nil pointer, got %T", dst)
+	}
+
+	m := &mapper{opts: o}
+	return m.assign(reflect.ValueOf(src), dv.Elem())
+}
+
+type mapper struct {
+	opts *options
+}
+
+// assign converts src and stores it into dst (which must be settable).
+func (m *mapper) assign(src, dst reflect.Value) error {
+	src = indirect(src)
+
+	if dst.Kind() == reflect.Pointer {
+		if !src.IsValid() {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return m.assign(src, dst.Elem())
+	}
+
+	if !src.IsValid() {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+
+	if dst.Kind() == reflect.Interface {
+		if !src.Type().AssignableTo(dst.Type()) {
+			return fmt.Errorf("cannot assign %s to %s", src.Type(), dst.Type())
+		}
+		dst.Set(src)
+		return nil
+	}
+
+	switch {
+	case src.Type() == timeType || dst.Type() == timeType:
+		return m.assignTime(src, dst)
+	case src.Kind() == reflect.Struct && dst.Kind() == reflect.Struct:
+		return m.assignStruct(src, dst)
+	case dst.Kind() == reflect.Slice && (src.Kind() == reflect.Slice || src.Kind() == reflect.Array):
+		return m.assignSlice(src, dst)
+	case dst.Kind() == reflect.Map && src.Kind() == reflect.Map:
+		return m.assignMap(src, dst)
+	}
+
+	return assignScalar(src, dst)
+}
+
+func (m *mapper) assignStruct(src, dst reflect.Value) error {
+	fields := m.fieldIndex(src.Type())
+
+	for _, df := range reflect.VisibleFields(dst.Type()) {
+		if !df.IsExported() || df.Anonymous {
+			continue
+		}
+		if _, skip := m.opts.ignores[df.Name]; skip {
+			continue
+		}
+
+		name, ok := m.fieldName(df)
+		if !ok {
+			continue
+		}
+		sf, ok := fields[strings.ToLower(name)]
+		if !ok {
+			continue
+		}
+
+		sv, err := src.FieldByIndexErr(sf.Index)
+		if err != nil {
+			// nil embedded pointer on the source side
+			continue
+		}
+		if m.opts.skipZero && sv.IsZero() {
+			continue
+		}
+
+		dv := dst.FieldByIndex(df.Index)
+		if conv, ok := m.opts.converters[df.Name]; ok {
+			out, err := conv(sv.Interface())
+			if err != nil {
+				return fmt.Errorf("field %s: %w", df.Name, err)
+			}
+			if err := m.assign(reflect.ValueOf(out), dv); err != nil {
+				return fmt.Errorf("field %s: %w", df.Name, err)
+			}
+			continue
+		}
+
+		if err := m.assign(sv, dv); err != nil {
+			return fmt.Errorf("field %s: %w", df.Name, err)
+		}
+	}
+	return nil
+}
+
+// fieldIndex returns the exported fields of t keyed by lower-cased match name.
+func (m *mapper) fieldIndex(t reflect.Type) map[string]reflect.StructField {
+	out := make(map[string]reflect.StructField)
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() || f.Anonymous {
+			continue
+		}
+		name, ok := m.fieldName(f)
+		if !ok {
+			continue
+		}
+		key := strings.ToLower(name)
+		if _, exists := out[key]; !exists {
+			out[key] = f
+		}
+	}
+	return out
+}
+
+// fieldName returns the name used to match f, or false when f is excluded with "-".
+func (m *mapper) fieldName(f reflect.StructField) (string, bool) {
+	tag := f.Tag.Get(m.opts.tagName)
+	if tag == "-" {
+		return "", false
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = f.Name
+	}
+	return name, true
+}
+
+func (m *mapper) assignSlice(src, dst reflect.Value) error {
+	if src.Kind() == reflect.Slice && src.IsNil() {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+
+	out := reflect.MakeSlice(dst.Type(), src.Len(), src.Len())
+	for i := 0; i < src.Len(); i++ {
+		if err := m.assign(src.Index(i), out.Index(i)); err != nil {
+			return fmt.Errorf("index %d: %w", i, err)
+		}
+	}
+	dst.Set(out)
+	return nil
+}
+
+func (m *mapper) assignMap(src, dst reflect.Value) error {
+	if src.IsNil() {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+
+	out := reflect.MakeMapWithSize(dst.Type(), src.Len())
+	iter := src.MapRange()
+	for iter.Next() {
+		k := reflect.New(dst.Type().Key()).Elem()
+		if err := m.assign(iter.Key(), k); err != nil {
+			return fmt.Errorf("key %v: %w", iter.Key(), err)
+		}
+		v := reflect.New(dst.Type().Elem()).Elem()
+		if err := m.assign(iter.Value(), v); err != nil {
+			return fmt.Errorf("key %v: %w", iter.Key(), err)
+		}
+		out.SetMapIndex(k, v)
+	}
+	dst.Set(out)
+	return nil
+}
+
+func (m *mapper) assignTime(src, dst reflect.Value) error {
+	switch {
+	case src.Type() == timeType && dst.Type() == timeType:
+		dst.Set(src)
+	case src.Type() == timeType && dst.Kind() == reflect.String:
+		t := src.Interface().(time.Time)
+		if t.IsZero() {
+			dst.SetString("")
+			return nil
+		}
+		dst.SetString(t.Format(m.opts.timeLayout))
+	case src.Type() == timeType && isInt(dst.Kind()):
+		unix := src.Interface().(time.Time).Unix()
+		if dst.OverflowInt(unix) {
+			return fmt.Errorf("%d overflows %s", unix, dst.Type())
+		}
+		dst.SetInt(unix)
+	case src.Kind() == reflect.String && dst.Type() == timeType:
+		s := strings.TrimSpace(src.String())
+		if s == "" {
+			dst.Set(reflect.Zero(timeType))
+			return nil
+		}
+		t, err := time.Parse(m.opts.timeLayout, s)
+		if err != nil {
+			return err
+		}
+		dst.Set(reflect.ValueOf(t))
+	case isInt(src.Kind()) && dst.Type() == timeType:
+		dst.Set(reflect.ValueOf(time.Unix(src.Int(), 0)))
+	default:
+		return fmt.Errorf("cannot convert %s to %s", src.Type(), dst.Type())
+	}
+	return nil
+}
+
+// assignScalar handles primitive conversions.
+func assignScalar(src, dst reflect.Value) error {
+	st, dt := src.Type(), dst.Type()
+
+	if st.AssignableTo(dt) {
+		dst.Set(src)
+		return nil
+	}
+
+	sk, dk := src.Kind(), dst.Kind()
+	switch {
+	case dk == reflect.String:
+		s, ok := formatScalar(src)
+		if !ok {
+			break
+		}
+		dst.SetString(s)
+		return nil
+
+	case sk == reflect.String:
+		return parseScalar(strings.TrimSpace(src.String()), dst)
+
+	case isNumber(sk) && isNumber(dk):
+		return assignNumber(src, dst)
+
+	case sk == reflect.Bool && dk == reflect.Bool:
+		dst.SetBool(src.Bool())
+		return nil
+	}
+
+	return fmt.Errorf("cannot convert %s to %s", st, dt)
+}
+
+// assignNumber converts between numeric kinds, failing instead of truncating
+// when the value does not fit dst exactly.
+func assignNumber(src, dst reflect.Value) error {
+	dk := dst.Kind()
+	overflow := func() error {
+		return fmt.Errorf("%v overflows %s", src.Interface(), dst.Type())
+	}
+
+	switch sk := src.Kind(); {
+	case isInt(sk):
+		n := src.Int()
+		switch {
+		case isInt(dk):
+			if dst.OverflowInt(n) {
+				return overflow()
+			}
+			dst.SetInt(n)
+		case isUint(dk):
+			if n < 0 || dst.OverflowUint(uint64(n)) {
+				return overflow()
+			}
+			dst.SetUint(uint64(n))
+		default:
+			dst.SetFloat(float64(n))
+		}
+	case isUint(sk):
+		n := src.Uint()
+		switch {
+		case isInt(dk):
+			if n > math.MaxInt64 || dst.OverflowInt(int64(n)) {
+				return overflow()
+			}
+			dst.SetInt(int64(n))
+		case isUint(dk):
+			if dst.OverflowUint(n) {
+				return overflow()
+			}
+			dst.SetUint(n)
+		default:
+			dst.SetFloat(float64(n))
+		}
+	default:
+		f := src.Float()
+		switch {
+		case isInt(dk):
+			if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 || dst.OverflowInt(int64(f)) {
+				return fmt.Errorf("cannot convert %v to %s exactly", f, dst.Type())
+			}
+			dst.SetInt(int64(f))
+		case isUint(dk):
+			if f != math.Trunc(f) || f < 0 || f >= math.MaxUint64 || dst.OverflowUint(uint64(f)) {
+				return fmt.Errorf("cannot convert %v to %s exactly", f, dst.Type())
+			}
+			dst.SetUint(uint64(f))
+		default:
+			if dst.OverflowFloat(f) {
+				return overflow()
+			}
+			dst.SetFloat(f)
+		}
+	}
+	return nil
+}
+
+func formatScalar(v reflect.Value) (string, bool) {
+	switch k := v.Kind(); {
+	case k == reflect.String:
+		return v.String(), true
+	case isInt(k):
+		return strconv.FormatInt(v.Int(), 10), true
+	case isUint(k):
+		return strconv.FormatUint(v.Uint(), 10), true
+	case k == reflect.Float32 || k == reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits()), true
+	case k == reflect.Bool:
+		return strconv.FormatBool(v.Bool()), true
+	case k == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8:
+		return string(v.Bytes()), true
+	default:
+		return "", false
+	}
+}
+
+func parseScalar(s string, dst reflect.Value) error {
+	dk := dst.Kind()
+	if s == "" && dk != reflect.Slice {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+
+	switch {
+	case isInt(dk):
+		n, err := strconv.ParseInt(s, 10, dst.Type().Bits())
+		if err != nil {
+			return err
+		}
+		dst.SetInt(n)
+	case isUint(dk):
+		n, err := strconv.ParseUint(s, 10, dst.Type().Bits())
+		if err != nil {
+			return err
+		}
+		dst.SetUint(n)
+	case dk == reflect.Float32 || dk == reflect.Float64:
+		f, err := strconv.ParseFloat(s, dst.Type().Bits())
+		if err != nil {
+			return err
+		}
+		dst.SetFloat(f)
+	case dk == reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		dst.SetBool(b)
+	case dk == reflect.Slice && dst.Type().Elem().Kind() == reflect.Uint8:
+		dst.SetBytes([]byte(s))
+	default:
+		return fmt.Errorf("cannot convert string to %s", dst.Type())
+	}
+	return nil
+}
+
+// indirect dereferences pointers and interfaces, returning an invalid Value for nil.
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+func isInt(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Int64
+}
+
+func isUint(k reflect.Kind) bool {
+	return k >= reflect.Uint && k <= reflect.Uintptr
+}
+
+func isNumber(k reflect.Kind) bool {
+	return isInt(k) || isUint(k) || k == reflect.Float32 || k == reflect.Float64
+}
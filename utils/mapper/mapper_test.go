@@ -0,0 +1,209 @@
+package mapper
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type address struct {
+	City string
+	Zip  int
+}
+
+type addressDTO struct {
+	City string
+	Zip  string
+}
+
+type base struct {
+	ID        int64
+	CreatedAt time.Time
+}
+
+type user struct {
+	base
+	Name     string
+	Age      string
+	Score    float64
+	Active   bool
+	Nick     *string
+	Status   int
+	Address  *address
+	Tags     []string
+	Previous []address
+	Meta     map[string]int
+	Secret   string
+}
+
+type userDTO struct {
+	ID        string `mapper:"id"`
+	CreatedAt string
+	FullName  string `mapper:"name"`
+	Age       int
+	Score     *float64
+	Active    string
+	Nick      string
+	Status    string
+	Address   addressDTO
+	Tags      []string
+	Previous  []*addressDTO
+	Meta      map[string]string
+	Secret    string `mapper:"-"`
+}
+
+func TestCopy_ConvertsTypes(t *testing.T) {
+	nick := "al"
+	created := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	src := user{
+		base:     base{ID: 42, CreatedAt: created},
+		Name:     "Alice",
+		Age:      "30",
+		Score:    9.5,
+		Active:   true,
+		Nick:     &nick,
+		Status:   1,
+		Address:  &address{City: "HN", Zip: 100000},
+		Tags:     []string{"a", "b"},
+		Previous: []address{{City: "HCM", Zip: 700000}},
+		Meta:     map[string]int{"x": 1},
+		Secret:   "s3cr3t",
+	}
+
+	dst, err := Copy[user, userDTO](src,
+		WithTimeLayout("2006-01-02 15:04:05"),
+		WithConverter("Status", func(v any) (any, error) {
+			if v.(int) == 1 {
+				return "active", nil
+			}
+			return "inactive", nil
+		}),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "42", dst.ID)
+	assert.Equal(t, "2024-01-15 10:30:00", dst.CreatedAt)
+	assert.Equal(t, "Alice", dst.FullName)
+	assert.Equal(t, 30, dst.Age)
+	require.NotNil(t, dst.Score)
+	assert.Equal(t, 9.5, *dst.Score)
+	assert.Equal(t, "true", dst.Active)
+	assert.Equal(t, "al", dst.Nick)
+	assert.Equal(t, "active", dst.Status)
+	assert.Equal(t, addressDTO{City: "HN", Zip: "100000"}, dst.Address)
+	assert.Equal(t, []string{"a", "b"}, dst.Tags)
+	require.Len(t, dst.Previous, 1)
+	assert.Equal(t, "700000", dst.Previous[0].Zip)
+	assert.Equal(t, map[string]string{"x": "1"}, dst.Meta)
+	assert.Empty(t, dst.Secret)
+}
+
+func TestCopy_Reverse(t *testing.T) {
+	src := userDTO{
+		ID:        "7",
+		CreatedAt: "2024-01-15T10:30:00Z",
+		FullName:  "Bob",
+		Age:       20,
+		Active:    "false",
+		Nick:      "bobby",
+		Address:   addressDTO{City: "DN", Zip: "550000"},
+	}
+
+	dst, err := Copy[userDTO, user](src)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(7), dst.ID)
+	assert.True(t, dst.CreatedAt.Equal(time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)))
+	assert.Equal(t, "Bob", dst.Name)
+	assert.Equal(t, "20", dst.Age)
+	require.NotNil(t, dst.Nick)
+	assert.Equal(t, "bobby", *dst.Nick)
+	require.NotNil(t, dst.Address)
+	assert.Equal(t, 550000, dst.Address.Zip)
+}
+
+func TestCopy_ConversionError(t *testing.T) {
+	_, err := Copy[user, userDTO](user{Age: "abc"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Age")
+
+	_, err = Copy[user, userDTO](user{Status: 1}, WithConverter("Status", func(v any) (any, error) {
+		return nil, errors.New("boom")
+	}))
+	assert.ErrorContains(t, err, "boom")
+}
+
+func TestCopy_NumericOverflow(t *testing.T) {
+	type wide struct {
+		Small int64
+		Count int64
+		Ratio float64
+	}
+	type narrow struct {
+		Small int8
+		Count uint
+		Ratio int
+	}
+
+	got, err := Copy[wide, narrow](wide{Small: 100, Count: 3, Ratio: 2})
+	require.NoError(t, err)
+	assert.Equal(t, narrow{Small: 100, Count: 3, Ratio: 2}, got)
+
+	_, err = Copy[wide, narrow](wide{Small: 300})
+	assert.ErrorContains(t, err, "Small")
+	_, err = Copy[wide, narrow](wide{Count: -1})
+	assert.ErrorContains(t, err, "Count")
+	_, err = Copy[wide, narrow](wide{Ratio: 1.5})
+	assert.ErrorContains(t, err, "Ratio")
+}
+
+func TestCopy_InterfaceField(t *testing.T) {
+	type src struct{ Value int }
+	type dst struct{ Value fmt.Stringer }
+
+	_, err := Copy[src, dst](src{Value: 1})
+	assert.ErrorContains(t, err, "Value")
+}
+
+func TestCopyTo_SkipZeroAndIgnore(t *testing.T) {
+	dst := userDTO{FullName: "Keep", Age: 10, Nick: "keep"}
+
+	err := CopyTo(user{Age: "11", Nick: nil}, &dst, WithSkipZero(), WithIgnore("Age"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Keep", dst.FullName)
+	assert.Equal(t, 10, dst.Age)
+	assert.Equal(t, "keep", dst.Nick)
+}
+
+func TestCopyTo_InvalidDst(t *testing.T) {
+	assert.Error(t, CopyTo(user{}, userDTO{}))
+	assert.Error(t, CopyTo(user{}, (*userDTO)(nil)))
+}
+
+func TestCopySlice(t *testing.T) {
+	out, err := CopySlice[address, addressDTO]([]address{{City: "A", Zip: 1}, {City: "B", Zip: 2}})
+	require.NoError(t, err)
+	assert.Equal(t, []addressDTO{{City: "A", Zip: "1"}, {City: "B", Zip: "2"}}, out)
+
+	out, err = CopySlice[address, addressDTO](nil)
+	require.NoError(t, err)
+	assert.Nil(t, out)
+}
+
+func TestCopy_TagName(t *testing.T) {
+	type in struct {
+		UserName string `json:"user_name"`
+	}
+	type out struct {
+		Name string `json:"user_name"`
+	}
+
+	dst, err := Copy[in, out](in{UserName: "x"}, WithTagName("json"))
+	require.NoError(t, err)
+	assert.Equal(t, "x", dst.Name)
+}
@@ -0,0 +1,80 @@
+package mapper
+
+import "time"
+
+const defaultTagName = "mapper"
+
+// Converter converts a source field value into the value assigned to the destination field.
+type Converter func(v any) (any, error)
+
+type Option func(*options)
+
+type options struct {
+	// tagName is the struct tag used to rename fields (default "mapper").
+	tagName string
+
+	// timeLayout is used for time.Time <-> string conversion.
+	timeLayout string
+
+	// converters maps a destination field name to a custom converter.
+	converters map[string]Converter
+
+	// ignores holds destination field names that must not be set.
+	ignores map[string]struct{}
+
+	// skipZero skips source fields holding their zero value.
+	skipZero bool
+}
+
+func withDefaults() *options {
+	return &options{
+		tagName:    defaultTagName,
+		timeLayout: time.RFC3339,
+		converters: make(map[string]Converter),
+		ignores:    make(map[string]struct{}),
+	}
+}
+
+// WithTagName sets the struct tag used to match fields (e.g. "json").
+func WithTagName(tag string) Option {
+	return func(o *options) {
+		if tag != "" {
+			o.tagName = tag
+		}
+	}
+}
+
+// WithTimeLayout sets the layout used when converting between time.Time and string.
+func WithTimeLayout(layout string) Option {
+	return func(o *options) {
+		if layout != "" {
+			o.timeLayout = layout
+		}
+	}
+}
+
+// WithConverter registers a custom converter for the destination field name.
+func WithConverter(field string, fn Converter) Option {
+	return func(o *options) {
+		if fn != nil {
+			o.converters[field] = fn
+		}
+	}
+}
+
+// WithIgnore skips the given destination field names.
+func WithIgnore(fields ...string) Option {
+	return func(o *options) {
+		for _, f := range fields {
+			o.ignores[f] = struct{}{}
+		}
+	}
+}
+
+// WithSkipZero leaves destination fields untouched when the source value is zero.
+// Useful for partial updates (patching an entity from a DTO).
+func WithSkipZero() Option {
+	return func(o *options) {
+		o.skipZero = true
+	}
+}
@@ -0,0 +1,263 @@
+package mask
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// maxDepth bounds the recursion so cyclic values cannot loop forever;
+// anything deeper is replaced with its zero value.
+const maxDepth = 32
+
+// Masker masks sensitive values before they are logged or exported.
+//
+// Struct fields are masked by their `mask:"<rule>"` tag, map entries by
+// their key (see WithKey). Everything else is deep-copied, so the input is
+// never modified.
+type Masker struct {
+	*options
+}
+
+// New creates a Masker.
+//
+// Example:
+//
+//	m := mask.New(
+//		mask.WithKey("national_id", mask.RuleSecret),
+//		mask.WithPseudonymKey(cfg.PseudonymKey),
+//	)
+//	log.Info("order created", zap.Any("order", mask.Apply(m, order)))
+func New(opts ...Option) *Masker {
+	o := withDefaults()
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &Masker{options: o}
+}
+
+var std = New()
+
+// Mask returns a masked deep copy of v using the default Masker.
+//
+// Example:
+//
+//	type User struct {
+//		Name  string `mask:"name"`
+//		Email string `mask:"email"`
+//		Card  string `mask:"card"`
+//		Meta  map[string]any
+//	}
+//
+//	safe := mask.Mask(user)
+//	// {Name:"N***** V** A" Email:"j*******@example.com" Card:"************1111"
+//	//  Meta:{"password":"******"}}
+func Mask[T any](v T) T {
+	return Apply(std, v)
+}
+
+// Apply returns a masked deep copy of v using m.
+//
+// Tagged string fields (and strings inside tagged pointers, slices and maps)
+// are passed through the rule; other tagged fields are zeroed. Unknown rule
+// names mask like RuleSecret. Unexported fields are copied as is.
+func Apply[T any](m *Masker, v T) T {
+	var out T
+	reflect.ValueOf(&out).Elem().Set(m.copy(reflect.ValueOf(&v).Elem(), 0))
+	return out
+}
+
+// Value returns a masked deep copy of v.
+func (m *Masker) Value(v any) any {
+	return Apply(m, v)
+}
+
+// String masks s with the named rule.
+func (m *Masker) String(rule, s string) string {
+	return m.ruleFunc(rule)(s)
+}
+
+// JSON masks a JSON document by key, e.g. a request body before it is logged.
+func (m *Masker) JSON(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(Apply(m, v))
+}
+
+// ruleFunc returns the Func of a rule name, falling back to Secret for unknown names.
+func (m *Masker) ruleFunc(name string) Func {
+	if fn := m.rule(name); fn != nil {
+		return fn
+	}
+	return Secret
+}
+
+// keyRule returns the Func for a map key, nil when the key is not masked.
+func (m *Masker) keyRule(k reflect.Value) Func {
+	if k.Kind() == reflect.Interface {
+		k = k.Elem()
+	}
+	if k.Kind() != reflect.String {
+		return nil
+	}
+	rule, ok := m.keys[normalizeKey(k.String())]
+	if !ok {
+		return nil
+	}
+	return m.ruleFunc(rule)
+}
+
+// copy deep-copies v, masking tagged fields and masked map keys.
+func (m *Masker) copy(v reflect.Value, depth int) reflect.Value {
+	if depth > maxDepth {
+		return reflect.Zero(v.Type())
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(v.Type().Elem())
+		out.Elem().Set(m.copy(v.Elem(), depth+1))
+		return out
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(v.Type()).Elem()
+		out.Set(m.copy(v.Elem(), depth+1))
+		return out
+
+	case reflect.Struct:
+		t := v.Type()
+		out := reflect.New(t).Elem()
+		out.Set(v)
+		for i := range t.NumField() {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			if rule := f.Tag.Get(m.tagName); rule != "" {
+				out.Field(i).Set(m.apply(v.Field(i), m.ruleFunc(rule), depth+1))
+			} else {
+				out.Field(i).Set(m.copy(v.Field(i), depth+1))
+			}
+		}
+		return out
+
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			if fn := m.keyRule(iter.Key()); fn != nil {
+				out.SetMapIndex(iter.Key(), m.apply(iter.Value(), fn, depth+1))
+			} else {
+				out.SetMapIndex(iter.Key(), m.copy(iter.Value(), depth+1))
+			}
+		}
+		return out
+
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			reflect.Copy(out, v)
+			return out
+		}
+		for i := range v.Len() {
+			out.Index(i).Set(m.copy(v.Index(i), depth+1))
+		}
+		return out
+
+	case reflect.Array:
+		out := reflect.New(v.Type()).Elem()
+		for i := range v.Len() {
+			out.Index(i).Set(m.copy(v.Index(i), depth+1))
+		}
+		return out
+
+	default:
+		return v
+	}
+}
+
+// apply masks every string reachable from v with fn and zeroes other values.
+// Scalars held in an interface (e.g. a numeric card number decoded from JSON)
+// are formatted and masked as strings.
+func (m *Masker) apply(v reflect.Value, fn Func, depth int) reflect.Value {
+	if depth > maxDepth {
+		return reflect.Zero(v.Type())
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		out := reflect.New(v.Type()).Elem()
+		out.SetString(fn(v.String()))
+		return out
+
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(v.Type().Elem())
+		out.Elem().Set(m.apply(v.Elem(), fn, depth+1))
+		return out
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(v.Type()).Elem()
+		switch e := v.Elem(); e.Kind() {
+		case reflect.Pointer, reflect.Interface, reflect.Struct, reflect.Map, reflect.Slice, reflect.Array:
+			out.Set(m.apply(e, fn, depth+1))
+		default:
+			out.Set(reflect.ValueOf(fn(fmt.Sprint(e.Interface()))))
+		}
+		return out
+
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			out.SetMapIndex(iter.Key(), m.apply(iter.Value(), fn, depth+1))
+		}
+		return out
+
+	case reflect.Slice:
+		if v.IsNil() || v.Type().Elem().Kind() == reflect.Uint8 {
+			return reflect.Zero(v.Type())
+		}
+		out := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := range v.Len() {
+			out.Index(i).Set(m.apply(v.Index(i), fn, depth+1))
+		}
+		return out
+
+	case reflect.Array:
+		out := reflect.New(v.Type()).Elem()
+		for i := range v.Len() {
+			out.Index(i).Set(m.apply(v.Index(i), fn, depth+1))
+		}
+		return out
+
+	default:
+		return reflect.Zero(v.Type())
+	}
+}
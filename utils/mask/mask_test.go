@@ -0,0 +1,123 @@
+package mask
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type address struct {
+	Street string `mask:"secret"`
+	City   string
+}
+
+type customer struct {
+	ID       int64
+	Name     string   `mask:"name"`
+	Email    string   `mask:"email"`
+	Phone    *string  `mask:"phone"`
+	Cards    []string `mask:"card"`
+	Pin      int      `mask:"secret"`
+	Address  *address
+	Previous []address
+	Meta     map[string]any
+	note     string
+}
+
+func TestRules(t *testing.T) {
+	assert.Equal(t, "j*******@example.com", Email("john.doe@example.com"))
+	assert.Equal(t, "******", Email("not-an-email"))
+	assert.Equal(t, "**** **** **** 1111", Card("4111 1111 1111 1111"))
+	assert.Equal(t, "************1111", Card("4111111111111111"))
+	assert.Equal(t, "+**-***-***-567", Phone("+84-912-345-567"))
+	assert.Equal(t, "******", Phone("12"))
+	assert.Equal(t, "N***** V** A", Name("Nguyễn Văn A"))
+	assert.Equal(t, "******", Secret("p@ssw0rd-long"))
+	assert.Equal(t, "", Secret(""))
+
+	p := Pseudonym([]byte("k"), "user-1")
+	assert.Len(t, p, 64)
+	assert.Equal(t, p, Pseudonym([]byte("k"), "user-1"))
+	assert.NotEqual(t, p, Pseudonym([]byte("other"), "user-1"))
+}
+
+func TestMask_Struct(t *testing.T) {
+	phone := "0912345678"
+	in := customer{
+		ID:       7,
+		Name:     "Nguyen Van A",
+		Email:    "a@example.com",
+		Phone:    &phone,
+		Cards:    []string{"4111111111111111"},
+		Pin:      1234,
+		Address:  &address{Street: "1 Le Loi", City: "HCM"},
+		Previous: []address{{Street: "2 Hai Ba Trung", City: "HN"}},
+		Meta: map[string]any{
+			"Password": "secret",
+			"nested":   map[string]any{"access_token": "abc", "card_number": 4111111111111111},
+			"source":   "web",
+		},
+		note: "kept",
+	}
+
+	out := Mask(in)
+	assert.Equal(t, int64(7), out.ID)
+	assert.Equal(t, "N***** V** A", out.Name)
+	assert.Equal(t, "a@example.com", out.Email)
+	assert.Equal(t, "*******678", *out.Phone)
+	assert.Equal(t, []string{"************1111"}, out.Cards)
+	assert.Zero(t, out.Pin)
+	assert.Equal(t, address{Street: "******", City: "HCM"}, *out.Address)
+	assert.Equal(t, []address{{Street: "******", City: "HN"}}, out.Previous)
+	assert.Equal(t, map[string]any{
+		"Password": "******",
+		"nested":   map[string]any{"access_token": "******", "card_number": "************1111"},
+		"source":   "web",
+	}, out.Meta)
+	assert.Equal(t, "kept", out.note)
+
+	// the input is not modified
+	assert.Equal(t, "0912345678", phone)
+	assert.Equal(t, "1 Le Loi", in.Address.Street)
+	assert.Equal(t, "4111111111111111", in.Cards[0])
+	assert.Equal(t, "secret", in.Meta["Password"])
+
+	var nilAny any
+	assert.Nil(t, Mask(nilAny))
+	assert.Nil(t, Mask[*customer](nil))
+}
+
+func TestMasker_Options(t *testing.T) {
+	type event struct {
+		UserID string `anon:"pseudo"`
+		TaxID  string `anon:"tax"`
+	}
+
+	m := New(
+		WithTagName("anon"),
+		WithPseudonymKey([]byte("analytics")),
+		WithRule("tax", func(s string) string { return "TAX-" + Secret(s) }),
+		WithoutDefaultKeys(),
+		WithKey("national-id", RuleSecret),
+	)
+
+	out := Apply(m, event{UserID: "u-1", TaxID: "0301234567"})
+	assert.Equal(t, Pseudonym([]byte("analytics"), "u-1"), out.UserID)
+	assert.Equal(t, "TAX-******", out.TaxID)
+
+	meta := m.Value(map[string]string{"NationalID": "079", "password": "x"})
+	assert.Equal(t, map[string]string{"NationalID": "******", "password": "x"}, meta)
+
+	assert.Equal(t, "******", New().String(RulePseudo, "u-1"), "pseudo without a key masks")
+	assert.Equal(t, "******", m.String("unknown", "value"))
+}
+
+func TestMasker_JSON(t *testing.T) {
+	out, err := New().JSON([]byte(`{"email":"john@example.com","items":[{"pin":1234,"qty":2}],"amount":10.5}`))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"email":"j***@example.com","items":[{"pin":"******","qty":2}],"amount":10.5}`, string(out))
+
+	_, err = New().JSON([]byte(`{`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,107 @@
+package mask
+
+import "strings"
+
+const defaultTagName = "mask"
+
+// defaultKeys are the map keys masked by default, normalized (lower case,
+// without '_', '-' and '.').
+var defaultKeys = map[string]string{
+	"password":      RuleSecret,
+	"passwd":        RuleSecret,
+	"secret":        RuleSecret,
+	"token":         RuleSecret,
+	"accesstoken":   RuleSecret,
+	"refreshtoken":  RuleSecret,
+	"authorization": RuleSecret,
+	"apikey":        RuleSecret,
+	"pin":           RuleSecret,
+	"cvv":           RuleSecret,
+	"otp":           RuleSecret,
+	"cardnumber":    RuleCard,
+	"pan":           RuleCard,
+	"email":         RuleEmail,
+	"phone":         RulePhone,
+	"phonenumber":   RulePhone,
+}
+
+type Option func(*options)
+
+type options struct {
+	// tagName is the struct tag holding the rule name of a field.
+	tagName string
+
+	// keys maps normalized map keys to rule names.
+	keys map[string]string
+
+	// rules holds the custom rules registered with WithRule.
+	rules map[string]Func
+
+	// pseudoKey is the HMAC key of RulePseudo; without it RulePseudo masks like RuleSecret.
+	pseudoKey []byte
+}
+
+func withDefaults() *options {
+	keys := make(map[string]string, len(defaultKeys))
+	for k, v := range defaultKeys {
+		keys[k] = v
+	}
+	return &options{
+		tagName: defaultTagName,
+		keys:    keys,
+		rules:   make(map[string]Func),
+	}
+}
+
+// WithTagName sets the struct tag read for rule names (default "mask").
+func WithTagName(name string) Option {
+	return func(o *options) {
+		if name != "" {
+			o.tagName = name
+		}
+	}
+}
+
+// WithKey masks the values of map entries whose key matches key with rule.
+// Keys match case-insensitively, ignoring '_', '-' and '.', so "api_key"
+// also matches "apiKey" and "API-KEY".
+func WithKey(key, rule string) Option {
+	return func(o *options) {
+		o.keys[normalizeKey(key)] = rule
+	}
+}
+
+// WithoutDefaultKeys disables the built-in map keys (password, token, email, ...).
+func WithoutDefaultKeys() Option {
+	return func(o *options) {
+		for k := range defaultKeys {
+			delete(o.keys, k)
+		}
+	}
+}
+
+// WithRule registers a custom rule usable in tags and WithKey, or overrides a built-in one.
+func WithRule(name string, fn Func) Option {
+	return func(o *options) {
+		if name != "" && fn != nil {
+			o.rules[name] = fn
+		}
+	}
+}
+
+// WithPseudonymKey sets the HMAC key used by RulePseudo.
+func WithPseudonymKey(key []byte) Option {
+	return func(o *options) {
+		o.pseudoKey = key
+	}
+}
+
+func normalizeKey(k string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '_', '-', '.':
+			return -1
+		}
+		return r
+	}, strings.ToLower(k))
+}
@@ -0,0 +1,149 @@
+package mask
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"unicode"
+
+	"github.com/BevisDev/godev/utils"
+)
+
+// Built-in rule names, usable as `mask:"<rule>"` tags and in WithKey.
+const (
+	// RuleEmail keeps the first character of the local part and the domain: j*******@example.com.
+	RuleEmail = "email"
+
+	// RuleCard keeps the last 4 digits of a card number and its separators: **** **** **** 1111.
+	RuleCard = "card"
+
+	// RulePhone keeps the last 3 digits of a phone number and its separators: +**-***-***-567.
+	RulePhone = "phone"
+
+	// RuleName keeps the first letter of every word: N***** V** A.
+	RuleName = "name"
+
+	// RuleSecret replaces the whole value with a fixed-length mask, hiding its length.
+	RuleSecret = "secret"
+
+	// RulePseudo replaces the value with its HMAC pseudonym (see Pseudonym).
+	// The Masker needs WithPseudonymKey; without a key it masks like RuleSecret.
+	RulePseudo = "pseudo"
+)
+
+const secretMask = "******"
+
+// Func masks a single string value.
+type Func func(s string) string
+
+// Email masks an email address with RuleEmail. Values without '@' are fully masked.
+func Email(s string) string {
+	local, _, ok := strings.Cut(s, "@")
+	if !ok || local == "" {
+		return Secret(s)
+	}
+	return utils.MaskEmail(s, len(local)-1, 0)
+}
+
+// Card masks a card number with RuleCard.
+func Card(s string) string {
+	return keepLastDigits(s, 4)
+}
+
+// Phone masks a phone number with RulePhone.
+func Phone(s string) string {
+	return keepLastDigits(s, 3)
+}
+
+// Name masks a person name with RuleName.
+func Name(s string) string {
+	var b strings.Builder
+	first := true
+	for _, r := range s {
+		switch {
+		case unicode.IsSpace(r):
+			first = true
+			b.WriteRune(r)
+		case first:
+			first = false
+			b.WriteRune(r)
+		default:
+			b.WriteByte('*')
+		}
+	}
+	return b.String()
+}
+
+// Secret masks s with RuleSecret.
+func Secret(s string) string {
+	if s == "" {
+		return ""
+	}
+	return secretMask
+}
+
+// Pseudonym returns the hex HMAC-SHA256 of value under key. The same value
+// and key always give the same pseudonym, so extracts can still be joined
+// and counted by it, while the original value cannot be recovered without the key.
+func Pseudonym(key []byte, value string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// keepLastDigits masks every digit but the last n, keeping other characters.
+func keepLastDigits(s string, n int) string {
+	digits := 0
+	for _, r := range s {
+		if unicode.IsDigit(r) {
+			digits++
+		}
+	}
+	if digits <= n {
+		return Secret(s)
+	}
+
+	var b strings.Builder
+	b.Grow(len(s))
+	masked := digits - n
+	for _, r := range s {
+		if unicode.IsDigit(r) && masked > 0 {
+			masked--
+			b.WriteByte('*')
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// rule returns the Func of a rule name, nil if unknown.
+func (o *options) rule(name string) Func {
+	if fn, ok := o.rules[name]; ok {
+		return fn
+	}
+	switch name {
+	case RuleEmail:
+		return Email
+	case RuleCard:
+		return Card
+	case RulePhone:
+		return Phone
+	case RuleName:
+		return Name
+	case RuleSecret:
+		return Secret
+	case RulePseudo:
+		if len(o.pseudoKey) == 0 {
+			return Secret
+		}
+		return func(s string) string {
+			if s == "" {
+				return ""
+			}
+			return Pseudonym(o.pseudoKey, s)
+		}
+	}
+	return nil
+}
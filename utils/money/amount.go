@@ -0,0 +1,293 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"math/bits"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrCurrencyMismatch is returned when combining amounts of different currencies.
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+
+	// ErrInvalidRatios is returned when allocation ratios are empty, negative or all zero.
+	ErrInvalidRatios = errors.New("invalid allocation ratios")
+
+	// ErrOverflow is returned when an allocation does not fit in int64 minor units.
+	ErrOverflow = errors.New("amount overflows int64 minor units")
+)
+
+// RoundingMode selects how values are rounded to a number of places.
+type RoundingMode int
+
+const (
+	// RoundHalfUp rounds half away from zero (1.5 -> 2, -1.5 -> -2).
+	RoundHalfUp RoundingMode = iota
+
+	// RoundHalfEven rounds half to the nearest even digit (banker's rounding).
+	RoundHalfEven
+
+	// RoundDown truncates toward zero.
+	RoundDown
+
+	// RoundUp rounds away from zero.
+	RoundUp
+
+	// RoundCeil rounds toward positive infinity.
+	RoundCeil
+
+	// RoundFloor rounds toward negative infinity.
+	RoundFloor
+)
+
+// RoundWith rounds m to places using mode.
+func RoundWith(m Money, places int32, mode RoundingMode) Money {
+	switch mode {
+	case RoundHalfEven:
+		return m.RoundBank(places)
+	case RoundDown:
+		return m.RoundDown(places)
+	case RoundUp:
+		return m.RoundUp(places)
+	case RoundCeil:
+		return m.RoundCeil(places)
+	case RoundFloor:
+		return m.RoundFloor(places)
+	default:
+		return m.Round(places)
+	}
+}
+
+// Amount is a currency-aware monetary value. The zero value is 0 with no currency.
+type Amount struct {
+	value    Money
+	currency Currency
+}
+
+// New creates an Amount of value in cur, rounded half-up to the currency's minor unit.
+func New(value Money, cur Currency) Amount {
+	return Amount{value: value.Round(cur.Decimals), currency: cur}
+}
+
+// NewFromString parses s (e.g. "1234.56") into an Amount of cur.
+func NewFromString(s string, cur Currency) (Amount, error) {
+	d, err := FromString(s)
+	if err != nil {
+		return Amount{}, err
+	}
+	return New(d, cur), nil
+}
+
+// NewFromMinor creates an Amount from minor units (e.g. cents): NewFromMinor(1050, USD) is $10.50.
+func NewFromMinor(minor int64, cur Currency) Amount {
+	return Amount{value: decimal.New(minor, -cur.Decimals), currency: cur}
+}
+
+// Value returns the decimal value.
+func (a Amount) Value() Money {
+	return a.value
+}
+
+// Currency returns the amount's currency.
+func (a Amount) Currency() Currency {
+	return a.currency
+}
+
+// Minor returns the value in minor units (e.g. cents).
+func (a Amount) Minor() int64 {
+	return a.value.Shift(a.currency.Decimals).IntPart()
+}
+
+func (a Amount) sameCurrency(b Amount) error {
+	if a.currency.Code != b.currency.Code {
+		return fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, a.currency.Code, b.currency.Code)
+	}
+	return nil
+}
+
+// Add returns a + b. Both amounts must share the same currency.
+func (a Amount) Add(b Amount) (Amount, error) {
+	if err := a.sameCurrency(b); err != nil {
+		return Amount{}, err
+	}
+	return Amount{value: a.value.Add(b.value), currency: a.currency}, nil
+}
+
+// Sub returns a - b. Both amounts must share the same currency.
+func (a Amount) Sub(b Amount) (Amount, error) {
+	if err := a.sameCurrency(b); err != nil {
+		return Amount{}, err
+	}
+	return Amount{value: a.value.Sub(b.value), currency: a.currency}, nil
+}
+
+// Mul multiplies a by factor and rounds the result to the currency's minor unit using mode.
+func (a Amount) Mul(factor Money, mode RoundingMode) Amount {
+	return Amount{value: RoundWith(a.value.Mul(factor), a.currency.Decimals, mode), currency: a.currency}
+}
+
+// Percent returns pct percent of a, rounded to the currency's minor unit using mode.
+func (a Amount) Percent(pct Money, mode RoundingMode) Amount {
+	return a.Mul(pct.Div(decimal.NewFromInt(100)), mode)
+}
+
+// Round rounds a to its currency's minor unit using mode.
+func (a Amount) Round(mode RoundingMode) Amount {
+	return Amount{value: RoundWith(a.value, a.currency.Decimals, mode), currency: a.currency}
+}
+
+// Neg returns -a.
+func (a Amount) Neg() Amount {
+	return Amount{value: a.value.Neg(), currency: a.currency}
+}
+
+// Compare returns -1, 0 or +1. Both amounts must share the same currency.
+func (a Amount) Compare(b Amount) (int, error) {
+	if err := a.sameCurrency(b); err != nil {
+		return 0, err
+	}
+	return a.value.Cmp(b.value), nil
+}
+
+// Equal reports whether a and b have the same currency and value.
+func (a Amount) Equal(b Amount) bool {
+	return a.currency.Code == b.currency.Code && a.value.Equal(b.value)
+}
+
+// IsZero reports whether the value is zero.
+func (a Amount) IsZero() bool {
+	return a.value.IsZero()
+}
+
+// IsNegative reports whether the value is below zero.
+func (a Amount) IsNegative() bool {
+	return a.value.IsNegative()
+}
+
+// Allocate splits a by ratios without losing minor units: the remainder left
+// after integer division is handed out one minor unit at a time from the first share.
+//
+// Example:
+//
+//	a := money.NewFromMinor(100, money.USD)   // $1.00
+//	parts, _ := a.Allocate(1, 1, 1)          // $0.34, $0.33, $0.33
+func (a Amount) Allocate(ratios ...int) ([]Amount, error) {
+	if len(ratios) == 0 {
+		return nil, ErrInvalidRatios
+	}
+	var sum int64
+	for _, r := range ratios {
+		if r < 0 {
+			return nil, ErrInvalidRatios
+		}
+		if sum > math.MaxInt64-int64(r) {
+			return nil, ErrOverflow
+		}
+		sum += int64(r)
+	}
+	if sum == 0 {
+		return nil, ErrInvalidRatios
+	}
+
+	minor := a.Round(RoundHalfUp).value.Shift(a.currency.Decimals).BigInt()
+	if minor.CmpAbs(big.NewInt(math.MaxInt64)) > 0 {
+		return nil, ErrOverflow
+	}
+	total := minor.Int64()
+	sign := int64(1)
+	if total < 0 {
+		sign, total = -1, -total
+	}
+
+	// total*r needs 128 bits; the quotient fits as r <= sum
+	shares := make([]int64, len(ratios))
+	remainder := total
+	for i, r := range ratios {
+		hi, lo := bits.Mul64(uint64(total), uint64(r))
+		q, _ := bits.Div64(hi, lo, uint64(sum))
+		shares[i] = int64(q)
+		remainder -= shares[i]
+	}
+	for i := 0; remainder > 0; i = (i + 1) % len(shares) {
+		if ratios[i] == 0 {
+			continue
+		}
+		shares[i]++
+		remainder--
+	}
+
+	out := make([]Amount, len(shares))
+	for i, s := range shares {
+		out[i] = NewFromMinor(sign*s, a.currency)
+	}
+	return out, nil
+}
+
+// Split divides a into n equal parts without losing minor units.
+func (a Amount) Split(n int) ([]Amount, error) {
+	if n < 1 {
+		return nil, ErrInvalidRatios
+	}
+	ratios := make([]int, n)
+	for i := range ratios {
+		ratios[i] = 1
+	}
+	return a.Allocate(ratios...)
+}
+
+// String returns e.g. "1234.50 USD".
+func (a Amount) String() string {
+	return a.value.StringFixed(a.currency.Decimals) + " " + a.currency.Code
+}
+
+// Format renders a with the currency's separators and symbol, e.g. "$1,234.50" or "1.234.500 ₫".
+func (a Amount) Format() string {
+	num := FormatWithSeparators(a.value, a.currency.Decimals, a.currency.Thousand, a.currency.Decimal)
+	if a.currency.Symbol == "" {
+		return num
+	}
+	if a.currency.SymbolAfter {
+		return num + " " + a.currency.Symbol
+	}
+	if strings.HasPrefix(num, "-") {
+		return "-" + a.currency.Symbol + num[1:]
+	}
+	return a.currency.Symbol + num
+}
+
+// FormatWithSeparators formats m with fixed places, grouping thousands with thousandSep
+// and using decimalSep for the fraction.
+//
+// Example:
+//
+//	FormatWithSeparators(FromFloat(1234567.891), 2, ",", ".") // "1,234,567.89"
+//	FormatWithSeparators(FromInt(1500000), 0, ".", ",")      // "1.500.000"
+func FormatWithSeparators(m Money, places int32, thousandSep, decimalSep string) string {
+	s := m.StringFixed(places)
+
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+
+	intPart, frac, _ := strings.Cut(s, ".")
+
+	var sb strings.Builder
+	if neg {
+		sb.WriteByte('-')
+	}
+	for i, c := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			sb.WriteString(thousandSep)
+		}
+		sb.WriteRune(c)
+	}
+	if frac != "" {
+		sb.WriteString(decimalSep)
+		sb.WriteString(frac)
+	}
+	return sb.String()
+}
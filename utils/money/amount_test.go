@@ -0,0 +1,131 @@
+package money
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustString(t *testing.T, s string) Money {
+	t.Helper()
+	m, err := FromString(s)
+	require.NoError(t, err)
+	return m
+}
+
+func TestRoundWith(t *testing.T) {
+	tests := []struct {
+		in       string
+		mode     RoundingMode
+		expected string
+	}{
+		{"2.345", RoundHalfUp, "2.35"},
+		{"-2.345", RoundHalfUp, "-2.35"},
+		{"2.345", RoundHalfEven, "2.34"},
+		{"2.355", RoundHalfEven, "2.36"},
+		{"2.349", RoundDown, "2.34"},
+		{"2.341", RoundUp, "2.35"},
+		{"-2.341", RoundCeil, "-2.34"},
+		{"-2.341", RoundFloor, "-2.35"},
+	}
+	for _, tt := range tests {
+		got := RoundWith(mustString(t, tt.in), 2, tt.mode)
+		assert.Equal(t, tt.expected, got.StringFixed(2), "%s mode=%d", tt.in, tt.mode)
+	}
+}
+
+func TestAmount_Arithmetic(t *testing.T) {
+	a := NewFromMinor(1050, USD)
+	b, err := NewFromString("2.255", USD)
+	require.NoError(t, err)
+	assert.Equal(t, "2.26", b.Value().StringFixed(2))
+
+	sum, err := a.Add(b)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1276), sum.Minor())
+
+	diff, err := a.Sub(b)
+	require.NoError(t, err)
+	assert.Equal(t, "8.24 USD", diff.String())
+
+	_, err = a.Add(New(FromInt(1), VND))
+	assert.ErrorIs(t, err, ErrCurrencyMismatch)
+
+	assert.Equal(t, "3.50 USD", a.Mul(mustString(t, "0.3333"), RoundHalfUp).String())
+	assert.Equal(t, "0.84 USD", a.Percent(FromInt(8), RoundHalfEven).String())
+
+	cmp, err := a.Compare(b)
+	require.NoError(t, err)
+	assert.Equal(t, 1, cmp)
+	assert.True(t, a.Equal(NewFromMinor(1050, USD)))
+	assert.False(t, a.Equal(NewFromMinor(1050, EUR)))
+	assert.True(t, a.Neg().IsNegative())
+}
+
+func TestAmount_Allocate(t *testing.T) {
+	parts, err := NewFromMinor(100, USD).Allocate(1, 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{34, 33, 33}, minors(parts))
+
+	parts, err = NewFromMinor(-100, USD).Split(3)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{-34, -33, -33}, minors(parts))
+
+	parts, err = New(FromInt(1_000_000), VND).Allocate(70, 20, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{700000, 200000, 100000, 0}, minors(parts))
+
+	parts, err = NewFromMinor(5, USD).Allocate(0, 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{0, 3, 2}, minors(parts))
+
+	_, err = NewFromMinor(5, USD).Allocate()
+	assert.ErrorIs(t, err, ErrInvalidRatios)
+	_, err = NewFromMinor(5, USD).Allocate(0, 0)
+	assert.ErrorIs(t, err, ErrInvalidRatios)
+	_, err = NewFromMinor(5, USD).Split(0)
+	assert.ErrorIs(t, err, ErrInvalidRatios)
+
+	// total*ratio exceeds int64
+	parts, err = NewFromMinor(math.MaxInt64, USD).Allocate(math.MaxInt32, math.MaxInt32)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{math.MaxInt64/2 + 1, math.MaxInt64 / 2}, minors(parts))
+
+	_, err = NewFromMinor(5, USD).Allocate(math.MaxInt, 1)
+	assert.ErrorIs(t, err, ErrOverflow)
+	_, err = New(mustString(t, "1e30"), USD).Split(2)
+	assert.ErrorIs(t, err, ErrOverflow)
+}
+
+func minors(parts []Amount) []int64 {
+	out := make([]int64, len(parts))
+	for i, p := range parts {
+		out[i] = p.Minor()
+	}
+	return out
+}
+
+func TestAmount_Format(t *testing.T) {
+	assert.Equal(t, "$1,234,567.89", New(mustString(t, "1234567.891"), USD).Format())
+	assert.Equal(t, "-$5.00", NewFromMinor(-500, USD).Format())
+	assert.Equal(t, "1.500.000 ₫", New(FromInt(1_500_000), VND).Format())
+	assert.Equal(t, "1.234,50 €", NewFromMinor(123450, EUR).Format())
+	assert.Equal(t, "999", FormatWithSeparators(FromInt(999), 0, ",", "."))
+	assert.Equal(t, "-1,000.5", FormatWithSeparators(mustString(t, "-1000.5"), 1, ",", "."))
+}
+
+func TestCurrencyRegistry(t *testing.T) {
+	c, ok := GetCurrency("vnd")
+	require.True(t, ok)
+	assert.Equal(t, int32(0), c.Decimals)
+
+	_, ok = GetCurrency("XYZ")
+	assert.False(t, ok)
+
+	RegisterCurrency(Currency{Code: "THB", Decimals: 2, Symbol: "฿"})
+	c, ok = GetCurrency("THB")
+	require.True(t, ok)
+	assert.Equal(t, "฿", c.Symbol)
+}
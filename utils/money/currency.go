@@ -0,0 +1,58 @@
+package money
+
+import (
+	"strings"
+	"sync"
+)
+
+// Currency describes an ISO 4217 currency and how amounts in it are displayed.
+type Currency struct {
+	// Code is the ISO 4217 code, e.g. "VND".
+	Code string
+
+	// Decimals is the number of minor-unit digits (0 for VND/JPY, 2 for USD).
+	Decimals int32
+
+	// Symbol is the display symbol, e.g. "₫" or "$".
+	Symbol string
+
+	// SymbolAfter places the symbol after the number ("1.000 ₫") instead of before ("$1,000").
+	SymbolAfter bool
+
+	// Thousand is the grouping separator.
+	Thousand string
+
+	// Decimal is the fractional separator.
+	Decimal string
+}
+
+var (
+	VND = Currency{Code: "VND", Decimals: 0, Symbol: "₫", SymbolAfter: true, Thousand: ".", Decimal: ","}
+	USD = Currency{Code: "USD", Decimals: 2, Symbol: "$", Thousand: ",", Decimal: "."}
+	EUR = Currency{Code: "EUR", Decimals: 2, Symbol: "€", SymbolAfter: true, Thousand: ".", Decimal: ","}
+	JPY = Currency{Code: "JPY", Decimals: 0, Symbol: "¥", Thousand: ",", Decimal: "."}
+	GBP = Currency{Code: "GBP", Decimals: 2, Symbol: "£", Thousand: ",", Decimal: "."}
+	SGD = Currency{Code: "SGD", Decimals: 2, Symbol: "S$", Thousand: ",", Decimal: "."}
+)
+
+var currencies sync.Map // map[string]Currency
+
+func init() {
+	for _, c := range []Currency{VND, USD, EUR, JPY, GBP, SGD} {
+		RegisterCurrency(c)
+	}
+}
+
+// RegisterCurrency adds or replaces a currency in the registry used by GetCurrency.
+func RegisterCurrency(c Currency) {
+	currencies.Store(strings.ToUpper(c.Code), c)
+}
+
+// GetCurrency looks up a registered currency by ISO code (case-insensitive).
+func GetCurrency(code string) (Currency, bool) {
+	v, ok := currencies.Load(strings.ToUpper(code))
+	if !ok {
+		return Currency{}, false
+	}
+	return v.(Currency), true
+}
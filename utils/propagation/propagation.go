@@ -0,0 +1,95 @@
+// Package propagation carries inbound request headers (request ID, W3C trace
+// context and custom correlation headers) in context.Context so that outgoing
+// calls made with that context forward them.
+//
+// The ginfw/middleware/propagation middleware captures the headers and the
+// rest client injects them into every request.
+package propagation
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/BevisDev/godev/consts"
+	"github.com/BevisDev/godev/utils/ctxmeta"
+)
+
+// W3C trace context headers.
+const (
+	TraceParent = "traceparent"
+	TraceState  = "tracestate"
+)
+
+// DefaultHeaders are the headers propagated when none are configured.
+var DefaultHeaders = []string{consts.XRequestID, TraceParent, TraceState}
+
+type key struct{}
+
+// WithHeaders stores the propagated headers in ctx; empty values are skipped.
+// Headers already stored in ctx are kept unless overridden.
+func WithHeaders(ctx context.Context, h http.Header) context.Context {
+	prev, _ := ctx.Value(key{}).(http.Header)
+	next := prev.Clone()
+	if next == nil {
+		next = make(http.Header, len(h))
+	}
+	for k, v := range h {
+		if len(v) > 0 && v[0] != "" {
+			next[http.CanonicalHeaderKey(k)] = append([]string(nil), v...)
+		}
+	}
+	return context.WithValue(ctx, key{}, next)
+}
+
+// Headers returns a copy of the headers to propagate from ctx.
+// The request ID of ctxmeta is added as X-Request-ID when not stored.
+func Headers(ctx context.Context) http.Header {
+	if ctx == nil {
+		return http.Header{}
+	}
+
+	h, _ := ctx.Value(key{}).(http.Header)
+	h = h.Clone()
+	if h == nil {
+		h = http.Header{}
+	}
+	if h.Get(consts.XRequestID) == "" {
+		if rid := ctxmeta.RID(ctx); rid != "" {
+			h.Set(consts.XRequestID, rid)
+		}
+	}
+	return h
+}
+
+// Inject sets the propagated headers of ctx on h.
+// Headers already present in h are left untouched.
+func Inject(ctx context.Context, h http.Header) {
+	for k, v := range Headers(ctx) {
+		if h.Get(k) == "" {
+			h[k] = v
+		}
+	}
+}
+
+// TraceID returns the trace ID of a W3C traceparent header
+// ("00-<trace-id>-<parent-id>-<flags>"), or "" when it is malformed.
+func TraceID(traceparent string) string {
+	parts := strings.Split(strings.TrimSpace(traceparent), "-")
+	if len(parts) < 4 || len(parts[1]) != 32 || !isHex(parts[1]) {
+		return ""
+	}
+	if strings.Trim(parts[1], "0") == "" {
+		return ""
+	}
+	return parts[1]
+}
+
+func isHex(s string) bool {
+	for _, c := range s {
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,59 @@
+package propagation
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/BevisDev/godev/utils/ctxmeta"
+	"github.com/stretchr/testify/assert"
+)
+
+const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func TestWithHeaders_AndHeaders(t *testing.T) {
+	ctx := WithHeaders(context.Background(), http.Header{
+		"x-request-id": {"rid-1"},
+		"Traceparent":  {traceparent},
+		"X-Empty":      {""},
+	})
+	ctx = WithHeaders(ctx, http.Header{"X-Correlation-Id": {"c-1"}})
+
+	h := Headers(ctx)
+	assert.Equal(t, "rid-1", h.Get("X-Request-ID"))
+	assert.Equal(t, traceparent, h.Get(TraceParent))
+	assert.Equal(t, "c-1", h.Get("X-Correlation-ID"))
+	assert.NotContains(t, h, "X-Empty")
+
+	// the stored headers are not shared with the caller
+	h.Set("X-Request-ID", "changed")
+	assert.Equal(t, "rid-1", Headers(ctx).Get("X-Request-ID"))
+}
+
+func TestHeaders_FallsBackToRID(t *testing.T) {
+	assert.Empty(t, Headers(context.Background()))
+
+	ctx := ctxmeta.WithRID(context.Background(), "rid-2")
+	assert.Equal(t, "rid-2", Headers(ctx).Get("X-Request-ID"))
+}
+
+func TestInject_KeepsExistingHeaders(t *testing.T) {
+	ctx := WithHeaders(context.Background(), http.Header{
+		"X-Request-Id": {"rid-1"},
+		"Traceparent":  {traceparent},
+	})
+
+	h := http.Header{}
+	h.Set("X-Request-ID", "explicit")
+	Inject(ctx, h)
+
+	assert.Equal(t, "explicit", h.Get("X-Request-ID"))
+	assert.Equal(t, traceparent, h.Get(TraceParent))
+}
+
+func TestTraceID(t *testing.T) {
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", TraceID(traceparent))
+	assert.Empty(t, TraceID(""))
+	assert.Empty(t, TraceID("00-xyz-00f067aa0ba902b7-01"))
+	assert.Empty(t, TraceID("00-00000000000000000000000000000000-00f067aa0ba902b7-01"))
+}
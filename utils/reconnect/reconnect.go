@@ -0,0 +1,138 @@
+// Package reconnect is the reconnect policy shared by the clients of long-lived
+// connections (RabbitMQ, Redis): exponential backoff with jitter, a bounded number of
+// attempts, and connection-state events.
+package reconnect
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+)
+
+// ErrMaxAttempts is returned by Run when every attempt failed.
+var ErrMaxAttempts = errors.New("[reconnect] max attempts reached")
+
+// State is the state of a connection.
+type State string
+
+const (
+	StateConnected    State = "connected"
+	StateDisconnected State = "disconnected"
+	StateReconnecting State = "reconnecting"
+
+	// StateFailed: the policy gave up; the client stays unusable until the next reconnect.
+	StateFailed State = "failed"
+)
+
+// Event is a connection state change.
+type Event struct {
+	Name    string // client name, e.g. "rabbitmq", "redis"
+	State   State
+	Attempt int   // reconnect attempt, 0 outside reconnects
+	Err     error // cause of a disconnect or of a failed attempt
+	Time    time.Time
+}
+
+// Policy controls how a client reconnects.
+type Policy struct {
+	// InitialDelay is the wait after the first failed attempt (default 1s).
+	InitialDelay time.Duration
+
+	// MaxDelay caps the wait between attempts (default 30s).
+	MaxDelay time.Duration
+
+	// Jitter randomizes each delay in [delay/2, delay) so instances do not reconnect in step.
+	Jitter bool
+
+	// MaxAttempts bounds the attempts of one reconnect (0 means until the context is done).
+	MaxAttempts int
+
+	// OnReconnect is called after a successful reconnect, e.g. to redeclare state.
+	OnReconnect func(attempt int)
+
+	// OnStateChange receives the connection state changes.
+	OnStateChange func(Event)
+}
+
+// DefaultPolicy returns a policy retrying forever from 1s to 30s, with jitter.
+func DefaultPolicy() Policy {
+	return Policy{
+		InitialDelay: time.Second,
+		MaxDelay:     30 * time.Second,
+		Jitter:       true,
+	}
+}
+
+func (p Policy) normalize() Policy {
+	if p.InitialDelay <= 0 {
+		p.InitialDelay = time.Second
+	}
+	if p.MaxDelay <= 0 {
+		p.MaxDelay = 30 * time.Second
+	}
+	if p.MaxDelay < p.InitialDelay {
+		p.MaxDelay = p.InitialDelay
+	}
+	return p
+}
+
+// Delay returns the wait after the given failed attempt (1-based), without jitter.
+func (p Policy) Delay(attempt int) time.Duration {
+	p = p.normalize()
+	d := p.InitialDelay
+	for i := 1; i < attempt && d < p.MaxDelay; i++ {
+		d *= 2
+	}
+	return min(d, p.MaxDelay)
+}
+
+// Notify sends an event for name to OnStateChange, if set.
+func (p Policy) Notify(name string, state State, attempt int, err error) {
+	if p.OnStateChange == nil {
+		return
+	}
+	p.OnStateChange(Event{Name: name, State: state, Attempt: attempt, Err: err, Time: time.Now()})
+}
+
+// Run calls connect until it succeeds, MaxAttempts is reached (ErrMaxAttempts wrapping the
+// last error) or ctx is done, notifying StateReconnecting before each attempt, then
+// StateConnected and OnReconnect, or StateFailed.
+func (p Policy) Run(ctx context.Context, name string, connect func(ctx context.Context) error) error {
+	p = p.normalize()
+
+	for attempt := 1; ; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		p.Notify(name, StateReconnecting, attempt, nil)
+		err := connect(ctx)
+		if err == nil {
+			p.Notify(name, StateConnected, attempt, nil)
+			if p.OnReconnect != nil {
+				p.OnReconnect(attempt)
+			}
+			return nil
+		}
+
+		if p.MaxAttempts > 0 && attempt >= p.MaxAttempts {
+			p.Notify(name, StateFailed, attempt, err)
+			return errors.Join(ErrMaxAttempts, err)
+		}
+
+		wait := p.Delay(attempt)
+		if p.Jitter {
+			wait = wait/2 + rand.N(wait/2+1)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			p.Notify(name, StateFailed, attempt, ctx.Err())
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
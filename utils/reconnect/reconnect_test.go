@@ -0,0 +1,76 @@
+package reconnect
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicy_Delay(t *testing.T) {
+	p := Policy{InitialDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	assert.Equal(t, 100*time.Millisecond, p.Delay(1))
+	assert.Equal(t, 200*time.Millisecond, p.Delay(2))
+	assert.Equal(t, 800*time.Millisecond, p.Delay(4))
+	assert.Equal(t, time.Second, p.Delay(5))
+	assert.Equal(t, time.Second, p.Delay(100))
+
+	assert.Equal(t, time.Second, Policy{}.Delay(1), "default initial delay")
+}
+
+func TestPolicy_Run(t *testing.T) {
+	var (
+		events    []State
+		attempts  []int
+		reconnect int
+	)
+	p := Policy{
+		InitialDelay:  time.Millisecond,
+		OnReconnect:   func(attempt int) { reconnect = attempt },
+		OnStateChange: func(e Event) { events = append(events, e.State); attempts = append(attempts, e.Attempt) },
+	}
+
+	calls := 0
+	err := p.Run(context.Background(), "mq", func(ctx context.Context) error {
+		calls++
+		if calls < 3 {
+			return errors.New("dial failed")
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, reconnect)
+	assert.Equal(t, []State{StateReconnecting, StateReconnecting, StateReconnecting, StateConnected}, events)
+	assert.Equal(t, []int{1, 2, 3, 3}, attempts)
+}
+
+func TestPolicy_Run_MaxAttempts(t *testing.T) {
+	var last Event
+	p := Policy{
+		InitialDelay:  time.Millisecond,
+		Jitter:        true,
+		MaxAttempts:   2,
+		OnStateChange: func(e Event) { last = e },
+	}
+
+	dialErr := errors.New("dial failed")
+	err := p.Run(context.Background(), "mq", func(ctx context.Context) error { return dialErr })
+	assert.ErrorIs(t, err, ErrMaxAttempts)
+	assert.ErrorIs(t, err, dialErr)
+	assert.Equal(t, StateFailed, last.State)
+	assert.Equal(t, "mq", last.Name)
+	assert.Equal(t, 2, last.Attempt)
+}
+
+func TestPolicy_Run_ContextDone(t *testing.T) {
+	p := Policy{InitialDelay: time.Hour}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := p.Run(ctx, "mq", func(ctx context.Context) error { return errors.New("dial failed") })
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
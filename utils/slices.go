@@ -0,0 +1,187 @@
+package utils
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Map returns a new slice with fn applied to every element.
+func Map[T any, R any](s []T, fn func(T) R) []R {
+	if s == nil {
+		return nil
+	}
+	out := make([]R, len(s))
+	for i, v := range s {
+		out[i] = fn(v)
+	}
+	return out
+}
+
+// Filter returns the elements of s for which keep returns true.
+func Filter[T any](s []T, keep func(T) bool) []T {
+	if s == nil {
+		return nil
+	}
+	out := make([]T, 0, len(s))
+	for _, v := range s {
+		if keep(v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// Reduce folds s into a single value starting from init.
+//
+// Example:
+//
+//	sum := Reduce([]int{1, 2, 3}, 0, func(acc, v int) int { return acc + v }) // 6
+func Reduce[T any, R any](s []T, init R, fn func(R, T) R) R {
+	acc := init
+	for _, v := range s {
+		acc = fn(acc, v)
+	}
+	return acc
+}
+
+// Unique returns s without duplicates, keeping the first occurrence order.
+func Unique[T comparable](s []T) []T {
+	return UniqueBy(s, func(v T) T { return v })
+}
+
+// UniqueBy returns s without elements whose key was already seen.
+func UniqueBy[T any, K comparable](s []T, key func(T) K) []T {
+	if s == nil {
+		return nil
+	}
+	seen := make(map[K]struct{}, len(s))
+	out := make([]T, 0, len(s))
+	for _, v := range s {
+		k := key(v)
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		out = append(out, v)
+	}
+	return out
+}
+
+// Chunk splits s into consecutive slices of at most size elements.
+// If size < 1, it returns nil.
+//
+// Example:
+//
+//	Chunk([]int{1, 2, 3, 4, 5}, 2) // [[1 2] [3 4] [5]]
+func Chunk[T any](s []T, size int) [][]T {
+	if size < 1 || len(s) == 0 {
+		return nil
+	}
+	out := make([][]T, 0, (len(s)+size-1)/size)
+	for i := 0; i < len(s); i += size {
+		end := min(i+size, len(s))
+		out = append(out, s[i:end:end])
+	}
+	return out
+}
+
+// GroupBy groups elements of s by key, keeping the original order inside each group.
+func GroupBy[T any, K comparable](s []T, key func(T) K) map[K][]T {
+	out := make(map[K][]T)
+	for _, v := range s {
+		k := key(v)
+		out[k] = append(out[k], v)
+	}
+	return out
+}
+
+// KeyBy indexes elements of s by key. Later elements overwrite earlier ones with the same key.
+func KeyBy[T any, K comparable](s []T, key func(T) K) map[K]T {
+	out := make(map[K]T, len(s))
+	for _, v := range s {
+		out[key(v)] = v
+	}
+	return out
+}
+
+// Partition splits s into elements that match pred and elements that do not.
+func Partition[T any](s []T, pred func(T) bool) (matched []T, rest []T) {
+	for _, v := range s {
+		if pred(v) {
+			matched = append(matched, v)
+		} else {
+			rest = append(rest, v)
+		}
+	}
+	return matched, rest
+}
+
+// Difference returns the elements of a that are not present in b.
+func Difference[T comparable](a, b []T) []T {
+	set := toSet(b)
+	return Filter(a, func(v T) bool {
+		_, ok := set[v]
+		return !ok
+	})
+}
+
+// Intersect returns the unique elements present in both a and b, in the order of a.
+func Intersect[T comparable](a, b []T) []T {
+	set := toSet(b)
+	return Unique(Filter(a, func(v T) bool {
+		_, ok := set[v]
+		return ok
+	}))
+}
+
+// SortBy returns a sorted copy of s ordered by key ascending. The sort is stable.
+func SortBy[T any, K cmp.Ordered](s []T, key func(T) K) []T {
+	out := slices.Clone(s)
+	slices.SortStableFunc(out, func(a, b T) int {
+		return cmp.Compare(key(a), key(b))
+	})
+	return out
+}
+
+// SortByDesc returns a sorted copy of s ordered by key descending. The sort is stable.
+func SortByDesc[T any, K cmp.Ordered](s []T, key func(T) K) []T {
+	out := slices.Clone(s)
+	slices.SortStableFunc(out, func(a, b T) int {
+		return cmp.Compare(key(b), key(a))
+	})
+	return out
+}
+
+// Find returns the first element matching pred.
+func Find[T any](s []T, pred func(T) bool) (T, bool) {
+	for _, v := range s {
+		if pred(v) {
+			return v, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// Any reports whether at least one element matches pred.
+func Any[T any](s []T, pred func(T) bool) bool {
+	return slices.ContainsFunc(s, pred)
+}
+
+// All reports whether every element matches pred. It returns true for an empty slice.
+func All[T any](s []T, pred func(T) bool) bool {
+	for _, v := range s {
+		if !pred(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func toSet[T comparable](s []T) map[T]struct{} {
+	set := make(map[T]struct{}, len(s))
+	for _, v := range s {
+		set[v] = struct{}{}
+	}
+	return set
+}
@@ -0,0 +1,99 @@
+package utils
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMap(t *testing.T) {
+	assert.Equal(t, []string{"1", "2", "3"}, Map([]int{1, 2, 3}, strconv.Itoa))
+	assert.Nil(t, Map[int, string](nil, strconv.Itoa))
+	assert.Equal(t, []string{}, Map([]int{}, strconv.Itoa))
+}
+
+func TestFilter(t *testing.T) {
+	even := func(n int) bool { return n%2 == 0 }
+	assert.Equal(t, []int{2, 4}, Filter([]int{1, 2, 3, 4}, even))
+	assert.Empty(t, Filter([]int{1, 3}, even))
+	assert.Nil(t, Filter(nil, even))
+}
+
+func TestReduce(t *testing.T) {
+	sum := Reduce([]int{1, 2, 3, 4}, 0, func(acc, v int) int { return acc + v })
+	assert.Equal(t, 10, sum)
+
+	joined := Reduce([]int{1, 2}, "", func(acc string, v int) string { return acc + strconv.Itoa(v) })
+	assert.Equal(t, "12", joined)
+
+	assert.Equal(t, 5, Reduce(nil, 5, func(acc, v int) int { return acc + v }))
+}
+
+func TestUnique(t *testing.T) {
+	assert.Equal(t, []int{3, 1, 2}, Unique([]int{3, 1, 3, 2, 1}))
+	assert.Equal(t, []User{{"a", 1}, {"b", 2}},
+		UniqueBy([]User{{"a", 1}, {"b", 2}, {"a", 3}}, func(u User) string { return u.Name }))
+}
+
+func TestChunk(t *testing.T) {
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}, {5}}, Chunk([]int{1, 2, 3, 4, 5}, 2))
+	assert.Equal(t, [][]int{{1, 2, 3}}, Chunk([]int{1, 2, 3}, 10))
+	assert.Nil(t, Chunk([]int{1, 2}, 0))
+	assert.Nil(t, Chunk([]int{}, 2))
+
+	// chunks must not share capacity with the next chunk
+	chunks := Chunk([]int{1, 2, 3, 4}, 2)
+	chunks[0] = append(chunks[0], 99)
+	assert.Equal(t, []int{3, 4}, chunks[1])
+}
+
+func TestGroupByAndKeyBy(t *testing.T) {
+	users := []User{{"a", 20}, {"b", 30}, {"c", 20}}
+
+	groups := GroupBy(users, func(u User) int { return u.Age })
+	assert.Equal(t, []User{{"a", 20}, {"c", 20}}, groups[20])
+	assert.Equal(t, []User{{"b", 30}}, groups[30])
+
+	byName := KeyBy(users, func(u User) string { return u.Name })
+	assert.Len(t, byName, 3)
+	assert.Equal(t, 30, byName["b"].Age)
+}
+
+func TestPartition(t *testing.T) {
+	matched, rest := Partition([]int{1, 2, 3, 4, 5}, func(n int) bool { return n > 2 })
+	assert.Equal(t, []int{3, 4, 5}, matched)
+	assert.Equal(t, []int{1, 2}, rest)
+}
+
+func TestDifferenceAndIntersect(t *testing.T) {
+	assert.Equal(t, []int{1, 3}, Difference([]int{1, 2, 3, 4}, []int{2, 4, 6}))
+	assert.Equal(t, []int{2, 4}, Intersect([]int{1, 2, 2, 3, 4}, []int{4, 2, 6}))
+	assert.Empty(t, Intersect([]int{1}, nil))
+}
+
+func TestSortBy(t *testing.T) {
+	users := []User{{"b", 30}, {"a", 20}, {"c", 20}}
+
+	asc := SortBy(users, func(u User) int { return u.Age })
+	assert.Equal(t, []User{{"a", 20}, {"c", 20}, {"b", 30}}, asc)
+
+	desc := SortByDesc(users, func(u User) string { return u.Name })
+	assert.Equal(t, []User{{"c", 20}, {"b", 30}, {"a", 20}}, desc)
+
+	// original is untouched
+	assert.Equal(t, "b", users[0].Name)
+}
+
+func TestFindAnyAll(t *testing.T) {
+	v, ok := Find([]int{1, 2, 3}, func(n int) bool { return n > 1 })
+	assert.True(t, ok)
+	assert.Equal(t, 2, v)
+
+	_, ok = Find([]int{1}, func(n int) bool { return n > 1 })
+	assert.False(t, ok)
+
+	assert.True(t, Any([]int{1, 2}, func(n int) bool { return n == 2 }))
+	assert.False(t, All([]int{1, 2}, func(n int) bool { return n == 2 }))
+	assert.True(t, All([]int{}, func(n int) bool { return false }))
+}
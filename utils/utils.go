@@ -15,6 +15,7 @@ import (
 
 	"github.com/BevisDev/godev/consts"
 	"github.com/BevisDev/godev/types"
+	"github.com/BevisDev/godev/utils/ctxmeta"
 	"github.com/BevisDev/godev/utils/jsonx"
 	"github.com/BevisDev/godev/utils/random"
 	"github.com/BevisDev/godev/utils/str"
@@ -24,33 +25,26 @@ import (
 // NewCtxWithRequest creates a fresh context and keeps request ID from r.
 func NewCtxWithRequest(r context.Context) context.Context {
 	var rid = GetRID(r)
-	ctx := NewCtx()
-	return SetValueCtx(ctx, consts.RID, rid)
+	return ctxmeta.WithRID(context.Background(), rid)
 }
 
 // NewCtx creates a background context with a generated request ID.
 func NewCtx() context.Context {
-	ctx := context.Background()
-	return context.WithValue(ctx, consts.RID, random.NewUUID())
+	return ctxmeta.WithRID(context.Background(), random.NewUUID())
 }
 
 // SetValueCtx stores key/value into context.
+// Prefer the typed setters of utils/ctxmeta for request metadata.
 func SetValueCtx(ctx context.Context, key string, value interface{}) context.Context {
 	return context.WithValue(ctx, key, value)
 }
 
 // GetRID returns request ID from context, or generates one when missing.
 func GetRID(ctx context.Context) string {
-	if ctx == nil {
-		return random.NewUUID()
+	if rid := ctxmeta.RID(ctx); rid != "" {
+		return rid
 	}
-
-	rid, ok := ctx.Value(consts.RID).(string)
-	if !ok {
-		rid = random.NewUUID()
-	}
-
-	return rid
+	return random.NewUUID()
 }
 
 // NewCtxTimeout wraps ctx with timeout.
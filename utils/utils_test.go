@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/BevisDev/godev/consts"
+	"github.com/BevisDev/godev/utils/ctxmeta"
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/exp/constraints"
 )
@@ -19,9 +20,9 @@ type User struct {
 
 func TestNewCtx_ShouldReturnContextWithRID(t *testing.T) {
 	ctx := NewCtx()
-	state := ctx.Value(consts.RID)
+	state := ctxmeta.RID(ctx)
 
-	if state == nil || state == "" {
+	if state == "" {
 		t.Error("Expected state in context")
 	}
 }
@@ -0,0 +1,182 @@
+package validate
+
+import (
+	"strconv"
+	"strings"
+)
+
+// CardBrand is a payment card network detected from the card's BIN (leading digits).
+type CardBrand string
+
+const (
+	CardUnknown    CardBrand = ""
+	CardVisa       CardBrand = "VISA"
+	CardMastercard CardBrand = "MASTERCARD"
+	CardAmex       CardBrand = "AMEX"
+	CardJCB        CardBrand = "JCB"
+	CardUnionPay   CardBrand = "UNIONPAY"
+	CardDiscover   CardBrand = "DISCOVER"
+	CardNapas      CardBrand = "NAPAS"
+)
+
+// napasBIN is the BIN prefix shared by Vietnamese domestic (NAPAS) cards.
+const napasBIN = "9704"
+
+// ibanLengths holds the IBAN length per country (ISO 13616 registry).
+var ibanLengths = map[string]int{
+	"AD": 24, "AE": 23, "AL": 28, "AT": 20, "AZ": 28, "BA": 20, "BE": 16, "BG": 22,
+	"BH": 22, "BR": 29, "BY": 28, "CH": 21, "CR": 22, "CY": 28, "CZ": 24, "DE": 22,
+	"DK": 18, "DO": 28, "EE": 20, "EG": 29, "ES": 24, "FI": 18, "FO": 18, "FR": 27,
+	"GB": 22, "GE": 22, "GI": 23, "GL": 18, "GR": 27, "GT": 28, "HR": 21, "HU": 28,
+	"IE": 22, "IL": 23, "IQ": 23, "IS": 26, "IT": 27, "JO": 30, "KW": 30, "KZ": 20,
+	"LB": 28, "LC": 32, "LI": 21, "LT": 20, "LU": 20, "LV": 21, "MC": 27, "MD": 24,
+	"ME": 22, "MK": 19, "MR": 27, "MT": 31, "MU": 30, "NL": 18, "NO": 15, "PK": 24,
+	"PL": 28, "PS": 29, "PT": 25, "QA": 29, "RO": 24, "RS": 22, "SA": 24, "SC": 31,
+	"SE": 24, "SI": 19, "SK": 24, "SM": 27, "TL": 23, "TN": 24, "TR": 26, "UA": 29,
+	"VA": 22, "VG": 24, "XK": 20,
+}
+
+// IsLuhn reports whether s is a non-empty digit string with a valid Luhn (mod 10) checksum.
+//
+// Example:
+//
+//	IsLuhn("4111111111111111") // true
+//	IsLuhn("4111111111111112") // false
+func IsLuhn(s string) bool {
+	if !IsDigits(s) {
+		return false
+	}
+
+	sum := 0
+	double := false
+	for i := len(s) - 1; i >= 0; i-- {
+		d := int(s[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// IsCardNumber reports whether s is a plausible payment card number.
+// Spaces and hyphens are ignored. International cards must be 12–19 digits and pass
+// the Luhn check; domestic NAPAS cards (BIN 9704) must be 16 or 19 digits and are not
+// required to pass Luhn.
+//
+// Example:
+//
+//	IsCardNumber("4111 1111 1111 1111") // true
+//	IsCardNumber("9704 0000 0000 0018") // true (NAPAS)
+func IsCardNumber(s string) bool {
+	num := normalizeCardNumber(s)
+	if !IsDigits(num) {
+		return false
+	}
+	if strings.HasPrefix(num, napasBIN) {
+		return IsNapasCard(num)
+	}
+	return len(num) >= 12 && len(num) <= 19 && IsLuhn(num)
+}
+
+// IsNapasCard reports whether s is a Vietnamese domestic NAPAS card number:
+// BIN 9704 followed by digits, 16 or 19 digits in total. Spaces and hyphens are ignored.
+func IsNapasCard(s string) bool {
+	num := normalizeCardNumber(s)
+	return IsDigits(num) &&
+		strings.HasPrefix(num, napasBIN) &&
+		(len(num) == 16 || len(num) == 19)
+}
+
+// DetectCardBrand returns the card network for s based on its BIN,
+// or CardUnknown when no known range matches. It does not validate the checksum.
+//
+// Example:
+//
+//	DetectCardBrand("5500 0000 0000 0004") // CardMastercard
+//	DetectCardBrand("9704 1234 5678 9012") // CardNapas
+func DetectCardBrand(s string) CardBrand {
+	num := normalizeCardNumber(s)
+	if len(num) < 4 || !IsDigits(num) {
+		return CardUnknown
+	}
+
+	p2, _ := strconv.Atoi(num[:2])
+	p3, _ := strconv.Atoi(num[:3])
+	p4, _ := strconv.Atoi(num[:4])
+	p6 := 0
+	if len(num) >= 6 {
+		p6, _ = strconv.Atoi(num[:6])
+	}
+
+	switch {
+	case num[:4] == napasBIN:
+		return CardNapas
+	case num[0] == '4':
+		return CardVisa
+	case p2 >= 51 && p2 <= 55, p4 >= 2221 && p4 <= 2720:
+		return CardMastercard
+	case p2 == 34 || p2 == 37:
+		return CardAmex
+	case p4 >= 3528 && p4 <= 3589:
+		return CardJCB
+	case p4 == 6011, p2 == 65, p3 >= 644 && p3 <= 649, p6 >= 622126 && p6 <= 622925:
+		return CardDiscover
+	case p2 == 62:
+		return CardUnionPay
+	default:
+		return CardUnknown
+	}
+}
+
+// IsIBAN reports whether s is a valid International Bank Account Number:
+// known country code, matching country length and a valid mod-97 check.
+// Spaces are ignored and letters are case-insensitive.
+//
+// Example:
+//
+//	IsIBAN("GB82 WEST 1234 5698 7654 32") // true
+func IsIBAN(s string) bool {
+	iban := strings.ToUpper(strings.ReplaceAll(s, " ", ""))
+	if len(iban) < 4 {
+		return false
+	}
+	if n, ok := ibanLengths[iban[:2]]; !ok || n != len(iban) {
+		return false
+	}
+	if iban[2] < '0' || iban[2] > '9' || iban[3] < '0' || iban[3] > '9' {
+		return false
+	}
+
+	// move the first four characters to the end and convert letters to numbers (A=10 ... Z=35),
+	// computing the remainder incrementally to avoid big integers
+	rearranged := iban[4:] + iban[:4]
+	rem := 0
+	for i := 0; i < len(rearranged); i++ {
+		c := rearranged[i]
+		switch {
+		case c >= '0' && c <= '9':
+			rem = (rem*10 + int(c-'0')) % 97
+		case c >= 'A' && c <= 'Z':
+			rem = (rem*100 + int(c-'A') + 10) % 97
+		default:
+			return false
+		}
+	}
+	return rem == 1
+}
+
+// IsVNBankAccount reports whether s looks like a Vietnamese bank account number:
+// 6–19 digits. Banks use different lengths, so this is a format check only;
+// use a NAPAS account inquiry to confirm the account exists.
+func IsVNBankAccount(s string) bool {
+	return IsDigits(s) && len(s) >= 6 && len(s) <= 19
+}
+
+func normalizeCardNumber(s string) string {
+	return strings.NewReplacer(" ", "", "-", "").Replace(s)
+}
@@ -0,0 +1,108 @@
+package validate
+
+import "testing"
+
+func TestIsLuhn(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"4111111111111111", true},
+		{"79927398713", true},
+		{"4111111111111112", false},
+		{"0", true},
+		{"", false},
+		{"4111 1111 1111 1111", false},
+		{"abc", false},
+	}
+	for _, tt := range tests {
+		if got := IsLuhn(tt.input); got != tt.expected {
+			t.Errorf("IsLuhn(%q) = %v, want %v", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestIsCardNumber(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"4111 1111 1111 1111", true},
+		{"5500-0000-0000-0004", true},
+		{"378282246310005", true},
+		{"9704000000000018", true},
+		{"9704000000000000018", true},
+		{"97040000000000001", false}, // NAPAS must be 16 or 19 digits
+		{"4111111111111112", false},
+		{"41111111111", false}, // too short
+		{"4111-1111-1111-111x", false},
+	}
+	for _, tt := range tests {
+		if got := IsCardNumber(tt.input); got != tt.expected {
+			t.Errorf("IsCardNumber(%q) = %v, want %v", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestDetectCardBrand(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected CardBrand
+	}{
+		{"4111111111111111", CardVisa},
+		{"5500000000000004", CardMastercard},
+		{"2223000048400011", CardMastercard},
+		{"378282246310005", CardAmex},
+		{"3530111333300000", CardJCB},
+		{"6011111111111117", CardDiscover},
+		{"6212345678901232", CardUnionPay},
+		{"9704 1234 5678 9012", CardNapas},
+		{"1234567890123", CardUnknown},
+		{"41", CardUnknown},
+	}
+	for _, tt := range tests {
+		if got := DetectCardBrand(tt.input); got != tt.expected {
+			t.Errorf("DetectCardBrand(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestIsIBAN(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"GB82 WEST 1234 5698 7654 32", true},
+		{"gb82west12345698765432", true},
+		{"DE89370400440532013000", true},
+		{"FR1420041010050500013M02606", true},
+		{"GB82 WEST 1234 5698 7654 33", false}, // bad checksum
+		{"DE8937040044053201300", false},       // wrong length
+		{"VN12345678901234", false},            // unsupported country
+		{"GBXX WEST 1234 5698 7654 32", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := IsIBAN(tt.input); got != tt.expected {
+			t.Errorf("IsIBAN(%q) = %v, want %v", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestIsVNBankAccount(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"0071000123456", true},
+		{"123456", true},
+		{"12345", false},
+		{"12345678901234567890", false},
+		{"0071-000123456", false},
+	}
+	for _, tt := range tests {
+		if got := IsVNBankAccount(tt.input); got != tt.expected {
+			t.Errorf("IsVNBankAccount(%q) = %v, want %v", tt.input, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,305 @@
+package xmlx
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// SOAP envelope namespaces.
+const (
+	SOAP11 = "http://schemas.xmlsoap.org/soap/envelope/"
+	SOAP12 = "http://www.w3.org/2003/05/soap-envelope"
+)
+
+// ErrNoBody is returned when a response is not a SOAP envelope with a Body.
+var ErrNoBody = errors.New("[xmlx] soap envelope has no body")
+
+// Envelope builds a SOAP request:
+//
+//	raw, err := xmlx.NewEnvelope(GetRate{From: "USD"}).
+//		Namespace("tem", "http://tempuri.org/").
+//		Header(security).
+//		Marshal()
+type Envelope struct {
+	// Version is the envelope namespace, SOAP11 (default) or SOAP12.
+	Version string
+
+	// Namespaces are declared on the envelope as xmlns:prefix, so the header
+	// and body can use prefixed names (e.g. `xml:"tem:GetRate"`).
+	Namespaces map[string]string
+
+	// Headers are marshalled into soap:Header (e.g. a WS-Security header).
+	Headers []any
+
+	// Body is marshalled into soap:Body; []byte and string are written as-is.
+	Body any
+}
+
+// NewEnvelope creates a SOAP 1.1 envelope around body.
+func NewEnvelope(body any) *Envelope {
+	return &Envelope{Version: SOAP11, Body: body}
+}
+
+// Namespace declares xmlns:prefix="uri" on the envelope.
+func (e *Envelope) Namespace(prefix, uri string) *Envelope {
+	if e.Namespaces == nil {
+		e.Namespaces = make(map[string]string)
+	}
+	e.Namespaces[prefix] = uri
+	return e
+}
+
+// Header adds an entry to soap:Header.
+func (e *Envelope) Header(h any) *Envelope {
+	e.Headers = append(e.Headers, h)
+	return e
+}
+
+// SOAP12 switches the envelope to SOAP 1.2.
+func (e *Envelope) SOAP12() *Envelope {
+	e.Version = SOAP12
+	return e
+}
+
+// IsSOAP12 reports whether the envelope uses SOAP 1.2.
+func (e *Envelope) IsSOAP12() bool {
+	return e.Version == SOAP12
+}
+
+// Marshal encodes the envelope, including the XML declaration.
+func (e *Envelope) Marshal() ([]byte, error) {
+	version := e.Version
+	if version == "" {
+		version = SOAP11
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<soap:Envelope`)
+	ns := map[string]string{"soap": version}
+	for p, uri := range e.Namespaces {
+		if p != "soap" {
+			ns[p] = uri
+		}
+	}
+	writeNamespaces(&buf, ns)
+	buf.WriteByte('>')
+
+	if len(e.Headers) > 0 {
+		buf.WriteString(`<soap:Header>`)
+		for _, h := range e.Headers {
+			if err := writeContent(&buf, h); err != nil {
+				return nil, fmt.Errorf("[xmlx] soap header: %w", err)
+			}
+		}
+		buf.WriteString(`</soap:Header>`)
+	}
+
+	buf.WriteString(`<soap:Body>`)
+	if err := writeContent(&buf, e.Body); err != nil {
+		return nil, fmt.Errorf("[xmlx] soap body: %w", err)
+	}
+	buf.WriteString(`</soap:Body></soap:Envelope>`)
+	return buf.Bytes(), nil
+}
+
+func writeContent(buf *bytes.Buffer, v any) error {
+	switch c := v.(type) {
+	case nil:
+		return nil
+	case []byte:
+		buf.Write(c)
+		return nil
+	case string:
+		buf.WriteString(c)
+		return nil
+	default:
+		return xml.NewEncoder(buf).Encode(v)
+	}
+}
+
+// Fault is a SOAP 1.1 or 1.2 fault. It is returned as the error of
+// DecodeEnvelope, so callers can match it with AsFault.
+type Fault struct {
+	// Code is faultcode (1.1) or Code/Value (1.2), e.g. "soap:Server".
+	Code string
+
+	// Subcode is Code/Subcode/Value (1.2 only).
+	Subcode string
+
+	// Message is faultstring (1.1) or the first Reason/Text (1.2).
+	Message string
+
+	// Actor is faultactor (1.1) or Role (1.2).
+	Actor string
+
+	// Detail is the raw inner XML of detail (1.1) or Detail (1.2).
+	Detail []byte
+}
+
+// Error returns the fault code and message.
+func (f *Fault) Error() string {
+	code := f.Code
+	if f.Subcode != "" {
+		code += "/" + f.Subcode
+	}
+	return fmt.Sprintf("soap fault %s: %s", code, f.Message)
+}
+
+// DecodeDetail unmarshals the first element of the fault detail into v.
+func (f *Fault) DecodeDetail(v any) error {
+	if len(bytes.TrimSpace(f.Detail)) == 0 {
+		return fmt.Errorf("[xmlx] soap fault has no detail")
+	}
+	return xml.Unmarshal(f.Detail, v)
+}
+
+// AsFault attempts to cast a generic error to *Fault using errors.As.
+func AsFault(err error) (*Fault, bool) {
+	var f *Fault
+	ok := errors.As(err, &f)
+	return f, ok
+}
+
+type innerXML struct {
+	Inner []byte `xml:",innerxml"`
+}
+
+// faultXML matches both fault layouts; element names are case-sensitive, so
+// the 1.1 (lower case) and 1.2 (capitalised) children do not collide.
+type faultXML struct {
+	// SOAP 1.1
+	FaultCode   string   `xml:"faultcode"`
+	FaultString string   `xml:"faultstring"`
+	FaultActor  string   `xml:"faultactor"`
+	FaultDetail innerXML `xml:"detail"`
+
+	// SOAP 1.2
+	Code struct {
+		Value   string `xml:"Value"`
+		Subcode struct {
+			Value string `xml:"Value"`
+		} `xml:"Subcode"`
+	} `xml:"Code"`
+	Reason struct {
+		Text []string `xml:"Text"`
+	} `xml:"Reason"`
+	Role   string   `xml:"Role"`
+	Detail innerXML `xml:"Detail"`
+}
+
+func (f *faultXML) fault() *Fault {
+	out := &Fault{
+		Code:    strings.TrimSpace(f.FaultCode),
+		Message: strings.TrimSpace(f.FaultString),
+		Actor:   strings.TrimSpace(f.FaultActor),
+		Detail:  bytes.TrimSpace(f.FaultDetail.Inner),
+	}
+	if out.Code == "" {
+		out.Code = strings.TrimSpace(f.Code.Value)
+		out.Subcode = strings.TrimSpace(f.Code.Subcode.Value)
+	}
+	if out.Message == "" && len(f.Reason.Text) > 0 {
+		out.Message = strings.TrimSpace(f.Reason.Text[0])
+	}
+	if out.Actor == "" {
+		out.Actor = strings.TrimSpace(f.Role)
+	}
+	if len(out.Detail) == 0 {
+		out.Detail = bytes.TrimSpace(f.Detail.Inner)
+	}
+	return out
+}
+
+// DecodeEnvelope decodes the first element of a SOAP 1.1 or 1.2 Body into T.
+// A soap:Fault is returned as a *Fault error. When T is string or []byte it
+// receives the raw inner XML of the Body instead. An empty Body returns the
+// zero T.
+//
+// Decoding runs over the whole envelope, so prefixes declared on the
+// envelope resolve inside the body.
+func DecodeEnvelope[T any](raw []byte) (T, error) {
+	var t T
+	_, wantRaw := any(t).(string)
+	if _, ok := any(t).([]byte); ok {
+		wantRaw = true
+	}
+
+	d := xml.NewDecoder(bytes.NewReader(raw))
+	inBody := false
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			return t, ErrNoBody
+		}
+		if err != nil {
+			return t, err
+		}
+
+		switch el := tok.(type) {
+		case xml.StartElement:
+			if !inBody {
+				switch el.Name.Local {
+				case "Envelope":
+				case "Header":
+					if err := d.Skip(); err != nil {
+						return t, err
+					}
+				case "Body":
+					if wantRaw {
+						return decodeRawBody[T](d, el)
+					}
+					inBody = true
+				default:
+					return t, ErrNoBody
+				}
+				continue
+			}
+
+			if el.Name.Local == "Fault" {
+				var f faultXML
+				if err := d.DecodeElement(&f, &el); err != nil {
+					return t, err
+				}
+				return t, f.fault()
+			}
+			err := d.DecodeElement(&t, &el)
+			return t, err
+
+		case xml.EndElement:
+			if inBody {
+				// empty body
+				return t, nil
+			}
+		}
+	}
+}
+
+func decodeRawBody[T any](d *xml.Decoder, start xml.StartElement) (T, error) {
+	var (
+		t    T
+		body struct {
+			Fault *faultXML `xml:"Fault"`
+			Inner []byte    `xml:",innerxml"`
+		}
+	)
+	if err := d.DecodeElement(&body, &start); err != nil {
+		return t, err
+	}
+	if body.Fault != nil {
+		return t, body.Fault.fault()
+	}
+
+	inner := bytes.TrimSpace(body.Inner)
+	switch p := any(&t).(type) {
+	case *string:
+		*p = string(inner)
+	case *[]byte:
+		*p = inner
+	}
+	return t, nil
+}
@@ -0,0 +1,106 @@
+package xmlx
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"sort"
+)
+
+// ToXMLBytes marshals a value into XML bytes, without the XML declaration.
+func ToXMLBytes(v any) ([]byte, error) {
+	return xml.Marshal(v)
+}
+
+// ToXML marshals a value into an XML string ("" on error).
+func ToXML(v any) string {
+	b, err := ToXMLBytes(v)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// FromXMLBytes unmarshals XML bytes into type T.
+//
+// Struct tags with a namespace (`xml:"urn:bank GetRate"`) only match elements
+// of that namespace; tags without one match the local name in any namespace.
+func FromXMLBytes[T any](raw []byte) (T, error) {
+	var t T
+	err := xml.Unmarshal(raw, &t)
+	return t, err
+}
+
+// FromXML unmarshals an XML string into type T.
+func FromXML[T any](s string) (T, error) {
+	return FromXMLBytes[T]([]byte(s))
+}
+
+// WithDeclaration prefixes raw with the standard XML declaration.
+func WithDeclaration(raw []byte) []byte {
+	out := make([]byte, 0, len(xml.Header)+len(raw))
+	out = append(out, xml.Header...)
+	return append(out, raw...)
+}
+
+// MarshalNS marshals v and declares the namespaces on its root element as
+// xmlns:prefix="uri" (the empty prefix declares the default namespace).
+// This allows prefixed element names in struct tags:
+//
+//	type GetRate struct {
+//		XMLName xml.Name `xml:"tem:GetRate"`
+//		From    string   `xml:"tem:from"`
+//	}
+//
+//	xmlx.MarshalNS(GetRate{From: "USD"}, map[string]string{"tem": "http://tempuri.org/"})
+//	// <tem:GetRate xmlns:tem="http://tempuri.org/"><tem:from>USD</tem:from></tem:GetRate>
+func MarshalNS(v any, namespaces map[string]string) ([]byte, error) {
+	raw, err := ToXMLBytes(v)
+	if err != nil {
+		return nil, err
+	}
+	if len(namespaces) == 0 {
+		return raw, nil
+	}
+
+	// the root start tag ends its name at the first space, '/' or '>'
+	end := bytes.IndexAny(raw, " />")
+	if len(raw) == 0 || raw[0] != '<' || end < 0 {
+		return nil, fmt.Errorf("[xmlx] no root element to declare namespaces on")
+	}
+
+	var buf bytes.Buffer
+	buf.Grow(len(raw) + 64*len(namespaces))
+	buf.Write(raw[:end])
+	writeNamespaces(&buf, namespaces)
+	buf.Write(raw[end:])
+	return buf.Bytes(), nil
+}
+
+// Pretty returns an indented XML string for a value.
+func Pretty(v any) string {
+	b, err := xml.MarshalIndent(v, "", "\t")
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// writeNamespaces writes the xmlns attributes sorted by prefix.
+func writeNamespaces(buf *bytes.Buffer, namespaces map[string]string) {
+	prefixes := make([]string, 0, len(namespaces))
+	for p := range namespaces {
+		prefixes = append(prefixes, p)
+	}
+	sort.Strings(prefixes)
+
+	for _, p := range prefixes {
+		if p == "" {
+			buf.WriteString(` xmlns="`)
+		} else {
+			buf.WriteString(` xmlns:` + p + `="`)
+		}
+		_ = xml.EscapeText(buf, []byte(namespaces[p]))
+		buf.WriteByte('"')
+	}
+}
@@ -0,0 +1,152 @@
+package xmlx
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type getRate struct {
+	XMLName xml.Name `xml:"tem:GetRate"`
+	From    string   `xml:"tem:from"`
+	To      string   `xml:"tem:to"`
+}
+
+type rateResult struct {
+	XMLName xml.Name `xml:"http://tempuri.org/ GetRateResponse"`
+	Rate    float64  `xml:"GetRateResult"`
+}
+
+func TestToXMLAndBack(t *testing.T) {
+	type item struct {
+		XMLName xml.Name `xml:"item"`
+		ID      int      `xml:"id,attr"`
+		Name    string   `xml:"name"`
+	}
+
+	s := ToXML(item{ID: 1, Name: "A & B"})
+	assert.Equal(t, `<item id="1"><name>A &amp; B</name></item>`, s)
+
+	got, err := FromXML[item](s)
+	require.NoError(t, err)
+	assert.Equal(t, 1, got.ID)
+	assert.Equal(t, "A & B", got.Name)
+}
+
+func TestMarshalNS(t *testing.T) {
+	raw, err := MarshalNS(getRate{From: "USD", To: "VND"}, map[string]string{
+		"tem": "http://tempuri.org/",
+		"":    "urn:default",
+	})
+	require.NoError(t, err)
+	assert.Equal(t,
+		`<tem:GetRate xmlns="urn:default" xmlns:tem="http://tempuri.org/"><tem:from>USD</tem:from><tem:to>VND</tem:to></tem:GetRate>`,
+		string(raw))
+
+	raw, err = MarshalNS(struct {
+		XMLName xml.Name `xml:"empty"`
+	}{}, map[string]string{"a": "urn:a"})
+	require.NoError(t, err)
+	assert.Equal(t, `<empty xmlns:a="urn:a"></empty>`, string(raw))
+}
+
+func TestEnvelopeMarshal(t *testing.T) {
+	type security struct {
+		XMLName  xml.Name `xml:"wsse:Security"`
+		Username string   `xml:"wsse:UsernameToken>wsse:Username"`
+	}
+
+	raw, err := NewEnvelope(getRate{From: "USD", To: "VND"}).
+		Namespace("tem", "http://tempuri.org/").
+		Namespace("wsse", "urn:wsse").
+		Header(security{Username: "merchant"}).
+		Marshal()
+	require.NoError(t, err)
+
+	assert.Equal(t, xml.Header+
+		`<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/" xmlns:tem="http://tempuri.org/" xmlns:wsse="urn:wsse">`+
+		`<soap:Header><wsse:Security><wsse:UsernameToken><wsse:Username>merchant</wsse:Username></wsse:UsernameToken></wsse:Security></soap:Header>`+
+		`<soap:Body><tem:GetRate><tem:from>USD</tem:from><tem:to>VND</tem:to></tem:GetRate></soap:Body></soap:Envelope>`,
+		string(raw))
+
+	raw, err = NewEnvelope("<Ping/>").SOAP12().Marshal()
+	require.NoError(t, err)
+	assert.Contains(t, string(raw), `xmlns:soap="`+SOAP12+`"`)
+	assert.Contains(t, string(raw), `<soap:Body><Ping/></soap:Body>`)
+}
+
+func TestDecodeEnvelope(t *testing.T) {
+	raw := []byte(`<?xml version="1.0" encoding="utf-8"?>
+<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/" xmlns:t="http://tempuri.org/">
+  <s:Header><Session>abc</Session></s:Header>
+  <s:Body>
+    <t:GetRateResponse><t:GetRateResult>25400.5</t:GetRateResult></t:GetRateResponse>
+  </s:Body>
+</s:Envelope>`)
+
+	got, err := DecodeEnvelope[rateResult](raw)
+	require.NoError(t, err)
+	assert.Equal(t, 25400.5, got.Rate)
+
+	inner, err := DecodeEnvelope[string](raw)
+	require.NoError(t, err)
+	assert.Equal(t, `<t:GetRateResponse><t:GetRateResult>25400.5</t:GetRateResult></t:GetRateResponse>`, inner)
+
+	// the namespace on the struct tag must match
+	_, err = DecodeEnvelope[rateResult]([]byte(`<Envelope><Body><GetRateResponse xmlns="urn:other"/></Body></Envelope>`))
+	assert.Error(t, err)
+
+	empty, err := DecodeEnvelope[rateResult]([]byte(`<Envelope><Body></Body></Envelope>`))
+	require.NoError(t, err)
+	assert.Zero(t, empty.Rate)
+
+	_, err = DecodeEnvelope[rateResult]([]byte(`<html><body>502</body></html>`))
+	assert.ErrorIs(t, err, ErrNoBody)
+}
+
+func TestDecodeEnvelopeFault11(t *testing.T) {
+	raw := []byte(`<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">
+<soap:Body><soap:Fault>
+  <faultcode>soap:Client</faultcode>
+  <faultstring>Invalid account</faultstring>
+  <detail><BankError><Code>E102</Code></BankError></detail>
+</soap:Fault></soap:Body></soap:Envelope>`)
+
+	for _, decode := range []func([]byte) error{
+		func(b []byte) error { _, err := DecodeEnvelope[rateResult](b); return err },
+		func(b []byte) error { _, err := DecodeEnvelope[string](b); return err },
+	} {
+		err := decode(raw)
+		f, ok := AsFault(err)
+		require.True(t, ok, err)
+		assert.Equal(t, "soap:Client", f.Code)
+		assert.Equal(t, "Invalid account", f.Message)
+		assert.Equal(t, "soap fault soap:Client: Invalid account", f.Error())
+
+		var detail struct {
+			Code string `xml:"Code"`
+		}
+		require.NoError(t, f.DecodeDetail(&detail))
+		assert.Equal(t, "E102", detail.Code)
+	}
+}
+
+func TestDecodeEnvelopeFault12(t *testing.T) {
+	raw := []byte(`<env:Envelope xmlns:env="http://www.w3.org/2003/05/soap-envelope">
+<env:Body><env:Fault>
+  <env:Code><env:Value>env:Sender</env:Value><env:Subcode><env:Value>m:Timeout</env:Value></env:Subcode></env:Code>
+  <env:Reason><env:Text xml:lang="en">Upstream timeout</env:Text></env:Reason>
+  <env:Role>urn:gateway</env:Role>
+</env:Fault></env:Body></env:Envelope>`)
+
+	_, err := DecodeEnvelope[rateResult](raw)
+	f, ok := AsFault(err)
+	require.True(t, ok, err)
+	assert.Equal(t, "env:Sender", f.Code)
+	assert.Equal(t, "m:Timeout", f.Subcode)
+	assert.Equal(t, "Upstream timeout", f.Message)
+	assert.Equal(t, "urn:gateway", f.Actor)
+	assert.Error(t, f.DecodeDetail(&struct{}{}))
+}